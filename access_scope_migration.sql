@@ -0,0 +1,5 @@
+-- Access Scope Migration
+-- Limits non-admin users to the domains of some projects and tags. An empty scope, the
+-- default, grants every domain.
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS access_scope JSONB NOT NULL DEFAULT '{"project_ids": [], "tags": []}';
@@ -0,0 +1,7 @@
+-- Calendar Feed Token Migration
+-- Adds a per-user read-only token for the iCalendar expiry feed.
+-- Only a SHA-256 hash of the token is stored.
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS calendar_token_hash VARCHAR(64);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_calendar_token_hash ON users(calendar_token_hash);
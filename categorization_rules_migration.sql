@@ -0,0 +1,19 @@
+-- Categorization Rules Migration
+-- Rules that assign a category and tags to domains matching a name pattern, provider or DNS
+-- record type. They run in priority order (lowest first) on import, on sync and on demand.
+
+CREATE TABLE IF NOT EXISTS categorization_rules (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(100) NOT NULL,
+    priority INTEGER NOT NULL DEFAULT 0,
+    enabled BOOLEAN NOT NULL DEFAULT true,
+    name_pattern VARCHAR(255) NOT NULL DEFAULT '',
+    provider VARCHAR(50) NOT NULL DEFAULT '',
+    has_record_type VARCHAR(10) NOT NULL DEFAULT '',
+    category_id UUID REFERENCES categories(id) ON DELETE SET NULL,
+    tags JSONB NOT NULL DEFAULT '[]',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_categorization_rules_priority ON categorization_rules(priority, created_at);
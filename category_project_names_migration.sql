@@ -0,0 +1,6 @@
+-- Category and Project Names Migration
+-- Category and project names are unique regardless of letter case, so "Marketing" and
+-- "marketing" can't both exist. Rename any such duplicates before running this.
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_categories_name_lower ON categories (LOWER(name));
+CREATE UNIQUE INDEX IF NOT EXISTS idx_projects_name_lower ON projects (LOWER(name));
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rusiqe/domainvault/internal/types"
+)
+
+// DevFixture is a portfolio to seed the development server with, read from the JSON file
+// named by DEV_SEED_FILE. Entries use the same fields as the API's JSON.
+type DevFixture struct {
+	Categories   []types.Category            `json:"categories"`
+	Projects     []types.Project             `json:"projects"`
+	Credentials  []types.ProviderCredentials `json:"credentials"`
+	Domains      []types.Domain              `json:"domains"`
+	MockProvider bool                        `json:"mock_provider"` // Also sync the mock provider's generated domains
+}
+
+// LoadFixture reads and validates a fixture file. Unknown fields are rejected so a typo
+// doesn't silently drop part of a scenario.
+func LoadFixture(path string) (*DevFixture, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read fixture: %w", err)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	var fixture DevFixture
+	if err := decoder.Decode(&fixture); err != nil {
+		return nil, fmt.Errorf("failed to parse fixture %s: %w", path, err)
+	}
+	if err := fixture.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid fixture %s: %w", path, err)
+	}
+	return &fixture, nil
+}
+
+// Validate checks each entry the way the API would, fills in missing IDs and timestamps, and
+// checks that domains only reference categories, projects and credentials in the fixture
+func (f *DevFixture) Validate() error {
+	now := time.Now()
+	categories := make(map[string]bool)
+	for i := range f.Categories {
+		category := &f.Categories[i]
+		if err := category.Validate(); err != nil {
+			return fmt.Errorf("categories[%d]: %w", i, err)
+		}
+		if err := fillIdentity(&category.ID, &category.CreatedAt, &category.UpdatedAt, now, categories); err != nil {
+			return fmt.Errorf("categories[%d]: %w", i, err)
+		}
+	}
+
+	projects := make(map[string]bool)
+	for i := range f.Projects {
+		project := &f.Projects[i]
+		if err := project.Validate(); err != nil {
+			return fmt.Errorf("projects[%d]: %w", i, err)
+		}
+		if err := fillIdentity(&project.ID, &project.CreatedAt, &project.UpdatedAt, now, projects); err != nil {
+			return fmt.Errorf("projects[%d]: %w", i, err)
+		}
+	}
+
+	credentials := make(map[string]bool)
+	for i := range f.Credentials {
+		creds := &f.Credentials[i]
+		if err := creds.Validate(); err != nil {
+			return fmt.Errorf("credentials[%d]: %w", i, err)
+		}
+		if err := fillIdentity(&creds.ID, &creds.CreatedAt, &creds.UpdatedAt, now, credentials); err != nil {
+			return fmt.Errorf("credentials[%d]: %w", i, err)
+		}
+	}
+
+	names := make(map[string]bool)
+	ids := make(map[string]bool)
+	for i := range f.Domains {
+		domain := &f.Domains[i]
+		if err := domain.Validate(); err != nil {
+			return fmt.Errorf("domains[%d]: %w", i, err)
+		}
+		if names[domain.Name] {
+			return fmt.Errorf("domains[%d]: duplicate domain %s", i, domain.Name)
+		}
+		names[domain.Name] = true
+		if domain.ExpiresAt.IsZero() {
+			return fmt.Errorf("domains[%d]: expires_at is required", i)
+		}
+		if domain.CategoryID != nil && !categories[*domain.CategoryID] {
+			return fmt.Errorf("domains[%d]: unknown category_id %q", i, *domain.CategoryID)
+		}
+		if domain.ProjectID != nil && !projects[*domain.ProjectID] {
+			return fmt.Errorf("domains[%d]: unknown project_id %q", i, *domain.ProjectID)
+		}
+		if domain.CredentialID != nil && !credentials[*domain.CredentialID] {
+			return fmt.Errorf("domains[%d]: unknown credential_id %q", i, *domain.CredentialID)
+		}
+		if err := fillIdentity(&domain.ID, &domain.CreatedAt, &domain.UpdatedAt, now, ids); err != nil {
+			return fmt.Errorf("domains[%d]: %w", i, err)
+		}
+		if domain.Status == "" {
+			domain.Status = "active"
+		}
+		domain.Visible = true
+	}
+	return nil
+}
+
+// fillIdentity generates a missing ID and timestamps and checks the ID isn't in seen yet
+func fillIdentity(id *string, createdAt, updatedAt *time.Time, now time.Time, seen map[string]bool) error {
+	if *id == "" {
+		*id = uuid.New().String()
+	}
+	if seen[*id] {
+		return fmt.Errorf("duplicate id %q", *id)
+	}
+	seen[*id] = true
+	if createdAt.IsZero() {
+		*createdAt = now
+	}
+	if updatedAt.IsZero() {
+		*updatedAt = *createdAt
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rusiqe/domainvault/internal/types"
+)
+
+func writeFixture(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "fixture.json")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write fixture: %v", err)
+	}
+	return path
+}
+
+func TestLoadFixture(t *testing.T) {
+	fixture, err := LoadFixture(filepath.Join("fixtures", "example.json"))
+	if err != nil {
+		t.Fatalf("LoadFixture() of the example fixture error: %v", err)
+	}
+	if len(fixture.Domains) != 2 || len(fixture.Categories) != 1 || len(fixture.Projects) != 1 || len(fixture.Credentials) != 1 {
+		t.Errorf("LoadFixture() = %+v, want the example's entries", fixture)
+	}
+	for _, domain := range fixture.Domains {
+		if domain.ID == "" || domain.CreatedAt.IsZero() || domain.Status != "active" || !domain.Visible {
+			t.Errorf("LoadFixture() left %s without defaults: %+v", domain.Name, domain)
+		}
+	}
+
+	repo := NewInMemoryRepo()
+	if err := repo.Seed(fixture); err != nil {
+		t.Fatalf("Seed() error: %v", err)
+	}
+	if domains, _ := repo.GetAll(); len(domains) != 2 {
+		t.Errorf("Seed() stored %d domains, want 2", len(domains))
+	}
+}
+
+func TestLoadFixture_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{
+			name:    "unknown field",
+			content: `{"domains": [], "mock_providers": true}`,
+			wantErr: `unknown field "mock_providers"`,
+		},
+		{
+			name:    "unknown domain field",
+			content: `{"domains": [{"name": "a.com", "provider": "mock", "expires_at": "2030-01-01T00:00:00Z", "expiry": "2030"}]}`,
+			wantErr: `unknown field "expiry"`,
+		},
+		{
+			name:    "missing expiry",
+			content: `{"domains": [{"name": "a.com", "provider": "mock"}]}`,
+			wantErr: "expires_at is required",
+		},
+		{
+			name:    "duplicate domain",
+			content: `{"domains": [{"name": "a.com", "provider": "mock", "expires_at": "2030-01-01T00:00:00Z"}, {"name": "A.com.", "provider": "mock", "expires_at": "2030-01-01T00:00:00Z"}]}`,
+			wantErr: "duplicate domain a.com",
+		},
+		{
+			name:    "unknown category",
+			content: `{"domains": [{"name": "a.com", "provider": "mock", "expires_at": "2030-01-01T00:00:00Z", "category_id": "missing"}]}`,
+			wantErr: `unknown category_id "missing"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadFixture(writeFixture(t, tt.content))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("LoadFixture() error = %v, want one containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDevFixture_ValidateDomains(t *testing.T) {
+	tests := []struct {
+		name    string
+		domain  types.Domain
+		wantErr error
+	}{
+		{name: "missing name", domain: types.Domain{Provider: "mock"}, wantErr: types.ErrInvalidDomainName},
+		{name: "missing provider", domain: types.Domain{Name: "a.com"}, wantErr: types.ErrInvalidProvider},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fixture := DevFixture{Domains: []types.Domain{tt.domain}}
+			if err := fixture.Validate(); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Validate() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"fmt"
 	"log"
+	"os"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -12,409 +13,65 @@ import (
 	"github.com/rusiqe/domainvault/internal/config"
 	"github.com/rusiqe/domainvault/internal/core"
 	"github.com/rusiqe/domainvault/internal/dns"
+	"github.com/rusiqe/domainvault/internal/jobs"
 	"github.com/rusiqe/domainvault/internal/notifications"
 	"github.com/rusiqe/domainvault/internal/providers"
 	"github.com/rusiqe/domainvault/internal/security"
+	"github.com/rusiqe/domainvault/internal/storage"
 	"github.com/rusiqe/domainvault/internal/types"
 	"github.com/rusiqe/domainvault/internal/uptimerobot"
 )
 
-// InMemoryRepo implements DomainRepository for development
+// InMemoryRepo is the development server's storage: the shared in-memory mock repository,
+// seeded with development categories, projects and credentials
 type InMemoryRepo struct {
-	domains []types.Domain
+	*storage.MockRepo
 }
 
+// NewInMemoryRepo creates a repo with the default development categories, projects and
+// credentials; its domains come from syncing the mock provider
 func NewInMemoryRepo() *InMemoryRepo {
-	return &InMemoryRepo{
-		domains: make([]types.Domain, 0),
-	}
-}
-
-func (r *InMemoryRepo) UpsertDomains(domains []types.Domain) error {
-	for _, domain := range domains {
-		// Check if domain exists
-		found := false
-		for i, existing := range r.domains {
-			if existing.Name == domain.Name {
-				r.domains[i] = domain
-				found = true
-				break
-			}
-		}
-		if !found {
-			r.domains = append(r.domains, domain)
-		}
-	}
-	return nil
-}
-
-func (r *InMemoryRepo) GetAll() ([]types.Domain, error) {
-	return r.domains, nil
-}
-
-func (r *InMemoryRepo) GetByID(id string) (*types.Domain, error) {
-	for _, domain := range r.domains {
-		if domain.ID == id {
-			return &domain, nil
-		}
-	}
-	return nil, types.ErrDomainNotFound
-}
-
-func (r *InMemoryRepo) GetByFilter(filter types.DomainFilter) ([]types.Domain, error) {
-	result := make([]types.Domain, 0)
-	
-	for _, domain := range r.domains {
-		// Apply filters
-		if filter.Provider != "" && domain.Provider != filter.Provider {
-			continue
-		}
-		
-		if filter.Search != "" && domain.Name != filter.Search {
-			continue
-		}
-		
-		if filter.ExpiresAfter != nil && domain.ExpiresAt.Before(*filter.ExpiresAfter) {
-			continue
-		}
-		
-		if filter.ExpiresBefore != nil && domain.ExpiresAt.After(*filter.ExpiresBefore) {
-			continue
-		}
-		
-		result = append(result, domain)
-	}
-	
-	// Apply pagination
-	start := filter.Offset
-	if start > len(result) {
-		return []types.Domain{}, nil
-	}
-	
-	end := start + filter.Limit
-	if filter.Limit == 0 || end > len(result) {
-		end = len(result)
-	}
-	
-	return result[start:end], nil
-}
-
-func (r *InMemoryRepo) Delete(id string) error {
-	for i, domain := range r.domains {
-		if domain.ID == id {
-			r.domains = append(r.domains[:i], r.domains[i+1:]...)
-			return nil
-		}
-	}
-	return types.ErrDomainNotFound
-}
-
-func (r *InMemoryRepo) GetExpiring(threshold time.Duration) ([]types.Domain, error) {
-	cutoff := time.Now().Add(threshold)
-	result := make([]types.Domain, 0)
-	
-	for _, domain := range r.domains {
-		if domain.ExpiresAt.Before(cutoff) {
-			result = append(result, domain)
-		}
+	r := &InMemoryRepo{}
+	if err := r.Seed(&DevFixture{
+		Categories: []types.Category{
+			{ID: "1", Name: "Personal", Description: "Personal domains", Color: "#6366f1"},
+			{ID: "2", Name: "Business", Description: "Business domains", Color: "#dc2626"},
+		},
+		Projects: []types.Project{
+			{ID: "1", Name: "Portfolio Sites", Description: "Personal portfolio websites", Color: "#059669"},
+			{ID: "2", Name: "E-commerce", Description: "Online store projects", Color: "#dc2626"},
+		},
+		Credentials: []types.ProviderCredentials{
+			{ID: "1", Provider: "mock", Name: "Mock Provider", Enabled: true},
+		},
+	}); err != nil {
+		log.Fatal("Failed to seed default development data: ", err)
 	}
-	
-	return result, nil
+	return r
 }
 
-func (r *InMemoryRepo) GetSummary() (*types.DomainSummary, error) {
-	summary := &types.DomainSummary{
-		Total:      len(r.domains),
-		ByProvider: make(map[string]int),
-		ExpiringIn: make(map[string]int),
-		LastSync:   time.Now(),
-	}
-	
-	for _, domain := range r.domains {
-		summary.ByProvider[domain.Provider]++
-	}
-	
-	// Count expiring domains
-	now := time.Now()
-	for _, domain := range r.domains {
-		if domain.ExpiresAt.Before(now.AddDate(0, 0, 30)) {
-			summary.ExpiringIn["30_days"]++
-		}
-		if domain.ExpiresAt.Before(now.AddDate(0, 0, 90)) {
-			summary.ExpiringIn["90_days"]++
-		}
-		if domain.ExpiresAt.Before(now.AddDate(1, 0, 0)) {
-			summary.ExpiringIn["365_days"]++
+// Seed replaces the repo's contents with a fixture's
+func (r *InMemoryRepo) Seed(fixture *DevFixture) error {
+	repo := storage.NewEmptyMockRepo()
+	for _, category := range fixture.Categories {
+		if err := repo.CreateCategory(&category); err != nil {
+			return fmt.Errorf("category %s: %w", category.Name, err)
 		}
 	}
-	
-	return summary, nil
-}
-
-func (r *InMemoryRepo) BulkRenew(domainIDs []string) error {
-	return nil // Mock implementation
-}
-
-func (r *InMemoryRepo) Close() error {
-	return nil
-}
-
-func (r *InMemoryRepo) Ping() error {
-	return nil
-}
-
-// Update method for domains
-func (r *InMemoryRepo) Update(domain *types.Domain) error {
-	for i, existing := range r.domains {
-		if existing.ID == domain.ID {
-			r.domains[i] = *domain
-			return nil
+	for _, project := range fixture.Projects {
+		if err := repo.CreateProject(&project); err != nil {
+			return fmt.Errorf("project %s: %w", project.Name, err)
 		}
 	}
-	return types.ErrDomainNotFound
-}
-
-// GetDomainsByName returns domains matching the given domain name
-func (r *InMemoryRepo) GetDomainsByName(name string) ([]types.Domain, error) {
-	result := make([]types.Domain, 0)
-	for _, domain := range r.domains {
-		if domain.Name == name {
-			result = append(result, domain)
+	for _, creds := range fixture.Credentials {
+		if err := repo.CreateCredentials(&creds); err != nil {
+			return fmt.Errorf("credentials %s: %w", creds.Name, err)
 		}
 	}
-	return result, nil
-}
-
-// User repository methods (in-memory)
-func (r *InMemoryRepo) CreateUser(user *types.User) error {
-	return nil // Mock implementation
-}
-
-func (r *InMemoryRepo) GetUserByUsername(username string) (*types.User, error) {
-	if username == "admin" {
-		return &types.User{
-			ID:           "admin-id",
-			Username:     "admin",
-			Email:        "admin@domainvault.local",
-			PasswordHash: "$2a$12$LQv3c1yqBWVHxkd0LHAkCOYz6TtxMQJqhN8/LewdBPj0GdRLXu6G6", // admin123
-			Role:         "admin",
-			Enabled:      true,
-		}, nil
+	if _, err := repo.UpsertDomains(fixture.Domains); err != nil {
+		return err
 	}
-	return nil, types.ErrDomainNotFound
-}
-
-func (r *InMemoryRepo) GetUserByID(id string) (*types.User, error) {
-	return nil, types.ErrDomainNotFound
-}
-
-func (r *InMemoryRepo) UpdateUser(user *types.User) error {
-	return nil
-}
-
-func (r *InMemoryRepo) DeleteUser(id string) error {
-	return nil
-}
-
-func (r *InMemoryRepo) CreateSession(session *types.Session) error {
-	return nil
-}
-
-func (r *InMemoryRepo) GetSessionByToken(token string) (*types.Session, error) {
-	return &types.Session{
-		ID:        "session-id",
-		UserID:    "admin-id",
-		Token:     token,
-		ExpiresAt: time.Now().Add(24 * time.Hour),
-	}, nil
-}
-
-func (r *InMemoryRepo) DeleteSession(token string) error {
-	return nil
-}
-
-func (r *InMemoryRepo) DeleteExpiredSessions() error {
-	return nil
-}
-
-func (r *InMemoryRepo) UpdateLastLogin(userID string) error {
-	return nil
-}
-
-// DNS repository methods (in-memory)
-func (r *InMemoryRepo) CreateRecord(record *types.DNSRecord) error {
-	return nil
-}
-
-func (r *InMemoryRepo) GetRecordsByDomain(domainID string) ([]types.DNSRecord, error) {
-	return []types.DNSRecord{
-		{ID: "1", DomainID: domainID, Type: "A", Name: "@", Value: "192.168.1.1", TTL: 3600},
-		{ID: "2", DomainID: domainID, Type: "A", Name: "www", Value: "192.168.1.1", TTL: 3600},
-		{ID: "3", DomainID: domainID, Type: "MX", Name: "@", Value: "mail.example.com", TTL: 3600, Priority: &[]int{10}[0]},
-	}, nil
-}
-
-func (r *InMemoryRepo) GetRecordByID(id string) (*types.DNSRecord, error) {
-	return &types.DNSRecord{
-		ID:       id,
-		DomainID: "domain-1",
-		Type:     "A",
-		Name:     "@",
-		Value:    "192.168.1.1",
-		TTL:      3600,
-	}, nil
-}
-
-func (r *InMemoryRepo) UpdateRecord(record *types.DNSRecord) error {
-	return nil
-}
-
-func (r *InMemoryRepo) DeleteRecord(id string) error {
-	return nil
-}
-
-func (r *InMemoryRepo) DeleteRecordsByDomain(domainID string) error {
-	return nil
-}
-
-func (r *InMemoryRepo) BulkCreateRecords(records []types.DNSRecord) error {
-	return nil
-}
-
-// Category and Project methods (in-memory)
-func (r *InMemoryRepo) CreateCategory(category *types.Category) error {
-	return nil
-}
-
-func (r *InMemoryRepo) GetAllCategories() ([]types.Category, error) {
-	return []types.Category{
-		{ID: "1", Name: "Personal", Description: "Personal domains", Color: "#6366f1"},
-		{ID: "2", Name: "Business", Description: "Business domains", Color: "#dc2626"},
-	}, nil
-}
-
-func (r *InMemoryRepo) GetCategoryByID(id string) (*types.Category, error) {
-	return &types.Category{ID: id, Name: "Personal", Description: "Personal domains", Color: "#6366f1"}, nil
-}
-
-func (r *InMemoryRepo) UpdateCategory(category *types.Category) error {
-	return nil
-}
-
-func (r *InMemoryRepo) DeleteCategory(id string) error {
-	return nil
-}
-
-func (r *InMemoryRepo) CreateProject(project *types.Project) error {
-	return nil
-}
-
-func (r *InMemoryRepo) GetAllProjects() ([]types.Project, error) {
-	return []types.Project{
-		{ID: "1", Name: "Portfolio Sites", Description: "Personal portfolio websites", Color: "#059669"},
-		{ID: "2", Name: "E-commerce", Description: "Online store projects", Color: "#dc2626"},
-	}, nil
-}
-
-func (r *InMemoryRepo) GetProjectByID(id string) (*types.Project, error) {
-	return &types.Project{ID: id, Name: "Portfolio Sites", Description: "Personal portfolio websites", Color: "#059669"}, nil
-}
-
-func (r *InMemoryRepo) UpdateProject(project *types.Project) error {
-	return nil
-}
-
-func (r *InMemoryRepo) DeleteProject(id string) error {
-	return nil
-}
-
-// Credentials methods (in-memory)
-func (r *InMemoryRepo) CreateCredentials(creds *types.ProviderCredentials) error {
-	return nil
-}
-
-func (r *InMemoryRepo) GetAllCredentials() ([]types.ProviderCredentials, error) {
-	return []types.ProviderCredentials{
-		{ID: "1", Provider: "mock", Name: "Mock Provider", Enabled: true},
-	}, nil
-}
-
-func (r *InMemoryRepo) GetCredentialsByID(id string) (*types.ProviderCredentials, error) {
-	return &types.ProviderCredentials{ID: id, Provider: "mock", Name: "Mock Provider", Enabled: true}, nil
-}
-
-func (r *InMemoryRepo) GetCredentialsByProvider(provider string) ([]types.ProviderCredentials, error) {
-	return []types.ProviderCredentials{
-		{ID: "1", Provider: provider, Name: "Mock Provider", Enabled: true},
-	}, nil
-}
-
-func (r *InMemoryRepo) UpdateCredentials(creds *types.ProviderCredentials) error {
-	return nil
-}
-
-func (r *InMemoryRepo) DeleteCredentials(id string) error {
-	return nil
-}
-
-// Secure credentials methods (in-memory mock implementations)
-func (r *InMemoryRepo) CreateSecureCredentials(creds *types.SecureProviderCredentials) error {
-	return nil
-}
-
-func (r *InMemoryRepo) GetAllSecureCredentials() ([]types.SecureProviderCredentials, error) {
-	return []types.SecureProviderCredentials{
-		{
-			ID:                  "mock-1",
-			Provider:            "mock",
-			Name:                "Mock Secure Credentials",
-			CredentialReference: "MOCK_DEFAULT",
-			Enabled:             true,
-			ConnectionStatus:    "connected",
-		},
-	}, nil
-}
-
-func (r *InMemoryRepo) GetSecureCredentialsByID(id string) (*types.SecureProviderCredentials, error) {
-	return &types.SecureProviderCredentials{
-		ID:                  id,
-		Provider:            "mock",
-		Name:                "Mock Secure Credentials",
-		CredentialReference: "MOCK_DEFAULT",
-		Enabled:             true,
-		ConnectionStatus:    "connected",
-	}, nil
-}
-
-func (r *InMemoryRepo) GetSecureCredentialsByProvider(provider string) ([]types.SecureProviderCredentials, error) {
-	return []types.SecureProviderCredentials{
-		{
-			ID:                  "provider-1",
-			Provider:            provider,
-			Name:                "Mock Secure Credentials",
-			CredentialReference: "MOCK_DEFAULT",
-			Enabled:             true,
-			ConnectionStatus:    "connected",
-		},
-	}, nil
-}
-
-func (r *InMemoryRepo) GetSecureCredentialsByReference(reference string) (*types.SecureProviderCredentials, error) {
-	return &types.SecureProviderCredentials{
-		ID:                  "ref-1",
-		Provider:            "mock",
-		Name:                "Mock Secure Credentials",
-		CredentialReference: reference,
-		Enabled:             true,
-		ConnectionStatus:    "connected",
-	}, nil
-}
-
-func (r *InMemoryRepo) UpdateSecureCredentials(creds *types.SecureProviderCredentials) error {
-	return nil
-}
-
-func (r *InMemoryRepo) DeleteSecureCredentials(id string) error {
+	r.MockRepo = repo
 	return nil
 }
 
@@ -426,14 +83,30 @@ func main() {
 	fmt.Println("🌐 Server will start on http://localhost:8080")
 	fmt.Println("")
 
-	// Initialize in-memory storage
+	// Initialize in-memory storage, from a fixture if one is given
 	repo := NewInMemoryRepo()
+	useMockProvider := true
+	if path := os.Getenv("DEV_SEED_FILE"); path != "" {
+		fixture, err := LoadFixture(path)
+		if err != nil {
+			log.Fatal("Failed to load seed fixture: ", err)
+		}
+		if err := repo.Seed(fixture); err != nil {
+			log.Fatal("Failed to seed fixture: ", err)
+		}
+		useMockProvider = fixture.MockProvider
+		fmt.Printf("🌱 Seeded %d domains, %d categories, %d projects and %d credentials from %s\n",
+			len(fixture.Domains), len(fixture.Categories), len(fixture.Projects), len(fixture.Credentials), path)
+	}
 
 	// Initialize services
 	syncSvc := core.NewSyncService(repo)
 	authSvc := auth.NewAuthService(repo)
 	dnsSvc := dns.NewDNSService(repo)
 	analyticsSvc := analytics.NewAnalyticsService(repo)
+	if err := authSvc.CreateDefaultAdmin(); err != nil {
+		log.Fatal("Failed to create admin user: ", err)
+	}
 	
 	// Mock notification configurations
 	emailConfig := notifications.EmailConfig{Enabled: false}
@@ -444,34 +117,37 @@ func main() {
 	// Mock security service - use nil for development
 	var securitySvc *security.SecurityService = nil
 
-	// Add mock provider
-	mockClient, err := providers.NewMockClient(providers.ProviderCredentials{})
-	if err != nil {
-		log.Fatal("Failed to create mock client:", err)
-	}
-	syncSvc.AddProvider("mock", mockClient)
-
-	// Perform initial sync to populate data
-	fmt.Println("🔄 Performing initial sync...")
-	if err := syncSvc.Run(); err != nil {
-		log.Printf("Initial sync error: %v", err)
-	} else {
-		fmt.Println("✅ Initial sync completed")
-	}
-
-	// Start periodic sync
-	go func() {
-		ticker := time.NewTicker(5 * time.Minute)
-		defer ticker.Stop()
+	if useMockProvider {
+		// Add mock provider
+		mockClient, err := providers.NewMockClient(providers.ProviderCredentials{})
+		if err != nil {
+			log.Fatal("Failed to create mock client:", err)
+		}
+		syncSvc.AddProvider("mock", mockClient)
+		syncSvc.SetDNSSync(true, 0) // Stores the mock zones so the DNS pages have records
+
+		// Perform initial sync to populate data
+		fmt.Println("🔄 Performing initial sync...")
+		if err := syncSvc.Run(); err != nil {
+			log.Printf("Initial sync error: %v", err)
+		} else {
+			fmt.Println("✅ Initial sync completed")
+		}
 
-		for range ticker.C {
-			if err := syncSvc.Run(); err != nil {
-				log.Printf("Periodic sync failed: %v", err)
-			} else {
-				log.Println("🔄 Periodic sync completed")
+		// Start periodic sync
+		go func() {
+			ticker := time.NewTicker(5 * time.Minute)
+			defer ticker.Stop()
+
+			for range ticker.C {
+				if err := syncSvc.Run(); err != nil {
+					log.Printf("Periodic sync failed: %v", err)
+				} else {
+					log.Println("🔄 Periodic sync completed")
+				}
 			}
-		}
-	}()
+		}()
+	}
 
 	// Load configuration (including UptimeRobot if available)
 	cfg, err := config.Load()
@@ -492,13 +168,14 @@ func main() {
 	}
 
 	// Initialize API handlers
-	handler := api.NewDomainHandler(repo, syncSvc, uptimeRobotSvc)
-adminHandler := api.NewAdminHandler(repo, authSvc, syncSvc, dnsSvc, analyticsSvc, notificationSvc, securitySvc, uptimeRobotSvc)
+	jobQueue := jobs.NewQueue(jobs.DefaultWorkers)
+	handler := api.NewDomainHandler(repo, syncSvc, uptimeRobotSvc, jobQueue)
+adminHandler := api.NewAdminHandler(repo, authSvc, syncSvc, dnsSvc, providers.NewProviderService(), analyticsSvc, notificationSvc, securitySvc, uptimeRobotSvc, jobQueue)
 
 	// Setup Gin router
 	gin.SetMode(gin.ReleaseMode)
 	r := gin.New()
-	r.Use(gin.Logger(), gin.Recovery())
+	r.Use(gin.Logger(), gin.Recovery(), api.GzipMiddleware())
 
 	// Add CORS middleware for frontend testing
 	r.Use(func(c *gin.Context) {
@@ -2,10 +2,15 @@ package main
 
 import (
 	"github.com/joho/godotenv"
+	"context"
+	"errors"
 	"fmt"
 	"log"
+	"net/http"
 	"os"
+	"os/signal"
 	"strconv"
+	"syscall"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -15,9 +20,11 @@ import (
 	"github.com/rusiqe/domainvault/internal/config"
 	"github.com/rusiqe/domainvault/internal/core"
 	"github.com/rusiqe/domainvault/internal/dns"
+	"github.com/rusiqe/domainvault/internal/jobs"
 	"github.com/rusiqe/domainvault/internal/notifications"
 	"github.com/rusiqe/domainvault/internal/providers"
 	"github.com/rusiqe/domainvault/internal/security"
+	"github.com/rusiqe/domainvault/internal/status"
 	"github.com/rusiqe/domainvault/internal/storage"
 	"github.com/rusiqe/domainvault/internal/types"
 	"github.com/rusiqe/domainvault/internal/uptimerobot"
@@ -49,18 +56,72 @@ func main() {
 	// Debug: print the loaded database URL
 	log.Printf("Database URL: %s", cfg.DatabaseURL)
 
+	// Cancel background work on SIGINT/SIGTERM so deploys don't cut syncs off mid-write
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// Encrypt provider credentials at rest when a master key is configured
+	if cfg.CredentialsMasterKey != "" {
+		primary, err := types.ParseMasterKey(cfg.CredentialsMasterKey)
+		if err != nil {
+			log.Fatal("Invalid CREDENTIALS_MASTER_KEY:", err)
+		}
+		var previous [][]byte
+		for _, encoded := range cfg.CredentialsPreviousKeys {
+			key, err := types.ParseMasterKey(encoded)
+			if err != nil {
+				log.Fatal("Invalid CREDENTIALS_PREVIOUS_KEYS entry:", err)
+			}
+			previous = append(previous, key)
+		}
+		if err := types.SetCredentialsMasterKey(primary, previous...); err != nil {
+			log.Fatal("Failed to configure credentials encryption:", err)
+		}
+	} else {
+		log.Printf("Warning: CREDENTIALS_MASTER_KEY not set; provider credentials are stored unencrypted")
+	}
+
 	// Initialize storage
-	repo, err := storage.NewRepo(cfg.DatabaseURL)
+	repo, err := storage.NewRepo(cfg.DatabaseURL, storage.PoolConfig{
+		MaxOpenConns:    cfg.DatabasePool.MaxOpenConns,
+		MaxIdleConns:    cfg.DatabasePool.MaxIdleConns,
+		ConnMaxLifetime: cfg.DatabasePool.ConnMaxLifetime,
+		QueryTimeout:    cfg.DatabasePool.QueryTimeout,
+		UpsertBatchSize: cfg.DatabasePool.UpsertBatchSize,
+	})
 	if err != nil {
 		log.Fatal("Failed to initialize database:", err)
 	}
-	defer repo.Close()
+
+	// Encrypt any credentials written before encryption was enabled or under a rotated key
+	if encrypter, ok := repo.(interface{ EncryptCredentials() (int, error) }); ok {
+		if n, err := encrypter.EncryptCredentials(); err != nil {
+			log.Fatal("Failed to encrypt stored credentials:", err)
+		} else if n > 0 {
+			log.Printf("Encrypted %d stored provider credential sets", n)
+		}
+	}
 
 	// Initialize sync service
 	syncSvc := core.NewSyncService(repo)
+	breakerConfig := providers.BreakerConfig{
+		Threshold:   cfg.ProviderBreakerThreshold,
+		Cooldown:    cfg.ProviderBreakerCooldown,
+		CallTimeout: cfg.ProviderCallTimeout,
+	}
+	syncSvc.SetCircuitBreaker(breakerConfig)
 
 	// Initialize providers
 	providerSvc := providers.NewProviderService()
+	providerSvc.SetStaleMultiplier(cfg.ProviderStaleMultiplier)
+	providerSvc.SetBreakerConfig(breakerConfig)
+	providerSvc.SetAvailabilityRateLimit(cfg.Lookup.RegistrarRate)
+
+	// Registry lookups are shared so every caller counts against the same rate limits
+	rdapClient := providers.NewRDAPClient("")
+	rdapClient.SetRateLimit(cfg.Lookup.RDAPRate)
+	whoisClient := providers.NewWHOISClient("")
+	whoisClient.SetRateLimit(cfg.Lookup.WHOISRate)
 	for _, providerConfig := range cfg.Providers {
 		client, err := providers.NewClient(providerConfig.Name, providerConfig.Credentials)
 		if err != nil {
@@ -70,20 +131,94 @@ func main() {
 		syncSvc.AddProvider(providerConfig.Name, client)
 		providerSvc.RegisterClient(providerConfig.Name, client)
 	}
+	// Secure connections hold only a reference; their credentials are read from the configured
+	// secret store each time they're used
+	var secretStore providers.SecretStore = providers.NewDBSecretStore(repo)
+	switch cfg.Secrets.Backend {
+	case providers.SecretBackendVault:
+		secretStore = providers.NewVaultSecretStore(providers.VaultConfig{
+			Address:   cfg.Secrets.VaultAddress,
+			Token:     cfg.Secrets.VaultToken,
+			Namespace: cfg.Secrets.VaultNamespace,
+			Mount:     cfg.Secrets.VaultMount,
+			Prefix:    cfg.Secrets.VaultPrefix,
+		})
+	case providers.SecretBackendAWS:
+		secretStore = providers.NewAWSSecretStore(providers.AWSSecretsConfig{
+			Region:          cfg.Secrets.AWSRegion,
+			AccessKeyID:     cfg.Secrets.AWSAccessKeyID,
+			SecretAccessKey: cfg.Secrets.AWSSecretAccessKey,
+			SessionToken:    cfg.Secrets.AWSSessionToken,
+			Prefix:          cfg.Secrets.AWSPrefix,
+			Endpoint:        cfg.Secrets.AWSEndpoint,
+		})
+	}
+	secureProviderSvc := providers.NewSecureProviderService(repo)
+	secureProviderSvc.SetSecretStore(secretStore)
+	log.Printf("Secure provider credentials are kept in the %s secret store", secretStore.Backend())
+	for id, client := range secureProviderSvc.SyncClients() {
+		syncSvc.AddProvider(core.SecureSyncName(client.GetProviderName(), id), client)
+	}
+
+	// Every enabled set of stored credentials syncs as its own account; reloaded on each sync
+	if count, err := syncSvc.LoadAccounts(); err != nil {
+		log.Printf("Failed to load stored provider accounts: %v", err)
+	} else if count > 0 {
+		log.Printf("Syncing %d stored provider accounts", count)
+	}
 
 	// Initialize DNS service early for schedulers
 	dnsSvc := dns.NewDNSService(repo)
+	dnsSvc.SetCacheTTL(cfg.DNSCacheTTL)
+	dnsSvc.SetBackupRetention(cfg.DNSBackupRetention)
 	// Configure sync service to use DNS service
 	syncSvc.SetDNSService(dnsSvc)
+	syncSvc.SetRegistrarStatusSync(cfg.SyncRegistrarStatus)
+	syncSvc.SetDNSSync(cfg.SyncDNS, cfg.SyncDNSWorkers)
+	syncSvc.SetRenewalPriceAlertPercent(cfg.RenewalPriceAlertPercent)
+	syncSvc.SetGracePeriodAlerts(cfg.GracePeriodAlerts)
+	// A restart during maintenance stays paused; the settings service restores the rest below
+	var maintenance types.MaintenanceMode
+	if err := repo.GetSetting(types.SettingMaintenance, &maintenance); err == nil && maintenance.Enabled {
+		syncSvc.SetPaused(true)
+	}
+
+	// Initialize notification service with default configuration
+	emailConfig := notifications.EmailConfig{
+		SMTPHost:    "smtp.gmail.com",
+		SMTPPort:    587,
+		FromAddress: "noreply@domainvault.com",
+		FromName:    "DomainVault",
+		Enabled:     false, // Disabled by default
+	}
+	slackConfig := notifications.SlackConfig{
+		Enabled: false, // Disabled by default
+	}
+	webhookConfig := notifications.WebhookConfig{
+		Enabled: false, // Disabled by default
+	}
+	notificationSvc := notifications.NewNotificationService(emailConfig, slackConfig, webhookConfig)
+	notificationSvc.SetWebhookStore(repo)
+	notificationSvc.SetTemplateStore(repo)
+	notificationSvc.SetDashboardURL(cfg.PublicURL + "/admin")
+	syncSvc.SetNotificationService(notificationSvc)
 
 	// Start domain sync scheduler (registrar domains)
 	go func() {
 		ticker := time.NewTicker(cfg.SyncInterval)
 		defer ticker.Stop()
 
-		for range ticker.C {
-			if err := syncSvc.Run(); err != nil {
-				log.Printf("Sync failed: %v", err)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case interval := <-syncSvc.IntervalUpdates():
+				ticker.Reset(interval)
+				log.Printf("Sync interval set to %s", interval)
+			case <-ticker.C:
+				if err := syncSvc.Run(); err != nil {
+					log.Printf("Sync failed: %v", err)
+				}
 			}
 		}
 	}()
@@ -111,67 +246,272 @@ func main() {
 				log.Printf("DNS refresh: failed to list domains: %v", err)
 				return
 			}
-			updated := 0
-			skipped := 0
-			for _, d := range domains {
-				// Fetch from Cloudflare
-				records, err := cfClient.FetchDNSRecords(d.Name)
-				if err != nil {
-					// Optional: try registrar as fallback
-					if regClient, ok := providerSvc.GetClientByProviderName(d.Provider); ok {
-						records, err = regClient.FetchDNSRecords(d.Name)
+			registrarFor := func(provider string) providers.RegistrarClient {
+				client, _ := providerSvc.GetClientByProviderName(provider)
+				return client
+			}
+			summary := dnsSvc.RefreshDomains(ctx, domains, cfClient, registrarFor, cfg.DNSRefreshWorkers,
+				func(d types.Domain, result *dns.RefreshResult, err error) {
+					if result != nil && result.NameserverChange != nil {
+						notificationSvc.NotifyNameserverChange(d, *result.NameserverChange)
 					}
-				}
-				if err != nil || len(records) == 0 {
-					continue
-				}
-				// Compare with DB
-				stored, err := dnsSvc.GetDomainRecords(d.ID)
+					if err != nil {
+						log.Printf("DNS refresh: %v", err)
+					}
+				})
+			log.Printf("DNS refresh completed: %d updated, %d unchanged, %d failed, %d skipped, total %d",
+				summary.Updated, summary.Unchanged, summary.Failed, summary.Skipped, summary.Total)
+		}
+
+		// Initial run, then on each tick; tracked so shutdown waits for in-progress DNS writes
+		tracked := func() {
+			if err := syncSvc.Track(func() error { refresh(); return nil }); err != nil {
+				log.Printf("DNS refresh skipped: %v", err)
+			}
+		}
+		tracked()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-dnsTicker.C:
+				tracked()
+			}
+		}
+	}()
+
+	// Start auto-renew reconciliation (compares our auto-renew flags with the registrars)
+	if cfg.AutoRenewReconcileInterval > 0 {
+		go func() {
+			ticker := time.NewTicker(cfg.AutoRenewReconcileInterval)
+			defer ticker.Stop()
+
+			reconcile := func() error {
+				domains, err := repo.GetAll()
 				if err != nil {
-					log.Printf("DNS refresh: failed to get stored records for %s: %v", d.Name, err)
+					return fmt.Errorf("failed to list domains: %w", err)
 				}
-				if dnsRecordSetsEqual(stored, records) {
-					skipped++
-					continue
+				drifted, pushed := 0, 0
+				for _, d := range domains {
+					report, err := providerSvc.ReconcileAutoRenew(d, cfg.AutoRenewEnforce)
+					if err != nil {
+						if !errors.Is(err, types.ErrAutoRenewUnsupported) {
+							log.Printf("Auto-renew reconcile: %s: %v", d.Name, err)
+						}
+						continue
+					}
+					if report.Drift {
+						drifted++
+					}
+					if report.Pushed {
+						pushed++
+					} else if report.AtRisk {
+						log.Printf("Auto-renew drift: %s is set to auto-renew locally but %s has it disabled", d.Name, d.Provider)
+					}
 				}
-				// Replace stored records with fresh ones
-				if err := dnsSvc.BulkUpdateRecords(d.ID, normalizeRecordsForStore(d.ID, records)); err != nil {
-					log.Printf("DNS refresh: failed to update records for %s: %v", d.Name, err)
-					continue
+				log.Printf("Auto-renew reconcile completed: %d drifted, %d pushed, total %d", drifted, pushed, len(domains))
+				return nil
+			}
+
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					if err := syncSvc.Track(reconcile); err != nil {
+						log.Printf("Auto-renew reconcile failed: %v", err)
+					}
 				}
-				updated++
 			}
-			log.Printf("DNS refresh completed: %d updated, %d unchanged, total %d", updated, skipped, len(domains))
-		}
+		}()
+	}
 
-		// Initial run
-		refresh()
-		for range dnsTicker.C {
-			refresh()
-		}
-	}()
+	// Start data retention cleanup (deletes audit/history rows past their retention window)
+	if purger, ok := repo.(interface {
+		PurgeExpired(map[string]int, int, bool) ([]storage.RetentionResult, error)
+	}); ok && cfg.Retention.Interval > 0 {
+		go func() {
+			ticker := time.NewTicker(cfg.Retention.Interval)
+			defer ticker.Stop()
+
+			cleanup := func() error {
+				results, err := purger.PurgeExpired(cfg.Retention.Days, cfg.Retention.BatchSize, cfg.Retention.DryRun)
+				for _, result := range results {
+					if result.Skipped != "" || result.Rows == 0 {
+						continue
+					}
+					if result.DryRun {
+						log.Printf("Retention (dry run): %d rows in %s older than %d days would be deleted", result.Rows, result.Table, result.RetentionDays)
+					} else {
+						log.Printf("Retention: deleted %d rows from %s older than %d days", result.Rows, result.Table, result.RetentionDays)
+					}
+				}
+				return err
+			}
+
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					if err := syncSvc.Track(cleanup); err != nil {
+						log.Printf("Retention cleanup failed: %v", err)
+					}
+				}
+			}
+		}()
+	}
 
 	// Initialize services
 	authSvc := auth.NewAuthService(repo)
+	authSvc.SetPasswordResetConfig(cfg.PasswordResetTTL, cfg.PublicURL+"/admin")
 
 	// Initialize enhanced services
 	analyticsSvc := analytics.NewAnalyticsService(repo)
+	analyticsSvc.SetHealthWeights(cfg.HealthWeights)
 
-	// Initialize notification service with default configuration
-	emailConfig := notifications.EmailConfig{
-		SMTPHost:    "smtp.gmail.com",
-		SMTPPort:    587,
-		FromAddress: "noreply@domainvault.com",
-		FromName:    "DomainVault",
-		Enabled:     false, // Disabled by default
+	// Record a daily portfolio snapshot for the analytics history; a rerun on the same day
+	// replaces that day's snapshot
+	go func() {
+		ticker := time.NewTicker(24 * time.Hour)
+		defer ticker.Stop()
+
+		snapshot := func() error {
+			s, err := analyticsSvc.TakeSnapshot()
+			if err != nil {
+				return err
+			}
+			log.Printf("Portfolio snapshot recorded: %d domains, %.2f renewal cost", s.TotalDomains, s.TotalRenewalCost)
+			return nil
+		}
+
+		for {
+			if err := syncSvc.Track(snapshot); err != nil {
+				log.Printf("Portfolio snapshot failed: %v", err)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	// Check watched domains for availability; registrars that can check (with prices) are
+	// asked first, then RDAP
+	watchlistSvc := core.NewWatchlistService(repo, providerSvc, rdapClient)
+	watchlistSvc.SetNotificationService(notificationSvc)
+	if cfg.WatchlistCheckInterval > 0 {
+		go func() {
+			ticker := time.NewTicker(cfg.WatchlistCheckInterval)
+			defer ticker.Stop()
+
+			check := func() error {
+				summary, err := watchlistSvc.CheckAll()
+				if summary.Checked > 0 {
+					log.Printf("Watchlist check completed: %d checked, %d available, %d alerted, %d failed",
+						summary.Checked, summary.Available, summary.Notified, summary.Failed)
+				}
+				return err
+			}
+
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					if err := syncSvc.Track(check); err != nil {
+						log.Printf("Watchlist check failed: %v", err)
+					}
+				}
+			}
+		}()
 	}
-	slackConfig := notifications.SlackConfig{
-		Enabled: false, // Disabled by default
+
+	// Alert when an auto-sync provider stops syncing, e.g. after its API key was rotated
+	if cfg.ProviderStaleCheckInterval > 0 {
+		go func() {
+			ticker := time.NewTicker(cfg.ProviderStaleCheckInterval)
+			defer ticker.Stop()
+
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					now := time.Now()
+					for _, provider := range providerSvc.CheckStaleProviders(now) {
+						alert := notificationSvc.CreateProviderStaleAlert(provider.Name, provider.Provider,
+							provider.LastSuccessfulSync, provider.LastSuccessAge(now), providerSvc.StaleAfter(&provider))
+						if err := notificationSvc.Notify(alert); err != nil {
+							log.Printf("Failed to send stale provider alert for %s: %v", provider.Name, err)
+						}
+					}
+				}
+			}
+		}()
 	}
-	webhookConfig := notifications.WebhookConfig{
-		Enabled: false, // Disabled by default
+
+	// Look for records pointing at deprovisioned infrastructure, a subdomain takeover risk
+	danglingDNS := core.NewDanglingDNSMonitor(repo, dns.NewDanglingChecker())
+	danglingDNS.SetNotificationService(notificationSvc)
+	if cfg.DanglingDNSCheckInterval > 0 {
+		go func() {
+			ticker := time.NewTicker(cfg.DanglingDNSCheckInterval)
+			defer ticker.Stop()
+
+			scan := func() error {
+				report, err := danglingDNS.Scan(ctx)
+				if report != nil {
+					log.Printf("Dangling DNS check completed: %d records checked, %d findings, %d failed",
+						report.RecordsChecked, len(report.Findings), report.Errors)
+				}
+				return err
+			}
+
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					if err := syncSvc.Track(scan); err != nil {
+						log.Printf("Dangling DNS check failed: %v", err)
+					}
+				}
+			}
+		}()
+	}
+
+	// Compare provider-reported expiries with the registries', a share of the portfolio per run;
+	// RDAP is asked first, then WHOIS for registries without it
+	if cfg.ExpiryReconcileInterval > 0 {
+		reconciler := core.NewExpiryReconciler(repo, rdapClient, whoisClient)
+		reconciler.SetPercent(cfg.ExpiryReconcilePercent)
+		go func() {
+			ticker := time.NewTicker(cfg.ExpiryReconcileInterval)
+			defer ticker.Stop()
+
+			reconcile := func() error {
+				summary, err := reconciler.Run()
+				if summary.Checked > 0 {
+					log.Printf("Expiry reconcile completed: %d of %d checked, %d discrepancies, %d failed",
+						summary.Checked, summary.Portfolio, summary.Discrepancies, summary.Failed)
+				}
+				return err
+			}
+
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					if err := syncSvc.Track(reconcile); err != nil {
+						log.Printf("Expiry reconcile failed: %v", err)
+					}
+				}
+			}
+		}()
 	}
-	notificationSvc := notifications.NewNotificationService(emailConfig, slackConfig, webhookConfig)
 
 	// Initialize security service with default configuration
 	securityConfig := security.SecurityConfig{
@@ -184,9 +524,35 @@ func main() {
 		EnableIPWhitelisting: false,
 		EnableMFA:           false,
 		JWTSigningKey:       "your-secret-key-change-in-production",
+		RiskWeights:         make(map[security.AuditEventType]int),
+		OffHoursStart:       cfg.RiskScoring.OffHoursStart,
+		OffHoursEnd:         cfg.RiskScoring.OffHoursEnd,
+		OffHoursTimezone:    cfg.RiskScoring.Timezone,
+		RiskAlertThreshold:  cfg.RiskScoring.AlertThreshold,
+		DataRetention:       cfg.Retention.Days,
+	}
+	for eventType, score := range cfg.RiskScoring.Weights {
+		securityConfig.RiskWeights[security.AuditEventType(eventType)] = score
 	}
-	// Note: In production, these would be implemented with actual repository interfaces
-	securitySvc := security.NewSecurityService(nil, nil, nil, securityConfig)
+	// Note: In production, these would be implemented with actual repository interfaces.
+	// Login attempts are kept in memory so failed logins lock accounts out.
+	securitySvc := security.NewSecurityService(nil, nil, security.NewMemorySecurityRepository(), securityConfig)
+
+	// Runtime settings saved through the admin API override the values above and the environment
+	settingsSvc := core.NewSettingsService(repo, types.RuntimeSettings{
+		SyncIntervalMinutes: int(cfg.SyncInterval / time.Minute),
+		SyncRegistrarStatus: cfg.SyncRegistrarStatus,
+		Notifications: types.NotificationChannelSettings{
+			Email:   emailConfig.Enabled,
+			Slack:   slackConfig.Enabled,
+			Webhook: webhookConfig.Enabled,
+		},
+		Security: types.SecurityPolicySettings{
+			MaxLoginAttempts: securityConfig.MaxLoginAttempts,
+			LockoutMinutes:   int(securityConfig.LockoutDuration / time.Minute),
+		},
+	}, syncSvc, notificationSvc, securitySvc)
+	settingsSvc.Apply()
 
 	// Create default admin user if it doesn't exist
 	if err := authSvc.CreateDefaultAdmin(); err != nil {
@@ -199,6 +565,7 @@ if cfg.UptimeRobot != nil {
 	uptimeRobotSvc = uptimerobot.NewService(cfg.UptimeRobot)
 	if uptimeRobotSvc.IsConfigured() {
 		log.Printf("UptimeRobot service initialized")
+		syncSvc.SetUptimeRobotService(uptimeRobotSvc)
 	} else {
 		log.Printf("UptimeRobot service disabled: not properly configured")
 	}
@@ -209,11 +576,47 @@ if cfg.UptimeRobot != nil {
 }
 
 // Initialize API handlers (with UptimeRobot service)
-handler := api.NewDomainHandler(repo, syncSvc, uptimeRobotSvc)
-adminHandler := api.NewAdminHandler(repo, authSvc, syncSvc, dnsSvc, providerSvc, analyticsSvc, notificationSvc, securitySvc, uptimeRobotSvc)
+jobQueue := jobs.NewQueue(jobs.DefaultWorkers)
+handler := api.NewDomainHandler(repo, syncSvc, uptimeRobotSvc, jobQueue)
+handler.SetAnalyticsService(analyticsSvc)
+handler.SetMaintenanceState(settingsSvc)
+handler.SetAuthService(authSvc)
+requestLimits := api.RequestLimits{
+	MaxBodyBytes:      cfg.RequestLimits.MaxBodyBytes,
+	MaxBulkBodyBytes:  cfg.RequestLimits.MaxBulkBodyBytes,
+	MaxBulkOperations: cfg.RequestLimits.MaxBulkOperations,
+}
+handler.SetRequestLimits(requestLimits)
+adminHandler := api.NewAdminHandler(repo, authSvc, syncSvc, dnsSvc, providerSvc, analyticsSvc, notificationSvc, securitySvc, uptimeRobotSvc, jobQueue)
+statusChecker, err := status.NewStatusCheckerWithOptions(status.CheckerOptions{
+	ProxyURL:                cfg.StatusCheck.ProxyURL,
+	InsecureSkipVerifyHosts: cfg.StatusCheck.InsecureHosts,
+})
+if err != nil {
+	log.Fatal("Invalid status check configuration:", err)
+}
+if len(cfg.StatusCheck.InsecureHosts) > 0 {
+	log.Printf("Warning: status checks skip TLS verification for %v", cfg.StatusCheck.InsecureHosts)
+}
+adminHandler.SetStatusChecker(statusChecker)
+adminHandler.SetWatchlistService(watchlistSvc)
+adminHandler.SetDanglingDNSMonitor(danglingDNS)
+adminHandler.SetSettingsService(settingsSvc)
+adminHandler.SetRequireVerification(cfg.RequireDomainVerification)
+adminHandler.SetDNSMaxRecords(cfg.DNSMaxRecords)
+adminHandler.SetPurchaseEvents(cfg.PurchaseEventsEnabled)
+adminHandler.SetRequestLimits(requestLimits)
+adminHandler.SetLookupLimits(api.LookupLimits{
+	Workers:        cfg.Lookup.Concurrency,
+	AsyncThreshold: cfg.Lookup.AsyncThreshold,
+	MaxBatch:       cfg.Lookup.MaxBatch,
+})
 
 	// Setup Gin router
 	r := gin.Default()
+	r.Use(api.GzipMiddleware())
+	r.Use(api.MaintenanceMiddleware(settingsSvc))
+	r.Use(api.BodyLimitMiddleware(requestLimits))
 
 	// Serve static files
 	r.Static("/static", "./web/static")
@@ -233,57 +636,40 @@ adminHandler := api.NewAdminHandler(repo, authSvc, syncSvc, dnsSvc, providerSvc,
 	adminHandler.RegisterAdminRoutes(r)
 
 	// Start server
-	log.Printf("Starting server on port %d", cfg.Port)
-	log.Printf("Admin interface available at: http://localhost:%d/admin", cfg.Port)
-	log.Printf("Default admin credentials: admin / admin123")
-	if err := r.Run(fmt.Sprintf(":%d", cfg.Port)); err != nil {
-		log.Fatal("Failed to start server:", err)
+	srv := &http.Server{
+		Addr:    fmt.Sprintf(":%d", cfg.Port),
+		Handler: r,
 	}
-}
-
-// dnsRecordSetsEqual compares two DNS record sets for equality ignoring IDs and timestamps.
-func dnsRecordSetsEqual(a, b []types.DNSRecord) bool {
-	if len(a) != len(b) {
-		return false
-	}
-	am := make(map[string]int, len(a))
-	for _, r := range a {
-		am[fingerprint(r)]++
-	}
-	for _, r := range b {
-		key := fingerprint(r)
-		if c, ok := am[key]; !ok || c == 0 {
-			return false
-		} else {
-			am[key] = c - 1
+	go func() {
+		log.Printf("Starting server on port %d", cfg.Port)
+		log.Printf("Admin interface available at: http://localhost:%d/admin", cfg.Port)
+		log.Printf("Default admin credentials: admin / admin123")
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatal("Failed to start server:", err)
 		}
+	}()
+
+	// Wait for a shutdown signal, then drain in order: HTTP, schedulers, background jobs, in-flight syncs, database
+	<-ctx.Done()
+	stop()
+	log.Printf("Shutdown signal received, draining (timeout %s)", cfg.ShutdownTimeout)
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+	defer cancel()
+
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		log.Printf("HTTP server shutdown error: %v", err)
 	}
-	for _, c := range am {
-		if c != 0 {
-			return false
-		}
+	providerSvc.StopAutoSync()
+	secureProviderSvc.Close()
+	if err := jobQueue.Shutdown(shutdownCtx); err != nil {
+		log.Printf("Background jobs still running: %v", err)
 	}
-	return true
-}
-
-func fingerprint(r types.DNSRecord) string {
-	prio := ""
-	if r.Priority != nil {
-		prio = fmt.Sprintf("|p=%d", *r.Priority)
+	if err := syncSvc.Drain(shutdownCtx); err != nil {
+		log.Printf("Sync drain incomplete: %v", err)
 	}
-	return fmt.Sprintf("%s|%s|%s|ttl=%d%s", r.Type, r.Name, r.Value, r.TTL, prio)
-}
-
-// normalizeRecordsForStore sets DomainID and timestamps; values are already normalized by provider clients
-func normalizeRecordsForStore(domainID string, in []types.DNSRecord) []types.DNSRecord {
-	out := make([]types.DNSRecord, len(in))
-	now := time.Now()
-	for i := range in {
-		out[i] = in[i]
-		out[i].DomainID = domainID
-		// Keep TTL/priority as provided
-		out[i].CreatedAt = now
-		out[i].UpdatedAt = now
+	if err := repo.Close(); err != nil {
+		log.Printf("Failed to close database: %v", err)
 	}
-	return out
+	log.Printf("Shutdown complete")
 }
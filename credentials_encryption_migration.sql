@@ -0,0 +1,11 @@
+-- Provider Credentials Encryption Migration
+-- Credentials are envelope-encrypted by the application (AES-256-GCM) when CREDENTIALS_MASTER_KEY is set.
+-- Encrypted rows are stored as a JSON envelope in the existing JSONB column:
+--   {"_enc": "aes-256-gcm-v1", "kid": "<key id>", "dek": "...", "nonce": "...", "ct": "..."}
+--
+-- Existing plaintext rows (and rows wrapped with a key listed in CREDENTIALS_PREVIOUS_KEYS)
+-- are re-encrypted with the current key automatically when the server starts.
+-- Generate a key with: openssl rand -base64 32
+
+-- Check for rows that are still stored in plaintext:
+SELECT id, provider, name FROM provider_credentials WHERE NOT (credentials ? '_enc');
@@ -0,0 +1,16 @@
+-- DNS Backups Migration
+-- The zone each provider served right before DomainVault wrote to it, so a bad push can be
+-- restored. Only the most recent backups of each domain are kept (DNS_BACKUP_RETENTION).
+
+CREATE TABLE IF NOT EXISTS dns_backups (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    domain_id UUID NOT NULL REFERENCES domains(id) ON DELETE CASCADE,
+    provider VARCHAR(50) NOT NULL,
+    reason VARCHAR(255) NOT NULL DEFAULT '',
+    records JSONB NOT NULL DEFAULT '[]',
+    record_count INTEGER NOT NULL DEFAULT 0,
+    created_by VARCHAR(255) NOT NULL DEFAULT 'system',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_dns_backups_domain_created ON dns_backups(domain_id, created_at DESC);
@@ -0,0 +1,16 @@
+-- DNS Record History Migration
+-- Captures before/after snapshots of every DNS record change
+
+CREATE TABLE IF NOT EXISTS dns_record_history (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    domain_id UUID NOT NULL REFERENCES domains(id) ON DELETE CASCADE,
+    record_id VARCHAR(64),
+    action VARCHAR(10) NOT NULL CHECK (action IN ('create', 'update', 'delete')),
+    before_value JSONB,
+    after_value JSONB,
+    changed_by VARCHAR(255) NOT NULL DEFAULT 'system',
+    changed_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_dns_record_history_domain_changed ON dns_record_history(domain_id, changed_at DESC);
+CREATE INDEX IF NOT EXISTS idx_dns_record_history_record_id ON dns_record_history(record_id);
@@ -0,0 +1,10 @@
+-- DNS Provider State Migration
+-- The records each domain's DNS provider returned the last time they were fetched. Stored
+-- records are compared against this to flag local edits that haven't been pushed yet.
+
+CREATE TABLE IF NOT EXISTS dns_provider_state (
+    domain_id UUID PRIMARY KEY REFERENCES domains(id) ON DELETE CASCADE,
+    provider VARCHAR(50) NOT NULL,
+    records JSONB NOT NULL DEFAULT '[]',
+    fetched_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
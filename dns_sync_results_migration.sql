@@ -0,0 +1,12 @@
+-- DNS Sync Results Migration
+-- Whether the last domain sync managed to fetch each domain's DNS records into the local
+-- store (SYNC_DNS), so stale or failing zones can be spotted.
+
+CREATE TABLE IF NOT EXISTS dns_sync_results (
+    domain_id UUID PRIMARY KEY REFERENCES domains(id) ON DELETE CASCADE,
+    provider VARCHAR(50) NOT NULL,
+    success BOOLEAN NOT NULL,
+    record_count INTEGER NOT NULL DEFAULT 0,
+    error TEXT NOT NULL DEFAULT '',
+    synced_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
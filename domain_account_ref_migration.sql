@@ -0,0 +1,7 @@
+-- Domain Account Reference Migration
+-- Records which connected provider account each domain was last synced from, so the admin
+-- UI can list and group domains by the specific account that holds them
+
+ALTER TABLE domains ADD COLUMN IF NOT EXISTS account_ref TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_domains_account_ref ON domains(account_ref);
@@ -0,0 +1,7 @@
+-- Domain Credential Migration
+-- Records which stored provider account (provider_credentials row) each domain was synced
+-- from, so several accounts at the same registrar can be synced side by side
+
+ALTER TABLE domains ADD COLUMN IF NOT EXISTS credential_id UUID REFERENCES provider_credentials(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_domains_credential_id ON domains(credential_id);
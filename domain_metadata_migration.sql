@@ -0,0 +1,7 @@
+-- Domain Metadata Migration
+-- Adds a JSON column for deployment-specific attributes (cost center, owner email, ticket link)
+
+ALTER TABLE domains ADD COLUMN IF NOT EXISTS metadata JSONB NOT NULL DEFAULT '{}';
+
+-- Supports metadata.<key>=<value> filters on the domain list
+CREATE INDEX IF NOT EXISTS idx_domains_metadata ON domains USING GIN (metadata);
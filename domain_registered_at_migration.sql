@@ -0,0 +1,5 @@
+-- Domain Registration Date Migration
+-- The date each domain was registered, as its registrar or registry reports it. created_at
+-- stays the time DomainVault first stored the domain.
+
+ALTER TABLE domains ADD COLUMN IF NOT EXISTS registered_at TIMESTAMPTZ;
@@ -0,0 +1,7 @@
+-- Domain Verification Migration
+-- Proof of control of a domain through a DNS TXT challenge. verification_token is the
+-- pending challenge; verified_at is set once the TXT record was found.
+
+ALTER TABLE domains ADD COLUMN IF NOT EXISTS verification_token VARCHAR(64);
+ALTER TABLE domains ADD COLUMN IF NOT EXISTS verification_requested_at TIMESTAMPTZ;
+ALTER TABLE domains ADD COLUMN IF NOT EXISTS verified_at TIMESTAMPTZ;
@@ -10,18 +10,26 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/rusiqe/domainvault/internal/api"
 	"github.com/rusiqe/domainvault/internal/core"
+	"github.com/rusiqe/domainvault/internal/jobs"
 	"github.com/rusiqe/domainvault/internal/providers"
+	"github.com/rusiqe/domainvault/internal/storage"
 	"github.com/rusiqe/domainvault/internal/types"
 )
 
-// Mock repository for integration testing
+// Mock repository for integration testing: the shared mock storage with the domain methods
+// the workflow exercises replaced by a plain list
 type mockIntegrationRepo struct {
+	*storage.MockRepo
 	domains []types.Domain
 }
 
-func (m *mockIntegrationRepo) UpsertDomains(domains []types.Domain) error {
+func newMockIntegrationRepo() *mockIntegrationRepo {
+	return &mockIntegrationRepo{MockRepo: storage.NewEmptyMockRepo()}
+}
+
+func (m *mockIntegrationRepo) UpsertDomains(domains []types.Domain) (storage.UpsertResult, error) {
 	m.domains = append(m.domains, domains...)
-	return nil
+	return storage.UpsertResult{Inserted: len(domains)}, nil
 }
 
 func (m *mockIntegrationRepo) GetAll() ([]types.Domain, error) {
@@ -98,7 +106,7 @@ func (m *mockIntegrationRepo) GetExpiring(threshold time.Duration) ([]types.Doma
 	return result, nil
 }
 
-func (m *mockIntegrationRepo) GetSummary() (*types.DomainSummary, error) {
+func (m *mockIntegrationRepo) GetSummary(expiringDays []int) (*types.DomainSummary, error) {
 	summary := &types.DomainSummary{
 		Total:      len(m.domains),
 		ByProvider: make(map[string]int),
@@ -113,21 +121,9 @@ func (m *mockIntegrationRepo) GetSummary() (*types.DomainSummary, error) {
 	return summary, nil
 }
 
-func (m *mockIntegrationRepo) BulkRenew(domainIDs []string) error {
-	return nil
-}
-
-func (m *mockIntegrationRepo) Close() error {
-	return nil
-}
-
-func (m *mockIntegrationRepo) Ping() error {
-	return nil
-}
-
 func TestIntegration_FullWorkflow(t *testing.T) {
 	// Setup components
-	repo := &mockIntegrationRepo{}
+	repo := newMockIntegrationRepo()
 	syncSvc := core.NewSyncService(repo)
 	
 	// Add mock provider
@@ -138,7 +134,7 @@ func TestIntegration_FullWorkflow(t *testing.T) {
 	syncSvc.AddProvider("mock", mockClient)
 	
 	// Setup API handler
-	handler := api.NewDomainHandler(repo, syncSvc)
+	handler := api.NewDomainHandler(repo, syncSvc, nil, jobs.NewQueue(1))
 	
 	// Setup Gin router
 	gin.SetMode(gin.TestMode)
@@ -314,9 +310,9 @@ func TestIntegration_FullWorkflow(t *testing.T) {
 
 func TestIntegration_ErrorHandling(t *testing.T) {
 	// Setup components
-	repo := &mockIntegrationRepo{}
+	repo := newMockIntegrationRepo()
 	syncSvc := core.NewSyncService(repo)
-	handler := api.NewDomainHandler(repo, syncSvc)
+	handler := api.NewDomainHandler(repo, syncSvc, nil, jobs.NewQueue(1))
 	
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
@@ -359,7 +355,7 @@ func TestIntegration_ErrorHandling(t *testing.T) {
 
 func TestIntegration_ConcurrentRequests(t *testing.T) {
 	// Setup components
-	repo := &mockIntegrationRepo{}
+	repo := newMockIntegrationRepo()
 	syncSvc := core.NewSyncService(repo)
 	
 	// Add mock provider
@@ -369,7 +365,7 @@ func TestIntegration_ConcurrentRequests(t *testing.T) {
 	}
 	syncSvc.AddProvider("mock", mockClient)
 	
-	handler := api.NewDomainHandler(repo, syncSvc)
+	handler := api.NewDomainHandler(repo, syncSvc, nil, jobs.NewQueue(1))
 	
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
@@ -0,0 +1,201 @@
+package analytics
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/rusiqe/domainvault/internal/types"
+)
+
+// HealthScoreSortField sorts domain lists by health score. It's computed rather than stored,
+// so it isn't one of types.DomainSortFields.
+const HealthScoreSortField = "health_score"
+
+// Health score factors
+const (
+	HealthFactorExpiry    = "expiry"
+	HealthFactorHTTP      = "http_status"
+	HealthFactorSSL       = "ssl"
+	HealthFactorDNS       = "dns"
+	HealthFactorAutoRenew = "auto_renew"
+)
+
+// HealthFactors lists the health score factors in the order they're reported
+var HealthFactors = []string{HealthFactorExpiry, HealthFactorHTTP, HealthFactorSSL, HealthFactorDNS, HealthFactorAutoRenew}
+
+// DefaultHealthWeights weigh expiry most heavily, since a lapsed domain takes everything
+// else down with it
+var DefaultHealthWeights = map[string]float64{
+	HealthFactorExpiry:    40,
+	HealthFactorHTTP:      20,
+	HealthFactorSSL:       15,
+	HealthFactorDNS:       15,
+	HealthFactorAutoRenew: 10,
+}
+
+// healthExpiryHorizonDays is how far out an expiry has to be to score in full
+const healthExpiryHorizonDays = 90
+
+// HealthFactor is one factor's part in a domain's health score
+type HealthFactor struct {
+	Name     string  `json:"name"`
+	Score    float64 `json:"score"`    // 0-100
+	Weight   float64 `json:"weight"`   // Relative weight among the included factors
+	Included bool    `json:"included"` // False when there's no data for the factor yet, so it doesn't count
+	Detail   string  `json:"detail"`   // Why the factor scored what it did
+}
+
+// HealthScore is a domain's health from 0 to 100 with the factors it was computed from
+type HealthScore struct {
+	Score   int            `json:"score"`
+	Factors []HealthFactor `json:"factors"`
+}
+
+// SetHealthWeights overrides the weights of the given health factors; factors not in
+// weights keep their defaults
+func (as *AnalyticsService) SetHealthWeights(weights map[string]float64) {
+	merged := make(map[string]float64, len(DefaultHealthWeights))
+	for factor, weight := range DefaultHealthWeights {
+		merged[factor] = weight
+	}
+	for factor, weight := range weights {
+		merged[factor] = weight
+	}
+	as.healthWeights = merged
+}
+
+// HealthWeights returns the weight of each health factor
+func (as *AnalyticsService) HealthWeights() map[string]float64 {
+	if as.healthWeights == nil {
+		return DefaultHealthWeights
+	}
+	return as.healthWeights
+}
+
+// DomainHealth scores a domain's health with the service's weights. dnsIssues are the
+// problems a DNS analysis found; nil means the domain's DNS wasn't analyzed.
+func (as *AnalyticsService) DomainHealth(domain types.Domain, dnsIssues []string, now time.Time) HealthScore {
+	return ScoreDomainHealth(domain, dnsIssues, as.HealthWeights(), now)
+}
+
+// ScoreDomainHealth combines a domain's days until expiry, last HTTP status, SSL status, DNS
+// issues and auto-renew setting into a 0-100 score, weighting each factor by weights. Factors
+// without data are reported but left out, so a domain isn't marked down for not having been
+// checked yet.
+func ScoreDomainHealth(domain types.Domain, dnsIssues []string, weights map[string]float64, now time.Time) HealthScore {
+	factors := []HealthFactor{
+		expiryHealth(domain, now),
+		httpHealth(domain),
+		sslHealth(domain),
+		dnsHealth(dnsIssues),
+		autoRenewHealth(domain),
+	}
+
+	var total, weightSum float64
+	for i := range factors {
+		factors[i].Weight = math.Max(weights[factors[i].Name], 0)
+		if factors[i].Included {
+			total += factors[i].Score * factors[i].Weight
+			weightSum += factors[i].Weight
+		}
+	}
+
+	health := HealthScore{Factors: factors}
+	if weightSum > 0 {
+		health.Score = int(math.Round(total / weightSum))
+	}
+	return health
+}
+
+// expiryHealth scores in full from healthExpiryHorizonDays out, dropping linearly to 0 at expiry
+func expiryHealth(domain types.Domain, now time.Time) HealthFactor {
+	days := int(domain.ExpiresAt.Sub(now).Hours() / 24)
+	factor := HealthFactor{Name: HealthFactorExpiry, Included: true}
+	switch {
+	case days < 0:
+		factor.Detail = fmt.Sprintf("Expired %d days ago", -days)
+	case days >= healthExpiryHorizonDays:
+		factor.Score = 100
+		factor.Detail = fmt.Sprintf("Expires in %d days", days)
+	default:
+		factor.Score = math.Round(float64(days) * 100 / healthExpiryHorizonDays)
+		factor.Detail = fmt.Sprintf("Expires in %d days", days)
+	}
+	return factor
+}
+
+// httpHealth scores the last website check: 2xx is healthy, redirects nearly so, client
+// errors poorly and server errors or no response not at all
+func httpHealth(domain types.Domain) HealthFactor {
+	factor := HealthFactor{Name: HealthFactorHTTP}
+	if domain.HTTPStatus == nil {
+		factor.Detail = "Website not checked yet"
+		return factor
+	}
+	factor.Included = true
+	code := *domain.HTTPStatus
+	switch {
+	case code >= 200 && code < 300:
+		factor.Score = 100
+	case code >= 300 && code < 400:
+		factor.Score = 90
+	case code >= 400 && code < 500:
+		factor.Score = 30
+	}
+	if code == 0 {
+		factor.Detail = "Website didn't respond"
+	} else {
+		factor.Detail = fmt.Sprintf("Website returned HTTP %d", code)
+	}
+	return factor
+}
+
+// sslHealth scores the last SSL check. Unverified certificates belong to hosts whose TLS
+// verification is deliberately skipped, so they aren't marked down far.
+func sslHealth(domain types.Domain) HealthFactor {
+	factor := HealthFactor{Name: HealthFactorSSL}
+	if domain.SSLStatus == nil || *domain.SSLStatus == "" {
+		factor.Detail = "SSL not checked yet"
+		return factor
+	}
+	factor.Included = true
+	switch *domain.SSLStatus {
+	case "valid":
+		factor.Score = 100
+	case "unverified":
+		factor.Score = 70
+	case "unavailable":
+		factor.Score = 30
+	}
+	factor.Detail = fmt.Sprintf("SSL certificate is %s", *domain.SSLStatus)
+	return factor
+}
+
+// dnsHealth loses 25 points for each issue the DNS analysis found
+func dnsHealth(issues []string) HealthFactor {
+	factor := HealthFactor{Name: HealthFactorDNS}
+	if issues == nil {
+		factor.Detail = "DNS not analyzed"
+		return factor
+	}
+	factor.Included = true
+	factor.Score = math.Max(0, 100-25*float64(len(issues)))
+	if len(issues) == 0 {
+		factor.Detail = "No DNS issues found"
+	} else {
+		factor.Detail = strings.Join(issues, "; ")
+	}
+	return factor
+}
+
+// autoRenewHealth scores a domain that renews itself in full
+func autoRenewHealth(domain types.Domain) HealthFactor {
+	factor := HealthFactor{Name: HealthFactorAutoRenew, Included: true, Detail: "Auto-renew is off"}
+	if domain.AutoRenew {
+		factor.Score = 100
+		factor.Detail = "Auto-renew is on"
+	}
+	return factor
+}
@@ -0,0 +1,202 @@
+package analytics
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rusiqe/domainvault/internal/types"
+)
+
+// History period bounds, in days
+const (
+	DefaultHistoryDays = 90
+	MaxHistoryDays     = 5 * 365
+)
+
+// trendMonths is how many months of snapshots the trend analysis covers
+const trendMonths = 12
+
+// ErrUnknownMetric is returned for a history metric snapshots don't record
+var ErrUnknownMetric = errors.New("unknown metric")
+
+// HistoryPoint is one day's value of a metric
+type HistoryPoint struct {
+	Date  time.Time `json:"date"`
+	Value float64   `json:"value"`
+}
+
+// MetricHistory is a metric's daily series from the portfolio snapshots
+type MetricHistory struct {
+	Metric        string         `json:"metric"`
+	PeriodDays    int            `json:"period_days"`
+	Since         time.Time      `json:"since"`
+	Points        []HistoryPoint `json:"points"`
+	First         float64        `json:"first"`
+	Last          float64        `json:"last"`
+	Change        float64        `json:"change"`
+	ChangePercent *float64       `json:"change_percent,omitempty"` // Nil when the series is empty or starts at 0
+}
+
+// ParseHistoryPeriod parses a period such as "90d", "12w" or "1y" into days. An empty period
+// is DefaultHistoryDays.
+func ParseHistoryPeriod(period string) (int, error) {
+	period = strings.ToLower(strings.TrimSpace(period))
+	if period == "" {
+		return DefaultHistoryDays, nil
+	}
+
+	unit := period[len(period)-1:]
+	multiplier := map[string]int{"d": 1, "w": 7, "y": 365}[unit]
+	if multiplier == 0 {
+		return 0, fmt.Errorf("period must be a number followed by d, w or y, e.g. 90d")
+	}
+	n, err := strconv.Atoi(period[:len(period)-1])
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("period must be a positive number followed by d, w or y, e.g. 90d")
+	}
+	if days := n * multiplier; days <= MaxHistoryDays {
+		return days, nil
+	}
+	return 0, fmt.Errorf("period can be at most %d days", MaxHistoryDays)
+}
+
+// snapshotDate truncates t to the UTC day a snapshot taken at t belongs to
+func snapshotDate(t time.Time) time.Time {
+	y, m, d := t.UTC().Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// TakeSnapshot records today's portfolio figures, replacing any snapshot already taken today
+func (as *AnalyticsService) TakeSnapshot() (*types.PortfolioSnapshot, error) {
+	domains, err := as.domainRepo.GetAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch domains: %w", err)
+	}
+
+	now := time.Now()
+	snapshot := &types.PortfolioSnapshot{
+		SnapshotDate:       snapshotDate(now),
+		TotalDomains:       len(domains),
+		TotalRenewalCost:   math.Round(as.calculateFinancialMetrics(domains).TotalRenewalCost*100) / 100,
+		StatusDistribution: types.StatusCounts{},
+	}
+	for _, domain := range domains {
+		status := domain.Status
+		if status == "" {
+			status = "unknown"
+		}
+		snapshot.StatusDistribution[status]++
+
+		days := domain.ExpiresAt.Sub(now).Hours() / 24
+		if days < 0 {
+			snapshot.ExpiredDomains++
+			continue
+		}
+		snapshot.ActiveDomains++
+		if days <= 30 {
+			snapshot.Expiring30++
+		}
+		if days <= 90 {
+			snapshot.Expiring90++
+		}
+	}
+
+	if err := as.domainRepo.SavePortfolioSnapshot(snapshot); err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+// GetMetricHistory returns a metric's daily values over the last days days
+func (as *AnalyticsService) GetMetricHistory(metric string, days int) (*MetricHistory, error) {
+	if _, ok := (types.PortfolioSnapshot{}).Metric(metric); !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownMetric, metric)
+	}
+
+	since := snapshotDate(time.Now()).AddDate(0, 0, -days)
+	snapshots, err := as.domainRepo.GetPortfolioSnapshots(since)
+	if err != nil {
+		return nil, err
+	}
+
+	history := &MetricHistory{
+		Metric:     metric,
+		PeriodDays: days,
+		Since:      since,
+		Points:     make([]HistoryPoint, 0, len(snapshots)),
+	}
+	for _, snapshot := range snapshots {
+		value, _ := snapshot.Metric(metric)
+		history.Points = append(history.Points, HistoryPoint{Date: snapshot.SnapshotDate, Value: value})
+	}
+
+	if len(history.Points) > 0 {
+		history.First = history.Points[0].Value
+		history.Last = history.Points[len(history.Points)-1].Value
+		history.Change = history.Last - history.First
+		if history.First != 0 {
+			percent := history.Change / history.First * 100
+			history.ChangePercent = &percent
+		}
+	}
+	return history, nil
+}
+
+// calculateTrendAnalysis builds monthly growth and cost trends from the last snapshot of each
+// of the past twelve months. Snapshots cover the whole portfolio, so a scoped user gets none.
+func (as *AnalyticsService) calculateTrendAnalysis(scope *types.AccessScope) TrendAnalysis {
+	trends := TrendAnalysis{
+		DomainGrowth:     []GrowthTrend{},
+		CostTrends:       []CostTrend{},
+		ExpirationTrends: []ExpirationTrend{},
+		StatusTrends:     []StatusTrend{},
+	}
+	if scope != nil {
+		return trends
+	}
+
+	since := snapshotDate(time.Now()).AddDate(0, -trendMonths, 0)
+	snapshots, err := as.domainRepo.GetPortfolioSnapshots(since)
+	if err != nil {
+		log.Printf("Trend analysis unavailable: %v", err)
+		return trends
+	}
+
+	// Snapshots are oldest first, so the last one seen for a month is its closing figure
+	var months []string
+	closing := make(map[string]types.PortfolioSnapshot)
+	for _, snapshot := range snapshots {
+		month := snapshot.SnapshotDate.Format("2006-01")
+		if _, seen := closing[month]; !seen {
+			months = append(months, month)
+		}
+		closing[month] = snapshot
+	}
+
+	for i, month := range months {
+		snapshot := closing[month]
+		growth := GrowthTrend{Period: month, DomainCount: snapshot.TotalDomains}
+		cost := CostTrend{Period: month, TotalCost: snapshot.TotalRenewalCost}
+		if snapshot.TotalDomains > 0 {
+			cost.AverageCost = snapshot.TotalRenewalCost / float64(snapshot.TotalDomains)
+		}
+
+		if i > 0 {
+			previous := closing[months[i-1]]
+			growth.NetChange = snapshot.TotalDomains - previous.TotalDomains
+			if previous.TotalDomains > 0 {
+				growth.GrowthRate = float64(growth.NetChange) / float64(previous.TotalDomains) * 100
+			}
+			cost.CostChange = snapshot.TotalRenewalCost - previous.TotalRenewalCost
+		}
+
+		trends.DomainGrowth = append(trends.DomainGrowth, growth)
+		trends.CostTrends = append(trends.CostTrends, cost)
+	}
+	return trends
+}
@@ -0,0 +1,184 @@
+package analytics
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/go-pdf/fpdf"
+	"github.com/rusiqe/domainvault/internal/types"
+)
+
+// FinancialReport is the renewal cost report offered as a downloadable export
+type FinancialReport struct {
+	GeneratedAt            time.Time
+	DomainCount            int
+	TotalRenewalCost       float64
+	AverageRenewalCost     float64
+	RenewalCostNext30Days  float64
+	RenewalCostNext90Days  float64
+	CostByProvider         []ReportLine // Highest cost first
+	CostByCategory         []ReportLine // Highest cost first, categories by name
+	MonthlyRenewalSchedule []ReportLine // Chronological, labelled YYYY-MM
+}
+
+// ReportLine is a labelled amount in a report section
+type ReportLine struct {
+	Label  string
+	Amount float64
+}
+
+// GetFinancialReport builds the renewal cost report from the current portfolio, limited to
+// scope's domains unless it's nil
+func (as *AnalyticsService) GetFinancialReport(scope *types.AccessScope) (*FinancialReport, error) {
+	domains, err := as.scopedDomains(scope)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch domains: %w", err)
+	}
+	metrics := as.calculateFinancialMetrics(domains)
+
+	// Costs are grouped by category ID; show names where the category still exists
+	categoryNames := map[string]string{}
+	if categories, err := as.domainRepo.GetAllCategories(); err == nil {
+		for _, category := range categories {
+			categoryNames[category.ID] = category.Name
+		}
+	}
+	byCategory := make(map[string]float64, len(metrics.CostByCategory))
+	for id, cost := range metrics.CostByCategory {
+		label := id
+		if name, ok := categoryNames[id]; ok {
+			label = name
+		}
+		byCategory[label] += cost
+	}
+
+	schedule := reportLines(metrics.MonthlyRenewalSchedule)
+	sort.Slice(schedule, func(i, j int) bool { return schedule[i].Label < schedule[j].Label })
+
+	return &FinancialReport{
+		GeneratedAt:            time.Now(),
+		DomainCount:            len(domains),
+		TotalRenewalCost:       metrics.TotalRenewalCost,
+		AverageRenewalCost:     metrics.AverageRenewalCost,
+		RenewalCostNext30Days:  metrics.RenewalCostNext30Days,
+		RenewalCostNext90Days:  metrics.RenewalCostNext90Days,
+		CostByProvider:         sortedByAmount(reportLines(metrics.CostByProvider)),
+		CostByCategory:         sortedByAmount(reportLines(byCategory)),
+		MonthlyRenewalSchedule: schedule,
+	}, nil
+}
+
+// reportLines converts a cost map into report lines
+func reportLines(costs map[string]float64) []ReportLine {
+	lines := make([]ReportLine, 0, len(costs))
+	for label, amount := range costs {
+		lines = append(lines, ReportLine{Label: label, Amount: amount})
+	}
+	return lines
+}
+
+// sortedByAmount orders lines by amount descending, then label
+func sortedByAmount(lines []ReportLine) []ReportLine {
+	sort.Slice(lines, func(i, j int) bool {
+		if lines[i].Amount != lines[j].Amount {
+			return lines[i].Amount > lines[j].Amount
+		}
+		return lines[i].Label < lines[j].Label
+	})
+	return lines
+}
+
+// summaryLines returns the headline figures in display order
+func (r *FinancialReport) summaryLines() []ReportLine {
+	return []ReportLine{
+		{Label: "Total annual renewal cost", Amount: r.TotalRenewalCost},
+		{Label: "Average renewal cost per domain", Amount: r.AverageRenewalCost},
+		{Label: "Renewals due in the next 30 days", Amount: r.RenewalCostNext30Days},
+		{Label: "Renewals due in the next 90 days", Amount: r.RenewalCostNext90Days},
+	}
+}
+
+// CSV renders the report as section,item,amount rows
+func (r *FinancialReport) CSV() ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	rows := [][]string{
+		{"section", "item", "amount"},
+		{"report", "generated_at", r.GeneratedAt.UTC().Format(time.RFC3339)},
+		{"report", "domains", fmt.Sprintf("%d", r.DomainCount)},
+	}
+	sections := []struct {
+		name  string
+		lines []ReportLine
+	}{
+		{"summary", r.summaryLines()},
+		{"provider", r.CostByProvider},
+		{"category", r.CostByCategory},
+		{"month", r.MonthlyRenewalSchedule},
+	}
+	for _, section := range sections {
+		for _, line := range section.lines {
+			rows = append(rows, []string{section.name, line.Label, fmt.Sprintf("%.2f", line.Amount)})
+		}
+	}
+
+	if err := w.WriteAll(rows); err != nil {
+		return nil, fmt.Errorf("failed to write CSV report: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// PDF renders the report as an A4 document with one table per section
+func (r *FinancialReport) PDF() ([]byte, error) {
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetTitle("DomainVault Renewal Cost Report", true)
+	pdf.SetAutoPageBreak(true, 15)
+	pdf.AddPage()
+
+	pdf.SetFont("Helvetica", "B", 18)
+	pdf.CellFormat(0, 10, "Renewal Cost Report", "", 1, "L", false, 0, "")
+	pdf.SetFont("Helvetica", "", 10)
+	pdf.CellFormat(0, 6, fmt.Sprintf("Generated %s - %d domains", r.GeneratedAt.UTC().Format("2 January 2006 15:04 MST"), r.DomainCount), "", 1, "L", false, 0, "")
+	pdf.Ln(4)
+
+	r.pdfTable(pdf, "Summary", "", r.summaryLines())
+	r.pdfTable(pdf, "Cost by provider", "Provider", r.CostByProvider)
+	r.pdfTable(pdf, "Cost by category", "Category", r.CostByCategory)
+	r.pdfTable(pdf, "Monthly renewal schedule", "Month", r.MonthlyRenewalSchedule)
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, fmt.Errorf("failed to render PDF report: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// pdfTable writes a section heading and a two-column label/amount table
+func (r *FinancialReport) pdfTable(pdf *fpdf.Fpdf, title, labelHeader string, lines []ReportLine) {
+	const labelWidth, amountWidth, rowHeight = 130.0, 50.0, 7.0
+	tr := pdf.UnicodeTranslatorFromDescriptor("") // Core fonts are cp1252; map names with accents
+
+	pdf.SetFont("Helvetica", "B", 13)
+	pdf.CellFormat(0, 9, title, "", 1, "L", false, 0, "")
+
+	if labelHeader != "" {
+		pdf.SetFont("Helvetica", "B", 10)
+		pdf.SetFillColor(230, 230, 230)
+		pdf.CellFormat(labelWidth, rowHeight, labelHeader, "1", 0, "L", true, 0, "")
+		pdf.CellFormat(amountWidth, rowHeight, "Amount", "1", 1, "R", true, 0, "")
+	}
+
+	pdf.SetFont("Helvetica", "", 10)
+	if len(lines) == 0 {
+		pdf.CellFormat(labelWidth+amountWidth, rowHeight, "No data", "1", 1, "L", false, 0, "")
+	}
+	for _, line := range lines {
+		pdf.CellFormat(labelWidth, rowHeight, tr(line.Label), "1", 0, "L", false, 0, "")
+		pdf.CellFormat(amountWidth, rowHeight, fmt.Sprintf("%.2f", line.Amount), "1", 1, "R", false, 0, "")
+	}
+	pdf.Ln(5)
+}
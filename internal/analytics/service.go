@@ -13,7 +13,8 @@ import (
 
 // AnalyticsService provides domain portfolio analytics
 type AnalyticsService struct {
-	domainRepo storage.DomainRepository
+	domainRepo    storage.DomainRepository
+	healthWeights map[string]float64 // Weight per health factor, nil for DefaultHealthWeights
 }
 
 // NewAnalyticsService creates a new analytics service
@@ -44,7 +45,8 @@ type OverviewMetrics struct {
 	ExpiredDomains      int       `json:"expired_domains"`
 	DomainsExpiring30   int       `json:"domains_expiring_30"`
 	DomainsExpiring7    int       `json:"domains_expiring_7"`
-	AverageAge          float64   `json:"average_age_days"`
+	AverageAge          float64   `json:"average_age_days"` // Over domains with a known registration date
+	DomainsWithAge      int       `json:"domains_with_age"` // Domains whose registration date is known
 	OldestDomain        string    `json:"oldest_domain"`
 	NewestDomain        string    `json:"newest_domain"`
 	LastSyncTime        time.Time `json:"last_sync_time"`
@@ -290,9 +292,10 @@ type Recommendation struct {
 	CreatedAt   time.Time `json:"created_at"`
 }
 
-// GetPortfolioMetrics generates comprehensive portfolio analytics
-func (as *AnalyticsService) GetPortfolioMetrics() (*PortfolioMetrics, error) {
-	domains, err := as.domainRepo.GetAll()
+// GetPortfolioMetrics generates comprehensive portfolio analytics. scope limits them to a
+// user's domains, nil for the whole portfolio.
+func (as *AnalyticsService) GetPortfolioMetrics(scope *types.AccessScope) (*PortfolioMetrics, error) {
+	domains, err := as.scopedDomains(scope)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch domains: %w", err)
 	}
@@ -304,7 +307,7 @@ func (as *AnalyticsService) GetPortfolioMetrics() (*PortfolioMetrics, error) {
 		ProviderAnalysis:   as.calculateProviderAnalysis(domains),
 		CategoryAnalysis:   as.calculateCategoryAnalysis(domains),
 		StatusMetrics:      as.calculateStatusMetrics(domains),
-		TrendAnalysis:      as.calculateTrendAnalysis(domains),
+		TrendAnalysis:      as.calculateTrendAnalysis(scope),
 		RiskAssessment:     as.calculateRiskAssessment(domains),
 		Recommendations:    as.generateRecommendations(domains),
 		LastUpdated:        time.Now(),
@@ -313,6 +316,14 @@ func (as *AnalyticsService) GetPortfolioMetrics() (*PortfolioMetrics, error) {
 	return metrics, nil
 }
 
+// scopedDomains returns the visible domains in scope, or every visible domain when scope is nil
+func (as *AnalyticsService) scopedDomains(scope *types.AccessScope) ([]types.Domain, error) {
+	if scope == nil {
+		return as.domainRepo.GetAll()
+	}
+	return as.domainRepo.GetByFilter(types.DomainFilter{Scope: scope})
+}
+
 // calculateOverviewMetrics calculates basic portfolio overview
 func (as *AnalyticsService) calculateOverviewMetrics(domains []types.Domain) OverviewMetrics {
 	now := time.Now()
@@ -321,23 +332,23 @@ func (as *AnalyticsService) calculateOverviewMetrics(domains []types.Domain) Ove
 	expiring30 := 0
 	expiring7 := 0
 	totalAge := 0.0
+	withAge := 0
 	oldestDomain := ""
 	newestDomain := ""
-	oldestDate := now
-	newestDate := time.Time{}
+	oldestAge := 0.0
+	newestAge := 0.0
 
 	for _, domain := range domains {
-		age := now.Sub(domain.CreatedAt).Hours() / 24
-		totalAge += age
-
-		if domain.CreatedAt.Before(oldestDate) {
-			oldestDate = domain.CreatedAt
-			oldestDomain = domain.Name
-		}
-
-		if domain.CreatedAt.After(newestDate) {
-			newestDate = domain.CreatedAt
-			newestDomain = domain.Name
+		// Age counts from registration; CreatedAt is only when we first stored the domain
+		if age, ok := domain.AgeDays(now); ok {
+			totalAge += age
+			withAge++
+			if oldestDomain == "" || age > oldestAge {
+				oldestAge, oldestDomain = age, domain.Name
+			}
+			if newestDomain == "" || age < newestAge {
+				newestAge, newestDomain = age, domain.Name
+			}
 		}
 
 		daysUntilExpiry := int(domain.ExpiresAt.Sub(now).Hours() / 24)
@@ -356,8 +367,8 @@ func (as *AnalyticsService) calculateOverviewMetrics(domains []types.Domain) Ove
 	}
 
 	averageAge := 0.0
-	if len(domains) > 0 {
-		averageAge = totalAge / float64(len(domains))
+	if withAge > 0 {
+		averageAge = totalAge / float64(withAge)
 	}
 
 	return OverviewMetrics{
@@ -367,6 +378,7 @@ func (as *AnalyticsService) calculateOverviewMetrics(domains []types.Domain) Ove
 		DomainsExpiring30:   expiring30,
 		DomainsExpiring7:    expiring7,
 		AverageAge:          averageAge,
+		DomainsWithAge:      withAge,
 		OldestDomain:        oldestDomain,
 		NewestDomain:        newestDomain,
 		LastSyncTime:        now,
@@ -553,8 +565,7 @@ func (as *AnalyticsService) estimateDomainValue(domain types.Domain) float64 {
 	}
 
 	// Age factor
-	age := time.Since(domain.CreatedAt).Hours() / 24 / 365
-	if age > 5 {
+	if age, ok := domain.AgeDays(time.Now()); ok && age > 5*365 {
 		baseValue *= 1.5
 	}
 
@@ -578,8 +589,7 @@ func (as *AnalyticsService) getValuationFactors(domain types.Domain) []string {
 		factors = append(factors, "Premium TLD (.com)")
 	}
 
-	age := time.Since(domain.CreatedAt).Hours() / 24 / 365
-	if age > 5 {
+	if age, ok := domain.AgeDays(time.Now()); ok && age > 5*365 {
 		factors = append(factors, "Established domain (5+ years)")
 	}
 
@@ -592,8 +602,53 @@ func (as *AnalyticsService) getValuationFactors(domain types.Domain) []string {
 
 // Placeholder methods for other calculations
 func (as *AnalyticsService) calculateExpirationAnalysis(domains []types.Domain) ExpirationAnalysis {
-	// Implementation would calculate expiration patterns, critical domains, etc.
-	return ExpirationAnalysis{}
+	// Implementation would calculate expiration patterns, etc.
+	return ExpirationAnalysis{
+		CriticalDomains: as.criticalDomains(domains),
+	}
+}
+
+// criticalExpiryDays is how close to expiry a domain must be to count as critical
+const criticalExpiryDays = 7
+
+// criticalDomains lists the domains that need renewing now: those in their grace period first,
+// longest expired first, then those expiring within criticalExpiryDays, soonest first
+func (as *AnalyticsService) criticalDomains(domains []types.Domain) []ExpiringDomain {
+	now := time.Now()
+	critical := []ExpiringDomain{}
+	for _, domain := range domains {
+		days := int(math.Floor(domain.ExpiresAt.Sub(now).Hours() / 24))
+		risk := "high"
+		switch {
+		case domain.Status == types.DomainStatusGracePeriod:
+			risk = types.DomainStatusGracePeriod
+		case domain.ExpiresAt.IsZero() || days < 0 || days > criticalExpiryDays:
+			continue
+		}
+
+		cost := as.estimateRenewalCost(domain)
+		if domain.RenewalPrice != nil {
+			cost = *domain.RenewalPrice
+		}
+		critical = append(critical, ExpiringDomain{
+			DomainName:      domain.Name,
+			ExpiresAt:       domain.ExpiresAt,
+			DaysUntilExpiry: days,
+			RenewalCost:     cost,
+			AutoRenew:       domain.AutoRenew,
+			Provider:        domain.Provider,
+			RiskLevel:       risk,
+		})
+	}
+
+	sort.SliceStable(critical, func(i, j int) bool {
+		gi, gj := critical[i].RiskLevel == types.DomainStatusGracePeriod, critical[j].RiskLevel == types.DomainStatusGracePeriod
+		if gi != gj {
+			return gi
+		}
+		return critical[i].ExpiresAt.Before(critical[j].ExpiresAt)
+	})
+	return critical
 }
 
 func (as *AnalyticsService) calculateProviderAnalysis(domains []types.Domain) ProviderAnalysis {
@@ -608,12 +663,34 @@ func (as *AnalyticsService) calculateCategoryAnalysis(domains []types.Domain) Ca
 
 func (as *AnalyticsService) calculateStatusMetrics(domains []types.Domain) StatusMetrics {
 	// Implementation would analyze uptime, response times, status trends
-	return StatusMetrics{}
+	return StatusMetrics{
+		ProblematicDomains: as.slaProblems(domains),
+	}
 }
 
-func (as *AnalyticsService) calculateTrendAnalysis(domains []types.Domain) TrendAnalysis {
-	// Implementation would analyze historical trends
-	return TrendAnalysis{}
+// slaProblems lists the domains whose average response time exceeds their SLA; twice the
+// threshold or more is high impact
+func (as *AnalyticsService) slaProblems(domains []types.Domain) []ProblematicDomain {
+	sla := types.DefaultResponseTimeSLA()
+	if err := as.domainRepo.GetSetting(types.SettingResponseTimeSLA, &sla); err == nil {
+		if err := sla.Validate(); err != nil {
+			sla = types.DefaultResponseTimeSLA()
+		}
+	}
+
+	problems := []ProblematicDomain{}
+	for _, breach := range sla.Breaches(domains) {
+		impact := "medium"
+		if breach.ResponseTime >= 2*breach.ThresholdMS {
+			impact = "high"
+		}
+		problems = append(problems, ProblematicDomain{
+			DomainName:  breach.DomainName,
+			Issue:       fmt.Sprintf("Average response time %dms exceeds the %dms SLA", breach.ResponseTime, breach.ThresholdMS),
+			ImpactLevel: impact,
+		})
+	}
+	return problems
 }
 
 func (as *AnalyticsService) calculateRiskAssessment(domains []types.Domain) RiskAssessment {
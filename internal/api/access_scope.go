@@ -0,0 +1,198 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rusiqe/domainvault/internal/storage"
+	"github.com/rusiqe/domainvault/internal/types"
+)
+
+// scopeCheckedRoutes change several domains named in the request body; scoped users may call
+// them because their handlers check each domain against the scope with checkDomainScope
+var scopeCheckedRoutes = map[string]bool{
+	"POST /api/v1/domains/bulk-visibility":         true,
+	"POST /api/v1/admin/domains/bulk-renew":        true,
+	"POST /api/v1/admin/domains/bulk-decommission": true,
+	"POST /api/v1/admin/domains/bulk-check-status": true,
+	"POST /api/v1/admin/monitoring/bulk-pause":     true,
+	"POST /api/v1/admin/monitoring/bulk-resume":    true,
+	"POST /api/v1/admin/dns/bulk/ip":               true,
+	"POST /api/v1/admin/dns/bulk/ipv6":             true,
+	"POST /api/v1/admin/dns/bulk/nameservers":      true,
+	"POST /api/v1/admin/dns/bulk/csv":              true,
+}
+
+// portfolioWideReads report on the whole portfolio and can't be narrowed to an access scope,
+// so scoped users may not call them
+var portfolioWideReads = map[string]bool{
+	"GET /api/v1/admin/analytics/security":  true,
+	"GET /api/v1/admin/analytics/history":   true,
+	"GET /api/v1/admin/monitoring/stats":    true, // UptimeRobot account totals
+	"GET /api/v1/admin/monitoring/monitors": true, // Every monitor on the UptimeRobot account
+	"GET /api/v1/monitoring/stats":          true,
+}
+
+// requestScope returns the access scope of the request's user, or nil when the user may reach
+// every domain or the request isn't authenticated
+func requestScope(c *gin.Context) *types.AccessScope {
+	if user, ok := c.Get("user"); ok {
+		if u, ok := user.(*types.User); ok {
+			return u.DomainScope()
+		}
+	}
+	return nil
+}
+
+// checkDomainScope returns ErrOutOfScope if the request's user may not reach domain
+func checkDomainScope(c *gin.Context, domain *types.Domain) error {
+	if scope := requestScope(c); scope != nil && !scope.Allows(*domain) {
+		return fmt.Errorf("%w: %s", types.ErrOutOfScope, domain.Name)
+	}
+	return nil
+}
+
+// scopeDomains drops the domains the request's user may not reach
+func scopeDomains(c *gin.Context, domains []types.Domain) []types.Domain {
+	return domainsInScope(requestScope(c), domains)
+}
+
+// domainsInScope drops the domains outside scope; a nil scope keeps them all
+func domainsInScope(scope *types.AccessScope, domains []types.Domain) []types.Domain {
+	if scope == nil {
+		return domains
+	}
+	inScope := make([]types.Domain, 0, len(domains))
+	for _, domain := range domains {
+		if scope.Allows(domain) {
+			inScope = append(inScope, domain)
+		}
+	}
+	return inScope
+}
+
+// scopeDomainRefs marks the resolved domains the request's user may not reach as failed
+func scopeDomainRefs(c *gin.Context, results []domainRefResult) []domainRefResult {
+	for i := range results {
+		if results[i].Err == nil {
+			results[i].Err = checkDomainScope(c, results[i].Domain)
+		}
+	}
+	return results
+}
+
+// DomainScopeMiddleware limits users with an access scope to their domains. It runs after
+// AuthMiddleware and rejects requests without a user, so a missing token never means "every
+// domain". Routes naming a domain, DNS record or project are allowed only when it's in scope;
+// other reads are allowed, with results filtered by their handlers, except portfolioWideReads;
+// other changes are allowed only on scopeCheckedRoutes, since a scoped user can't make
+// portfolio-wide changes.
+func DomainScopeMiddleware(repo storage.DomainRepository) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if user, _ := c.Get("user"); user == nil {
+			abortWithError(c, http.StatusUnauthorized, types.CodeUnauthorized, "Authentication required")
+			return
+		}
+		scope := requestScope(c)
+		if scope == nil {
+			c.Next()
+			return
+		}
+		if err := authorizeScopedRoute(c, repo, *scope); err != nil {
+			status, code := errorStatus(err, http.StatusInternalServerError)
+			abortWithError(c, status, code, err.Error())
+			return
+		}
+		c.Next()
+	}
+}
+
+// authorizeScopedRoute checks the request's route against a user's access scope
+func authorizeScopedRoute(c *gin.Context, repo storage.DomainRepository, scope types.AccessScope) error {
+	route := c.FullPath()
+	switch {
+	case strings.Contains(route, "/domains/:id"):
+		if err := authorizeScopedDomain(repo, scope, c.Param("id")); err != nil {
+			return err
+		}
+		if strings.Contains(route, ":sourceId") {
+			return authorizeScopedDomain(repo, scope, c.Param("sourceId"))
+		}
+		return nil
+	case strings.HasPrefix(route, "/api/v1/admin/dns/:id"):
+		record, err := repo.GetRecordByID(c.Param("id"))
+		if err != nil {
+			return nil // The handler reports the missing record
+		}
+		return authorizeScopedDomain(repo, scope, record.DomainID)
+	case strings.Contains(route, "/projects/:id"):
+		if !scope.AllowsProject(c.Param("id")) {
+			return fmt.Errorf("%w: project %s", types.ErrOutOfScope, c.Param("id"))
+		}
+		return nil
+	}
+
+	if portfolioWideReads[c.Request.Method+" "+route] {
+		return fmt.Errorf("%w: this report covers the whole portfolio", types.ErrOutOfScope)
+	}
+	switch c.Request.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return nil
+	}
+	if scopeCheckedRoutes[c.Request.Method+" "+route] {
+		return nil
+	}
+	return fmt.Errorf("%w: only users without an access scope can make portfolio-wide changes", types.ErrOutOfScope)
+}
+
+// authorizeScopedDomain checks the domain with id against scope. Hidden domains aren't
+// returned by GetByID, so a domain it can't find is allowed only if it's a hidden domain in
+// scope; the handler then reports it as it normally does.
+func authorizeScopedDomain(repo storage.DomainRepository, scope types.AccessScope, id string) error {
+	domain, err := repo.GetByID(id)
+	if err == types.ErrDomainNotFound {
+		allowed, err := scopedDomainIDs(repo, scope)
+		if err != nil {
+			return err
+		}
+		if !allowed[id] {
+			return fmt.Errorf("%w: domain %s", types.ErrOutOfScope, id)
+		}
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if !scope.Allows(*domain) {
+		return fmt.Errorf("%w: %s", types.ErrOutOfScope, domain.Name)
+	}
+	return nil
+}
+
+// scopedDomainIDs returns the IDs of every domain in scope, hidden ones included
+func scopedDomainIDs(repo storage.DomainRepository, scope types.AccessScope) (map[string]bool, error) {
+	domains, err := repo.GetByFilter(types.DomainFilter{IncludeHidden: true, Scope: &scope})
+	if err != nil {
+		return nil, err
+	}
+	ids := make(map[string]bool, len(domains))
+	for _, domain := range domains {
+		ids[domain.ID] = true
+	}
+	return ids, nil
+}
+
+// scopedDomainNames returns the normalized names of every domain in scope, hidden ones included
+func scopedDomainNames(repo storage.DomainRepository, scope types.AccessScope) (map[string]bool, error) {
+	domains, err := repo.GetByFilter(types.DomainFilter{IncludeHidden: true, Scope: &scope})
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[string]bool, len(domains))
+	for _, domain := range domains {
+		names[types.NormalizeDomainName(domain.Name)] = true
+	}
+	return names, nil
+}
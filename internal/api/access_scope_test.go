@@ -0,0 +1,188 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rusiqe/domainvault/internal/auth"
+	"github.com/rusiqe/domainvault/internal/storage"
+	"github.com/rusiqe/domainvault/internal/types"
+)
+
+// newScopeTestServer serves the domain and admin routes over a mock repository holding a pair
+// of duplicate domains in scope and a pair out of scope, and returns a token for the scoped editor
+func newScopeTestServer(t *testing.T) (*gin.Engine, string) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	repo := storage.NewMockRepo()
+	expires, checked := time.Now().AddDate(1, 0, 0), time.Now()
+	if _, err := repo.UpsertDomains([]types.Domain{
+		{ID: "acme", Name: "acme.com", Provider: "mock", ExpiresAt: expires, Visible: true, Tags: types.TagsSlice{"client-acme"}, LastStatusCheck: &checked},
+		{ID: "acme-www", Name: "www.acme.com", Provider: "mock", ExpiresAt: expires, Visible: true, Tags: types.TagsSlice{"client-acme"}, LastStatusCheck: &checked},
+		{ID: "other", Name: "other.com", Provider: "mock", ExpiresAt: expires, Visible: true, LastStatusCheck: &checked},
+		{ID: "other-www", Name: "www.other.com", Provider: "mock", ExpiresAt: expires, Visible: true, LastStatusCheck: &checked},
+	}); err != nil {
+		t.Fatalf("UpsertDomains() error: %v", err)
+	}
+
+	hash, err := auth.HashPassword("editor-password")
+	if err != nil {
+		t.Fatalf("HashPassword() error: %v", err)
+	}
+	editor := &types.User{
+		Username:     "editor",
+		Email:        "editor@example.com",
+		PasswordHash: hash,
+		Role:         types.RoleEditor,
+		Enabled:      true,
+		Scope:        types.AccessScope{Tags: types.TagsSlice{"client-acme"}},
+	}
+	if err := repo.CreateUser(editor); err != nil {
+		t.Fatalf("CreateUser() error: %v", err)
+	}
+	authSvc := auth.NewAuthService(repo)
+	login, err := authSvc.Login("editor", "editor-password")
+	if err != nil {
+		t.Fatalf("Login() error: %v", err)
+	}
+
+	r := gin.New()
+	handler := NewDomainHandler(repo, nil, nil, nil)
+	handler.SetAuthService(authSvc)
+	handler.RegisterRoutes(r)
+	NewAdminHandler(repo, authSvc, nil, nil, nil, nil, nil, nil, nil, nil).RegisterAdminRoutes(r)
+	return r, login.Token
+}
+
+func serveScopeRequest(r *gin.Engine, method, path, token string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, nil)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestDomainScope_RequiresAuthentication(t *testing.T) {
+	r, _ := newScopeTestServer(t)
+
+	tests := []struct {
+		method string
+		path   string
+	}{
+		{http.MethodGet, "/api/v1/domains"},
+		{http.MethodGet, "/api/v1/domains/other"},
+		{http.MethodPut, "/api/v1/domains/other"},
+		{http.MethodDelete, "/api/v1/domains/other"},
+		{http.MethodPut, "/api/v1/credentials/cred-1"},
+		{http.MethodDelete, "/api/v1/credentials/cred-1"},
+		{http.MethodPost, "/api/v1/domains/bulk-visibility"},
+		{http.MethodPost, "/api/v1/admin/dns/bulk/ip"},
+		{http.MethodGet, "/api/v1/admin/domains/duplicates"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			if w := serveScopeRequest(r, tt.method, tt.path, ""); w.Code != http.StatusUnauthorized {
+				t.Errorf("status without a token = %d, want %d", w.Code, http.StatusUnauthorized)
+			}
+		})
+	}
+
+	if w := serveScopeRequest(r, http.MethodGet, "/api/v1/health", ""); w.Code != http.StatusOK {
+		t.Errorf("health check without a token = %d, want %d", w.Code, http.StatusOK)
+	}
+}
+
+func TestDomainScope_ScopedUser(t *testing.T) {
+	r, token := newScopeTestServer(t)
+
+	forbidden := []struct {
+		method string
+		path   string
+	}{
+		{http.MethodGet, "/api/v1/domains/other"},
+		{http.MethodPut, "/api/v1/domains/other"},
+		{http.MethodDelete, "/api/v1/credentials/cred-1"},
+		{http.MethodGet, "/api/v1/admin/analytics/history"},
+		{http.MethodGet, "/api/v1/admin/analytics/security"},
+		{http.MethodGet, "/api/v1/admin/monitoring/stats"},
+		{http.MethodGet, "/api/v1/admin/monitoring/monitors"},
+		{http.MethodGet, "/api/v1/monitoring/stats"},
+	}
+	for _, tt := range forbidden {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			if w := serveScopeRequest(r, tt.method, tt.path, token); w.Code != http.StatusForbidden {
+				t.Errorf("status = %d, want %d: %s", w.Code, http.StatusForbidden, w.Body)
+			}
+		})
+	}
+
+	w := serveScopeRequest(r, http.MethodGet, "/api/v1/domains", token)
+	var list struct {
+		Domains []types.Domain `json:"domains"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &list); err != nil || w.Code != http.StatusOK {
+		t.Fatalf("GET /domains = %d %s", w.Code, w.Body)
+	}
+	for _, domain := range list.Domains {
+		if domain.ID == "other" || domain.ID == "other-www" {
+			t.Errorf("GET /domains listed out-of-scope domain %s", domain.Name)
+		}
+	}
+	if len(list.Domains) != 2 {
+		t.Errorf("GET /domains listed %d domains, want 2", len(list.Domains))
+	}
+
+	w = serveScopeRequest(r, http.MethodGet, "/api/v1/admin/domains/duplicates", token)
+	var duplicates struct {
+		Count int `json:"count"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &duplicates); err != nil || w.Code != http.StatusOK {
+		t.Fatalf("GET /admin/domains/duplicates = %d %s", w.Code, w.Body)
+	}
+	if duplicates.Count != 1 {
+		t.Errorf("GET /admin/domains/duplicates found %d groups, want only the in-scope one: %s", duplicates.Count, w.Body)
+	}
+
+	w = serveScopeRequest(r, http.MethodGet, "/api/v1/admin/status/summary", token)
+	var summary struct {
+		TotalChecked int `json:"total_checked"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &summary); err != nil || w.Code != http.StatusOK {
+		t.Fatalf("GET /admin/status/summary = %d %s", w.Code, w.Body)
+	}
+	if summary.TotalChecked != 2 {
+		t.Errorf("GET /admin/status/summary counted %d checked domains, want only the 2 in scope", summary.TotalChecked)
+	}
+}
+
+func TestDomainScope_CalendarFeed(t *testing.T) {
+	r, token := newScopeTestServer(t)
+
+	w := serveScopeRequest(r, http.MethodPost, "/api/v1/auth/calendar-token", token)
+	var issued struct {
+		Feed string `json:"feed"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &issued); err != nil || w.Code != http.StatusOK {
+		t.Fatalf("POST /auth/calendar-token = %d %s", w.Code, w.Body)
+	}
+
+	w = serveScopeRequest(r, http.MethodGet, issued.Feed, "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("GET %s = %d %s", issued.Feed, w.Code, w.Body)
+	}
+	feed := w.Body.String()
+	if !strings.Contains(feed, "acme.com") {
+		t.Error("Expected the feed to include the in-scope domains")
+	}
+	if strings.Contains(feed, "other.com") {
+		t.Error("Expected the feed to leave out the out-of-scope domains")
+	}
+}
@@ -1,9 +1,16 @@
 package api
 
 import (
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"errors"
 	"fmt"
+	"html"
+	"io"
 	"log"
 	"net/http"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -13,6 +20,7 @@ import (
 	"github.com/rusiqe/domainvault/internal/auth"
 	"github.com/rusiqe/domainvault/internal/core"
 	"github.com/rusiqe/domainvault/internal/dns"
+	"github.com/rusiqe/domainvault/internal/jobs"
 	"github.com/rusiqe/domainvault/internal/notifications"
 	"github.com/rusiqe/domainvault/internal/providers"
 	"github.com/rusiqe/domainvault/internal/security"
@@ -34,6 +42,16 @@ type AdminHandler struct {
 	notificationSvc  *notifications.NotificationService
 	securitySvc      *security.SecurityService
 	uptimeRobotSvc  *uptimerobot.Service
+	watchlistSvc     *core.WatchlistService
+	danglingDNS      *core.DanglingDNSMonitor
+	settingsSvc      *core.SettingsService
+	requireVerification bool // Refuse to decommission domains without proven ownership
+	dnsMaxRecords    int // Most DNS records one listing returns, 0 for defaultDNSMaxRecords
+	purchaseEvents   bool // Emit domain.purchased and domain.purchase_failed events
+	lookupLimits     LookupLimits
+	requestLimits    RequestLimits
+	resetThrottle    *resetThrottle // Rate limits ForgotPassword per IP address and account
+	jobs             *jobs.Queue
 }
 
 // NewAdminHandler creates a new admin handler
@@ -47,6 +65,7 @@ func NewAdminHandler(
 	notificationSvc *notifications.NotificationService,
 	securitySvc *security.SecurityService,
 	uptimeRobotSvc *uptimerobot.Service,
+	jobQueue *jobs.Queue,
 ) *AdminHandler {
 	return &AdminHandler{
 		domainRepo:       domainRepo,
@@ -59,9 +78,58 @@ func NewAdminHandler(
 		notificationSvc:  notificationSvc,
 		securitySvc:      securitySvc,
 		uptimeRobotSvc:   uptimeRobotSvc,
+		resetThrottle:    newResetThrottle(),
+		jobs:             jobQueue,
 	}
 }
 
+// SetStatusChecker replaces the default status checker, e.g. with one that goes through a proxy
+func (h *AdminHandler) SetStatusChecker(checker *status.StatusChecker) {
+	h.statusChecker = checker
+}
+
+// SetWatchlistService enables the domain acquisition watchlist endpoints
+func (h *AdminHandler) SetWatchlistService(svc *core.WatchlistService) {
+	h.watchlistSvc = svc
+}
+
+// SetDanglingDNSMonitor enables the dangling DNS security report
+func (h *AdminHandler) SetDanglingDNSMonitor(monitor *core.DanglingDNSMonitor) {
+	h.danglingDNS = monitor
+}
+
+// SetLookupLimits sets how bulk availability lookups are batched
+func (h *AdminHandler) SetLookupLimits(limits LookupLimits) {
+	h.lookupLimits = limits
+}
+
+// SetRequestLimits sets how many items one bulk request may name
+func (h *AdminHandler) SetRequestLimits(limits RequestLimits) {
+	h.requestLimits = limits
+}
+
+// SetSettingsService enables the runtime settings endpoints
+func (h *AdminHandler) SetSettingsService(svc *core.SettingsService) {
+	h.settingsSvc = svc
+}
+
+// SetRequireVerification makes operations that give up a domain, like decommissioning, refuse
+// domains whose ownership hasn't been verified
+func (h *AdminHandler) SetRequireVerification(required bool) {
+	h.requireVerification = required
+}
+
+// SetDNSMaxRecords caps how many DNS records one listing returns; 0 or less uses the default
+func (h *AdminHandler) SetDNSMaxRecords(max int) {
+	h.dnsMaxRecords = max
+}
+
+// SetPurchaseEvents turns the domain.purchased and domain.purchase_failed events on purchases
+// on or off
+func (h *AdminHandler) SetPurchaseEvents(enabled bool) {
+	h.purchaseEvents = enabled
+}
+
 // RegisterAdminRoutes sets up the admin HTTP routes
 func (h *AdminHandler) RegisterAdminRoutes(r *gin.Engine) {
 	// Public authentication routes
@@ -69,30 +137,82 @@ func (h *AdminHandler) RegisterAdminRoutes(r *gin.Engine) {
 	{
 		authRoutes.POST("/login", h.Login)
 		authRoutes.POST("/logout", h.Logout)
+		authRoutes.POST("/forgot-password", h.ForgotPassword)
+		authRoutes.POST("/reset-password", h.ResetPassword)
+		authRoutes.GET("/login-attempts", h.GetLoginAttemptStatus)
+		authRoutes.POST("/calendar-token", auth.AuthMiddleware(h.authSvc), h.CreateCalendarToken)
+	}
+
+	// Calendar feeds authenticate with a ?token= query parameter since calendar clients can't send headers
+	r.GET("/api/v1/domains/calendar.ics", h.GetExpiryCalendar)
+
+	// UptimeRobot alert webhooks authenticate with a shared ?token= in the alert contact's URL
+	r.POST("/api/v1/webhooks/uptimerobot", h.ReceiveUptimeRobotWebhook)
+
+	// Saved views are private to their owner, so every role, viewers included, manages its own
+	views := r.Group("/api/v1/views")
+	views.Use(auth.AuthMiddleware(h.authSvc))
+	{
+		views.GET("", h.ListSavedViews)
+		views.POST("", h.CreateSavedView)
+		views.GET("/:id", h.GetSavedView)
+		views.PUT("/:id", h.UpdateSavedView)
+		views.DELETE("/:id", h.DeleteSavedView)
 	}
 
-// Admin routes (authentication temporarily disabled for development)
+	// Admin routes require a session; what each role may do is enforced per route
 	admin := r.Group("/api/v1/admin")
+	admin.Use(auth.AuthMiddleware(h.authSvc), auth.RequirePermission(h.auditPermissionDenied), DomainScopeMiddleware(h.domainRepo))
 	{
+		// User management
+		admin.PUT("/users/:id/role", h.SetUserRole)
+		admin.PUT("/users/:id/scope", h.SetUserScope)
+
 		// Domain management
 		admin.GET("/domains/:id/details", h.GetDomainDetails)
+		admin.GET("/domains/duplicates", h.GetDuplicateDomains)
+		admin.POST("/domains/merge", h.MergeDomains)
 		admin.PUT("/domains/:id", h.UpdateDomain)
-		admin.POST("/domains/bulk-purchase", h.BulkPurchaseDomains)
+		admin.POST("/domains/bulk-purchase", IdempotencyMiddleware(h.domainRepo), h.BulkPurchaseDomains)
+		admin.POST("/domains/bulk-renew", IdempotencyMiddleware(h.domainRepo), h.BulkRenewDomains)
 		admin.POST("/domains/bulk-decommission", h.BulkDecommissionDomains)
 		admin.POST("/domains/bulk-sync", h.BulkSyncDomains)
+		admin.POST("/domains/import-csv", h.ImportDomainsCSV)
+		admin.POST("/domains/:id/sync-autorenew", h.SyncDomainAutoRenew)
+		admin.POST("/domains/:id/verify", h.RequestDomainVerification)
+		admin.POST("/domains/:id/verify/check", h.CheckDomainVerification)
+		admin.GET("/domains/:id/related", h.GetRelatedDomains)
+		admin.GET("/domains/:id/contacts", h.GetDomainContacts)
+		admin.PUT("/domains/:id/contacts", h.UpdateDomainContacts)
 
 		// DNS management
 		admin.GET("/domains/:id/dns", h.GetDomainDNS)
+		admin.POST("/domains/:id/dns/refresh", h.RefreshDomainDNS)
 		admin.POST("/domains/:id/dns", h.CreateDNSRecord)
 		admin.PUT("/domains/:id/dns", h.BulkUpdateDNS)
+		admin.GET("/domains/:id/dns/history", h.GetDNSHistory)
+		admin.GET("/domains/:id/dns/backups", h.ListDNSBackups)
+		admin.POST("/domains/:id/dns/backups/:backupId/restore", h.RestoreDNSBackup)
+		admin.GET("/domains/:id/dns/health", h.GetDNSHealth)
+		admin.GET("/domains/:id/dns/compare", h.CompareDomainDNS)
+		admin.GET("/domains/:id/nameserver-history", h.GetNameserverHistory)
+		admin.GET("/domains/:id/dns/zone", h.ExportDNSZone)
+		admin.POST("/domains/:id/dns/zone", h.ImportDNSZone)
+		admin.POST("/domains/:id/dns/clone-from/:sourceId", h.CloneDNSFrom)
 		admin.PUT("/dns/:id", h.UpdateDNSRecord)
 		admin.DELETE("/dns/:id", h.DeleteDNSRecord)
 		admin.GET("/dns/templates", h.GetDNSTemplates)
+		admin.GET("/dns/inventory", h.GetDNSInventory)
+		admin.GET("/domains/:id/email-setup", h.GetEmailSetup)
+		admin.GET("/domains/:id/spf/analyze", h.AnalyzeSPF)
 		
 		// Bulk DNS operations
 		admin.POST("/dns/bulk/ip", h.BulkAssignIP)
+		admin.POST("/dns/bulk/ipv6", h.BulkAssignIPv6)
 		admin.POST("/dns/bulk/nameservers", h.BulkUpdateNameservers)
 		admin.POST("/dns/bulk/csv", h.BulkUpdateFromCSV)
+		admin.POST("/dns/bulk/pattern", h.BulkUpdateDNSByPattern)
+		admin.POST("/dns/bulk/delete", h.BulkDeleteDNSRecords)
 
 		// Category management
 		admin.GET("/categories", h.ListCategories)
@@ -100,20 +220,32 @@ func (h *AdminHandler) RegisterAdminRoutes(r *gin.Engine) {
 		admin.PUT("/categories/:id", h.UpdateCategory)
 		admin.DELETE("/categories/:id", h.DeleteCategory)
 
+		// Automatic categorization rules
+		admin.GET("/categorization-rules", h.ListCategorizationRules)
+		admin.POST("/categorization-rules", h.CreateCategorizationRule)
+		admin.PUT("/categorization-rules/:id", h.UpdateCategorizationRule)
+		admin.DELETE("/categorization-rules/:id", h.DeleteCategorizationRule)
+		admin.POST("/domains/recategorize", h.RecategorizeDomains)
+
 		// Project management
 		admin.GET("/projects", h.ListProjects)
 		admin.POST("/projects", h.CreateProject)
 		admin.PUT("/projects/:id", h.UpdateProject)
 		admin.DELETE("/projects/:id", h.DeleteProject)
+		admin.GET("/projects/:id/dns/export", h.ExportProjectDNS)
+		admin.POST("/projects/:id/dns/import", h.ImportProjectDNS)
 
 		// Provider management
 		admin.GET("/providers/supported", h.ListSupportedProviders)
 		admin.GET("/providers/connected", h.ListConnectedProviders)
+		admin.GET("/providers/:name/capabilities", h.GetProviderCapabilities)
 		admin.GET("/providers/connected/:id", h.GetConnectedProvider)
+		admin.GET("/providers/connected/:id/domains", h.GetConnectedProviderDomains)
 		admin.PUT("/providers/connected/:id", h.UpdateConnectedProvider)
 		admin.DELETE("/providers/connected/:id", h.RemoveConnectedProvider)
 		admin.POST("/providers/connect", h.ConnectProvider)
 		admin.POST("/providers/test", h.TestProviderConnection)
+		admin.POST("/providers/test-all", h.TestAllProviderConnections)
 		admin.POST("/providers/:id/sync", h.SyncProviderByID)
 		admin.POST("/providers/sync-all", h.SyncAllConnectedProviders)
 		admin.GET("/providers/auto-sync/status", h.GetAutoSyncStatus)
@@ -122,6 +254,7 @@ func (h *AdminHandler) RegisterAdminRoutes(r *gin.Engine) {
 		
 		// Provider credentials management
 		admin.GET("/credentials", h.ListCredentials)
+		admin.GET("/credentials/export", h.ExportCredentials)
 		admin.POST("/credentials", h.CreateCredentials)
 		admin.PUT("/credentials/:id", h.UpdateCredentials)
 		admin.DELETE("/credentials/:id", h.DeleteCredentials)
@@ -129,6 +262,7 @@ func (h *AdminHandler) RegisterAdminRoutes(r *gin.Engine) {
 		// Advanced sync operations
 		admin.POST("/sync/manual", h.ManualSync)
 		admin.GET("/sync/providers", h.GetSupportedProviders)
+		admin.GET("/sync/last-report", h.GetLastSyncReport)
 
 		// Status checking
 		admin.POST("/domains/:id/check-status", h.CheckDomainStatus)
@@ -136,17 +270,32 @@ func (h *AdminHandler) RegisterAdminRoutes(r *gin.Engine) {
 		admin.GET("/status/summary", h.GetStatusSummary)
 		admin.POST("/domains/:id/check-website-status", h.CheckWebsiteStatus)
 		admin.POST("/domains/bulk-check-website-status", h.BulkCheckWebsiteStatus)
+		admin.POST("/domains/check-expiring", h.CheckExpiringDomains)
 
 		// Domain search and purchase
 		admin.POST("/domains/search", h.SearchDomains)
-		admin.POST("/domains/purchase", h.PurchaseDomains)
+		admin.POST("/domains/purchase", IdempotencyMiddleware(h.domainRepo), h.PurchaseDomains)
 		admin.GET("/domains/purchase-providers", h.GetPurchaseProviders)
 
-		// Analytics and reporting
-		admin.GET("/analytics/portfolio", h.GetPortfolioAnalytics)
-		admin.GET("/analytics/financial", h.GetFinancialAnalytics)
-		admin.GET("/analytics/security", h.GetSecurityAnalytics)
-		admin.GET("/analytics/trends", h.GetTrendAnalytics)
+		// Acquisition watchlist
+		admin.GET("/watchlist", h.ListWatchlist)
+		admin.POST("/watchlist", h.AddToWatchlist)
+		admin.PUT("/watchlist/:id", h.UpdateWatchlistEntry)
+		admin.DELETE("/watchlist/:id", h.RemoveFromWatchlist)
+		admin.POST("/watchlist/:id/check", h.CheckWatchlistEntry)
+		admin.POST("/domains/availability", h.SearchAvailability)
+
+		// Registry expiry reconciliation
+		admin.GET("/registry-expiry", h.GetRegistryExpiries)
+
+		// Analytics and reporting (large, frequently re-fetched responses)
+		analytics := admin.Group("/analytics", ETagMiddleware())
+		analytics.GET("/portfolio", h.GetPortfolioAnalytics)
+		analytics.GET("/financial", h.GetFinancialAnalytics)
+		analytics.GET("/financial/report", h.ExportFinancialReport)
+		analytics.GET("/security", h.GetSecurityAnalytics)
+		analytics.GET("/trends", h.GetTrendAnalytics)
+		analytics.GET("/history", h.GetAnalyticsHistory)
 
 		// Notifications and alerts
 		admin.GET("/notifications/rules", h.GetNotificationRules)
@@ -154,6 +303,15 @@ func (h *AdminHandler) RegisterAdminRoutes(r *gin.Engine) {
 		admin.PUT("/notifications/rules/:id", h.UpdateNotificationRule)
 		admin.DELETE("/notifications/rules/:id", h.DeleteNotificationRule)
 		admin.POST("/notifications/test", h.TestNotification)
+		admin.GET("/notifications/templates", h.ListNotificationTemplates)
+		admin.POST("/notifications/templates/preview", h.PreviewNotificationTemplate)
+		admin.PUT("/notifications/templates/:channel/:event", h.SaveNotificationTemplate)
+		admin.DELETE("/notifications/templates/:channel/:event", h.DeleteNotificationTemplate)
+		admin.GET("/webhooks", h.ListWebhookEndpoints)
+		admin.POST("/webhooks", h.CreateWebhookEndpoint)
+		admin.PUT("/webhooks/:id", h.UpdateWebhookEndpoint)
+		admin.DELETE("/webhooks/:id", h.DeleteWebhookEndpoint)
+		admin.POST("/webhooks/:id/rotate-secret", h.RotateWebhookSecret)
 		admin.GET("/alerts", h.GetAlerts)
 		admin.POST("/alerts/:id/resolve", h.ResolveAlert)
 
@@ -163,17 +321,43 @@ func (h *AdminHandler) RegisterAdminRoutes(r *gin.Engine) {
 		admin.GET("/security/alerts", h.GetSecurityAlerts)
 		admin.POST("/security/alerts/:id/resolve", h.ResolveSecurityAlert)
 		admin.GET("/security/sessions", h.GetActiveSessions)
+		admin.GET("/security/locked-accounts", h.GetLockedAccounts)
+		admin.POST("/security/unlock", h.UnlockAccount)
 		admin.DELETE("/security/sessions/:id", h.TerminateSession)
+		admin.GET("/security/dangling-dns", h.GetDanglingDNS)
+		admin.GET("/security/retention", h.GetRetention)
+		admin.POST("/security/retention/cleanup", h.RunRetentionCleanup)
+
+		// Background jobs
+		admin.GET("/jobs", h.ListJobs)
+		admin.GET("/jobs/:id", h.GetJob)
+
+		// Settings
+		admin.GET("/settings", h.GetRuntimeSettings)
+		admin.PUT("/settings", h.UpdateRuntimeSettings)
+		admin.GET("/settings/expiry", h.GetExpirySettings)
+		admin.PUT("/settings/expiry", h.UpdateExpirySettings)
+		admin.GET("/settings/response-time-sla", h.GetResponseTimeSLASettings)
+		admin.PUT("/settings/response-time-sla", h.UpdateResponseTimeSLASettings)
+		admin.GET("/maintenance", h.GetMaintenanceMode)
+		admin.PUT("/maintenance", h.UpdateMaintenanceMode)
+
+		// System health
+		admin.GET("/health/detailed", h.GetDetailedHealth)
 
 		// UptimeRobot monitoring
 		admin.GET("/monitoring/stats", h.GetMonitoringStats)
 		admin.GET("/monitoring/monitors", h.GetMonitors)
 		admin.POST("/monitoring/sync", h.SyncMonitors)
 		admin.POST("/monitoring/create", h.CreateMonitor)
+		admin.POST("/monitoring/bulk-pause", h.BulkPauseMonitoring)
+		admin.POST("/monitoring/bulk-resume", h.BulkResumeMonitoring)
 		admin.PUT("/monitoring/:id", h.UpdateMonitor)
 		admin.DELETE("/monitoring/:id", h.DeleteMonitor)
 		admin.GET("/monitoring/:id/logs", h.GetMonitorLogs)
 		admin.GET("/domains/:id/monitoring", h.GetDomainMonitoring)
+		admin.POST("/domains/:id/monitoring/enable", h.EnableDomainMonitoring)
+		admin.POST("/domains/:id/monitoring/disable", h.DisableDomainMonitoring)
 	}
 }
 
@@ -181,13 +365,26 @@ func (h *AdminHandler) RegisterAdminRoutes(r *gin.Engine) {
 func (h *AdminHandler) Login(c *gin.Context) {
 	var req types.LoginRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Invalid request format")
 		return
 	}
 
+	ip, userAgent := c.ClientIP(), c.GetHeader("User-Agent")
+	if h.securitySvc != nil {
+		if err := h.securitySvc.ValidateLogin(ip, req.Username, userAgent); err != nil {
+			respondWithError(c, http.StatusInternalServerError, err)
+			return
+		}
+	}
+
 	response, err := h.authSvc.Login(req.Username, req.Password)
+	if h.securitySvc != nil {
+		if recordErr := h.securitySvc.RecordLoginAttempt(ip, req.Username, userAgent, err == nil); recordErr != nil {
+			log.Printf("Failed to record login attempt for %s: %v", req.Username, recordErr)
+		}
+	}
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		respondWithError(c, http.StatusUnauthorized, err)
 		return
 	}
 
@@ -215,14 +412,280 @@ func (h *AdminHandler) Logout(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "Logged out successfully"})
 }
 
-// GetDomainDetails retrieves comprehensive domain information including DNS, status, and financial details
+// auditPermissionDenied records an authorization failure as a security violation
+func (h *AdminHandler) auditPermissionDenied(c *gin.Context, user *types.User) {
+	if h.securitySvc == nil {
+		return
+	}
+	h.securitySvc.LogAuditEvent(
+		security.EventSecurityViolation,
+		user.ID,
+		user.Username,
+		c.ClientIP(),
+		c.GetHeader("User-Agent"),
+		c.FullPath(),
+		c.Request.Method,
+		false,
+		map[string]interface{}{
+			"error": "insufficient permissions",
+			"role":  user.Role,
+			"path":  c.Request.URL.Path,
+		},
+		"",
+	)
+}
+
+// SetUserRole changes a user's role (admin only)
+func (h *AdminHandler) SetUserRole(c *gin.Context) {
+	var req types.SetUserRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Invalid request format")
+		return
+	}
+
+	userID := c.Param("id")
+	if current, ok := c.Get("user"); ok {
+		if u, ok := current.(*types.User); ok && u.ID == userID && req.Role != types.RoleAdmin {
+			respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Admins cannot remove their own admin role")
+			return
+		}
+	}
+
+	user, err := h.authSvc.SetUserRole(userID, req.Role)
+	if err != nil {
+		switch err {
+		case types.ErrInvalidRole:
+			respondError(c, http.StatusBadRequest, types.CodeValidationFailed, "Role must be one of admin, editor, viewer")
+		case types.ErrDomainNotFound:
+			respondError(c, http.StatusNotFound, types.CodeNotFound, "User not found")
+		default:
+			respondWithError(c, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	if h.securitySvc != nil {
+		h.securitySvc.LogAuditEvent(security.EventSettingsChange, userID, user.Username, c.ClientIP(),
+			c.GetHeader("User-Agent"), "user", "set_role", true, map[string]interface{}{"role": req.Role}, "")
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+// SetUserScope limits a non-admin user to the domains of some projects and tags (admin only).
+// Empty lists give the user every domain again.
+func (h *AdminHandler) SetUserScope(c *gin.Context) {
+	var req types.SetUserScopeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Invalid request format")
+		return
+	}
+
+	scope := types.AccessScope{ProjectIDs: req.ProjectIDs, Tags: types.TagsSlice(req.Tags)}
+	for _, projectID := range scope.ProjectIDs {
+		if _, err := h.domainRepo.GetProjectByID(projectID); err != nil {
+			respondError(c, http.StatusBadRequest, types.CodeValidationFailed, fmt.Sprintf("Project %s not found", projectID))
+			return
+		}
+	}
+
+	userID := c.Param("id")
+	user, err := h.authSvc.SetUserScope(userID, scope)
+	if err != nil {
+		if err == types.ErrDomainNotFound {
+			respondError(c, http.StatusNotFound, types.CodeNotFound, "User not found")
+			return
+		}
+		respondWithError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	if h.securitySvc != nil {
+		h.securitySvc.LogAuditEvent(security.EventSettingsChange, userID, user.Username, c.ClientIP(),
+			c.GetHeader("User-Agent"), "user", "set_scope", true,
+			map[string]interface{}{"project_ids": user.Scope.ProjectIDs, "tags": user.Scope.Tags}, "")
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+// requestActor names who is making a request, for audit trails: the logged-in username when
+// auth middleware ran, otherwise the caller's IP address
+func requestActor(c *gin.Context) string {
+	if user, ok := c.Get("user"); ok {
+		if u, ok := user.(*types.User); ok && u != nil {
+			return u.Username
+		}
+	}
+	if userID, ok := c.Get("userID"); ok {
+		return fmt.Sprintf("user:%v", userID)
+	}
+	return "api:" + c.ClientIP()
+}
+
+// ForgotPassword emails a time-limited password reset link. The response is the same whether
+// or not the account exists so the endpoint can't be used to enumerate users. Requests are
+// limited per client IP, and an account is sent at most one link per cooldown.
+func (h *AdminHandler) ForgotPassword(c *gin.Context) {
+	var req types.ForgotPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Invalid request format")
+		return
+	}
+	identifier := strings.TrimSpace(req.Username)
+	if identifier == "" {
+		identifier = strings.TrimSpace(req.Email)
+	}
+	if identifier == "" {
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "username or email is required")
+		return
+	}
+
+	if ok, retryAfter := h.resetThrottle.allowIP(c.ClientIP()); !ok {
+		c.Header("Retry-After", strconv.Itoa(int(retryAfter/time.Second)+1))
+		respondError(c, http.StatusTooManyRequests, types.CodeRateLimited, "Too many password reset requests, try again later")
+		return
+	}
+
+	response := gin.H{"message": "If the account exists, a password reset link has been sent to its email address"}
+
+	// Answer as usual but don't issue another token, which would also invalidate the link just sent
+	if !h.resetThrottle.allowAccount(identifier) {
+		h.securitySvc.LogAuditEvent(security.EventPasswordResetRequest, "", identifier, c.ClientIP(), c.Request.UserAgent(),
+			"auth", "forgot_password", false, map[string]interface{}{"error": "reset requested again within the cooldown"}, "")
+		c.JSON(http.StatusOK, response)
+		return
+	}
+
+	user, token, err := h.authSvc.RequestPasswordReset(identifier)
+	if err != nil {
+		h.securitySvc.LogAuditEvent(security.EventPasswordResetRequest, "", identifier, c.ClientIP(), c.Request.UserAgent(),
+			"auth", "forgot_password", false, map[string]interface{}{"error": err.Error()}, "")
+		c.JSON(http.StatusOK, response)
+		return
+	}
+
+	link := h.authSvc.PasswordResetLink(token)
+	body := fmt.Sprintf(`<p>Hello %s,</p>
+<p>A password reset was requested for your DomainVault account. Use the link below within %s to choose a new password:</p>
+<p><a href="%s">%s</a></p>
+<p>If you didn't request this, you can ignore this email; your password won't change.</p>`,
+		html.EscapeString(user.Username), h.authSvc.PasswordResetTTL(), html.EscapeString(link), html.EscapeString(link))
+
+	sendErr := h.notificationSvc.SendEmail([]string{user.Email}, "[DomainVault] Password reset", body)
+	if sendErr != nil {
+		log.Printf("Failed to send password reset email to user %s: %v", user.Username, sendErr)
+	}
+
+	details := map[string]interface{}{"email_sent": sendErr == nil}
+	h.securitySvc.LogAuditEvent(security.EventPasswordResetRequest, user.ID, user.Username, c.ClientIP(), c.Request.UserAgent(),
+		"auth", "forgot_password", true, details, "")
+
+	c.JSON(http.StatusOK, response)
+}
+
+// ResetPassword sets a new password using a reset token and signs the user out everywhere
+func (h *AdminHandler) ResetPassword(c *gin.Context) {
+	var req types.ResetPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Invalid request format")
+		return
+	}
+
+	// Check policy before spending the token so a weak password can be retried
+	if err := h.securitySvc.ValidatePassword(req.NewPassword); err != nil {
+		respondWithError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	user, err := h.authSvc.ResetPassword(req.Token, req.NewPassword)
+	if user == nil {
+		h.securitySvc.LogAuditEvent(security.EventPasswordReset, "", "", c.ClientIP(), c.Request.UserAgent(),
+			"auth", "reset_password", false, map[string]interface{}{"error": err.Error()}, "")
+		respondWithError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	details := map[string]interface{}{"sessions_revoked": err == nil}
+	if err != nil {
+		log.Printf("Password reset for user %s: %v", user.Username, err)
+	}
+	h.securitySvc.LogAuditEvent(security.EventPasswordReset, user.ID, user.Username, c.ClientIP(), c.Request.UserAgent(),
+		"auth", "reset_password", true, details, "")
+
+	c.JSON(http.StatusOK, gin.H{"message": "Password has been reset; please log in with your new password"})
+}
+
+// CreateCalendarToken issues a read-only calendar feed token for the logged-in user
+func (h *AdminHandler) CreateCalendarToken(c *gin.Context) {
+	user, ok := c.MustGet("user").(*types.User)
+	if !ok {
+		respondError(c, http.StatusUnauthorized, types.CodeUnauthorized, "User not authenticated")
+		return
+	}
+
+	token, err := h.authSvc.GenerateCalendarToken(user.ID)
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"token":   token,
+		"feed":    "/api/v1/domains/calendar.ics?token=" + token,
+		"message": "Store this token now; it replaces any previous calendar token and cannot be shown again",
+	})
+}
+
+// GetExpiryCalendar serves an iCalendar feed with one event per visible domain in the token user's
+// access scope on its expiry date.
+// Reminder alarms default to the configured reminder_days; override with ?reminder_days=30,7.
+func (h *AdminHandler) GetExpiryCalendar(c *gin.Context) {
+	user, err := h.authSvc.ValidateCalendarToken(c.Query("token"))
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, types.CodeUnauthorized, "Invalid calendar token")
+		return
+	}
+
+	reminderDays := loadExpiryThresholds(h.domainRepo).ReminderDays
+	if param := c.Query("reminder_days"); param != "" {
+		reminderDays = nil
+		for _, part := range strings.Split(param, ",") {
+			days, err := strconv.Atoi(strings.TrimSpace(part))
+			if err != nil || days < 0 {
+				respondError(c, http.StatusBadRequest, types.CodeValidationFailed, "reminder_days must be a comma-separated list of non-negative integers")
+				return
+			}
+			reminderDays = append(reminderDays, days)
+		}
+	}
+
+	// GetAll applies the same visibility filtering as the domain list
+	domains, err := h.domainRepo.GetAll()
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, types.CodeInternal, "Failed to load domains")
+		return
+	}
+	// The feed is fetched without a session, so the token's user sets the access scope
+	domains = domainsInScope(user.DomainScope(), domains)
+
+	c.Header("Content-Disposition", `inline; filename="domainvault-expiry.ics"`)
+	c.Data(http.StatusOK, "text/calendar; charset=utf-8", buildExpiryCalendar(domains, reminderDays, time.Now()))
+}
+
+// renewalPriceHistoryLimit is how many renewal price changes the domain details include
+const renewalPriceHistoryLimit = 20
+
+// GetDomainDetails retrieves comprehensive domain information including DNS, status, and financial details.
+// With ?live=true it also runs fresh HTTP status, SSL certificate and DNS health checks and includes
+// them under "live", so one call shows the domain as it is right now.
 func (h *AdminHandler) GetDomainDetails(c *gin.Context) {
 	id := c.Param("id")
 	forceProvider := strings.ToLower(strings.TrimSpace(c.Query("force_provider")))
 	domainParam := strings.TrimSpace(c.Query("domain"))
 
 	if id == "" && domainParam == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Domain ID or domain query parameter required"})
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Domain ID or domain query parameter required")
 		return
 	}
 
@@ -240,9 +703,9 @@ func (h *AdminHandler) GetDomainDetails(c *gin.Context) {
 		domain, err = h.domainRepo.GetByID(id)
 		if err != nil {
 			if err == types.ErrDomainNotFound {
-				c.JSON(http.StatusNotFound, gin.H{"error": "Domain not found"})
+				respondError(c, http.StatusNotFound, types.CodeDomainNotFound, "Domain not found")
 			} else {
-				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				respondWithError(c, http.StatusInternalServerError, err)
 			}
 			return
 		}
@@ -253,13 +716,21 @@ func (h *AdminHandler) GetDomainDetails(c *gin.Context) {
 	var dnsRecords []types.DNSRecord
 	var dnsSource string
 
-	// Helper to fetch from a provider by name
+	// Helper to fetch from a provider by name. Reads of portfolio domains go through the DNS
+	// cache, which record edits invalidate.
 	fetchFrom := func(providerName string) bool {
 		client, ok := h.providerSvc.GetClientByProviderName(providerName)
 		if !ok {
 			return false
 		}
-		records, err := client.FetchDNSRecords(domainName)
+		fetch := func() ([]types.DNSRecord, error) { return client.FetchDNSRecords(domainName) }
+		var records []types.DNSRecord
+		var err error
+		if domainParam == "" {
+			records, err = h.dnsSvc.ProviderRecords(domain.ID, providerName, fetch)
+		} else {
+			records, err = fetch()
+		}
 		if err == nil {
 			dnsRecords = records
 			dnsSource = providerName
@@ -290,6 +761,10 @@ func (h *AdminHandler) GetDomainDetails(c *gin.Context) {
 		}
 		dnsSource = "database"
 	}
+	var dnsSync *dns.SyncState
+	if dnsSource == "database" {
+		dnsSync = h.dnsSyncStatus(id, dnsRecords)
+	}
 
 	// Organize DNS records by type for easier display
 	dnsRecordsByType := make(map[string][]types.DNSRecord)
@@ -304,11 +779,22 @@ func (h *AdminHandler) GetDomainDetails(c *gin.Context) {
 	renewalStatus := "active"
 	if daysUntilExpiration < 0 {
 		renewalStatus = "expired"
-	} else if daysUntilExpiration <= 30 {
+	} else if daysUntilExpiration <= loadExpiryThresholds(h.domainRepo).ExpiringSoonDays {
 		renewalStatus = "expiring_soon"
 	} else if daysUntilExpiration <= 90 {
 		renewalStatus = "expiring_within_90_days"
 	}
+	sla := loadResponseTimeSLA(h.domainRepo)
+
+	// Renewal price changes seen by syncs, oldest first; a domain outside the portfolio has none
+	priceHistory := []types.RenewalPriceChange{}
+	if domainParam == "" {
+		if history, err := h.domainRepo.GetRenewalPriceHistory(domain.ID, renewalPriceHistoryLimit); err != nil {
+			log.Printf("Failed to get renewal price history for %s: %v", domain.Name, err)
+		} else if len(history) > 0 {
+			priceHistory = history
+		}
+	}
 
 	// Get category and project names if available
 	var categoryName, projectName string
@@ -335,6 +821,7 @@ func (h *AdminHandler) GetDomainDetails(c *gin.Context) {
 			"provider":         domain.Provider,
 			"expires_at":       domain.ExpiresAt,
 			"created_at":       domain.CreatedAt,
+			"registered_at":    domain.RegisteredAt,
 			"updated_at":       domain.UpdatedAt,
 			"category_id":      domain.CategoryID,
 			"category_name":    categoryName,
@@ -343,7 +830,10 @@ func (h *AdminHandler) GetDomainDetails(c *gin.Context) {
 			"auto_renew":       domain.AutoRenew,
 			"renewal_price":    domain.RenewalPrice,
 			"status":           domain.Status,
+			"registrar_status": domain.RegistrarStatuses(),
+			"registrar_status_concerns": domain.RegistrarStatusConcerns(),
 			"tags":             domain.Tags,
+			"metadata":         domain.Metadata,
 		},
 		"renewal_info": gin.H{
 			"days_until_expiration": daysUntilExpiration,
@@ -351,6 +841,7 @@ func (h *AdminHandler) GetDomainDetails(c *gin.Context) {
 			"expires_at":            domain.ExpiresAt.Format("2006-01-02 15:04:05"),
 			"renewal_price":         domain.RenewalPrice,
 			"auto_renew_enabled":    domain.AutoRenew,
+			"price_history":         priceHistory,
 		},
 		"http_status": gin.H{
 			"status_code":       domain.HTTPStatus,
@@ -361,6 +852,8 @@ func (h *AdminHandler) GetDomainDetails(c *gin.Context) {
 			"uptime_robot_monitor_id": domain.UptimeRobotMonitorID,
 			"uptime_ratio":            domain.UptimeRatio,
 			"response_time":           domain.ResponseTime,
+			"response_time_sla_ms":    sla.ThresholdFor(domain.Name),
+			"sla_breached":            sla.Breached(*domain),
 			"monitor_status":          domain.MonitorStatus,
 			"last_downtime":           domain.LastDowntime,
 		},
@@ -369,16 +862,40 @@ func (h *AdminHandler) GetDomainDetails(c *gin.Context) {
 			"by_type":      dnsRecordsByType,
 			"all_records":  dnsRecords,
 			"source":       dnsSource,
+			"sync":         dnsSync,
 		},
 	}
 
 	// Add DNS summary inspired by intodns.com
 	dnsSummary := h.generateDNSSummary(dnsRecordsByType, domain.Name)
 	response["dns_analysis"] = dnsSummary
+	if h.analyticsSvc != nil && domainParam == "" {
+		response["health"] = h.analyticsSvc.DomainHealth(*domain, dnsAnalysisIssues(dnsSummary), time.Now())
+	}
+	if c.Query("live") == "true" {
+		response["live"] = h.liveDomainChecks(c.Request.Context(), domainName)
+	}
 
 	c.JSON(http.StatusOK, response)
 }
 
+// dnsAnalysisIssues lists the warnings and errors of the DNS summary sections that make up its
+// overall status, e.g. "nameservers: No nameserver records found"
+func dnsAnalysisIssues(summary gin.H) []string {
+	issues := []string{}
+	for _, section := range []string{"nameservers", "a_records", "mx_records", "txt_records"} {
+		sectionData, ok := summary[section].(gin.H)
+		if !ok {
+			continue
+		}
+		if status, _ := sectionData["status"].(string); status == "warning" || status == "error" {
+			message, _ := sectionData["message"].(string)
+			issues = append(issues, section+": "+message)
+		}
+	}
+	return issues
+}
+
 // generateDNSSummary creates a DNS analysis summary similar to intodns.com
 func (h *AdminHandler) generateDNSSummary(recordsByType map[string][]types.DNSRecord, domainName string) gin.H {
 	summary := gin.H{
@@ -394,7 +911,7 @@ func (h *AdminHandler) generateDNSSummary(recordsByType map[string][]types.DNSRe
 		},
 		"mx_records": gin.H{
 			"status": "unknown",
-			"records": []string{},
+			"records": []dns.MXEntry{},
 			"count": 0,
 		},
 		"txt_records": gin.H{
@@ -451,6 +968,13 @@ func (h *AdminHandler) generateDNSSummary(recordsByType map[string][]types.DNSRe
 			"count": len(aValues),
 			"message": fmt.Sprintf("Found %d A record(s)", len(aValues)),
 		}
+	} else if hasApexAlias(recordsByType, domainName) {
+		summary["a_records"] = gin.H{
+			"status":  "info",
+			"records": []string{},
+			"count":   0,
+			"message": "No A records found; the apex is served through an ALIAS record",
+		}
 	} else {
 		summary["a_records"] = gin.H{
 			"status": "warning",
@@ -460,26 +984,27 @@ func (h *AdminHandler) generateDNSSummary(recordsByType map[string][]types.DNSRe
 		}
 	}
 
-	// Analyze MX records
+	// Analyze MX records in the order senders try them
 	if mxRecords, exists := recordsByType["MX"]; exists {
-		var mxValues []string
-		for _, record := range mxRecords {
-			mxValue := record.Value
-			if record.Priority != nil {
-				mxValue = fmt.Sprintf("%d %s", *record.Priority, record.Value)
-			}
-			mxValues = append(mxValues, mxValue)
+		mx := dns.AnalyzeMX(mxRecords)
+		sectionStatus := "ok"
+		if len(mx.Warnings) > 0 {
+			sectionStatus = "warning"
 		}
 		summary["mx_records"] = gin.H{
-			"status": "ok",
-			"records": mxValues,
-			"count": len(mxValues),
-			"message": fmt.Sprintf("Found %d MX record(s)", len(mxValues)),
+			"status": sectionStatus,
+			"records": mx.Records,
+			"primary": mx.Primary,
+			"warnings": mx.Warnings,
+			"count": len(mx.Records),
+			"message": fmt.Sprintf("Found %d MX record(s); primary: %s", len(mx.Records), strings.Join(mx.Primary, ", ")),
 		}
 	} else {
 		summary["mx_records"] = gin.H{
 			"status": "info",
-			"records": []string{},
+			"records": []dns.MXEntry{},
+			"primary": []string{},
+			"warnings": []string{},
 			"count": 0,
 			"message": "No MX records found (no email configured)",
 		}
@@ -541,11 +1066,17 @@ func (h *AdminHandler) generateDNSSummary(recordsByType map[string][]types.DNSRe
 		for _, record := range cnameRecords {
 			cnameValues = append(cnameValues, fmt.Sprintf("%s -> %s", record.Name, record.Value))
 		}
+		sectionStatus := "ok"
+		message := fmt.Sprintf("Found %d CNAME record(s)", len(cnameValues))
+		if hasApexRecord(cnameRecords, domainName) {
+			sectionStatus = "warning"
+			message = "A CNAME at the zone apex conflicts with the NS and MX records there; use an ALIAS record instead"
+		}
 		summary["cname_records"] = gin.H{
-			"status": "ok",
+			"status": sectionStatus,
 			"records": cnameValues,
 			"count": len(cnameValues),
-			"message": fmt.Sprintf("Found %d CNAME record(s)", len(cnameValues)),
+			"message": message,
 		}
 	} else {
 		summary["cname_records"] = gin.H{
@@ -556,6 +1087,27 @@ func (h *AdminHandler) generateDNSSummary(recordsByType map[string][]types.DNSRe
 		}
 	}
 
+	// Analyze ALIAS records (CNAME-like targets the DNS host flattens, usable at the apex)
+	if aliasRecords, exists := recordsByType["ALIAS"]; exists {
+		aliasValues := []string{}
+		for _, record := range aliasRecords {
+			aliasValues = append(aliasValues, fmt.Sprintf("%s -> %s", record.Name, record.Value))
+		}
+		summary["alias_records"] = gin.H{
+			"status":  "ok",
+			"records": aliasValues,
+			"count":   len(aliasValues),
+			"message": fmt.Sprintf("Found %d ALIAS record(s); the DNS host answers them with the target's addresses", len(aliasValues)),
+		}
+	} else {
+		summary["alias_records"] = gin.H{
+			"status":  "info",
+			"records": []string{},
+			"count":   0,
+			"message": "No ALIAS records found",
+		}
+	}
+
 	// Analyze AAAA records (IPv6)
 	if aaaaRecords, exists := recordsByType["AAAA"]; exists {
 		var aaaaValues []string
@@ -577,6 +1129,109 @@ func (h *AdminHandler) generateDNSSummary(recordsByType map[string][]types.DNSRe
 		}
 	}
 
+	// Analyze CAA records (which certificate authorities may issue)
+	if caaRecords, exists := recordsByType["CAA"]; exists {
+		policy := dns.AnalyzeCAA(caaRecords)
+		message := fmt.Sprintf("Certificates may be issued by: %s", strings.Join(policy.Issuers, ", "))
+		if policy.IssuanceBlocked && len(policy.Issuers) == 0 {
+			message = "CAA forbids certificate issuance by any CA"
+		} else if len(policy.Issuers) == 0 {
+			message = "CAA records do not authorize any CA for standard certificates"
+		}
+		summary["caa_records"] = gin.H{
+			"status":           "ok",
+			"count":            len(caaRecords),
+			"authorized_cas":   policy.Issuers,
+			"wildcard_cas":     policy.WildcardIssuers,
+			"report_uris":      policy.ReportURIs,
+			"issuance_blocked": policy.IssuanceBlocked,
+			"message":          message,
+		}
+	} else {
+		summary["caa_records"] = gin.H{
+			"status":         "info",
+			"count":          0,
+			"authorized_cas": []string{},
+			"message":        "No CAA records found (any CA may issue certificates)",
+		}
+	}
+
+	// Analyze SRV records (non-HTTP services such as SIP, XMPP or game servers)
+	if srvRecords, exists := recordsByType["SRV"]; exists {
+		srvValues := []string{}
+		services := []string{}
+		warnings := []string{}
+		sectionStatus := "ok"
+		badNames := false
+		// Records are listed per service in the order clients try them
+		byPreference := dns.AnalyzeSRV(srvRecords)
+		for _, service := range byPreference {
+			if svc, err := dns.ParseSRVName(service.Name); err == nil {
+				services = append(services, svc.Service+"/"+svc.Protocol)
+			} else {
+				badNames = true
+			}
+			for _, target := range service.Targets {
+				srvValues = append(srvValues, target.Display)
+			}
+			warnings = append(warnings, service.Warnings...)
+		}
+		message := fmt.Sprintf("Found %d SRV record(s)", len(srvValues))
+		if badNames {
+			message += "; some names are not in the _service._proto form"
+		}
+		if badNames || len(warnings) > 0 {
+			sectionStatus = "warning"
+		}
+		summary["srv_records"] = gin.H{
+			"status":        sectionStatus,
+			"records":       srvValues,
+			"services":      services,
+			"by_preference": byPreference,
+			"warnings":      warnings,
+			"count":         len(srvValues),
+			"message":       message,
+		}
+	} else {
+		summary["srv_records"] = gin.H{
+			"status":        "info",
+			"records":       []string{},
+			"services":      []string{},
+			"by_preference": []dns.SRVServiceAnalysis{},
+			"warnings":      []string{},
+			"count":         0,
+			"message":       "No SRV records found",
+		}
+	}
+
+	// Analyze TLSA records (DANE certificate pinning)
+	if tlsaRecords, exists := recordsByType["TLSA"]; exists {
+		tlsaValues := []string{}
+		sectionStatus := "ok"
+		for _, record := range tlsaRecords {
+			tlsa, err := dns.ParseTLSA(record.Value)
+			if err != nil {
+				sectionStatus = "warning"
+				tlsaValues = append(tlsaValues, fmt.Sprintf("%s: invalid (%v)", record.Name, err))
+				continue
+			}
+			tlsaValues = append(tlsaValues, fmt.Sprintf("%s: %s %s", record.Name, tlsa.UsageName(), tlsa.String()))
+		}
+		summary["tlsa_records"] = gin.H{
+			"status":  sectionStatus,
+			"records": tlsaValues,
+			"count":   len(tlsaRecords),
+			"message": fmt.Sprintf("Found %d TLSA record(s); DANE only takes effect when the zone is DNSSEC-signed", len(tlsaRecords)),
+		}
+	} else {
+		summary["tlsa_records"] = gin.H{
+			"status":  "info",
+			"records": []string{},
+			"count":   0,
+			"message": "No TLSA records found (DANE not configured)",
+		}
+	}
+
 	// Determine overall DNS health status
 	overallStatus := "ok"
 	warningCount := 0
@@ -605,20 +1260,38 @@ func (h *AdminHandler) generateDNSSummary(recordsByType map[string][]types.DNSRe
 		"total_record_types": len(recordsByType),
 		"warnings": warningCount,
 		"errors": errorCount,
-		"recommendations": h.generateDNSRecommendations(recordsByType),
+		"recommendations": h.generateDNSRecommendations(recordsByType, domainName),
 	}
 
 	return summary
 }
 
+// hasApexAlias reports whether the zone apex is served through an ALIAS record
+func hasApexAlias(recordsByType map[string][]types.DNSRecord, domainName string) bool {
+	return hasApexRecord(recordsByType["ALIAS"], domainName)
+}
+
+// hasApexRecord reports whether any of records is at the zone apex
+func hasApexRecord(records []types.DNSRecord, domainName string) bool {
+	for _, record := range records {
+		if dns.IsApexName(record.Name, domainName) {
+			return true
+		}
+	}
+	return false
+}
+
 // generateDNSRecommendations provides actionable DNS improvement suggestions
-func (h *AdminHandler) generateDNSRecommendations(recordsByType map[string][]types.DNSRecord) []string {
+func (h *AdminHandler) generateDNSRecommendations(recordsByType map[string][]types.DNSRecord, domainName string) []string {
 	recommendations := []string{}
 	
 	// Check for missing essential records
-	if _, hasA := recordsByType["A"]; !hasA {
+	if _, hasA := recordsByType["A"]; !hasA && !hasApexAlias(recordsByType, domainName) {
 		recommendations = append(recommendations, "Add A records to make your domain accessible via IPv4")
 	}
+	if hasApexRecord(recordsByType["CNAME"], domainName) {
+		recommendations = append(recommendations, "Replace the CNAME at the zone apex with an ALIAS record, or A/AAAA records if your provider doesn't support ALIAS")
+	}
 	
 	if _, hasNS := recordsByType["NS"]; !hasNS {
 		recommendations = append(recommendations, "Configure nameserver records for proper DNS resolution")
@@ -674,19 +1347,23 @@ func (h *AdminHandler) generateDNSRecommendations(recordsByType map[string][]typ
 func (h *AdminHandler) UpdateDomain(c *gin.Context) {
 	id := c.Param("id")
 	if id == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Domain ID required"})
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Domain ID required")
 		return
 	}
 
 	var domain types.Domain
 	if err := c.ShouldBindJSON(&domain); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid domain data"})
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Invalid domain data")
+		return
+	}
+	if err := domain.ValidateAnnotations(); err != nil {
+		respondWithError(c, http.StatusBadRequest, err)
 		return
 	}
 
 	domain.ID = id
 	if err := h.domainRepo.Update(&domain); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondWithError(c, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -697,7 +1374,10 @@ func (h *AdminHandler) UpdateDomain(c *gin.Context) {
 func (h *AdminHandler) BulkPurchaseDomains(c *gin.Context) {
 	var req types.DomainPurchaseRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Invalid request format")
+		return
+	}
+	if !checkBulkOperations(c, h.requestLimits, len(req.Domains)) {
 		return
 	}
 
@@ -712,75 +1392,371 @@ func (h *AdminHandler) BulkPurchaseDomains(c *gin.Context) {
 	})
 }
 
-// BulkDecommissionDomains handles bulk domain decommissioning
-func (h *AdminHandler) BulkDecommissionDomains(c *gin.Context) {
-	var req types.DomainDecommissionRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+// GetDuplicateDomains groups domains whose names only differ by case, a trailing dot or a
+// "www." prefix, which happens when the same domain is imported from several providers
+func (h *AdminHandler) GetDuplicateDomains(c *gin.Context) {
+	domains, err := h.domainRepo.GetAll()
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err)
 		return
 	}
+	domains = scopeDomains(c, domains)
 
-	// This would integrate with domain registrar APIs
-	// For now, update the domains in our database
-	successCount := 0
-	var errors []string
+	groups := make(map[string][]types.Domain)
+	var keys []string
+	for _, domain := range domains {
+		key := types.DuplicateKey(domain.Name)
+		if _, seen := groups[key]; !seen {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], domain)
+	}
 
-	for _, domainID := range req.DomainIDs {
-		domain, err := h.domainRepo.GetByID(domainID)
-		if err != nil {
-			errors = append(errors, fmt.Sprintf("Domain %s: %v", domainID, err))
+	duplicates := []gin.H{}
+	for _, key := range keys {
+		group := groups[key]
+		if len(group) < 2 {
 			continue
 		}
 
-		if req.StopAutoRenew {
-			domain.AutoRenew = false
-		}
-		if req.TransferOut {
-			domain.Status = "transferring"
-		}
+		// Oldest first, so the first entry is the natural canonical record
+		sort.Slice(group, func(i, j int) bool { return group[i].CreatedAt.Before(group[j].CreatedAt) })
 
-		if err := h.domainRepo.Update(domain); err != nil {
-			errors = append(errors, fmt.Sprintf("Domain %s: %v", domainID, err))
-			continue
+		reasons := []string{}
+		seen := make(map[string]bool)
+		addReason := func(reason string) {
+			if !seen[reason] {
+				seen[reason] = true
+				reasons = append(reasons, reason)
+			}
+		}
+		for _, domain := range group {
+			if strings.HasSuffix(strings.TrimSpace(domain.Name), ".") {
+				addReason("trailing_dot")
+			}
+			if strings.HasPrefix(types.NormalizeDomainName(domain.Name), "www.") {
+				addReason("www_prefix")
+			}
+			if domain.Name != strings.ToLower(domain.Name) {
+				addReason("case")
+			}
+			if types.NormalizeDomainName(domain.Name) == types.NormalizeDomainName(group[0].Name) && domain.ID != group[0].ID {
+				addReason("same_name")
+			}
 		}
 
-		successCount++
+		duplicates = append(duplicates, gin.H{
+			"normalized_name":        key,
+			"suggested_canonical_id": group[0].ID,
+			"domains":                group,
+			"reasons":                reasons,
+		})
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"message":        "Bulk decommission completed",
-		"processed":      successCount,
-		"total":          len(req.DomainIDs),
-		"errors":         errors,
-		"stop_auto_renew": req.StopAutoRenew,
-		"transfer_out":   req.TransferOut,
+		"groups": duplicates,
+		"count":  len(duplicates),
 	})
 }
 
-// BulkSyncDomains handles manual bulk sync operations
-func (h *AdminHandler) BulkSyncDomains(c *gin.Context) {
-	var req types.BulkSyncRequest
+// MergeDomains consolidates duplicate domains into one canonical record
+func (h *AdminHandler) MergeDomains(c *gin.Context) {
+	var req struct {
+		CanonicalID  string   `json:"canonical_id" binding:"required"`
+		DuplicateIDs []string `json:"duplicate_ids" binding:"required,min=1"`
+		Password     string   `json:"password" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Invalid request format: " + err.Error())
+		return
+	}
+
+	// Verify admin password for security
+	userID, exists := c.Get("userID")
+	if !exists {
+		respondError(c, http.StatusUnauthorized, types.CodeUnauthorized, "User not authenticated")
+		return
+	}
+
+	if !h.authSvc.VerifyCurrentUserPassword(userID.(string), req.Password) {
+		respondError(c, http.StatusUnauthorized, types.CodeUnauthorized, "Invalid password")
+		return
+	}
+
+	canonical, err := h.domainRepo.GetByID(req.CanonicalID)
+	if err != nil {
+		respondError(c, http.StatusNotFound, types.CodeDomainNotFound, "Canonical domain not found")
+		return
+	}
+
+	// Only near-duplicates of the canonical name may be merged into it
+	key := types.DuplicateKey(canonical.Name)
+	for _, id := range req.DuplicateIDs {
+		dup, err := h.domainRepo.GetByID(id)
+		if err != nil {
+			respondError(c, http.StatusNotFound, types.CodeDomainNotFound, fmt.Sprintf("Domain %s not found", id))
+			return
+		}
+		if types.DuplicateKey(dup.Name) != key {
+			respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, fmt.Sprintf("%s is not a duplicate of %s", dup.Name, canonical.Name))
+			return
+		}
+	}
+
+	merged, err := h.domainRepo.MergeDomains(req.CanonicalID, req.DuplicateIDs)
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	log.Printf("Merged %d duplicate domains into %s by user %s", len(req.DuplicateIDs), merged.Name, userID)
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Domains merged successfully",
+		"domain":  merged,
+		"merged":  len(req.DuplicateIDs),
+	})
+}
+
+// BulkRenewDomains renews the given domains, each given by ID or name. References that don't
+// resolve to a domain are reported and the rest are still renewed.
+func (h *AdminHandler) BulkRenewDomains(c *gin.Context) {
+	var req struct {
+		DomainIDs []string `json:"domain_ids" binding:"required,min=1"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Invalid request format")
+		return
+	}
+	if !checkBulkOperations(c, h.requestLimits, len(req.DomainIDs)) {
+		return
+	}
+
+	var ids []string
+	var errors []string
+	seen := make(map[string]bool, len(req.DomainIDs))
+	for _, ref := range scopeDomainRefs(c, resolveDomainRefs(h.domainRepo, req.DomainIDs)) {
+		if ref.Err != nil {
+			errors = append(errors, fmt.Sprintf("Domain %s: %v", ref.Ref, ref.Err))
+			continue
+		}
+		if !seen[ref.Domain.ID] {
+			seen[ref.Domain.ID] = true
+			ids = append(ids, ref.Domain.ID)
+		}
+	}
+	if len(ids) == 0 {
+		respondError(c, http.StatusNotFound, types.CodeDomainNotFound, "No domains found: "+strings.Join(errors, "; "))
+		return
+	}
+
+	if err := h.domainRepo.BulkRenew(ids); err != nil {
+		respondWithError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":       "Domains renewed successfully",
+		"renewed_count": len(ids),
+		"domain_ids":    ids,
+		"errors":        errors,
+	})
+}
+
+// BulkDecommissionDomains handles bulk domain decommissioning; each domain is given by ID or name
+func (h *AdminHandler) BulkDecommissionDomains(c *gin.Context) {
+	var req types.DomainDecommissionRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Invalid request format")
+		return
+	}
+	if !checkBulkOperations(c, h.requestLimits, len(req.DomainIDs)) {
 		return
 	}
 
-	// Start sync in background
-	go func() {
-		if len(req.Providers) > 0 {
-			for _, provider := range req.Providers {
-				h.syncSvc.SyncProvider(provider)
+	// This would integrate with domain registrar APIs
+	// For now, update the domains in our database
+	successCount := 0
+	var errors []string
+	changes := []types.DomainDecommissionChange{}
+
+	for _, domainID := range req.DomainIDs {
+		domain, err := resolveDomainRef(h.domainRepo, domainID)
+		if err == nil {
+			err = checkDomainScope(c, domain)
+		}
+		if err != nil {
+			errors = append(errors, fmt.Sprintf("Domain %s: %v", domainID, err))
+			continue
+		}
+		if h.requireVerification && !domain.IsVerified() {
+			errors = append(errors, fmt.Sprintf("Domain %s: %v", domainID, types.ErrDomainNotVerified))
+			continue
+		}
+
+		if req.DryRun {
+			change, err := h.previewDecommission(*domain, req)
+			if err != nil {
+				errors = append(errors, fmt.Sprintf("Domain %s: %v", domainID, err))
+				continue
 			}
-		} else {
-			h.syncSvc.Run()
+			changes = append(changes, change)
+			successCount++
+			continue
 		}
-	}()
 
-	c.JSON(http.StatusAccepted, gin.H{
+		previous := *domain
+		if req.StopAutoRenew {
+			domain.AutoRenew = false
+		}
+		if req.TransferOut {
+			domain.Status = "transferring"
+		}
+
+		if err := h.domainRepo.Update(domain); err != nil {
+			errors = append(errors, fmt.Sprintf("Domain %s: %v", domainID, err))
+			continue
+		}
+		h.notifyTransitions(previous, *domain)
+
+		successCount++
+	}
+
+	if req.DryRun {
+		c.JSON(http.StatusOK, gin.H{
+			"message":         "Bulk decommission dry run completed, no changes were made",
+			"processed":       successCount,
+			"total":           len(req.DomainIDs),
+			"errors":          errors,
+			"stop_auto_renew": req.StopAutoRenew,
+			"transfer_out":    req.TransferOut,
+			"dry_run":         true,
+			"changes":         changes,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":        "Bulk decommission completed",
+		"processed":      successCount,
+		"total":          len(req.DomainIDs),
+		"errors":         errors,
+		"stop_auto_renew": req.StopAutoRenew,
+		"transfer_out":   req.TransferOut,
+	})
+}
+
+// previewDecommission reports what BulkDecommissionDomains would change on a domain without writing
+func (h *AdminHandler) previewDecommission(domain types.Domain, req types.DomainDecommissionRequest) (types.DomainDecommissionChange, error) {
+	change := types.DomainDecommissionChange{
+		DomainID:        domain.ID,
+		DomainName:      domain.Name,
+		AutoRenewBefore: domain.AutoRenew,
+		AutoRenewAfter:  domain.AutoRenew,
+		StatusBefore:    domain.Status,
+		StatusAfter:     domain.Status,
+	}
+
+	if req.StopAutoRenew {
+		change.AutoRenewAfter = false
+	}
+	if req.TransferOut {
+		change.StatusAfter = "transferring"
+	}
+	if req.DeleteDNS {
+		records, err := h.dnsSvc.GetDomainRecords(domain.ID)
+		if err != nil {
+			return change, fmt.Errorf("failed to count DNS records: %w", err)
+		}
+		change.DNSRecordsToDelete = len(records)
+	}
+
+	return change, nil
+}
+
+// BulkSyncDomains handles manual bulk sync operations
+func (h *AdminHandler) BulkSyncDomains(c *gin.Context) {
+	var req types.BulkSyncRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Invalid request format")
+		return
+	}
+
+	job, ok := enqueueJob(c, h.jobs, "bulk_sync", h.syncProvidersJob(req.Providers))
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusAccepted, jobAccepted(job, gin.H{
 		"message":       "Bulk sync initiated",
 		"providers":     req.Providers,
 		"force_refresh": req.ForceRefresh,
-		"status":        "running",
+	}))
+}
+
+// syncProvidersJob syncs the named providers one after another, or runs a full sync when none
+// are named. The result holds each provider's sync status; the job fails if any provider did.
+func (h *AdminHandler) syncProvidersJob(providerNames []string) jobs.Func {
+	return func(ctx context.Context, tracker *jobs.Tracker) (interface{}, error) {
+		if len(providerNames) == 0 {
+			tracker.SetTotal(1)
+			err := h.syncSvc.Run()
+			tracker.Advance(1, "")
+			return h.syncSvc.GetStatus(), err
+		}
+
+		tracker.SetTotal(len(providerNames))
+		results := make(map[string]core.ProviderStatus, len(providerNames))
+		failed := 0
+		for _, provider := range providerNames {
+			if ctx.Err() != nil {
+				return results, errJobCancelled
+			}
+			if err := h.syncSvc.SyncProvider(provider); err != nil {
+				failed++
+				results[provider] = core.ProviderStatus{Name: provider, State: core.SyncStateError, Error: err.Error()}
+			} else {
+				results[provider] = h.syncSvc.GetStatus().Providers[provider]
+			}
+			tracker.Advance(1, provider)
+		}
+
+		if failed > 0 {
+			return results, fmt.Errorf("%d of %d provider syncs failed", failed, len(providerNames))
+		}
+		return results, nil
+	}
+}
+
+// RefreshDomainDNS re-runs the scheduled DNS refresh for one domain: records are fetched from
+// Cloudflare, falling back to the registrar, and replace the stored ones if they differ
+func (h *AdminHandler) RefreshDomainDNS(c *gin.Context) {
+	domain, err := h.domainRepo.GetByID(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusNotFound, types.CodeDomainNotFound, "Domain not found")
+		return
+	}
+
+	cfClient, _ := h.providerSvc.GetClientByProviderName("cloudflare")
+	regClient, _ := h.providerSvc.GetClientByProviderName(domain.Provider)
+	result, err := h.dnsSvc.RefreshDomain(*domain, cfClient, regClient)
+	if result != nil && result.NameserverChange != nil && h.notificationSvc != nil {
+		h.notificationSvc.NotifyNameserverChange(*domain, *result.NameserverChange)
+	}
+	if err != nil {
+		respondWithErrorDetails(c, http.StatusBadGateway, err, gin.H{"domain_id": domain.ID})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"domain_id":         result.DomainID,
+		"domain_name":       result.DomainName,
+		"source":            result.Source,
+		"updated":           result.Updated,
+		"records":           result.Records,
+		"count":             len(result.Records),
+		"nameserver_change": result.NameserverChange,
+		"sync":              h.dnsSyncStatus(domain.ID, result.Records),
 	})
 }
 
@@ -791,7 +1767,11 @@ func (h *AdminHandler) GetDomainDNS(c *gin.Context) {
 	domainParam := strings.TrimSpace(c.Query("domain"))
 
 	if domainID == "" && domainParam == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Domain ID or domain query parameter required"})
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Domain ID or domain query parameter required")
+		return
+	}
+	filter, ok := h.dnsRecordFilter(c)
+	if !ok {
 		return
 	}
 
@@ -804,7 +1784,7 @@ func (h *AdminHandler) GetDomainDNS(c *gin.Context) {
 		if err == nil && domain != nil {
 			domainName = domain.Name
 		} else {
-			c.JSON(http.StatusNotFound, gin.H{"error": "Domain not found"})
+			respondError(c, http.StatusNotFound, types.CodeDomainNotFound, "Domain not found")
 			return
 		}
 	}
@@ -817,19 +1797,14 @@ func (h *AdminHandler) GetDomainDNS(c *gin.Context) {
 				for i := range dns {
 					dns[i].DomainID = domainID
 				}
-				if err := h.dnsSvc.BulkUpdateRecords(domainID, dns); err != nil {
+				if err := h.dnsSvc.StoreProviderRecords(domainID, forceProvider, dns); err != nil {
 					log.Printf("Failed to persist DNS for %s from %s: %v", domainName, forceProvider, err)
 				}
-				c.JSON(http.StatusOK, gin.H{
-					"domain_id": domainID,
-					"records":   dns,
-					"count":     len(dns),
-					"source":    forceProvider,
-				})
+				h.respondDNSPage(c, domainID, forceProvider, dns, filter)
 				return
 			}
 		}
-		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Unknown or unavailable provider: %s", forceProvider)})
+		respondError(c, http.StatusBadRequest, types.CodeUnsupportedProvider, fmt.Sprintf("Unknown or unavailable provider: %s", forceProvider))
 		return
 	}
 
@@ -840,15 +1815,10 @@ func (h *AdminHandler) GetDomainDNS(c *gin.Context) {
 			for i := range dns {
 				dns[i].DomainID = domainID
 			}
-			if err := h.dnsSvc.BulkUpdateRecords(domainID, dns); err != nil {
+			if err := h.dnsSvc.StoreProviderRecords(domainID, "cloudflare", dns); err != nil {
 				log.Printf("Failed to persist Cloudflare DNS for %s: %v", domainName, err)
 			}
-			c.JSON(http.StatusOK, gin.H{
-				"domain_id": domainID,
-				"records":   dns,
-				"count":     len(dns),
-				"source":    "cloudflare",
-			})
+			h.respondDNSPage(c, domainID, "cloudflare", dns, filter)
 			return
 		}
 	}
@@ -859,25 +1829,20 @@ func (h *AdminHandler) GetDomainDNS(c *gin.Context) {
 			if regClient, ok := h.providerSvc.GetClientByProviderName(domain.Provider); ok {
 				if dns, err := regClient.FetchDNSRecords(domain.Name); err == nil && len(dns) > 0 {
 					for i := range dns { dns[i].DomainID = domainID }
-					if err := h.dnsSvc.BulkUpdateRecords(domainID, dns); err != nil {
+					if err := h.dnsSvc.StoreProviderRecords(domainID, domain.Provider, dns); err != nil {
 						log.Printf("Failed to persist %s DNS for %s: %v", domain.Provider, domain.Name, err)
 					}
-					c.JSON(http.StatusOK, gin.H{
-						"domain_id": domainID,
-						"records":   dns,
-						"count":     len(dns),
-						"source":    domain.Provider,
-					})
+					h.respondDNSPage(c, domainID, domain.Provider, dns, filter)
 					return
 				}
 			}
 		}
 	}
 
-	// Fallback to stored records in repository
-	records, err := h.dnsSvc.GetDomainRecords(domainID)
+	// Fallback to stored records in repository, paged by the database
+	records, total, counts, err := h.dnsSvc.GetDomainRecordsPage(domainID, filter)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondWithError(c, http.StatusInternalServerError, err)
 		return
 	}
 	// Ensure empty array instead of null for frontend rendering
@@ -885,53 +1850,145 @@ func (h *AdminHandler) GetDomainDNS(c *gin.Context) {
 		records = []types.DNSRecord{}
 	}
 
+	// Records on other pages can't be told apart from ones deleted locally, so a partial
+	// page's sync summary covers the page and doesn't list pending deletions
+	sync := h.dnsSyncStatus(domainID, records)
+	if sync != nil && len(records) < total {
+		sync.PendingDelete = []types.DNSRecord{}
+	}
+
 	c.JSON(http.StatusOK, gin.H{
-		"domain_id": domainID,
-		"records":   records,
-		"count":     len(records),
-		"source":    "database",
+		"domain_id":      domainID,
+		"records":        records,
+		"count":          len(records),
+		"total":          total,
+		"limit":          filter.Limit,
+		"offset":         filter.Offset,
+		"counts_by_type": counts,
+		"source":         "database",
+		"sync":           sync,
+	})
+}
+
+// defaultDNSMaxRecords caps a DNS listing when no maximum is configured
+const defaultDNSMaxRecords = 500
+
+// dnsRecordFilter reads the ?type= (comma separated), ?limit= and ?offset= of a DNS listing.
+// The limit defaults to, and can't exceed, the configured maximum. Responds and returns false
+// when a value is invalid.
+func (h *AdminHandler) dnsRecordFilter(c *gin.Context) (types.DNSRecordFilter, bool) {
+	max := h.dnsMaxRecords
+	if max <= 0 {
+		max = defaultDNSMaxRecords
+	}
+	filter := types.DNSRecordFilter{Limit: max}
+
+	if limitStr := c.Query("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil || limit < 1 || limit > max {
+			respondError(c, http.StatusBadRequest, types.CodeValidationFailed, fmt.Sprintf("limit must be between 1 and %d", max))
+			return filter, false
+		}
+		filter.Limit = limit
+	}
+	if offsetStr := c.Query("offset"); offsetStr != "" {
+		offset, err := strconv.Atoi(offsetStr)
+		if err != nil || offset < 0 {
+			respondError(c, http.StatusBadRequest, types.CodeValidationFailed, "offset must not be negative")
+			return filter, false
+		}
+		filter.Offset = offset
+	}
+	for _, recordType := range strings.Split(c.Query("type"), ",") {
+		if recordType = strings.ToUpper(strings.TrimSpace(recordType)); recordType != "" {
+			filter.Types = append(filter.Types, recordType)
+		}
+	}
+	return filter, true
+}
+
+// respondDNSPage sends a page of the records just fetched from a provider. The sync summary
+// and counts cover every record fetched.
+func (h *AdminHandler) respondDNSPage(c *gin.Context, domainID, source string, records []types.DNSRecord, filter types.DNSRecordFilter) {
+	sync := h.dnsSyncStatus(domainID, records)
+	page, total := filter.Page(records)
+	c.JSON(http.StatusOK, gin.H{
+		"domain_id":      domainID,
+		"records":        page,
+		"count":          len(page),
+		"total":          total,
+		"limit":          filter.Limit,
+		"offset":         filter.Offset,
+		"counts_by_type": types.CountDNSRecordsByType(records),
+		"source":         source,
+		"sync":           sync,
 	})
 }
 
+// dnsSyncStatus flags each of a domain's stored records as synced or pending push and returns
+// the summary for the response, or nil if the provider state couldn't be read
+func (h *AdminHandler) dnsSyncStatus(domainID string, records []types.DNSRecord) *dns.SyncState {
+	state, err := h.dnsSvc.AnnotateSyncStatus(domainID, records)
+	if err != nil {
+		log.Printf("Failed to compare DNS for domain %s with provider state: %v", domainID, err)
+		return nil
+	}
+	return state
+}
+
 // CreateDNSRecord creates a new DNS record for a domain
 func (h *AdminHandler) CreateDNSRecord(c *gin.Context) {
 	domainID := c.Param("id")
 	if domainID == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Domain ID required"})
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Domain ID required")
 		return
 	}
 
 	var record types.DNSRecord
 	if err := c.ShouldBindJSON(&record); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid DNS record data"})
+		respondError(c, http.StatusBadRequest, types.CodeInvalidDNSRecord, "Invalid DNS record data")
 		return
 	}
 
 	record.DomainID = domainID
-	if err := h.dnsSvc.CreateRecord(&record); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if err := h.dnsSvc.WithActor(requestActor(c)).CreateRecord(&record); err != nil {
+		respondWithError(c, http.StatusBadRequest, err)
 		return
 	}
 
-	c.JSON(http.StatusCreated, record)
+	c.JSON(http.StatusCreated, dnsRecordWithWarnings{DNSRecord: record, Warnings: dns.RecordWarnings([]types.DNSRecord{record})})
+}
+
+// dnsRecordWithWarnings is a saved DNS record with anything about how it will be published
+type dnsRecordWithWarnings struct {
+	types.DNSRecord
+	Warnings []string `json:"warnings,omitempty"`
 }
 
 // BulkUpdateDNS updates all DNS records for a domain
 func (h *AdminHandler) BulkUpdateDNS(c *gin.Context) {
 	domainID := c.Param("id")
 	if domainID == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Domain ID required"})
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Domain ID required")
 		return
 	}
 
 	var records []types.DNSRecord
 	if err := c.ShouldBindJSON(&records); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid DNS records data"})
+		respondError(c, http.StatusBadRequest, types.CodeInvalidDNSRecord, "Invalid DNS records data")
+		return
+	}
+	if !checkBulkOperations(c, h.requestLimits, len(records)) {
 		return
 	}
 
-	if err := h.dnsSvc.BulkUpdateRecords(domainID, records); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if err := h.dnsSvc.ValidateForProvider(domainID, records, len(records)); err != nil {
+		respondWithError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := h.dnsSvc.WithActor(requestActor(c)).BulkUpdateRecords(domainID, records); err != nil {
+		respondWithError(c, http.StatusBadRequest, err)
 		return
 	}
 
@@ -939,86 +1996,358 @@ func (h *AdminHandler) BulkUpdateDNS(c *gin.Context) {
 		"message":   "DNS records updated successfully",
 		"domain_id": domainID,
 		"count":     len(records),
+		"warnings":  dns.RecordWarnings(records),
 	})
 }
 
-// UpdateDNSRecord updates a specific DNS record
-func (h *AdminHandler) UpdateDNSRecord(c *gin.Context) {
-	id := c.Param("id")
-	if id == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "DNS record ID required"})
+// ExportDNSZone returns a domain's stored DNS records as a BIND-style zone file
+func (h *AdminHandler) ExportDNSZone(c *gin.Context) {
+	domain, err := h.domainRepo.GetByID(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusNotFound, types.CodeDomainNotFound, "Domain not found")
 		return
 	}
 
-	var record types.DNSRecord
-	if err := c.ShouldBindJSON(&record); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid DNS record data"})
+	records, err := h.dnsSvc.GetDomainRecords(domain.ID)
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err)
 		return
 	}
 
-	record.ID = id
-	if err := h.dnsSvc.UpdateRecord(&record); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s.zone", domain.Name))
+	c.String(http.StatusOK, dns.ExportZone(domain.Name, records))
+}
+
+// ImportDNSZone replaces a domain's DNS records with those parsed from a zone file in the request body
+func (h *AdminHandler) ImportDNSZone(c *gin.Context) {
+	domain, err := h.domainRepo.GetByID(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusNotFound, types.CodeDomainNotFound, "Domain not found")
 		return
 	}
 
-	c.JSON(http.StatusOK, record)
-}
+	body, err := c.GetRawData()
+	if err != nil || len(body) == 0 {
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Zone file content required")
+		return
+	}
 
-// DeleteDNSRecord deletes a DNS record
-func (h *AdminHandler) DeleteDNSRecord(c *gin.Context) {
-	id := c.Param("id")
-	if id == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "DNS record ID required"})
+	records, err := dns.ImportZone(domain.ID, string(body))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, types.CodeInvalidDNSRecord, fmt.Sprintf("Invalid zone file: %v", err))
 		return
 	}
 
-	if err := h.dnsSvc.DeleteRecord(id); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if err := h.dnsSvc.ValidateForProvider(domain.ID, records, len(records)); err != nil {
+		respondWithError(c, http.StatusBadRequest, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "DNS record deleted successfully"})
-}
+	if err := h.dnsSvc.WithActor(requestActor(c)).BulkUpdateRecords(domain.ID, records); err != nil {
+		respondWithError(c, http.StatusBadRequest, err)
+		return
+	}
 
-// GetDNSTemplates returns common DNS record templates
-func (h *AdminHandler) GetDNSTemplates(c *gin.Context) {
-	templates := h.dnsSvc.GetCommonRecordTemplates()
 	c.JSON(http.StatusOK, gin.H{
-		"templates": templates,
+		"message":   "Zone imported successfully",
+		"domain_id": domain.ID,
+		"count":     len(records),
+		"warnings":  dns.RecordWarnings(records),
 	})
 }
 
-// ManualSync triggers a manual sync with detailed options
-func (h *AdminHandler) ManualSync(c *gin.Context) {
-	var req struct {
-		Provider      string `json:"provider,omitempty"`
-		CredentialsID string `json:"credentials_id,omitempty"`
-		ForceRefresh  bool   `json:"force_refresh"`
+// CloneDNSFrom copies another domain's DNS records to this one, rewriting names and targets
+// under the source domain for this one. The optional body sets the conflict policy,
+// {"policy": "replace"} (the default) or "merge", and {"dry_run": true} to only report the
+// changes.
+func (h *AdminHandler) CloneDNSFrom(c *gin.Context) {
+	var opts dns.CloneOptions
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&opts); err != nil {
+			respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Invalid request format")
+			return
+		}
 	}
 
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+	target, err := h.domainRepo.GetByID(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusNotFound, types.CodeDomainNotFound, "Domain not found")
+		return
+	}
+	source, err := h.domainRepo.GetByID(c.Param("sourceId"))
+	if err != nil {
+		respondError(c, http.StatusNotFound, types.CodeDomainNotFound, "Source domain not found")
 		return
 	}
 
-	// Start sync in background
-	go func() {
-		if req.Provider != "" {
-			h.syncSvc.SyncProvider(req.Provider)
-		} else {
-			h.syncSvc.Run()
+	result, err := h.dnsSvc.WithActor(requestActor(c)).CloneRecords(*source, *target, opts)
+	if err != nil {
+		respondWithError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// GetDNSHistory returns a domain's DNS change log in chronological order (?limit=, default 200, max 1000)
+func (h *AdminHandler) GetDNSHistory(c *gin.Context) {
+	domainID := c.Param("id")
+
+	limit := 200
+	if limitStr := c.Query("limit"); limitStr != "" {
+		parsed, err := strconv.Atoi(limitStr)
+		if err != nil || parsed <= 0 {
+			respondError(c, http.StatusBadRequest, types.CodeValidationFailed, "limit must be a positive integer")
+			return
 		}
-	}()
+		limit = parsed
+	}
+	if limit > 1000 {
+		limit = 1000
+	}
 
-	c.JSON(http.StatusAccepted, gin.H{
-		"message":        "Manual sync initiated",
-		"provider":       req.Provider,
-		"credentials_id": req.CredentialsID,
-		"force_refresh":  req.ForceRefresh,
+	history, err := h.dnsSvc.GetHistory(domainID, limit)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, types.CodeInternal, "Failed to get DNS history: " + err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"domain_id": domainID,
+		"history":   history,
+		"count":     len(history),
 	})
 }
 
+// GetDNSHealth checks a domain live against its parent zone and authoritative nameservers:
+// delegation, glue, lame delegation, NS and serial consistency, open recursion and SOA timers
+func (h *AdminHandler) GetDNSHealth(c *gin.Context) {
+	domain, err := h.domainRepo.GetByID(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusNotFound, types.CodeDomainNotFound, "Domain not found")
+		return
+	}
+
+	c.JSON(http.StatusOK, dns.NewHealthChecker().Check(domain.Name))
+}
+
+// GetNameserverHistory returns the nameserver changes seen for a domain in chronological order (?limit=, default 100, max 1000)
+func (h *AdminHandler) GetNameserverHistory(c *gin.Context) {
+	domainID := c.Param("id")
+
+	domain, err := h.domainRepo.GetByID(domainID)
+	if err != nil {
+		respondError(c, http.StatusNotFound, types.CodeDomainNotFound, "Domain not found")
+		return
+	}
+
+	limit := 100
+	if limitStr := c.Query("limit"); limitStr != "" {
+		parsed, err := strconv.Atoi(limitStr)
+		if err != nil || parsed <= 0 {
+			respondError(c, http.StatusBadRequest, types.CodeValidationFailed, "limit must be a positive integer")
+			return
+		}
+		limit = parsed
+	}
+	if limit > 1000 {
+		limit = 1000
+	}
+
+	history, err := h.dnsSvc.GetNameserverHistory(domainID, limit)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, types.CodeInternal, "Failed to get nameserver history: " + err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"domain_id":   domainID,
+		"domain_name": domain.Name,
+		"history":     history,
+		"count":       len(history),
+	})
+}
+
+// UpdateDNSRecord updates a specific DNS record
+func (h *AdminHandler) UpdateDNSRecord(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "DNS record ID required")
+		return
+	}
+
+	var record types.DNSRecord
+	if err := c.ShouldBindJSON(&record); err != nil {
+		respondError(c, http.StatusBadRequest, types.CodeInvalidDNSRecord, "Invalid DNS record data")
+		return
+	}
+
+	record.ID = id
+	if err := h.dnsSvc.WithActor(requestActor(c)).UpdateRecord(&record); err != nil {
+		respondWithError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dnsRecordWithWarnings{DNSRecord: record, Warnings: dns.RecordWarnings([]types.DNSRecord{record})})
+}
+
+// DeleteDNSRecord deletes a DNS record
+func (h *AdminHandler) DeleteDNSRecord(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "DNS record ID required")
+		return
+	}
+
+	if err := h.dnsSvc.WithActor(requestActor(c)).DeleteRecord(id); err != nil {
+		respondWithError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "DNS record deleted successfully"})
+}
+
+// GetDNSTemplates returns common DNS record templates
+func (h *AdminHandler) GetDNSTemplates(c *gin.Context) {
+	templates := h.dnsSvc.GetCommonRecordTemplates()
+	c.JSON(http.StatusOK, gin.H{
+		"templates": templates,
+	})
+}
+
+// GetEmailSetup returns the MX, SPF, DKIM and DMARC records a domain needs for an email provider
+func (h *AdminHandler) GetEmailSetup(c *gin.Context) {
+	id := c.Param("id")
+	provider := c.Query("provider")
+	if provider == "" {
+		respondErrorDetails(c, http.StatusBadRequest, types.CodeInvalidRequest, "provider query parameter required",
+			gin.H{"supported": dns.SupportedEmailProviders()})
+		return
+	}
+
+	domain, err := h.domainRepo.GetByID(id)
+	if err != nil {
+		if err == types.ErrDomainNotFound {
+			respondError(c, http.StatusNotFound, types.CodeDomainNotFound, "Domain not found")
+		} else {
+			respondWithError(c, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	setup, err := dns.BuildEmailSetup(domain.ID, domain.Name, provider, dns.EmailSetupOptions{
+		Tenant:     c.Query("tenant"),
+		DMARCEmail: c.Query("dmarc_email"),
+	})
+	if err != nil {
+		respondWithError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	// Flag existing mail records so the user knows what the setup replaces
+	existing, err := h.dnsSvc.GetDomainRecords(domain.ID)
+	if err != nil {
+		log.Printf("Failed to load DNS records for %s while building email setup: %v", domain.Name, err)
+	}
+	conflicts := dns.EmailSetupConflicts(existing)
+	if conflicts == nil {
+		conflicts = []types.DNSRecord{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"domain_id":    domain.ID,
+		"domain":       domain.Name,
+		"provider":     setup.Provider,
+		"display_name": setup.DisplayName,
+		"records":      setup.Records,
+		"notes":        setup.Notes,
+		"conflicts":    conflicts,
+	})
+}
+
+// AnalyzeSPF resolves a domain's SPF record, counts the DNS lookups it needs against the limit
+// of 10 and suggests a flattened record with the included addresses inlined. The stored record
+// is analysed when DomainVault has one, otherwise the published record is looked up.
+func (h *AdminHandler) AnalyzeSPF(c *gin.Context) {
+	domain, err := h.domainRepo.GetByID(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusNotFound, types.CodeDomainNotFound, "Domain not found")
+		return
+	}
+
+	records, err := h.dnsSvc.GetDomainRecords(domain.ID)
+	if err != nil {
+		log.Printf("Failed to load DNS records for %s while analyzing SPF: %v", domain.Name, err)
+	}
+
+	analyzer := dns.NewSPFAnalyzer()
+	var analysis *dns.SPFAnalysis
+	if stored := dns.FindSPFRecords(records); len(stored) > 0 {
+		analysis = analyzer.Analyze(domain.ID, domain.Name, stored[0].Value, dns.SPFSourceStored)
+		if len(stored) > 1 {
+			analysis.Warnings = append(analysis.Warnings, fmt.Sprintf(
+				"The domain has %d SPF records; receivers reject more than one, so merge them into a single record", len(stored)))
+		}
+	} else {
+		record, err := analyzer.LookupRecord(domain.Name)
+		if err != nil {
+			respondErrorDetails(c, http.StatusNotFound, types.CodeNotFound, "No SPF record found", err.Error())
+			return
+		}
+		analysis = analyzer.Analyze(domain.ID, domain.Name, record, dns.SPFSourceLive)
+	}
+
+	c.JSON(http.StatusOK, analysis)
+}
+
+// ManualSync triggers a manual sync with detailed options
+func (h *AdminHandler) ManualSync(c *gin.Context) {
+	var req struct {
+		Provider      string `json:"provider,omitempty"`
+		CredentialsID string `json:"credentials_id,omitempty"`
+		ForceRefresh  bool   `json:"force_refresh"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Invalid request format")
+		return
+	}
+
+	var providerNames []string
+	if req.Provider != "" {
+		providerNames = []string{req.Provider}
+	}
+	job, ok := enqueueJob(c, h.jobs, "manual_sync", h.syncProvidersJob(providerNames))
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusAccepted, jobAccepted(job, gin.H{
+		"message":        "Manual sync initiated",
+		"provider":       req.Provider,
+		"credentials_id": req.CredentialsID,
+		"force_refresh":  req.ForceRefresh,
+	}))
+}
+
+// GetLastSyncReport returns what the most recent sync added, changed and no longer found at providers
+func (h *AdminHandler) GetLastSyncReport(c *gin.Context) {
+	report := h.syncSvc.LastReport()
+	if report == nil {
+		respondError(c, http.StatusNotFound, types.CodeNotFound, "No sync has completed since the server started")
+		return
+	}
+	if scope := requestScope(c); scope != nil {
+		names, err := scopedDomainNames(h.domainRepo, *scope)
+		if err != nil {
+			respondWithError(c, http.StatusInternalServerError, err)
+			return
+		}
+		report = report.Filter(func(name string) bool { return names[types.NormalizeDomainName(name)] })
+	}
+	c.JSON(http.StatusOK, report)
+}
+
 // GetSupportedProviders returns the list of supported domain providers
 func (h *AdminHandler) GetSupportedProviders(c *gin.Context) {
 	providers := []string{"godaddy", "namecheap", "cloudflare"}
@@ -1040,7 +2369,7 @@ func (h *AdminHandler) ListCategories(c *gin.Context) {
 	if repo, ok := h.domainRepo.(interface{ GetAllCategories() ([]types.Category, error) }); ok {
 		categories, err := repo.GetAllCategories()
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			respondWithError(c, http.StatusInternalServerError, err)
 			return
 		}
 		c.JSON(http.StatusOK, gin.H{
@@ -1048,7 +2377,7 @@ func (h *AdminHandler) ListCategories(c *gin.Context) {
 			"count":      len(categories),
 		})
 	} else {
-		c.JSON(http.StatusNotImplemented, gin.H{"error": "Category operations not implemented"})
+		respondError(c, http.StatusNotImplemented, types.CodeNotImplemented, "Category operations not implemented")
 	}
 }
 
@@ -1056,18 +2385,22 @@ func (h *AdminHandler) ListCategories(c *gin.Context) {
 func (h *AdminHandler) CreateCategory(c *gin.Context) {
 	var category types.Category
 	if err := c.ShouldBindJSON(&category); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid category data"})
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Invalid category data")
+		return
+	}
+	if err := category.Validate(); err != nil {
+		respondWithError(c, http.StatusBadRequest, err)
 		return
 	}
 
 	if repo, ok := h.domainRepo.(interface{ CreateCategory(*types.Category) error }); ok {
 		if err := repo.CreateCategory(&category); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			respondWithError(c, http.StatusInternalServerError, err)
 			return
 		}
 		c.JSON(http.StatusCreated, category)
 	} else {
-		c.JSON(http.StatusNotImplemented, gin.H{"error": "Category operations not implemented"})
+		respondError(c, http.StatusNotImplemented, types.CodeNotImplemented, "Category operations not implemented")
 	}
 }
 
@@ -1075,25 +2408,29 @@ func (h *AdminHandler) CreateCategory(c *gin.Context) {
 func (h *AdminHandler) UpdateCategory(c *gin.Context) {
 	id := c.Param("id")
 	if id == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Category ID required"})
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Category ID required")
 		return
 	}
 
 	var category types.Category
 	if err := c.ShouldBindJSON(&category); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid category data"})
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Invalid category data")
+		return
+	}
+	if err := category.Validate(); err != nil {
+		respondWithError(c, http.StatusBadRequest, err)
 		return
 	}
 
 	category.ID = id
 	if repo, ok := h.domainRepo.(interface{ UpdateCategory(*types.Category) error }); ok {
 		if err := repo.UpdateCategory(&category); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			respondWithError(c, http.StatusInternalServerError, err)
 			return
 		}
 		c.JSON(http.StatusOK, category)
 	} else {
-		c.JSON(http.StatusNotImplemented, gin.H{"error": "Category operations not implemented"})
+		respondError(c, http.StatusNotImplemented, types.CodeNotImplemented, "Category operations not implemented")
 	}
 }
 
@@ -1101,18 +2438,18 @@ func (h *AdminHandler) UpdateCategory(c *gin.Context) {
 func (h *AdminHandler) DeleteCategory(c *gin.Context) {
 	id := c.Param("id")
 	if id == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Category ID required"})
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Category ID required")
 		return
 	}
 
 	if repo, ok := h.domainRepo.(interface{ DeleteCategory(string) error }); ok {
 		if err := repo.DeleteCategory(id); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			respondWithError(c, http.StatusInternalServerError, err)
 			return
 		}
 		c.JSON(http.StatusOK, gin.H{"message": "Category deleted successfully"})
 	} else {
-		c.JSON(http.StatusNotImplemented, gin.H{"error": "Category operations not implemented"})
+		respondError(c, http.StatusNotImplemented, types.CodeNotImplemented, "Category operations not implemented")
 	}
 }
 
@@ -1125,7 +2462,7 @@ func (h *AdminHandler) ListProjects(c *gin.Context) {
 	if repo, ok := h.domainRepo.(interface{ GetAllProjects() ([]types.Project, error) }); ok {
 		projects, err := repo.GetAllProjects()
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			respondWithError(c, http.StatusInternalServerError, err)
 			return
 		}
 		c.JSON(http.StatusOK, gin.H{
@@ -1133,7 +2470,7 @@ func (h *AdminHandler) ListProjects(c *gin.Context) {
 			"count":    len(projects),
 		})
 	} else {
-		c.JSON(http.StatusNotImplemented, gin.H{"error": "Project operations not implemented"})
+		respondError(c, http.StatusNotImplemented, types.CodeNotImplemented, "Project operations not implemented")
 	}
 }
 
@@ -1141,18 +2478,22 @@ func (h *AdminHandler) ListProjects(c *gin.Context) {
 func (h *AdminHandler) CreateProject(c *gin.Context) {
 	var project types.Project
 	if err := c.ShouldBindJSON(&project); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project data"})
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Invalid project data")
+		return
+	}
+	if err := project.Validate(); err != nil {
+		respondWithError(c, http.StatusBadRequest, err)
 		return
 	}
 
 	if repo, ok := h.domainRepo.(interface{ CreateProject(*types.Project) error }); ok {
 		if err := repo.CreateProject(&project); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			respondWithError(c, http.StatusInternalServerError, err)
 			return
 		}
 		c.JSON(http.StatusCreated, project)
 	} else {
-		c.JSON(http.StatusNotImplemented, gin.H{"error": "Project operations not implemented"})
+		respondError(c, http.StatusNotImplemented, types.CodeNotImplemented, "Project operations not implemented")
 	}
 }
 
@@ -1160,25 +2501,29 @@ func (h *AdminHandler) CreateProject(c *gin.Context) {
 func (h *AdminHandler) UpdateProject(c *gin.Context) {
 	id := c.Param("id")
 	if id == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Project ID required"})
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Project ID required")
 		return
 	}
 
 	var project types.Project
 	if err := c.ShouldBindJSON(&project); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project data"})
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Invalid project data")
+		return
+	}
+	if err := project.Validate(); err != nil {
+		respondWithError(c, http.StatusBadRequest, err)
 		return
 	}
 
 	project.ID = id
 	if repo, ok := h.domainRepo.(interface{ UpdateProject(*types.Project) error }); ok {
 		if err := repo.UpdateProject(&project); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			respondWithError(c, http.StatusInternalServerError, err)
 			return
 		}
 		c.JSON(http.StatusOK, project)
 	} else {
-		c.JSON(http.StatusNotImplemented, gin.H{"error": "Project operations not implemented"})
+		respondError(c, http.StatusNotImplemented, types.CodeNotImplemented, "Project operations not implemented")
 	}
 }
 
@@ -1186,18 +2531,18 @@ func (h *AdminHandler) UpdateProject(c *gin.Context) {
 func (h *AdminHandler) DeleteProject(c *gin.Context) {
 	id := c.Param("id")
 	if id == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Project ID required"})
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Project ID required")
 		return
 	}
 
 	if repo, ok := h.domainRepo.(interface{ DeleteProject(string) error }); ok {
 		if err := repo.DeleteProject(id); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			respondWithError(c, http.StatusInternalServerError, err)
 			return
 		}
 		c.JSON(http.StatusOK, gin.H{"message": "Project deleted successfully"})
 	} else {
-		c.JSON(http.StatusNotImplemented, gin.H{"error": "Project operations not implemented"})
+		respondError(c, http.StatusNotImplemented, types.CodeNotImplemented, "Project operations not implemented")
 	}
 }
 
@@ -1210,7 +2555,7 @@ func (h *AdminHandler) ListCredentials(c *gin.Context) {
 	if repo, ok := h.domainRepo.(interface{ GetAllCredentials() ([]types.ProviderCredentials, error) }); ok {
 		credentials, err := repo.GetAllCredentials()
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			respondWithError(c, http.StatusInternalServerError, err)
 			return
 		}
 
@@ -1224,43 +2569,127 @@ func (h *AdminHandler) ListCredentials(c *gin.Context) {
 			"count":       len(credentials),
 		})
 	} else {
-		c.JSON(http.StatusNotImplemented, gin.H{"error": "Credentials operations not implemented"})
+		respondError(c, http.StatusNotImplemented, types.CodeNotImplemented, "Credentials operations not implemented")
+	}
+}
+
+// ExportCredentials returns every connected provider and stored provider account with its
+// metadata and sync settings but no secrets, as a backup of the provider setup. Each entry's
+// connection can be resubmitted to connect the provider again once its credentials are filled in.
+func (h *AdminHandler) ExportCredentials(c *gin.Context) {
+	export := types.ProviderConfigExport{
+		Version:    types.ProviderExportVersion,
+		ExportedAt: time.Now(),
+		Providers:  []types.ProviderExportEntry{},
+	}
+
+	exported := make(map[string]bool)
+	for _, provider := range h.providerSvc.GetConnectedProviders() {
+		fields := make(map[string]string, len(provider.Credentials))
+		for field := range provider.Credentials {
+			fields[field] = ""
+		}
+		entry := types.ProviderExportEntry{
+			ID:               provider.ID,
+			Source:           types.ProviderSourceConnected,
+			Enabled:          provider.Enabled,
+			ConnectionStatus: provider.ConnectionStatus,
+			LastSyncStatus:   provider.LastSyncStatus,
+			DomainsCount:     provider.DomainsCount,
+			Connection: types.ProviderConnectionRequest{
+				Provider:          provider.Provider,
+				Name:              provider.Name,
+				AccountName:       provider.AccountName,
+				Credentials:       fields,
+				AutoSync:          provider.AutoSyncEnabled,
+				SyncIntervalHours: int(provider.SyncInterval.Hours()),
+			},
+		}
+		if !provider.LastSyncTime.IsZero() {
+			lastSync := provider.LastSyncTime
+			entry.LastSync = &lastSync
+		}
+		export.Providers = append(export.Providers, entry)
+		exported[provider.ID] = true
+	}
+
+	// Stored accounts sync with the scheduled sync rather than their own interval
+	if repo, ok := h.domainRepo.(interface{ GetAllCredentials() ([]types.ProviderCredentials, error) }); ok {
+		credentials, err := repo.GetAllCredentials()
+		if err != nil {
+			respondWithError(c, http.StatusInternalServerError, err)
+			return
+		}
+		for _, creds := range credentials {
+			if exported[creds.ID] {
+				continue
+			}
+			entry := types.ProviderExportEntry{
+				ID:               creds.ID,
+				Source:           types.ProviderSourceStored,
+				Enabled:          creds.Enabled,
+				ConnectionStatus: creds.ConnectionStatus,
+				LastSync:         creds.LastSync,
+				Connection: types.ProviderConnectionRequest{
+					Provider:    creds.Provider,
+					Name:        creds.Name,
+					AccountName: creds.AccountName,
+					Credentials: types.RedactCredentials(creds.Credentials),
+				},
+			}
+			if creds.LastSyncError != nil {
+				entry.LastSyncStatus = "error"
+			}
+			export.Providers = append(export.Providers, entry)
+		}
+	}
+
+	sort.Slice(export.Providers, func(i, j int) bool {
+		a, b := export.Providers[i].Connection, export.Providers[j].Connection
+		if a.Provider != b.Provider {
+			return a.Provider < b.Provider
+		}
+		return a.Name < b.Name
+	})
+
+	if h.securitySvc != nil {
+		userID, _ := c.Get("userID")
+		id, _ := userID.(string)
+		h.securitySvc.LogAuditEvent(security.EventDataExport, id, requestActor(c), c.ClientIP(),
+			c.GetHeader("User-Agent"), "credentials", "export", true,
+			map[string]interface{}{"providers": len(export.Providers)}, "")
 	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="domainvault-providers-%s.json"`, export.ExportedAt.Format("20060102")))
+	c.JSON(http.StatusOK, export)
 }
 
 // ============================================================================
 // ENHANCED PROVIDER MANAGEMENT ENDPOINTS
 // ============================================================================
 
-// ListConnectedProviders returns all connected providers with status
+// ListConnectedProviders returns all connected providers with status; stale providers come first
 func (h *AdminHandler) ListConnectedProviders(c *gin.Context) {
-	providers := h.providerSvc.GetConnectedProviders()
+	connected := h.providerSvc.GetConnectedProviders()
 	
 	// Convert to response format without exposing credentials
-	response := make([]map[string]interface{}, 0, len(providers))
-	for _, provider := range providers {
-		providerData := map[string]interface{}{
-			"id":                provider.ID,
-			"provider":          provider.Provider,
-			"name":              provider.Name,
-			"account_name":      provider.AccountName,
-			"enabled":           provider.Enabled,
-			"auto_sync_enabled": provider.AutoSyncEnabled,
-			"sync_interval":     provider.SyncInterval.Hours(),
-			"connection_status": provider.ConnectionStatus,
-			"last_sync_time":    provider.LastSyncTime,
-			"last_sync_status":  provider.LastSyncStatus,
-			"domains_count":     provider.DomainsCount,
-			"error_count":       provider.ErrorCount,
-			"created_at":        provider.CreatedAt,
-			"updated_at":        provider.UpdatedAt,
-		}
-		response = append(response, providerData)
+	now := time.Now()
+	response := make([]map[string]interface{}, 0, len(connected))
+	staleCount := 0
+	for _, provider := range connected {
+		if provider.ConnectionStatus == providers.ConnectionStatusStale {
+			staleCount++
+		}
+		response = append(response, h.connectedProviderResponse(provider, now))
 	}
+	sort.SliceStable(response, func(i, j int) bool {
+		return response[i]["stale"].(bool) && !response[j]["stale"].(bool)
+	})
 	
 	c.JSON(http.StatusOK, gin.H{
-		"providers": response,
-		"count":     len(response),
+		"providers":   response,
+		"count":       len(response),
+		"stale_count": staleCount,
 	})
 }
 
@@ -1268,53 +2697,100 @@ func (h *AdminHandler) ListConnectedProviders(c *gin.Context) {
 func (h *AdminHandler) GetConnectedProvider(c *gin.Context) {
 	id := c.Param("id")
 	if id == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Provider ID required"})
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Provider ID required")
 		return
 	}
 	
 	provider, err := h.providerSvc.GetConnectedProvider(id)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		respondWithError(c, http.StatusNotFound, err)
 		return
 	}
 	
-	// Return without exposing credentials
-	response := map[string]interface{}{
-		"id":                provider.ID,
-		"provider":          provider.Provider,
-		"name":              provider.Name,
-		"account_name":      provider.AccountName,
-		"enabled":           provider.Enabled,
-		"auto_sync_enabled": provider.AutoSyncEnabled,
-		"sync_interval":     provider.SyncInterval.Hours(),
-		"connection_status": provider.ConnectionStatus,
-		"last_sync_time":    provider.LastSyncTime,
-		"last_sync_status":  provider.LastSyncStatus,
-		"domains_count":     provider.DomainsCount,
-		"error_count":       provider.ErrorCount,
-		"created_at":        provider.CreatedAt,
-		"updated_at":        provider.UpdatedAt,
+	c.JSON(http.StatusOK, h.connectedProviderResponse(provider, time.Now()))
+}
+
+// GetConnectedProviderDomains lists the domains synced from one connected provider account,
+// so domains can be grouped by the account that holds them rather than only by registrar
+func (h *AdminHandler) GetConnectedProviderDomains(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Provider ID required")
+		return
 	}
-	
-	c.JSON(http.StatusOK, response)
+
+	provider, err := h.providerSvc.GetConnectedProvider(id)
+	if err != nil {
+		respondError(c, http.StatusNotFound, types.CodeNotFound, "Provider not found")
+		return
+	}
+
+	domains, err := h.domainRepo.GetByFilter(types.DomainFilter{AccountRef: &provider.ID, SortBy: "name", SortOrder: types.SortAsc})
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err)
+		return
+	}
+	if domains == nil {
+		domains = []types.Domain{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"provider_id":  provider.ID,
+		"provider":     provider.Provider,
+		"name":         provider.Name,
+		"account_name": provider.AccountName,
+		"domains":      domains,
+		"count":        len(domains),
+	})
+}
+
+// connectedProviderResponse describes a connected provider without its credentials, with how
+// long ago it last synced successfully and the state of its circuit breaker
+func (h *AdminHandler) connectedProviderResponse(provider *providers.ConnectedProvider, now time.Time) map[string]interface{} {
+	response := map[string]interface{}{
+		"id":                       provider.ID,
+		"provider":                 provider.Provider,
+		"name":                     provider.Name,
+		"account_name":             provider.AccountName,
+		"enabled":                  provider.Enabled,
+		"auto_sync_enabled":        provider.AutoSyncEnabled,
+		"sync_interval":            provider.SyncInterval.Hours(),
+		"connection_status":        provider.ConnectionStatus,
+		"stale":                    provider.ConnectionStatus == providers.ConnectionStatusStale,
+		"stale_after_hours":        h.providerSvc.StaleAfter(provider).Hours(),
+		"last_sync_time":           provider.LastSyncTime,
+		"last_sync_status":         provider.LastSyncStatus,
+		"last_successful_sync":     nil,
+		"last_success_age_seconds": int(provider.LastSuccessAge(now).Seconds()),
+		"domains_count":            provider.DomainsCount,
+		"error_count":              provider.ErrorCount,
+		"degraded":                 provider.ConnectionStatus == providers.ConnectionStatusDegraded,
+		"breaker":                  provider.BreakerStatus(),
+		"created_at":               provider.CreatedAt,
+		"updated_at":               provider.UpdatedAt,
+	}
+	if !provider.LastSuccessfulSync.IsZero() {
+		response["last_successful_sync"] = provider.LastSuccessfulSync
+	}
+	return response
 }
 
 // UpdateConnectedProvider updates a connected provider's settings
 func (h *AdminHandler) UpdateConnectedProvider(c *gin.Context) {
 	id := c.Param("id")
 	if id == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Provider ID required"})
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Provider ID required")
 		return
 	}
 	
 	var updates map[string]interface{}
 	if err := c.ShouldBindJSON(&updates); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid update data"})
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Invalid update data")
 		return
 	}
 	
 	if err := h.providerSvc.UpdateConnectedProvider(id, updates); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondWithError(c, http.StatusBadRequest, err)
 		return
 	}
 	
@@ -1325,12 +2801,12 @@ func (h *AdminHandler) UpdateConnectedProvider(c *gin.Context) {
 func (h *AdminHandler) RemoveConnectedProvider(c *gin.Context) {
 	id := c.Param("id")
 	if id == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Provider ID required"})
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Provider ID required")
 		return
 	}
 	
 	if err := h.providerSvc.RemoveConnectedProvider(id); err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		respondWithError(c, http.StatusNotFound, err)
 		return
 	}
 	
@@ -1341,78 +2817,97 @@ func (h *AdminHandler) RemoveConnectedProvider(c *gin.Context) {
 func (h *AdminHandler) SyncProviderByID(c *gin.Context) {
 	id := c.Param("id")
 	if id == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Provider ID required"})
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Provider ID required")
 		return
 	}
 
 	// Get the connected provider
 	provider, err := h.providerSvc.GetConnectedProvider(id)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Provider not found"})
+		respondError(c, http.StatusNotFound, types.CodeNotFound, "Provider not found")
 		return
 	}
 
-	// Start sync in background
-	go func() {
-		syncFunc := func(client providers.RegistrarClient) ([]types.Domain, error) {
-			domains, err := client.FetchDomains()
-			if err != nil {
-				return nil, err
-			}
-			
-			// Save domains to repository
-			if len(domains) > 0 {
-				if err := h.domainRepo.UpsertDomains(domains); err != nil {
-					return domains, fmt.Errorf("failed to save domains: %w", err)
-				}
-			}
-			
-			return domains, nil
+	job, ok := enqueueJob(c, h.jobs, "provider_sync", h.connectedProviderSyncJob(id, provider.Name))
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusAccepted, jobAccepted(job, gin.H{
+		"message":     "Sync initiated",
+		"provider_id": id,
+	}))
+}
+
+// fetchAndStore is the sync function for connected providers: fetch the domains, tag them
+// with the account they came from and upsert them
+func (h *AdminHandler) fetchAndStore(accountRef string, client providers.RegistrarClient) ([]types.Domain, error) {
+	if h.settingsSvc != nil && h.settingsSvc.Maintenance().Enabled {
+		return nil, types.ErrMaintenanceMode
+	}
+	domains, err := client.FetchDomains()
+	if err != nil {
+		return nil, err
+	}
+	for i := range domains {
+		ref := accountRef
+		domains[i].AccountRef = &ref
+	}
+	if len(domains) > 0 {
+		if err := h.categorize(domains, true); err != nil {
+			log.Printf("Skipping categorization rules for %s: %v", accountRef, err)
 		}
-		
-		if err := h.providerSvc.SyncProvider(id, syncFunc); err != nil {
-			log.Printf("Sync failed for provider %s (%s): %v", provider.Name, provider.Provider, err)
+		if _, err := h.domainRepo.UpsertDomains(domains); err != nil {
+			return domains, fmt.Errorf("failed to save domains: %w", err)
+		}
+	}
+	return domains, nil
+}
+
+// connectedProviderSyncJob syncs one connected provider; the result is its updated connection
+func (h *AdminHandler) connectedProviderSyncJob(id, name string) jobs.Func {
+	return func(ctx context.Context, tracker *jobs.Tracker) (interface{}, error) {
+		tracker.SetTotal(1)
+		err := h.providerSvc.SyncProvider(id, h.fetchAndStore)
+		if err != nil {
+			log.Printf("Sync failed for provider %s: %v", name, err)
 		} else {
-			log.Printf("Sync completed for provider %s (%s)", provider.Name, provider.Provider)
+			log.Printf("Sync completed for provider %s", name)
 		}
-	}()
+		tracker.Advance(1, name)
 
-	c.JSON(http.StatusAccepted, gin.H{
-		"message":     "Sync initiated",
-		"provider_id": id,
-	})
+		provider, getErr := h.providerSvc.GetConnectedProvider(id)
+		if getErr != nil {
+			return nil, err
+		}
+		return gin.H{
+			"provider_id":      provider.ID,
+			"name":             provider.Name,
+			"provider":         provider.Provider,
+			"last_sync_time":   provider.LastSyncTime,
+			"last_sync_status": provider.LastSyncStatus,
+			"domains_count":    provider.DomainsCount,
+		}, err
+	}
 }
 
 // SyncAllConnectedProviders syncs all enabled connected providers
 func (h *AdminHandler) SyncAllConnectedProviders(c *gin.Context) {
-	// Start sync in background
-	go func() {
-		syncFunc := func(client providers.RegistrarClient) ([]types.Domain, error) {
-			domains, err := client.FetchDomains()
-			if err != nil {
-				return nil, err
-			}
-			
-			// Save domains to repository
-			if len(domains) > 0 {
-				if err := h.domainRepo.UpsertDomains(domains); err != nil {
-					return domains, fmt.Errorf("failed to save domains: %w", err)
-				}
-			}
-			
-			return domains, nil
-		}
-		
-		if err := h.providerSvc.SyncAllProviders(syncFunc); err != nil {
+	job, ok := enqueueJob(c, h.jobs, "provider_sync_all", func(ctx context.Context, tracker *jobs.Tracker) (interface{}, error) {
+		if err := h.providerSvc.SyncAllProviders(h.fetchAndStore); err != nil {
 			log.Printf("Sync all providers failed: %v", err)
-		} else {
-			log.Printf("Sync all providers completed")
+			return nil, err
 		}
-	}()
-	
-	c.JSON(http.StatusAccepted, gin.H{
-		"message": "Sync all providers initiated",
+		log.Printf("Sync all providers completed")
+		return nil, nil
 	})
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusAccepted, jobAccepted(job, gin.H{
+		"message": "Sync all providers initiated",
+	}))
 }
 
 // GetAutoSyncStatus returns the auto-sync status for all providers
@@ -1423,11 +2918,7 @@ func (h *AdminHandler) GetAutoSyncStatus(c *gin.Context) {
 
 // StartAutoSync starts the auto-sync scheduler
 func (h *AdminHandler) StartAutoSync(c *gin.Context) {
-	syncFunc := func(client providers.RegistrarClient) ([]types.Domain, error) {
-		return client.FetchDomains()
-	}
-	
-	h.providerSvc.StartAutoSync(syncFunc)
+	h.providerSvc.StartAutoSync(h.fetchAndStore)
 	
 	c.JSON(http.StatusOK, gin.H{
 		"message": "Auto-sync scheduler started",
@@ -1447,28 +2938,30 @@ func (h *AdminHandler) StopAutoSync(c *gin.Context) {
 func (h *AdminHandler) CheckDomainStatus(c *gin.Context) {
 	id := c.Param("id")
 	if id == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Domain ID required"})
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Domain ID required")
 		return
 	}
 
 	// Get the domain
 	domain, err := h.domainRepo.GetByID(id)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Domain not found"})
+		respondError(c, http.StatusNotFound, types.CodeDomainNotFound, "Domain not found")
 		return
 	}
 
 	// Check the status
+	previous := *domain
 	if err := h.statusChecker.CheckDomainWithHTTPS(domain); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to check status: %v", err)})
+		respondError(c, http.StatusInternalServerError, types.CodeInternal, fmt.Sprintf("Failed to check status: %v", err))
 		return
 	}
 
 	// Update the domain in the database
 	if err := h.domainRepo.Update(domain); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to update domain: %v", err)})
+		respondError(c, http.StatusInternalServerError, types.CodeInternal, fmt.Sprintf("Failed to update domain: %v", err))
 		return
 	}
+	h.notifyTransitions(previous, *domain)
 
 	c.JSON(http.StatusOK, gin.H{
 		"domain_id":         domain.ID,
@@ -1479,7 +2972,19 @@ func (h *AdminHandler) CheckDomainStatus(c *gin.Context) {
 	})
 }
 
-// BulkCheckStatus checks the HTTP status of multiple domains
+// notifyTransitions sends alerts for status transitions between a domain's stored and updated state
+func (h *AdminHandler) notifyTransitions(previous, current types.Domain) {
+	if h.notificationSvc == nil {
+		return
+	}
+	for _, alert := range h.notificationSvc.DetectTransitions(previous, current) {
+		if err := h.notificationSvc.Notify(alert); err != nil {
+			log.Printf("Failed to send transition alert for %s: %v", current.Name, err)
+		}
+	}
+}
+
+// BulkCheckStatus checks the HTTP status of multiple domains, each given by ID or name
 func (h *AdminHandler) BulkCheckStatus(c *gin.Context) {
 	var req struct {
 		DomainIDs []string `json:"domain_ids"`
@@ -1487,28 +2992,61 @@ func (h *AdminHandler) BulkCheckStatus(c *gin.Context) {
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Invalid request format")
 		return
 	}
 
 	if len(req.DomainIDs) == 0 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "No domain IDs provided"})
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "No domain IDs provided")
+		return
+	}
+	if !checkBulkOperations(c, h.requestLimits, len(req.DomainIDs)) {
 		return
 	}
 
-	var results []gin.H
-	var errors []string
+	scope := requestScope(c)
 
-	for _, domainID := range req.DomainIDs {
-		domain, err := h.domainRepo.GetByID(domainID)
-		if err != nil {
-			errors = append(errors, fmt.Sprintf("Domain %s: not found", domainID))
+	// ?async=true runs the checks as a background job for lists too long to wait on
+	if c.Query("async") == "true" {
+		job, ok := enqueueJob(c, h.jobs, "bulk_status_check", func(ctx context.Context, tracker *jobs.Tracker) (interface{}, error) {
+			return h.checkDomainStatuses(ctx, req.DomainIDs, req.CheckHTTPS, scope, tracker), nil
+		})
+		if ok {
+			c.JSON(http.StatusAccepted, jobAccepted(job, gin.H{"message": "Bulk status check queued"}))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, h.checkDomainStatuses(c.Request.Context(), req.DomainIDs, req.CheckHTTPS, scope, nil))
+}
+
+// checkDomainStatuses checks and stores the HTTP status of each domain, reporting progress to
+// tracker if one is given. Domains outside a non-nil scope are reported as errors.
+func (h *AdminHandler) checkDomainStatuses(ctx context.Context, domainIDs []string, checkHTTPS bool, scope *types.AccessScope, tracker *jobs.Tracker) gin.H {
+	var results []gin.H
+	var errors []string
+
+	tracker.SetTotal(len(domainIDs))
+	for _, domainID := range domainIDs {
+		if ctx.Err() != nil {
+			errors = append(errors, "Check stopped early: "+ctx.Err().Error())
+			break
+		}
+		tracker.Advance(1, domainID)
+
+		domain, err := resolveDomainRef(h.domainRepo, domainID)
+		if err == nil && scope != nil && !scope.Allows(*domain) {
+			err = types.ErrOutOfScope
+		}
+		if err != nil {
+			errors = append(errors, fmt.Sprintf("Domain %s: %v", domainID, err))
 			continue
 		}
 
 		// Check status
+		previous := *domain
 		var checkErr error
-		if req.CheckHTTPS {
+		if checkHTTPS {
 			checkErr = h.statusChecker.CheckDomainWithHTTPS(domain)
 		} else {
 			checkErr = h.statusChecker.CheckDomain(domain)
@@ -1524,6 +3062,7 @@ func (h *AdminHandler) BulkCheckStatus(c *gin.Context) {
 			errors = append(errors, fmt.Sprintf("Domain %s: failed to update: %v", domain.Name, err))
 			continue
 		}
+		h.notifyTransitions(previous, *domain)
 
 		results = append(results, gin.H{
 			"domain_id":         domain.ID,
@@ -1536,7 +3075,7 @@ func (h *AdminHandler) BulkCheckStatus(c *gin.Context) {
 
 	response := gin.H{
 		"checked_count": len(results),
-		"total_count":   len(req.DomainIDs),
+		"total_count":   len(domainIDs),
 		"results":       results,
 	}
 
@@ -1544,20 +3083,22 @@ func (h *AdminHandler) BulkCheckStatus(c *gin.Context) {
 		response["errors"] = errors
 	}
 
-	c.JSON(http.StatusOK, response)
+	return response
 }
 
 // GetStatusSummary provides a summary of domain HTTP statuses
 func (h *AdminHandler) GetStatusSummary(c *gin.Context) {
-	// Get all domains
+	// Get all domains the user may reach
 	domains, err := h.domainRepo.GetAll()
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch domains"})
+		respondError(c, http.StatusInternalServerError, types.CodeInternal, "Failed to fetch domains")
 		return
 	}
+	domains = scopeDomains(c, domains)
 
 	// Generate status summary
 	summary := status.GetStatusSummary(domains)
+	summary.SLABreaches = loadResponseTimeSLA(h.domainRepo).Breaches(domains)
 
 	c.JSON(http.StatusOK, summary)
 }
@@ -1571,18 +3112,29 @@ func (h *AdminHandler) ListSupportedProviders(c *gin.Context) {
 	})
 }
 
+// GetProviderCapabilities returns the DNS limits (TTL range, record types, zone size) a provider enforces
+func (h *AdminHandler) GetProviderCapabilities(c *gin.Context) {
+	name := c.Param("name")
+	caps, ok := providers.GetCapabilities(name)
+	if !ok {
+		respondError(c, http.StatusNotFound, types.CodeNotFound, fmt.Sprintf("No capabilities known for provider: %s", name))
+		return
+	}
+	c.JSON(http.StatusOK, caps)
+}
+
 // TestProviderConnection tests provider credentials without saving
 func (h *AdminHandler) TestProviderConnection(c *gin.Context) {
 	var req types.ProviderConnectionRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Invalid request format")
 		return
 	}
 
 	// Test the connection
 	response, err := h.providerSvc.TestConnection(req.Provider, req.Credentials)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Test failed: %v", err)})
+		respondError(c, http.StatusInternalServerError, types.CodeInternal, fmt.Sprintf("Test failed: %v", err))
 		return
 	}
 
@@ -1592,18 +3144,36 @@ func (h *AdminHandler) TestProviderConnection(c *gin.Context) {
 	c.JSON(http.StatusOK, response)
 }
 
+// providerTestAllTimeout bounds POST /admin/providers/test-all; providers that haven't
+// answered by then are reported as failed
+const providerTestAllTimeout = 60 * time.Second
+
+// TestAllProviderConnections tests the credentials of every connected provider at once and
+// updates each provider's connection status from the outcome
+func (h *AdminHandler) TestAllProviderConnections(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), providerTestAllTimeout)
+	defer cancel()
+
+	report := h.providerSvc.TestConnectedProviders(ctx, providers.DefaultConnectionTestWorkers)
+	for _, result := range report.Results {
+		h.providerSvc.LogProviderConnection(result.Provider, result.AccountName, result.Success, result.Message)
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
 // ConnectProvider adds a new provider with credentials and optional auto-sync
 func (h *AdminHandler) ConnectProvider(c *gin.Context) {
 	var req types.ProviderConnectionRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Invalid request format")
 		return
 	}
 
 	// Use enhanced provider service to add connected provider
 	connectedProvider, err := h.providerSvc.AddConnectedProvider(&req)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondWithError(c, http.StatusBadRequest, err)
 		return
 	}
 
@@ -1635,31 +3205,14 @@ func (h *AdminHandler) ConnectProvider(c *gin.Context) {
 	}
 
 	// Run initial sync if requested
-	if req.AutoSync {
-		go func() {
-			syncFunc := func(client providers.RegistrarClient) ([]types.Domain, error) {
-				domains, err := client.FetchDomains()
-				if err != nil {
-					return nil, err
-				}
-				
-				// Save domains to repository
-				if len(domains) > 0 {
-					if err := h.domainRepo.UpsertDomains(domains); err != nil {
-						return domains, fmt.Errorf("failed to save domains: %w", err)
-					}
-				}
-				
-				return domains, nil
-			}
-			
-			if err := h.providerSvc.SyncProvider(connectedProvider.ID, syncFunc); err != nil {
-				log.Printf("Auto-sync failed for provider %s: %v", req.Name, err)
-			} else {
-				log.Printf("Auto-sync completed for provider %s", req.Name)
-			}
-		}()
-		response.SyncStarted = true
+	if req.AutoSync && h.jobs != nil {
+		job, err := h.jobs.Enqueue("provider_sync", requestActor(c), h.connectedProviderSyncJob(connectedProvider.ID, req.Name))
+		if err != nil {
+			log.Printf("Failed to queue initial sync for provider %s: %v", req.Name, err)
+		} else {
+			response.SyncStarted = true
+			response.SyncJobID = job.ID
+		}
 	}
 
 	c.JSON(http.StatusCreated, response)
@@ -1669,13 +3222,13 @@ func (h *AdminHandler) ConnectProvider(c *gin.Context) {
 func (h *AdminHandler) CreateCredentials(c *gin.Context) {
 	var creds types.ProviderCredentials
 	if err := c.ShouldBindJSON(&creds); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid credentials data"})
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Invalid credentials data")
 		return
 	}
 
 	if repo, ok := h.domainRepo.(interface{ CreateCredentials(*types.ProviderCredentials) error }); ok {
 		if err := repo.CreateCredentials(&creds); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			respondWithError(c, http.StatusInternalServerError, err)
 			return
 		}
 
@@ -1683,7 +3236,7 @@ func (h *AdminHandler) CreateCredentials(c *gin.Context) {
 		creds.Credentials = map[string]string{"***": "***"}
 		c.JSON(http.StatusCreated, creds)
 	} else {
-		c.JSON(http.StatusNotImplemented, gin.H{"error": "Credentials operations not implemented"})
+		respondError(c, http.StatusNotImplemented, types.CodeNotImplemented, "Credentials operations not implemented")
 	}
 }
 
@@ -1691,20 +3244,20 @@ func (h *AdminHandler) CreateCredentials(c *gin.Context) {
 func (h *AdminHandler) UpdateCredentials(c *gin.Context) {
 	id := c.Param("id")
 	if id == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Credentials ID required"})
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Credentials ID required")
 		return
 	}
 
 	var creds types.ProviderCredentials
 	if err := c.ShouldBindJSON(&creds); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid credentials data"})
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Invalid credentials data")
 		return
 	}
 
 	creds.ID = id
 	if repo, ok := h.domainRepo.(interface{ UpdateCredentials(*types.ProviderCredentials) error }); ok {
 		if err := repo.UpdateCredentials(&creds); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			respondWithError(c, http.StatusInternalServerError, err)
 			return
 		}
 
@@ -1712,7 +3265,7 @@ func (h *AdminHandler) UpdateCredentials(c *gin.Context) {
 		creds.Credentials = map[string]string{"***": "***"}
 		c.JSON(http.StatusOK, creds)
 	} else {
-		c.JSON(http.StatusNotImplemented, gin.H{"error": "Credentials operations not implemented"})
+		respondError(c, http.StatusNotImplemented, types.CodeNotImplemented, "Credentials operations not implemented")
 	}
 }
 
@@ -1720,26 +3273,26 @@ func (h *AdminHandler) UpdateCredentials(c *gin.Context) {
 func (h *AdminHandler) DeleteCredentials(c *gin.Context) {
 	id := c.Param("id")
 	if id == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Credentials ID required"})
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Credentials ID required")
 		return
 	}
 
 	if repo, ok := h.domainRepo.(interface{ DeleteCredentials(string) error }); ok {
 		if err := repo.DeleteCredentials(id); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			respondWithError(c, http.StatusInternalServerError, err)
 			return
 		}
 		c.JSON(http.StatusOK, gin.H{"message": "Credentials deleted successfully"})
 	} else {
-		c.JSON(http.StatusNotImplemented, gin.H{"error": "Credentials operations not implemented"})
+		respondError(c, http.StatusNotImplemented, types.CodeNotImplemented, "Credentials operations not implemented")
 	}
 }
 
 // GetPortfolioAnalytics retrieves aggregated domain portfolio analytics
 func (h *AdminHandler) GetPortfolioAnalytics(c *gin.Context) {
-	metrics, err := h.analyticsSvc.GetPortfolioMetrics()
+	metrics, err := h.analyticsSvc.GetPortfolioMetrics(requestScope(c))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondWithError(c, http.StatusInternalServerError, err)
 		return
 	}
 	c.JSON(http.StatusOK, metrics)
@@ -1748,14 +3301,46 @@ func (h *AdminHandler) GetPortfolioAnalytics(c *gin.Context) {
 // GetFinancialAnalytics retrieves financial analysis and metrics
 func (h *AdminHandler) GetFinancialAnalytics(c *gin.Context) {
 	// Example: Return a subset of financial metrics for demonstration
-	metrics, err := h.analyticsSvc.GetPortfolioMetrics()
+	metrics, err := h.analyticsSvc.GetPortfolioMetrics(requestScope(c))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondWithError(c, http.StatusInternalServerError, err)
 		return
 	}
 	c.JSON(http.StatusOK, metrics.FinancialMetrics)
 }
 
+// ExportFinancialReport downloads the renewal cost report as a PDF (?format=pdf, the default) or CSV
+func (h *AdminHandler) ExportFinancialReport(c *gin.Context) {
+	format := strings.ToLower(c.DefaultQuery("format", "pdf"))
+	if format != "pdf" && format != "csv" {
+		respondError(c, http.StatusBadRequest, types.CodeValidationFailed, "format must be pdf or csv")
+		return
+	}
+
+	report, err := h.analyticsSvc.GetFinancialReport(requestScope(c))
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	filename := fmt.Sprintf("domainvault-renewal-costs-%s.%s", report.GeneratedAt.Format("2006-01"), format)
+	var body []byte
+	contentType := "application/pdf"
+	if format == "csv" {
+		body, err = report.CSV()
+		contentType = "text/csv; charset=utf-8"
+	} else {
+		body, err = report.PDF()
+	}
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	c.Data(http.StatusOK, contentType, body)
+}
+
 // GetSecurityAnalytics retrieves security analysis and metrics
 func (h *AdminHandler) GetSecurityAnalytics(c *gin.Context) {
 	if h.securitySvc == nil {
@@ -1771,7 +3356,7 @@ func (h *AdminHandler) GetSecurityAnalytics(c *gin.Context) {
 	}
 	metrics, err := h.securitySvc.GetSecurityMetrics(30 * 24 * time.Hour) // Last 30 days
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondWithError(c, http.StatusInternalServerError, err)
 		return
 	}
 	c.JSON(http.StatusOK, metrics)
@@ -1779,41 +3364,103 @@ func (h *AdminHandler) GetSecurityAnalytics(c *gin.Context) {
 
 // GetTrendAnalytics retrieves historical trend analysis
 func (h *AdminHandler) GetTrendAnalytics(c *gin.Context) {
-	metrics, err := h.analyticsSvc.GetPortfolioMetrics()
+	metrics, err := h.analyticsSvc.GetPortfolioMetrics(requestScope(c))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondWithError(c, http.StatusInternalServerError, err)
 		return
 	}
 	c.JSON(http.StatusOK, metrics.TrendAnalysis)
 }
 
+// GetAnalyticsHistory returns a metric's daily series from the portfolio snapshots
+// (?metric=total_cost, ?period=90d; periods use d, w or y)
+func (h *AdminHandler) GetAnalyticsHistory(c *gin.Context) {
+	days, err := analytics.ParseHistoryPeriod(c.Query("period"))
+	if err != nil {
+		respondWithError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	history, err := h.analyticsSvc.GetMetricHistory(c.DefaultQuery("metric", types.MetricTotalDomains), days)
+	if err != nil {
+		if errors.Is(err, analytics.ErrUnknownMetric) {
+			respondWithErrorDetails(c, http.StatusBadRequest, err,
+				gin.H{"supported": append(types.SnapshotMetrics(), types.MetricStatusPrefix+"<status>")})
+			return
+		}
+		respondWithError(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusOK, history)
+}
+
 // GetNotificationRules retrieves all notification rules
 func (h *AdminHandler) GetNotificationRules(c *gin.Context) {
-	// Implementation for fetching notification rules
-	c.JSON(http.StatusOK, gin.H{"message": "Not yet implemented"})
+	rules := h.notificationSvc.GetRules()
+	c.JSON(http.StatusOK, gin.H{
+		"rules": rules,
+		"count": len(rules),
+	})
 }
 
-// CreateNotificationRule creates a new notification rule
+// CreateNotificationRule creates a new notification rule. Transition rules (status_transition,
+// http_status_transition) may set "from"/"to" conditions to match specific old and new values.
 func (h *AdminHandler) CreateNotificationRule(c *gin.Context) {
-	// Implementation for creating a new notification rule
-	c.JSON(http.StatusCreated, gin.H{"message": "Not yet implemented"})
+	var rule notifications.NotificationRule
+	if err := c.ShouldBindJSON(&rule); err != nil {
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Invalid request format")
+		return
+	}
+	if rule.Name == "" || len(rule.Channels) == 0 {
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Rule name and at least one channel are required")
+		return
+	}
+
+	rule.ID = ""
+	c.JSON(http.StatusCreated, h.notificationSvc.SaveRule(rule))
 }
 
 // UpdateNotificationRule updates an existing notification rule
 func (h *AdminHandler) UpdateNotificationRule(c *gin.Context) {
-	// Implementation for updating a notification rule
-	c.JSON(http.StatusOK, gin.H{"message": "Not yet implemented"})
+	id := c.Param("id")
+	exists := false
+	for _, existing := range h.notificationSvc.GetRules() {
+		if existing.ID == id {
+			exists = true
+			break
+		}
+	}
+	if !exists {
+		respondError(c, http.StatusNotFound, types.CodeNotFound, "Notification rule not found")
+		return
+	}
+
+	var rule notifications.NotificationRule
+	if err := c.ShouldBindJSON(&rule); err != nil {
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Invalid request format")
+		return
+	}
+	if rule.Name == "" || len(rule.Channels) == 0 {
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Rule name and at least one channel are required")
+		return
+	}
+
+	rule.ID = id
+	c.JSON(http.StatusOK, h.notificationSvc.SaveRule(rule))
 }
 
 // DeleteNotificationRule deletes a notification rule
 func (h *AdminHandler) DeleteNotificationRule(c *gin.Context) {
 	id := c.Param("id")
 	if id == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Rule ID required"})
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Rule ID required")
+		return
+	}
+	if !h.notificationSvc.DeleteRule(id) {
+		respondError(c, http.StatusNotFound, types.CodeNotFound, "Notification rule not found")
 		return
 	}
-	// Implementation for deleting a notification rule
-	c.JSON(http.StatusOK, gin.H{"message": "Not yet implemented"})
+	c.JSON(http.StatusOK, gin.H{"message": "Notification rule deleted"})
 }
 
 // TestNotification sends a test notification
@@ -1832,7 +3479,7 @@ func (h *AdminHandler) GetAlerts(c *gin.Context) {
 func (h *AdminHandler) ResolveAlert(c *gin.Context) {
 	id := c.Param("id")
 	if id == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Alert ID required"})
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Alert ID required")
 		return
 	}
 	// Implementation for resolving an alert
@@ -1965,7 +3612,7 @@ func (h *AdminHandler) GetSecurityMetrics(c *gin.Context) {
 	duration := time.Duration(period) * 24 * time.Hour
 	metrics, err := h.securitySvc.GetSecurityMetrics(duration)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondWithError(c, http.StatusInternalServerError, err)
 		return
 	}
 	c.JSON(http.StatusOK, metrics)
@@ -1981,7 +3628,7 @@ func (h *AdminHandler) GetSecurityAlerts(c *gin.Context) {
 func (h *AdminHandler) ResolveSecurityAlert(c *gin.Context) {
 	id := c.Param("id")
 	if id == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Alert ID required"})
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Alert ID required")
 		return
 	}
 	// Implementation for resolving a security alert
@@ -1998,16 +3645,128 @@ func (h *AdminHandler) GetActiveSessions(c *gin.Context) {
 func (h *AdminHandler) TerminateSession(c *gin.Context) {
 	id := c.Param("id")
 	if id == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Session ID required"})
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Session ID required")
 		return
 	}
 	// Implementation for terminating a session
 	c.JSON(http.StatusOK, gin.H{"message": "Session terminated successfully"})
 }
 
+// retentionPurger is implemented by repositories that can apply data retention
+type retentionPurger interface {
+	PurgeExpired(policy map[string]int, batchSize int, dryRun bool) ([]storage.RetentionResult, error)
+}
+
+// retentionPolicy returns the configured retention windows, or nil when retention can't be applied
+func (h *AdminHandler) retentionPolicy() (map[string]int, retentionPurger) {
+	purger, ok := h.domainRepo.(retentionPurger)
+	if !ok || h.securitySvc == nil {
+		return nil, nil
+	}
+	return h.securitySvc.RetentionPolicy(), purger
+}
+
+// GetRetention returns the data retention policy and how many rows each table would lose on the next cleanup
+func (h *AdminHandler) GetRetention(c *gin.Context) {
+	policy, purger := h.retentionPolicy()
+	if purger == nil {
+		respondError(c, http.StatusServiceUnavailable, types.CodeServiceUnavailable, "Data retention is not available")
+		return
+	}
+
+	results, err := purger.PurgeExpired(policy, storage.DefaultRetentionBatchSize, true)
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"policy":  policy,
+		"pending": results,
+	})
+}
+
+// RunRetentionCleanup deletes rows older than the retention policy; {"dry_run": true} only reports counts
+func (h *AdminHandler) RunRetentionCleanup(c *gin.Context) {
+	var req struct {
+		DryRun bool `json:"dry_run"`
+	}
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Invalid request format")
+			return
+		}
+	}
+
+	policy, purger := h.retentionPolicy()
+	if purger == nil {
+		respondError(c, http.StatusServiceUnavailable, types.CodeServiceUnavailable, "Data retention is not available")
+		return
+	}
+
+	results, err := purger.PurgeExpired(policy, storage.DefaultRetentionBatchSize, req.DryRun)
+	if err != nil {
+		respondWithErrorDetails(c, http.StatusInternalServerError, err, gin.H{"results": results})
+		return
+	}
+
+	var total int64
+	for _, result := range results {
+		total += result.Rows
+	}
+
+	if !req.DryRun {
+		userID, _ := c.Get("userID")
+		id, _ := userID.(string)
+		h.securitySvc.LogAuditEvent(security.EventSettingsChange, id, requestActor(c), c.ClientIP(),
+			c.GetHeader("User-Agent"), "retention", "cleanup", true, map[string]interface{}{"rows_deleted": total}, "")
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"dry_run": req.DryRun,
+		"total":   total,
+		"results": results,
+	})
+}
+
+// GetDetailedHealth reports database connectivity and connection pool usage along with sync state
+func (h *AdminHandler) GetDetailedHealth(c *gin.Context) {
+	response := gin.H{
+		"status":   "healthy",
+		"database": gin.H{"status": "connected"},
+		"sync":     h.syncSvc.GetStatus(),
+	}
+
+	statusCode := http.StatusOK
+	database := response["database"].(gin.H)
+	if err := h.domainRepo.Ping(); err != nil {
+		statusCode = http.StatusServiceUnavailable
+		response["status"] = "unhealthy"
+		database["status"] = "disconnected"
+		database["error"] = err.Error()
+	}
+
+	// Pool stats are only available from SQL-backed repositories
+	if pooled, ok := h.domainRepo.(interface{ PoolStats() sql.DBStats }); ok {
+		stats := pooled.PoolStats()
+		database["pool"] = gin.H{
+			"max_open_connections": stats.MaxOpenConnections,
+			"open_connections":     stats.OpenConnections,
+			"in_use":               stats.InUse,
+			"idle":                 stats.Idle,
+			"wait_count":           stats.WaitCount,
+			"wait_duration_ms":     stats.WaitDuration.Milliseconds(),
+			"max_idle_closed":      stats.MaxIdleClosed,
+			"max_lifetime_closed":  stats.MaxLifetimeClosed,
+		}
+	}
+
+	c.JSON(statusCode, response)
+}
+
 // Bulk DNS Management Handlers
 
-// BulkAssignIP assigns the same IP address to multiple domains
+// BulkAssignIP assigns the same IP address to multiple domains; each operation names its domain by name or ID
 func (h *AdminHandler) BulkAssignIP(c *gin.Context) {
 	var req struct {
 		Password   string `json:"password" binding:"required"`
@@ -2020,58 +3779,107 @@ func (h *AdminHandler) BulkAssignIP(c *gin.Context) {
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format: " + err.Error()})
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Invalid request format: " + err.Error())
+		return
+	}
+
+	operations := make([]bulkAddressOperation, len(req.Operations))
+	for i, op := range req.Operations {
+		operations[i] = bulkAddressOperation(op)
+	}
+	h.bulkAssignAddresses(c, "A", req.Password, operations)
+}
+
+// BulkAssignIPv6 assigns the same IPv6 address to multiple domains as AAAA records; each operation names its domain by name or ID
+func (h *AdminHandler) BulkAssignIPv6(c *gin.Context) {
+	var req struct {
+		Password   string `json:"password" binding:"required"`
+		Operations []struct {
+			DomainName string `json:"domain_name" binding:"required"`
+			RecordName string `json:"record_name" binding:"required"`
+			IPAddress  string `json:"ip_address" binding:"required,ipv6"`
+			TTL        int    `json:"ttl" binding:"required,min=60,max=604800"`
+		} `json:"operations" binding:"required,min=1"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Invalid request format: "+err.Error())
+		return
+	}
+
+	operations := make([]bulkAddressOperation, len(req.Operations))
+	for i, op := range req.Operations {
+		operations[i] = bulkAddressOperation(op)
+	}
+	h.bulkAssignAddresses(c, "AAAA", req.Password, operations)
+}
+
+// bulkAddressOperation points a record name on a domain at an IP address
+type bulkAddressOperation struct {
+	DomainName string
+	RecordName string
+	IPAddress  string
+	TTL        int
+}
+
+// bulkAssignAddresses creates or updates an address record of recordType (A or AAAA) for each
+// operation after verifying the admin's password, reporting the outcome per domain
+func (h *AdminHandler) bulkAssignAddresses(c *gin.Context, recordType, password string, operations []bulkAddressOperation) {
+	if !checkBulkOperations(c, h.requestLimits, len(operations)) {
 		return
 	}
 
 	// Verify admin password for security
 	userID, exists := c.Get("userID")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		respondError(c, http.StatusUnauthorized, types.CodeUnauthorized, "User not authenticated")
 		return
 	}
 
 	// Verify password (this would need to be implemented in auth service)
-	if !h.authSvc.VerifyCurrentUserPassword(userID.(string), req.Password) {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid password"})
+	if !h.authSvc.VerifyCurrentUserPassword(userID.(string), password) {
+		respondError(c, http.StatusUnauthorized, types.CodeUnauthorized, "Invalid password")
 		return
 	}
 
 	// Log the bulk operation for security audit
-	log.Printf("Bulk IP assignment initiated by user %s for %d domains", userID, len(req.Operations))
+	log.Printf("Bulk %s assignment initiated by user %s for %d domains", recordType, userID, len(operations))
 
-	results := make([]map[string]interface{}, 0, len(req.Operations))
+	results := make([]map[string]interface{}, 0, len(operations))
 	successCount := 0
 	errorCount := 0
 
-	for _, op := range req.Operations {
+	for _, op := range operations {
 		result := map[string]interface{}{
 			"domain_name": op.DomainName,
 			"success":     false,
 			"error":       nil,
 		}
 
-		// Get domain ID from domain name
-		domains, err := h.domainRepo.GetDomainsByName(op.DomainName)
-		if err != nil || len(domains) == 0 {
-			result["error"] = fmt.Sprintf("Domain %s not found", op.DomainName)
+		// The domain may be given by ID or name
+		domain, err := resolveDomainRef(h.domainRepo, op.DomainName)
+		if err == nil {
+			err = checkDomainScope(c, domain)
+		}
+		if err != nil {
+			result["error"] = err.Error()
 			errorCount++
 			results = append(results, result)
 			continue
 		}
 
-		domainID := domains[0].ID
+		domainID := domain.ID
 
 		// Create DNS record
 		dnsRecord := types.DNSRecord{
 			DomainID: domainID,
-			Type:     "A",
+			Type:     recordType,
 			Name:     op.RecordName,
 			Value:    op.IPAddress,
 			TTL:      op.TTL,
 		}
 
-		err = h.dnsSvc.CreateOrUpdateRecord(dnsRecord)
+		err = h.dnsSvc.WithActor(requestActor(c)).CreateOrUpdateRecord(dnsRecord)
 		if err != nil {
 			result["error"] = err.Error()
 			errorCount++
@@ -2083,261 +3891,910 @@ func (h *AdminHandler) BulkAssignIP(c *gin.Context) {
 		results = append(results, result)
 	}
 
+	label := "IP"
+	if recordType == "AAAA" {
+		label = "IPv6"
+	}
 	c.JSON(http.StatusOK, gin.H{
-		"message":      fmt.Sprintf("Bulk IP assignment completed: %d successful, %d failed", successCount, errorCount),
+		"message":      fmt.Sprintf("Bulk %s assignment completed: %d successful, %d failed", label, successCount, errorCount),
 		"success_count": successCount,
 		"error_count":   errorCount,
 		"results":       results,
 	})
 }
 
-// BulkUpdateNameservers updates nameservers for multiple domains
-func (h *AdminHandler) BulkUpdateNameservers(c *gin.Context) {
+// BulkUpdateDNSByPattern sets a record's value on every domain matching a project/category/tag filter
+func (h *AdminHandler) BulkUpdateDNSByPattern(c *gin.Context) {
 	var req struct {
-		Password   string `json:"password" binding:"required"`
-		Operations []struct {
-			DomainName  string   `json:"domain_name" binding:"required"`
-			Nameservers []string `json:"nameservers" binding:"required,min=2"`
-		} `json:"operations" binding:"required,min=1"`
+		Password string `json:"password" binding:"required"`
+		Filter   struct {
+			ProjectID  *string `json:"project_id"`
+			CategoryID *string `json:"category_id"`
+			Tag        string  `json:"tag"`
+			Provider   string  `json:"provider"`
+		} `json:"filter"`
+		Record struct {
+			Type string `json:"type" binding:"required"`
+			Name string `json:"name" binding:"required"`
+		} `json:"record"`
+		Value         string `json:"value" binding:"required"`
+		TTL           int    `json:"ttl"`
+		CreateMissing bool   `json:"create_missing"` // Create the record on matching domains that don't have it
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format: " + err.Error()})
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Invalid request format: " + err.Error())
+		return
+	}
+
+	// Refuse to touch the whole portfolio by accident
+	if req.Filter.ProjectID == nil && req.Filter.CategoryID == nil && req.Filter.Tag == "" && req.Filter.Provider == "" {
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "At least one filter (project_id, category_id, tag, provider) is required")
+		return
+	}
+	if req.TTL != 0 && (req.TTL < 60 || req.TTL > 604800) {
+		respondError(c, http.StatusBadRequest, types.CodeInvalidDNSRecord, "TTL must be between 60 and 604800")
 		return
 	}
 
 	// Verify admin password for security
 	userID, exists := c.Get("userID")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		respondError(c, http.StatusUnauthorized, types.CodeUnauthorized, "User not authenticated")
 		return
 	}
 
-	// Verify password
 	if !h.authSvc.VerifyCurrentUserPassword(userID.(string), req.Password) {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid password"})
+		respondError(c, http.StatusUnauthorized, types.CodeUnauthorized, "Invalid password")
 		return
 	}
 
-	// Log the bulk operation for security audit
-	log.Printf("Bulk nameserver update initiated by user %s for %d domains", userID, len(req.Operations))
+	domains, err := h.domainRepo.GetByFilter(types.DomainFilter{
+		Provider:   req.Filter.Provider,
+		CategoryID: req.Filter.CategoryID,
+		ProjectID:  req.Filter.ProjectID,
+	})
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err)
+		return
+	}
 
-	results := make([]map[string]interface{}, 0, len(req.Operations))
-	successCount := 0
-	errorCount := 0
+	recordType := strings.ToUpper(req.Record.Type)
+	dnsSvc := h.dnsSvc.WithActor(requestActor(c))
+
+	log.Printf("Bulk DNS pattern update initiated by user %s: %s %s -> %s", userID, recordType, req.Record.Name, req.Value)
+
+	results := make([]map[string]interface{}, 0, len(domains))
+	successCount, skippedCount, errorCount := 0, 0, 0
+
+	for _, domain := range domains {
+		if req.Filter.Tag != "" && !hasTag(domain.Tags, req.Filter.Tag) {
+			continue
+		}
 
-	for _, op := range req.Operations {
 		result := map[string]interface{}{
-			"domain_name": op.DomainName,
+			"domain_id":   domain.ID,
+			"domain_name": domain.Name,
 			"success":     false,
 			"error":       nil,
 		}
 
-		// Get domain ID from domain name
-		domains, err := h.domainRepo.GetDomainsByName(op.DomainName)
-		if err != nil || len(domains) == 0 {
-			result["error"] = fmt.Sprintf("Domain %s not found", op.DomainName)
+		records, err := dnsSvc.GetDomainRecords(domain.ID)
+		if err != nil {
+			result["error"] = err.Error()
 			errorCount++
 			results = append(results, result)
 			continue
 		}
 
-		domainID := domains[0].ID
+		var matches []types.DNSRecord
+		for _, record := range records {
+			if strings.EqualFold(record.Type, recordType) && strings.EqualFold(record.Name, req.Record.Name) {
+				matches = append(matches, record)
+			}
+		}
 
-		// Update nameservers for the domain
-		// First, remove existing NS records
-		existingRecords, err := h.dnsSvc.GetDomainRecords(domainID)
-		if err != nil {
-			result["error"] = fmt.Sprintf("Failed to get existing records: %v", err)
+		if len(matches) == 0 {
+			if !req.CreateMissing {
+				result["skipped"] = true
+				result["error"] = "No matching record"
+				skippedCount++
+				results = append(results, result)
+				continue
+			}
+			ttl := req.TTL
+			if ttl == 0 {
+				ttl = 3600
+			}
+			record := &types.DNSRecord{DomainID: domain.ID, Type: recordType, Name: req.Record.Name, Value: req.Value, TTL: ttl}
+			if err := dnsSvc.CreateRecord(record); err != nil {
+				result["error"] = err.Error()
+				errorCount++
+			} else {
+				result["success"] = true
+				result["action"] = "created"
+				successCount++
+			}
+			results = append(results, result)
+			continue
+		}
+
+		// Point the first match at the new value and drop any other values so the name resolves only there
+		record := matches[0]
+		record.Value = req.Value
+		if req.TTL != 0 {
+			record.TTL = req.TTL
+		}
+		if err := dnsSvc.UpdateRecord(&record); err != nil {
+			result["error"] = err.Error()
 			errorCount++
 			results = append(results, result)
 			continue
 		}
 
-		// Remove existing NS records
-		for _, record := range existingRecords {
-			if record.Type == "NS" {
-				h.dnsSvc.DeleteRecord(record.ID)
+		removed := 0
+		for _, extra := range matches[1:] {
+			if err := dnsSvc.DeleteRecord(extra.ID); err != nil {
+				result["error"] = fmt.Sprintf("updated, but failed to remove extra record %s: %v", extra.ID, err)
+				continue
 			}
+			removed++
 		}
 
-		// Add new NS records
-		nsSuccess := true
-		for _, ns := range op.Nameservers {
-			dnsRecord := types.DNSRecord{
-				DomainID: domainID,
-				Type:     "NS",
-				Name:     "@",
-				Value:    ns,
-				TTL:      86400, // 24 hours default for NS records
-			}
+		result["success"] = true
+		result["action"] = "updated"
+		result["removed"] = removed
+		successCount++
+		results = append(results, result)
+	}
 
-			if err := h.dnsSvc.CreateOrUpdateRecord(dnsRecord); err != nil {
-				result["error"] = fmt.Sprintf("Failed to create NS record for %s: %v", ns, err)
-				nsSuccess = false
-				break
-			}
-		}
+	c.JSON(http.StatusOK, gin.H{
+		"message":       fmt.Sprintf("Bulk DNS update completed: %d successful, %d skipped, %d failed", successCount, skippedCount, errorCount),
+		"success_count": successCount,
+		"skipped_count": skippedCount,
+		"error_count":   errorCount,
+		"results":       results,
+	})
+}
 
-		if nsSuccess {
-			result["success"] = true
-			successCount++
-		} else {
-			errorCount++
+// BulkDeleteDNSRecords deletes the records matching a type, name pattern and value substring on
+// every domain matching a project/category/tag/provider filter, e.g. to clear out an old
+// verification token across a project
+func (h *AdminHandler) BulkDeleteDNSRecords(c *gin.Context) {
+	var req struct {
+		Password string `json:"password" binding:"required"`
+		Filter   struct {
+			ProjectID  *string `json:"project_id"`
+			CategoryID *string `json:"category_id"`
+			Tag        string  `json:"tag"`
+			Provider   string  `json:"provider"`
+		} `json:"filter"`
+		Match dns.RecordMatcher `json:"match"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Invalid request format: "+err.Error())
+		return
+	}
+
+	// Refuse to touch the whole portfolio by accident
+	if req.Filter.ProjectID == nil && req.Filter.CategoryID == nil && req.Filter.Tag == "" && req.Filter.Provider == "" {
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "At least one filter (project_id, category_id, tag, provider) is required")
+		return
+	}
+	if err := req.Match.Validate(); err != nil {
+		respondWithError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	// Verify admin password for security
+	userID, exists := c.Get("userID")
+	if !exists {
+		respondError(c, http.StatusUnauthorized, types.CodeUnauthorized, "User not authenticated")
+		return
+	}
+
+	if !h.authSvc.VerifyCurrentUserPassword(userID.(string), req.Password) {
+		respondError(c, http.StatusUnauthorized, types.CodeUnauthorized, "Invalid password")
+		return
+	}
+
+	domains, err := h.domainRepo.GetByFilter(types.DomainFilter{
+		Provider:   req.Filter.Provider,
+		CategoryID: req.Filter.CategoryID,
+		ProjectID:  req.Filter.ProjectID,
+	})
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	dnsSvc := h.dnsSvc.WithActor(requestActor(c))
+	log.Printf("Bulk DNS delete initiated by user %s: type=%q name=%q value contains %q",
+		userID, req.Match.Type, req.Match.NamePattern, req.Match.ValueContains)
+
+	results := make([]map[string]interface{}, 0, len(domains))
+	domainCount, deletedCount, errorCount := 0, 0, 0
+
+	for _, domain := range domains {
+		if req.Filter.Tag != "" && !hasTag(domain.Tags, req.Filter.Tag) {
+			continue
+		}
+		domainCount++
+
+		deleted, err := dnsSvc.DeleteMatching(domain.ID, req.Match)
+		result := map[string]interface{}{
+			"domain_id":   domain.ID,
+			"domain_name": domain.Name,
+			"deleted":     len(deleted),
+			"error":       nil,
+		}
+		if err != nil {
+			result["error"] = err.Error()
+			errorCount++
+		}
+		deletedCount += len(deleted)
+		results = append(results, result)
+	}
+
+	if h.securitySvc != nil {
+		id, _ := userID.(string)
+		h.securitySvc.LogAuditEvent(security.EventBulkOperation, id, requestActor(c), c.ClientIP(),
+			c.GetHeader("User-Agent"), "dns", "bulk_delete", errorCount == 0, map[string]interface{}{
+				"filter":        req.Filter,
+				"match":         req.Match,
+				"domain_count":  domainCount,
+				"deleted_count": deletedCount,
+				"error_count":   errorCount,
+			}, "")
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":       fmt.Sprintf("Bulk DNS delete completed: %d records deleted across %d domains, %d failed", deletedCount, domainCount, errorCount),
+		"domain_count":  domainCount,
+		"deleted_count": deletedCount,
+		"error_count":   errorCount,
+		"results":       results,
+	})
+}
+
+// hasTag reports whether tags contains tag, ignoring case
+func hasTag(tags types.TagsSlice, tag string) bool {
+	for _, t := range tags {
+		if strings.EqualFold(t, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// BulkUpdateNameservers updates nameservers for multiple domains, each given by name or ID
+func (h *AdminHandler) BulkUpdateNameservers(c *gin.Context) {
+	var req struct {
+		Password   string `json:"password" binding:"required"`
+		Operations []struct {
+			DomainName  string   `json:"domain_name" binding:"required"`
+			Nameservers []string `json:"nameservers" binding:"required,min=2"`
+		} `json:"operations" binding:"required,min=1"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Invalid request format: " + err.Error())
+		return
+	}
+	if !checkBulkOperations(c, h.requestLimits, len(req.Operations)) {
+		return
+	}
+
+	// Verify admin password for security
+	userID, exists := c.Get("userID")
+	if !exists {
+		respondError(c, http.StatusUnauthorized, types.CodeUnauthorized, "User not authenticated")
+		return
+	}
+
+	// Verify password
+	if !h.authSvc.VerifyCurrentUserPassword(userID.(string), req.Password) {
+		respondError(c, http.StatusUnauthorized, types.CodeUnauthorized, "Invalid password")
+		return
+	}
+
+	// Log the bulk operation for security audit
+	log.Printf("Bulk nameserver update initiated by user %s for %d domains", userID, len(req.Operations))
+
+	results := make([]map[string]interface{}, 0, len(req.Operations))
+	successCount := 0
+	errorCount := 0
+
+	for _, op := range req.Operations {
+		result := map[string]interface{}{
+			"domain_name": op.DomainName,
+			"success":     false,
+			"error":       nil,
+		}
+
+		// The domain may be given by ID or name
+		domain, err := resolveDomainRef(h.domainRepo, op.DomainName)
+		if err == nil {
+			err = checkDomainScope(c, domain)
+		}
+		if err != nil {
+			result["error"] = err.Error()
+			errorCount++
+			results = append(results, result)
+			continue
+		}
+
+		domainID := domain.ID
+
+		// Update nameservers for the domain
+		// First, remove existing NS records
+		existingRecords, err := h.dnsSvc.GetDomainRecords(domainID)
+		if err != nil {
+			result["error"] = fmt.Sprintf("Failed to get existing records: %v", err)
+			errorCount++
+			results = append(results, result)
+			continue
+		}
+
+		// Remove existing NS records
+		for _, record := range existingRecords {
+			if record.Type == "NS" {
+				h.dnsSvc.WithActor(requestActor(c)).DeleteRecord(record.ID)
+			}
+		}
+
+		// Add new NS records
+		nsSuccess := true
+		for _, ns := range op.Nameservers {
+			dnsRecord := types.DNSRecord{
+				DomainID: domainID,
+				Type:     "NS",
+				Name:     "@",
+				Value:    ns,
+				TTL:      86400, // 24 hours default for NS records
+			}
+
+			if err := h.dnsSvc.WithActor(requestActor(c)).CreateOrUpdateRecord(dnsRecord); err != nil {
+				result["error"] = fmt.Sprintf("Failed to create NS record for %s: %v", ns, err)
+				nsSuccess = false
+				break
+			}
+		}
+
+		if nsSuccess {
+			result["success"] = true
+			successCount++
+		} else {
+			errorCount++
+		}
+
+		results = append(results, result)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":      fmt.Sprintf("Bulk nameserver update completed: %d successful, %d failed", successCount, errorCount),
+		"success_count": successCount,
+		"error_count":   errorCount,
+		"results":       results,
+	})
+}
+
+// BulkUpdateFromCSV processes bulk DNS updates from CSV data; each row's domain may be a name or an ID
+func (h *AdminHandler) BulkUpdateFromCSV(c *gin.Context) {
+	var req struct {
+		Password string `json:"password" binding:"required"`
+		CSVData  []struct {
+			Domain      string `json:"domain" binding:"required"`
+			RecordType  string `json:"record_type"`
+			Name        string `json:"name"`
+			Value       string `json:"value"`
+			TTL         string `json:"ttl"`
+			Nameserver1 string `json:"nameserver1"`
+			Nameserver2 string `json:"nameserver2"`
+		} `json:"csv_data" binding:"required,min=1"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Invalid request format: " + err.Error())
+		return
+	}
+	if !checkBulkOperations(c, h.requestLimits, len(req.CSVData)) {
+		return
+	}
+
+	// Verify admin password for security
+	userID, exists := c.Get("userID")
+	if !exists {
+		respondError(c, http.StatusUnauthorized, types.CodeUnauthorized, "User not authenticated")
+		return
+	}
+
+	// Verify password
+	if !h.authSvc.VerifyCurrentUserPassword(userID.(string), req.Password) {
+		respondError(c, http.StatusUnauthorized, types.CodeUnauthorized, "Invalid password")
+		return
+	}
+
+	// Log the bulk operation for security audit
+	log.Printf("Bulk CSV update initiated by user %s for %d rows", userID, len(req.CSVData))
+
+	results := make([]map[string]interface{}, 0, len(req.CSVData))
+	successCount := 0
+	errorCount := 0
+
+	for _, row := range req.CSVData {
+		result := map[string]interface{}{
+			"domain":  row.Domain,
+			"success": false,
+			"error":   nil,
+		}
+
+		// The domain may be given by ID or name
+		domain, err := resolveDomainRef(h.domainRepo, row.Domain)
+		if err == nil {
+			err = checkDomainScope(c, domain)
+		}
+		if err != nil {
+			result["error"] = err.Error()
+			errorCount++
+			results = append(results, result)
+			continue
+		}
+
+		domainID := domain.ID
+
+		// Process DNS record if provided
+		if row.RecordType != "" && row.Name != "" && row.Value != "" {
+			ttl := 3600 // default TTL
+			if row.TTL != "" {
+				if parsedTTL, err := strconv.Atoi(row.TTL); err == nil {
+					ttl = parsedTTL
+				}
+			}
+
+			dnsRecord := types.DNSRecord{
+				DomainID: domainID,
+				Type:     row.RecordType,
+				Name:     row.Name,
+				Value:    row.Value,
+				TTL:      ttl,
+			}
+
+			if err := h.dnsSvc.WithActor(requestActor(c)).CreateOrUpdateRecord(dnsRecord); err != nil {
+				result["error"] = fmt.Sprintf("Failed to create/update DNS record: %v", err)
+				errorCount++
+				results = append(results, result)
+				continue
+			}
+		}
+
+		// Process nameservers if provided
+		if row.Nameserver1 != "" && row.Nameserver2 != "" {
+			// Remove existing NS records
+			existingRecords, err := h.dnsSvc.GetDomainRecords(domainID)
+			if err == nil {
+				for _, record := range existingRecords {
+					if record.Type == "NS" {
+						h.dnsSvc.WithActor(requestActor(c)).DeleteRecord(record.ID)
+					}
+				}
+			}
+
+			// Add new nameservers
+			nameservers := []string{row.Nameserver1, row.Nameserver2}
+			for _, ns := range nameservers {
+				if ns != "" {
+					dnsRecord := types.DNSRecord{
+						DomainID: domainID,
+						Type:     "NS",
+						Name:     "@",
+						Value:    ns,
+						TTL:      86400,
+					}
+
+					if err := h.dnsSvc.WithActor(requestActor(c)).CreateOrUpdateRecord(dnsRecord); err != nil {
+						result["error"] = fmt.Sprintf("Failed to create NS record: %v", err)
+						errorCount++
+						results = append(results, result)
+						continue
+					}
+				}
+			}
+		}
+
+		result["success"] = true
+		successCount++
+		results = append(results, result)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":      fmt.Sprintf("Bulk CSV update completed: %d successful, %d failed", successCount, errorCount),
+		"success_count": successCount,
+		"error_count":   errorCount,
+		"results":       results,
+	})
+}
+
+// csvImportDateLayouts are the expiry date formats accepted by ImportDomainsCSV
+var csvImportDateLayouts = []string{time.RFC3339, "2006-01-02", "2006/01/02", "01/02/2006", "02 Jan 2006"}
+
+// ImportDomainsCSV imports domains from a spreadsheet export. The CSV is read from a
+// multipart "file" field or the raw request body and must have a header row with at
+// least a name column; provider, expires_at, auto_renew, renewal_price, category,
+// project and tags are optional. Categories and projects may be given by name or ID.
+// Invalid rows are reported individually and do not abort the rest of the import.
+// With ?async=true the valid rows are stored by a background job.
+func (h *AdminHandler) ImportDomainsCSV(c *gin.Context) {
+	var reader io.Reader = c.Request.Body
+	if file, _, err := c.Request.FormFile("file"); err == nil {
+		defer file.Close()
+		reader = file
+	}
+
+	csvReader := csv.NewReader(reader)
+	csvReader.FieldsPerRecord = -1
+	csvReader.TrimLeadingSpace = true
+
+	header, err := csvReader.Read()
+	if err != nil {
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Failed to read CSV header: " + err.Error())
+		return
+	}
+	columns := make(map[string]int, len(header))
+	for i, col := range header {
+		columns[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(col, "\ufeff")))] = i
+	}
+	if _, ok := columns["name"]; !ok {
+		respondError(c, http.StatusBadRequest, types.CodeValidationFailed, "CSV header must include a name column")
+		return
+	}
+
+	// Resolve categories and projects by lower-cased name or ID
+	categoryIDs := make(map[string]string)
+	if categories, err := h.domainRepo.GetAllCategories(); err == nil {
+		for _, cat := range categories {
+			categoryIDs[strings.ToLower(cat.Name)] = cat.ID
+			categoryIDs[strings.ToLower(cat.ID)] = cat.ID
+		}
+	}
+	projectIDs := make(map[string]string)
+	if projects, err := h.domainRepo.GetAllProjects(); err == nil {
+		for _, proj := range projects {
+			projectIDs[strings.ToLower(proj.Name)] = proj.ID
+			projectIDs[strings.ToLower(proj.ID)] = proj.ID
+		}
+	}
+
+	type rowError struct {
+		Row    int    `json:"row"`
+		Domain string `json:"domain,omitempty"`
+		Error  string `json:"error"`
+	}
+
+	var (
+		toUpsert []types.Domain
+		rowErrs  []rowError
+		inserted int
+		updated  int
+		skipped  int
+	)
+	fieldOf := func(record []string, name string) string {
+		if i, ok := columns[name]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	// Read every row first so the stored domains can be looked up in a single query
+	type csvRow struct {
+		num    int
+		name   string
+		record []string
+	}
+	var rows []csvRow
+	seen := make(map[string]bool)
+
+	for rowNum := 2; ; rowNum++ {
+		record, err := csvReader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		var parseErr *csv.ParseError
+		if err != nil && !errors.As(err, &parseErr) {
+			respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Failed to read CSV: "+err.Error())
+			return
+		}
+		if !checkBulkOperations(c, h.requestLimits, rowNum-1) {
+			return
+		}
+		if err != nil {
+			rowErrs = append(rowErrs, rowError{Row: rowNum, Error: "Malformed CSV row: " + err.Error()})
+			continue
+		}
+
+		name := strings.ToLower(strings.TrimSuffix(fieldOf(record, "name"), "."))
+		if name == "" {
+			skipped++ // Blank rows are common at the end of spreadsheet exports
+			continue
+		}
+		if seen[name] {
+			skipped++
+			rowErrs = append(rowErrs, rowError{Row: rowNum, Domain: name, Error: "Duplicate domain in CSV; only the first row was imported"})
+			continue
+		}
+		seen[name] = true
+		rows = append(rows, csvRow{num: rowNum, name: name, record: record})
+	}
+
+	names := make([]string, 0, len(rows))
+	for _, row := range rows {
+		names = append(names, row.name)
+	}
+	storedDomains, err := h.domainRepo.GetDomainsByNames(names)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, types.CodeInternal, "Failed to look up existing domains: "+err.Error())
+		return
+	}
+	stored := make(map[string]types.Domain, len(storedDomains))
+	for _, domain := range storedDomains {
+		if _, ok := stored[domain.Name]; !ok {
+			stored[domain.Name] = domain
+		}
+	}
+
+	for _, row := range rows {
+		rowNum, name, record := row.num, row.name, row.record
+		field := func(name string) string { return fieldOf(record, name) }
+
+		// Start from the stored domain so columns absent from the CSV keep their values
+		domain, isUpdate := stored[name]
+		if !isUpdate {
+			domain = types.Domain{Name: name, Status: "active", Visible: true}
+		}
+
+		var problems []string
+		if provider := field("provider"); provider != "" {
+			domain.Provider = strings.ToLower(provider)
+		} else if !isUpdate {
+			domain.Provider = "manual"
+		}
+
+		if expires := field("expires_at"); expires != "" {
+			parsed := false
+			for _, layout := range csvImportDateLayouts {
+				if t, err := time.Parse(layout, expires); err == nil {
+					domain.ExpiresAt = t
+					parsed = true
+					break
+				}
+			}
+			if !parsed {
+				problems = append(problems, fmt.Sprintf("invalid expires_at %q (use YYYY-MM-DD)", expires))
+			}
+		} else if !isUpdate {
+			problems = append(problems, "expires_at is required for new domains")
+		}
+
+		if autoRenew := field("auto_renew"); autoRenew != "" {
+			switch strings.ToLower(autoRenew) {
+			case "1", "true", "yes", "y", "on":
+				domain.AutoRenew = true
+			case "0", "false", "no", "n", "off":
+				domain.AutoRenew = false
+			default:
+				problems = append(problems, fmt.Sprintf("invalid auto_renew %q", autoRenew))
+			}
+		}
+
+		if price := field("renewal_price"); price != "" {
+			value, err := strconv.ParseFloat(strings.TrimLeft(price, "$€£"), 64)
+			if err != nil || value < 0 {
+				problems = append(problems, fmt.Sprintf("invalid renewal_price %q", price))
+			} else {
+				domain.RenewalPrice = &value
+			}
+		}
+
+		if category := field("category"); category != "" {
+			if id, ok := categoryIDs[strings.ToLower(category)]; ok {
+				domain.CategoryID = &id
+			} else {
+				problems = append(problems, fmt.Sprintf("unknown category %q", category))
+			}
+		}
+
+		if project := field("project"); project != "" {
+			if id, ok := projectIDs[strings.ToLower(project)]; ok {
+				domain.ProjectID = &id
+			} else {
+				problems = append(problems, fmt.Sprintf("unknown project %q", project))
+			}
+		}
+
+		if tags := field("tags"); tags != "" {
+			var parsedTags types.TagsSlice
+			for _, tag := range strings.FieldsFunc(tags, func(r rune) bool { return r == ';' || r == ',' || r == '|' }) {
+				if tag = strings.TrimSpace(tag); tag != "" {
+					parsedTags = append(parsedTags, tag)
+				}
+			}
+			if err := parsedTags.Validate(); err != nil {
+				problems = append(problems, err.Error())
+			}
+			domain.Tags = parsedTags
+		}
+
+		if len(problems) > 0 {
+			rowErrs = append(rowErrs, rowError{Row: rowNum, Domain: name, Error: strings.Join(problems, "; ")})
+			continue
+		}
+
+		toUpsert = append(toUpsert, domain)
+		if isUpdate {
+			updated++
+		} else {
+			inserted++
+		}
+	}
+
+	// Malformed rows were reported while reading, before the rows above them were validated
+	sort.SliceStable(rowErrs, func(i, j int) bool { return rowErrs[i].Row < rowErrs[j].Row })
+
+	// Rows start from their stored domain, so the rules only fill in what the CSV left empty
+	categorized, err := core.CategorizeDomains(h.domainRepo, toUpsert, nil)
+	if err != nil {
+		log.Printf("Skipping categorization rules for CSV import: %v", err)
+	}
+
+	userID, _ := c.Get("userID")
+	result := gin.H{
+		"message":     fmt.Sprintf("CSV import completed: %d inserted, %d updated, %d skipped, %d invalid", inserted, updated, skipped, len(rowErrs)),
+		"inserted":    inserted,
+		"updated":     updated,
+		"skipped":     skipped,
+		"categorized": categorized,
+		"error_count": len(rowErrs),
+		"errors":      rowErrs,
+	}
+	store := func() error {
+		if _, err := h.domainRepo.UpsertDomains(toUpsert); err != nil {
+			return err
+		}
+		log.Printf("CSV domain import by user %v: %d inserted, %d updated, %d skipped, %d errors", userID, inserted, updated, skipped, len(rowErrs))
+		return nil
+	}
+
+	// The CSV is parsed within the request; ?async=true stores the rows in a background job
+	if c.Query("async") == "true" {
+		job, ok := enqueueJob(c, h.jobs, "domain_import", func(ctx context.Context, tracker *jobs.Tracker) (interface{}, error) {
+			tracker.SetTotal(len(toUpsert))
+			if err := store(); err != nil {
+				return nil, fmt.Errorf("failed to import domains: %w", err)
+			}
+			tracker.Advance(len(toUpsert), "")
+			return result, nil
+		})
+		if ok {
+			c.JSON(http.StatusAccepted, jobAccepted(job, gin.H{"message": fmt.Sprintf("CSV import of %d domains queued", len(toUpsert))}))
 		}
-
-		results = append(results, result)
+		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"message":      fmt.Sprintf("Bulk nameserver update completed: %d successful, %d failed", successCount, errorCount),
-		"success_count": successCount,
-		"error_count":   errorCount,
-		"results":       results,
-	})
+	if err := store(); err != nil {
+		respondError(c, http.StatusInternalServerError, types.CodeInternal, "Failed to import domains: " + err.Error())
+		return
+	}
+	c.JSON(http.StatusOK, result)
 }
 
-// BulkUpdateFromCSV processes bulk DNS updates from CSV data
-func (h *AdminHandler) BulkUpdateFromCSV(c *gin.Context) {
+// SyncDomainAutoRenew compares a domain's auto-renew setting with the registrar and reports drift.
+// With {"push": true} our stored value is written to the registrar when they differ.
+func (h *AdminHandler) SyncDomainAutoRenew(c *gin.Context) {
+	domainID := c.Param("id")
+
 	var req struct {
-		Password string `json:"password" binding:"required"`
-		CSVData  []struct {
-			Domain      string `json:"domain" binding:"required"`
-			RecordType  string `json:"record_type"`
-			Name        string `json:"name"`
-			Value       string `json:"value"`
-			TTL         string `json:"ttl"`
-			Nameserver1 string `json:"nameserver1"`
-			Nameserver2 string `json:"nameserver2"`
-		} `json:"csv_data" binding:"required,min=1"`
+		Push bool `json:"push"`
+	}
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Invalid request format: " + err.Error())
+			return
+		}
 	}
 
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format: " + err.Error()})
+	domain, err := h.domainRepo.GetByID(domainID)
+	if err != nil {
+		respondError(c, http.StatusNotFound, types.CodeDomainNotFound, "Domain not found")
 		return
 	}
 
-	// Verify admin password for security
-	userID, exists := c.Get("userID")
-	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+	report, err := h.providerSvc.ReconcileAutoRenew(*domain, req.Push)
+	if err != nil {
+		respondWithErrorDetails(c, http.StatusBadGateway, err, gin.H{"report": report})
 		return
 	}
 
-	// Verify password
-	if !h.authSvc.VerifyCurrentUserPassword(userID.(string), req.Password) {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid password"})
-		return
+	if report.AtRisk && !report.Pushed {
+		log.Printf("Auto-renew drift: %s is set to auto-renew locally but %s has it disabled", domain.Name, domain.Provider)
 	}
 
-	// Log the bulk operation for security audit
-	log.Printf("Bulk CSV update initiated by user %s for %d rows", userID, len(req.CSVData))
+	c.JSON(http.StatusOK, report)
+}
 
-	results := make([]map[string]interface{}, 0, len(req.CSVData))
-	successCount := 0
-	errorCount := 0
+// GetDomainContacts returns a domain's registrar contacts and WHOIS privacy state
+func (h *AdminHandler) GetDomainContacts(c *gin.Context) {
+	domain, err := h.domainRepo.GetByID(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusNotFound, types.CodeDomainNotFound, "Domain not found")
+		return
+	}
 
-	for _, row := range req.CSVData {
-		result := map[string]interface{}{
-			"domain":  row.Domain,
-			"success": false,
-			"error":   nil,
-		}
+	contacts, err := h.providerSvc.GetDomainContacts(*domain)
+	if err != nil {
+		respondWithError(c, http.StatusBadGateway, err)
+		return
+	}
+	c.JSON(http.StatusOK, contacts)
+}
 
-		// Get domain ID from domain name
-		domains, err := h.domainRepo.GetDomainsByName(row.Domain)
-		if err != nil || len(domains) == 0 {
-			result["error"] = fmt.Sprintf("Domain %s not found", row.Domain)
-			errorCount++
-			results = append(results, result)
-			continue
+// UpdateDomainContacts toggles WHOIS privacy and/or replaces the registrant at the registrar.
+// Body: {"privacy": true} and/or {"registrant": {...}}.
+func (h *AdminHandler) UpdateDomainContacts(c *gin.Context) {
+	var req types.DomainContactsUpdate
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Invalid request format: " + err.Error())
+		return
+	}
+	if req.Privacy == nil && req.Registrant == nil {
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "privacy or registrant is required")
+		return
+	}
+	if req.Registrant != nil {
+		if err := req.Registrant.Validate(); err != nil {
+			respondWithError(c, http.StatusBadRequest, err)
+			return
 		}
+	}
 
-		domainID := domains[0].ID
-
-		// Process DNS record if provided
-		if row.RecordType != "" && row.Name != "" && row.Value != "" {
-			ttl := 3600 // default TTL
-			if row.TTL != "" {
-				if parsedTTL, err := strconv.Atoi(row.TTL); err == nil {
-					ttl = parsedTTL
-				}
-			}
-
-			dnsRecord := types.DNSRecord{
-				DomainID: domainID,
-				Type:     row.RecordType,
-				Name:     row.Name,
-				Value:    row.Value,
-				TTL:      ttl,
-			}
+	domain, err := h.domainRepo.GetByID(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusNotFound, types.CodeDomainNotFound, "Domain not found")
+		return
+	}
 
-			if err := h.dnsSvc.CreateOrUpdateRecord(dnsRecord); err != nil {
-				result["error"] = fmt.Sprintf("Failed to create/update DNS record: %v", err)
-				errorCount++
-				results = append(results, result)
-				continue
-			}
+	contacts, err := h.providerSvc.UpdateDomainContacts(*domain, req)
+	if h.securitySvc != nil {
+		userID, _ := c.Get("userID")
+		id, _ := userID.(string)
+		details := map[string]interface{}{"domain": domain.Name, "provider": domain.Provider, "registrant_updated": req.Registrant != nil}
+		if req.Privacy != nil {
+			details["privacy"] = *req.Privacy
 		}
-
-		// Process nameservers if provided
-		if row.Nameserver1 != "" && row.Nameserver2 != "" {
-			// Remove existing NS records
-			existingRecords, err := h.dnsSvc.GetDomainRecords(domainID)
-			if err == nil {
-				for _, record := range existingRecords {
-					if record.Type == "NS" {
-						h.dnsSvc.DeleteRecord(record.ID)
-					}
-				}
-			}
-
-			// Add new nameservers
-			nameservers := []string{row.Nameserver1, row.Nameserver2}
-			for _, ns := range nameservers {
-				if ns != "" {
-					dnsRecord := types.DNSRecord{
-						DomainID: domainID,
-						Type:     "NS",
-						Name:     "@",
-						Value:    ns,
-						TTL:      86400,
-					}
-
-					if err := h.dnsSvc.CreateOrUpdateRecord(dnsRecord); err != nil {
-						result["error"] = fmt.Sprintf("Failed to create NS record: %v", err)
-						errorCount++
-						results = append(results, result)
-						continue
-					}
-				}
-			}
+		if err != nil {
+			details["error"] = err.Error()
 		}
-
-		result["success"] = true
-		successCount++
-		results = append(results, result)
+		h.securitySvc.LogAuditEvent(security.EventDomainUpdate, id, requestActor(c), c.ClientIP(),
+			c.GetHeader("User-Agent"), "domain", "update_contacts", err == nil, details, "")
 	}
-
-	c.JSON(http.StatusOK, gin.H{
-		"message":      fmt.Sprintf("Bulk CSV update completed: %d successful, %d failed", successCount, errorCount),
-		"success_count": successCount,
-		"error_count":   errorCount,
-		"results":       results,
-	})
+	if err != nil {
+		respondWithError(c, http.StatusBadGateway, err)
+		return
+	}
+	c.JSON(http.StatusOK, contacts)
 }
 
 // SearchDomains handles the domain availability search endpoint
 func (h *AdminHandler) SearchDomains(c *gin.Context) {
 	var request types.DomainSearchRequest
 	if err := c.ShouldBindJSON(&request); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format: " + err.Error()})
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Invalid request format: " + err.Error())
 		return
 	}
 
 	results, err := h.providerSvc.SearchDomains(request)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to search domains: " + err.Error()})
+		respondError(c, http.StatusInternalServerError, types.CodeInternal, "Failed to search domains: " + err.Error())
 		return
 	}
 
@@ -2348,30 +4805,44 @@ func (h *AdminHandler) SearchDomains(c *gin.Context) {
 func (h *AdminHandler) PurchaseDomains(c *gin.Context) {
 	var request types.DomainPurchaseRequest
 	if err := c.ShouldBindJSON(&request); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format: " + err.Error()})
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Invalid request format: " + err.Error())
 		return
 	}
 
 	response, err := h.providerSvc.PurchaseDomains(request)
+	h.emitPurchaseEvents(request, response, err)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to purchase domains: " + err.Error()})
+		respondError(c, http.StatusInternalServerError, types.CodeInternal, "Failed to purchase domains: " + err.Error())
 		return
 	}
 
 	c.JSON(http.StatusOK, response)
 }
 
+// emitPurchaseEvents sends an event for each domain a purchase bought or failed to buy, so
+// webhooks and notification channels hear about it
+func (h *AdminHandler) emitPurchaseEvents(request types.DomainPurchaseRequest, response *types.DomainPurchaseResponse, purchaseErr error) {
+	if !h.purchaseEvents || h.notificationSvc == nil {
+		return
+	}
+	for _, event := range h.notificationSvc.CreatePurchaseEvents(request, response, purchaseErr) {
+		if err := h.notificationSvc.EmitEvent(event); err != nil {
+			log.Printf("Failed to emit %s event for %v: %v", event.Type, event.Data["domain"], err)
+		}
+	}
+}
+
 // CheckWebsiteStatus handles the website status check endpoint
 func (h *AdminHandler) CheckWebsiteStatus(c *gin.Context) {
 	var request types.WebsiteStatusRequest
 	if err := c.ShouldBindJSON(&request); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format: " + err.Error()})
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Invalid request format: " + err.Error())
 		return
 	}
 
 	results, err := h.statusChecker.CheckWebsiteStatus(request)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check website status: " + err.Error()})
+		respondError(c, http.StatusInternalServerError, types.CodeInternal, "Failed to check website status: " + err.Error())
 		return
 	}
 
@@ -2382,16 +4853,42 @@ func (h *AdminHandler) CheckWebsiteStatus(c *gin.Context) {
 func (h *AdminHandler) BulkCheckWebsiteStatus(c *gin.Context) {
 	var request types.WebsiteStatusRequest
 	if err := c.ShouldBindJSON(&request); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format: " + err.Error()})
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Invalid request format: " + err.Error())
+		return
+	}
+	if !checkBulkOperations(c, h.requestLimits, len(request.Domains)) {
 		return
 	}
 
-	results, err := h.statusChecker.BulkCheckWebsiteStatus(request)
+	ctx, cancel := context.WithTimeout(c.Request.Context(), status.DefaultBulkCheckTimeout)
+	defer cancel()
+
+	results, err := h.statusChecker.BulkCheckWebsiteStatus(ctx, request, status.DefaultBulkCheckWorkers)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check website statuses: " + err.Error()})
+		respondError(c, http.StatusInternalServerError, types.CodeInternal, "Failed to check website statuses: " + err.Error())
 		return
 	}
 
+	// Persist results onto the matching domains so the dashboard shows the last known state
+	for _, result := range results {
+		if result.StatusMessage == "Not checked" {
+			continue
+		}
+		domains, err := h.domainRepo.GetDomainsByName(result.Domain)
+		if err != nil {
+			continue
+		}
+		for i := range domains {
+			previous := domains[i]
+			status.ApplyWebsiteStatus(&domains[i], result)
+			if err := h.domainRepo.Update(&domains[i]); err != nil {
+				log.Printf("Failed to store website status for %s: %v", result.Domain, err)
+				continue
+			}
+			h.notifyTransitions(previous, domains[i])
+		}
+	}
+
 	c.JSON(http.StatusOK, results)
 }
 
@@ -2399,7 +4896,7 @@ func (h *AdminHandler) BulkCheckWebsiteStatus(c *gin.Context) {
 func (h *AdminHandler) GetPurchaseProviders(c *gin.Context) {
 	providers, err := h.providerSvc.GetPurchaseProviders()
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get purchase providers: " + err.Error()})
+		respondError(c, http.StatusInternalServerError, types.CodeInternal, "Failed to get purchase providers: " + err.Error())
 		return
 	}
 
@@ -2413,13 +4910,13 @@ func (h *AdminHandler) GetPurchaseProviders(c *gin.Context) {
 // GetMonitoringStats returns overall monitoring statistics
 func (h *AdminHandler) GetMonitoringStats(c *gin.Context) {
 	if h.uptimeRobotSvc == nil {
-		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "UptimeRobot service not configured"})
+		respondError(c, http.StatusServiceUnavailable, types.CodeServiceUnavailable, "UptimeRobot service not configured")
 		return
 	}
 
 	stats, err := h.uptimeRobotSvc.GetMonitoringStats()
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondWithError(c, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -2429,13 +4926,13 @@ func (h *AdminHandler) GetMonitoringStats(c *gin.Context) {
 // GetMonitors returns all UptimeRobot monitors
 func (h *AdminHandler) GetMonitors(c *gin.Context) {
 	if h.uptimeRobotSvc == nil {
-		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "UptimeRobot service not configured"})
+		respondError(c, http.StatusServiceUnavailable, types.CodeServiceUnavailable, "UptimeRobot service not configured")
 		return
 	}
 
 	monitors, err := h.uptimeRobotSvc.GetMonitors()
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondWithError(c, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -2448,28 +4945,72 @@ func (h *AdminHandler) GetMonitors(c *gin.Context) {
 // SyncMonitors synchronizes UptimeRobot monitor data with the database
 func (h *AdminHandler) SyncMonitors(c *gin.Context) {
 	if h.uptimeRobotSvc == nil {
-		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "UptimeRobot service not configured"})
+		respondError(c, http.StatusServiceUnavailable, types.CodeServiceUnavailable, "UptimeRobot service not configured")
 		return
 	}
 
-	// Start sync in background
-	go func() {
-		if err := h.uptimeRobotSvc.SyncMonitors(); err != nil {
-			log.Printf("Monitor sync failed: %v", err)
-		} else {
-			log.Printf("Monitor sync completed successfully")
+	domains, err := h.domainRepo.GetAll()
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, types.CodeInternal, "Failed to fetch domains")
+		return
+	}
+
+	updated, result, err := h.uptimeRobotSvc.SyncMonitors(domains)
+	if err != nil {
+		respondError(c, http.StatusBadGateway, types.CodeProviderUnavailable, "Monitor sync failed: " + err.Error())
+		return
+	}
+
+	// Persist the fetched stats; a domain that can't be saved counts as failed
+	failedSaves := map[string]error{}
+	for i := range updated {
+		if err := h.domainRepo.Update(&updated[i]); err != nil {
+			failedSaves[updated[i].ID] = err
+		}
+	}
+	for i := range result.Results {
+		if err, failed := failedSaves[result.Results[i].DomainID]; failed {
+			result.Results[i].Success = false
+			result.Results[i].Action = "failed"
+			result.Results[i].Message = "Failed to save monitor stats"
+			result.Results[i].Error = err.Error()
+			result.MonitorsSync--
+			result.MonitorsUpdated--
+			result.MonitorsFailed++
 		}
-	}()
+	}
+	log.Printf("Monitor sync completed: %d synced, %d failed", result.MonitorsSync, result.MonitorsFailed)
 
-	c.JSON(http.StatusAccepted, gin.H{
-		"message": "Monitor synchronization initiated",
-	})
+	// Alert on domains that went over their response-time SLA with this sync
+	sla := loadResponseTimeSLA(h.domainRepo)
+	previous := make(map[string]types.Domain, len(domains))
+	for _, domain := range domains {
+		previous[domain.ID] = domain
+	}
+	var saved []types.Domain
+	for _, domain := range updated {
+		if _, failed := failedSaves[domain.ID]; failed {
+			continue
+		}
+		saved = append(saved, domain)
+		if h.notificationSvc == nil {
+			continue
+		}
+		if alert, breached := h.notificationSvc.DetectSLABreach(previous[domain.ID], domain, sla); breached {
+			if err := h.notificationSvc.Notify(alert); err != nil {
+				log.Printf("Failed to send SLA alert for %s: %v", domain.Name, err)
+			}
+		}
+	}
+	result.SLABreaches = sla.Breaches(saved)
+
+	c.JSON(http.StatusOK, result)
 }
 
 // CreateMonitor creates a new UptimeRobot monitor
 func (h *AdminHandler) CreateMonitor(c *gin.Context) {
 	if h.uptimeRobotSvc == nil {
-		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "UptimeRobot service not configured"})
+		respondError(c, http.StatusServiceUnavailable, types.CodeServiceUnavailable, "UptimeRobot service not configured")
 		return
 	}
 
@@ -2482,21 +5023,21 @@ func (h *AdminHandler) CreateMonitor(c *gin.Context) {
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format: " + err.Error()})
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Invalid request format: " + err.Error())
 		return
 	}
 
 	// Get the domain to validate it exists
 	domain, err := h.domainRepo.GetByID(req.DomainID)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Domain not found"})
+		respondError(c, http.StatusNotFound, types.CodeDomainNotFound, "Domain not found")
 		return
 	}
 
 	// Create monitor using UptimeRobot service
 	monitor, err := h.uptimeRobotSvc.CreateMonitor(req.URL, req.Type, req.Name, req.Interval)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create monitor: " + err.Error()})
+		respondError(c, http.StatusInternalServerError, types.CodeInternal, "Failed to create monitor: " + err.Error())
 		return
 	}
 
@@ -2515,25 +5056,25 @@ func (h *AdminHandler) CreateMonitor(c *gin.Context) {
 // UpdateMonitor updates an existing UptimeRobot monitor
 func (h *AdminHandler) UpdateMonitor(c *gin.Context) {
 	if h.uptimeRobotSvc == nil {
-		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "UptimeRobot service not configured"})
+		respondError(c, http.StatusServiceUnavailable, types.CodeServiceUnavailable, "UptimeRobot service not configured")
 		return
 	}
 
 	monitorIDStr := c.Param("id")
 	monitorID, err := strconv.ParseInt(monitorIDStr, 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid monitor ID"})
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Invalid monitor ID")
 		return
 	}
 
 	var updates map[string]interface{}
 	if err := c.ShouldBindJSON(&updates); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid update data"})
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Invalid update data")
 		return
 	}
 
 	if err := h.uptimeRobotSvc.UpdateMonitor(int(monitorID), updates); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update monitor: " + err.Error()})
+		respondError(c, http.StatusInternalServerError, types.CodeInternal, "Failed to update monitor: " + err.Error())
 		return
 	}
 
@@ -2545,19 +5086,19 @@ func (h *AdminHandler) UpdateMonitor(c *gin.Context) {
 // DeleteMonitor deletes a UptimeRobot monitor
 func (h *AdminHandler) DeleteMonitor(c *gin.Context) {
 	if h.uptimeRobotSvc == nil {
-		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "UptimeRobot service not configured"})
+		respondError(c, http.StatusServiceUnavailable, types.CodeServiceUnavailable, "UptimeRobot service not configured")
 		return
 	}
 
 	monitorIDStr := c.Param("id")
 	monitorID, err := strconv.ParseInt(monitorIDStr, 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid monitor ID"})
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Invalid monitor ID")
 		return
 	}
 
 	if err := h.uptimeRobotSvc.DeleteMonitor(int(monitorID)); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete monitor: " + err.Error()})
+		respondError(c, http.StatusInternalServerError, types.CodeInternal, "Failed to delete monitor: " + err.Error())
 		return
 	}
 
@@ -2584,14 +5125,14 @@ func (h *AdminHandler) DeleteMonitor(c *gin.Context) {
 // GetMonitorLogs retrieves logs for a specific monitor
 func (h *AdminHandler) GetMonitorLogs(c *gin.Context) {
 	if h.uptimeRobotSvc == nil {
-		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "UptimeRobot service not configured"})
+		respondError(c, http.StatusServiceUnavailable, types.CodeServiceUnavailable, "UptimeRobot service not configured")
 		return
 	}
 
 	monitorIDStr := c.Param("id")
 	monitorID, err := strconv.ParseInt(monitorIDStr, 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid monitor ID"})
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Invalid monitor ID")
 		return
 	}
 
@@ -2603,7 +5144,7 @@ func (h *AdminHandler) GetMonitorLogs(c *gin.Context) {
 
 	logs, err := h.uptimeRobotSvc.GetMonitorLogs(int(monitorID), limit, offset, startDateStr, endDateStr)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch monitor logs: " + err.Error()})
+		respondError(c, http.StatusInternalServerError, types.CodeInternal, "Failed to fetch monitor logs: " + err.Error())
 		return
 	}
 
@@ -2616,20 +5157,169 @@ func (h *AdminHandler) GetMonitorLogs(c *gin.Context) {
 	})
 }
 
+// EnableDomainMonitoring creates an UptimeRobot monitor for a domain and stores its ID
+func (h *AdminHandler) EnableDomainMonitoring(c *gin.Context) {
+	if h.uptimeRobotSvc == nil || !h.uptimeRobotSvc.IsConfigured() {
+		respondError(c, http.StatusServiceUnavailable, types.CodeServiceUnavailable, "UptimeRobot service not configured")
+		return
+	}
+
+	domain, err := h.domainRepo.GetByID(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusNotFound, types.CodeDomainNotFound, "Domain not found")
+		return
+	}
+
+	if domain.UptimeRobotMonitorID != nil {
+		c.JSON(http.StatusOK, gin.H{
+			"message":    "Monitoring already enabled",
+			"monitor_id": *domain.UptimeRobotMonitorID,
+		})
+		return
+	}
+
+	if err := h.uptimeRobotSvc.EnableMonitoringForDomain(domain); err != nil {
+		respondError(c, http.StatusBadGateway, types.CodeProviderUnavailable, "Failed to create monitor: " + err.Error())
+		return
+	}
+
+	if err := h.domainRepo.Update(domain); err != nil {
+		respondError(c, http.StatusInternalServerError, types.CodeInternal, "Monitor created but failed to update domain: " + err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":    "Monitoring enabled",
+		"monitor_id": *domain.UptimeRobotMonitorID,
+	})
+}
+
+// DisableDomainMonitoring deletes a domain's UptimeRobot monitor and clears its monitoring fields
+func (h *AdminHandler) DisableDomainMonitoring(c *gin.Context) {
+	if h.uptimeRobotSvc == nil || !h.uptimeRobotSvc.IsConfigured() {
+		respondError(c, http.StatusServiceUnavailable, types.CodeServiceUnavailable, "UptimeRobot service not configured")
+		return
+	}
+
+	domain, err := h.domainRepo.GetByID(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusNotFound, types.CodeDomainNotFound, "Domain not found")
+		return
+	}
+
+	if err := h.uptimeRobotSvc.DisableMonitoringForDomain(domain); err != nil {
+		respondError(c, http.StatusBadGateway, types.CodeProviderUnavailable, "Failed to delete monitor: " + err.Error())
+		return
+	}
+
+	if err := h.domainRepo.Update(domain); err != nil {
+		respondWithError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Monitoring disabled"})
+}
+
+// BulkPauseMonitoring pauses the UptimeRobot monitors of several domains, such as for a
+// project's planned maintenance, so their downtime doesn't alert
+func (h *AdminHandler) BulkPauseMonitoring(c *gin.Context) {
+	h.bulkSetMonitorsPaused(c, true)
+}
+
+// BulkResumeMonitoring resumes the UptimeRobot monitors of several domains
+func (h *AdminHandler) BulkResumeMonitoring(c *gin.Context) {
+	h.bulkSetMonitorsPaused(c, false)
+}
+
+// bulkSetMonitorsPaused pauses or resumes each listed domain's monitor and stores its new
+// monitor status. Domains without a monitor are skipped; each domain's outcome is reported.
+func (h *AdminHandler) bulkSetMonitorsPaused(c *gin.Context, paused bool) {
+	if h.uptimeRobotSvc == nil || !h.uptimeRobotSvc.IsConfigured() {
+		respondError(c, http.StatusServiceUnavailable, types.CodeServiceUnavailable, "UptimeRobot service not configured")
+		return
+	}
+
+	var req types.BulkMonitorPauseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Invalid request format: domain_ids is required")
+		return
+	}
+	if len(req.DomainIDs) == 0 {
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "No domain IDs provided")
+		return
+	}
+	if !checkBulkOperations(c, h.requestLimits, len(req.DomainIDs)) {
+		return
+	}
+
+	action, verb := "resumed", "resume"
+	if paused {
+		action, verb = "paused", "pause"
+	}
+	results := make([]types.DomainMonitorResult, 0, len(req.DomainIDs))
+	succeeded, skipped, failed := 0, 0, 0
+	for _, ref := range scopeDomainRefs(c, resolveDomainRefs(h.domainRepo, req.DomainIDs)) {
+		if ref.Err != nil {
+			results = append(results, types.DomainMonitorResult{DomainID: ref.Ref, Action: "failed", Error: ref.Err.Error()})
+			failed++
+			continue
+		}
+		domain := ref.Domain
+		result := types.DomainMonitorResult{DomainID: domain.ID, DomainName: domain.Name, MonitorID: domain.UptimeRobotMonitorID}
+		if domain.UptimeRobotMonitorID == nil {
+			result.Action = "skipped"
+			result.Message = "Domain has no UptimeRobot monitor"
+			results = append(results, result)
+			skipped++
+			continue
+		}
+
+		if err := h.uptimeRobotSvc.SetDomainMonitorPaused(domain, paused); err != nil {
+			result.Action = "failed"
+			result.Error = fmt.Sprintf("failed to %s monitor: %v", verb, err)
+			results = append(results, result)
+			failed++
+			continue
+		}
+		if err := h.domainRepo.Update(domain); err != nil {
+			result.Action = "failed"
+			result.Error = fmt.Sprintf("monitor %s but failed to update domain: %v", action, err)
+			results = append(results, result)
+			failed++
+			continue
+		}
+		result.Action = action
+		result.Success = true
+		result.Message = "Monitor " + action
+		results = append(results, result)
+		succeeded++
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"paused":  paused,
+		"total":   len(results),
+		"updated": succeeded,
+		"skipped": skipped,
+		"failed":  failed,
+		"results": results,
+	})
+}
+
 // GetDomainMonitoring returns monitoring data for a specific domain
 func (h *AdminHandler) GetDomainMonitoring(c *gin.Context) {
 	domainID := c.Param("id")
 	if domainID == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Domain ID required"})
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Domain ID required")
 		return
 	}
 
 	// Get the domain
 	domain, err := h.domainRepo.GetByID(domainID)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Domain not found"})
+		respondError(c, http.StatusNotFound, types.CodeDomainNotFound, "Domain not found")
 		return
 	}
+	sla := loadResponseTimeSLA(h.domainRepo)
 
 	response := gin.H{
 		"domain_id":   domainID,
@@ -2639,6 +5329,8 @@ func (h *AdminHandler) GetDomainMonitoring(c *gin.Context) {
 			"uptime_robot_monitor_id": domain.UptimeRobotMonitorID,
 			"uptime_ratio":            domain.UptimeRatio,
 			"response_time":           domain.ResponseTime,
+			"response_time_sla_ms":    sla.ThresholdFor(domain.Name),
+			"sla_breached":            sla.Breached(*domain),
 			"monitor_status":          domain.MonitorStatus,
 			"last_downtime":           domain.LastDowntime,
 		},
@@ -0,0 +1,66 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rusiqe/domainvault/internal/storage"
+	"github.com/rusiqe/domainvault/internal/types"
+)
+
+func TestImportDomainsCSV(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	repo := storage.NewEmptyMockRepo()
+	if _, err := repo.UpsertDomains([]types.Domain{
+		{ID: "existing", Name: "existing.com", Provider: "godaddy", ExpiresAt: time.Now().AddDate(1, 0, 0), Visible: true},
+	}); err != nil {
+		t.Fatalf("UpsertDomains() error: %v", err)
+	}
+
+	r := gin.New()
+	r.POST("/import", NewAdminHandler(repo, nil, nil, nil, nil, nil, nil, nil, nil, nil).ImportDomainsCSV)
+
+	body := "name,expires_at,auto_renew\n" +
+		"bad.com,someday,\n" +
+		"Existing.com.,,true\n" +
+		"new.com,2030-01-01,\n" +
+		"new.com,2031-01-01,\n"
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/import", strings.NewReader(body)))
+	if w.Code != http.StatusOK {
+		t.Fatalf("POST /import = %d %s", w.Code, w.Body)
+	}
+
+	var result struct {
+		Inserted int `json:"inserted"`
+		Updated  int `json:"updated"`
+		Skipped  int `json:"skipped"`
+		Errors   []struct {
+			Row    int    `json:"row"`
+			Domain string `json:"domain"`
+		} `json:"errors"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &result); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if result.Inserted != 1 || result.Updated != 1 || result.Skipped != 1 {
+		t.Errorf("POST /import = %s, want 1 inserted, 1 updated and 1 skipped", w.Body)
+	}
+	if len(result.Errors) != 2 || result.Errors[0].Domain != "bad.com" || result.Errors[1].Row != 5 {
+		t.Errorf("errors = %+v, want bad.com's row then the duplicate", result.Errors)
+	}
+
+	existing, err := repo.GetByID("existing")
+	if err != nil {
+		t.Fatalf("GetByID() error: %v", err)
+	}
+	if !existing.AutoRenew || existing.Provider != "godaddy" {
+		t.Errorf("existing.com = %+v, want auto-renew set and the stored provider kept", existing)
+	}
+}
@@ -0,0 +1,86 @@
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rusiqe/domainvault/internal/core"
+	"github.com/rusiqe/domainvault/internal/jobs"
+	"github.com/rusiqe/domainvault/internal/types"
+)
+
+// Defaults for LookupLimits fields left at 0
+const (
+	defaultLookupAsyncThreshold = 25
+	defaultLookupMaxBatch       = 1000
+)
+
+// LookupLimits batches bulk availability lookups; the rate of each lookup service is limited
+// by its client
+type LookupLimits struct {
+	Workers        int // Lookups one search runs at once
+	AsyncThreshold int // Searches with more domains run as a background job
+	MaxBatch       int // Most domains one search accepts
+}
+
+func (l LookupLimits) workers() int {
+	if l.Workers > 0 {
+		return l.Workers
+	}
+	return core.DefaultAvailabilityWorkers
+}
+
+func (l LookupLimits) asyncThreshold() int {
+	if l.AsyncThreshold > 0 {
+		return l.AsyncThreshold
+	}
+	return defaultLookupAsyncThreshold
+}
+
+func (l LookupLimits) maxBatch() int {
+	if l.MaxBatch > 0 {
+		return l.MaxBatch
+	}
+	return defaultLookupMaxBatch
+}
+
+// SearchAvailability checks whether each of a batch of domains can be registered, through the
+// connected registrars and then RDAP. Small batches are answered directly. Batches over the
+// async threshold, or any with ?async=true, run as a background job and return 202 at once;
+// polling the job returns the results so far, with "processing" true until it's done.
+func (h *AdminHandler) SearchAvailability(c *gin.Context) {
+	if h.watchlistSvc == nil {
+		respondError(c, http.StatusServiceUnavailable, types.CodeServiceUnavailable, "Availability lookups not configured")
+		return
+	}
+
+	var req types.AvailabilitySearchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Invalid request format")
+		return
+	}
+	if err := req.Normalize(h.lookupLimits.maxBatch()); err != nil {
+		respondWithError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	workers := h.lookupLimits.workers()
+	if len(req.Domains) <= h.lookupLimits.asyncThreshold() && c.Query("async") != "true" {
+		c.JSON(http.StatusOK, h.watchlistSvc.SearchAvailability(c.Request.Context(), req.Domains, workers, nil))
+		return
+	}
+
+	job, ok := enqueueJob(c, h.jobs, "availability_search", func(ctx context.Context, tracker *jobs.Tracker) (interface{}, error) {
+		tracker.SetTotal(len(req.Domains))
+		return h.watchlistSvc.SearchAvailability(ctx, req.Domains, workers, func(search *types.AvailabilitySearch) {
+			tracker.SetResult(search)
+			tracker.Advance(1, "")
+		}), nil
+	})
+	if ok {
+		pending := types.NewAvailabilitySearch(req.Domains)
+		pending.Processing = true
+		c.JSON(http.StatusAccepted, jobAccepted(job, gin.H{"processing": true, "search": pending}))
+	}
+}
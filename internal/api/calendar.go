@@ -0,0 +1,83 @@
+package api
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rusiqe/domainvault/internal/types"
+)
+
+// buildExpiryCalendar renders an RFC 5545 calendar with an all-day event on each domain's
+// expiry date and a display alarm for each entry in reminderDays
+func buildExpiryCalendar(domains []types.Domain, reminderDays []int, now time.Time) []byte {
+	var b strings.Builder
+	stamp := now.UTC().Format("20060102T150405Z")
+
+	writeICSLine(&b, "BEGIN:VCALENDAR")
+	writeICSLine(&b, "VERSION:2.0")
+	writeICSLine(&b, "PRODID:-//DomainVault//Domain Expiry//EN")
+	writeICSLine(&b, "CALSCALE:GREGORIAN")
+	writeICSLine(&b, "METHOD:PUBLISH")
+	writeICSLine(&b, "X-WR-CALNAME:Domain expirations")
+
+	for _, d := range domains {
+		if d.ExpiresAt.IsZero() {
+			continue
+		}
+		expires := d.ExpiresAt.UTC()
+
+		description := fmt.Sprintf("Provider: %s", d.Provider)
+		if d.RenewalPrice != nil {
+			description += fmt.Sprintf("\nRenewal cost: %.2f", *d.RenewalPrice)
+		}
+		if d.AutoRenew {
+			description += "\nAuto-renew: on"
+		} else {
+			description += "\nAuto-renew: off"
+		}
+
+		writeICSLine(&b, "BEGIN:VEVENT")
+		writeICSLine(&b, "UID:"+d.ID+"-expiry@domainvault")
+		writeICSLine(&b, "DTSTAMP:"+stamp)
+		writeICSLine(&b, "DTSTART;VALUE=DATE:"+expires.Format("20060102"))
+		writeICSLine(&b, "DTEND;VALUE=DATE:"+expires.AddDate(0, 0, 1).Format("20060102"))
+		writeICSLine(&b, "SUMMARY:"+escapeICSText(d.Name+" expires"))
+		writeICSLine(&b, "DESCRIPTION:"+escapeICSText(description))
+		writeICSLine(&b, "TRANSP:TRANSPARENT")
+		for _, days := range reminderDays {
+			writeICSLine(&b, "BEGIN:VALARM")
+			writeICSLine(&b, "ACTION:DISPLAY")
+			writeICSLine(&b, fmt.Sprintf("TRIGGER:-P%dD", days))
+			writeICSLine(&b, "DESCRIPTION:"+escapeICSText(fmt.Sprintf("%s expires in %d days", d.Name, days)))
+			writeICSLine(&b, "END:VALARM")
+		}
+		writeICSLine(&b, "END:VEVENT")
+	}
+
+	writeICSLine(&b, "END:VCALENDAR")
+	return []byte(b.String())
+}
+
+// writeICSLine writes a content line terminated by CRLF, folding it at 75 octets as RFC 5545 requires
+func writeICSLine(b *strings.Builder, line string) {
+	limit := 75
+	for len(line) > limit {
+		cut := limit
+		// Don't split a multi-byte UTF-8 sequence
+		for cut > 0 && line[cut]&0xC0 == 0x80 {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+		limit = 74 // Continuation lines start with a space
+	}
+	b.WriteString(line)
+	b.WriteString("\r\n")
+}
+
+// escapeICSText escapes a TEXT property value
+func escapeICSText(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(s)
+}
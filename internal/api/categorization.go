@@ -0,0 +1,186 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rusiqe/domainvault/internal/core"
+	"github.com/rusiqe/domainvault/internal/security"
+	"github.com/rusiqe/domainvault/internal/types"
+)
+
+// auditCategorizationChange records a change to the categorization rules or a recategorization
+func (h *AdminHandler) auditCategorizationChange(c *gin.Context, action string, details map[string]interface{}) {
+	if h.securitySvc == nil {
+		return
+	}
+	userID, _ := c.Get("userID")
+	id, _ := userID.(string)
+	h.securitySvc.LogAuditEvent(security.EventSettingsChange, id, requestActor(c), c.ClientIP(),
+		c.GetHeader("User-Agent"), "categorization", action, true, details, "")
+}
+
+// categorize applies the categorization rules to domains about to be stored. Freshly fetched
+// domains are matched to the portfolio by name so they keep their stored category and tags.
+func (h *AdminHandler) categorize(domains []types.Domain, fetched bool) error {
+	var stored map[string]types.Domain
+	if fetched {
+		existing, err := h.domainRepo.GetByFilter(types.DomainFilter{IncludeHidden: true})
+		if err != nil {
+			return fmt.Errorf("failed to load stored domains: %w", err)
+		}
+		stored = make(map[string]types.Domain, len(existing))
+		for _, d := range existing {
+			stored[types.NormalizeDomainName(d.Name)] = d
+		}
+	}
+	_, err := core.CategorizeDomains(h.domainRepo, domains, stored)
+	return err
+}
+
+// applyCategorizationRuleRequest copies a request onto a rule and validates it, checking the
+// category exists
+func (h *AdminHandler) applyCategorizationRuleRequest(rule *types.CategorizationRule, req types.CategorizationRuleRequest) error {
+	rule.Name = req.Name
+	rule.Priority = req.Priority
+	rule.NamePattern = req.NamePattern
+	rule.Provider = req.Provider
+	rule.HasRecordType = req.HasRecordType
+	rule.CategoryID = req.CategoryID
+	rule.Tags = types.TagsSlice(req.Tags)
+	if req.Enabled != nil {
+		rule.Enabled = *req.Enabled
+	}
+	if err := rule.Validate(); err != nil {
+		return err
+	}
+	if rule.CategoryID != nil {
+		if _, err := h.domainRepo.GetCategoryByID(*rule.CategoryID); err != nil {
+			return fmt.Errorf("%w: unknown category_id %q", types.ErrInvalidCategorizationRule, *rule.CategoryID)
+		}
+	}
+	return nil
+}
+
+// ListCategorizationRules returns the categorization rules in the order they run
+func (h *AdminHandler) ListCategorizationRules(c *gin.Context) {
+	rules, err := h.domainRepo.GetCategorizationRules()
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err)
+		return
+	}
+	if rules == nil {
+		rules = []types.CategorizationRule{}
+	}
+	c.JSON(http.StatusOK, gin.H{"rules": rules, "total": len(rules)})
+}
+
+// CreateCategorizationRule adds a rule. It applies to domains imported or synced from now on;
+// POST /domains/recategorize applies it to the existing portfolio.
+func (h *AdminHandler) CreateCategorizationRule(c *gin.Context) {
+	var req types.CategorizationRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Invalid request format")
+		return
+	}
+
+	rule := types.CategorizationRule{Enabled: true}
+	if err := h.applyCategorizationRuleRequest(&rule, req); err != nil {
+		respondWithError(c, http.StatusBadRequest, err)
+		return
+	}
+	if err := h.domainRepo.CreateCategorizationRule(&rule); err != nil {
+		respondWithError(c, http.StatusInternalServerError, err)
+		return
+	}
+	h.auditCategorizationChange(c, "create_rule", map[string]interface{}{"rule_id": rule.ID, "name": rule.Name})
+
+	c.JSON(http.StatusCreated, rule)
+}
+
+// UpdateCategorizationRule replaces a rule's conditions, actions, priority and enabled flag
+func (h *AdminHandler) UpdateCategorizationRule(c *gin.Context) {
+	rule, err := h.domainRepo.GetCategorizationRuleByID(c.Param("id"))
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	var req types.CategorizationRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Invalid request format")
+		return
+	}
+
+	if err := h.applyCategorizationRuleRequest(rule, req); err != nil {
+		respondWithError(c, http.StatusBadRequest, err)
+		return
+	}
+	if err := h.domainRepo.UpdateCategorizationRule(rule); err != nil {
+		respondWithError(c, http.StatusInternalServerError, err)
+		return
+	}
+	h.auditCategorizationChange(c, "update_rule", map[string]interface{}{
+		"rule_id": rule.ID,
+		"name":    rule.Name,
+		"enabled": rule.Enabled,
+	})
+
+	c.JSON(http.StatusOK, rule)
+}
+
+// DeleteCategorizationRule removes a rule. Categories and tags it already assigned are kept.
+func (h *AdminHandler) DeleteCategorizationRule(c *gin.Context) {
+	rule, err := h.domainRepo.GetCategorizationRuleByID(c.Param("id"))
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	if err := h.domainRepo.DeleteCategorizationRule(rule.ID); err != nil {
+		respondWithError(c, http.StatusInternalServerError, err)
+		return
+	}
+	h.auditCategorizationChange(c, "delete_rule", map[string]interface{}{"rule_id": rule.ID, "name": rule.Name})
+
+	c.JSON(http.StatusOK, gin.H{"message": "Categorization rule deleted successfully"})
+}
+
+// RecategorizeDomains re-applies the categorization rules to the whole portfolio.
+// {"dry_run": true} only reports the changes; {"overwrite": true} lets rules replace
+// categories domains already have rather than only filling missing ones.
+func (h *AdminHandler) RecategorizeDomains(c *gin.Context) {
+	var req types.RecategorizeRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Invalid request format")
+			return
+		}
+	}
+
+	changes, err := core.Recategorize(h.domainRepo, req.DryRun, req.Overwrite)
+	if err != nil && changes == nil {
+		respondWithError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	if !req.DryRun {
+		h.auditCategorizationChange(c, "recategorize", map[string]interface{}{
+			"domains_changed": len(changes),
+			"overwrite":       req.Overwrite,
+		})
+	}
+
+	response := gin.H{
+		"dry_run":   req.DryRun,
+		"overwrite": req.Overwrite,
+		"changed":   len(changes),
+		"changes":   changes,
+	}
+	if err != nil {
+		respondWithErrorDetails(c, http.StatusInternalServerError, err, response)
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,39 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rusiqe/domainvault/internal/types"
+)
+
+// GetDanglingDNS reports CNAME and A records pointing at infrastructure that no longer exists,
+// flagging possible subdomain takeovers. It returns the last scheduled scan's report; with
+// ?refresh=true, or before the first scan, it scans now. ?risk= narrows the findings to one risk.
+func (h *AdminHandler) GetDanglingDNS(c *gin.Context) {
+	if h.danglingDNS == nil {
+		respondError(c, http.StatusServiceUnavailable, types.CodeServiceUnavailable, "Dangling DNS monitor not configured")
+		return
+	}
+
+	report := h.danglingDNS.LastReport()
+	if report == nil || c.Query("refresh") == "true" {
+		var err error
+		if report, err = h.danglingDNS.Scan(c.Request.Context()); err != nil {
+			respondWithError(c, http.StatusInternalServerError, err)
+			return
+		}
+	}
+
+	if risk := types.DanglingRisk(c.Query("risk")); risk != "" {
+		filtered := *report
+		filtered.Findings = []types.DanglingDNSFinding{}
+		for _, finding := range report.Findings {
+			if finding.Risk == risk {
+				filtered.Findings = append(filtered.Findings, finding)
+			}
+		}
+		report = &filtered
+	}
+	c.JSON(http.StatusOK, report)
+}
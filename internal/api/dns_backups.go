@@ -0,0 +1,62 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rusiqe/domainvault/internal/types"
+)
+
+// ListDNSBackups returns the zone backups taken before each write to a domain's DNS provider,
+// newest first
+func (h *AdminHandler) ListDNSBackups(c *gin.Context) {
+	domain, err := h.domainRepo.GetByID(c.Param("id"))
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	backups, err := h.dnsSvc.GetBackups(domain.ID)
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"domain_id": domain.ID,
+		"backups":   backups,
+		"total":     len(backups),
+	})
+}
+
+// RestoreDNSBackup pushes a backup's records back to the provider it was taken from and stores
+// them as the domain's records. The zone it replaces is backed up first.
+func (h *AdminHandler) RestoreDNSBackup(c *gin.Context) {
+	domain, err := h.domainRepo.GetByID(c.Param("id"))
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err)
+		return
+	}
+	backup, err := h.dnsSvc.GetBackup(domain.ID, c.Param("backupId"))
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	client, ok := h.providerSvc.GetClientByProviderName(backup.Provider)
+	if !ok {
+		respondWithError(c, http.StatusBadRequest, fmt.Errorf("%w: %s is not connected", types.ErrUnsupportedProvider, backup.Provider))
+		return
+	}
+
+	result, err := h.dnsSvc.WithActor(requestActor(c)).RestoreBackup(*domain, *backup, client)
+	if err != nil {
+		respondWithError(c, http.StatusBadGateway, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"message":       fmt.Sprintf("Restored %d records to %s", len(result.Records), result.Provider),
+		"restored_from": backup.ID,
+		"result":        result,
+	})
+}
@@ -0,0 +1,178 @@
+package api
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rusiqe/domainvault/internal/dns"
+	"github.com/rusiqe/domainvault/internal/types"
+)
+
+// maxDNSBundleSize is the largest zone bundle an import accepts
+const maxDNSBundleSize = 50 << 20
+
+// projectDNSImportResult is the outcome of restoring one bundle entry
+type projectDNSImportResult struct {
+	DomainName string   `json:"domain_name"`
+	DomainID   string   `json:"domain_id,omitempty"`
+	Status     string   `json:"status"` // imported, valid (dry run), skipped or failed
+	Records    int      `json:"records"`
+	Warnings   []string `json:"warnings,omitempty"`
+	Error      string   `json:"error,omitempty"`
+}
+
+// projectDomains returns a project and its domains, including hidden ones, by name
+func (h *AdminHandler) projectDomains(c *gin.Context) (*types.Project, []types.Domain, bool) {
+	project, err := h.domainRepo.GetProjectByID(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusNotFound, types.CodeNotFound, "Project not found")
+		return nil, nil, false
+	}
+	domains, err := h.domainRepo.GetByFilter(types.DomainFilter{
+		ProjectID:     &project.ID,
+		IncludeHidden: true,
+		SortBy:        "name",
+		SortOrder:     types.SortAsc,
+	})
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err)
+		return nil, nil, false
+	}
+	return project, domains, true
+}
+
+// ExportProjectDNS returns the stored DNS records of every domain in a project as a bundle of
+// BIND zone files with a manifest mapping each domain to its file. ?format=zip (the default)
+// returns a zip archive; ?format=json returns the manifest with the zones inline.
+func (h *AdminHandler) ExportProjectDNS(c *gin.Context) {
+	format := c.DefaultQuery("format", "zip")
+	if format != "zip" && format != "json" {
+		respondError(c, http.StatusBadRequest, types.CodeValidationFailed, "format must be zip or json")
+		return
+	}
+
+	project, domains, ok := h.projectDomains(c)
+	if !ok {
+		return
+	}
+
+	manifest := dns.BundleManifest{
+		Version:     dns.BundleVersion,
+		ProjectID:   project.ID,
+		ProjectName: project.Name,
+		ExportedAt:  time.Now(),
+		Domains:     make([]dns.BundleEntry, 0, len(domains)),
+	}
+	for _, domain := range domains {
+		records, err := h.dnsSvc.GetDomainRecords(domain.ID)
+		if err != nil {
+			respondWithError(c, http.StatusInternalServerError, fmt.Errorf("failed to get DNS records for %s: %w", domain.Name, err))
+			return
+		}
+		manifest.Domains = append(manifest.Domains, dns.NewBundleEntry(domain, records))
+	}
+
+	filename := fmt.Sprintf("project-%s-dns.%s", project.ID, format)
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s", filename))
+	if format == "json" {
+		c.JSON(http.StatusOK, manifest)
+		return
+	}
+
+	var archive bytes.Buffer
+	if err := dns.WriteZipBundle(&archive, manifest); err != nil {
+		respondWithError(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.Data(http.StatusOK, "application/zip", archive.Bytes())
+}
+
+// ImportProjectDNS restores a bundle from ExportProjectDNS, zip or JSON, read from a multipart
+// "file" field or the raw request body. Entries are matched to the project's domains by name,
+// so a bundle exported elsewhere restores onto the same names here, and each matched domain's
+// records are replaced with its zone. Entries for domains not in the project are skipped, and
+// a zone that fails to parse or validate fails only its own domain. With ?dry_run=true the
+// zones are parsed and validated but nothing is written.
+func (h *AdminHandler) ImportProjectDNS(c *gin.Context) {
+	dryRun := c.Query("dry_run") == "true"
+
+	var reader io.Reader = c.Request.Body
+	if file, _, err := c.Request.FormFile("file"); err == nil {
+		defer file.Close()
+		reader = file
+	}
+	data, err := io.ReadAll(io.LimitReader(reader, maxDNSBundleSize+1))
+	if err != nil || len(data) == 0 {
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Zone bundle required")
+		return
+	}
+	if len(data) > maxDNSBundleSize {
+		respondError(c, http.StatusRequestEntityTooLarge, types.CodeInvalidRequest, fmt.Sprintf("Zone bundle is larger than %d bytes", maxDNSBundleSize))
+		return
+	}
+	manifest, err := dns.ReadBundle(data)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Invalid zone bundle: "+err.Error())
+		return
+	}
+
+	project, domains, ok := h.projectDomains(c)
+	if !ok {
+		return
+	}
+	byName := make(map[string]types.Domain, len(domains))
+	for _, domain := range domains {
+		byName[types.NormalizeDomainName(domain.Name)] = domain
+	}
+
+	dnsSvc := h.dnsSvc.WithActor(requestActor(c))
+	results := make([]projectDNSImportResult, 0, len(manifest.Domains))
+	counts := map[string]int{}
+	for _, entry := range manifest.Domains {
+		result := projectDNSImportResult{DomainName: entry.DomainName}
+		domain, found := byName[types.NormalizeDomainName(entry.DomainName)]
+		if !found {
+			result.Status = "skipped"
+			result.Error = "domain is not in this project"
+		} else {
+			result.DomainID = domain.ID
+			result.Status, result.Records, result.Warnings, result.Error = importBundleZone(dnsSvc, domain, entry.Zone, dryRun)
+		}
+		counts[result.Status]++
+		results = append(results, result)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"project_id": project.ID,
+		"dry_run":    dryRun,
+		"imported":   counts["imported"],
+		"valid":      counts["valid"],
+		"skipped":    counts["skipped"],
+		"failed":     counts["failed"],
+		"results":    results,
+	})
+}
+
+// importBundleZone parses a bundle zone for a domain and, unless dryRun, replaces the domain's
+// records with it. It returns the entry's status, record count, warnings and error.
+func importBundleZone(dnsSvc *dns.DNSService, domain types.Domain, zone string, dryRun bool) (string, int, []string, string) {
+	records, err := dns.ImportZone(domain.ID, zone)
+	if err != nil {
+		return "failed", 0, nil, fmt.Sprintf("invalid zone file: %v", err)
+	}
+	if err := dnsSvc.ValidateForProvider(domain.ID, records, len(records)); err != nil {
+		return "failed", len(records), nil, err.Error()
+	}
+	warnings := dns.RecordWarnings(records)
+	if dryRun {
+		return "valid", len(records), warnings, ""
+	}
+	if err := dnsSvc.BulkUpdateRecords(domain.ID, records); err != nil {
+		return "failed", len(records), warnings, err.Error()
+	}
+	return "imported", len(records), warnings, ""
+}
@@ -0,0 +1,92 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rusiqe/domainvault/internal/dns"
+	"github.com/rusiqe/domainvault/internal/types"
+)
+
+// DNS compare sources other than provider names
+const (
+	dnsSourceStored    = "stored"    // The records stored here
+	dnsSourceRegistrar = "registrar" // The domain's own provider
+)
+
+// dnsSourceRecords is what one side of a comparison returned
+type dnsSourceRecords struct {
+	records []types.DNSRecord
+	err     error
+}
+
+// CompareDomainDNS fetches a domain's records from two sources live and diffs them, to find
+// out where a zone managed in two places has drifted. ?a= and ?b= each name a connected
+// provider ("cloudflare"), "registrar" for the domain's own provider, or "stored" for the
+// records stored here; they default to cloudflare and registrar. ?ignore_ttl=true matches
+// records that differ only in TTL.
+func (h *AdminHandler) CompareDomainDNS(c *gin.Context) {
+	domain, err := h.domainRepo.GetByID(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusNotFound, types.CodeDomainNotFound, "Domain not found")
+		return
+	}
+
+	a := strings.ToLower(strings.TrimSpace(c.DefaultQuery("a", "cloudflare")))
+	b := strings.ToLower(strings.TrimSpace(c.DefaultQuery("b", dnsSourceRegistrar)))
+	if a == b {
+		respondError(c, http.StatusBadRequest, types.CodeValidationFailed, "a and b must name different sources")
+		return
+	}
+
+	// Both sides are fetched at once so a slow provider doesn't double the wait
+	results := [2]chan dnsSourceRecords{make(chan dnsSourceRecords, 1), make(chan dnsSourceRecords, 1)}
+	for i, source := range []string{a, b} {
+		go func(i int, source string) {
+			records, err := h.dnsSourceRecords(*domain, source)
+			results[i] <- dnsSourceRecords{records: records, err: err}
+		}(i, source)
+	}
+	sideA, sideB := <-results[0], <-results[1]
+	for _, side := range []struct {
+		name   string
+		result dnsSourceRecords
+	}{{a, sideA}, {b, sideB}} {
+		if side.result.err != nil {
+			respondWithErrorDetails(c, http.StatusBadGateway, side.result.err, gin.H{"source": side.name})
+			return
+		}
+	}
+
+	comparison := dns.CompareRecords(domain.Name, sideA.records, sideB.records, c.Query("ignore_ttl") == "true")
+	c.JSON(http.StatusOK, gin.H{
+		"domain_id":  domain.ID,
+		"a":          a,
+		"b":          b,
+		"a_count":    len(sideA.records),
+		"b_count":    len(sideB.records),
+		"comparison": comparison,
+	})
+}
+
+// dnsSourceRecords fetches a domain's records from a compare source
+func (h *AdminHandler) dnsSourceRecords(domain types.Domain, source string) ([]types.DNSRecord, error) {
+	switch source {
+	case dnsSourceStored:
+		return h.dnsSvc.GetDomainRecords(domain.ID)
+	case dnsSourceRegistrar:
+		source = domain.Provider
+	}
+
+	client, ok := h.providerSvc.GetClientByProviderName(source)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s is not connected", types.ErrUnsupportedProvider, source)
+	}
+	records, err := client.FetchDNSRecords(domain.Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch DNS records from %s: %w", source, err)
+	}
+	return records, nil
+}
@@ -0,0 +1,50 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rusiqe/domainvault/internal/types"
+)
+
+// GetDNSInventory lists every unique value of one stored DNS record type across the portfolio
+// and the domains and record names pointing at each, e.g. every IP in use by an A record.
+// ?type= is one of types.DNSInventoryTypes and defaults to A. ?project_id= and ?category_id=
+// narrow the domains; hidden domains are left out unless ?include_hidden=true.
+func (h *AdminHandler) GetDNSInventory(c *gin.Context) {
+	recordType := strings.ToUpper(strings.TrimSpace(c.DefaultQuery("type", "A")))
+	if !types.IsDNSInventoryType(recordType) {
+		respondError(c, http.StatusBadRequest, types.CodeValidationFailed,
+			fmt.Sprintf("Unsupported record type %q; expected one of %s", recordType, strings.Join(types.DNSInventoryTypes, ", ")))
+		return
+	}
+
+	filter := types.DomainFilter{IncludeHidden: c.Query("include_hidden") == "true"}
+	if projectID := c.Query("project_id"); projectID != "" {
+		filter.ProjectID = &projectID
+	}
+	if categoryID := c.Query("category_id"); categoryID != "" {
+		filter.CategoryID = &categoryID
+	}
+	filter.Scope = requestScope(c)
+	domains, err := h.domainRepo.GetByFilter(filter)
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err)
+		return
+	}
+	names := make(map[string]string, len(domains))
+	for _, domain := range domains {
+		names[domain.ID] = domain.Name
+	}
+
+	records, err := h.domainRepo.GetRecordsByType(recordType)
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.BuildDNSInventory(recordType, records, names, time.Now()))
+}
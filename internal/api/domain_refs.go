@@ -0,0 +1,62 @@
+package api
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/rusiqe/domainvault/internal/storage"
+	"github.com/rusiqe/domainvault/internal/types"
+)
+
+// resolveDomainRef finds the domain a bulk item refers to by either its ID or its name.
+// References containing a dot are looked up as names and anything else as an ID, so callers
+// don't need to resolve names to IDs first. The error names the reference and wraps
+// types.ErrDomainNotFound when nothing matches.
+func resolveDomainRef(repo storage.DomainRepository, ref string) (*types.Domain, error) {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return nil, fmt.Errorf("%w: empty domain ID or name", types.ErrDomainNotFound)
+	}
+
+	if !strings.Contains(ref, ".") {
+		if _, err := uuid.Parse(ref); err != nil {
+			return nil, fmt.Errorf("%w: %q is neither a domain ID nor a domain name", types.ErrDomainNotFound, ref)
+		}
+		domain, err := repo.GetByID(ref)
+		if err == types.ErrDomainNotFound {
+			return nil, fmt.Errorf("%w: no domain with ID %q", types.ErrDomainNotFound, ref)
+		}
+		return domain, err
+	}
+
+	domains, err := repo.GetDomainsByName(types.NormalizeDomainName(ref))
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up domain %q: %w", ref, err)
+	}
+	switch len(domains) {
+	case 0:
+		return nil, fmt.Errorf("%w: no domain named %q", types.ErrDomainNotFound, ref)
+	case 1:
+		return &domains[0], nil
+	}
+	return nil, fmt.Errorf("%d domains are named %q, use the domain ID instead", len(domains), ref)
+}
+
+// domainRefResult is one bulk item's reference and the domain it resolved to, or why it didn't
+type domainRefResult struct {
+	Ref    string
+	Domain *types.Domain
+	Err    error
+}
+
+// resolveDomainRefs resolves each reference with resolveDomainRef, keeping the request's order
+// so handlers can report unresolved references per item
+func resolveDomainRefs(repo storage.DomainRepository, refs []string) []domainRefResult {
+	results := make([]domainRefResult, len(refs))
+	for i, ref := range refs {
+		results[i].Ref = ref
+		results[i].Domain, results[i].Err = resolveDomainRef(repo, ref)
+	}
+	return results
+}
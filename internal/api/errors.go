@@ -0,0 +1,146 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rusiqe/domainvault/internal/analytics"
+	"github.com/rusiqe/domainvault/internal/dns"
+	"github.com/rusiqe/domainvault/internal/jobs"
+	"github.com/rusiqe/domainvault/internal/notifications"
+	"github.com/rusiqe/domainvault/internal/security"
+	"github.com/rusiqe/domainvault/internal/types"
+)
+
+// errorMapping gives the HTTP status and error code for an internal error
+type errorMapping struct {
+	err    error
+	status int
+	code   string
+}
+
+// errorMappings is checked in order with errors.Is, so more specific errors come first
+var errorMappings = []errorMapping{
+	{types.ErrDomainNotFound, http.StatusNotFound, types.CodeDomainNotFound},
+	{types.ErrDNSRecordNotFound, http.StatusNotFound, types.CodeDNSRecordNotFound},
+	{types.ErrSettingNotFound, http.StatusNotFound, types.CodeNotFound},
+	{types.ErrWebhookNotFound, http.StatusNotFound, types.CodeNotFound},
+	{types.ErrNotificationTemplateNotFound, http.StatusNotFound, types.CodeNotFound},
+	{types.ErrDNSBackupNotFound, http.StatusNotFound, types.CodeNotFound},
+	{types.ErrWatchlistNotFound, http.StatusNotFound, types.CodeNotFound},
+	{types.ErrCategorizationRuleNotFound, http.StatusNotFound, types.CodeNotFound},
+	{types.ErrSavedViewNotFound, http.StatusNotFound, types.CodeNotFound},
+	{types.ErrDomainNotVerified, http.StatusForbidden, types.CodeDomainNotVerified},
+	{types.ErrOutOfScope, http.StatusForbidden, types.CodeForbidden},
+	{types.ErrDomainExists, http.StatusConflict, types.CodeDomainExists},
+	{types.ErrWatchlistExists, http.StatusConflict, types.CodeConflict},
+	{types.ErrCategoryExists, http.StatusConflict, types.CodeConflict},
+	{types.ErrProjectExists, http.StatusConflict, types.CodeConflict},
+	{types.ErrSavedViewExists, http.StatusConflict, types.CodeConflict},
+	{types.ErrSyncInProgress, http.StatusConflict, types.CodeSyncInProgress},
+	{types.ErrInvalidDomainName, http.StatusBadRequest, types.CodeInvalidDomainName},
+	{types.ErrUnsupportedRecordType, http.StatusBadRequest, types.CodeProviderUnsupported},
+	{types.ErrInvalidDNSRecord, http.StatusBadRequest, types.CodeInvalidDNSRecord},
+	{types.ErrInvalidProvider, http.StatusBadRequest, types.CodeValidationFailed},
+	{types.ErrInvalidSort, http.StatusBadRequest, types.CodeValidationFailed},
+	{types.ErrInvalidMetadata, http.StatusBadRequest, types.CodeValidationFailed},
+	{types.ErrInvalidTags, http.StatusBadRequest, types.CodeValidationFailed},
+	{types.ErrInvalidCategory, http.StatusBadRequest, types.CodeValidationFailed},
+	{types.ErrInvalidProject, http.StatusBadRequest, types.CodeValidationFailed},
+	{types.ErrInvalidContact, http.StatusBadRequest, types.CodeValidationFailed},
+	{types.ErrInvalidRole, http.StatusBadRequest, types.CodeValidationFailed},
+	{types.ErrInvalidScope, http.StatusBadRequest, types.CodeValidationFailed},
+	{types.ErrInvalidResetToken, http.StatusBadRequest, types.CodeValidationFailed},
+	{types.ErrInvalidSettings, http.StatusBadRequest, types.CodeValidationFailed},
+	{types.ErrInvalidWebhook, http.StatusBadRequest, types.CodeValidationFailed},
+	{types.ErrInvalidNotificationTemplate, http.StatusBadRequest, types.CodeValidationFailed},
+	{types.ErrInvalidWatchlist, http.StatusBadRequest, types.CodeValidationFailed},
+	{types.ErrInvalidAvailabilitySearch, http.StatusBadRequest, types.CodeValidationFailed},
+	{types.ErrInvalidCategorizationRule, http.StatusBadRequest, types.CodeValidationFailed},
+	{types.ErrInvalidSavedView, http.StatusBadRequest, types.CodeValidationFailed},
+	{types.ErrInvalidSecretReference, http.StatusBadRequest, types.CodeValidationFailed},
+	{analytics.ErrUnknownMetric, http.StatusBadRequest, types.CodeValidationFailed},
+	{dns.ErrUnsupportedEmailProvider, http.StatusBadRequest, types.CodeValidationFailed},
+	{types.ErrUnsupportedProvider, http.StatusBadRequest, types.CodeUnsupportedProvider},
+	{types.ErrAutoRenewUnsupported, http.StatusNotImplemented, types.CodeProviderUnsupported},
+	{types.ErrContactsUnsupported, http.StatusNotImplemented, types.CodeProviderUnsupported},
+	{types.ErrAvailabilityUnsupported, http.StatusNotImplemented, types.CodeProviderUnsupported},
+	{types.ErrDNSWriteUnsupported, http.StatusNotImplemented, types.CodeProviderUnsupported},
+	{types.ErrDNSBackupFailed, http.StatusBadGateway, types.CodeProviderUnavailable},
+	{types.ErrProviderAuth, http.StatusBadGateway, types.CodeProviderAuthFailed},
+	{types.ErrProviderRateLimit, http.StatusTooManyRequests, types.CodeProviderRateLimited},
+	{types.ErrProviderTimeout, http.StatusGatewayTimeout, types.CodeProviderTimeout},
+	{types.ErrProviderUnexpectedResponse, http.StatusBadGateway, types.CodeProviderUnavailable},
+	{types.ErrProviderCircuitOpen, http.StatusServiceUnavailable, types.CodeProviderUnavailable},
+	{notifications.ErrInvalidWebhookSignature, http.StatusUnauthorized, types.CodeUnauthorized},
+	{security.ErrAccountLocked, http.StatusTooManyRequests, types.CodeAccountLocked},
+	{security.ErrLockoutUnavailable, http.StatusServiceUnavailable, types.CodeServiceUnavailable},
+	{jobs.ErrQueueFull, http.StatusServiceUnavailable, types.CodeServiceUnavailable},
+	{jobs.ErrQueueClosed, http.StatusServiceUnavailable, types.CodeServiceUnavailable},
+	{types.ErrShuttingDown, http.StatusServiceUnavailable, types.CodeServiceUnavailable},
+	{types.ErrMaintenanceMode, http.StatusServiceUnavailable, types.CodeMaintenance},
+}
+
+// statusCodes is the default error code for each HTTP status
+var statusCodes = map[int]string{
+	http.StatusBadRequest:            types.CodeInvalidRequest,
+	http.StatusUnauthorized:          types.CodeUnauthorized,
+	http.StatusForbidden:             types.CodeForbidden,
+	http.StatusNotFound:              types.CodeNotFound,
+	http.StatusConflict:              types.CodeConflict,
+	http.StatusRequestEntityTooLarge: types.CodeRequestTooLarge,
+	http.StatusUnprocessableEntity:   types.CodeValidationFailed,
+	http.StatusTooManyRequests:       types.CodeRateLimited,
+	http.StatusNotImplemented:        types.CodeNotImplemented,
+	http.StatusBadGateway:            types.CodeProviderUnavailable,
+	http.StatusServiceUnavailable:    types.CodeServiceUnavailable,
+	http.StatusGatewayTimeout:        types.CodeProviderTimeout,
+}
+
+// errorCodeForStatus returns the default error code for an HTTP status
+func errorCodeForStatus(status int) string {
+	if code, ok := statusCodes[status]; ok {
+		return code
+	}
+	return types.CodeInternal
+}
+
+// errorStatus returns the HTTP status and error code for err. Errors with no mapping get
+// fallback and its default code.
+func errorStatus(err error, fallback int) (int, string) {
+	for _, m := range errorMappings {
+		if errors.Is(err, m.err) {
+			return m.status, m.code
+		}
+	}
+	return fallback, errorCodeForStatus(fallback)
+}
+
+// respondError writes an error response with the given status, code and message
+func respondError(c *gin.Context, status int, code, message string) {
+	c.JSON(status, types.NewErrorResponse(code, message, nil))
+}
+
+// respondErrorDetails writes an error response carrying extra context for the client
+func respondErrorDetails(c *gin.Context, status int, code, message string, details interface{}) {
+	c.JSON(status, types.NewErrorResponse(code, message, details))
+}
+
+// respondWithError writes err as an error response. Known errors get their own status and
+// code; anything else is reported with fallback.
+func respondWithError(c *gin.Context, fallback int, err error) {
+	status, code := errorStatus(err, fallback)
+	respondError(c, status, code, err.Error())
+}
+
+// respondWithErrorDetails is respondWithError with extra context for the client
+func respondWithErrorDetails(c *gin.Context, fallback int, err error, details interface{}) {
+	status, code := errorStatus(err, fallback)
+	respondErrorDetails(c, status, code, err.Error(), details)
+}
+
+// abortWithError writes an error response and stops the handler chain, for middleware
+func abortWithError(c *gin.Context, status int, code, message string) {
+	c.AbortWithStatusJSON(status, types.NewErrorResponse(code, message, nil))
+}
@@ -1,12 +1,19 @@
 package api
 
 import (
+	"context"
 	"net/http"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/rusiqe/domainvault/internal/analytics"
+	"github.com/rusiqe/domainvault/internal/auth"
 	"github.com/rusiqe/domainvault/internal/core"
+	"github.com/rusiqe/domainvault/internal/jobs"
 	"github.com/rusiqe/domainvault/internal/storage"
 	"github.com/rusiqe/domainvault/internal/types"
 	"github.com/rusiqe/domainvault/internal/uptimerobot"
@@ -14,23 +21,37 @@ import (
 
 // DomainHandler handles HTTP requests for domain operations
 type DomainHandler struct {
-	repo      storage.DomainRepository
-	syncSvc   *core.SyncService
-	uptimeSvc *uptimerobot.Service
+	repo         storage.DomainRepository
+	syncSvc      *core.SyncService
+	uptimeSvc    *uptimerobot.Service
+	jobs         *jobs.Queue
+	analyticsSvc *analytics.AnalyticsService // Scores domain health in lists, nil leaves scores out
+	authSvc      *auth.AuthService           // Requires a bearer token so the caller's access scope applies
+	maintenance  maintenanceState            // Reported by the health check, nil leaves it out
+	limits       RequestLimits               // Caps how many domains one bulk request may name
 }
 
 // NewDomainHandler creates a new domain handler
-func NewDomainHandler(repo storage.DomainRepository, syncSvc *core.SyncService, uptimeSvc *uptimerobot.Service) *DomainHandler {
+func NewDomainHandler(repo storage.DomainRepository, syncSvc *core.SyncService, uptimeSvc *uptimerobot.Service, jobQueue *jobs.Queue) *DomainHandler {
 	return &DomainHandler{
 		repo:      repo,
 		syncSvc:   syncSvc,
 		uptimeSvc: uptimeSvc,
+		jobs:      jobQueue,
 	}
 }
 
 // RegisterRoutes sets up the HTTP routes
 func (h *DomainHandler) RegisterRoutes(r *gin.Engine) {
 	api := r.Group("/api/v1")
+
+	// Health check, open so load balancers can probe it
+	api.GET("/health", h.HealthCheck)
+
+	// Everything else needs a signed-in user once auth is configured, so roles and access scopes apply
+	if h.authSvc != nil {
+		api.Use(auth.AuthMiddleware(h.authSvc), auth.RequirePermission(nil), DomainScopeMiddleware(h.repo))
+	}
 	{
 		// Domain operations
 		api.GET("/domains", h.ListDomains)
@@ -38,12 +59,14 @@ func (h *DomainHandler) RegisterRoutes(r *gin.Engine) {
 		api.PUT("/domains/:id", h.UpdateDomain)
 api.DELETE("/domains/:id", h.DeleteDomain)
 		api.PUT("/domains/:id/visibility", h.SetDomainVisibility)
+		api.POST("/domains/bulk-visibility", h.BulkSetDomainVisibility)
 		api.GET("/domains/summary", h.GetSummary)
 		api.GET("/domains/expiring", h.GetExpiringDomains)
 
 		// Category operations
 		api.GET("/categories", h.ListCategories)
 		api.POST("/categories", h.CreateCategory)
+		api.GET("/categories/palette", h.GetColorPalette)
 		api.GET("/categories/:id", h.GetCategory)
 		api.PUT("/categories/:id", h.UpdateCategory)
 		api.DELETE("/categories/:id", h.DeleteCategory)
@@ -75,16 +98,50 @@ api.DELETE("/domains/:id", h.DeleteDomain)
 		api.POST("/monitoring/sync", h.SyncMonitoring)
 		api.POST("/monitoring/create", h.CreateMonitoring)
 		api.GET("/monitoring/stats", h.GetMonitoringStats)
-
-		// Health check
-		api.GET("/health", h.HealthCheck)
 	}
 }
 
+// SetMaintenanceState adds the maintenance mode to the health check
+func (h *DomainHandler) SetMaintenanceState(state maintenanceState) {
+	h.maintenance = state
+}
+
+// SetRequestLimits sets how many domains one bulk request may name
+func (h *DomainHandler) SetRequestLimits(limits RequestLimits) {
+	h.limits = limits
+}
+
+// SetAuthService identifies callers that send a bearer token, limiting users with an access
+// scope to their domains
+func (h *DomainHandler) SetAuthService(svc *auth.AuthService) {
+	h.authSvc = svc
+}
+
+// SetAnalyticsService adds health scores to domain lists and lets them be sorted by score
+func (h *DomainHandler) SetAnalyticsService(svc *analytics.AnalyticsService) {
+	h.analyticsSvc = svc
+}
+
+// domainWithHealth is a listed domain with its health score
+type domainWithHealth struct {
+	types.Domain
+	HealthScore int `json:"health_score"`
+}
+
 // ListDomains returns a list of domains with optional filtering
 func (h *DomainHandler) ListDomains(c *gin.Context) {
 	filter := types.DomainFilter{}
 
+	// A saved view supplies the starting filter and sort; other parameters refine it
+	if viewID := c.Query("view"); viewID != "" {
+		view, err := h.repo.GetSavedViewByID(viewID)
+		if err != nil {
+			respondWithError(c, http.StatusInternalServerError, err)
+			return
+		}
+		filter = types.DomainFilter(view.Filter)
+	}
+
 	// Parse query parameters
 	if provider := c.Query("provider"); provider != "" {
 		filter.Provider = provider
@@ -94,11 +151,29 @@ func (h *DomainHandler) ListDomains(c *gin.Context) {
 		filter.Search = search
 	}
 
+	if accountRef := c.Query("account_ref"); accountRef != "" {
+		filter.AccountRef = &accountRef
+	}
+
+	if categoryID := c.Query("category_id"); categoryID != "" {
+		filter.CategoryID = &categoryID
+	}
+
+	if projectID := c.Query("project_id"); projectID != "" {
+		filter.ProjectID = &projectID
+	}
+
+	if autoRenew := c.Query("auto_renew"); autoRenew != "" {
+		if value, err := strconv.ParseBool(autoRenew); err == nil {
+			filter.AutoRenew = &value
+		}
+	}
+
 	if limitStr := c.Query("limit"); limitStr != "" {
 		if limit, err := strconv.Atoi(limitStr); err == nil && limit > 0 {
 			filter.Limit = limit
 		}
-	} else {
+	} else if filter.Limit == 0 {
 		filter.Limit = 50 // Default limit
 	}
 
@@ -129,15 +204,96 @@ func (h *DomainHandler) ListDomains(c *gin.Context) {
 		filter.IncludeHidden = true
 	}
 
+	// metadata.<key>=<value> filters on custom metadata; repeat for several keys
+	for param, values := range c.Request.URL.Query() {
+		if key, ok := strings.CutPrefix(param, "metadata."); ok && len(values) > 0 {
+			if filter.Metadata == nil {
+				filter.Metadata = map[string]string{}
+			}
+			filter.Metadata[key] = values[0]
+		}
+	}
+	if err := types.MetadataMap(filter.Metadata).Validate(); err != nil {
+		respondWithError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	if sortBy := c.Query("sort"); sortBy != "" {
+		filter.SortBy = sortBy
+	}
+	if order := c.Query("order"); order != "" {
+		filter.SortOrder = order
+	}
+	// Health scores aren't stored, so sorting by them loads every match and pages here
+	byHealth := filter.SortBy == analytics.HealthScoreSortField && h.analyticsSvc != nil
+	if byHealth {
+		filter.SortBy = ""
+	}
+	if err := filter.ValidateSort(); err != nil {
+		allowed := types.DomainSortFields
+		if h.analyticsSvc != nil {
+			allowed = append(append([]string{}, allowed...), analytics.HealthScoreSortField)
+		}
+		respondWithErrorDetails(c, http.StatusBadRequest, err, gin.H{"allowed_fields": allowed})
+		return
+	}
+	limit, offset := filter.Limit, filter.Offset
+	if byHealth {
+		filter.Limit, filter.Offset = 0, 0
+	}
+	filter.Scope = requestScope(c)
+
 	domains, err := h.repo.GetByFilter(filter)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondWithError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	if h.analyticsSvc == nil {
+		// Dashboards poll this list; skip re-sending it when nothing has changed
+		if notModified(c, domainListETag(filter, domains)) {
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{
+			"domains": domains,
+			"count":   len(domains),
+			"filter":  filter,
+		})
+		return
+	}
+
+	now := time.Now()
+	scored := make([]domainWithHealth, len(domains))
+	for i, domain := range domains {
+		scored[i] = domainWithHealth{Domain: domain, HealthScore: h.analyticsSvc.DomainHealth(domain, nil, now).Score}
+	}
+	if byHealth {
+		filter.SortBy, filter.Limit, filter.Offset = analytics.HealthScoreSortField, limit, offset
+		sort.SliceStable(scored, func(i, j int) bool {
+			if filter.SortOrder == types.SortDesc {
+				return scored[i].HealthScore > scored[j].HealthScore
+			}
+			return scored[i].HealthScore < scored[j].HealthScore
+		})
+		start, end := min(offset, len(scored)), len(scored)
+		if limit > 0 && start+limit < end {
+			end = start + limit
+		}
+		scored, domains = scored[start:end], domains[:0]
+		for _, d := range scored {
+			domains = append(domains, d.Domain)
+		}
+	}
+
+	// Scores drift as expiry approaches even when no domain changes, so the tag changes daily
+	etag := strings.TrimSuffix(domainListETag(filter, domains), `"`) + "-" + now.Format("20060102") + `"`
+	if notModified(c, etag) {
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"domains": domains,
-		"count":   len(domains),
+		"domains": scored,
+		"count":   len(scored),
 		"filter":  filter,
 	})
 }
@@ -146,16 +302,16 @@ func (h *DomainHandler) ListDomains(c *gin.Context) {
 func (h *DomainHandler) GetDomain(c *gin.Context) {
 	id := c.Param("id")
 	if id == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "domain ID required"})
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "domain ID required")
 		return
 	}
 
 	domain, err := h.repo.GetByID(id)
 	if err != nil {
 		if err == types.ErrDomainNotFound {
-			c.JSON(http.StatusNotFound, gin.H{"error": "domain not found"})
+			respondError(c, http.StatusNotFound, types.CodeDomainNotFound, "domain not found")
 		} else {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			respondWithError(c, http.StatusInternalServerError, err)
 		}
 		return
 	}
@@ -167,16 +323,16 @@ func (h *DomainHandler) GetDomain(c *gin.Context) {
 func (h *DomainHandler) DeleteDomain(c *gin.Context) {
 	id := c.Param("id")
 	if id == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "domain ID required"})
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "domain ID required")
 		return
 	}
 
 	err := h.repo.Delete(id)
 	if err != nil {
 		if err == types.ErrDomainNotFound {
-			c.JSON(http.StatusNotFound, gin.H{"error": "domain not found"})
+			respondError(c, http.StatusNotFound, types.CodeDomainNotFound, "domain not found")
 		} else {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			respondWithError(c, http.StatusInternalServerError, err)
 		}
 		return
 	}
@@ -188,20 +344,20 @@ func (h *DomainHandler) DeleteDomain(c *gin.Context) {
 func (h *DomainHandler) SetDomainVisibility(c *gin.Context) {
 	id := c.Param("id")
 	if id == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "domain ID required"})
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "domain ID required")
 		return
 	}
 	var req struct{ Visible bool `json:"visible"` }
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "invalid request body")
 		return
 	}
 	if err := h.repo.SetVisibility(id, req.Visible); err != nil {
 		if err == types.ErrDomainNotFound {
-			c.JSON(http.StatusNotFound, gin.H{"error": "domain not found"})
+			respondError(c, http.StatusNotFound, types.CodeDomainNotFound, "domain not found")
 			return
 		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondWithError(c, http.StatusInternalServerError, err)
 		return
 	}
 	status := "hidden"
@@ -211,11 +367,131 @@ func (h *DomainHandler) SetDomainVisibility(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "domain visibility updated", "status": status})
 }
 
-// GetSummary returns domain statistics
+// BulkSetDomainVisibility hides or restores several domains, each given by ID or name, in one
+// transaction. When hiding, pause_monitors also pauses each domain's UptimeRobot monitor.
+func (h *DomainHandler) BulkSetDomainVisibility(c *gin.Context) {
+	var req types.DomainVisibilityRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "invalid request body: domain_ids and visible are required")
+		return
+	}
+	if len(req.DomainIDs) == 0 {
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "at least one domain ID is required")
+		return
+	}
+	if !checkBulkOperations(c, h.limits, len(req.DomainIDs)) {
+		return
+	}
+	if req.PauseMonitors && *req.Visible {
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "pause_monitors only applies when hiding domains")
+		return
+	}
+	if req.PauseMonitors && h.uptimeSvc == nil {
+		respondError(c, http.StatusServiceUnavailable, types.CodeServiceUnavailable, "UptimeRobot service not available")
+		return
+	}
+
+	// Domains are given by ID or name; unresolved references are reported per domain rather
+	// than aborting the transaction. IDs go straight to the transaction, since a lookup by ID
+	// skips the hidden domains being restored.
+	results := make([]types.DomainVisibilityResult, len(req.DomainIDs))
+	var ids []string
+	seen := make(map[string]bool, len(req.DomainIDs))
+	for i, ref := range req.DomainIDs {
+		id := strings.TrimSpace(ref)
+		results[i].DomainID = id
+		if _, err := uuid.Parse(id); err != nil {
+			domain, err := resolveDomainRef(h.repo, ref)
+			if err != nil {
+				results[i].Error = err.Error()
+				continue
+			}
+			id = domain.ID
+			results[i].DomainID = id
+		}
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	if scope := requestScope(c); scope != nil {
+		var err error
+		if ids, err = h.scopeVisibilityIDs(*scope, ids, results); err != nil {
+			respondWithError(c, http.StatusInternalServerError, err)
+			return
+		}
+	}
+
+	updated, err := h.repo.BulkSetVisibility(ids, *req.Visible)
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err)
+		return
+	}
+	byID := make(map[string]types.Domain, len(updated))
+	for _, domain := range updated {
+		byID[domain.ID] = domain
+	}
+
+	succeeded, paused := 0, 0
+	for i := range results {
+		result := &results[i]
+		if result.Error != "" {
+			continue
+		}
+		domain, ok := byID[result.DomainID]
+		if !ok {
+			result.Error = "domain not found"
+			continue
+		}
+		result.DomainName = domain.Name
+		result.Success = true
+		succeeded++
+
+		if req.PauseMonitors && domain.UptimeRobotMonitorID != nil {
+			if err := h.uptimeSvc.PauseMonitor(*domain.UptimeRobotMonitorID); err != nil {
+				result.Error = "domain hidden but failed to pause monitor: " + err.Error()
+				continue
+			}
+			result.MonitorPaused = true
+			paused++
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"visible":         *req.Visible,
+		"total":           len(results),
+		"updated":         succeeded,
+		"failed":          len(results) - succeeded,
+		"monitors_paused": paused,
+		"results":         results,
+	})
+}
+
+// scopeVisibilityIDs drops the domains outside scope from ids, marking their results as failed
+func (h *DomainHandler) scopeVisibilityIDs(scope types.AccessScope, ids []string, results []types.DomainVisibilityResult) ([]string, error) {
+	allowed, err := scopedDomainIDs(h.repo, scope)
+	if err != nil {
+		return nil, err
+	}
+	inScope := ids[:0]
+	for _, id := range ids {
+		if allowed[id] {
+			inScope = append(inScope, id)
+		}
+	}
+	for i := range results {
+		if results[i].Error == "" && !allowed[results[i].DomainID] {
+			results[i].Error = types.ErrOutOfScope.Error()
+		}
+	}
+	return inScope, nil
+}
+
+// GetSummary returns domain statistics, with expiring counts for the configured summary thresholds
 func (h *DomainHandler) GetSummary(c *gin.Context) {
-	summary, err := h.repo.GetSummary()
+	summary, err := h.repo.GetSummary(loadExpiryThresholds(h.repo).SummaryDays)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondWithError(c, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -224,8 +500,8 @@ func (h *DomainHandler) GetSummary(c *gin.Context) {
 
 // GetExpiringDomains returns domains expiring within a threshold
 func (h *DomainHandler) GetExpiringDomains(c *gin.Context) {
-	// Default to 30 days
-	threshold := 30 * 24 * time.Hour
+	// Default to the configured "expiring soon" window
+	threshold := time.Duration(loadExpiryThresholds(h.repo).ExpiringSoonDays) * 24 * time.Hour
 
 	if daysStr := c.Query("days"); daysStr != "" {
 		if days, err := strconv.Atoi(daysStr); err == nil && days > 0 {
@@ -235,9 +511,10 @@ func (h *DomainHandler) GetExpiringDomains(c *gin.Context) {
 
 	domains, err := h.repo.GetExpiring(threshold)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondWithError(c, http.StatusInternalServerError, err)
 		return
 	}
+	domains = scopeDomains(c, domains)
 
 	c.JSON(http.StatusOK, gin.H{
 		"domains":   domains,
@@ -246,40 +523,41 @@ func (h *DomainHandler) GetExpiringDomains(c *gin.Context) {
 	})
 }
 
-// TriggerSync starts a full sync across all providers including DNS records
+// TriggerSync queues a full sync across all providers including DNS records
 func (h *DomainHandler) TriggerSync(c *gin.Context) {
-	go func() {
+	job, ok := enqueueJob(c, h.jobs, "sync", func(ctx context.Context, tracker *jobs.Tracker) (interface{}, error) {
 		if err := h.syncSvc.SyncDomainsWithDNS(); err != nil {
-			// Log error, but don't block the response
-			// In production, this would be logged properly
+			return nil, err
 		}
-	}()
-
-	c.JSON(http.StatusAccepted, gin.H{
-		"message": "sync started",
-		"status":  "accepted",
+		return h.syncSvc.LastReport(), nil
 	})
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusAccepted, jobAccepted(job, gin.H{"message": "sync started"}))
 }
 
 // SyncProvider triggers sync for a specific provider including DNS records
 func (h *DomainHandler) SyncProvider(c *gin.Context) {
 	provider := c.Param("provider")
 	if provider == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "provider name required"})
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "provider name required")
 		return
 	}
 
-	go func() {
-		if err := h.syncSvc.SyncProviderWithDNS(provider); err != nil {
-			// Log error, but don't block the response
-		}
-	}()
+	job, ok := enqueueJob(c, h.jobs, "provider_sync", func(ctx context.Context, tracker *jobs.Tracker) (interface{}, error) {
+		err := h.syncSvc.SyncProviderWithDNS(provider)
+		return h.syncSvc.GetStatus().Providers[provider], err
+	})
+	if !ok {
+		return
+	}
 
-	c.JSON(http.StatusAccepted, gin.H{
+	c.JSON(http.StatusAccepted, jobAccepted(job, gin.H{
 		"message":  "provider sync started",
 		"provider": provider,
-		"status":   "accepted",
-	})
+	}))
 }
 
 // GetSyncStatus returns the current sync service status
@@ -300,11 +578,15 @@ func (h *DomainHandler) HealthCheck(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
+	response := gin.H{
 		"status":   "healthy",
 		"database": "connected",
 		"version":  "1.0.0",
-	})
+	}
+	if h.maintenance != nil {
+		response["maintenance"] = h.maintenance.Maintenance()
+	}
+	c.JSON(http.StatusOK, response)
 }
 
 // ============================================================================
@@ -315,19 +597,23 @@ func (h *DomainHandler) HealthCheck(c *gin.Context) {
 func (h *DomainHandler) UpdateDomain(c *gin.Context) {
 	id := c.Param("id")
 	if id == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Domain ID required"})
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Domain ID required")
 		return
 	}
 
 	var domain types.Domain
 	if err := c.ShouldBindJSON(&domain); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid domain data"})
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Invalid domain data")
+		return
+	}
+	if err := domain.ValidateAnnotations(); err != nil {
+		respondWithError(c, http.StatusBadRequest, err)
 		return
 	}
 
 	domain.ID = id
 	if err := h.repo.Update(&domain); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondWithError(c, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -338,59 +624,115 @@ func (h *DomainHandler) UpdateDomain(c *gin.Context) {
 // CATEGORY MANAGEMENT METHODS
 // ============================================================================
 
-// ListCategories returns all categories
+// ListCategories returns all categories.
+// Supports ?with_counts=true to include domain_count per category, ?sort=name|count,
+// and limit/offset pagination.
 func (h *DomainHandler) ListCategories(c *gin.Context) {
-	if repo, ok := h.repo.(interface{ GetAllCategories() ([]types.Category, error) }); ok {
-		categories, err := repo.GetAllCategories()
+	sortBy := c.DefaultQuery("sort", "name")
+	if sortBy != "name" && sortBy != "count" {
+		respondError(c, http.StatusBadRequest, types.CodeValidationFailed, "sort must be 'name' or 'count'")
+		return
+	}
+
+	if c.Query("with_counts") == "true" || sortBy == "count" {
+		categories, err := h.repo.GetCategoriesWithCounts(sortBy)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			respondWithError(c, http.StatusInternalServerError, err)
 			return
 		}
+		start, end := pageBounds(c, len(categories))
 		c.JSON(http.StatusOK, gin.H{
-			"categories": categories,
-			"count":      len(categories),
+			"categories": categories[start:end],
+			"count":      end - start,
+			"total":      len(categories),
 		})
-	} else {
-		c.JSON(http.StatusNotImplemented, gin.H{"error": "Category operations not implemented"})
+		return
 	}
+
+	categories, err := h.repo.GetAllCategories()
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err)
+		return
+	}
+	start, end := pageBounds(c, len(categories))
+	c.JSON(http.StatusOK, gin.H{
+		"categories": categories[start:end],
+		"count":      end - start,
+		"total":      len(categories),
+	})
 }
 
 // CreateCategory creates a new category
 func (h *DomainHandler) CreateCategory(c *gin.Context) {
 	var category types.Category
 	if err := c.ShouldBindJSON(&category); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid category data"})
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Invalid category data")
+		return
+	}
+	if err := category.Validate(); err != nil {
+		respondWithError(c, http.StatusBadRequest, err)
 		return
 	}
 
 	if repo, ok := h.repo.(interface{ CreateCategory(*types.Category) error }); ok {
 		if err := repo.CreateCategory(&category); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			respondWithError(c, http.StatusInternalServerError, err)
 			return
 		}
 		c.JSON(http.StatusCreated, category)
 	} else {
-		c.JSON(http.StatusNotImplemented, gin.H{"error": "Category operations not implemented"})
+		respondError(c, http.StatusNotImplemented, types.CodeNotImplemented, "Category operations not implemented")
+	}
+}
+
+// GetColorPalette returns the curated colors for categories and projects. With
+// ?exclude_used=true, colors already used by a category or project are left out so new ones
+// stand apart.
+func (h *DomainHandler) GetColorPalette(c *gin.Context) {
+	if c.Query("exclude_used") != "true" {
+		c.JSON(http.StatusOK, gin.H{"colors": types.ColorPalette, "count": len(types.ColorPalette)})
+		return
+	}
+
+	categories, err := h.repo.GetAllCategories()
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err)
+		return
 	}
+	projects, err := h.repo.GetAllProjects()
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err)
+		return
+	}
+	used := make([]string, 0, len(categories)+len(projects))
+	for _, category := range categories {
+		used = append(used, category.Color)
+	}
+	for _, project := range projects {
+		used = append(used, project.Color)
+	}
+
+	colors := types.SuggestColors(used)
+	c.JSON(http.StatusOK, gin.H{"colors": colors, "count": len(colors)})
 }
 
 // GetCategory returns a specific category by ID
 func (h *DomainHandler) GetCategory(c *gin.Context) {
 	id := c.Param("id")
 	if id == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Category ID required"})
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Category ID required")
 		return
 	}
 
 	if repo, ok := h.repo.(interface{ GetCategoryByID(string) (*types.Category, error) }); ok {
 		category, err := repo.GetCategoryByID(id)
 		if err != nil {
-			c.JSON(http.StatusNotFound, gin.H{"error": "Category not found"})
+			respondError(c, http.StatusNotFound, types.CodeNotFound, "Category not found")
 			return
 		}
 		c.JSON(http.StatusOK, category)
 	} else {
-		c.JSON(http.StatusNotImplemented, gin.H{"error": "Category operations not implemented"})
+		respondError(c, http.StatusNotImplemented, types.CodeNotImplemented, "Category operations not implemented")
 	}
 }
 
@@ -398,25 +740,29 @@ func (h *DomainHandler) GetCategory(c *gin.Context) {
 func (h *DomainHandler) UpdateCategory(c *gin.Context) {
 	id := c.Param("id")
 	if id == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Category ID required"})
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Category ID required")
 		return
 	}
 
 	var category types.Category
 	if err := c.ShouldBindJSON(&category); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid category data"})
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Invalid category data")
+		return
+	}
+	if err := category.Validate(); err != nil {
+		respondWithError(c, http.StatusBadRequest, err)
 		return
 	}
 
 	category.ID = id
 	if repo, ok := h.repo.(interface{ UpdateCategory(*types.Category) error }); ok {
 		if err := repo.UpdateCategory(&category); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			respondWithError(c, http.StatusInternalServerError, err)
 			return
 		}
 		c.JSON(http.StatusOK, category)
 	} else {
-		c.JSON(http.StatusNotImplemented, gin.H{"error": "Category operations not implemented"})
+		respondError(c, http.StatusNotImplemented, types.CodeNotImplemented, "Category operations not implemented")
 	}
 }
 
@@ -424,18 +770,18 @@ func (h *DomainHandler) UpdateCategory(c *gin.Context) {
 func (h *DomainHandler) DeleteCategory(c *gin.Context) {
 	id := c.Param("id")
 	if id == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Category ID required"})
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Category ID required")
 		return
 	}
 
 	if repo, ok := h.repo.(interface{ DeleteCategory(string) error }); ok {
 		if err := repo.DeleteCategory(id); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			respondWithError(c, http.StatusInternalServerError, err)
 			return
 		}
 		c.JSON(http.StatusOK, gin.H{"message": "Category deleted successfully"})
 	} else {
-		c.JSON(http.StatusNotImplemented, gin.H{"error": "Category operations not implemented"})
+		respondError(c, http.StatusNotImplemented, types.CodeNotImplemented, "Category operations not implemented")
 	}
 }
 
@@ -443,39 +789,64 @@ func (h *DomainHandler) DeleteCategory(c *gin.Context) {
 // PROJECT MANAGEMENT METHODS
 // ============================================================================
 
-// ListProjects returns all projects
+// ListProjects returns all projects.
+// Supports ?with_counts=true to include domain_count per project, ?sort=name|count,
+// and limit/offset pagination.
 func (h *DomainHandler) ListProjects(c *gin.Context) {
-	if repo, ok := h.repo.(interface{ GetAllProjects() ([]types.Project, error) }); ok {
-		projects, err := repo.GetAllProjects()
+	sortBy := c.DefaultQuery("sort", "name")
+	if sortBy != "name" && sortBy != "count" {
+		respondError(c, http.StatusBadRequest, types.CodeValidationFailed, "sort must be 'name' or 'count'")
+		return
+	}
+
+	if c.Query("with_counts") == "true" || sortBy == "count" {
+		projects, err := h.repo.GetProjectsWithCounts(sortBy)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			respondWithError(c, http.StatusInternalServerError, err)
 			return
 		}
+		start, end := pageBounds(c, len(projects))
 		c.JSON(http.StatusOK, gin.H{
-			"projects": projects,
-			"count":    len(projects),
+			"projects": projects[start:end],
+			"count":    end - start,
+			"total":    len(projects),
 		})
-	} else {
-		c.JSON(http.StatusNotImplemented, gin.H{"error": "Project operations not implemented"})
+		return
+	}
+
+	projects, err := h.repo.GetAllProjects()
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err)
+		return
 	}
+	start, end := pageBounds(c, len(projects))
+	c.JSON(http.StatusOK, gin.H{
+		"projects": projects[start:end],
+		"count":    end - start,
+		"total":    len(projects),
+	})
 }
 
 // CreateProject creates a new project
 func (h *DomainHandler) CreateProject(c *gin.Context) {
 	var project types.Project
 	if err := c.ShouldBindJSON(&project); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project data"})
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Invalid project data")
+		return
+	}
+	if err := project.Validate(); err != nil {
+		respondWithError(c, http.StatusBadRequest, err)
 		return
 	}
 
 	if repo, ok := h.repo.(interface{ CreateProject(*types.Project) error }); ok {
 		if err := repo.CreateProject(&project); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			respondWithError(c, http.StatusInternalServerError, err)
 			return
 		}
 		c.JSON(http.StatusCreated, project)
 	} else {
-		c.JSON(http.StatusNotImplemented, gin.H{"error": "Project operations not implemented"})
+		respondError(c, http.StatusNotImplemented, types.CodeNotImplemented, "Project operations not implemented")
 	}
 }
 
@@ -483,19 +854,19 @@ func (h *DomainHandler) CreateProject(c *gin.Context) {
 func (h *DomainHandler) GetProject(c *gin.Context) {
 	id := c.Param("id")
 	if id == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Project ID required"})
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Project ID required")
 		return
 	}
 
 	if repo, ok := h.repo.(interface{ GetProjectByID(string) (*types.Project, error) }); ok {
 		project, err := repo.GetProjectByID(id)
 		if err != nil {
-			c.JSON(http.StatusNotFound, gin.H{"error": "Project not found"})
+			respondError(c, http.StatusNotFound, types.CodeNotFound, "Project not found")
 			return
 		}
 		c.JSON(http.StatusOK, project)
 	} else {
-		c.JSON(http.StatusNotImplemented, gin.H{"error": "Project operations not implemented"})
+		respondError(c, http.StatusNotImplemented, types.CodeNotImplemented, "Project operations not implemented")
 	}
 }
 
@@ -503,25 +874,29 @@ func (h *DomainHandler) GetProject(c *gin.Context) {
 func (h *DomainHandler) UpdateProject(c *gin.Context) {
 	id := c.Param("id")
 	if id == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Project ID required"})
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Project ID required")
 		return
 	}
 
 	var project types.Project
 	if err := c.ShouldBindJSON(&project); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project data"})
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Invalid project data")
+		return
+	}
+	if err := project.Validate(); err != nil {
+		respondWithError(c, http.StatusBadRequest, err)
 		return
 	}
 
 	project.ID = id
 	if repo, ok := h.repo.(interface{ UpdateProject(*types.Project) error }); ok {
 		if err := repo.UpdateProject(&project); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			respondWithError(c, http.StatusInternalServerError, err)
 			return
 		}
 		c.JSON(http.StatusOK, project)
 	} else {
-		c.JSON(http.StatusNotImplemented, gin.H{"error": "Project operations not implemented"})
+		respondError(c, http.StatusNotImplemented, types.CodeNotImplemented, "Project operations not implemented")
 	}
 }
 
@@ -529,18 +904,18 @@ func (h *DomainHandler) UpdateProject(c *gin.Context) {
 func (h *DomainHandler) DeleteProject(c *gin.Context) {
 	id := c.Param("id")
 	if id == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Project ID required"})
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Project ID required")
 		return
 	}
 
 	if repo, ok := h.repo.(interface{ DeleteProject(string) error }); ok {
 		if err := repo.DeleteProject(id); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			respondWithError(c, http.StatusInternalServerError, err)
 			return
 		}
 		c.JSON(http.StatusOK, gin.H{"message": "Project deleted successfully"})
 	} else {
-		c.JSON(http.StatusNotImplemented, gin.H{"error": "Project operations not implemented"})
+		respondError(c, http.StatusNotImplemented, types.CodeNotImplemented, "Project operations not implemented")
 	}
 }
 
@@ -553,7 +928,7 @@ func (h *DomainHandler) ListCredentials(c *gin.Context) {
 	if repo, ok := h.repo.(interface{ GetAllCredentials() ([]types.ProviderCredentials, error) }); ok {
 		credentials, err := repo.GetAllCredentials()
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			respondWithError(c, http.StatusInternalServerError, err)
 			return
 		}
 
@@ -567,7 +942,7 @@ func (h *DomainHandler) ListCredentials(c *gin.Context) {
 			"count":       len(credentials),
 		})
 	} else {
-		c.JSON(http.StatusNotImplemented, gin.H{"error": "Credentials operations not implemented"})
+		respondError(c, http.StatusNotImplemented, types.CodeNotImplemented, "Credentials operations not implemented")
 	}
 }
 
@@ -575,13 +950,13 @@ func (h *DomainHandler) ListCredentials(c *gin.Context) {
 func (h *DomainHandler) CreateCredentials(c *gin.Context) {
 	var creds types.ProviderCredentials
 	if err := c.ShouldBindJSON(&creds); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid credentials data"})
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Invalid credentials data")
 		return
 	}
 
 	if repo, ok := h.repo.(interface{ CreateCredentials(*types.ProviderCredentials) error }); ok {
 		if err := repo.CreateCredentials(&creds); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			respondWithError(c, http.StatusInternalServerError, err)
 			return
 		}
 
@@ -589,7 +964,7 @@ func (h *DomainHandler) CreateCredentials(c *gin.Context) {
 		creds.Credentials = map[string]string{"***": "***"}
 		c.JSON(http.StatusCreated, creds)
 	} else {
-		c.JSON(http.StatusNotImplemented, gin.H{"error": "Credentials operations not implemented"})
+		respondError(c, http.StatusNotImplemented, types.CodeNotImplemented, "Credentials operations not implemented")
 	}
 }
 
@@ -597,14 +972,14 @@ func (h *DomainHandler) CreateCredentials(c *gin.Context) {
 func (h *DomainHandler) GetCredentials(c *gin.Context) {
 	id := c.Param("id")
 	if id == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Credentials ID required"})
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Credentials ID required")
 		return
 	}
 
 	if repo, ok := h.repo.(interface{ GetCredentialsByID(string) (*types.ProviderCredentials, error) }); ok {
 		creds, err := repo.GetCredentialsByID(id)
 		if err != nil {
-			c.JSON(http.StatusNotFound, gin.H{"error": "Credentials not found"})
+			respondError(c, http.StatusNotFound, types.CodeNotFound, "Credentials not found")
 			return
 		}
 
@@ -612,7 +987,7 @@ func (h *DomainHandler) GetCredentials(c *gin.Context) {
 		creds.Credentials = map[string]string{"***": "***"}
 		c.JSON(http.StatusOK, creds)
 	} else {
-		c.JSON(http.StatusNotImplemented, gin.H{"error": "Credentials operations not implemented"})
+		respondError(c, http.StatusNotImplemented, types.CodeNotImplemented, "Credentials operations not implemented")
 	}
 }
 
@@ -620,20 +995,20 @@ func (h *DomainHandler) GetCredentials(c *gin.Context) {
 func (h *DomainHandler) UpdateCredentials(c *gin.Context) {
 	id := c.Param("id")
 	if id == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Credentials ID required"})
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Credentials ID required")
 		return
 	}
 
 	var creds types.ProviderCredentials
 	if err := c.ShouldBindJSON(&creds); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid credentials data"})
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Invalid credentials data")
 		return
 	}
 
 	creds.ID = id
 	if repo, ok := h.repo.(interface{ UpdateCredentials(*types.ProviderCredentials) error }); ok {
 		if err := repo.UpdateCredentials(&creds); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			respondWithError(c, http.StatusInternalServerError, err)
 			return
 		}
 
@@ -641,7 +1016,7 @@ func (h *DomainHandler) UpdateCredentials(c *gin.Context) {
 		creds.Credentials = map[string]string{"***": "***"}
 		c.JSON(http.StatusOK, creds)
 	} else {
-		c.JSON(http.StatusNotImplemented, gin.H{"error": "Credentials operations not implemented"})
+		respondError(c, http.StatusNotImplemented, types.CodeNotImplemented, "Credentials operations not implemented")
 	}
 }
 
@@ -649,18 +1024,18 @@ func (h *DomainHandler) UpdateCredentials(c *gin.Context) {
 func (h *DomainHandler) DeleteCredentials(c *gin.Context) {
 	id := c.Param("id")
 	if id == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Credentials ID required"})
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Credentials ID required")
 		return
 	}
 
 	if repo, ok := h.repo.(interface{ DeleteCredentials(string) error }); ok {
 		if err := repo.DeleteCredentials(id); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			respondWithError(c, http.StatusInternalServerError, err)
 			return
 		}
 		c.JSON(http.StatusOK, gin.H{"message": "Credentials deleted successfully"})
 	} else {
-		c.JSON(http.StatusNotImplemented, gin.H{"error": "Credentials operations not implemented"})
+		respondError(c, http.StatusNotImplemented, types.CodeNotImplemented, "Credentials operations not implemented")
 	}
 }
 
@@ -672,7 +1047,7 @@ func (h *DomainHandler) DeleteCredentials(c *gin.Context) {
 func (h *DomainHandler) ImportDomains(c *gin.Context) {
 	var req types.ImportRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid import request"})
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Invalid import request")
 		return
 	}
 
@@ -738,30 +1113,24 @@ func (h *DomainHandler) CreateMonitoring(c *gin.Context) {
 func (h *DomainHandler) GetMonitoringStats(c *gin.Context) {
 	// Check if UptimeRobot is configured
 	if h.uptimeSvc == nil || !h.uptimeSvc.IsConfigured() {
-		c.JSON(http.StatusServiceUnavailable, gin.H{
-			"error": "UptimeRobot is not configured",
-			"message": "Please configure UptimeRobot API key first",
-		})
+		respondErrorDetails(c, http.StatusServiceUnavailable, types.CodeServiceUnavailable,
+			"UptimeRobot is not configured", "Please configure UptimeRobot API key first")
 		return
 	}
 
 	// Get live monitoring metrics from UptimeRobot
 	uptimeMetrics, err := h.uptimeSvc.GetMonitoringMetrics()
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Failed to get UptimeRobot monitoring statistics",
-			"details": err.Error(),
-		})
+		respondErrorDetails(c, http.StatusInternalServerError, types.CodeProviderUnavailable,
+			"Failed to get UptimeRobot monitoring statistics", err.Error())
 		return
 	}
 
 	// Get live DomainVault monitors with detailed stats
 	liveMonitors, err := h.uptimeSvc.GetDomainVaultMonitors(true)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Failed to get live monitor data",
-			"details": err.Error(),
-		})
+		respondErrorDetails(c, http.StatusInternalServerError, types.CodeProviderUnavailable,
+			"Failed to get live monitor data", err.Error())
 		return
 	}
 
@@ -851,7 +1220,7 @@ func (h *DomainHandler) GetMonitoringStats(c *gin.Context) {
 // getDBMonitoringStats retrieves monitoring statistics from the database
 func (h *DomainHandler) getDBMonitoringStats() map[string]interface{} {
 	// Get domain summary to provide real database stats
-	summary, err := h.repo.GetSummary()
+	summary, err := h.repo.GetSummary(loadExpiryThresholds(h.repo).SummaryDays)
 	if err != nil {
 		// Return empty stats if we can't get summary
 		return map[string]interface{}{
@@ -913,3 +1282,20 @@ func (h *DomainHandler) getMonitorTypeString(monitorType uptimerobot.MonitorType
 		return "unknown"
 	}
 }
+
+// pageBounds converts the limit/offset query parameters into slice bounds for a list of the given size.
+// A missing or invalid limit returns everything from offset onwards.
+func pageBounds(c *gin.Context, total int) (int, int) {
+	start := 0
+	if offset, err := strconv.Atoi(c.Query("offset")); err == nil && offset > 0 {
+		start = offset
+	}
+	if start > total {
+		start = total
+	}
+	end := total
+	if limit, err := strconv.Atoi(c.Query("limit")); err == nil && limit > 0 && start+limit < total {
+		end = start + limit
+	}
+	return start, end
+}
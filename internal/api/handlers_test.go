@@ -0,0 +1,142 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rusiqe/domainvault/internal/auth"
+	"github.com/rusiqe/domainvault/internal/storage"
+	"github.com/rusiqe/domainvault/internal/types"
+)
+
+// loginTestUser creates an unscoped user with role and returns a session token for it
+func loginTestUser(t *testing.T, repo *storage.MockRepo, authSvc *auth.AuthService, role string) string {
+	t.Helper()
+	hash, err := auth.HashPassword(role + "-password")
+	if err != nil {
+		t.Fatalf("HashPassword() error: %v", err)
+	}
+	user := &types.User{Username: role, Email: role + "@example.com", PasswordHash: hash, Role: role, Enabled: true}
+	if err := repo.CreateUser(user); err != nil {
+		t.Fatalf("CreateUser() error: %v", err)
+	}
+	login, err := authSvc.Login(role, role+"-password")
+	if err != nil {
+		t.Fatalf("Login() error: %v", err)
+	}
+	return login.Token
+}
+
+func TestRegisterRoutes_RolePermissions(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	repo := storage.NewMockRepo()
+	authSvc := auth.NewAuthService(repo)
+	viewer := loginTestUser(t, repo, authSvc, types.RoleViewer)
+	editor := loginTestUser(t, repo, authSvc, types.RoleEditor)
+
+	r := gin.New()
+	handler := NewDomainHandler(repo, nil, nil, nil)
+	handler.SetAuthService(authSvc)
+	handler.RegisterRoutes(r)
+
+	forbidden := []struct {
+		token  string
+		method string
+		path   string
+	}{
+		{viewer, http.MethodPut, "/api/v1/domains/dom1"},
+		{viewer, http.MethodDelete, "/api/v1/domains/dom1"},
+		{viewer, http.MethodPost, "/api/v1/sync"},
+		{viewer, http.MethodPost, "/api/v1/import"},
+		{viewer, http.MethodGet, "/api/v1/credentials"},
+		{editor, http.MethodGet, "/api/v1/credentials"},
+		{editor, http.MethodPost, "/api/v1/credentials"},
+		{editor, http.MethodGet, "/api/v1/credentials/cred1"},
+		{editor, http.MethodDelete, "/api/v1/credentials/cred1"},
+	}
+	for _, tt := range forbidden {
+		name := "viewer"
+		if tt.token == editor {
+			name = "editor"
+		}
+		t.Run(name+" "+tt.method+" "+tt.path, func(t *testing.T) {
+			if w := serveScopeRequest(r, tt.method, tt.path, tt.token); w.Code != http.StatusForbidden {
+				t.Errorf("status = %d, want %d: %s", w.Code, http.StatusForbidden, w.Body)
+			}
+		})
+	}
+
+	if w := serveScopeRequest(r, http.MethodGet, "/api/v1/domains", viewer); w.Code != http.StatusOK {
+		t.Errorf("GET /domains as a viewer = %d, want %d", w.Code, http.StatusOK)
+	}
+	if _, err := repo.GetCredentialsByID("cred1"); err != nil {
+		t.Errorf("Expected cred1 to survive the denied requests: %v", err)
+	}
+}
+
+func TestListCategories_Pagination(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	repo := storage.NewEmptyMockRepo()
+	ids := make(map[string]string)
+	for _, name := range []string{"Charlie", "Alpha", "Bravo"} {
+		category := &types.Category{Name: name}
+		if err := repo.CreateCategory(category); err != nil {
+			t.Fatalf("CreateCategory() error: %v", err)
+		}
+		ids[name] = category.ID
+	}
+	alpha, bravo := ids["Alpha"], ids["Bravo"]
+	expires := time.Now().AddDate(1, 0, 0)
+	if _, err := repo.UpsertDomains([]types.Domain{
+		{Name: "b1.com", Provider: "mock", ExpiresAt: expires, Visible: true, CategoryID: &bravo},
+		{Name: "b2.com", Provider: "mock", ExpiresAt: expires, Visible: true, CategoryID: &bravo},
+		{Name: "a1.com", Provider: "mock", ExpiresAt: expires, CategoryID: &alpha},
+		{Name: "a2.com", Provider: "mock", ExpiresAt: expires, CategoryID: &alpha},
+		{Name: "a3.com", Provider: "mock", ExpiresAt: expires, CategoryID: &alpha},
+	}); err != nil {
+		t.Fatalf("UpsertDomains() error: %v", err)
+	}
+
+	r := gin.New()
+	NewDomainHandler(repo, nil, nil, nil).RegisterRoutes(r)
+	list := func(query string) []types.CategoryWithCount {
+		t.Helper()
+		w := serveScopeRequest(r, http.MethodGet, "/api/v1/categories?"+query, "")
+		var page struct {
+			Categories []types.CategoryWithCount `json:"categories"`
+			Total      int                       `json:"total"`
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &page); err != nil || w.Code != http.StatusOK || page.Total != 3 {
+			t.Fatalf("GET /categories?%s = %d %s", query, w.Code, w.Body)
+		}
+		return page.Categories
+	}
+	names := func(categories []types.CategoryWithCount) []string {
+		var names []string
+		for _, category := range categories {
+			names = append(names, category.Name)
+		}
+		return names
+	}
+
+	if got := names(list("limit=2")); len(got) != 2 || got[0] != "Alpha" || got[1] != "Bravo" {
+		t.Errorf("First page = %v, want [Alpha Bravo]", got)
+	}
+	if got := names(list("limit=2&offset=2")); len(got) != 1 || got[0] != "Charlie" {
+		t.Errorf("Second page = %v, want [Charlie]", got)
+	}
+
+	// Hidden domains don't count, so Alpha's three hidden domains leave it behind Bravo
+	byCount := list("sort=count")
+	if got := names(byCount); got[0] != "Bravo" || got[1] != "Alpha" || got[2] != "Charlie" {
+		t.Errorf("Sorted by count = %v, want [Bravo Alpha Charlie]", got)
+	}
+	if byCount[0].DomainCount != 2 || byCount[1].DomainCount != 0 {
+		t.Errorf("Counts = %d, %d, want 2 and 0", byCount[0].DomainCount, byCount[1].DomainCount)
+	}
+}
@@ -0,0 +1,98 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rusiqe/domainvault/internal/jobs"
+	"github.com/rusiqe/domainvault/internal/types"
+)
+
+// enqueueJob queues fn as a background job on behalf of the requesting user. On failure it
+// writes the error response and returns false.
+func enqueueJob(c *gin.Context, queue *jobs.Queue, jobType string, fn jobs.Func) (jobs.Job, bool) {
+	if queue == nil {
+		respondError(c, http.StatusServiceUnavailable, types.CodeServiceUnavailable, "Job queue not available")
+		return jobs.Job{}, false
+	}
+
+	job, err := queue.Enqueue(jobType, requestActor(c), fn)
+	if err != nil {
+		respondError(c, http.StatusServiceUnavailable, types.CodeServiceUnavailable, "Failed to queue job: "+err.Error())
+		return jobs.Job{}, false
+	}
+	return job, true
+}
+
+// jobAccepted builds the 202 response for a queued job, merging in any extra fields
+func jobAccepted(job jobs.Job, extra gin.H) gin.H {
+	response := gin.H{
+		"job_id":  job.ID,
+		"job":     job,
+		"job_url": "/api/v1/admin/jobs/" + job.ID,
+		"status":  job.Status,
+	}
+	for key, value := range extra {
+		response[key] = value
+	}
+	return response
+}
+
+// ListJobs returns the retained background jobs, newest first.
+// Supports ?type= and ?status= filters plus limit/offset paging.
+func (h *AdminHandler) ListJobs(c *gin.Context) {
+	if h.jobs == nil {
+		respondError(c, http.StatusServiceUnavailable, types.CodeServiceUnavailable, "Job queue not available")
+		return
+	}
+
+	status := jobs.Status(c.Query("status"))
+	switch status {
+	case "", jobs.StatusQueued, jobs.StatusRunning, jobs.StatusSucceeded, jobs.StatusFailed:
+	default:
+		respondError(c, http.StatusBadRequest, types.CodeValidationFailed, "status must be one of queued, running, succeeded, failed")
+		return
+	}
+
+	list := h.jobs.List(c.Query("type"), status)
+	if requestScope(c) != nil {
+		own := []jobs.Job{}
+		for _, job := range list {
+			if visibleJob(c, job) {
+				own = append(own, job)
+			}
+		}
+		list = own
+	}
+	start, end := pageBounds(c, len(list))
+	c.JSON(http.StatusOK, gin.H{
+		"jobs":  list[start:end],
+		"count": end - start,
+		"total": len(list),
+	})
+}
+
+// GetJob returns the status, progress and, once finished, the result of a background job
+func (h *AdminHandler) GetJob(c *gin.Context) {
+	if h.jobs == nil {
+		respondError(c, http.StatusServiceUnavailable, types.CodeServiceUnavailable, "Job queue not available")
+		return
+	}
+
+	job, ok := h.jobs.Get(c.Param("id"))
+	if !ok || !visibleJob(c, job) {
+		respondError(c, http.StatusNotFound, types.CodeNotFound, "Job not found")
+		return
+	}
+	c.JSON(http.StatusOK, job)
+}
+
+// visibleJob reports whether the request's user may see job. Jobs can name any domain, so
+// users with an access scope only see the jobs they started.
+func visibleJob(c *gin.Context, job jobs.Job) bool {
+	return requestScope(c) == nil || job.CreatedBy == requestActor(c)
+}
+
+// errJobCancelled is returned by jobs that stop early because the queue is shutting down
+var errJobCancelled = errors.New("job cancelled by server shutdown")
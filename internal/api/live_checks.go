@@ -0,0 +1,61 @@
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rusiqe/domainvault/internal/dns"
+	"github.com/rusiqe/domainvault/internal/status"
+	"github.com/rusiqe/domainvault/internal/types"
+)
+
+// liveChecksTimeout bounds the live checks of GET /domains/:id/details?live=true; checks not
+// finished by then are reported as timed out
+const liveChecksTimeout = 20 * time.Second
+
+// liveDomainChecks runs a fresh website status check, certificate check and DNS health check
+// on a domain concurrently and reports the ones that finish within liveChecksTimeout. The
+// results are only reported, not stored on the domain.
+func (h *AdminHandler) liveDomainChecks(ctx context.Context, domainName string) gin.H {
+	ctx, cancel := context.WithTimeout(ctx, liveChecksTimeout)
+	defer cancel()
+	start := time.Now()
+
+	// Buffered so checks finishing after the timeout don't block
+	websiteDone := make(chan types.WebsiteStatusResult, 1)
+	certificateDone := make(chan status.CertificateResult, 1)
+	healthDone := make(chan *dns.HealthReport, 1)
+	go func() {
+		results, _ := h.statusChecker.BulkCheckWebsiteStatus(ctx, types.WebsiteStatusRequest{Domains: []string{domainName}}, 1)
+		websiteDone <- results[0]
+	}()
+	go func() { certificateDone <- h.statusChecker.CheckCertificate(ctx, domainName) }()
+	go func() { healthDone <- dns.NewHealthChecker().Check(domainName) }()
+
+	checks := gin.H{"http_status": nil, "ssl": nil, "dns_health": nil}
+	timedOut := []string{}
+collect:
+	for pending := len(checks); pending > 0; pending-- {
+		select {
+		case result := <-websiteDone:
+			checks["http_status"] = result
+		case result := <-certificateDone:
+			checks["ssl"] = result
+		case report := <-healthDone:
+			checks["dns_health"] = report
+		case <-ctx.Done():
+			for _, name := range []string{"http_status", "ssl", "dns_health"} {
+				if checks[name] == nil {
+					timedOut = append(timedOut, name)
+				}
+			}
+			break collect
+		}
+	}
+
+	checks["checked_at"] = start
+	checks["duration_ms"] = time.Since(start).Milliseconds()
+	checks["timed_out"] = timedOut
+	return checks
+}
@@ -0,0 +1,97 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rusiqe/domainvault/internal/security"
+	"github.com/rusiqe/domainvault/internal/types"
+)
+
+// unlockAccountRequest names the lockout to lift: a username, an IP address, or both
+type unlockAccountRequest struct {
+	Username  string `json:"username"`
+	IPAddress string `json:"ip_address"`
+}
+
+// GetLockedAccounts lists the usernames currently locked out after repeated failed logins,
+// with the IP address they failed from and when the lock lifts on its own
+func (h *AdminHandler) GetLockedAccounts(c *gin.Context) {
+	if h.securitySvc == nil {
+		respondError(c, http.StatusServiceUnavailable, types.CodeServiceUnavailable, "Security service not configured")
+		return
+	}
+
+	locked, err := h.securitySvc.LockedAccounts()
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"locked_accounts": locked, "total": len(locked)})
+}
+
+// UnlockAccount lifts a lockout before it expires by clearing the recent failed logins for a
+// username, an IP address, or a username from one IP address
+func (h *AdminHandler) UnlockAccount(c *gin.Context) {
+	if h.securitySvc == nil {
+		respondError(c, http.StatusServiceUnavailable, types.CodeServiceUnavailable, "Security service not configured")
+		return
+	}
+
+	var req unlockAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Invalid request format")
+		return
+	}
+	req.Username = strings.TrimSpace(req.Username)
+	req.IPAddress = strings.TrimSpace(req.IPAddress)
+	if req.Username == "" && req.IPAddress == "" {
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "username or ip_address is required")
+		return
+	}
+
+	cleared, err := h.securitySvc.UnlockAccount(req.Username, req.IPAddress)
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	userID, _ := c.Get("userID")
+	id, _ := userID.(string)
+	h.securitySvc.LogAuditEvent(security.EventSettingsChange, id, requestActor(c), c.ClientIP(),
+		c.GetHeader("User-Agent"), "account_lockout", "unlock", true, map[string]interface{}{
+			"username":         req.Username,
+			"ip_address":       req.IPAddress,
+			"cleared_attempts": cleared,
+		}, "")
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":          "Account unlocked",
+		"username":         req.Username,
+		"ip_address":       req.IPAddress,
+		"cleared_attempts": cleared,
+	})
+}
+
+// GetLoginAttemptStatus tells a user how many failed logins they have left before their
+// username is locked out from the IP address they're calling from
+func (h *AdminHandler) GetLoginAttemptStatus(c *gin.Context) {
+	if h.securitySvc == nil {
+		respondError(c, http.StatusServiceUnavailable, types.CodeServiceUnavailable, "Security service not configured")
+		return
+	}
+
+	username := strings.TrimSpace(c.Query("username"))
+	if username == "" {
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "username query parameter required")
+		return
+	}
+
+	status, err := h.securitySvc.LoginAttemptStatus(username, c.ClientIP())
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusOK, status)
+}
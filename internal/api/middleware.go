@@ -0,0 +1,291 @@
+package api
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rusiqe/domainvault/internal/types"
+)
+
+var gzipWriterPool = sync.Pool{
+	New: func() interface{} { return gzip.NewWriter(nil) },
+}
+
+// gzipResponseWriter compresses the body on the fly. Whether to compress is decided on the
+// first write, once the handler has set Content-Type and the status code.
+type gzipResponseWriter struct {
+	gin.ResponseWriter
+	gz      *gzip.Writer
+	decided bool
+}
+
+func (w *gzipResponseWriter) decide() {
+	if w.decided {
+		return
+	}
+	w.decided = true
+
+	header := w.Header()
+	status := w.Status()
+	if header.Get("Content-Encoding") != "" || status == http.StatusNoContent || status == http.StatusNotModified ||
+		strings.HasPrefix(header.Get("Content-Type"), "image/") {
+		return
+	}
+
+	header.Set("Content-Encoding", "gzip")
+	header.Add("Vary", "Accept-Encoding")
+	header.Del("Content-Length")
+
+	w.gz = gzipWriterPool.Get().(*gzip.Writer)
+	w.gz.Reset(w.ResponseWriter)
+}
+
+func (w *gzipResponseWriter) Write(data []byte) (int, error) {
+	w.decide()
+	if w.gz == nil {
+		return w.ResponseWriter.Write(data)
+	}
+	return w.gz.Write(data)
+}
+
+func (w *gzipResponseWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// GzipMiddleware compresses responses for clients that accept gzip
+func GzipMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.Method == http.MethodHead || !strings.Contains(c.GetHeader("Accept-Encoding"), "gzip") {
+			c.Next()
+			return
+		}
+
+		writer := &gzipResponseWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+		defer func() {
+			if writer.gz != nil {
+				writer.gz.Close()
+				writer.gz.Reset(nil)
+				gzipWriterPool.Put(writer.gz)
+			}
+			c.Writer = writer.ResponseWriter
+		}()
+
+		c.Next()
+	}
+}
+
+// bufferedResponseWriter holds the response so an ETag can be computed over the full body
+type bufferedResponseWriter struct {
+	gin.ResponseWriter
+	body   bytes.Buffer
+	status int
+}
+
+func (w *bufferedResponseWriter) WriteHeader(code int) {
+	w.status = code
+}
+
+func (w *bufferedResponseWriter) WriteHeaderNow() {}
+
+func (w *bufferedResponseWriter) Status() int {
+	return w.status
+}
+
+func (w *bufferedResponseWriter) Written() bool {
+	return w.body.Len() > 0
+}
+
+func (w *bufferedResponseWriter) Write(data []byte) (int, error) {
+	return w.body.Write(data)
+}
+
+func (w *bufferedResponseWriter) WriteString(s string) (int, error) {
+	return w.body.WriteString(s)
+}
+
+// ETagMiddleware tags successful GET responses with a hash of their body and answers
+// 304 Not Modified when the client already has that version
+func ETagMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.Method != http.MethodGet {
+			c.Next()
+			return
+		}
+
+		original := c.Writer
+		writer := &bufferedResponseWriter{ResponseWriter: original, status: http.StatusOK}
+		c.Writer = writer
+		c.Next()
+		c.Writer = original
+
+		if writer.status != http.StatusOK || original.Header().Get("ETag") != "" {
+			original.WriteHeader(writer.status)
+			original.Write(writer.body.Bytes())
+			return
+		}
+
+		sum := sha256.Sum256(writer.body.Bytes())
+		etag := `W/"` + hex.EncodeToString(sum[:16]) + `"`
+		if notModified(c, etag) {
+			return
+		}
+
+		original.WriteHeader(writer.status)
+		original.Write(writer.body.Bytes())
+	}
+}
+
+// notModified sets the ETag header and, if the request's If-None-Match already names it,
+// responds 304 and returns true
+func notModified(c *gin.Context, etag string) bool {
+	c.Header("ETag", etag)
+	c.Header("Cache-Control", "private, no-cache")
+
+	for _, candidate := range strings.Split(c.GetHeader("If-None-Match"), ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == etag || candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+			c.Status(http.StatusNotModified)
+			c.Writer.WriteHeaderNow()
+			return true
+		}
+	}
+	return false
+}
+
+// domainListETag derives a validator for a domain list from the filter, the listed IDs and
+// the most recent update, so any change to a listed domain produces a new tag
+func domainListETag(filter types.DomainFilter, domains []types.Domain) string {
+	filterJSON, _ := json.Marshal(filter)
+
+	hash := sha256.New()
+	hash.Write(filterJSON)
+
+	var latest int64
+	for _, d := range domains {
+		hash.Write([]byte(d.ID))
+		if ts := d.UpdatedAt.UnixNano(); ts > latest {
+			latest = ts
+		}
+	}
+	fmt.Fprintf(hash, "|%d", latest)
+
+	return `W/"` + hex.EncodeToString(hash.Sum(nil)[:16]) + `"`
+}
+
+// idempotencyStore persists responses for requests sent with an Idempotency-Key header
+type idempotencyStore interface {
+	ReserveIdempotencyKey(record *types.IdempotencyRecord) (*types.IdempotencyRecord, error)
+	CompleteIdempotencyKey(key string, statusCode int, contentType string, body []byte) error
+}
+
+// IdempotencyMiddleware makes a route safe to retry: the first request with a given
+// Idempotency-Key header runs and its response is stored for types.IdempotencyTTL; replays
+// with the same key get the stored response instead of running the handler again.
+// Keys are scoped to the user and route, and reusing a key with a different body is rejected.
+func IdempotencyMiddleware(store idempotencyStore) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		clientKey := strings.TrimSpace(c.GetHeader("Idempotency-Key"))
+		if clientKey == "" {
+			c.Next()
+			return
+		}
+		if len(clientKey) > 255 {
+			abortWithError(c, http.StatusBadRequest, types.CodeValidationFailed, "Idempotency-Key must be at most 255 characters")
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			abortWithError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Failed to read request body")
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		userID, _ := c.Get("userID")
+		keySum := sha256.Sum256([]byte(fmt.Sprintf("%v|%s %s|%s", userID, c.Request.Method, c.FullPath(), clientKey)))
+		bodySum := sha256.Sum256(body)
+
+		now := time.Now()
+		record := &types.IdempotencyRecord{
+			Key:         hex.EncodeToString(keySum[:]),
+			RequestHash: hex.EncodeToString(bodySum[:]),
+			CreatedAt:   now,
+			ExpiresAt:   now.Add(types.IdempotencyTTL),
+		}
+
+		existing, err := store.ReserveIdempotencyKey(record)
+		if err != nil {
+			abortWithError(c, http.StatusInternalServerError, types.CodeInternal, err.Error())
+			return
+		}
+		if existing != nil {
+			switch {
+			case existing.RequestHash != record.RequestHash:
+				abortWithError(c, http.StatusUnprocessableEntity, types.CodeConflict, "Idempotency-Key was already used with a different request")
+			case existing.StatusCode == 0:
+				abortWithError(c, http.StatusConflict, types.CodeConflict, "A request with this Idempotency-Key is still in progress")
+			default:
+				c.Header("Idempotent-Replayed", "true")
+				c.Data(existing.StatusCode, existing.ContentType, existing.Body)
+				c.Abort()
+			}
+			return
+		}
+
+		original := c.Writer
+		writer := &bufferedResponseWriter{ResponseWriter: original, status: http.StatusOK}
+		c.Writer = writer
+		c.Next()
+		c.Writer = original
+
+		if err := store.CompleteIdempotencyKey(record.Key, writer.status, original.Header().Get("Content-Type"), writer.body.Bytes()); err != nil {
+			log.Printf("Failed to store idempotent response: %v", err)
+		}
+
+		original.WriteHeader(writer.status)
+		original.Write(writer.body.Bytes())
+	}
+}
+
+// maintenanceState reports whether maintenance mode is on
+type maintenanceState interface {
+	Maintenance() types.MaintenanceMode
+}
+
+// maintenanceExemptPaths stay writable during maintenance, so admins can still sign in and
+// turn it off
+var maintenanceExemptPaths = map[string]bool{
+	"/api/v1/auth/login":        true,
+	"/api/v1/auth/logout":       true,
+	"/api/v1/admin/maintenance": true,
+}
+
+// MaintenanceMiddleware rejects every change with 503 while maintenance mode is on. Reads
+// (GET, HEAD and OPTIONS) are still served.
+func MaintenanceMiddleware(state maintenanceState) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		switch c.Request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			c.Next()
+			return
+		}
+		mode := state.Maintenance()
+		if !mode.Enabled || maintenanceExemptPaths[c.FullPath()] {
+			c.Next()
+			return
+		}
+		abortWithError(c, http.StatusServiceUnavailable, types.CodeMaintenance, mode.Notice())
+	}
+}
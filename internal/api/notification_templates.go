@@ -0,0 +1,125 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rusiqe/domainvault/internal/notifications"
+	"github.com/rusiqe/domainvault/internal/security"
+	"github.com/rusiqe/domainvault/internal/types"
+)
+
+// auditTemplateChange records a change to a notification template
+func (h *AdminHandler) auditTemplateChange(c *gin.Context, action, channel, event string) {
+	if h.securitySvc == nil {
+		return
+	}
+	userID, _ := c.Get("userID")
+	id, _ := userID.(string)
+	h.securitySvc.LogAuditEvent(security.EventSettingsChange, id, requestActor(c), c.ClientIP(),
+		c.GetHeader("User-Agent"), "notification_template", action, true,
+		map[string]interface{}{"channel": channel, "event": event}, "")
+}
+
+// ListNotificationTemplates returns the stored templates, the default wording of every event
+// and the channels and events templates can be written for
+func (h *AdminHandler) ListNotificationTemplates(c *gin.Context) {
+	templates, err := h.domainRepo.GetNotificationTemplates()
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	defaults := make([]types.NotificationTemplate, 0, len(notifications.TemplateEvents))
+	for _, event := range notifications.TemplateEvents {
+		defaults = append(defaults, notifications.DefaultTemplate("", event))
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"templates": templates,
+		"total":     len(templates),
+		"defaults":  defaults,
+		"channels":  notifications.TemplateChannels,
+		"events":    notifications.TemplateEvents,
+	})
+}
+
+// SaveNotificationTemplate creates or replaces the template for a channel and event. The
+// template is rendered against a sample alert first, so one that can't render isn't saved.
+func (h *AdminHandler) SaveNotificationTemplate(c *gin.Context) {
+	var req types.NotificationTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Invalid request format")
+		return
+	}
+
+	template := types.NotificationTemplate{
+		Channel: c.Param("channel"),
+		Event:   c.Param("event"),
+		Subject: req.Subject,
+		Body:    req.Body,
+	}
+	if err := h.notificationSvc.ValidateTemplate(&template); err != nil {
+		respondWithError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := h.domainRepo.SaveNotificationTemplate(&template); err != nil {
+		respondWithError(c, http.StatusInternalServerError, err)
+		return
+	}
+	h.auditTemplateChange(c, "save_notification_template", template.Channel, template.Event)
+
+	c.JSON(http.StatusOK, template)
+}
+
+// DeleteNotificationTemplate removes the template for a channel and event, so its alerts go
+// back to the default wording
+func (h *AdminHandler) DeleteNotificationTemplate(c *gin.Context) {
+	channel, event := c.Param("channel"), c.Param("event")
+	if err := h.domainRepo.DeleteNotificationTemplate(channel, event); err != nil {
+		respondWithError(c, http.StatusInternalServerError, err)
+		return
+	}
+	h.auditTemplateChange(c, "delete_notification_template", channel, event)
+
+	c.JSON(http.StatusOK, gin.H{"message": "Notification template deleted; the default wording is used again"})
+}
+
+// PreviewNotificationTemplate renders a template without saving it, against the domain with
+// domain_id or a sample domain
+func (h *AdminHandler) PreviewNotificationTemplate(c *gin.Context) {
+	var req types.NotificationTemplatePreviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Invalid request format")
+		return
+	}
+
+	template := types.NotificationTemplate{Channel: req.Channel, Event: req.Event, Subject: req.Subject, Body: req.Body}
+	if err := h.notificationSvc.ValidateTemplate(&template); err != nil {
+		respondWithError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	domain := notifications.SampleDomain()
+	if req.DomainID != "" {
+		found, err := h.domainRepo.GetByID(req.DomainID)
+		if err != nil {
+			respondWithError(c, http.StatusInternalServerError, err)
+			return
+		}
+		domain = *found
+	}
+
+	subject, body, err := h.notificationSvc.PreviewTemplate(template, domain)
+	if err != nil {
+		respondWithError(c, http.StatusBadRequest, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"channel": template.Channel,
+		"event":   template.Event,
+		"domain":  domain.Name,
+		"subject": subject,
+		"body":    body,
+	})
+}
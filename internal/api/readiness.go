@@ -0,0 +1,140 @@
+package api
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rusiqe/domainvault/internal/dns"
+	"github.com/rusiqe/domainvault/internal/status"
+	"github.com/rusiqe/domainvault/internal/types"
+)
+
+// CheckExpiringDomains runs the website, SSL and live DNS health checks on every domain
+// expiring within ?days= (default the expiring-soon threshold) and reports whether each is
+// ready for renewal. Website results are stored on the domains like a bulk status check.
+func (h *AdminHandler) CheckExpiringDomains(c *gin.Context) {
+	if h.statusChecker == nil {
+		respondError(c, http.StatusServiceUnavailable, types.CodeServiceUnavailable, "Status checker not configured")
+		return
+	}
+
+	days := loadExpiryThresholds(h.domainRepo).ExpiringSoonDays
+	if daysStr := c.Query("days"); daysStr != "" {
+		parsed, err := strconv.Atoi(daysStr)
+		if err != nil || parsed < 1 || parsed > 3650 {
+			respondError(c, http.StatusBadRequest, types.CodeValidationFailed, "days must be between 1 and 3650")
+			return
+		}
+		days = parsed
+	}
+
+	domains, err := h.domainRepo.GetExpiring(time.Duration(days) * 24 * time.Hour)
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err)
+		return
+	}
+	domains = scopeDomains(c, domains)
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), status.DefaultBulkCheckTimeout)
+	defer cancel()
+
+	names := make([]string, len(domains))
+	for i, domain := range domains {
+		names[i] = domain.Name
+	}
+
+	// Website and DNS checks hit different servers, so they run side by side
+	var websites []types.WebsiteStatusResult
+	var dnsReports []*dns.HealthReport
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		websites, _ = h.statusChecker.BulkCheckWebsiteStatus(ctx, types.WebsiteStatusRequest{Domains: names}, status.DefaultBulkCheckWorkers)
+	}()
+	go func() {
+		defer wg.Done()
+		dnsReports = checkDNSHealth(ctx, names, status.DefaultBulkCheckWorkers)
+	}()
+	wg.Wait()
+
+	now := time.Now()
+	report := types.RenewalReadinessReport{
+		Days:      days,
+		Checked:   len(domains),
+		Domains:   make([]types.RenewalReadiness, 0, len(domains)),
+		CheckedAt: now,
+	}
+	for i, domain := range domains {
+		readiness := types.NewRenewalReadiness(domain, now)
+
+		website := websites[i]
+		readiness.HTTPStatus = website.HTTPStatus
+		readiness.StatusMessage = website.StatusMessage
+		readiness.SSLStatus = website.SSLStatus
+		if website.StatusMessage != "Not checked" {
+			previous := domain
+			status.ApplyWebsiteStatus(&domain, website)
+			if err := h.domainRepo.Update(&domain); err != nil {
+				log.Printf("Failed to store website status for %s: %v", domain.Name, err)
+			} else {
+				h.notifyTransitions(previous, domain)
+			}
+		}
+
+		if health := dnsReports[i]; health != nil {
+			readiness.DNSStatus = string(health.Status)
+			for _, category := range health.Categories {
+				for _, check := range category.Checks {
+					if check.Status == dns.HealthFail {
+						readiness.DNSProblems = append(readiness.DNSProblems, check.Name+": "+check.Message)
+					}
+				}
+			}
+		}
+
+		readiness.Evaluate()
+		if readiness.Ready {
+			report.Ready++
+		} else {
+			report.NotReady++
+		}
+		report.Domains = append(report.Domains, readiness)
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// checkDNSHealth runs the live DNS health check on each domain with a pool of workers.
+// Domains not started before ctx is done have a nil report.
+func checkDNSHealth(ctx context.Context, names []string, workers int) []*dns.HealthReport {
+	reports := make([]*dns.HealthReport, len(names))
+	checker := dns.NewHealthChecker()
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers && w < len(names); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range jobs {
+				reports[index] = checker.Check(names[index])
+			}
+		}()
+	}
+
+	for i := range names {
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+		}
+	}
+	close(jobs)
+	wg.Wait()
+	return reports
+}
@@ -0,0 +1,58 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rusiqe/domainvault/internal/types"
+)
+
+// registryExpiryView is a registry expiry check with how far the registry is from the stored expiry
+type registryExpiryView struct {
+	types.RegistryExpiry
+	DifferenceDays int `json:"difference_days"`
+}
+
+// GetRegistryExpiries lists the registry expiry reconciliation results, compared with each
+// domain's current stored expiry so a sync that since caught up clears the discrepancy.
+// ?discrepancies=true lists only domains whose expiries disagree.
+func (h *AdminHandler) GetRegistryExpiries(c *gin.Context) {
+	expiries, err := h.domainRepo.GetRegistryExpiries()
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err)
+		return
+	}
+	domains, err := h.domainRepo.GetAll()
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err)
+		return
+	}
+	domains = scopeDomains(c, domains)
+	byID := make(map[string]types.Domain, len(domains))
+	for _, domain := range domains {
+		byID[domain.ID] = domain
+	}
+
+	onlyDiscrepancies := c.Query("discrepancies") == "true"
+	views := []registryExpiryView{}
+	discrepancies := 0
+	for _, expiry := range expiries {
+		domain, ok := byID[expiry.DomainID]
+		if !ok {
+			continue
+		}
+		expiry.Compare(domain.ExpiresAt)
+		if expiry.Discrepancy {
+			discrepancies++
+		} else if onlyDiscrepancies {
+			continue
+		}
+		views = append(views, registryExpiryView{RegistryExpiry: expiry, DifferenceDays: expiry.DifferenceDays()})
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"expiries":      views,
+		"total":         len(views),
+		"discrepancies": discrepancies,
+	})
+}
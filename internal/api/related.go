@@ -0,0 +1,174 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rusiqe/domainvault/internal/types"
+)
+
+// relatedAvailabilityTimeout bounds the availability lookups of related domain suggestions;
+// suggestions not looked up by then are left unchecked
+const relatedAvailabilityTimeout = 30 * time.Second
+
+// GetRelatedDomains suggests domains related to one in the portfolio: portfolio domains whose
+// stored DNS records point at the same addresses or mail servers, the name under other
+// suffixes, and likely typos of it. Suggestions that aren't owned get an availability lookup
+// unless ?availability=false.
+func (h *AdminHandler) GetRelatedDomains(c *gin.Context) {
+	domain, err := h.domainRepo.GetByID(c.Param("id"))
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err)
+		return
+	}
+	portfolio, err := h.domainRepo.GetAll()
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err)
+		return
+	}
+	owned := make(map[string]types.Domain, len(portfolio))
+	for _, d := range portfolio {
+		owned[types.NormalizeDomainName(d.Name)] = d
+	}
+
+	report := types.RelatedDomainsReport{
+		DomainID:            domain.ID,
+		DomainName:          domain.Name,
+		SameInfrastructure:  []types.RelatedDomain{},
+		TLDVariants:         []types.RelatedDomain{},
+		TyposquatCandidates: []types.RelatedDomain{},
+		GeneratedAt:         time.Now(),
+	}
+
+	sameInfra, err := h.sameInfrastructureDomains(domain, portfolio)
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err)
+		return
+	}
+	report.SameInfrastructure = sameInfra
+
+	suggest := func(names []string, reason string) []types.RelatedDomain {
+		suggestions := make([]types.RelatedDomain, 0, len(names))
+		for _, name := range names {
+			suggestion := types.RelatedDomain{Domain: name, Reasons: []string{reason}}
+			if d, ok := owned[name]; ok {
+				suggestion.Owned = true
+				suggestion.DomainID = d.ID
+				suggestion.Monitored = d.UptimeRobotMonitorID != nil
+			}
+			suggestions = append(suggestions, suggestion)
+		}
+		return suggestions
+	}
+	report.TLDVariants = suggest(types.TLDVariantNames(domain.Name), "alternate TLD")
+	report.TyposquatCandidates = suggest(types.TyposquatNames(domain.Name), "typo variant")
+
+	if c.Query("availability") != "false" && h.watchlistSvc != nil {
+		var unowned []*types.RelatedDomain
+		for _, group := range [][]types.RelatedDomain{report.TLDVariants, report.TyposquatCandidates} {
+			for i := range group {
+				if !group[i].Owned {
+					unowned = append(unowned, &group[i])
+				}
+			}
+		}
+		ctx, cancel := context.WithTimeout(c.Request.Context(), relatedAvailabilityTimeout)
+		defer cancel()
+		h.checkRelatedAvailability(ctx, unowned, h.lookupLimits.workers())
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// sameInfrastructureDomains finds the portfolio domains with stored A, AAAA, CNAME or MX
+// records pointing where the domain's do
+func (h *AdminHandler) sameInfrastructureDomains(domain *types.Domain, portfolio []types.Domain) ([]types.RelatedDomain, error) {
+	records, err := h.domainRepo.GetRecordsByDomain(domain.ID)
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool)
+	var values []string
+	for _, record := range records {
+		value := types.InfrastructureValue(record.Value)
+		if value != "" && !seen[value] {
+			seen[value] = true
+			values = append(values, value)
+		}
+	}
+	matches, err := h.domainRepo.GetRecordsByValues(types.SharedInfrastructureTypes, values)
+	if err != nil {
+		return nil, err
+	}
+
+	byDomain := make(map[string][]types.DNSRecord)
+	for _, record := range matches {
+		if record.DomainID != domain.ID {
+			byDomain[record.DomainID] = append(byDomain[record.DomainID], record)
+		}
+	}
+
+	related := []types.RelatedDomain{}
+	for _, d := range portfolio {
+		shared, ok := byDomain[d.ID]
+		if !ok {
+			continue
+		}
+		if reasons := types.SharedInfrastructure(records, shared); len(reasons) > 0 {
+			related = append(related, types.RelatedDomain{
+				Domain:    d.Name,
+				Reasons:   reasons,
+				Owned:     true,
+				DomainID:  d.ID,
+				Monitored: d.UptimeRobotMonitorID != nil,
+			})
+		}
+	}
+	// The most shared infrastructure first
+	sort.SliceStable(related, func(i, j int) bool {
+		if len(related[i].Reasons) != len(related[j].Reasons) {
+			return len(related[i].Reasons) > len(related[j].Reasons)
+		}
+		return related[i].Domain < related[j].Domain
+	})
+	return related, nil
+}
+
+// checkRelatedAvailability looks up each suggestion's availability with a pool of workers.
+// Suggestions not started before ctx is done are marked unchecked.
+func (h *AdminHandler) checkRelatedAvailability(ctx context.Context, suggestions []*types.RelatedDomain, workers int) {
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers && w < len(suggestions); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				availability, err := h.watchlistSvc.CheckAvailability(suggestions[i].Domain)
+				if err != nil {
+					suggestions[i].AvailabilityError = err.Error()
+					continue
+				}
+				suggestions[i].Availability = availability
+			}
+		}()
+	}
+
+dispatch:
+	for i := range suggestions {
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+			for _, s := range suggestions[i:] {
+				s.AvailabilityError = "Not checked"
+			}
+			break dispatch
+		}
+	}
+	close(jobs)
+	wg.Wait()
+}
@@ -0,0 +1,131 @@
+package api
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rusiqe/domainvault/internal/types"
+)
+
+// Defaults for RequestLimits fields left at 0
+const (
+	defaultMaxBodyBytes      = 1 << 20
+	defaultMaxBulkBodyBytes  = 10 << 20
+	defaultMaxBulkOperations = 1000
+)
+
+// RequestLimits bounds how much one request may send, so a single oversized request can't
+// exhaust the server's memory
+type RequestLimits struct {
+	MaxBodyBytes      int64 // Largest body most routes accept
+	MaxBulkBodyBytes  int64 // Largest body the bulk and import routes accept
+	MaxBulkOperations int   // Most domains, records or rows one bulk request may name
+}
+
+func (l RequestLimits) maxBodyBytes() int64 {
+	if l.MaxBodyBytes > 0 {
+		return l.MaxBodyBytes
+	}
+	return defaultMaxBodyBytes
+}
+
+func (l RequestLimits) maxBulkBodyBytes() int64 {
+	if l.MaxBulkBodyBytes > 0 {
+		return l.MaxBulkBodyBytes
+	}
+	return defaultMaxBulkBodyBytes
+}
+
+func (l RequestLimits) maxBulkOperations() int {
+	if l.MaxBulkOperations > 0 {
+		return l.MaxBulkOperations
+	}
+	return defaultMaxBulkOperations
+}
+
+// bulkBodyRoutes take whole lists of domains, records or CSV rows and get the bulk body limit
+var bulkBodyRoutes = map[string]bool{
+	"POST /api/v1/import":                                  true,
+	"POST /api/v1/domains/bulk-visibility":                 true,
+	"POST /api/v1/admin/domains/bulk-purchase":             true,
+	"POST /api/v1/admin/domains/bulk-renew":                true,
+	"POST /api/v1/admin/domains/bulk-decommission":         true,
+	"POST /api/v1/admin/domains/import-csv":                true,
+	"POST /api/v1/admin/domains/bulk-check-status":         true,
+	"POST /api/v1/admin/domains/bulk-check-website-status": true,
+	"POST /api/v1/admin/monitoring/bulk-pause":             true,
+	"POST /api/v1/admin/monitoring/bulk-resume":            true,
+	"PUT /api/v1/admin/domains/:id/dns":                    true,
+	"POST /api/v1/admin/domains/:id/dns/zone":              true,
+	"POST /api/v1/admin/projects/:id/dns/import":           true,
+	"POST /api/v1/admin/dns/bulk/ip":                       true,
+	"POST /api/v1/admin/dns/bulk/ipv6":                     true,
+	"POST /api/v1/admin/dns/bulk/nameservers":              true,
+	"POST /api/v1/admin/dns/bulk/csv":                      true,
+	"POST /api/v1/admin/dns/bulk/pattern":                  true,
+	"POST /api/v1/admin/dns/bulk/delete":                   true,
+}
+
+// bodyLimit returns the largest body a route accepts
+func (l RequestLimits) bodyLimit(method, route string) int64 {
+	if bulkBodyRoutes[method+" "+route] {
+		return l.maxBulkBodyBytes()
+	}
+	return l.maxBodyBytes()
+}
+
+// BodyLimitMiddleware rejects bodies over the route's limit with 413 before any handler reads
+// them. A declared length over the limit is rejected unread; a chunked body is read up to the
+// limit, so handlers never see more than it.
+func BodyLimitMiddleware(limits RequestLimits) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.Body == nil || c.Request.Body == http.NoBody {
+			c.Next()
+			return
+		}
+		limit := limits.bodyLimit(c.Request.Method, c.FullPath())
+		tooLarge := func() {
+			abortWithError(c, http.StatusRequestEntityTooLarge, types.CodeRequestTooLarge,
+				fmt.Sprintf("Request body exceeds the %d byte limit", limit))
+		}
+
+		if c.Request.ContentLength > limit {
+			tooLarge()
+			return
+		}
+		body := http.MaxBytesReader(c.Writer, c.Request.Body, limit)
+		if c.Request.ContentLength < 0 {
+			data, err := io.ReadAll(body)
+			if err != nil {
+				var maxErr *http.MaxBytesError
+				if errors.As(err, &maxErr) {
+					tooLarge()
+				} else {
+					abortWithError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Failed to read request body")
+				}
+				return
+			}
+			c.Request.Body = io.NopCloser(bytes.NewReader(data))
+			c.Next()
+			return
+		}
+		c.Request.Body = body
+		c.Next()
+	}
+}
+
+// checkBulkOperations responds 400 and returns false when a bulk request names more items
+// than one request may
+func checkBulkOperations(c *gin.Context, limits RequestLimits, count int) bool {
+	if max := limits.maxBulkOperations(); count > max {
+		respondErrorDetails(c, http.StatusBadRequest, types.CodeTooManyOperations,
+			fmt.Sprintf("%d items given, at most %d can be processed in one request", count, max),
+			gin.H{"count": count, "max": max})
+		return false
+	}
+	return true
+}
@@ -0,0 +1,94 @@
+package api
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+// Limits on password reset requests. An IP address may ask for maxResetRequestsPerIP resets
+// inside resetRequestWindow, and an account gets at most one reset email per resetEmailCooldown.
+const (
+	maxResetRequestsPerIP = 5
+	resetRequestWindow    = 15 * time.Minute
+	resetEmailCooldown    = 5 * time.Minute
+)
+
+// resetThrottle keeps ForgotPassword from being used to flood a mailbox or to probe many
+// accounts from one address. Its state is in memory, so each server process limits separately.
+type resetThrottle struct {
+	mu        sync.Mutex
+	now       func() time.Time
+	requests  map[string][]time.Time // Recent requests by client IP, oldest first
+	lastEmail map[string]time.Time   // When each username or email last had a reset issued
+	lastSweep time.Time
+}
+
+func newResetThrottle() *resetThrottle {
+	return &resetThrottle{
+		now:       time.Now,
+		requests:  make(map[string][]time.Time),
+		lastEmail: make(map[string]time.Time),
+	}
+}
+
+// allowIP records a reset request from ip. Once the IP address has used up its requests it
+// returns false and how long until the oldest one leaves the window.
+func (t *resetThrottle) allowIP(ip string) (bool, time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	now := t.now()
+	t.sweep(now)
+
+	recent := pruneUntil(t.requests[ip], now.Add(-resetRequestWindow))
+	if len(recent) >= maxResetRequestsPerIP {
+		t.requests[ip] = recent
+		return false, recent[0].Add(resetRequestWindow).Sub(now)
+	}
+	t.requests[ip] = append(recent, now)
+	return true, 0
+}
+
+// allowAccount reports whether a reset may be issued for identifier, starting its cooldown if so.
+// Usernames and emails are compared case-insensitively.
+func (t *resetThrottle) allowAccount(identifier string) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	now := t.now()
+	key := strings.ToLower(identifier)
+
+	if last, ok := t.lastEmail[key]; ok && now.Sub(last) < resetEmailCooldown {
+		return false
+	}
+	t.lastEmail[key] = now
+	return true
+}
+
+// sweep drops state that no longer limits anything, at most once per window. Callers hold t.mu.
+func (t *resetThrottle) sweep(now time.Time) {
+	if now.Sub(t.lastSweep) < resetRequestWindow {
+		return
+	}
+	t.lastSweep = now
+	for ip, times := range t.requests {
+		if recent := pruneUntil(times, now.Add(-resetRequestWindow)); len(recent) == 0 {
+			delete(t.requests, ip)
+		} else {
+			t.requests[ip] = recent
+		}
+	}
+	for key, last := range t.lastEmail {
+		if now.Sub(last) >= resetEmailCooldown {
+			delete(t.lastEmail, key)
+		}
+	}
+}
+
+// pruneUntil drops the times at or before cutoff from a list sorted oldest first
+func pruneUntil(times []time.Time, cutoff time.Time) []time.Time {
+	i := 0
+	for i < len(times) && !times[i].After(cutoff) {
+		i++
+	}
+	return times[i:]
+}
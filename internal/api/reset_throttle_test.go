@@ -0,0 +1,52 @@
+package api
+
+import (
+	"testing"
+	"time"
+)
+
+func TestResetThrottle_PerIP(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	throttle := newResetThrottle()
+	throttle.now = func() time.Time { return now }
+
+	for i := 0; i < maxResetRequestsPerIP; i++ {
+		if ok, _ := throttle.allowIP("10.0.0.1"); !ok {
+			t.Fatalf("request %d was limited, want %d allowed", i+1, maxResetRequestsPerIP)
+		}
+		now = now.Add(time.Minute)
+	}
+	ok, retryAfter := throttle.allowIP("10.0.0.1")
+	if ok || retryAfter != resetRequestWindow-maxResetRequestsPerIP*time.Minute {
+		t.Errorf("allowIP() over the limit = %v, %v", ok, retryAfter)
+	}
+	if ok, _ := throttle.allowIP("10.0.0.2"); !ok {
+		t.Error("Expected another IP address to have its own limit")
+	}
+
+	now = now.Add(retryAfter)
+	if ok, _ := throttle.allowIP("10.0.0.1"); !ok {
+		t.Error("Expected a request once the oldest left the window")
+	}
+}
+
+func TestResetThrottle_PerAccount(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	throttle := newResetThrottle()
+	throttle.now = func() time.Time { return now }
+
+	if !throttle.allowAccount("alice@example.com") {
+		t.Fatal("Expected the first reset to be allowed")
+	}
+	if throttle.allowAccount("Alice@Example.com") {
+		t.Error("Expected a second reset inside the cooldown to be refused")
+	}
+	if !throttle.allowAccount("bob@example.com") {
+		t.Error("Expected another account to have its own cooldown")
+	}
+
+	now = now.Add(resetEmailCooldown)
+	if !throttle.allowAccount("alice@example.com") {
+		t.Error("Expected a reset once the cooldown passed")
+	}
+}
@@ -0,0 +1,106 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rusiqe/domainvault/internal/analytics"
+	"github.com/rusiqe/domainvault/internal/types"
+)
+
+// ListSavedViews returns the logged-in user's saved domain list views by name
+func (h *AdminHandler) ListSavedViews(c *gin.Context) {
+	user := c.MustGet("user").(*types.User)
+	views, err := h.domainRepo.GetSavedViews(user.ID)
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"views": views, "total": len(views)})
+}
+
+// CreateSavedView saves a named domain list filter and sort for the logged-in user. Apply it
+// with GET /api/v1/domains?view=<id>.
+func (h *AdminHandler) CreateSavedView(c *gin.Context) {
+	var req types.SavedViewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Invalid request format")
+		return
+	}
+
+	view := &types.SavedView{
+		UserID: c.MustGet("user").(*types.User).ID,
+		Name:   req.Name,
+		Filter: types.SavedViewFilter(req.Filter),
+	}
+	if err := view.Validate(analytics.HealthScoreSortField); err != nil {
+		respondWithError(c, http.StatusBadRequest, err)
+		return
+	}
+	if err := h.domainRepo.CreateSavedView(view); err != nil {
+		respondWithError(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusCreated, view)
+}
+
+// GetSavedView returns one of the logged-in user's saved views
+func (h *AdminHandler) GetSavedView(c *gin.Context) {
+	view, ok := h.ownSavedView(c)
+	if !ok {
+		return
+	}
+	c.JSON(http.StatusOK, view)
+}
+
+// UpdateSavedView renames a saved view or replaces its filter
+func (h *AdminHandler) UpdateSavedView(c *gin.Context) {
+	view, ok := h.ownSavedView(c)
+	if !ok {
+		return
+	}
+
+	var req types.SavedViewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Invalid request format")
+		return
+	}
+	view.Name = req.Name
+	view.Filter = types.SavedViewFilter(req.Filter)
+	if err := view.Validate(analytics.HealthScoreSortField); err != nil {
+		respondWithError(c, http.StatusBadRequest, err)
+		return
+	}
+	if err := h.domainRepo.UpdateSavedView(view); err != nil {
+		respondWithError(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusOK, view)
+}
+
+// DeleteSavedView removes one of the logged-in user's saved views
+func (h *AdminHandler) DeleteSavedView(c *gin.Context) {
+	view, ok := h.ownSavedView(c)
+	if !ok {
+		return
+	}
+	if err := h.domainRepo.DeleteSavedView(view.ID); err != nil {
+		respondWithError(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Saved view deleted"})
+}
+
+// ownSavedView loads the view named by the :id parameter. Other users' views are reported as
+// not found, so their IDs can't be probed.
+func (h *AdminHandler) ownSavedView(c *gin.Context) (*types.SavedView, bool) {
+	view, err := h.domainRepo.GetSavedViewByID(c.Param("id"))
+	if err == nil && view.UserID != c.MustGet("user").(*types.User).ID {
+		err = types.ErrSavedViewNotFound
+	}
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err)
+		return nil, false
+	}
+	return view, true
+}
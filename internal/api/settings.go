@@ -0,0 +1,208 @@
+package api
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rusiqe/domainvault/internal/security"
+	"github.com/rusiqe/domainvault/internal/storage"
+	"github.com/rusiqe/domainvault/internal/types"
+)
+
+// loadExpiryThresholds returns the saved expiry thresholds, or the defaults if none were saved
+// or the settings store can't be read
+func loadExpiryThresholds(repo storage.DomainRepository) types.ExpiryThresholds {
+	var thresholds types.ExpiryThresholds
+	err := repo.GetSetting(types.SettingExpiryThresholds, &thresholds)
+	if err == nil {
+		if err = thresholds.Validate(); err == nil {
+			return thresholds
+		}
+	}
+	if !errors.Is(err, types.ErrSettingNotFound) {
+		log.Printf("Using default expiry thresholds: %v", err)
+	}
+	return types.DefaultExpiryThresholds()
+}
+
+// loadResponseTimeSLA returns the saved response-time SLA, or the default if none was saved
+// or the settings store can't be read
+func loadResponseTimeSLA(repo storage.DomainRepository) types.ResponseTimeSLA {
+	var sla types.ResponseTimeSLA
+	err := repo.GetSetting(types.SettingResponseTimeSLA, &sla)
+	if err == nil {
+		if err = sla.Validate(); err == nil {
+			return sla
+		}
+	}
+	if !errors.Is(err, types.ErrSettingNotFound) {
+		log.Printf("Using default response-time SLA: %v", err)
+	}
+	return types.DefaultResponseTimeSLA()
+}
+
+// GetRuntimeSettings returns the settings that can be changed without a restart, with the
+// environment values they fall back to
+func (h *AdminHandler) GetRuntimeSettings(c *gin.Context) {
+	if h.settingsSvc == nil {
+		respondError(c, http.StatusServiceUnavailable, types.CodeServiceUnavailable, "Settings service not configured")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"settings": h.settingsSvc.Get(), "defaults": h.settingsSvc.Defaults()})
+}
+
+// UpdateRuntimeSettings replaces the runtime settings. The sync interval, notification channels
+// and lockout policy change immediately.
+func (h *AdminHandler) UpdateRuntimeSettings(c *gin.Context) {
+	if h.settingsSvc == nil {
+		respondError(c, http.StatusServiceUnavailable, types.CodeServiceUnavailable, "Settings service not configured")
+		return
+	}
+
+	var settings types.RuntimeSettings
+	if err := c.ShouldBindJSON(&settings); err != nil {
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Invalid request body")
+		return
+	}
+
+	previous, err := h.settingsSvc.Update(settings)
+	if h.securitySvc != nil {
+		userID, _ := c.Get("userID")
+		id, _ := userID.(string)
+		details := map[string]interface{}{"previous": previous, "current": settings}
+		if err != nil {
+			details = map[string]interface{}{"requested": settings, "error": err.Error()}
+		}
+		h.securitySvc.LogAuditEvent(security.EventSettingsChange, id, requestActor(c), c.ClientIP(),
+			c.GetHeader("User-Agent"), "settings", "update_runtime_settings", err == nil, details, "")
+	}
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"settings": settings})
+}
+
+// GetExpirySettings returns the thresholds that decide when domains count as expiring
+func (h *AdminHandler) GetExpirySettings(c *gin.Context) {
+	c.JSON(http.StatusOK, loadExpiryThresholds(h.domainRepo))
+}
+
+// UpdateExpirySettings replaces the expiry thresholds used by the summary, the expiring list
+// and the calendar reminders
+func (h *AdminHandler) UpdateExpirySettings(c *gin.Context) {
+	var thresholds types.ExpiryThresholds
+	if err := c.ShouldBindJSON(&thresholds); err != nil {
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Invalid request body")
+		return
+	}
+	if err := thresholds.Validate(); err != nil {
+		respondWithError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	previous := loadExpiryThresholds(h.domainRepo)
+	if err := h.domainRepo.SaveSetting(types.SettingExpiryThresholds, thresholds); err != nil {
+		respondWithError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	if h.securitySvc != nil {
+		userID, _ := c.Get("userID")
+		id, _ := userID.(string)
+		h.securitySvc.LogAuditEvent(security.EventSettingsChange, id, requestActor(c), c.ClientIP(),
+			c.GetHeader("User-Agent"), "settings", "update_expiry_thresholds", true,
+			map[string]interface{}{"previous": previous, "current": thresholds}, "")
+	}
+
+	c.JSON(http.StatusOK, thresholds)
+}
+
+// GetResponseTimeSLASettings returns the response-time SLA thresholds
+func (h *AdminHandler) GetResponseTimeSLASettings(c *gin.Context) {
+	c.JSON(http.StatusOK, loadResponseTimeSLA(h.domainRepo))
+}
+
+// UpdateResponseTimeSLASettings replaces the response-time SLA thresholds checked on monitor
+// sync. Alerts fire when a domain goes over its threshold, so domains already over a lowered
+// threshold don't alert; they are listed in the status summary's SLA breaches.
+func (h *AdminHandler) UpdateResponseTimeSLASettings(c *gin.Context) {
+	var sla types.ResponseTimeSLA
+	if err := c.ShouldBindJSON(&sla); err != nil {
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Invalid request body")
+		return
+	}
+	if err := sla.Validate(); err != nil {
+		respondWithError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	previous := loadResponseTimeSLA(h.domainRepo)
+	if err := h.domainRepo.SaveSetting(types.SettingResponseTimeSLA, sla); err != nil {
+		respondWithError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	if h.securitySvc != nil {
+		userID, _ := c.Get("userID")
+		id, _ := userID.(string)
+		h.securitySvc.LogAuditEvent(security.EventSettingsChange, id, requestActor(c), c.ClientIP(),
+			c.GetHeader("User-Agent"), "settings", "update_response_time_sla", true,
+			map[string]interface{}{"previous": previous, "current": sla}, "")
+	}
+
+	c.JSON(http.StatusOK, sla)
+}
+
+// GetMaintenanceMode returns whether maintenance mode is on, and since when
+func (h *AdminHandler) GetMaintenanceMode(c *gin.Context) {
+	if h.settingsSvc == nil {
+		respondError(c, http.StatusServiceUnavailable, types.CodeServiceUnavailable, "Settings service not configured")
+		return
+	}
+	c.JSON(http.StatusOK, h.settingsSvc.Maintenance())
+}
+
+// UpdateMaintenanceMode turns maintenance mode on or off with {"enabled": true, "message": "..."}.
+// While it is on, every change except signing in and this endpoint gets 503 with the message,
+// reads are still served, and scheduled syncs and DNS refreshes are skipped.
+func (h *AdminHandler) UpdateMaintenanceMode(c *gin.Context) {
+	if h.settingsSvc == nil {
+		respondError(c, http.StatusServiceUnavailable, types.CodeServiceUnavailable, "Settings service not configured")
+		return
+	}
+
+	var mode types.MaintenanceMode
+	if err := c.ShouldBindJSON(&mode); err != nil {
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Invalid request body")
+		return
+	}
+
+	previous, err := h.settingsSvc.SetMaintenance(mode, requestActor(c))
+	if h.securitySvc != nil {
+		userID, _ := c.Get("userID")
+		id, _ := userID.(string)
+		details := map[string]interface{}{"previous": previous, "enabled": mode.Enabled, "message": mode.Message}
+		if err != nil {
+			details["error"] = err.Error()
+		}
+		h.securitySvc.LogAuditEvent(security.EventSettingsChange, id, requestActor(c), c.ClientIP(),
+			c.GetHeader("User-Agent"), "settings", "update_maintenance_mode", err == nil, details, "")
+	}
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	current := h.settingsSvc.Maintenance()
+	switch {
+	case current.Enabled && !previous.Enabled:
+		log.Printf("Maintenance mode turned on by %s", requestActor(c))
+	case !current.Enabled && previous.Enabled:
+		log.Printf("Maintenance mode turned off by %s", requestActor(c))
+	}
+	c.JSON(http.StatusOK, current)
+}
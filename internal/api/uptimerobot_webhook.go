@@ -0,0 +1,79 @@
+package api
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rusiqe/domainvault/internal/types"
+	"github.com/rusiqe/domainvault/internal/uptimerobot"
+)
+
+// ReceiveUptimeRobotWebhook takes an alert from an UptimeRobot webhook alert contact, stores the
+// monitor's new status on its domain and sends it through the notification rules, so downtime
+// is seen without waiting for the next monitor sync. The alert contact's URL must carry the
+// configured ?token=.
+func (h *AdminHandler) ReceiveUptimeRobotWebhook(c *gin.Context) {
+	if h.uptimeRobotSvc == nil || !h.uptimeRobotSvc.WebhookEnabled() {
+		respondError(c, http.StatusServiceUnavailable, types.CodeServiceUnavailable, "UptimeRobot webhooks not configured")
+		return
+	}
+	if !h.uptimeRobotSvc.ValidWebhookToken(c.Query("token")) {
+		respondError(c, http.StatusUnauthorized, types.CodeUnauthorized, "Invalid webhook token")
+		return
+	}
+
+	var alert uptimerobot.AlertWebhook
+	if err := c.ShouldBind(&alert); err != nil {
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Invalid alert payload: "+err.Error())
+		return
+	}
+	if alert.MonitorID <= 0 {
+		respondError(c, http.StatusBadRequest, types.CodeValidationFailed, "monitorID is required")
+		return
+	}
+	if alert.AlertType != uptimerobot.AlertTypeDown && alert.AlertType != uptimerobot.AlertTypeUp {
+		// SSL expiry and other alerts don't change the monitor status
+		c.JSON(http.StatusOK, gin.H{"message": "Alert type ignored", "alert_type": alert.AlertType})
+		return
+	}
+
+	domain, err := h.domainRepo.GetByMonitorID(alert.MonitorID)
+	if err != nil {
+		if errors.Is(err, types.ErrDomainNotFound) {
+			respondError(c, http.StatusNotFound, types.CodeDomainNotFound, "No domain is linked to this monitor")
+			return
+		}
+		respondWithError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	previous := *domain
+	if err := h.uptimeRobotSvc.ApplyAlert(domain, alert); err != nil {
+		respondError(c, http.StatusBadRequest, types.CodeValidationFailed, err.Error())
+		return
+	}
+	if err := h.domainRepo.Update(domain); err != nil {
+		respondWithError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	// UptimeRobot can repeat an alert; only a change of status is notified
+	changed := previous.MonitorStatus == nil || *previous.MonitorStatus != *domain.MonitorStatus
+	if changed && h.notificationSvc != nil {
+		down := alert.AlertType == uptimerobot.AlertTypeDown
+		duration := time.Duration(alert.AlertDuration) * time.Second
+		notification := h.notificationSvc.CreateMonitorAlert(*domain, down, alert.AlertDetails, duration)
+		if err := h.notificationSvc.Notify(notification); err != nil {
+			log.Printf("Failed to send monitor alert for %s: %v", domain.Name, err)
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"domain_id":      domain.ID,
+		"monitor_status": domain.MonitorStatus,
+		"notified":       changed,
+	})
+}
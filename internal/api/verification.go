@@ -0,0 +1,94 @@
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rusiqe/domainvault/internal/dns"
+	"github.com/rusiqe/domainvault/internal/security"
+	"github.com/rusiqe/domainvault/internal/types"
+)
+
+// RequestDomainVerification issues a new ownership challenge for a domain and returns the TXT
+// record to publish. A domain that is already verified stays verified until the new
+// challenge is checked.
+func (h *AdminHandler) RequestDomainVerification(c *gin.Context) {
+	domain, err := h.domainRepo.GetByID(c.Param("id"))
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	token, err := types.NewVerificationToken()
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err)
+		return
+	}
+	now := time.Now()
+	if err := h.domainRepo.SetDomainVerification(domain.ID, &token, &now, domain.VerifiedAt); err != nil {
+		respondWithError(c, http.StatusInternalServerError, err)
+		return
+	}
+	domain.VerificationToken = &token
+	domain.VerificationRequestedAt = &now
+
+	h.auditVerification(c, domain, "request_verification", true, nil)
+	c.JSON(http.StatusOK, domain.VerificationChallenge())
+}
+
+// CheckDomainVerification looks for the pending challenge's TXT record at the domain's
+// nameservers and marks the domain verified when it's there
+func (h *AdminHandler) CheckDomainVerification(c *gin.Context) {
+	domain, err := h.domainRepo.GetByID(c.Param("id"))
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err)
+		return
+	}
+	if domain.VerificationToken == nil {
+		respondError(c, http.StatusConflict, types.CodeConflict, "No verification challenge is pending; request one first")
+		return
+	}
+
+	challenge := domain.VerificationChallenge()
+	values, err := dns.NewHealthChecker().LookupTXTAuthoritative(domain.Name, challenge.RecordName)
+	if err != nil {
+		respondErrorDetails(c, http.StatusBadGateway, types.CodeProviderUnavailable,
+			"Failed to look up the verification record: "+err.Error(), challenge)
+		return
+	}
+	if !types.VerificationMatches(values, *domain.VerificationToken) {
+		h.auditVerification(c, domain, "check_verification", false, map[string]interface{}{"found": values})
+		c.JSON(http.StatusOK, gin.H{"verified": false, "found": values, "challenge": challenge})
+		return
+	}
+
+	now := time.Now()
+	if err := h.domainRepo.SetDomainVerification(domain.ID, nil, nil, &now); err != nil {
+		respondWithError(c, http.StatusInternalServerError, err)
+		return
+	}
+	domain.VerificationToken = nil
+	domain.VerificationRequestedAt = nil
+	domain.VerifiedAt = &now
+
+	h.auditVerification(c, domain, "check_verification", true, nil)
+	c.JSON(http.StatusOK, gin.H{"verified": true, "found": values, "challenge": domain.VerificationChallenge()})
+}
+
+// auditVerification records an ownership verification step in the audit log
+func (h *AdminHandler) auditVerification(c *gin.Context, domain *types.Domain, action string, success bool, details map[string]interface{}) {
+	if h.securitySvc == nil {
+		return
+	}
+	if details == nil {
+		details = map[string]interface{}{}
+	}
+	details["domain_id"] = domain.ID
+	details["domain_name"] = domain.Name
+
+	userID, _ := c.Get("userID")
+	id, _ := userID.(string)
+	h.securitySvc.LogAuditEvent(security.EventDomainUpdate, id, requestActor(c), c.ClientIP(),
+		c.GetHeader("User-Agent"), "domain", action, success, details, "")
+}
@@ -0,0 +1,95 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rusiqe/domainvault/internal/types"
+)
+
+// ListWatchlist returns the domains being watched for availability
+func (h *AdminHandler) ListWatchlist(c *gin.Context) {
+	entries, err := h.domainRepo.GetWatchlist()
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err)
+		return
+	}
+	if entries == nil {
+		entries = []types.WatchlistEntry{}
+	}
+	c.JSON(http.StatusOK, gin.H{"watchlist": entries, "total": len(entries)})
+}
+
+// AddToWatchlist starts watching a domain someone else holds. An alert fires when it becomes
+// available to register for no more than max_price.
+func (h *AdminHandler) AddToWatchlist(c *gin.Context) {
+	if h.watchlistSvc == nil {
+		respondError(c, http.StatusServiceUnavailable, types.CodeServiceUnavailable, "Watchlist service not configured")
+		return
+	}
+
+	var req types.WatchlistRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Invalid request format")
+		return
+	}
+
+	entry, err := h.watchlistSvc.Add(req)
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusCreated, entry)
+}
+
+// UpdateWatchlistEntry changes a watched domain's price limit, currency or notes
+func (h *AdminHandler) UpdateWatchlistEntry(c *gin.Context) {
+	if h.watchlistSvc == nil {
+		respondError(c, http.StatusServiceUnavailable, types.CodeServiceUnavailable, "Watchlist service not configured")
+		return
+	}
+
+	var req types.WatchlistRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Invalid request format")
+		return
+	}
+
+	entry, err := h.watchlistSvc.Update(c.Param("id"), req)
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusOK, entry)
+}
+
+// RemoveFromWatchlist stops watching a domain
+func (h *AdminHandler) RemoveFromWatchlist(c *gin.Context) {
+	if err := h.domainRepo.DeleteWatchlistEntry(c.Param("id")); err != nil {
+		respondWithError(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Domain removed from watchlist"})
+}
+
+// CheckWatchlistEntry checks a watched domain's availability now rather than waiting for the
+// scheduled check
+func (h *AdminHandler) CheckWatchlistEntry(c *gin.Context) {
+	if h.watchlistSvc == nil {
+		respondError(c, http.StatusServiceUnavailable, types.CodeServiceUnavailable, "Watchlist service not configured")
+		return
+	}
+
+	entry, err := h.domainRepo.GetWatchlistEntryByID(c.Param("id"))
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	notified, err := h.watchlistSvc.Check(entry)
+	if err != nil {
+		respondWithErrorDetails(c, http.StatusBadGateway, err, gin.H{"entry": entry})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"entry": entry, "notified": notified})
+}
@@ -0,0 +1,199 @@
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rusiqe/domainvault/internal/notifications"
+	"github.com/rusiqe/domainvault/internal/security"
+	"github.com/rusiqe/domainvault/internal/types"
+)
+
+// webhookEndpointView is an endpoint as returned by the API; secrets are only shown once, when
+// they're created
+type webhookEndpointView struct {
+	types.WebhookEndpoint
+	PreviousSecretActive bool   `json:"previous_secret_active"`
+	SignatureHeader      string `json:"signature_header"`
+}
+
+func newWebhookEndpointView(endpoint types.WebhookEndpoint) webhookEndpointView {
+	return webhookEndpointView{
+		WebhookEndpoint:      endpoint,
+		PreviousSecretActive: endpoint.PreviousSecretActive(time.Now()),
+		SignatureHeader:      notifications.WebhookSignatureHeader,
+	}
+}
+
+// auditWebhookChange records a change to a webhook endpoint. Secrets are never logged.
+func (h *AdminHandler) auditWebhookChange(c *gin.Context, action string, endpoint types.WebhookEndpoint, details map[string]interface{}) {
+	if h.securitySvc == nil {
+		return
+	}
+	userID, _ := c.Get("userID")
+	id, _ := userID.(string)
+	if details == nil {
+		details = map[string]interface{}{}
+	}
+	details["webhook_id"] = endpoint.ID
+	details["url"] = endpoint.URL
+	h.securitySvc.LogAuditEvent(security.EventSettingsChange, id, requestActor(c), c.ClientIP(),
+		c.GetHeader("User-Agent"), "webhook", action, true, details, "")
+}
+
+// ListWebhookEndpoints returns the outbound webhook endpoints without their secrets
+func (h *AdminHandler) ListWebhookEndpoints(c *gin.Context) {
+	endpoints, err := h.domainRepo.GetWebhookEndpoints()
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	views := make([]webhookEndpointView, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		views = append(views, newWebhookEndpointView(endpoint))
+	}
+	c.JSON(http.StatusOK, gin.H{"webhooks": views, "total": len(views)})
+}
+
+// CreateWebhookEndpoint registers a webhook endpoint with a new signing secret. The secret is
+// only returned in this response.
+func (h *AdminHandler) CreateWebhookEndpoint(c *gin.Context) {
+	var req types.WebhookEndpointRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Invalid request format")
+		return
+	}
+
+	endpoint := types.WebhookEndpoint{URL: req.URL, Description: req.Description, Enabled: true}
+	if req.Enabled != nil {
+		endpoint.Enabled = *req.Enabled
+	}
+	if err := endpoint.Validate(); err != nil {
+		respondWithError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	secret, err := types.GenerateWebhookSecret()
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err)
+		return
+	}
+	endpoint.RotateSecret(secret, 0, time.Now())
+
+	if err := h.domainRepo.CreateWebhookEndpoint(&endpoint); err != nil {
+		respondWithError(c, http.StatusInternalServerError, err)
+		return
+	}
+	h.auditWebhookChange(c, "create_webhook", endpoint, nil)
+
+	c.JSON(http.StatusCreated, gin.H{
+		"webhook": newWebhookEndpointView(endpoint),
+		"secret":  secret,
+		"message": "Store the signing secret now; it will not be shown again",
+	})
+}
+
+// UpdateWebhookEndpoint changes an endpoint's URL, description or enabled flag
+func (h *AdminHandler) UpdateWebhookEndpoint(c *gin.Context) {
+	endpoint, err := h.domainRepo.GetWebhookEndpointByID(c.Param("id"))
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	var req types.WebhookEndpointRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Invalid request format")
+		return
+	}
+
+	previousURL := endpoint.URL
+	endpoint.URL = req.URL
+	endpoint.Description = req.Description
+	if req.Enabled != nil {
+		endpoint.Enabled = *req.Enabled
+	}
+	if err := endpoint.Validate(); err != nil {
+		respondWithError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := h.domainRepo.UpdateWebhookEndpoint(endpoint); err != nil {
+		respondWithError(c, http.StatusInternalServerError, err)
+		return
+	}
+	h.auditWebhookChange(c, "update_webhook", *endpoint, map[string]interface{}{
+		"previous_url": previousURL,
+		"enabled":      endpoint.Enabled,
+	})
+
+	c.JSON(http.StatusOK, newWebhookEndpointView(*endpoint))
+}
+
+// DeleteWebhookEndpoint removes a webhook endpoint
+func (h *AdminHandler) DeleteWebhookEndpoint(c *gin.Context) {
+	endpoint, err := h.domainRepo.GetWebhookEndpointByID(c.Param("id"))
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	if err := h.domainRepo.DeleteWebhookEndpoint(endpoint.ID); err != nil {
+		respondWithError(c, http.StatusInternalServerError, err)
+		return
+	}
+	h.auditWebhookChange(c, "delete_webhook", *endpoint, nil)
+
+	c.JSON(http.StatusOK, gin.H{"message": "Webhook endpoint deleted successfully"})
+}
+
+// RotateWebhookSecret replaces an endpoint's signing secret. Deliveries are signed with the new
+// secret straight away; the old one stays valid for receivers for grace_hours (default 24) so
+// they can roll the new secret out without rejecting deliveries.
+func (h *AdminHandler) RotateWebhookSecret(c *gin.Context) {
+	endpoint, err := h.domainRepo.GetWebhookEndpointByID(c.Param("id"))
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	var req types.WebhookRotateRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			respondError(c, http.StatusBadRequest, types.CodeInvalidRequest, "Invalid request format")
+			return
+		}
+	}
+	window := types.DefaultWebhookRotationWindow
+	if req.GraceHours != nil {
+		window = time.Duration(*req.GraceHours) * time.Hour
+		if window < 0 || window > types.MaxWebhookRotationWindow {
+			respondError(c, http.StatusBadRequest, types.CodeValidationFailed, "grace_hours must be between 0 and 720")
+			return
+		}
+	}
+
+	secret, err := types.GenerateWebhookSecret()
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err)
+		return
+	}
+	endpoint.RotateSecret(secret, window, time.Now())
+
+	if err := h.domainRepo.UpdateWebhookEndpoint(endpoint); err != nil {
+		respondWithError(c, http.StatusInternalServerError, err)
+		return
+	}
+	h.auditWebhookChange(c, "rotate_webhook_secret", *endpoint, map[string]interface{}{
+		"grace_hours":                int(window / time.Hour),
+		"previous_secret_expires_at": endpoint.PreviousExpiresAt,
+	})
+
+	c.JSON(http.StatusOK, gin.H{
+		"webhook": newWebhookEndpointView(*endpoint),
+		"secret":  secret,
+		"message": "Deliveries are now signed with the new secret; receivers should accept both secrets until the previous one expires",
+	})
+}
@@ -2,7 +2,9 @@ package auth
 
 import (
 	"crypto/rand"
+	"crypto/sha256"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"time"
 
@@ -13,6 +15,9 @@ import (
 // AuthService handles authentication operations
 type AuthService struct {
 	repo UserRepository
+
+	resetTTL time.Duration // Lifetime of password reset tokens
+	resetURL string        // Page that accepts ?reset_token=
 }
 
 // UserRepository defines the interface for user data operations
@@ -20,20 +25,40 @@ type UserRepository interface {
 	CreateUser(user *types.User) error
 	GetUserByUsername(username string) (*types.User, error)
 	GetUserByID(id string) (*types.User, error)
+	GetUserByEmail(email string) (*types.User, error)
 	UpdateUser(user *types.User) error
 	DeleteUser(id string) error
 	
 	CreateSession(session *types.Session) error
 	GetSessionByToken(token string) (*types.Session, error)
 	DeleteSession(token string) error
+	DeleteUserSessions(userID string) error
 	DeleteExpiredSessions() error
 	UpdateLastLogin(userID string) error
+
+	SetCalendarToken(userID, tokenHash string) error
+	GetUserByCalendarToken(tokenHash string) (*types.User, error)
+
+	CreatePasswordResetToken(userID, tokenHash string, expiresAt time.Time) error
+	ConsumePasswordResetToken(tokenHash string) (string, error)
 }
 
 // NewAuthService creates a new authentication service
 func NewAuthService(repo UserRepository) *AuthService {
 	return &AuthService{
-		repo: repo,
+		repo:     repo,
+		resetTTL: time.Hour,
+		resetURL: "http://localhost:8080/admin",
+	}
+}
+
+// SetPasswordResetConfig sets the reset token lifetime and the page linked from reset emails
+func (a *AuthService) SetPasswordResetConfig(ttl time.Duration, resetURL string) {
+	if ttl > 0 {
+		a.resetTTL = ttl
+	}
+	if resetURL != "" {
+		a.resetURL = resetURL
 	}
 }
 
@@ -145,6 +170,107 @@ func generateToken() (string, error) {
 	return hex.EncodeToString(bytes), nil
 }
 
+// GenerateCalendarToken issues a new read-only calendar feed token for a user, revoking any previous one.
+// Only a hash is stored, so the token is returned once and cannot be retrieved later.
+func (a *AuthService) GenerateCalendarToken(userID string) (string, error) {
+	token, err := generateToken()
+	if err != nil {
+		return "", fmt.Errorf("failed to generate calendar token: %w", err)
+	}
+	if err := a.repo.SetCalendarToken(userID, hashToken(token)); err != nil {
+		return "", fmt.Errorf("failed to store calendar token: %w", err)
+	}
+	return token, nil
+}
+
+// ValidateCalendarToken returns the enabled user owning a calendar feed token
+func (a *AuthService) ValidateCalendarToken(token string) (*types.User, error) {
+	if token == "" {
+		return nil, fmt.Errorf("invalid token")
+	}
+	user, err := a.repo.GetUserByCalendarToken(hashToken(token))
+	if err != nil || user == nil {
+		return nil, fmt.Errorf("invalid token")
+	}
+	if !user.Enabled {
+		return nil, fmt.Errorf("account disabled")
+	}
+	return user, nil
+}
+
+// hashToken hashes stored tokens so a database leak doesn't expose usable calendar feeds or reset links
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// RequestPasswordReset issues a single-use reset token for the user matching a username or email.
+// Any outstanding token for the user is replaced. The caller is responsible for delivering the token.
+func (a *AuthService) RequestPasswordReset(identifier string) (*types.User, string, error) {
+	user, err := a.repo.GetUserByUsername(identifier)
+	if err != nil {
+		user, err = a.repo.GetUserByEmail(identifier)
+	}
+	if err != nil || user == nil {
+		return nil, "", fmt.Errorf("user not found")
+	}
+	if !user.Enabled {
+		return nil, "", fmt.Errorf("account disabled")
+	}
+
+	token, err := generateToken()
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to generate reset token: %w", err)
+	}
+	if err := a.repo.CreatePasswordResetToken(user.ID, hashToken(token), time.Now().Add(a.resetTTL)); err != nil {
+		return nil, "", fmt.Errorf("failed to store reset token: %w", err)
+	}
+
+	return user, token, nil
+}
+
+// PasswordResetLink returns the URL sent to users for a reset token
+func (a *AuthService) PasswordResetLink(token string) string {
+	return a.resetURL + "?reset_token=" + token
+}
+
+// PasswordResetTTL returns how long reset tokens stay valid
+func (a *AuthService) PasswordResetTTL() time.Duration {
+	return a.resetTTL
+}
+
+// ResetPassword consumes a reset token, sets the new password and ends all of the user's sessions.
+// Password policy must be checked by the caller before the token is spent.
+func (a *AuthService) ResetPassword(token, newPassword string) (*types.User, error) {
+	userID, err := a.repo.ConsumePasswordResetToken(hashToken(token))
+	if errors.Is(err, types.ErrInvalidResetToken) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to check reset token: %w", err)
+	}
+
+	user, err := a.repo.GetUserByID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("user not found")
+	}
+
+	hashedPassword, err := HashPassword(newPassword)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash password: %w", err)
+	}
+	user.PasswordHash = hashedPassword
+	if err := a.repo.UpdateUser(user); err != nil {
+		return nil, fmt.Errorf("failed to update password: %w", err)
+	}
+
+	if err := a.repo.DeleteUserSessions(user.ID); err != nil {
+		return user, fmt.Errorf("password updated but failed to end existing sessions: %w", err)
+	}
+
+	return user, nil
+}
+
 // CreateDefaultAdmin creates the default admin user if it doesn't exist
 func (a *AuthService) CreateDefaultAdmin() error {
 	// Check if admin user already exists
@@ -172,6 +298,43 @@ func (a *AuthService) CreateDefaultAdmin() error {
 	return a.repo.CreateUser(admin)
 }
 
+// SetUserRole changes a user's role
+func (a *AuthService) SetUserRole(userID, role string) (*types.User, error) {
+	if !types.IsValidRole(role) {
+		return nil, types.ErrInvalidRole
+	}
+
+	user, err := a.repo.GetUserByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	user.Role = role
+	if err := a.repo.UpdateUser(user); err != nil {
+		return nil, fmt.Errorf("failed to update user: %w", err)
+	}
+	return user, nil
+}
+
+// SetUserScope replaces the projects and tags a user's domain access is limited to. An empty
+// scope lifts the limit; admins keep access to every domain whatever their scope.
+func (a *AuthService) SetUserScope(userID string, scope types.AccessScope) (*types.User, error) {
+	if err := scope.Validate(); err != nil {
+		return nil, err
+	}
+
+	user, err := a.repo.GetUserByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	user.Scope = scope
+	if err := a.repo.UpdateUser(user); err != nil {
+		return nil, fmt.Errorf("failed to update user: %w", err)
+	}
+	return user, nil
+}
+
 // VerifyCurrentUserPassword verifies the current user's password for security operations
 func (a *AuthService) VerifyCurrentUserPassword(userID, password string) bool {
 	user, err := a.repo.GetUserByID(userID)
@@ -0,0 +1,33 @@
+package auth
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/rusiqe/domainvault/internal/storage"
+	"github.com/rusiqe/domainvault/internal/types"
+)
+
+func TestResetPassword_InvalidToken(t *testing.T) {
+	repo := storage.NewEmptyMockRepo()
+	svc := NewAuthService(repo)
+	user := &types.User{Username: "alice", Email: "alice@example.com", Role: types.RoleViewer, Enabled: true}
+	if err := repo.CreateUser(user); err != nil {
+		t.Fatalf("CreateUser() error: %v", err)
+	}
+
+	if _, err := svc.ResetPassword("unknown-token", "new-password-123"); !errors.Is(err, types.ErrInvalidResetToken) {
+		t.Errorf("ResetPassword() with an unknown token error = %v, want ErrInvalidResetToken", err)
+	}
+
+	_, token, err := svc.RequestPasswordReset("alice")
+	if err != nil {
+		t.Fatalf("RequestPasswordReset() error: %v", err)
+	}
+	if _, err := svc.ResetPassword(token, "new-password-123"); err != nil {
+		t.Fatalf("ResetPassword() error: %v", err)
+	}
+	if _, err := svc.ResetPassword(token, "another-password-123"); !errors.Is(err, types.ErrInvalidResetToken) {
+		t.Errorf("ResetPassword() with a used token error = %v, want ErrInvalidResetToken", err)
+	}
+}
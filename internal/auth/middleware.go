@@ -14,7 +14,7 @@ func AuthMiddleware(authService *AuthService) gin.HandlerFunc {
 		// Get token from Authorization header
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Authorization header required"})
+			c.JSON(http.StatusUnauthorized, types.NewErrorResponse(types.CodeUnauthorized, "Authorization header required", nil))
 			c.Abort()
 			return
 		}
@@ -22,7 +22,7 @@ func AuthMiddleware(authService *AuthService) gin.HandlerFunc {
 		// Extract token from "Bearer <token>" format
 		tokenParts := strings.Split(authHeader, " ")
 		if len(tokenParts) != 2 || tokenParts[0] != "Bearer" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid authorization header format"})
+			c.JSON(http.StatusUnauthorized, types.NewErrorResponse(types.CodeUnauthorized, "Invalid authorization header format", nil))
 			c.Abort()
 			return
 		}
@@ -32,13 +32,14 @@ func AuthMiddleware(authService *AuthService) gin.HandlerFunc {
 		// Validate token
 		user, err := authService.ValidateToken(token)
 		if err != nil {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired token"})
+			c.JSON(http.StatusUnauthorized, types.NewErrorResponse(types.CodeUnauthorized, "Invalid or expired token", nil))
 			c.Abort()
 			return
 		}
 
 		// Store user in context for later use
 		c.Set("user", user)
+		c.Set("userID", user.ID)
 		c.Next()
 	}
 }
@@ -48,20 +49,93 @@ func RequireRole(role string) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		userInterface, exists := c.Get("user")
 		if !exists {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found in context"})
+			c.JSON(http.StatusUnauthorized, types.NewErrorResponse(types.CodeUnauthorized, "User not found in context", nil))
 			c.Abort()
 			return
 		}
 
 		user, ok := userInterface.(*types.User)
 		if !ok {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user type in context"})
+			c.JSON(http.StatusInternalServerError, types.NewErrorResponse(types.CodeInternal, "Invalid user type in context", nil))
 			c.Abort()
 			return
 		}
 
 		if user.Role != role {
-			c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions"})
+			c.JSON(http.StatusForbidden, types.NewErrorResponse(types.CodeForbidden, "Insufficient permissions", nil))
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// adminOnlyPaths are route prefixes restricted to admins regardless of method
+var adminOnlyPaths = []string{
+	"/api/v1/admin/users",
+	"/api/v1/admin/credentials",
+	"/api/v1/credentials",
+	"/api/v1/admin/providers/connect",
+	"/api/v1/admin/providers/connected",
+	"/api/v1/admin/security",
+	"/api/v1/admin/webhooks",
+	"/api/v1/admin/maintenance",
+	"/api/v1/admin/settings",
+}
+
+// RoleAllows reports whether a role may perform method on the given route path.
+// Admins can do everything, editors can change anything outside adminOnlyPaths such as
+// users, credentials and global settings, and viewers are limited to reads outside of those areas.
+func RoleAllows(role, method, path string) bool {
+	if role == types.RoleAdmin {
+		return true
+	}
+
+	for _, prefix := range adminOnlyPaths {
+		if strings.HasPrefix(path, prefix) {
+			return false
+		}
+	}
+
+	switch role {
+	case types.RoleEditor:
+		return true
+	case types.RoleViewer:
+		return method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+	default:
+		return false
+	}
+}
+
+// RequirePermission creates a middleware that enforces RoleAllows for the authenticated user.
+// onDenied, if set, is called before the request is rejected so callers can audit violations.
+func RequirePermission(onDenied func(c *gin.Context, user *types.User)) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userInterface, exists := c.Get("user")
+		if !exists {
+			c.JSON(http.StatusUnauthorized, types.NewErrorResponse(types.CodeUnauthorized, "User not found in context", nil))
+			c.Abort()
+			return
+		}
+
+		user, ok := userInterface.(*types.User)
+		if !ok {
+			c.JSON(http.StatusInternalServerError, types.NewErrorResponse(types.CodeInternal, "Invalid user type in context", nil))
+			c.Abort()
+			return
+		}
+
+		path := c.FullPath()
+		if path == "" {
+			path = c.Request.URL.Path
+		}
+
+		if !RoleAllows(user.Role, c.Request.Method, path) {
+			if onDenied != nil {
+				onDenied(c, user)
+			}
+			c.JSON(http.StatusForbidden, types.NewErrorResponse(types.CodeForbidden, "Insufficient permissions", nil))
 			c.Abort()
 			return
 		}
@@ -0,0 +1,61 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rusiqe/domainvault/internal/types"
+)
+
+func TestRoleAllows(t *testing.T) {
+	tests := []struct {
+		role   string
+		method string
+		path   string
+		want   bool
+	}{
+		{types.RoleAdmin, http.MethodPut, "/api/v1/admin/settings", true},
+		{types.RoleEditor, http.MethodPut, "/api/v1/admin/settings", false},
+		{types.RoleEditor, http.MethodPut, "/api/v1/admin/settings/expiry", false},
+		{types.RoleEditor, http.MethodGet, "/api/v1/admin/users", false},
+		{types.RoleEditor, http.MethodPut, "/api/v1/admin/domains/:id", true},
+		{types.RoleViewer, http.MethodGet, "/api/v1/admin/domains", true},
+		{types.RoleViewer, http.MethodPut, "/api/v1/admin/domains/:id", false},
+		{types.RoleViewer, http.MethodGet, "/api/v1/admin/settings", false},
+		{types.RoleEditor, http.MethodGet, "/api/v1/credentials", false},
+		{types.RoleEditor, http.MethodDelete, "/api/v1/credentials/:id", false},
+		{types.RoleViewer, http.MethodPut, "/api/v1/domains/:id", false},
+	}
+	for _, tt := range tests {
+		if got := RoleAllows(tt.role, tt.method, tt.path); got != tt.want {
+			t.Errorf("RoleAllows(%s, %s, %s) = %v, want %v", tt.role, tt.method, tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestRequirePermission_EditorCannotChangeSettings(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	var denied *types.User
+	editor := &types.User{ID: "editor-id", Username: "editor", Role: types.RoleEditor}
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("user", editor)
+		c.Next()
+	})
+	r.Use(RequirePermission(func(c *gin.Context, user *types.User) { denied = user }))
+	r.PUT("/api/v1/admin/settings", func(c *gin.Context) {
+		c.Status(http.StatusNoContent)
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPut, "/api/v1/admin/settings", nil))
+	if w.Code != http.StatusForbidden {
+		t.Errorf("PUT /admin/settings as an editor = %d, want %d", w.Code, http.StatusForbidden)
+	}
+	if denied != editor {
+		t.Error("Expected the denied request to be reported to onDenied")
+	}
+}
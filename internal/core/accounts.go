@@ -0,0 +1,135 @@
+package core
+
+import (
+	"fmt"
+	"log"
+	"sort"
+
+	"github.com/rusiqe/domainvault/internal/providers"
+	"github.com/rusiqe/domainvault/internal/types"
+)
+
+// syncAccount is a sync target built from a stored set of provider credentials
+type syncAccount struct {
+	provider     string
+	credentialID string
+	accountName  string
+}
+
+// AccountSyncName is the name a stored provider account syncs and reports under. Each account
+// gets its own name so several accounts at one registrar sync side by side.
+func AccountSyncName(provider, credentialID string) string {
+	return provider + ":" + credentialID
+}
+
+// SecureSyncName is the name a secure provider connection syncs and reports under. Its
+// domains aren't tagged with a credential ID, since the connection isn't a stored account.
+func SecureSyncName(provider, connectionID string) string {
+	return provider + ":secure:" + connectionID
+}
+
+// AddAccount adds a registrar client built from one set of stored provider credentials.
+// Domains it syncs are tagged with the credentials' ID.
+func (s *SyncService) AddAccount(creds types.ProviderCredentials) error {
+	providerCreds := make(providers.ProviderCredentials, len(creds.Credentials))
+	for key, value := range creds.Credentials {
+		providerCreds[key] = value
+	}
+	client, err := providers.NewClient(creds.Provider, providerCreds)
+	if err != nil {
+		return fmt.Errorf("failed to create %s client for account %s: %w", creds.Provider, creds.Name, err)
+	}
+
+	name := AccountSyncName(creds.Provider, creds.ID)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exists := s.accounts[name]; !exists {
+		log.Printf("Added provider account: %s (%s)", creds.Name, name)
+	}
+	s.providers[name] = client
+	s.accounts[name] = syncAccount{provider: creds.Provider, credentialID: creds.ID, accountName: creds.AccountName}
+	return nil
+}
+
+// LoadAccounts adds a sync target for every enabled set of stored provider credentials and
+// drops targets whose credentials were disabled or deleted. Credentials that can't build a
+// client are logged and skipped. It returns how many accounts are now synced.
+func (s *SyncService) LoadAccounts() (int, error) {
+	all, err := s.repo.GetAllCredentials()
+	if err != nil {
+		return 0, fmt.Errorf("failed to load provider credentials: %w", err)
+	}
+
+	wanted := make(map[string]bool, len(all))
+	for _, creds := range all {
+		if !creds.Enabled {
+			continue
+		}
+		if err := s.AddAccount(creds); err != nil {
+			log.Printf("Skipping provider account: %v", err)
+			continue
+		}
+		wanted[AccountSyncName(creds.Provider, creds.ID)] = true
+	}
+
+	s.mu.RLock()
+	var stale []string
+	for name := range s.accounts {
+		if !wanted[name] {
+			stale = append(stale, name)
+		}
+	}
+	s.mu.RUnlock()
+	for _, name := range stale {
+		s.RemoveProvider(name)
+	}
+	return len(wanted), nil
+}
+
+// syncTargets resolves a name to the sync targets it covers: the target of that name, or
+// every account of a provider when the name is a provider with accounts. Callers hold s.mu.
+func (s *SyncService) syncTargets(name string) []string {
+	if _, exists := s.providers[name]; exists {
+		return []string{name}
+	}
+	var targets []string
+	for target, account := range s.accounts {
+		if account.provider == name {
+			targets = append(targets, target)
+		}
+	}
+	sort.Strings(targets)
+	return targets
+}
+
+// domainTarget returns the sync target a stored domain belongs to: its account when it was
+// synced from stored credentials that are still configured, otherwise its provider. Callers
+// hold s.mu.
+func (s *SyncService) domainTarget(domain types.Domain) string {
+	if domain.CredentialID != nil {
+		name := AccountSyncName(domain.Provider, *domain.CredentialID)
+		if _, exists := s.providers[name]; exists {
+			return name
+		}
+	}
+	return domain.Provider
+}
+
+// tagAccount records on fetched domains which stored credentials they were synced from
+func tagAccount(domains []types.Domain, credentialID string) {
+	if credentialID == "" {
+		return
+	}
+	for i := range domains {
+		id := credentialID
+		domains[i].CredentialID = &id
+	}
+}
+
+// domainCredential returns the credential ID a domain was synced from, or "" if none
+func domainCredential(domain types.Domain) string {
+	if domain.CredentialID == nil {
+		return ""
+	}
+	return *domain.CredentialID
+}
@@ -0,0 +1,130 @@
+package core
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/rusiqe/domainvault/internal/storage"
+	"github.com/rusiqe/domainvault/internal/types"
+)
+
+// categorizer applies the enabled categorization rules, with the DNS record types they test
+// for loaded once up front
+type categorizer struct {
+	rules   []types.CategorizationRule
+	records map[string]map[string]bool // Record type -> IDs of domains with such a record
+}
+
+// loadCategorizer loads the enabled rules and the records they need. It returns nil when
+// there are no enabled rules.
+func loadCategorizer(repo storage.DomainRepository) (*categorizer, error) {
+	all, err := repo.GetCategorizationRules()
+	if err != nil {
+		return nil, err
+	}
+
+	c := &categorizer{records: make(map[string]map[string]bool)}
+	for _, rule := range all {
+		if !rule.Enabled {
+			continue
+		}
+		c.rules = append(c.rules, rule)
+		if rule.HasRecordType == "" || c.records[rule.HasRecordType] != nil {
+			continue
+		}
+		records, err := repo.GetRecordsByType(rule.HasRecordType)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load %s records for categorization: %w", rule.HasRecordType, err)
+		}
+		ids := make(map[string]bool, len(records))
+		for _, record := range records {
+			ids[record.DomainID] = true
+		}
+		c.records[rule.HasRecordType] = ids
+	}
+	if len(c.rules) == 0 {
+		return nil, nil
+	}
+	return c, nil
+}
+
+// apply runs the rules against a domain. storedID is the ID the domain's DNS records are
+// stored under, which differs from domain.ID for a freshly fetched copy of a stored domain.
+func (c *categorizer) apply(domain *types.Domain, storedID string, overwrite bool) *types.CategorizationChange {
+	hasRecord := func(recordType string) bool {
+		return c.records[recordType][storedID]
+	}
+	return types.ApplyCategorizationRules(c.rules, domain, hasRecord, overwrite)
+}
+
+// CategorizeDomains applies the categorization rules to domains about to be stored by an
+// import or sync, and returns how many it changed. stored holds the portfolio keyed by
+// normalized name; a domain found there keeps its stored category and tags, so rules only
+// fill in a missing category and add tags. Pass nil when domains already carry their stored
+// values. The stored values are carried over even when the rules can't be loaded.
+func CategorizeDomains(repo storage.DomainRepository, domains []types.Domain, stored map[string]types.Domain) (int, error) {
+	storedIDs := make([]string, len(domains))
+	for i := range domains {
+		storedIDs[i] = domains[i].ID
+		if previous, ok := stored[types.NormalizeDomainName(domains[i].Name)]; ok {
+			storedIDs[i] = previous.ID
+			if domains[i].CategoryID == nil {
+				domains[i].CategoryID = previous.CategoryID
+			}
+			if domains[i].Tags == nil {
+				domains[i].Tags = previous.Tags
+			}
+		}
+	}
+
+	c, err := loadCategorizer(repo)
+	if err != nil || c == nil {
+		return 0, err
+	}
+	changed := 0
+	for i := range domains {
+		if c.apply(&domains[i], storedIDs[i], false) != nil {
+			changed++
+		}
+	}
+	return changed, nil
+}
+
+// Recategorize re-applies the categorization rules to every stored domain, including hidden
+// ones, and returns the changes. With dryRun nothing is written. With overwrite a rule's
+// category replaces one a domain already has.
+func Recategorize(repo storage.DomainRepository, dryRun, overwrite bool) ([]types.CategorizationChange, error) {
+	c, err := loadCategorizer(repo)
+	if err != nil {
+		return nil, err
+	}
+	changes := []types.CategorizationChange{}
+	if c == nil {
+		return changes, nil
+	}
+
+	domains, err := repo.GetByFilter(types.DomainFilter{IncludeHidden: true, SortBy: "name", SortOrder: types.SortAsc})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load domains: %w", err)
+	}
+
+	failed := 0
+	for i := range domains {
+		change := c.apply(&domains[i], domains[i].ID, overwrite)
+		if change == nil {
+			continue
+		}
+		if !dryRun {
+			if err := repo.Update(&domains[i]); err != nil {
+				log.Printf("Failed to recategorize %s: %v", domains[i].Name, err)
+				failed++
+				continue
+			}
+		}
+		changes = append(changes, *change)
+	}
+	if failed > 0 {
+		return changes, fmt.Errorf("failed to update %d of %d recategorized domains", failed, failed+len(changes))
+	}
+	return changes, nil
+}
@@ -0,0 +1,97 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+
+	"github.com/rusiqe/domainvault/internal/dns"
+	"github.com/rusiqe/domainvault/internal/notifications"
+	"github.com/rusiqe/domainvault/internal/storage"
+	"github.com/rusiqe/domainvault/internal/types"
+)
+
+// DanglingDNSMonitor checks the portfolio's stored CNAME and A records for targets that no
+// longer exist, which can let someone else take over the subdomain, and alerts on new ones
+type DanglingDNSMonitor struct {
+	repo     storage.DomainRepository
+	checker  *dns.DanglingChecker
+	notifier *notifications.NotificationService
+	mu       sync.RWMutex // Protects notifier, last and alerted
+	last     *types.DanglingDNSReport
+	alerted  map[string]bool // Keys of findings already alerted on; forgotten once fixed
+}
+
+// NewDanglingDNSMonitor creates a monitor that checks records with checker
+func NewDanglingDNSMonitor(repo storage.DomainRepository, checker *dns.DanglingChecker) *DanglingDNSMonitor {
+	return &DanglingDNSMonitor{repo: repo, checker: checker, alerted: map[string]bool{}}
+}
+
+// SetNotificationService sets where dangling DNS alerts are sent
+func (m *DanglingDNSMonitor) SetNotificationService(ns *notifications.NotificationService) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.notifier = ns
+}
+
+// LastReport returns the report of the most recent scan, or nil before the first
+func (m *DanglingDNSMonitor) LastReport() *types.DanglingDNSReport {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.last
+}
+
+// Scan checks the records of every domain, hidden ones included, and alerts on takeover
+// risks and unresolved targets not seen by the previous scan. Unreachable addresses are only
+// reported, since a host may serve nothing on the web ports on purpose.
+func (m *DanglingDNSMonitor) Scan(ctx context.Context) (*types.DanglingDNSReport, error) {
+	domains, err := m.repo.GetByFilter(types.DomainFilter{IncludeHidden: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load domains: %w", err)
+	}
+	records := make(map[string][]types.DNSRecord)
+	for _, recordType := range []string{"CNAME", "ALIAS", "A"} {
+		byType, err := m.repo.GetRecordsByType(recordType)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load %s records: %w", recordType, err)
+		}
+		for _, record := range byType {
+			records[record.DomainID] = append(records[record.DomainID], record)
+		}
+	}
+
+	report := m.checker.Check(ctx, domains, records)
+	if err := ctx.Err(); err != nil {
+		return nil, err // A partial scan would forget findings it didn't get to
+	}
+
+	m.mu.Lock()
+	notifier := m.notifier
+	current := make(map[string]bool, len(report.Findings))
+	var fresh []types.DanglingDNSFinding
+	for _, finding := range report.Findings {
+		if finding.Risk == types.DanglingUnreachable {
+			continue
+		}
+		key := finding.Key()
+		current[key] = true
+		if !m.alerted[key] {
+			fresh = append(fresh, finding)
+		}
+	}
+	m.alerted = current
+	m.last = report
+	m.mu.Unlock()
+
+	for _, finding := range fresh {
+		log.Printf("SECURITY ALERT: dangling %s record %s -> %s (%s): %s", finding.Type, finding.Host, finding.Target, finding.Risk, finding.Reason)
+		if notifier == nil {
+			continue
+		}
+		if err := notifier.Notify(notifier.CreateDanglingDNSAlert(finding)); err != nil {
+			log.Printf("Failed to send dangling DNS alert for %s: %v", finding.Host, err)
+		}
+	}
+	return report, nil
+}
@@ -0,0 +1,127 @@
+package core
+
+import (
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/rusiqe/domainvault/internal/types"
+)
+
+// DefaultDNSSyncWorkers is how many domains a sync fetches DNS records for at once when not
+// configured
+const DefaultDNSSyncWorkers = 5
+
+// DNSSyncReport counts the domains whose DNS records a sync fetched into the local store
+type DNSSyncReport struct {
+	Synced   int              `json:"synced"`
+	Failed   int              `json:"failed"`
+	Failures []DNSSyncFailure `json:"failures"`
+}
+
+// DNSSyncFailure is a domain whose DNS records couldn't be synced
+type DNSSyncFailure struct {
+	Domain string `json:"domain"`
+	Error  string `json:"error"`
+}
+
+// SetDNSSync turns fetching each synced domain's DNS records during domain syncs on or off.
+// workers caps how many domains are fetched at once, 0 for DefaultDNSSyncWorkers. It costs
+// one DNS request per domain but keeps the stored records fresh for domain details.
+func (s *SyncService) SetDNSSync(enabled bool, workers int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.dnsSync = enabled
+	s.dnsSyncWorkers = workers
+}
+
+// dnsSyncEnabled reports whether domain syncs also fetch DNS records
+func (s *SyncService) dnsSyncEnabled() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.dnsSync && s.dnsService != nil
+}
+
+// syncFetchedDNS syncs the DNS records of domains just fetched from providers. The fetched
+// copies may be new and without IDs, so each is looked up among the stored domains first.
+func (s *SyncService) syncFetchedDNS(fetched []types.Domain) *DNSSyncReport {
+	stored := s.storedDomains()
+	if stored == nil {
+		log.Printf("Skipping DNS sync: stored domains couldn't be loaded")
+		return nil
+	}
+
+	domains := make([]types.Domain, 0, len(fetched))
+	seen := make(map[string]bool, len(fetched))
+	for _, domain := range fetched {
+		name := types.NormalizeDomainName(domain.Name)
+		if stored, ok := stored[name]; ok && !seen[name] {
+			seen[name] = true
+			domains = append(domains, stored)
+		}
+	}
+	return s.syncDomainsDNS(domains)
+}
+
+// syncDomainsDNS syncs each domain's DNS records with a pool of workers and records each
+// domain's outcome, so a failure for one domain doesn't hold up the others
+func (s *SyncService) syncDomainsDNS(domains []types.Domain) *DNSSyncReport {
+	s.mu.RLock()
+	workers := s.dnsSyncWorkers
+	s.mu.RUnlock()
+	if workers <= 0 {
+		workers = DefaultDNSSyncWorkers
+	}
+
+	report := &DNSSyncReport{Failures: []DNSSyncFailure{}}
+	var mu sync.Mutex // Protects report
+	jobs := make(chan types.Domain)
+	var wg sync.WaitGroup
+	for w := 0; w < workers && w < len(domains); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for domain := range jobs {
+				count, err := s.syncDomainDNS(domain)
+				s.recordDNSSync(domain, count, err)
+
+				mu.Lock()
+				if err != nil {
+					report.Failed++
+					report.Failures = append(report.Failures, DNSSyncFailure{Domain: domain.Name, Error: err.Error()})
+				} else {
+					report.Synced++
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	for _, domain := range domains {
+		jobs <- domain
+	}
+	close(jobs)
+	wg.Wait()
+
+	sort.Slice(report.Failures, func(i, j int) bool { return report.Failures[i].Domain < report.Failures[j].Domain })
+	log.Printf("Completed DNS sync for %d domains: %d synced, %d failed", len(domains), report.Synced, report.Failed)
+	return report
+}
+
+// recordDNSSync stores the outcome of syncing a domain's DNS records. Failures to store it
+// are logged; the sync itself already happened.
+func (s *SyncService) recordDNSSync(domain types.Domain, records int, syncErr error) {
+	result := &types.DNSSyncResult{
+		DomainID:    domain.ID,
+		Provider:    domain.Provider,
+		Success:     syncErr == nil,
+		RecordCount: records,
+		SyncedAt:    time.Now(),
+	}
+	if syncErr != nil {
+		result.Error = syncErr.Error()
+	}
+	if err := s.repo.SaveDNSSyncResult(result); err != nil {
+		log.Printf("Failed to record DNS sync of %s: %v", domain.Name, err)
+	}
+}
@@ -0,0 +1,150 @@
+package core
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/rusiqe/domainvault/internal/providers"
+	"github.com/rusiqe/domainvault/internal/storage"
+	"github.com/rusiqe/domainvault/internal/types"
+)
+
+// DefaultExpiryReconcilePercent is the share of the portfolio checked against the registry per run
+const DefaultExpiryReconcilePercent = 10
+
+// expiryLookupPause spaces out registry lookups so a run stays under RDAP and WHOIS rate limits
+const expiryLookupPause = time.Second
+
+// ExpiryReconciler checks the expiry providers report against the domain's registry, a share
+// of the portfolio at a time, and flags domains where they disagree
+type ExpiryReconciler struct {
+	repo    storage.DomainRepository
+	sources []providers.ExpiryLookup // Tried in order until one answers
+	percent int
+	pause   time.Duration
+}
+
+// ExpiryReconcileSummary counts the outcome of one reconciliation run
+type ExpiryReconcileSummary struct {
+	Checked       int `json:"checked"`
+	Discrepancies int `json:"discrepancies"`
+	Failed        int `json:"failed"`
+	Portfolio     int `json:"portfolio"`
+}
+
+// NewExpiryReconciler creates a reconciler that looks expiries up through sources, in order;
+// a source that fails passes the lookup to the next one
+func NewExpiryReconciler(repo storage.DomainRepository, sources ...providers.ExpiryLookup) *ExpiryReconciler {
+	return &ExpiryReconciler{repo: repo, sources: sources, percent: DefaultExpiryReconcilePercent, pause: expiryLookupPause}
+}
+
+// SetPercent sets the share of the portfolio each run checks, from 1 to 100
+func (e *ExpiryReconciler) SetPercent(percent int) {
+	if percent > 0 && percent <= 100 {
+		e.percent = percent
+	}
+}
+
+// Lookup asks each source in turn for a domain's registry expiry
+func (e *ExpiryReconciler) Lookup(domain string) (*types.RegistryExpiry, error) {
+	lastErr := fmt.Errorf("no registry expiry sources configured")
+	for _, source := range e.sources {
+		expiry, err := source.LookupExpiry(domain)
+		if err == nil {
+			return expiry, nil
+		}
+		lastErr = err
+	}
+	return nil, lastErr
+}
+
+// Check looks up one domain's registry expiry, compares it with the stored one and saves the
+// result. A failed lookup keeps the registry expiry found by the last successful one. A
+// registration date in the registry's answer replaces the domain's, since registrars often
+// report the date a domain was transferred in instead.
+func (e *ExpiryReconciler) Check(domain types.Domain, previous *types.RegistryExpiry) (*types.RegistryExpiry, error) {
+	result := &types.RegistryExpiry{DomainID: domain.ID, DomainName: domain.Name, CheckedAt: time.Now()}
+	found, lookupErr := e.Lookup(domain.Name)
+	switch {
+	case lookupErr == nil:
+		result.RegistryExpiresAt = found.RegistryExpiresAt
+		result.RegisteredAt = found.RegisteredAt
+		result.Source = found.Source
+	case previous != nil:
+		result.RegistryExpiresAt = previous.RegistryExpiresAt
+		result.Source = previous.Source
+		result.LastError = lookupErr.Error()
+	default:
+		result.LastError = lookupErr.Error()
+	}
+	result.Compare(domain.ExpiresAt)
+
+	if err := e.repo.SaveRegistryExpiry(result); err != nil {
+		return nil, err
+	}
+	if registered := result.RegisteredAt; registered != nil && (domain.RegisteredAt == nil || !domain.RegisteredAt.Equal(*registered)) {
+		if err := e.repo.SetDomainRegisteredAt(domain.ID, *registered); err != nil {
+			log.Printf("Failed to store registration date of %s: %v", domain.Name, err)
+		}
+	}
+	if lookupErr != nil {
+		return result, fmt.Errorf("registry expiry lookup for %s failed: %w", domain.Name, lookupErr)
+	}
+	return result, nil
+}
+
+// Run checks the configured share of the portfolio, domains never checked first and then the
+// least recently checked, so the whole portfolio is covered every 100/percent runs. A failed
+// lookup is logged and counted; the rest still run.
+func (e *ExpiryReconciler) Run() (ExpiryReconcileSummary, error) {
+	var summary ExpiryReconcileSummary
+	domains, err := e.repo.GetAll()
+	if err != nil {
+		return summary, fmt.Errorf("failed to list domains: %w", err)
+	}
+	checked, err := e.repo.GetRegistryExpiries()
+	if err != nil {
+		return summary, fmt.Errorf("failed to load registry expiries: %w", err)
+	}
+	previous := make(map[string]*types.RegistryExpiry, len(checked))
+	for i := range checked {
+		previous[checked[i].DomainID] = &checked[i]
+	}
+
+	sort.SliceStable(domains, func(i, j int) bool {
+		a, b := previous[domains[i].ID], previous[domains[j].ID]
+		switch {
+		case a == nil || b == nil:
+			return a == nil && b != nil
+		case !a.CheckedAt.Equal(b.CheckedAt):
+			return a.CheckedAt.Before(b.CheckedAt)
+		}
+		return domains[i].Name < domains[j].Name
+	})
+	summary.Portfolio = len(domains)
+	batch := int(math.Ceil(float64(len(domains)) * float64(e.percent) / 100))
+	if batch > len(domains) {
+		batch = len(domains)
+	}
+
+	for i, domain := range domains[:batch] {
+		if i > 0 && e.pause > 0 {
+			time.Sleep(e.pause)
+		}
+		result, err := e.Check(domain, previous[domain.ID])
+		summary.Checked++
+		if err != nil {
+			log.Printf("Expiry reconcile: %v", err)
+			summary.Failed++
+		}
+		if result != nil && result.Discrepancy {
+			summary.Discrepancies++
+			log.Printf("Expiry discrepancy: %s expires %s at the registry (%s) but %s is stored",
+				domain.Name, result.RegistryExpiresAt.Format("2006-01-02"), result.Source, domain.ExpiresAt.Format("2006-01-02"))
+		}
+	}
+	return summary, nil
+}
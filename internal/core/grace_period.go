@@ -0,0 +1,65 @@
+package core
+
+import (
+	"log"
+	"time"
+
+	"github.com/rusiqe/domainvault/internal/notifications"
+	"github.com/rusiqe/domainvault/internal/types"
+)
+
+// flagGracePeriod sets types.DomainStatusGracePeriod on fetched domains that have expired but
+// are still active at their provider, before they're stored. It logs and returns the domains
+// that weren't already flagged, with their stored IDs, so they can be alerted on once; with
+// no stored state to compare against nothing is returned.
+func flagGracePeriod(domains []types.Domain, stored map[string]types.Domain) []types.Domain {
+	now := time.Now()
+	var entered []types.Domain
+	for i := range domains {
+		if !types.InGracePeriod(domains[i], now) {
+			continue
+		}
+		domains[i].Status = types.DomainStatusGracePeriod
+
+		previous, known := stored[types.NormalizeDomainName(domains[i].Name)]
+		if known && previous.Status == types.DomainStatusGracePeriod {
+			continue
+		}
+		log.Printf("URGENT: %s expired on %s but is still held by %s; renew it before it is released",
+			domains[i].Name, domains[i].ExpiresAt.Format("2006-01-02"), domains[i].Provider)
+		if stored == nil {
+			continue
+		}
+		domain := domains[i]
+		domain.ID = previous.ID
+		entered = append(entered, domain)
+	}
+	return entered
+}
+
+// notifyGracePeriod sends a critical alert for each domain that entered its grace period,
+// unless grace period alerts are turned off
+func (s *SyncService) notifyGracePeriod(notifier *notifications.NotificationService, entered []types.Domain) {
+	if notifier == nil || !s.gracePeriodAlertsEnabled() {
+		return
+	}
+	for _, domain := range entered {
+		if err := notifier.Notify(notifier.CreateGracePeriodAlert(domain)); err != nil {
+			log.Printf("Failed to send grace period alert for %s: %v", domain.Name, err)
+		}
+	}
+}
+
+// SetGracePeriodAlerts turns the dedicated alert for domains entering their grace period on or
+// off. Turned off, the change to grace_period is alerted as an ordinary status transition.
+func (s *SyncService) SetGracePeriodAlerts(enabled bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.gracePeriodAlerts = enabled
+}
+
+func (s *SyncService) gracePeriodAlertsEnabled() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.gracePeriodAlerts
+}
@@ -0,0 +1,155 @@
+package core
+
+import (
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/rusiqe/domainvault/internal/notifications"
+	"github.com/rusiqe/domainvault/internal/security"
+	"github.com/rusiqe/domainvault/internal/storage"
+	"github.com/rusiqe/domainvault/internal/types"
+)
+
+// SettingsService keeps the runtime settings in the settings store and applies them to the
+// services they configure, so changes take effect without a restart
+type SettingsService struct {
+	repo     storage.DomainRepository
+	defaults types.RuntimeSettings // From the environment, used until settings are saved
+	sync     *SyncService
+	notifier *notifications.NotificationService
+	security *security.SecurityService
+	mu       sync.Mutex // Serializes updates so services see them in the order they were saved
+
+	maintenance   types.MaintenanceMode // Read on every request, so kept in memory
+	maintenanceMu sync.RWMutex
+}
+
+// NewSettingsService creates a settings service. defaults are the values configured through
+// the environment. Any of the services may be nil; its settings are then stored but not applied.
+func NewSettingsService(repo storage.DomainRepository, defaults types.RuntimeSettings, syncSvc *SyncService,
+	notifier *notifications.NotificationService, securitySvc *security.SecurityService) *SettingsService {
+	return &SettingsService{
+		repo:     repo,
+		defaults: defaults,
+		sync:     syncSvc,
+		notifier: notifier,
+		security: securitySvc,
+	}
+}
+
+// Defaults returns the settings configured through the environment
+func (s *SettingsService) Defaults() types.RuntimeSettings {
+	return s.defaults
+}
+
+// Get returns the saved settings, or the defaults if none were saved or the settings store
+// can't be read
+func (s *SettingsService) Get() types.RuntimeSettings {
+	var settings types.RuntimeSettings
+	err := s.repo.GetSetting(types.SettingRuntime, &settings)
+	if err == nil {
+		if err = settings.Validate(); err == nil {
+			return settings
+		}
+	}
+	if !errors.Is(err, types.ErrSettingNotFound) {
+		log.Printf("Using runtime settings from the environment: %v", err)
+	}
+	return s.defaults
+}
+
+// Apply pushes the current settings to the services, e.g. at startup, and restores a
+// maintenance mode that was on when the server stopped
+func (s *SettingsService) Apply() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.apply(s.Get())
+
+	var mode types.MaintenanceMode
+	if err := s.repo.GetSetting(types.SettingMaintenance, &mode); err != nil {
+		if !errors.Is(err, types.ErrSettingNotFound) {
+			log.Printf("Failed to load maintenance mode: %v", err)
+		}
+		return
+	}
+	s.applyMaintenance(mode)
+	if mode.Enabled {
+		log.Printf("Maintenance mode is on: changes are rejected and background syncs are paused")
+	}
+}
+
+// Maintenance returns the current maintenance mode
+func (s *SettingsService) Maintenance() types.MaintenanceMode {
+	s.maintenanceMu.RLock()
+	defer s.maintenanceMu.RUnlock()
+	return s.maintenance
+}
+
+// SetMaintenance turns maintenance mode on or off for actor, saves it so it survives a
+// restart and pauses or resumes background syncs. It returns the mode it replaced.
+func (s *SettingsService) SetMaintenance(mode types.MaintenanceMode, actor string) (types.MaintenanceMode, error) {
+	if err := mode.Validate(); err != nil {
+		return types.MaintenanceMode{}, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	previous := s.Maintenance()
+	switch {
+	case !mode.Enabled:
+		mode = types.MaintenanceMode{}
+	case previous.Enabled:
+		mode.EnabledAt, mode.EnabledBy = previous.EnabledAt, previous.EnabledBy // Still the same maintenance window
+	default:
+		now := time.Now()
+		mode.EnabledAt, mode.EnabledBy = &now, actor
+	}
+	if err := s.repo.SaveSetting(types.SettingMaintenance, mode); err != nil {
+		return types.MaintenanceMode{}, err
+	}
+	s.applyMaintenance(mode)
+	return previous, nil
+}
+
+// applyMaintenance makes mode the current maintenance mode and pauses syncs to match
+func (s *SettingsService) applyMaintenance(mode types.MaintenanceMode) {
+	s.maintenanceMu.Lock()
+	s.maintenance = mode
+	s.maintenanceMu.Unlock()
+	if s.sync != nil {
+		s.sync.SetPaused(mode.Enabled)
+	}
+}
+
+// Update validates and saves settings, then applies them. It returns the settings they replaced.
+func (s *SettingsService) Update(settings types.RuntimeSettings) (types.RuntimeSettings, error) {
+	if err := settings.Validate(); err != nil {
+		return types.RuntimeSettings{}, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	previous := s.Get()
+	if err := s.repo.SaveSetting(types.SettingRuntime, settings); err != nil {
+		return types.RuntimeSettings{}, err
+	}
+	s.apply(settings)
+	return previous, nil
+}
+
+// apply configures each service from settings
+func (s *SettingsService) apply(settings types.RuntimeSettings) {
+	if s.sync != nil {
+		s.sync.SetSyncInterval(time.Duration(settings.SyncIntervalMinutes) * time.Minute)
+		s.sync.SetRegistrarStatusSync(settings.SyncRegistrarStatus)
+	}
+	if s.notifier != nil {
+		channels := settings.Notifications
+		s.notifier.SetChannelsEnabled(channels.Email, channels.Slack, channels.Webhook)
+	}
+	if s.security != nil {
+		s.security.SetLockoutPolicy(settings.Security.MaxLoginAttempts, time.Duration(settings.Security.LockoutMinutes)*time.Minute)
+	}
+}
@@ -1,29 +1,83 @@
 package core
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log"
+	"sort"
 	"sync"
+	"time"
 
 	"github.com/rusiqe/domainvault/internal/dns"
+	"github.com/rusiqe/domainvault/internal/notifications"
 	"github.com/rusiqe/domainvault/internal/providers"
 	"github.com/rusiqe/domainvault/internal/storage"
 	"github.com/rusiqe/domainvault/internal/types"
+	"github.com/rusiqe/domainvault/internal/uptimerobot"
 )
 
 // SyncService manages domain synchronization across multiple providers
 type SyncService struct {
 	providers map[string]providers.RegistrarClient
+	accounts  map[string]syncAccount // Targets added from stored credentials, by sync name
 	repo      storage.DomainRepository
 	dnsService *dns.DNSService
-	mu        sync.RWMutex // Protects providers map
+	uptimeRobot *uptimerobot.Service
+	notifier    *notifications.NotificationService
+	registrarStatus bool // Read each domain's registrar status during sync
+	dnsSync         bool // Fetch each synced domain's DNS records during sync
+	dnsSyncWorkers  int  // Domains whose DNS records are fetched at once
+	priceAlertPercent float64 // Renewal price rise, in percent, that raises an alert
+	gracePeriodAlerts bool    // Send a dedicated critical alert when a domain enters its grace period
+	interval        time.Duration      // How often the scheduler runs a sync
+	intervalUpdates chan time.Duration // Interval changes for the scheduler, latest only
+	mu        sync.RWMutex // Protects providers map, uptimeRobot, notifier, registrarStatus, dnsSync, dnsSyncWorkers, priceAlertPercent, gracePeriodAlerts and interval
+
+	// In-flight operation tracking for graceful shutdown
+	inflight sync.WaitGroup
+	opMu     sync.Mutex // Protects draining and paused
+	draining bool
+	paused   bool // Maintenance mode: no new operations start
+
+	// Per-provider run state reported by GetStatus
+	states     map[string]*providerState
+	lastReport *SyncReport
+	breakers   map[string]*providers.CircuitBreaker // Guard each target's domain fetch, created on first use
+	breakerConfig providers.BreakerConfig
+	stateMu    sync.Mutex // Protects states, lastReport, breakers and breakerConfig
+}
+
+// Provider sync states reported by GetStatus
+const (
+	SyncStateIdle    = "idle"
+	SyncStateRunning = "running"
+	SyncStateError   = "error"
+)
+
+// providerState records the progress and outcome of a provider's most recent sync
+type providerState struct {
+	state            string
+	startedAt        *time.Time
+	finishedAt       *time.Time
+	lastSuccess      *time.Time
+	domainsProcessed int
+	lastError        string
+	skippedRuns      int        // Runs skipped because this one was still going
+	lastSkipped      *time.Time
 }
 
 // NewSyncService creates a new sync service
 func NewSyncService(repo storage.DomainRepository) *SyncService {
 	return &SyncService{
 		providers: make(map[string]providers.RegistrarClient),
+		accounts:  make(map[string]syncAccount),
 		repo:      repo,
+		states:    make(map[string]*providerState),
+		breakers:  make(map[string]*providers.CircuitBreaker),
+		intervalUpdates: make(chan time.Duration, 1),
+		priceAlertPercent: types.DefaultRenewalPriceAlertPercent,
+		gracePeriodAlerts: true,
 	}
 }
 
@@ -32,6 +86,66 @@ func (s *SyncService) SetDNSService(dnsService *dns.DNSService) {
 	s.dnsService = dnsService
 }
 
+// SetUptimeRobotService sets the monitoring service used to auto-create monitors for new domains
+func (s *SyncService) SetUptimeRobotService(svc *uptimerobot.Service) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.uptimeRobot = svc
+}
+
+// SetNotificationService sets the service that receives domain status transition alerts
+func (s *SyncService) SetNotificationService(ns *notifications.NotificationService) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.notifier = ns
+}
+
+// SetRegistrarStatusSync turns reading each domain's registrar status during sync on or off.
+// It costs one request per domain to registrars that support it.
+func (s *SyncService) SetRegistrarStatusSync(enabled bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.registrarStatus = enabled
+}
+
+// SetRenewalPriceAlertPercent sets how far, in percent, a domain's renewal price must rise
+// between syncs before an alert is sent. Every change is recorded in the price history.
+func (s *SyncService) SetRenewalPriceAlertPercent(percent float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.priceAlertPercent = percent
+}
+
+// SetSyncInterval changes how often the scheduler runs a sync. The scheduler receives the new
+// interval from IntervalUpdates, so it applies without a restart.
+func (s *SyncService) SetSyncInterval(interval time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if interval == s.interval {
+		return
+	}
+	s.interval = interval
+
+	// Replace any change the scheduler hasn't picked up yet
+	select {
+	case <-s.intervalUpdates:
+	default:
+	}
+	s.intervalUpdates <- interval
+}
+
+// SyncInterval returns how often the scheduler runs a sync, 0 if it was never set
+func (s *SyncService) SyncInterval() time.Duration {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.interval
+}
+
+// IntervalUpdates delivers sync interval changes to the scheduler
+func (s *SyncService) IntervalUpdates() <-chan time.Duration {
+	return s.intervalUpdates
+}
+
 // AddProvider adds a registrar client to the sync service
 func (s *SyncService) AddProvider(name string, client providers.RegistrarClient) {
 	s.mu.Lock()
@@ -45,9 +159,53 @@ func (s *SyncService) RemoveProvider(name string) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	delete(s.providers, name)
+	delete(s.accounts, name)
+
+	s.stateMu.Lock()
+	delete(s.states, name)
+	delete(s.breakers, name)
+	s.stateMu.Unlock()
 	log.Printf("Removed provider: %s", name)
 }
 
+// SetCircuitBreaker sets how the circuit breakers guarding each target's domain fetch behave,
+// resetting any existing breakers
+func (s *SyncService) SetCircuitBreaker(config providers.BreakerConfig) {
+	s.stateMu.Lock()
+	defer s.stateMu.Unlock()
+	s.breakerConfig = config
+	s.breakers = make(map[string]*providers.CircuitBreaker)
+}
+
+// breaker returns a sync target's circuit breaker, creating it on first use
+func (s *SyncService) breaker(name string) *providers.CircuitBreaker {
+	s.stateMu.Lock()
+	defer s.stateMu.Unlock()
+	b, ok := s.breakers[name]
+	if !ok {
+		b = providers.NewCircuitBreaker(s.breakerConfig)
+		s.breakers[name] = b
+	}
+	return b
+}
+
+// fetchDomains fetches a sync target's domains through its circuit breaker, so a provider
+// that keeps failing or hanging is skipped for the cooldown rather than tying up every run
+func (s *SyncService) fetchDomains(name string, client providers.RegistrarClient) ([]types.Domain, error) {
+	var domains []types.Domain
+	err := s.breaker(name).Call(func() error {
+		fetched, err := client.FetchDomains()
+		if err == nil {
+			domains = fetched
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return domains, nil
+}
+
 // GetProviders returns a list of configured provider names
 func (s *SyncService) GetProviders() []string {
 	s.mu.RLock()
@@ -60,8 +218,125 @@ func (s *SyncService) GetProviders() []string {
 	return names
 }
 
+// tryStartSync marks a provider sync as running, unless one already is. A run that overlaps
+// one still in progress, e.g. when a sync takes longer than the interval, would repeat its
+// API calls and upserts, so it's skipped and counted instead; it returns false then.
+func (s *SyncService) tryStartSync(name string) bool {
+	s.stateMu.Lock()
+	defer s.stateMu.Unlock()
+
+	st, ok := s.states[name]
+	if !ok {
+		st = &providerState{}
+		s.states[name] = st
+	}
+	now := time.Now()
+	if st.state == SyncStateRunning {
+		st.skippedRuns++
+		st.lastSkipped = &now
+		log.Printf("Sync of %s skipped, already running since %s", name, st.startedAt.Format(time.RFC3339))
+		return false
+	}
+	st.state = SyncStateRunning
+	st.startedAt = &now
+	st.finishedAt = nil
+	st.domainsProcessed = 0
+	return true
+}
+
+// markSyncFinished records the outcome of a provider sync started with tryStartSync
+func (s *SyncService) markSyncFinished(name string, domainsProcessed int, err error) {
+	s.stateMu.Lock()
+	defer s.stateMu.Unlock()
+
+	st, ok := s.states[name]
+	if !ok {
+		st = &providerState{}
+		s.states[name] = st
+	}
+	now := time.Now()
+	st.finishedAt = &now
+	st.domainsProcessed = domainsProcessed
+	if err != nil {
+		st.state = SyncStateError
+		st.lastError = err.Error()
+		return
+	}
+	st.state = SyncStateIdle
+	st.lastError = ""
+	st.lastSuccess = &now
+}
+
+// beginOperation registers an in-flight operation so Drain can wait for it. It fails once
+// draining has started, or while paused for maintenance, and no new work should begin.
+func (s *SyncService) beginOperation() error {
+	s.opMu.Lock()
+	defer s.opMu.Unlock()
+	if s.draining {
+		return types.ErrShuttingDown
+	}
+	if s.paused {
+		return types.ErrMaintenanceMode
+	}
+	s.inflight.Add(1)
+	return nil
+}
+
+// SetPaused pauses or resumes syncs and tracked background work, e.g. for maintenance.
+// Operations already running finish; new ones fail with types.ErrMaintenanceMode.
+func (s *SyncService) SetPaused(paused bool) {
+	s.opMu.Lock()
+	defer s.opMu.Unlock()
+	s.paused = paused
+}
+
+// Track runs fn as an in-flight operation that Drain will wait for.
+// Use it for background work outside the sync service (e.g. DNS refreshes) that must not be cut off mid-write.
+func (s *SyncService) Track(fn func() error) error {
+	if err := s.beginOperation(); err != nil {
+		return err
+	}
+	defer s.inflight.Done()
+	return fn()
+}
+
+// Drain stops new operations from starting and waits for running ones to finish,
+// or until ctx is done
+func (s *SyncService) Drain(ctx context.Context) error {
+	s.opMu.Lock()
+	s.draining = true
+	s.opMu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		s.inflight.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("timed out waiting for in-flight sync operations: %w", ctx.Err())
+	}
+}
+
 // Run executes a full synchronization across all providers
 func (s *SyncService) Run() error {
+	if err := s.beginOperation(); err != nil {
+		return err
+	}
+	defer s.inflight.Done()
+	return s.run(s.dnsSyncEnabled())
+}
+
+// run performs the full synchronization, fetching the synced domains' DNS records too when
+// syncDNS is set; callers must already hold an in-flight slot
+func (s *SyncService) run(syncDNS bool) error {
+	if _, err := s.LoadAccounts(); err != nil {
+		log.Printf("Syncing without stored provider accounts: %v", err)
+	}
+
 	s.mu.RLock()
 	providerCount := len(s.providers)
 	s.mu.RUnlock()
@@ -76,34 +351,94 @@ func (s *SyncService) Run() error {
 	// Channel to collect results from all providers
 	results := make(chan SyncResult, providerCount)
 
-	// Start goroutines for each provider
+	// Start goroutines for each provider not still syncing from an earlier run
+	started := 0
 	s.mu.RLock()
 	for name, client := range s.providers {
-		go s.syncProvider(name, client, results)
+		if !s.tryStartSync(name) {
+			continue
+		}
+		started++
+		go s.syncProvider(name, client, s.accounts[name].credentialID, results)
 	}
 	s.mu.RUnlock()
+	if started == 0 {
+		log.Println("Sync skipped, every provider is already syncing")
+		return nil
+	}
 
 	// Collect all domains from all providers
+	startedAt := time.Now()
 	var allDomains []types.Domain
 	var errors []error
+	fetched := make(map[string]int)
+	fetchedDomains := make(map[string][]types.Domain)
+	credentials := make(map[string]string)
 
-	for i := 0; i < providerCount; i++ {
+	for i := 0; i < started; i++ {
 		result := <-results
 		if result.Error != nil {
 			log.Printf("Provider %s sync failed: %v", result.ProviderName, result.Error)
 			errors = append(errors, result.Error)
+			s.markSyncFinished(result.ProviderName, 0, result.Error)
 		} else {
 			log.Printf("Provider %s fetched %d domains", result.ProviderName, len(result.Domains))
 			allDomains = append(allDomains, result.Domains...)
+			fetched[result.ProviderName] = len(result.Domains)
+			fetchedDomains[result.ProviderName] = result.Domains
+			credentials[result.ProviderName] = result.CredentialID
 		}
 	}
 
 	// Store all domains in the database
 	if len(allDomains) > 0 {
-		if err := s.repo.UpsertDomains(allDomains); err != nil {
-			return fmt.Errorf("failed to store domains: %w", err)
+		s.mu.RLock()
+		notifier := s.notifier
+		s.mu.RUnlock()
+
+		stored := s.storedDomains()
+		gracePeriod := flagGracePeriod(allDomains, stored)
+
+		upserted, dnsReport, err := s.storeFetched("the sync", allDomains, stored, syncDNS)
+		if err != nil {
+			storeErr := fmt.Errorf("failed to store domains: %w", err)
+			for name := range fetched {
+				s.markSyncFinished(name, 0, storeErr)
+			}
+			return storeErr
+		}
+
+		if stored != nil {
+			// A domain that moved between providers is missing from one fetch but present in another
+			fetchedNames := make(map[string]bool, len(allDomains))
+			for _, d := range allDomains {
+				fetchedNames[types.NormalizeDomainName(d.Name)] = true
+			}
+
+			report := &SyncReport{StartedAt: startedAt, DNS: dnsReport}
+			changed := allDomains
+			for _, name := range sortedKeys(fetchedDomains) {
+				providerReport := buildProviderReport(name, credentials[name], fetchedDomains[name], stored)
+				missing := providerReport.Missing[:0]
+				for _, domainName := range providerReport.Missing {
+					if !fetchedNames[domainName] {
+						missing = append(missing, domainName)
+					}
+				}
+				providerReport.Missing = missing
+				changed = append(changed, s.flagMissing(providerReport, stored)...)
+				report.Providers = append(report.Providers, providerReport)
+			}
+			report.FinishedAt = time.Now()
+			s.recordReport(report)
+			s.notifyTransitions(notifier, stored, changed)
+			s.notifyGracePeriod(notifier, gracePeriod)
+			s.recordPriceChanges(notifier, stored, allDomains)
 		}
-		log.Printf("Successfully synced %d domains total", len(allDomains))
+		log.Printf("Successfully synced %d domains total: %d new, %d updated", len(allDomains), upserted.Inserted, upserted.Updated)
+	}
+	for name, count := range fetched {
+		s.markSyncFinished(name, count, nil)
 	}
 
 	// Return combined error if any providers failed
@@ -116,140 +451,371 @@ func (s *SyncService) Run() error {
 
 // SyncProvider synchronizes domains from a specific provider
 func (s *SyncService) SyncProvider(providerName string) error {
+	if err := s.beginOperation(); err != nil {
+		return err
+	}
+	defer s.inflight.Done()
+	return s.syncProviderDomains(providerName, s.dnsSyncEnabled())
+}
+
+// syncProviderDomains fetches and stores domains for one sync target, or for every account of a
+// provider when given a provider name; callers must already hold an in-flight slot
+func (s *SyncService) syncProviderDomains(providerName string, syncDNS bool) error {
+	if _, err := s.LoadAccounts(); err != nil {
+		log.Printf("Syncing without stored provider accounts: %v", err)
+	}
+
 	s.mu.RLock()
-	client, exists := s.providers[providerName]
+	targets := s.syncTargets(providerName)
+	clients := make(map[string]providers.RegistrarClient, len(targets))
+	credentials := make(map[string]string, len(targets))
+	for _, target := range targets {
+		clients[target] = s.providers[target]
+		credentials[target] = s.accounts[target].credentialID
+	}
 	s.mu.RUnlock()
 
-	if !exists {
+	if len(targets) == 0 {
 		return fmt.Errorf("provider %s not found", providerName)
 	}
 
+	var failed []error
+	for _, target := range targets {
+		if !s.tryStartSync(target) {
+			failed = append(failed, fmt.Errorf("%w: %s", types.ErrSyncInProgress, target))
+			continue
+		}
+		count, err := s.fetchAndStoreProvider(target, credentials[target], clients[target], syncDNS)
+		s.markSyncFinished(target, count, err)
+		if err != nil {
+			failed = append(failed, err)
+		}
+	}
+	switch {
+	case len(failed) == 1:
+		return failed[0]
+	case len(failed) > 1:
+		return fmt.Errorf("%d of %d %s accounts failed to sync, first error: %w", len(failed), len(targets), providerName, failed[0])
+	}
+	return nil
+}
+
+// fetchAndStoreProvider fetches a sync target's domains and upserts them, returning how many
+// were stored. credentialID tags the domains when the target is a stored account; syncDNS
+// also fetches the stored domains' DNS records.
+func (s *SyncService) fetchAndStoreProvider(providerName, credentialID string, client providers.RegistrarClient, syncDNS bool) (int, error) {
 	log.Printf("Starting sync for provider: %s", providerName)
 
-	domains, err := client.FetchDomains()
+	domains, err := s.fetchDomains(providerName, client)
 	if err != nil {
-		return fmt.Errorf("failed to fetch domains from %s: %w", providerName, err)
+		return 0, fmt.Errorf("failed to fetch domains from %s: %w", providerName, err)
 	}
+	tagAccount(domains, credentialID)
+	s.fillRegistrarStatus(providerName, client, domains)
 
 	if len(domains) == 0 {
 		log.Printf("Provider %s returned no domains", providerName)
-		return nil
+		return 0, nil
 	}
 
-	// Store domains in database
-	if err := s.repo.UpsertDomains(domains); err != nil {
-		return fmt.Errorf("failed to store domains from %s: %w", providerName, err)
+	// Snapshot stored domains for the change report and status transitions
+	startedAt := time.Now()
+	s.mu.RLock()
+	notifier := s.notifier
+	s.mu.RUnlock()
+
+	stored := s.storedDomains()
+	gracePeriod := flagGracePeriod(domains, stored)
+
+	upserted, dnsReport, err := s.storeFetched(providerName, domains, stored, syncDNS)
+	if err != nil {
+		return 0, fmt.Errorf("failed to store domains from %s: %w", providerName, err)
+	}
+	log.Printf("Stored domains from %s: %d new, %d updated", providerName, upserted.Inserted, upserted.Updated)
+
+	if stored != nil {
+		report := buildProviderReport(providerName, credentialID, domains, stored)
+		flagged := s.flagMissing(report, stored)
+		s.recordReport(&SyncReport{StartedAt: startedAt, FinishedAt: time.Now(), Providers: []ProviderSyncReport{report}, DNS: dnsReport})
+		s.notifyTransitions(notifier, stored, append(domains, flagged...))
+		s.notifyGracePeriod(notifier, gracePeriod)
+		s.recordPriceChanges(notifier, stored, domains)
 	}
 
 	log.Printf("Successfully synced %d domains from %s", len(domains), providerName)
-	return nil
+	return len(domains), nil
 }
 
-// SyncDomainsWithDNS synchronizes domains and their DNS records from all providers
-func (s *SyncService) SyncDomainsWithDNS() error {
+// storeFetched is the store step shared by full and single-provider syncs. It applies the
+// categorization rules, upserts domains, fetches their DNS records when syncDNS is set and
+// starts creating monitors for the domains not in stored. source names the sync in logs.
+func (s *SyncService) storeFetched(source string, domains []types.Domain, stored map[string]types.Domain, syncDNS bool) (storage.UpsertResult, *DNSSyncReport, error) {
 	s.mu.RLock()
-	providerCount := len(s.providers)
+	monitoring := s.uptimeRobot
 	s.mu.RUnlock()
 
-	if providerCount == 0 {
-		log.Println("No providers configured, skipping DNS sync")
-		return nil
+	// Find the new domains before they're stored
+	var newNames []string
+	if monitoring != nil && monitoring.AutoCreateEnabled() {
+		newNames = unknownDomainNames(domains, stored)
 	}
 
-	if s.dnsService == nil {
-		log.Println("DNS service not configured, skipping DNS sync")
-		return s.Run() // Fall back to domain-only sync
+	// Keep stored categories and tags, and let the categorization rules fill in the rest
+	if categorized, err := CategorizeDomains(s.repo, domains, stored); err != nil {
+		log.Printf("Skipping categorization rules for %s: %v", source, err)
+	} else if categorized > 0 {
+		log.Printf("Categorization rules updated %d domains from %s", categorized, source)
+	}
+
+	upserted, err := s.repo.UpsertDomains(domains)
+	if err != nil {
+		return upserted, nil, err
+	}
+	logSkipped(source, upserted.Skipped)
+
+	var dnsReport *DNSSyncReport
+	if syncDNS {
+		dnsReport = s.syncFetchedDNS(domains)
+	}
+
+	// Probing and creating monitors is slow; don't hold up the sync for it. The operation is
+	// registered before returning so Drain waits for it.
+	if len(newNames) > 0 && s.beginOperation() == nil {
+		go func() {
+			defer s.inflight.Done()
+			s.autoCreateMonitors(monitoring, newNames)
+		}()
+	}
+
+	return upserted, dnsReport, nil
+}
+
+// logSkipped logs the domains an upsert left out because the provider returned them invalid
+func logSkipped(source string, skipped []storage.SkippedDomain) {
+	for _, domain := range skipped {
+		log.Printf("Skipped invalid domain %q from %s: %s", domain.Name, source, domain.Error)
 	}
+}
 
-	log.Printf("Starting domains and DNS sync for %d providers", providerCount)
+// fillRegistrarStatus reads each fetched domain's status from its registrar when enabled and
+// the client supports it. A domain whose status can't be read keeps its stored status; a rate
+// limit stops the remaining lookups.
+func (s *SyncService) fillRegistrarStatus(providerName string, client providers.RegistrarClient, domains []types.Domain) {
+	s.mu.RLock()
+	enabled := s.registrarStatus
+	s.mu.RUnlock()
+	reader, ok := client.(providers.DomainStatusReader)
+	if !enabled || !ok {
+		return
+	}
 
-	// First, sync domains
-	if err := s.Run(); err != nil {
-		return fmt.Errorf("failed to sync domains: %w", err)
+	failed := 0
+	var firstErr error
+	for i := range domains {
+		statuses, err := reader.GetDomainStatus(domains[i].Name)
+		if err != nil {
+			failed++
+			if firstErr == nil {
+				firstErr = err
+			}
+			if errors.Is(err, types.ErrProviderRateLimit) {
+				failed += len(domains) - i - 1
+				break
+			}
+			continue
+		}
+		status := types.JoinRegistrarStatuses(statuses)
+		domains[i].RegistrarStatus = &status
+	}
+	if failed > 0 {
+		log.Printf("Failed to read registrar status of %d of %d %s domains, first error: %v", failed, len(domains), providerName, firstErr)
 	}
+}
 
-	// Then, sync DNS records for each domain
-	domains, err := s.repo.GetAll()
+// storedDomains returns the stored domains keyed by normalized name, or nil if they can't be loaded
+func (s *SyncService) storedDomains() map[string]types.Domain {
+	// Hidden domains are still synced, so they must count as stored
+	existing, err := s.repo.GetByFilter(types.DomainFilter{IncludeHidden: true})
 	if err != nil {
-		return fmt.Errorf("failed to get domains for DNS sync: %w", err)
+		log.Printf("Failed to load existing domains: %v", err)
+		return nil
 	}
 
-	for _, domain := range domains {
-		if err := s.syncDomainDNS(domain); err != nil {
-			log.Printf("Failed to sync DNS for domain %s: %v", domain.Name, err)
-			// Continue with other domains even if one fails
+	stored := make(map[string]types.Domain, len(existing))
+	for _, d := range existing {
+		stored[types.NormalizeDomainName(d.Name)] = d
+	}
+	return stored
+}
+
+// sortedKeys returns the provider names of a fetch in a stable order
+func sortedKeys(fetched map[string][]types.Domain) []string {
+	names := make([]string, 0, len(fetched))
+	for name := range fetched {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// unknownDomainNames returns the names in domains that aren't in stored
+func unknownDomainNames(domains []types.Domain, stored map[string]types.Domain) []string {
+	if stored == nil {
+		return nil
+	}
+
+	var names []string
+	for _, d := range domains {
+		if _, known := stored[types.NormalizeDomainName(d.Name)]; !known {
+			names = append(names, d.Name)
 		}
 	}
+	return names
+}
+
+// notifyTransitions compares synced domains against their stored state and sends an alert
+// for each status transition through the notification rules
+func (s *SyncService) notifyTransitions(notifier *notifications.NotificationService, stored map[string]types.Domain, domains []types.Domain) {
+	if notifier == nil || stored == nil {
+		return
+	}
 
-	log.Printf("Completed DNS sync for %d domains", len(domains))
-	return nil
+	for _, domain := range domains {
+		previous, ok := stored[types.NormalizeDomainName(domain.Name)]
+		if !ok {
+			continue
+		}
+		// Provider data carries no website status; keep the stored value so only Status is compared
+		domain.ID = previous.ID
+		domain.HTTPStatus = previous.HTTPStatus
+		if domain.Status == types.DomainStatusGracePeriod && previous.Status != domain.Status && s.gracePeriodAlertsEnabled() {
+			domain.Status = previous.Status // Alerted by notifyGracePeriod instead
+		}
+
+		for _, alert := range notifier.DetectTransitions(previous, domain) {
+			if err := notifier.Notify(alert); err != nil {
+				log.Printf("Failed to send transition alert for %s: %v", domain.Name, err)
+			}
+		}
+	}
 }
 
-// SyncProviderWithDNS synchronizes domains and DNS records from a specific provider
-func (s *SyncService) SyncProviderWithDNS(providerName string) error {
+// recordPriceChanges records each synced domain's renewal price change in the price history and
+// sends an alert for a rise past the alert threshold
+func (s *SyncService) recordPriceChanges(notifier *notifications.NotificationService, stored map[string]types.Domain, domains []types.Domain) {
+	if stored == nil {
+		return
+	}
 	s.mu.RLock()
-	client, exists := s.providers[providerName]
+	threshold := s.priceAlertPercent
 	s.mu.RUnlock()
 
-	if !exists {
-		return fmt.Errorf("provider %s not found", providerName)
+	for _, domain := range domains {
+		previous, ok := stored[types.NormalizeDomainName(domain.Name)]
+		if !ok {
+			continue
+		}
+		change := types.DetectRenewalPriceChange(previous, domain)
+		if change == nil {
+			continue
+		}
+		if err := s.repo.CreateRenewalPriceChange(change); err != nil {
+			log.Printf("Failed to record renewal price change for %s: %v", domain.Name, err)
+		}
+		if notifier == nil || !change.ExceedsIncrease(threshold) {
+			continue
+		}
+		domain.ID = previous.ID
+		if err := notifier.Notify(notifier.CreateRenewalPriceAlert(domain, *change)); err != nil {
+			log.Printf("Failed to send renewal price alert for %s: %v", domain.Name, err)
+		}
 	}
+}
 
-	if s.dnsService == nil {
-		log.Println("DNS service not configured, falling back to domain-only sync")
-		return s.SyncProvider(providerName)
+// autoCreateMonitors creates UptimeRobot monitors for newly synced domains that serve HTTP
+func (s *SyncService) autoCreateMonitors(monitoring *uptimerobot.Service, names []string) {
+	created := 0
+	for _, name := range names {
+		stored, err := s.repo.GetDomainsByName(name)
+		if err != nil || len(stored) == 0 {
+			continue
+		}
+		domain := stored[0]
+		if domain.UptimeRobotMonitorID != nil || !monitoring.ServesHTTP(domain.Name) {
+			continue
+		}
+
+		if err := monitoring.EnableMonitoringForDomain(&domain); err != nil {
+			log.Printf("Failed to auto-create monitor for %s: %v", domain.Name, err)
+			continue
+		}
+		if err := s.repo.Update(&domain); err != nil {
+			log.Printf("Failed to store monitor for %s: %v", domain.Name, err)
+			continue
+		}
+		created++
 	}
 
-	log.Printf("Starting domains and DNS sync for provider: %s", providerName)
+	if created > 0 {
+		log.Printf("Auto-created %d UptimeRobot monitors for new domains", created)
+	}
+}
 
-	// First, sync domains
-	if err := s.SyncProvider(providerName); err != nil {
-		return fmt.Errorf("failed to sync domains from %s: %w", providerName, err)
+// SyncDomainsWithDNS synchronizes domains from all providers and the DNS records of every
+// domain fetched, whether or not DNS sync is enabled for scheduled syncs
+func (s *SyncService) SyncDomainsWithDNS() error {
+	if err := s.beginOperation(); err != nil {
+		return err
 	}
+	defer s.inflight.Done()
 
-	// Then, sync DNS records for domains from this provider
-	domains, err := s.repo.GetByFilter(types.DomainFilter{Provider: providerName})
-	if err != nil {
-		return fmt.Errorf("failed to get domains for DNS sync from %s: %w", providerName, err)
+	if s.dnsService == nil {
+		log.Println("DNS service not configured, falling back to domain-only sync")
+		return s.run(false)
 	}
+	return s.run(true)
+}
 
-	for _, domain := range domains {
-		if err := s.syncDomainDNSWithClient(domain, client); err != nil {
-			log.Printf("Failed to sync DNS for domain %s: %v", domain.Name, err)
-			// Continue with other domains even if one fails
-		}
+// SyncProviderWithDNS synchronizes domains and DNS records from a specific provider
+func (s *SyncService) SyncProviderWithDNS(providerName string) error {
+	if err := s.beginOperation(); err != nil {
+		return err
 	}
+	defer s.inflight.Done()
 
-	log.Printf("Completed DNS sync for %d domains from %s", len(domains), providerName)
-	return nil
+	if s.dnsService == nil {
+		log.Println("DNS service not configured, falling back to domain-only sync")
+		return s.syncProviderDomains(providerName, false)
+	}
+	return s.syncProviderDomains(providerName, true)
 }
 
-// syncDomainDNS synchronizes DNS records for a specific domain using its provider
-func (s *SyncService) syncDomainDNS(domain types.Domain) error {
+// syncDomainDNS synchronizes DNS records for a specific domain using its provider, returning
+// how many records were stored
+func (s *SyncService) syncDomainDNS(domain types.Domain) (int, error) {
 	s.mu.RLock()
-	client, exists := s.providers[domain.Provider]
+	client, exists := s.providers[s.domainTarget(domain)]
 	s.mu.RUnlock()
 
 	if !exists {
-		return fmt.Errorf("provider %s not found for domain %s", domain.Provider, domain.Name)
+		return 0, fmt.Errorf("provider %s not found for domain %s", domain.Provider, domain.Name)
 	}
 
 	return s.syncDomainDNSWithClient(domain, client)
 }
 
-// syncDomainDNSWithClient synchronizes DNS records for a domain using the specified client
-func (s *SyncService) syncDomainDNSWithClient(domain types.Domain, client providers.RegistrarClient) error {
-	log.Printf("Syncing DNS records for domain: %s", domain.Name)
-
+// syncDomainDNSWithClient synchronizes DNS records for a domain using the specified client.
+// An empty answer leaves the stored records alone.
+func (s *SyncService) syncDomainDNSWithClient(domain types.Domain, client providers.RegistrarClient) (int, error) {
 	// Fetch DNS records from provider
 	dnsRecords, err := client.FetchDNSRecords(domain.Name)
 	if err != nil {
-		return fmt.Errorf("failed to fetch DNS records for %s: %w", domain.Name, err)
+		return 0, fmt.Errorf("failed to fetch DNS records for %s: %w", domain.Name, err)
 	}
 
 	if len(dnsRecords) == 0 {
 		log.Printf("No DNS records found for domain %s", domain.Name)
-		return nil
+		return 0, nil
 	}
 
 	// Set domain ID for all records
@@ -258,18 +824,18 @@ func (s *SyncService) syncDomainDNSWithClient(domain types.Domain, client provid
 	}
 
 	// Replace all DNS records for this domain
-	if err := s.dnsService.BulkUpdateRecords(domain.ID, dnsRecords); err != nil {
-		return fmt.Errorf("failed to store DNS records for %s: %w", domain.Name, err)
+	if err := s.dnsService.StoreProviderRecords(domain.ID, client.GetProviderName(), dnsRecords); err != nil {
+		return 0, fmt.Errorf("failed to store DNS records for %s: %w", domain.Name, err)
 	}
-
-	log.Printf("Successfully synced %d DNS records for domain %s", len(dnsRecords), domain.Name)
-	return nil
+	return len(dnsRecords), nil
 }
 
 // GetStatus returns the current sync service status
 func (s *SyncService) GetStatus() SyncStatus {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
+	s.stateMu.Lock()
+	defer s.stateMu.Unlock()
 
 	status := SyncStatus{
 		ProvidersConfigured: len(s.providers),
@@ -277,21 +843,55 @@ func (s *SyncService) GetStatus() SyncStatus {
 	}
 
 	for name := range s.providers {
-		status.Providers[name] = ProviderStatus{
+		ps := ProviderStatus{
 			Name:    name,
 			Enabled: true,
-			// LastSync and other fields would be populated from database/cache
+			State:   SyncStateIdle,
+			Breaker: providers.BreakerStatus{State: providers.BreakerClosed},
 		}
+		if b, ok := s.breakers[name]; ok {
+			ps.Breaker = b.Status()
+		}
+		if account, ok := s.accounts[name]; ok {
+			ps.Provider = account.provider
+			ps.CredentialID = account.credentialID
+			ps.AccountName = account.accountName
+		}
+		if st, ok := s.states[name]; ok {
+			ps.State = st.state
+			ps.StartedAt = st.startedAt
+			ps.FinishedAt = st.finishedAt
+			ps.LastSuccess = st.lastSuccess
+			ps.DomainsProcessed = st.domainsProcessed
+			ps.Error = st.lastError
+			ps.SkippedRuns = st.skippedRuns
+			ps.LastSkipped = st.lastSkipped
+			if st.lastSuccess != nil {
+				ps.LastSync = st.lastSuccess.Format(time.RFC3339)
+			}
+		}
+		if ps.State == SyncStateRunning {
+			status.Running = true
+		}
+		if ps.LastSuccess != nil && (status.LastSuccess == nil || ps.LastSuccess.After(*status.LastSuccess)) {
+			status.LastSuccess = ps.LastSuccess
+		}
+		status.Providers[name] = ps
 	}
 
 	return status
 }
 
 // syncProvider is a helper function that runs in a goroutine
-func (s *SyncService) syncProvider(name string, client providers.RegistrarClient, results chan<- SyncResult) {
-	domains, err := client.FetchDomains()
+func (s *SyncService) syncProvider(name string, client providers.RegistrarClient, credentialID string, results chan<- SyncResult) {
+	domains, err := s.fetchDomains(name, client)
+	tagAccount(domains, credentialID)
+	if err == nil {
+		s.fillRegistrarStatus(name, client, domains)
+	}
 	results <- SyncResult{
 		ProviderName: name,
+		CredentialID: credentialID,
 		Domains:      domains,
 		Error:        err,
 	}
@@ -300,6 +900,7 @@ func (s *SyncService) syncProvider(name string, client providers.RegistrarClient
 // SyncResult represents the result of a provider sync operation
 type SyncResult struct {
 	ProviderName string
+	CredentialID string // Set when the provider is a stored account
 	Domains      []types.Domain
 	Error        error
 }
@@ -307,13 +908,26 @@ type SyncResult struct {
 // SyncStatus represents the current status of the sync service
 type SyncStatus struct {
 	ProvidersConfigured int                       `json:"providers_configured"`
+	Running             bool                      `json:"running"`                // True while any provider sync is in progress
+	LastSuccess         *time.Time                `json:"last_success,omitempty"` // Most recent successful provider sync
 	Providers          map[string]ProviderStatus `json:"providers"`
 }
 
 // ProviderStatus represents the status of an individual provider
 type ProviderStatus struct {
-	Name     string `json:"name"`
-	Enabled  bool   `json:"enabled"`
-	LastSync string `json:"last_sync,omitempty"`
-	Error    string `json:"error,omitempty"`
+	Name             string     `json:"name"`
+	Provider         string     `json:"provider,omitempty"`      // Registrar of a stored account
+	CredentialID     string     `json:"credential_id,omitempty"` // Stored credentials an account syncs with
+	AccountName      string     `json:"account_name,omitempty"`
+	Enabled          bool       `json:"enabled"`
+	State            string     `json:"state"`                  // idle, running or error
+	StartedAt        *time.Time `json:"started_at,omitempty"`   // Start of the current or last run
+	FinishedAt       *time.Time `json:"finished_at,omitempty"`  // End of the last run; nil while running
+	LastSuccess      *time.Time `json:"last_success,omitempty"` // End of the last successful run
+	DomainsProcessed int        `json:"domains_processed"`      // Domains stored by the last run
+	LastSync         string     `json:"last_sync,omitempty"`
+	Error            string     `json:"error,omitempty"`        // Error from the last run, if it failed
+	SkippedRuns      int        `json:"skipped_runs"`           // Runs skipped because a sync was still in progress
+	LastSkipped      *time.Time `json:"last_skipped,omitempty"` // When a run was last skipped
+	Breaker          providers.BreakerStatus `json:"breaker"`    // Circuit breaker guarding the domain fetch
 }
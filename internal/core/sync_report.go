@@ -0,0 +1,203 @@
+package core
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/rusiqe/domainvault/internal/types"
+)
+
+// StatusMissingAtProvider marks a stored domain its provider no longer returns,
+// which usually means it was transferred out or deleted
+const StatusMissingAtProvider = "missing_at_provider"
+
+// SyncReport describes what the most recent sync changed, per provider
+type SyncReport struct {
+	StartedAt  time.Time            `json:"started_at"`
+	FinishedAt time.Time            `json:"finished_at"`
+	Providers  []ProviderSyncReport `json:"providers"`
+	DNS        *DNSSyncReport       `json:"dns,omitempty"` // Set when the sync also fetched DNS records
+}
+
+// ProviderSyncReport lists the domains a provider sync added, changed or no longer returned
+type ProviderSyncReport struct {
+	Provider    string         `json:"provider"`
+	Fetched     int            `json:"fetched"`
+	Added       []string       `json:"added"`
+	Updated     []DomainChange `json:"updated"`
+	Missing     []string       `json:"missing"`      // Stored domains the provider didn't return; flagged StatusMissingAtProvider
+	GracePeriod []string       `json:"grace_period"` // Returned domains past expiry but still active; flagged types.DomainStatusGracePeriod
+}
+
+// DomainChange lists the fields a sync changed on one domain
+type DomainChange struct {
+	Domain  string        `json:"domain"`
+	Changes []FieldChange `json:"changes"`
+}
+
+// FieldChange is one field's value before and after a sync
+type FieldChange struct {
+	Field string `json:"field"`
+	Old   string `json:"old"`
+	New   string `json:"new"`
+}
+
+// buildProviderReport diffs a provider's fetched domains against the stored set. credentialID
+// is the stored account the fetch came from, "" for a configured provider.
+func buildProviderReport(providerName, credentialID string, fetched []types.Domain, stored map[string]types.Domain) ProviderSyncReport {
+	report := ProviderSyncReport{
+		Provider:    providerName,
+		Fetched:     len(fetched),
+		Added:       []string{},
+		Updated:     []DomainChange{},
+		Missing:     []string{},
+		GracePeriod: []string{},
+	}
+
+	// A domain belongs to this sync if it's stored under the sync name or a provider the fetch
+	// reported, and came from the same account; other accounts at the registrar aren't missing
+	owners := map[string]bool{providerName: true}
+	seen := make(map[string]bool, len(fetched))
+	for _, domain := range fetched {
+		name := types.NormalizeDomainName(domain.Name)
+		seen[name] = true
+		owners[domain.Provider] = true
+		if domain.Status == types.DomainStatusGracePeriod {
+			report.GracePeriod = append(report.GracePeriod, name)
+		}
+
+		previous, ok := stored[name]
+		if !ok {
+			report.Added = append(report.Added, name)
+			continue
+		}
+		if changes := diffSyncedFields(previous, domain); len(changes) > 0 {
+			report.Updated = append(report.Updated, DomainChange{Domain: name, Changes: changes})
+		}
+	}
+
+	// An empty fetch is more likely a provider fault than every domain leaving, so flag nothing
+	for name, domain := range stored {
+		if len(fetched) > 0 && owners[domain.Provider] && domainCredential(domain) == credentialID && !seen[name] {
+			report.Missing = append(report.Missing, name)
+		}
+	}
+
+	sort.Strings(report.Added)
+	sort.Strings(report.Missing)
+	sort.Strings(report.GracePeriod)
+	sort.Slice(report.Updated, func(i, j int) bool { return report.Updated[i].Domain < report.Updated[j].Domain })
+	return report
+}
+
+// diffSyncedFields compares the fields providers supply
+func diffSyncedFields(previous, current types.Domain) []FieldChange {
+	var changes []FieldChange
+	add := func(field, old, new string) {
+		if old != new {
+			changes = append(changes, FieldChange{Field: field, Old: old, New: new})
+		}
+	}
+
+	add("provider", previous.Provider, current.Provider)
+	add("status", previous.Status, current.Status)
+	add("auto_renew", fmt.Sprint(previous.AutoRenew), fmt.Sprint(current.AutoRenew))
+	add("expires_at", formatSyncDate(previous.ExpiresAt), formatSyncDate(current.ExpiresAt))
+	add("renewal_price", formatSyncPrice(previous.RenewalPrice), formatSyncPrice(current.RenewalPrice))
+	return changes
+}
+
+func formatSyncDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format("2006-01-02")
+}
+
+func formatSyncPrice(price *float64) string {
+	if price == nil {
+		return ""
+	}
+	return fmt.Sprintf("%.2f", *price)
+}
+
+// flagMissing marks the report's missing domains with StatusMissingAtProvider and returns the
+// domains it changed, so callers can raise transition alerts for them
+func (s *SyncService) flagMissing(report ProviderSyncReport, stored map[string]types.Domain) []types.Domain {
+	var flagged []types.Domain
+	for _, name := range report.Missing {
+		domain := stored[name]
+		if domain.Status == StatusMissingAtProvider {
+			continue
+		}
+		domain.Status = StatusMissingAtProvider
+		if err := s.repo.Update(&domain); err != nil {
+			log.Printf("Failed to flag %s as missing at %s: %v", name, report.Provider, err)
+			continue
+		}
+		flagged = append(flagged, domain)
+	}
+
+	if len(flagged) > 0 {
+		log.Printf("Flagged %d domains no longer returned by %s", len(flagged), report.Provider)
+	}
+	return flagged
+}
+
+// recordReport stores the report returned by LastReport
+func (s *SyncService) recordReport(report *SyncReport) {
+	s.stateMu.Lock()
+	defer s.stateMu.Unlock()
+	s.lastReport = report
+}
+
+// LastReport returns the report of the most recent sync, or nil if none has completed
+func (s *SyncService) LastReport() *SyncReport {
+	s.stateMu.Lock()
+	defer s.stateMu.Unlock()
+	return s.lastReport
+}
+
+// Filter returns a copy of the report listing only the domains keep accepts, for readers who
+// may not see every domain. Counts still describe the whole sync.
+func (r *SyncReport) Filter(keep func(name string) bool) *SyncReport {
+	names := func(list []string) []string {
+		kept := []string{}
+		for _, name := range list {
+			if keep(name) {
+				kept = append(kept, name)
+			}
+		}
+		return kept
+	}
+
+	filtered := &SyncReport{StartedAt: r.StartedAt, FinishedAt: r.FinishedAt, Providers: make([]ProviderSyncReport, 0, len(r.Providers))}
+	for _, provider := range r.Providers {
+		updated := []DomainChange{}
+		for _, change := range provider.Updated {
+			if keep(change.Domain) {
+				updated = append(updated, change)
+			}
+		}
+		filtered.Providers = append(filtered.Providers, ProviderSyncReport{
+			Provider:    provider.Provider,
+			Fetched:     provider.Fetched,
+			Added:       names(provider.Added),
+			Updated:     updated,
+			Missing:     names(provider.Missing),
+			GracePeriod: names(provider.GracePeriod),
+		})
+	}
+	if r.DNS != nil {
+		dns := &DNSSyncReport{Synced: r.DNS.Synced, Failed: r.DNS.Failed, Failures: []DNSSyncFailure{}}
+		for _, failure := range r.DNS.Failures {
+			if keep(failure.Domain) {
+				dns.Failures = append(dns.Failures, failure)
+			}
+		}
+		filtered.DNS = dns
+	}
+	return filtered
+}
@@ -1,26 +1,39 @@
 package core
 
 import (
+	"context"
 	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/rusiqe/domainvault/internal/config"
 	"github.com/rusiqe/domainvault/internal/providers"
+	"github.com/rusiqe/domainvault/internal/storage"
 	"github.com/rusiqe/domainvault/internal/types"
+	"github.com/rusiqe/domainvault/internal/uptimerobot"
 )
 
-// Mock repository for testing
+// Mock repository for testing: the shared mock storage with the domain writes and reads
+// replaced, so tests see exactly what a sync stored and can make writes fail
 type mockRepository struct {
+	*storage.MockRepo
 	domains []types.Domain
 	fail    bool
 }
 
-func (m *mockRepository) UpsertDomains(domains []types.Domain) error {
+func newMockRepository() *mockRepository {
+	return &mockRepository{MockRepo: storage.NewEmptyMockRepo()}
+}
+
+func (m *mockRepository) UpsertDomains(domains []types.Domain) (storage.UpsertResult, error) {
 	if m.fail {
-		return errors.New("database error")
+		return storage.UpsertResult{}, errors.New("database error")
 	}
 	m.domains = append(m.domains, domains...)
-	return nil
+	return storage.UpsertResult{Inserted: len(domains)}, nil
 }
 
 func (m *mockRepository) GetAll() ([]types.Domain, error) {
@@ -40,30 +53,6 @@ func (m *mockRepository) GetByFilter(filter types.DomainFilter) ([]types.Domain,
 	return m.domains, nil
 }
 
-func (m *mockRepository) Delete(id string) error {
-	return nil
-}
-
-func (m *mockRepository) GetExpiring(threshold time.Duration) ([]types.Domain, error) {
-	return nil, nil
-}
-
-func (m *mockRepository) GetSummary() (*types.DomainSummary, error) {
-	return &types.DomainSummary{}, nil
-}
-
-func (m *mockRepository) BulkRenew(domainIDs []string) error {
-	return nil
-}
-
-func (m *mockRepository) Close() error {
-	return nil
-}
-
-func (m *mockRepository) Ping() error {
-	return nil
-}
-
 // Mock provider client for testing
 type mockProviderClient struct {
 	name    string
@@ -82,8 +71,12 @@ func (m *mockProviderClient) GetProviderName() string {
 	return m.name
 }
 
+func (m *mockProviderClient) FetchDNSRecords(domain string) ([]types.DNSRecord, error) {
+	return nil, nil
+}
+
 func TestNewSyncService(t *testing.T) {
-	repo := &mockRepository{}
+	repo := newMockRepository()
 	service := NewSyncService(repo)
 
 	if service == nil {
@@ -100,7 +93,7 @@ func TestNewSyncService(t *testing.T) {
 }
 
 func TestSyncService_AddProvider(t *testing.T) {
-	repo := &mockRepository{}
+	repo := newMockRepository()
 	service := NewSyncService(repo)
 
 	mockClient := &mockProviderClient{
@@ -123,7 +116,7 @@ func TestSyncService_AddProvider(t *testing.T) {
 }
 
 func TestSyncService_RemoveProvider(t *testing.T) {
-	repo := &mockRepository{}
+	repo := newMockRepository()
 	service := NewSyncService(repo)
 
 	mockClient := &mockProviderClient{name: "test-provider"}
@@ -143,7 +136,7 @@ func TestSyncService_RemoveProvider(t *testing.T) {
 }
 
 func TestSyncService_Run_NoProviders(t *testing.T) {
-	repo := &mockRepository{}
+	repo := newMockRepository()
 	service := NewSyncService(repo)
 
 	err := service.Run()
@@ -157,7 +150,7 @@ func TestSyncService_Run_NoProviders(t *testing.T) {
 }
 
 func TestSyncService_Run_SingleProvider(t *testing.T) {
-	repo := &mockRepository{}
+	repo := newMockRepository()
 	service := NewSyncService(repo)
 
 	mockClient := &mockProviderClient{
@@ -180,8 +173,49 @@ func TestSyncService_Run_SingleProvider(t *testing.T) {
 	}
 }
 
+func TestSyncService_Run_AutoCreatesMonitors(t *testing.T) {
+	// The new domain is named after a local server so the HTTP probe finds it serving
+	site := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer site.Close()
+	newName := strings.TrimPrefix(site.URL, "http://")
+
+	repo := storage.NewEmptyMockRepo()
+	expires := time.Now().AddDate(1, 0, 0)
+	if _, err := repo.UpsertDomains([]types.Domain{{ID: "known", Name: "known.com", Provider: "test-provider", ExpiresAt: expires}}); err != nil {
+		t.Fatalf("UpsertDomains() error: %v", err)
+	}
+
+	service := NewSyncService(repo)
+	service.SetUptimeRobotService(uptimerobot.NewService(&config.UptimeRobotConfig{APIKey: "mock", AutoCreateMonitors: true}))
+	service.AddProvider("test-provider", &mockProviderClient{
+		name: "test-provider",
+		domains: []types.Domain{
+			{ID: "known", Name: "known.com", Provider: "test-provider", ExpiresAt: expires}, // The mock upserts by ID
+			{Name: newName, Provider: "test-provider", ExpiresAt: expires},
+		},
+	})
+
+	if err := service.Run(); err != nil {
+		t.Fatalf("Run() unexpected error: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if err := service.Drain(ctx); err != nil {
+		t.Fatalf("Drain() error: %v", err)
+	}
+
+	created, _ := repo.GetDomainsByName(newName)
+	if len(created) != 1 || created[0].UptimeRobotMonitorID == nil {
+		t.Errorf("Expected a monitor for the new domain %s, got %+v", newName, created)
+	}
+	known, _ := repo.GetDomainsByName("known.com")
+	if len(known) != 1 || known[0].UptimeRobotMonitorID != nil {
+		t.Error("Expected no monitor for the domain that was already stored")
+	}
+}
+
 func TestSyncService_Run_MultipleProviders(t *testing.T) {
-	repo := &mockRepository{}
+	repo := newMockRepository()
 	service := NewSyncService(repo)
 
 	// Add first provider
@@ -214,7 +248,7 @@ func TestSyncService_Run_MultipleProviders(t *testing.T) {
 }
 
 func TestSyncService_Run_ProviderError(t *testing.T) {
-	repo := &mockRepository{}
+	repo := newMockRepository()
 	service := NewSyncService(repo)
 
 	// Add working provider
@@ -245,7 +279,8 @@ func TestSyncService_Run_ProviderError(t *testing.T) {
 }
 
 func TestSyncService_Run_DatabaseError(t *testing.T) {
-	repo := &mockRepository{fail: true}
+	repo := newMockRepository()
+	repo.fail = true
 	service := NewSyncService(repo)
 
 	mockClient := &mockProviderClient{
@@ -263,7 +298,7 @@ func TestSyncService_Run_DatabaseError(t *testing.T) {
 }
 
 func TestSyncService_SyncProvider(t *testing.T) {
-	repo := &mockRepository{}
+	repo := newMockRepository()
 	service := NewSyncService(repo)
 
 	mockClient := &mockProviderClient{
@@ -285,7 +320,7 @@ func TestSyncService_SyncProvider(t *testing.T) {
 }
 
 func TestSyncService_SyncProvider_NotFound(t *testing.T) {
-	repo := &mockRepository{}
+	repo := newMockRepository()
 	service := NewSyncService(repo)
 
 	err := service.SyncProvider("nonexistent-provider")
@@ -295,7 +330,7 @@ func TestSyncService_SyncProvider_NotFound(t *testing.T) {
 }
 
 func TestSyncService_SyncProvider_NoDomains(t *testing.T) {
-	repo := &mockRepository{}
+	repo := newMockRepository()
 	service := NewSyncService(repo)
 
 	mockClient := &mockProviderClient{
@@ -315,7 +350,7 @@ func TestSyncService_SyncProvider_NoDomains(t *testing.T) {
 }
 
 func TestSyncService_GetStatus(t *testing.T) {
-	repo := &mockRepository{}
+	repo := newMockRepository()
 	service := NewSyncService(repo)
 
 	// Initially no providers
@@ -351,7 +386,7 @@ func TestSyncService_GetStatus(t *testing.T) {
 }
 
 func TestSyncService_Concurrency(t *testing.T) {
-	repo := &mockRepository{}
+	repo := newMockRepository()
 	service := NewSyncService(repo)
 
 	// Add mock provider
@@ -386,7 +421,7 @@ func TestSyncService_Concurrency(t *testing.T) {
 
 func TestSyncService_Integration_WithMockProvider(t *testing.T) {
 	// This tests the integration with the actual mock provider
-	repo := &mockRepository{}
+	repo := newMockRepository()
 	service := NewSyncService(repo)
 
 	// Use the real mock provider
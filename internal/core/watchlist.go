@@ -0,0 +1,242 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/rusiqe/domainvault/internal/notifications"
+	"github.com/rusiqe/domainvault/internal/providers"
+	"github.com/rusiqe/domainvault/internal/storage"
+	"github.com/rusiqe/domainvault/internal/types"
+)
+
+// WatchlistService checks domains we want to acquire and alerts when one becomes available
+// within its price limit
+type WatchlistService struct {
+	repo     storage.DomainRepository
+	sources  []providers.AvailabilityChecker // Tried in order until one can answer
+	notifier *notifications.NotificationService
+	mu       sync.RWMutex // Protects notifier
+}
+
+// WatchlistCheckSummary counts the outcome of checking the whole watchlist
+type WatchlistCheckSummary struct {
+	Checked   int `json:"checked"`
+	Available int `json:"available"`
+	Notified  int `json:"notified"`
+	Failed    int `json:"failed"`
+}
+
+// NewWatchlistService creates a watchlist service that checks availability through sources,
+// in order. A source returning ErrAvailabilityUnsupported, or failing, passes the check to
+// the next one.
+func NewWatchlistService(repo storage.DomainRepository, sources ...providers.AvailabilityChecker) *WatchlistService {
+	return &WatchlistService{repo: repo, sources: sources}
+}
+
+// SetNotificationService sets where availability alerts are sent
+func (w *WatchlistService) SetNotificationService(ns *notifications.NotificationService) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.notifier = ns
+}
+
+// Add puts a domain on the watchlist. Domains already in the portfolio can't be watched.
+func (w *WatchlistService) Add(req types.WatchlistRequest) (*types.WatchlistEntry, error) {
+	entry := &types.WatchlistEntry{
+		Domain:   req.Domain,
+		MaxPrice: req.MaxPrice,
+		Currency: req.Currency,
+		Notes:    req.Notes,
+	}
+	if err := entry.Validate(); err != nil {
+		return nil, err
+	}
+
+	owned, err := w.repo.GetDomainsByName(entry.Domain)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check portfolio for %s: %w", entry.Domain, err)
+	}
+	if len(owned) > 0 {
+		return nil, fmt.Errorf("%w: %s is already in the portfolio", types.ErrDomainExists, entry.Domain)
+	}
+
+	if err := w.repo.CreateWatchlistEntry(entry); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// Update changes an entry's price limit, currency and notes; the domain can't be changed
+func (w *WatchlistService) Update(id string, req types.WatchlistRequest) (*types.WatchlistEntry, error) {
+	entry, err := w.repo.GetWatchlistEntryByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if req.Domain != "" && types.NormalizeDomainName(req.Domain) != entry.Domain {
+		return nil, fmt.Errorf("%w: the domain of a watchlist entry can't be changed", types.ErrInvalidWatchlist)
+	}
+
+	entry.MaxPrice = req.MaxPrice
+	entry.Currency = req.Currency
+	entry.Notes = req.Notes
+	if err := entry.Validate(); err != nil {
+		return nil, err
+	}
+	if err := w.repo.UpdateWatchlistEntry(entry); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// CheckAvailability asks each source in turn whether domain can be registered
+func (w *WatchlistService) CheckAvailability(domain string) (*types.DomainAvailability, error) {
+	lastErr := types.ErrAvailabilityUnsupported
+	for _, source := range w.sources {
+		availability, err := source.CheckAvailability(domain)
+		if err == nil {
+			return availability, nil
+		}
+		if !errors.Is(err, types.ErrAvailabilityUnsupported) {
+			lastErr = err
+		}
+	}
+	return nil, lastErr
+}
+
+// DefaultAvailabilityWorkers is how many lookups a bulk availability search runs at once
+const DefaultAvailabilityWorkers = 5
+
+// SearchAvailability looks up a batch of domains with a pool of workers; the sources' rate
+// limits pace them, so a large batch takes a while rather than firing at once. After each
+// lookup, progress, if set, gets a snapshot of the search so far. Domains not started before
+// ctx is done are left pending.
+func (w *WatchlistService) SearchAvailability(ctx context.Context, domains []string, workers int, progress func(*types.AvailabilitySearch)) *types.AvailabilitySearch {
+	if workers <= 0 {
+		workers = DefaultAvailabilityWorkers
+	}
+	search := types.NewAvailabilitySearch(domains)
+	search.Processing = true
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	jobs := make(chan int)
+	for i := 0; i < workers && i < len(domains); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				availability, err := w.CheckAvailability(domains[j])
+				mu.Lock()
+				search.Record(j, availability, err)
+				if progress != nil {
+					progress(search.Snapshot())
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+dispatch:
+	for i := range domains {
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+			break dispatch
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	search.Processing = false
+	return search
+}
+
+// Check looks up one entry's availability, saves the result and sends an alert the first
+// time the domain is available within the price limit. Once the domain is registered again
+// the alert is re-armed.
+func (w *WatchlistService) Check(entry *types.WatchlistEntry) (notified bool, err error) {
+	now := time.Now()
+	entry.LastCheckedAt = &now
+
+	availability, checkErr := w.CheckAvailability(entry.Domain)
+	if checkErr != nil {
+		entry.LastError = checkErr.Error()
+		if err := w.repo.UpdateWatchlistEntry(entry); err != nil {
+			return false, err
+		}
+		return false, fmt.Errorf("failed to check availability of %s: %w", entry.Domain, checkErr)
+	}
+
+	entry.LastError = ""
+	entry.LastSource = availability.Source
+	entry.LastPrice = availability.Price
+	switch {
+	case !availability.Available:
+		entry.Status = types.WatchStatusRegistered
+		entry.NotifiedAt = nil
+	case !entry.WithinBudget(*availability):
+		entry.Status = types.WatchStatusOverBudget
+	default:
+		entry.Status = types.WatchStatusAvailable
+		if entry.NotifiedAt == nil {
+			notified = w.notify(*entry, *availability)
+			if notified {
+				entry.NotifiedAt = &now
+			}
+		}
+	}
+
+	if err := w.repo.UpdateWatchlistEntry(entry); err != nil {
+		return notified, err
+	}
+	return notified, nil
+}
+
+// CheckAll checks every watchlist entry, least recently checked first. A failed check is
+// logged and counted; the rest still run.
+func (w *WatchlistService) CheckAll() (WatchlistCheckSummary, error) {
+	var summary WatchlistCheckSummary
+	entries, err := w.repo.GetWatchlist()
+	if err != nil {
+		return summary, fmt.Errorf("failed to load watchlist: %w", err)
+	}
+
+	for i := range entries {
+		entry := &entries[i]
+		notified, err := w.Check(entry)
+		summary.Checked++
+		if err != nil {
+			log.Printf("Watchlist: %v", err)
+			summary.Failed++
+			continue
+		}
+		if entry.Status == types.WatchStatusAvailable {
+			summary.Available++
+		}
+		if notified {
+			summary.Notified++
+		}
+	}
+	return summary, nil
+}
+
+// notify sends an availability alert, reporting whether there was a notifier to send it
+func (w *WatchlistService) notify(entry types.WatchlistEntry, availability types.DomainAvailability) bool {
+	w.mu.RLock()
+	notifier := w.notifier
+	w.mu.RUnlock()
+	if notifier == nil {
+		return false
+	}
+
+	if err := notifier.Notify(notifier.CreateWatchlistAlert(entry, availability)); err != nil {
+		log.Printf("Failed to send watchlist alert for %s: %v", entry.Domain, err)
+		return false
+	}
+	return true
+}
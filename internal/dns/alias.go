@@ -0,0 +1,63 @@
+package dns
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/rusiqe/domainvault/internal/types"
+)
+
+// canonicalRecordType upper-cases a record type and maps ANAME, the other name providers use
+// for a flattened CNAME, to ALIAS
+func canonicalRecordType(recordType string) string {
+	recordType = strings.ToUpper(strings.TrimSpace(recordType))
+	if recordType == "ANAME" {
+		return "ALIAS"
+	}
+	return recordType
+}
+
+// validateAlias checks an ALIAS record points at a single hostname. ALIAS records are
+// resolved by the DNS host, which serves the target's addresses in their place, so unlike a
+// CNAME they can sit at the zone apex next to the NS and MX records.
+func validateAlias(record *types.DNSRecord) error {
+	target := strings.ToLower(strings.TrimSuffix(strings.TrimSpace(record.Value), "."))
+	if target == "" || target == "@" {
+		return fmt.Errorf("ALIAS target is required")
+	}
+	if strings.ContainsAny(target, " \t") {
+		return fmt.Errorf("ALIAS target must be a single hostname")
+	}
+	if net.ParseIP(target) != nil {
+		return fmt.Errorf("ALIAS target must be a hostname; use an A or AAAA record for an IP address")
+	}
+	record.Value = target
+	return nil
+}
+
+// validateRecordName checks a record's owner name. A wildcard is only allowed as the whole
+// leftmost label, as in `*` or `*.dev`.
+func validateRecordName(name string) error {
+	for i, label := range strings.Split(strings.TrimSuffix(name, "."), ".") {
+		if !strings.Contains(label, "*") {
+			continue
+		}
+		if label != "*" || i != 0 {
+			return fmt.Errorf("invalid record name %q: a wildcard must be the whole leftmost label, as in * or *.dev", name)
+		}
+	}
+	return nil
+}
+
+// IsWildcardName reports whether a record name is a wildcard, matching names with no records
+// of their own
+func IsWildcardName(name string) bool {
+	return name == "*" || strings.HasPrefix(name, "*.")
+}
+
+// IsApexName reports whether a record name, stored as @ or relative to the domain, is the zone
+// apex of domainName
+func IsApexName(name, domainName string) bool {
+	return recordHost(name, domainName) == types.NormalizeDomainName(domainName)
+}
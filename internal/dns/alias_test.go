@@ -0,0 +1,98 @@
+package dns
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rusiqe/domainvault/internal/storage"
+	"github.com/rusiqe/domainvault/internal/types"
+)
+
+func TestZoneAliasAndWildcardRoundTrip(t *testing.T) {
+	records := []types.DNSRecord{
+		{Type: "ALIAS", Name: "@", Value: "myapp.herokudns.com", TTL: 3600},
+		{Type: "CNAME", Name: "*.dev", Value: "dev.example.net", TTL: 3600},
+		{Type: "A", Name: "*", Value: "192.0.2.1", TTL: 3600},
+	}
+
+	zone := ExportZone("example.com", records)
+	if !strings.Contains(zone, "@\t3600\tIN\tALIAS\tmyapp.herokudns.com.") {
+		t.Errorf("ExportZone did not write the ALIAS record:\n%s", zone)
+	}
+
+	imported, err := ImportZone("d1", zone+"app.example.com. 300 IN ANAME lb.example.net.\n")
+	if err != nil {
+		t.Fatalf("ImportZone() unexpected error: %v", err)
+	}
+	got := map[string]types.DNSRecord{}
+	for _, r := range imported {
+		got[r.Name] = r
+	}
+	for _, r := range records {
+		if got[r.Name].Type != r.Type || got[r.Name].Value != r.Value {
+			t.Errorf("%s did not round-trip: got %s %q, want %s %q", r.Name, got[r.Name].Type, got[r.Name].Value, r.Type, r.Value)
+		}
+	}
+	if app := got["app"]; app.Type != "ALIAS" || app.Value != "lb.example.net" {
+		t.Errorf("ANAME imported as %s %q, want ALIAS %q", app.Type, app.Value, "lb.example.net")
+	}
+}
+
+func TestValidateRecord_AliasAndWildcard(t *testing.T) {
+	svc := NewDNSService(storage.NewMockRepo())
+
+	tests := []struct {
+		name    string
+		record  types.DNSRecord
+		wantErr bool
+	}{
+		{name: "apex alias", record: types.DNSRecord{Type: "ALIAS", Name: "@", Value: "myapp.herokudns.com."}},
+		{name: "aname", record: types.DNSRecord{Type: "aname", Name: "@", Value: "lb.example.net"}},
+		{name: "alias to IP", record: types.DNSRecord{Type: "ALIAS", Name: "@", Value: "192.0.2.1"}, wantErr: true},
+		{name: "alias without target", record: types.DNSRecord{Type: "ALIAS", Name: "@", Value: " "}, wantErr: true},
+		{name: "wildcard", record: types.DNSRecord{Type: "A", Name: "*", Value: "192.0.2.1"}},
+		{name: "nested wildcard", record: types.DNSRecord{Type: "CNAME", Name: "*.dev", Value: "dev.example.net"}},
+		{name: "wildcard inside name", record: types.DNSRecord{Type: "A", Name: "dev.*", Value: "192.0.2.1"}, wantErr: true},
+		{name: "partial wildcard label", record: types.DNSRecord{Type: "A", Name: "web*", Value: "192.0.2.1"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			record := tt.record
+			record.DomainID = "d1"
+			err := svc.validateRecord(&record)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("validateRecord() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && record.Type == "ALIAS" && strings.HasSuffix(record.Value, ".") {
+				t.Errorf("validateRecord() left the trailing dot on %q", record.Value)
+			}
+		})
+	}
+}
+
+func TestDNSService_AliasProviderSupport(t *testing.T) {
+	repo := storage.NewMockRepo()
+	svc := NewDNSService(repo)
+	expires := time.Now().AddDate(1, 0, 0)
+	if _, err := repo.UpsertDomains([]types.Domain{
+		{ID: "alias-supported", Name: "example.com", Provider: "dnsimple", ExpiresAt: expires},
+		{ID: "alias-unsupported", Name: "example.org", Provider: "hostinger", ExpiresAt: expires},
+	}); err != nil {
+		t.Fatalf("UpsertDomains() error: %v", err)
+	}
+
+	supported := types.DNSRecord{DomainID: "alias-supported", Type: "ANAME", Name: "@", Value: "myapp.herokudns.com", TTL: 3600}
+	if err := svc.CreateRecord(&supported); err != nil {
+		t.Fatalf("CreateRecord() on dnsimple error: %v", err)
+	}
+	if supported.Type != "ALIAS" {
+		t.Errorf("CreateRecord() stored type %q, want ALIAS", supported.Type)
+	}
+
+	unsupported := types.DNSRecord{DomainID: "alias-unsupported", Type: "ALIAS", Name: "@", Value: "myapp.herokudns.com", TTL: 3600}
+	if err := svc.CreateRecord(&unsupported); !errors.Is(err, types.ErrUnsupportedRecordType) {
+		t.Errorf("CreateRecord() on hostinger error = %v, want ErrUnsupportedRecordType", err)
+	}
+}
@@ -0,0 +1,121 @@
+package dns
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/rusiqe/domainvault/internal/providers"
+	"github.com/rusiqe/domainvault/internal/types"
+)
+
+// DefaultBackupRetention is how many DNS backups are kept per domain when not configured
+const DefaultBackupRetention = 20
+
+// PushResult is the outcome of writing a domain's records to a provider
+type PushResult struct {
+	DomainID string            `json:"domain_id"`
+	Provider string            `json:"provider"`
+	Backup   *types.DNSBackup  `json:"backup"`  // The zone as the provider had it before the push
+	Records  []types.DNSRecord `json:"records"` // What the provider has now
+}
+
+// SetBackupRetention keeps the newest keep backups of each domain; 0 or less uses
+// DefaultBackupRetention
+func (d *DNSService) SetBackupRetention(keep int) {
+	d.backupRetention = keep
+}
+
+// GetBackups returns a domain's DNS backups, newest first
+func (d *DNSService) GetBackups(domainID string) ([]types.DNSBackup, error) {
+	return d.repo.GetDNSBackups(domainID)
+}
+
+// GetBackup returns one of a domain's DNS backups
+func (d *DNSService) GetBackup(domainID, id string) (*types.DNSBackup, error) {
+	backup, err := d.repo.GetDNSBackup(id)
+	if err != nil {
+		return nil, err
+	}
+	if backup.DomainID != domainID {
+		return nil, types.ErrDNSBackupNotFound
+	}
+	return backup, nil
+}
+
+// PushRecords replaces a domain's zone at client's provider with records, and stores them as
+// the domain's records. The provider's current zone is fetched and saved as a backup first;
+// if that fails nothing is written, so every push can be undone with RestoreBackup. reason
+// describes the push in the backup.
+func (d *DNSService) PushRecords(domain types.Domain, client providers.RegistrarClient, records []types.DNSRecord, reason string) (*PushResult, error) {
+	provider := client.GetProviderName()
+	writer, ok := client.(providers.DNSWriter)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", types.ErrDNSWriteUnsupported, provider)
+	}
+
+	now := time.Now()
+	pushed := make([]types.DNSRecord, len(records))
+	for i, record := range records {
+		record.ID = ""
+		record.DomainID = domain.ID
+		record.CreatedAt, record.UpdatedAt = now, now
+		if err := d.validateRecord(&record); err != nil {
+			return nil, fmt.Errorf("%w at index %d: %w", types.ErrInvalidDNSRecord, i, err)
+		}
+		pushed[i] = record
+	}
+	if caps, ok := providers.GetCapabilities(provider); ok {
+		for i, record := range pushed {
+			if err := caps.ValidateRecord(record); err != nil {
+				return nil, fmt.Errorf("%w at index %d: %w", types.ErrInvalidDNSRecord, i, err)
+			}
+		}
+		if err := caps.ValidateRecordCount(len(pushed)); err != nil {
+			return nil, fmt.Errorf("%w: %w", types.ErrInvalidDNSRecord, err)
+		}
+	}
+
+	current, err := client.FetchDNSRecords(domain.Name)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to fetch %s's zone for %s: %v", types.ErrDNSBackupFailed, provider, domain.Name, err)
+	}
+	backup := &types.DNSBackup{
+		DomainID:  domain.ID,
+		Provider:  provider,
+		Reason:    reason,
+		Records:   append(types.DNSRecordList{}, current...),
+		CreatedBy: d.actor,
+	}
+	if err := d.repo.CreateDNSBackup(backup); err != nil {
+		return nil, fmt.Errorf("%w: %v", types.ErrDNSBackupFailed, err)
+	}
+	d.pruneBackups(domain.ID)
+
+	if err := writer.ReplaceDNSRecords(domain.Name, pushed); err != nil {
+		return nil, fmt.Errorf("failed to push records for %s to %s (the previous zone is in backup %s): %w", domain.Name, provider, backup.ID, err)
+	}
+	result := &PushResult{DomainID: domain.ID, Provider: provider, Backup: backup, Records: pushed}
+	if err := d.StoreProviderRecords(domain.ID, provider, pushed); err != nil {
+		return result, fmt.Errorf("pushed records for %s to %s but failed to store them: %w", domain.Name, provider, err)
+	}
+	return result, nil
+}
+
+// RestoreBackup pushes a backup's records back to client's provider. The zone being replaced
+// is backed up too, so a restore can itself be undone.
+func (d *DNSService) RestoreBackup(domain types.Domain, backup types.DNSBackup, client providers.RegistrarClient) (*PushResult, error) {
+	return d.PushRecords(domain, client, backup.Records, fmt.Sprintf("restore of backup %s", backup.ID))
+}
+
+// pruneBackups deletes a domain's backups beyond the retention limit. Failures are logged;
+// the backup being kept matters more than old ones going away.
+func (d *DNSService) pruneBackups(domainID string) {
+	keep := d.backupRetention
+	if keep <= 0 {
+		keep = DefaultBackupRetention
+	}
+	if err := d.repo.PruneDNSBackups(domainID, keep); err != nil {
+		log.Printf("Failed to prune DNS backups of domain %s: %v", domainID, err)
+	}
+}
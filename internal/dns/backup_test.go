@@ -0,0 +1,91 @@
+package dns
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/rusiqe/domainvault/internal/providers"
+	"github.com/rusiqe/domainvault/internal/storage"
+	"github.com/rusiqe/domainvault/internal/types"
+)
+
+// readOnlyClient is a provider that can't write DNS records
+type readOnlyClient struct{}
+
+func (readOnlyClient) FetchDomains() ([]types.Domain, error)                    { return nil, nil }
+func (readOnlyClient) GetProviderName() string                                  { return "readonly" }
+func (readOnlyClient) FetchDNSRecords(domain string) ([]types.DNSRecord, error) { return nil, nil }
+
+func TestDNSService_PushRecordsBacksUpFirst(t *testing.T) {
+	repo := storage.NewMockRepo()
+	svc := NewDNSService(repo).WithActor("admin")
+	client, err := providers.NewMockClient(providers.ProviderCredentials{})
+	if err != nil {
+		t.Fatalf("NewMockClient() error: %v", err)
+	}
+	domain := types.Domain{ID: "push-domain", Name: "example.com", Provider: "mock"}
+	original, _ := client.FetchDNSRecords(domain.Name)
+
+	records := []types.DNSRecord{{Type: "A", Name: "@", Value: "192.0.2.50", TTL: 3600}}
+	result, err := svc.PushRecords(domain, client, records, "test push")
+	if err != nil {
+		t.Fatalf("PushRecords() error: %v", err)
+	}
+	if result.Backup == nil || len(result.Backup.Records) != len(original) || result.Backup.CreatedBy != "admin" {
+		t.Fatalf("Expected a backup of the %d original records by admin, got %+v", len(original), result.Backup)
+	}
+	if live, _ := client.FetchDNSRecords(domain.Name); len(live) != 1 || live[0].Value != "192.0.2.50" {
+		t.Errorf("Expected the provider to have the pushed record, got %+v", live)
+	}
+	if stored, _ := svc.GetDomainRecords(domain.ID); len(stored) != 1 {
+		t.Errorf("Expected the pushed record to be stored, got %+v", stored)
+	}
+
+	restored, err := svc.RestoreBackup(domain, *result.Backup, client)
+	if err != nil {
+		t.Fatalf("RestoreBackup() error: %v", err)
+	}
+	if live, _ := client.FetchDNSRecords(domain.Name); !recordSetsEqual(live, original) {
+		t.Errorf("Expected the original zone back, got %+v", live)
+	}
+	if len(restored.Backup.Records) != 1 {
+		t.Errorf("Expected the restore to back up the pushed zone, got %+v", restored.Backup.Records)
+	}
+
+	backups, _ := svc.GetBackups(domain.ID)
+	if len(backups) != 2 || backups[0].ID != restored.Backup.ID {
+		t.Fatalf("Expected 2 backups, newest first, got %+v", backups)
+	}
+	if _, err := svc.GetBackup("other-domain", backups[0].ID); !errors.Is(err, types.ErrDNSBackupNotFound) {
+		t.Errorf("GetBackup() of another domain's backup error = %v, want ErrDNSBackupNotFound", err)
+	}
+}
+
+func TestDNSService_PushRecordsRetentionAndUnsupported(t *testing.T) {
+	repo := storage.NewMockRepo()
+	svc := NewDNSService(repo)
+	svc.SetBackupRetention(2)
+	client, _ := providers.NewMockClient(providers.ProviderCredentials{})
+	domain := types.Domain{ID: "retention-domain", Name: "example.com", Provider: "mock"}
+
+	records := []types.DNSRecord{{Type: "A", Name: "@", Value: "192.0.2.1", TTL: 3600}}
+	for i := 0; i < 4; i++ {
+		if _, err := svc.PushRecords(domain, client, records, "test push"); err != nil {
+			t.Fatalf("PushRecords() error: %v", err)
+		}
+	}
+	if backups, _ := svc.GetBackups(domain.ID); len(backups) != 2 {
+		t.Errorf("Expected 2 backups to be kept, got %d", len(backups))
+	}
+
+	if _, err := svc.PushRecords(domain, readOnlyClient{}, records, "test push"); !errors.Is(err, types.ErrDNSWriteUnsupported) {
+		t.Errorf("PushRecords() to a read-only provider error = %v, want ErrDNSWriteUnsupported", err)
+	}
+	invalid := []types.DNSRecord{{Type: "MX", Name: "@", Value: "mail.example.com"}} // No priority
+	if _, err := svc.PushRecords(domain, client, invalid, "test push"); !errors.Is(err, types.ErrInvalidDNSRecord) {
+		t.Errorf("PushRecords() of an invalid record error = %v, want ErrInvalidDNSRecord", err)
+	}
+	if backups, _ := svc.GetBackups(domain.ID); len(backups) != 2 {
+		t.Errorf("Expected rejected pushes not to take backups, got %d", len(backups))
+	}
+}
@@ -0,0 +1,65 @@
+package dns
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/rusiqe/domainvault/internal/types"
+)
+
+// RecordMatcher selects DNS records for bulk deletion. Empty fields match any record, but at
+// least one must be set.
+type RecordMatcher struct {
+	Type          string `json:"type"`           // Record type, e.g. "TXT"
+	NamePattern   string `json:"name_pattern"`   // Glob on the record name, e.g. "_acme-challenge*"
+	ValueContains string `json:"value_contains"` // Substring of the record value
+}
+
+// Validate normalizes the matcher and rejects one that would match every record
+func (m *RecordMatcher) Validate() error {
+	m.Type = strings.ToUpper(strings.TrimSpace(m.Type))
+	m.NamePattern = strings.ToLower(strings.TrimSpace(m.NamePattern))
+	if m.Type == "" && m.NamePattern == "" && m.ValueContains == "" {
+		return fmt.Errorf("%w: at least one of type, name_pattern or value_contains is required", types.ErrInvalidDNSRecord)
+	}
+	if _, err := path.Match(m.NamePattern, ""); err != nil {
+		return fmt.Errorf("%w: invalid name_pattern %q", types.ErrInvalidDNSRecord, m.NamePattern)
+	}
+	return nil
+}
+
+// Matches reports whether record satisfies every criterion that is set. Type and name are
+// compared case-insensitively; the value substring is matched exactly.
+func (m RecordMatcher) Matches(record types.DNSRecord) bool {
+	if m.Type != "" && !strings.EqualFold(record.Type, m.Type) {
+		return false
+	}
+	if m.NamePattern != "" {
+		if ok, _ := path.Match(m.NamePattern, strings.ToLower(record.Name)); !ok {
+			return false
+		}
+	}
+	return m.ValueContains == "" || strings.Contains(record.Value, m.ValueContains)
+}
+
+// DeleteMatching deletes a domain's records that match m, returning the deleted records. A
+// failed delete stops the run; records already deleted are still returned with the error.
+func (d *DNSService) DeleteMatching(domainID string, m RecordMatcher) ([]types.DNSRecord, error) {
+	records, err := d.repo.GetRecordsByDomain(domainID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get records: %w", err)
+	}
+
+	var deleted []types.DNSRecord
+	for _, record := range records {
+		if !m.Matches(record) {
+			continue
+		}
+		if err := d.DeleteRecord(record.ID); err != nil {
+			return deleted, fmt.Errorf("failed to delete %s record %s: %w", record.Type, record.Name, err)
+		}
+		deleted = append(deleted, record)
+	}
+	return deleted, nil
+}
@@ -0,0 +1,162 @@
+package dns
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/rusiqe/domainvault/internal/types"
+)
+
+// Zone bundle layout
+const (
+	BundleVersion      = 1
+	BundleManifestFile = "manifest.json"
+	maxBundleFileSize  = 10 << 20 // Largest manifest or zone file read from a bundle
+)
+
+// BundleEntry maps one domain in a zone bundle to its zone file
+type BundleEntry struct {
+	DomainID   string `json:"domain_id,omitempty"` // ID in the exporting portfolio; imports match on the name
+	DomainName string `json:"domain_name"`
+	File       string `json:"file"`
+	Records    int    `json:"records"`
+	Zone       string `json:"zone,omitempty"` // Zone file content; inline in JSON bundles, a separate file in zip bundles
+}
+
+// BundleManifest describes a bundle of BIND zone files, one per domain, exported together
+// for a migration or backup
+type BundleManifest struct {
+	Version     int           `json:"version"`
+	ProjectID   string        `json:"project_id,omitempty"`
+	ProjectName string        `json:"project_name,omitempty"`
+	ExportedAt  time.Time     `json:"exported_at"`
+	Domains     []BundleEntry `json:"domains"`
+}
+
+// BundleZoneFile is the path of a domain's zone file within a bundle
+func BundleZoneFile(domainName string) string {
+	return "zones/" + types.NormalizeDomainName(domainName) + ".zone"
+}
+
+// NewBundleEntry renders a domain's records as a bundle entry
+func NewBundleEntry(domain types.Domain, records []types.DNSRecord) BundleEntry {
+	return BundleEntry{
+		DomainID:   domain.ID,
+		DomainName: domain.Name,
+		File:       BundleZoneFile(domain.Name),
+		Records:    len(records),
+		Zone:       ExportZone(domain.Name, records),
+	}
+}
+
+// WriteZipBundle writes the bundle as a zip archive holding manifest.json and one zone file
+// per domain at the path its entry names
+func WriteZipBundle(w io.Writer, manifest BundleManifest) error {
+	archive := zip.NewWriter(w)
+	index := manifest
+	index.Domains = make([]BundleEntry, len(manifest.Domains))
+	for i, entry := range manifest.Domains {
+		file, err := archive.Create(entry.File)
+		if err != nil {
+			return fmt.Errorf("failed to add %s: %w", entry.File, err)
+		}
+		if _, err := io.WriteString(file, entry.Zone); err != nil {
+			return fmt.Errorf("failed to write %s: %w", entry.File, err)
+		}
+		entry.Zone = "" // The manifest only points at the file
+		index.Domains[i] = entry
+	}
+
+	file, err := archive.Create(BundleManifestFile)
+	if err != nil {
+		return fmt.Errorf("failed to add manifest: %w", err)
+	}
+	encoder := json.NewEncoder(file)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(index); err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+	return archive.Close()
+}
+
+// ReadBundle parses a zone bundle, either a zip archive from WriteZipBundle or a JSON manifest
+// with the zones inline, and returns the manifest with every entry's zone filled in
+func ReadBundle(data []byte) (*BundleManifest, error) {
+	if bytes.HasPrefix(data, []byte("PK\x03\x04")) {
+		return readZipBundle(data)
+	}
+
+	var manifest BundleManifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("bundle is neither a zip archive nor a JSON manifest: %w", err)
+	}
+	for _, entry := range manifest.Domains {
+		if entry.DomainName == "" {
+			return nil, fmt.Errorf("manifest entry for %q has no domain_name", entry.File)
+		}
+	}
+	return &manifest, nil
+}
+
+// readZipBundle reads a zip bundle's manifest and the zone files it points at
+func readZipBundle(data []byte) (*BundleManifest, error) {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("invalid zip archive: %w", err)
+	}
+	files := make(map[string]*zip.File, len(archive.File))
+	for _, file := range archive.File {
+		files[file.Name] = file
+	}
+
+	manifestFile, ok := files[BundleManifestFile]
+	if !ok {
+		return nil, fmt.Errorf("bundle has no %s", BundleManifestFile)
+	}
+	raw, err := readBundleFile(manifestFile)
+	if err != nil {
+		return nil, err
+	}
+	var manifest BundleManifest
+	if err := json.Unmarshal(raw, &manifest); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", BundleManifestFile, err)
+	}
+
+	for i, entry := range manifest.Domains {
+		if entry.DomainName == "" {
+			return nil, fmt.Errorf("manifest entry for %q has no domain_name", entry.File)
+		}
+		file, ok := files[entry.File]
+		if !ok {
+			return nil, fmt.Errorf("bundle is missing %s for %s", entry.File, entry.DomainName)
+		}
+		zone, err := readBundleFile(file)
+		if err != nil {
+			return nil, err
+		}
+		manifest.Domains[i].Zone = string(zone)
+	}
+	return &manifest, nil
+}
+
+// readBundleFile reads one file from a zip bundle, refusing ones over maxBundleFileSize
+func readBundleFile(file *zip.File) ([]byte, error) {
+	reader, err := file.Open()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", file.Name, err)
+	}
+	defer reader.Close()
+
+	content, err := io.ReadAll(io.LimitReader(reader, maxBundleFileSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", file.Name, err)
+	}
+	if len(content) > maxBundleFileSize {
+		return nil, fmt.Errorf("%s is larger than %d bytes", file.Name, maxBundleFileSize)
+	}
+	return content, nil
+}
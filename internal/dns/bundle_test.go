@@ -0,0 +1,88 @@
+package dns
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rusiqe/domainvault/internal/types"
+)
+
+func TestZoneBundleRoundTrip(t *testing.T) {
+	priority := 10
+	manifest := BundleManifest{
+		Version:     BundleVersion,
+		ProjectID:   "p1",
+		ProjectName: "Shop",
+		ExportedAt:  time.Now(),
+		Domains: []BundleEntry{
+			NewBundleEntry(types.Domain{ID: "d1", Name: "Example.com"}, []types.DNSRecord{
+				{Type: "A", Name: "@", Value: "192.0.2.1", TTL: 300},
+				{Type: "MX", Name: "@", Value: "mail.example.com", TTL: 3600, Priority: &priority},
+			}),
+			NewBundleEntry(types.Domain{ID: "d2", Name: "example.org"}, nil),
+		},
+	}
+	if manifest.Domains[0].File != "zones/example.com.zone" {
+		t.Errorf("Expected a normalized zone file path, got %q", manifest.Domains[0].File)
+	}
+
+	var archive bytes.Buffer
+	if err := WriteZipBundle(&archive, manifest); err != nil {
+		t.Fatalf("WriteZipBundle() unexpected error: %v", err)
+	}
+	fromZip, err := ReadBundle(archive.Bytes())
+	if err != nil {
+		t.Fatalf("ReadBundle(zip) unexpected error: %v", err)
+	}
+
+	inline, err := json.Marshal(manifest)
+	if err != nil {
+		t.Fatalf("Failed to marshal manifest: %v", err)
+	}
+	fromJSON, err := ReadBundle(inline)
+	if err != nil {
+		t.Fatalf("ReadBundle(json) unexpected error: %v", err)
+	}
+
+	for _, read := range []*BundleManifest{fromZip, fromJSON} {
+		if read.ProjectID != "p1" || len(read.Domains) != 2 {
+			t.Fatalf("Expected the manifest to round-trip, got %+v", read)
+		}
+		records, err := ImportZone("d1", read.Domains[0].Zone)
+		if err != nil {
+			t.Fatalf("ImportZone() unexpected error: %v", err)
+		}
+		if len(records) != 2 || read.Domains[0].Records != 2 {
+			t.Errorf("Expected 2 records for example.com, got %d (manifest says %d)", len(records), read.Domains[0].Records)
+		}
+	}
+}
+
+func TestReadBundleErrors(t *testing.T) {
+	var archive bytes.Buffer
+	manifest := BundleManifest{Version: BundleVersion, Domains: []BundleEntry{{DomainName: "example.com", File: "zones/example.com.zone", Zone: "$ORIGIN example.com.\n"}}}
+	if err := WriteZipBundle(&archive, manifest); err != nil {
+		t.Fatalf("WriteZipBundle() unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		data []byte
+		want string
+	}{
+		{"not a bundle", []byte("hello"), "neither a zip archive nor a JSON manifest"},
+		{"entry without a name", []byte(`{"domains":[{"file":"zones/x.zone"}]}`), "no domain_name"},
+		{"truncated zip", archive.Bytes()[:archive.Len()/2], "invalid zip archive"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ReadBundle(tt.data)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("ReadBundle() error = %v, want one containing %q", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package dns
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/rusiqe/domainvault/internal/types"
+)
+
+// CAA property tags (RFC 8659 plus the widely deployed extensions)
+var caaTags = map[string]bool{
+	"issue":        true,
+	"issuewild":    true,
+	"iodef":        true,
+	"issuemail":    true,
+	"contactemail": true,
+	"contactphone": true,
+}
+
+// CAARecord is the parsed form of a CAA record value: `<flags> <tag> "<value>"`
+type CAARecord struct {
+	Flags int    `json:"flags"`
+	Tag   string `json:"tag"`
+	Value string `json:"value"`
+}
+
+// ParseCAA parses a CAA record value such as `0 issue "letsencrypt.org"`.
+// The value may be quoted or a single unquoted token.
+func ParseCAA(value string) (CAARecord, error) {
+	fields := strings.SplitN(strings.TrimSpace(value), " ", 3)
+	if len(fields) != 3 {
+		return CAARecord{}, fmt.Errorf(`CAA value must be in the form: 0 issue "ca.example.com"`)
+	}
+
+	flags, err := strconv.Atoi(fields[0])
+	if err != nil || flags < 0 || flags > 255 {
+		return CAARecord{}, fmt.Errorf("CAA flags must be a number between 0 and 255")
+	}
+
+	tag := strings.ToLower(fields[1])
+	if !caaTags[tag] {
+		return CAARecord{}, fmt.Errorf("unsupported CAA tag: %s", fields[1])
+	}
+
+	raw := strings.TrimSpace(fields[2])
+	if strings.HasPrefix(raw, `"`) {
+		if len(raw) < 2 || !strings.HasSuffix(raw, `"`) {
+			return CAARecord{}, fmt.Errorf("CAA value has an unterminated quote")
+		}
+		raw = raw[1 : len(raw)-1]
+	} else if strings.ContainsAny(raw, " \t") {
+		return CAARecord{}, fmt.Errorf("CAA value containing spaces must be quoted")
+	}
+	if strings.Contains(raw, `"`) {
+		return CAARecord{}, fmt.Errorf("CAA value must not contain quotes")
+	}
+	if raw == "" && tag != "issue" && tag != "issuewild" {
+		// An empty issue value (";") forbids issuance, but other tags need a value
+		return CAARecord{}, fmt.Errorf("CAA %s value is required", tag)
+	}
+
+	return CAARecord{Flags: flags, Tag: tag, Value: raw}, nil
+}
+
+// String formats the record in canonical presentation form
+func (c CAARecord) String() string {
+	return fmt.Sprintf(`%d %s "%s"`, c.Flags, c.Tag, c.Value)
+}
+
+// CAAPolicy summarises which certificate authorities a domain's CAA records allow
+type CAAPolicy struct {
+	Issuers         []string `json:"issuers"`          // CAs allowed to issue certificates
+	WildcardIssuers []string `json:"wildcard_issuers"` // CAs allowed to issue wildcard certificates
+	ReportURIs      []string `json:"report_uris"`      // iodef destinations for violation reports
+	IssuanceBlocked bool     `json:"issuance_blocked"` // An empty issue value forbids all issuance
+}
+
+// AnalyzeCAA builds the CAA policy from a domain's records; unparsable records are skipped.
+// Without issuewild records, wildcard issuance falls back to the issue list (RFC 8659 §4.3).
+func AnalyzeCAA(records []types.DNSRecord) CAAPolicy {
+	policy := CAAPolicy{Issuers: []string{}, WildcardIssuers: []string{}, ReportURIs: []string{}}
+	hasWildcard := false
+
+	for _, record := range records {
+		if !strings.EqualFold(record.Type, "CAA") {
+			continue
+		}
+		caa, err := ParseCAA(record.Value)
+		if err != nil {
+			continue
+		}
+
+		issuer := strings.TrimSpace(strings.SplitN(caa.Value, ";", 2)[0])
+		switch caa.Tag {
+		case "issue":
+			if issuer == "" {
+				policy.IssuanceBlocked = true
+			} else {
+				policy.Issuers = append(policy.Issuers, issuer)
+			}
+		case "issuewild":
+			hasWildcard = true
+			if issuer != "" {
+				policy.WildcardIssuers = append(policy.WildcardIssuers, issuer)
+			}
+		case "iodef":
+			policy.ReportURIs = append(policy.ReportURIs, caa.Value)
+		}
+	}
+
+	if !hasWildcard {
+		policy.WildcardIssuers = append(policy.WildcardIssuers, policy.Issuers...)
+	}
+	return policy
+}
@@ -0,0 +1,102 @@
+package dns
+
+import (
+	"sync"
+	"time"
+
+	"github.com/rusiqe/domainvault/internal/types"
+)
+
+// CacheSourceDatabase is the cache source of a domain's stored records
+const CacheSourceDatabase = "database"
+
+// RecordCache holds each domain's DNS records as last read, per source: the stored records
+// or a provider's live ones. An entry lives for the cache TTL or the shortest TTL among its
+// records, whichever is less, so a cached answer is never older than DNS itself allows.
+//
+// Every change to a domain's records invalidates its entries. A read that started before the
+// change isn't cached when it finishes, so it can't bring back what the change replaced.
+type RecordCache struct {
+	mu          sync.Mutex
+	ttl         time.Duration
+	entries     map[string]map[string]cachedRecords // Domain ID, then source
+	generations map[string]uint64                   // Bumped by each invalidation of a domain
+	now         func() time.Time
+}
+
+// cachedRecords is one source's records for a domain and when they expire
+type cachedRecords struct {
+	records []types.DNSRecord
+	expires time.Time
+}
+
+// NewRecordCache creates a cache whose entries live at most ttl; 0 or less disables caching
+func NewRecordCache(ttl time.Duration) *RecordCache {
+	return &RecordCache{
+		ttl:         ttl,
+		entries:     make(map[string]map[string]cachedRecords),
+		generations: make(map[string]uint64),
+		now:         time.Now,
+	}
+}
+
+// Load returns a domain's records from source, reading them with fetch when they aren't
+// cached or have expired. Callers get their own copy to modify.
+func (c *RecordCache) Load(domainID, source string, fetch func() ([]types.DNSRecord, error)) ([]types.DNSRecord, error) {
+	if c == nil || c.ttl <= 0 || domainID == "" {
+		return fetch()
+	}
+
+	c.mu.Lock()
+	if entry, ok := c.entries[domainID][source]; ok && c.now().Before(entry.expires) {
+		c.mu.Unlock()
+		return copyRecords(entry.records), nil
+	}
+	generation := c.generations[domainID]
+	c.mu.Unlock()
+
+	records, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.generations[domainID] == generation {
+		if c.entries[domainID] == nil {
+			c.entries[domainID] = make(map[string]cachedRecords)
+		}
+		c.entries[domainID][source] = cachedRecords{records: copyRecords(records), expires: c.now().Add(c.lifetime(records))}
+	}
+	return records, nil
+}
+
+// Invalidate drops every cached source of a domain's records
+func (c *RecordCache) Invalidate(domainID string) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, domainID)
+	c.generations[domainID]++
+}
+
+// lifetime is the cache TTL capped at the shortest TTL among records
+func (c *RecordCache) lifetime(records []types.DNSRecord) time.Duration {
+	lifetime := c.ttl
+	for _, record := range records {
+		if ttl := time.Duration(record.TTL) * time.Second; ttl > 0 && ttl < lifetime {
+			lifetime = ttl
+		}
+	}
+	return lifetime
+}
+
+// copyRecords copies records so callers annotating them don't change the cached ones
+func copyRecords(records []types.DNSRecord) []types.DNSRecord {
+	if records == nil {
+		return nil
+	}
+	return append([]types.DNSRecord{}, records...)
+}
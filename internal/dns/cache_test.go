@@ -0,0 +1,130 @@
+package dns
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rusiqe/domainvault/internal/storage"
+	"github.com/rusiqe/domainvault/internal/types"
+)
+
+func TestDNSService_EditsBypassCacheTTL(t *testing.T) {
+	repo := storage.NewMockRepo()
+	svc := NewDNSService(repo)
+	svc.SetCacheTTL(time.Hour)
+
+	record := types.DNSRecord{DomainID: "cache-domain", Type: "A", Name: "www", Value: "192.0.2.1", TTL: 3600}
+	if err := svc.CreateRecord(&record); err != nil {
+		t.Fatalf("CreateRecord() error: %v", err)
+	}
+	records, err := svc.GetDomainRecords("cache-domain")
+	if err != nil || len(records) != 1 {
+		t.Fatalf("Expected 1 record, got %v, %v", records, err)
+	}
+
+	// A write that doesn't go through the service stays hidden until the entry expires
+	repo.CreateRecord(&types.DNSRecord{DomainID: "cache-domain", Type: "TXT", Name: "@", Value: "external", TTL: 3600})
+	if records, _ := svc.GetDomainRecords("cache-domain"); len(records) != 1 {
+		t.Fatalf("Expected the cached record set, got %d records", len(records))
+	}
+
+	// Edits through the service show up immediately, whatever the TTL
+	update := types.DNSRecord{ID: record.ID, DomainID: "cache-domain", Type: "A", Name: "www", Value: "192.0.2.2", TTL: 3600}
+	if err := svc.WithActor("admin").UpdateRecord(&update); err != nil {
+		t.Fatalf("UpdateRecord() error: %v", err)
+	}
+	records, _ = svc.GetDomainRecords("cache-domain")
+	if len(records) != 2 || valueOf(records, "A") != "192.0.2.2" {
+		t.Fatalf("Expected the updated record after the edit, got %+v", records)
+	}
+
+	if err := svc.DeleteRecord(record.ID); err != nil {
+		t.Fatalf("DeleteRecord() error: %v", err)
+	}
+	if records, _ := svc.GetDomainRecords("cache-domain"); valueOf(records, "A") != "" {
+		t.Fatalf("Expected the deleted record to be gone, got %+v", records)
+	}
+
+	replacement := []types.DNSRecord{{Type: "MX", Name: "@", Value: "mail.example.com", TTL: 3600, Priority: intPtr(10)}}
+	if err := svc.BulkUpdateRecords("cache-domain", replacement); err != nil {
+		t.Fatalf("BulkUpdateRecords() error: %v", err)
+	}
+	records, _ = svc.GetDomainRecords("cache-domain")
+	if len(records) != 1 || records[0].Type != "MX" {
+		t.Fatalf("Expected only the replacement record, got %+v", records)
+	}
+}
+
+func TestDNSService_BulkUpdateRecordsValidatesFirst(t *testing.T) {
+	repo := storage.NewMockRepo()
+	svc := NewDNSService(repo)
+	existing := types.DNSRecord{DomainID: "bulk-domain", Type: "A", Name: "@", Value: "192.0.2.1", TTL: 3600}
+	if err := svc.CreateRecord(&existing); err != nil {
+		t.Fatalf("CreateRecord() error: %v", err)
+	}
+
+	invalid := []types.DNSRecord{
+		{Type: "A", Name: "@", Value: "192.0.2.9"},
+		{Type: "MX", Name: "@", Value: "mail.example.com"}, // No priority
+	}
+	if err := svc.BulkUpdateRecords("bulk-domain", invalid); err == nil {
+		t.Fatal("Expected an invalid record to be rejected")
+	}
+	if records, _ := svc.GetDomainRecords("bulk-domain"); len(records) != 1 {
+		t.Errorf("Expected the existing records to be kept, got %+v", records)
+	}
+}
+
+func TestRecordCache_Lifetime(t *testing.T) {
+	now := time.Now()
+	cache := NewRecordCache(10 * time.Minute)
+	cache.now = func() time.Time { return now }
+
+	fetches := 0
+	fetch := func() ([]types.DNSRecord, error) {
+		fetches++
+		return []types.DNSRecord{{Type: "A", Value: "192.0.2.1", TTL: 60}, {Type: "MX", Value: "mx.example.com", TTL: 3600}}, nil
+	}
+	cache.Load("d1", CacheSourceDatabase, fetch)
+	now = now.Add(59 * time.Second)
+	cache.Load("d1", CacheSourceDatabase, fetch)
+	if fetches != 1 {
+		t.Fatalf("Expected a cached read within the shortest record TTL, got %d fetches", fetches)
+	}
+	now = now.Add(2 * time.Second)
+	cache.Load("d1", CacheSourceDatabase, fetch)
+	if fetches != 2 {
+		t.Errorf("Expected the entry to expire with its shortest record TTL, got %d fetches", fetches)
+	}
+}
+
+func TestRecordCache_ReadRacingInvalidation(t *testing.T) {
+	cache := NewRecordCache(time.Hour)
+	stale := func() ([]types.DNSRecord, error) {
+		// The records change while they're being read
+		cache.Invalidate("d1")
+		return []types.DNSRecord{{Type: "A", Value: "192.0.2.1"}}, nil
+	}
+	if records, _ := cache.Load("d1", "cloudflare", stale); len(records) != 1 {
+		t.Fatalf("Expected the read's records to be returned, got %+v", records)
+	}
+
+	fetched := false
+	cache.Load("d1", "cloudflare", func() ([]types.DNSRecord, error) {
+		fetched = true
+		return nil, nil
+	})
+	if !fetched {
+		t.Error("Expected a read that raced an invalidation not to be cached")
+	}
+}
+
+// valueOf returns the value of the first record of recordType, or "" if there is none
+func valueOf(records []types.DNSRecord, recordType string) string {
+	for _, record := range records {
+		if record.Type == recordType {
+			return record.Value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,197 @@
+package dns
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rusiqe/domainvault/internal/types"
+)
+
+// Clone conflict policies
+const (
+	ClonePolicyReplace = "replace" // The target's records are replaced by the source's
+	ClonePolicyMerge   = "merge"   // The source's records are added to the target's
+)
+
+// CloneOptions controls how one domain's DNS records are copied to another
+type CloneOptions struct {
+	Policy string `json:"policy"`  // ClonePolicyReplace or ClonePolicyMerge; defaults to replace
+	DryRun bool   `json:"dry_run"` // Report what would change without writing anything
+}
+
+// CloneSkip is a source record a merge left out and why
+type CloneSkip struct {
+	Record types.DNSRecord `json:"record"`
+	Reason string          `json:"reason"`
+}
+
+// CloneResult describes a clone: the target's records that were added and removed, and the
+// source records a merge skipped
+type CloneResult struct {
+	SourceDomainID string            `json:"source_domain_id"`
+	TargetDomainID string            `json:"target_domain_id"`
+	Policy         string            `json:"policy"`
+	DryRun         bool              `json:"dry_run"`
+	Added          []types.DNSRecord `json:"added"`
+	Removed        []types.DNSRecord `json:"removed"`
+	Skipped        []CloneSkip       `json:"skipped"`
+	Unchanged      int               `json:"unchanged"` // Records the target already had
+	Total          int               `json:"total"`     // The target's record count afterwards
+}
+
+// Validate normalizes the options and rejects an unknown policy
+func (o *CloneOptions) Validate() error {
+	o.Policy = strings.ToLower(strings.TrimSpace(o.Policy))
+	switch o.Policy {
+	case "":
+		o.Policy = ClonePolicyReplace
+	case ClonePolicyReplace, ClonePolicyMerge:
+	default:
+		return fmt.Errorf("%w: policy must be %q or %q", types.ErrInvalidDNSRecord, ClonePolicyReplace, ClonePolicyMerge)
+	}
+	return nil
+}
+
+// RewriteForDomain adapts a record copied from sourceName for targetName: fully qualified
+// names and hostname targets at or under sourceName are moved under targetName. Other values,
+// such as addresses and TXT content, are copied as they are.
+func RewriteForDomain(record types.DNSRecord, sourceName, targetName string) types.DNSRecord {
+	sourceName = types.NormalizeDomainName(sourceName)
+	targetName = types.NormalizeDomainName(targetName)
+	record.Name = rewriteHost(record.Name, sourceName, targetName)
+	switch strings.ToUpper(record.Type) {
+	case "CNAME", "MX", "NS", "SRV", "PTR", "ALIAS", "ANAME":
+		record.Value = rewriteHost(record.Value, sourceName, targetName)
+	}
+	return record
+}
+
+// rewriteHost replaces a sourceName suffix of host with targetName, keeping a trailing dot
+func rewriteHost(host, sourceName, targetName string) string {
+	trimmed := strings.TrimSpace(host)
+	dot := strings.HasSuffix(trimmed, ".")
+	name := strings.ToLower(strings.TrimSuffix(trimmed, "."))
+	switch {
+	case name == sourceName:
+		name = targetName
+	case strings.HasSuffix(name, "."+sourceName):
+		name = strings.TrimSuffix(name, sourceName) + targetName
+	default:
+		return host
+	}
+	if dot {
+		name += "."
+	}
+	return name
+}
+
+// CloneRecords copies the source domain's stored DNS records to the target, rewriting names
+// and targets for the target's name. Every copied record is validated, and the resulting zone
+// is checked against the target's provider before anything is written. Replace makes the
+// target's records match the source's; merge keeps the target's records and adds the source's
+// that it doesn't have yet, skipping ones that would clash with a CNAME.
+func (d *DNSService) CloneRecords(source, target types.Domain, opts CloneOptions) (*CloneResult, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	if source.ID == target.ID {
+		return nil, fmt.Errorf("%w: source and target are the same domain", types.ErrInvalidDNSRecord)
+	}
+
+	sourceRecords, err := d.repo.GetRecordsByDomain(source.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get source records: %w", err)
+	}
+	existing, err := d.repo.GetRecordsByDomain(target.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get target records: %w", err)
+	}
+
+	copied := make([]types.DNSRecord, 0, len(sourceRecords))
+	for i, record := range sourceRecords {
+		record = RewriteForDomain(record, source.Name, target.Name)
+		record.ID = ""
+		record.DomainID = target.ID
+		if err := d.validateRecord(&record); err != nil {
+			return nil, fmt.Errorf("%w: source record %d (%s %s): %w", types.ErrInvalidDNSRecord, i, record.Type, record.Name, err)
+		}
+		copied = append(copied, record)
+	}
+
+	result := &CloneResult{
+		SourceDomainID: source.ID,
+		TargetDomainID: target.ID,
+		Policy:         opts.Policy,
+		DryRun:         opts.DryRun,
+		Added:          []types.DNSRecord{},
+		Removed:        []types.DNSRecord{},
+		Skipped:        []CloneSkip{},
+	}
+
+	var final []types.DNSRecord
+	if opts.Policy == ClonePolicyReplace {
+		// Records the target already has keep their IDs, so only real changes show in history
+		remaining := make(map[string][]types.DNSRecord, len(existing))
+		for _, record := range existing {
+			remaining[recordKey(record)] = append(remaining[recordKey(record)], record)
+		}
+		for _, record := range copied {
+			key := recordKey(record)
+			if matches := remaining[key]; len(matches) > 0 {
+				final = append(final, matches[0])
+				remaining[key] = matches[1:]
+				result.Unchanged++
+				continue
+			}
+			final = append(final, record)
+			result.Added = append(result.Added, record)
+		}
+		for _, record := range existing {
+			key := recordKey(record)
+			if matches := remaining[key]; len(matches) > 0 && matches[0].ID == record.ID {
+				remaining[key] = matches[1:]
+				result.Removed = append(result.Removed, record)
+			}
+		}
+	} else {
+		final = append(final, existing...)
+		have := make(map[string]bool, len(existing))
+		names := make(map[string]string, len(existing)) // Lowercase name to the type of a record there, CNAME if any
+		for _, record := range existing {
+			have[recordKey(record)] = true
+			if names[strings.ToLower(record.Name)] != "CNAME" {
+				names[strings.ToLower(record.Name)] = strings.ToUpper(record.Type)
+			}
+		}
+		for _, record := range copied {
+			name := strings.ToLower(record.Name)
+			switch {
+			case have[recordKey(record)]:
+				result.Unchanged++
+				continue
+			case names[name] == "CNAME":
+				result.Skipped = append(result.Skipped, CloneSkip{Record: record, Reason: "target has a CNAME at this name"})
+				continue
+			case strings.EqualFold(record.Type, "CNAME") && names[name] != "":
+				result.Skipped = append(result.Skipped, CloneSkip{Record: record, Reason: "target already has records at this name"})
+				continue
+			}
+			have[recordKey(record)] = true
+			names[name] = strings.ToUpper(record.Type)
+			final = append(final, record)
+			result.Added = append(result.Added, record)
+		}
+	}
+	result.Total = len(final)
+
+	if err := d.ValidateForProvider(target.ID, result.Added, len(final)); err != nil {
+		return nil, err
+	}
+	if opts.DryRun || (len(result.Added) == 0 && len(result.Removed) == 0) {
+		return result, nil
+	}
+	if err := d.BulkUpdateRecords(target.ID, final); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,80 @@
+package dns
+
+import (
+	"testing"
+
+	"github.com/rusiqe/domainvault/internal/storage"
+	"github.com/rusiqe/domainvault/internal/types"
+)
+
+func TestRewriteForDomain(t *testing.T) {
+	tests := []struct {
+		record types.DNSRecord
+		name   string
+		value  string
+	}{
+		{types.DNSRecord{Type: "CNAME", Name: "www", Value: "example.com."}, "www", "staging.com."},
+		{types.DNSRecord{Type: "MX", Name: "@", Value: "mail.Example.com"}, "@", "mail.staging.com"},
+		{types.DNSRecord{Type: "CNAME", Name: "cdn.example.com.", Value: "cdn.provider.net"}, "cdn.staging.com.", "cdn.provider.net"},
+		{types.DNSRecord{Type: "CNAME", Name: "x", Value: "notexample.com"}, "x", "notexample.com"},
+		{types.DNSRecord{Type: "TXT", Name: "@", Value: "v=spf1 include:example.com ~all"}, "@", "v=spf1 include:example.com ~all"},
+		{types.DNSRecord{Type: "A", Name: "@", Value: "192.0.2.1"}, "@", "192.0.2.1"},
+	}
+	for _, tt := range tests {
+		got := RewriteForDomain(tt.record, "example.com", "staging.com")
+		if got.Name != tt.name || got.Value != tt.value {
+			t.Errorf("RewriteForDomain(%s %s %s) = %s %s, want %s %s", tt.record.Type, tt.record.Name, tt.record.Value, got.Name, got.Value, tt.name, tt.value)
+		}
+	}
+}
+
+func TestDNSService_CloneRecords(t *testing.T) {
+	repo := storage.NewMockRepo()
+	svc := NewDNSService(repo)
+	source := types.Domain{ID: "clone-source", Name: "example.com"}
+	target := types.Domain{ID: "clone-target", Name: "staging.com"}
+	for _, record := range []types.DNSRecord{
+		{DomainID: source.ID, Type: "A", Name: "@", Value: "192.0.2.1", TTL: 3600},
+		{DomainID: source.ID, Type: "CNAME", Name: "www", Value: "example.com", TTL: 3600},
+		{DomainID: target.ID, Type: "A", Name: "@", Value: "192.0.2.1", TTL: 3600},
+		{DomainID: target.ID, Type: "TXT", Name: "www", Value: "keep", TTL: 3600},
+	} {
+		if err := svc.CreateRecord(&record); err != nil {
+			t.Fatalf("CreateRecord() error: %v", err)
+		}
+	}
+
+	merge, err := svc.CloneRecords(source, target, CloneOptions{Policy: ClonePolicyMerge})
+	if err != nil {
+		t.Fatalf("CloneRecords(merge) error: %v", err)
+	}
+	if merge.Unchanged != 1 || len(merge.Added) != 0 || len(merge.Skipped) != 1 || merge.Total != 2 {
+		t.Fatalf("Expected the CNAME to be skipped beside the existing TXT, got %+v", merge)
+	}
+
+	dryRun, err := svc.CloneRecords(source, target, CloneOptions{DryRun: true})
+	if err != nil {
+		t.Fatalf("CloneRecords(dry run) error: %v", err)
+	}
+	if dryRun.Policy != ClonePolicyReplace || len(dryRun.Added) != 1 || len(dryRun.Removed) != 1 || dryRun.Unchanged != 1 {
+		t.Fatalf("Expected the dry run to replace the TXT with the CNAME, got %+v", dryRun)
+	}
+	if records, _ := svc.GetDomainRecords(target.ID); valueOf(records, "TXT") != "keep" {
+		t.Fatalf("Expected a dry run not to change the target, got %+v", records)
+	}
+
+	if _, err := svc.CloneRecords(source, target, CloneOptions{Policy: ClonePolicyReplace}); err != nil {
+		t.Fatalf("CloneRecords(replace) error: %v", err)
+	}
+	records, _ := svc.GetDomainRecords(target.ID)
+	if len(records) != 2 || valueOf(records, "CNAME") != "staging.com" || valueOf(records, "TXT") != "" {
+		t.Errorf("Expected the target to match the source under its own name, got %+v", records)
+	}
+
+	if _, err := svc.CloneRecords(source, source, CloneOptions{}); err == nil {
+		t.Error("Expected cloning a domain onto itself to fail")
+	}
+	if _, err := svc.CloneRecords(source, target, CloneOptions{Policy: "overwrite"}); err == nil {
+		t.Error("Expected an unknown policy to be rejected")
+	}
+}
@@ -0,0 +1,129 @@
+package dns
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/rusiqe/domainvault/internal/types"
+)
+
+// RecordMismatch is a name and type both sides have records for, with different values
+type RecordMismatch struct {
+	Type string            `json:"type"`
+	Name string            `json:"name"`
+	A    []types.DNSRecord `json:"a"`
+	B    []types.DNSRecord `json:"b"`
+}
+
+// RecordComparison is the difference between two sets of records for the same domain, such as
+// the zone at a DNS host and at the registrar
+type RecordComparison struct {
+	Domain     string            `json:"domain"`
+	InSync     bool              `json:"in_sync"`
+	Matching   int               `json:"matching"` // Records present on both sides
+	OnlyInA    []types.DNSRecord `json:"only_in_a"`
+	OnlyInB    []types.DNSRecord `json:"only_in_b"`
+	Mismatches []RecordMismatch  `json:"mismatches"`
+}
+
+// CompareRecords diffs two record sets by content, the way a refresh decides whether stored
+// records changed. Records are normalized first, so differences in how providers write names
+// (FQDN or relative, trailing dots, case) and TXT chunking don't count. Records with no exact
+// match are paired by name and type into mismatches; the rest are only on one side. With
+// ignoreTTL, records differing only in TTL match.
+func CompareRecords(domainName string, a, b []types.DNSRecord, ignoreTTL bool) *RecordComparison {
+	origin := types.NormalizeDomainName(domainName)
+	comparison := &RecordComparison{
+		Domain:     origin,
+		OnlyInA:    []types.DNSRecord{},
+		OnlyInB:    []types.DNSRecord{},
+		Mismatches: []RecordMismatch{},
+	}
+
+	normalize := func(records []types.DNSRecord) []types.DNSRecord {
+		normalized := make([]types.DNSRecord, len(records))
+		for i, r := range records {
+			normalized[i] = normalizeForCompare(r, origin, ignoreTTL)
+		}
+		return normalized
+	}
+	a, b = normalize(a), normalize(b)
+
+	remaining := make(map[string][]types.DNSRecord, len(b))
+	for _, r := range b {
+		remaining[recordKey(r)] = append(remaining[recordKey(r)], r)
+	}
+	var unmatchedA []types.DNSRecord
+	for _, r := range a {
+		key := recordKey(r)
+		if len(remaining[key]) > 0 {
+			remaining[key] = remaining[key][1:]
+			comparison.Matching++
+			continue
+		}
+		unmatchedA = append(unmatchedA, r)
+	}
+	var unmatchedB []types.DNSRecord
+	for _, r := range b {
+		key := recordKey(r)
+		if len(remaining[key]) > 0 {
+			remaining[key] = remaining[key][1:]
+			unmatchedB = append(unmatchedB, r)
+		}
+	}
+
+	// Pair what's left by owner name and type
+	nameType := func(r types.DNSRecord) string { return r.Type + " " + r.Name }
+	byNameB := make(map[string][]types.DNSRecord)
+	for _, r := range unmatchedB {
+		byNameB[nameType(r)] = append(byNameB[nameType(r)], r)
+	}
+	mismatches := make(map[string]*RecordMismatch)
+	var order []string
+	for _, r := range unmatchedA {
+		key := nameType(r)
+		if _, ok := byNameB[key]; !ok {
+			comparison.OnlyInA = append(comparison.OnlyInA, r)
+			continue
+		}
+		if mismatches[key] == nil {
+			mismatches[key] = &RecordMismatch{Type: r.Type, Name: r.Name, B: byNameB[key]}
+			order = append(order, key)
+		}
+		mismatches[key].A = append(mismatches[key].A, r)
+	}
+	for _, r := range unmatchedB {
+		if mismatches[nameType(r)] == nil {
+			comparison.OnlyInB = append(comparison.OnlyInB, r)
+		}
+	}
+	sort.Strings(order)
+	for _, key := range order {
+		comparison.Mismatches = append(comparison.Mismatches, *mismatches[key])
+	}
+
+	comparison.InSync = len(comparison.OnlyInA) == 0 && len(comparison.OnlyInB) == 0 && len(comparison.Mismatches) == 0
+	return comparison
+}
+
+// normalizeForCompare puts a record in the form stored records use: a relative lower-case
+// owner name, hostnames without trailing dots and TXT values unchunked
+func normalizeForCompare(r types.DNSRecord, origin string, ignoreTTL bool) types.DNSRecord {
+	r.Type = canonicalRecordType(r.Type)
+	r.Name = strings.ToLower(strings.TrimSuffix(r.Name, "."))
+	if r.Name == "" {
+		r.Name = "@"
+	}
+	r.Name = relativeName(r.Name, origin)
+
+	switch r.Type {
+	case "CNAME", "ALIAS", "MX", "NS", "SRV", "PTR":
+		r.Value = strings.ToLower(strings.TrimSuffix(r.Value, "."))
+	case "TXT":
+		r.Value = types.DechunkTXT(r.Value)
+	}
+	if ignoreTTL {
+		r.TTL = 0
+	}
+	return r
+}
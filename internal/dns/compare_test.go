@@ -0,0 +1,59 @@
+package dns
+
+import (
+	"testing"
+
+	"github.com/rusiqe/domainvault/internal/types"
+)
+
+func TestCompareRecords(t *testing.T) {
+	a := []types.DNSRecord{
+		{Type: "A", Name: "example.com.", Value: "192.0.2.1", TTL: 300},
+		{Type: "CNAME", Name: "www.example.com", Value: "Example.com.", TTL: 300},
+		{Type: "TXT", Name: "@", Value: `"v=spf1 " "-all"`, TTL: 300},
+		{Type: "MX", Name: "@", Value: "mail.example.com", TTL: 300, Priority: intPtr(10)},
+		{Type: "A", Name: "api", Value: "192.0.2.2", TTL: 300},
+		{Type: "A", Name: "old", Value: "192.0.2.9", TTL: 300},
+	}
+	b := []types.DNSRecord{
+		{Type: "a", Name: "@", Value: "192.0.2.1", TTL: 300},
+		{Type: "CNAME", Name: "WWW", Value: "example.com", TTL: 300},
+		{Type: "TXT", Name: "example.com", Value: "v=spf1 -all", TTL: 300},
+		{Type: "MX", Name: "@", Value: "mail.example.com.", TTL: 300, Priority: intPtr(10)},
+		{Type: "A", Name: "api", Value: "192.0.2.3", TTL: 300},
+		{Type: "A", Name: "new", Value: "192.0.2.8", TTL: 300},
+	}
+
+	cmp := CompareRecords("Example.com", a, b, false)
+	if cmp.InSync {
+		t.Error("expected the record sets not to be in sync")
+	}
+	if cmp.Matching != 4 {
+		t.Errorf("matching = %d, want 4", cmp.Matching)
+	}
+	if len(cmp.OnlyInA) != 1 || cmp.OnlyInA[0].Name != "old" {
+		t.Errorf("only in a = %+v, want old", cmp.OnlyInA)
+	}
+	if len(cmp.OnlyInB) != 1 || cmp.OnlyInB[0].Name != "new" {
+		t.Errorf("only in b = %+v, want new", cmp.OnlyInB)
+	}
+	if len(cmp.Mismatches) != 1 {
+		t.Fatalf("got %d mismatches, want 1: %+v", len(cmp.Mismatches), cmp.Mismatches)
+	}
+	m := cmp.Mismatches[0]
+	if m.Type != "A" || m.Name != "api" || m.A[0].Value != "192.0.2.2" || m.B[0].Value != "192.0.2.3" {
+		t.Errorf("mismatch = %+v", m)
+	}
+}
+
+func TestCompareRecords_TTL(t *testing.T) {
+	a := []types.DNSRecord{{Type: "A", Name: "@", Value: "192.0.2.1", TTL: 300}}
+	b := []types.DNSRecord{{Type: "A", Name: "@", Value: "192.0.2.1", TTL: 3600}}
+
+	if cmp := CompareRecords("example.com", a, b, false); cmp.InSync || len(cmp.Mismatches) != 1 {
+		t.Errorf("records differing in TTL compared as %+v, want one mismatch", cmp)
+	}
+	if cmp := CompareRecords("example.com", a, b, true); !cmp.InSync || cmp.Matching != 1 {
+		t.Errorf("records differing in TTL with ignoreTTL compared as %+v, want in sync", cmp)
+	}
+}
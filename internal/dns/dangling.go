@@ -0,0 +1,259 @@
+package dns
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rusiqe/domainvault/internal/types"
+)
+
+// danglingWorkers is how many records are checked at once
+const danglingWorkers = 10
+
+// maxFingerprintBody is how much of a target's response is searched for a takeover fingerprint
+const maxFingerprintBody = 64 << 10
+
+// takeoverService is a cloud service whose hostnames can be claimed by anyone once the
+// owner deprovisions them, leaving CNAMEs to them open to takeover
+type takeoverService struct {
+	Name    string
+	Pattern *regexp.Regexp // Matches the service's target hostnames
+	// Fingerprint is text the service serves for an unclaimed name. Empty when an unclaimed
+	// target simply stops resolving.
+	Fingerprint string
+}
+
+// takeoverServices are well-known services vulnerable to subdomain takeover
+var takeoverServices = []takeoverService{
+	{Name: "AWS S3", Pattern: regexp.MustCompile(`\.s3([.-][a-z0-9-]+)*\.amazonaws\.com$`), Fingerprint: "NoSuchBucket"},
+	{Name: "AWS Elastic Beanstalk", Pattern: regexp.MustCompile(`\.elasticbeanstalk\.com$`)},
+	{Name: "Microsoft Azure", Pattern: regexp.MustCompile(`\.(azurewebsites\.net|cloudapp\.net|cloudapp\.azure\.com|trafficmanager\.net|blob\.core\.windows\.net|azureedge\.net|azure-api\.net)$`)},
+	{Name: "GitHub Pages", Pattern: regexp.MustCompile(`\.github\.io$`), Fingerprint: "There isn't a GitHub Pages site here."},
+	{Name: "Heroku", Pattern: regexp.MustCompile(`\.(herokuapp|herokudns)\.com$`), Fingerprint: "No such app"},
+	{Name: "Shopify", Pattern: regexp.MustCompile(`\.myshopify\.com$`), Fingerprint: "Sorry, this shop is currently unavailable."},
+	{Name: "Fastly", Pattern: regexp.MustCompile(`\.fastly\.net$`), Fingerprint: "Fastly error: unknown domain"},
+	{Name: "Pantheon", Pattern: regexp.MustCompile(`\.pantheonsite\.io$`), Fingerprint: "The gods are wise"},
+	{Name: "Surge.sh", Pattern: regexp.MustCompile(`\.surge\.sh$`), Fingerprint: "project not found"},
+	{Name: "Bitbucket", Pattern: regexp.MustCompile(`\.bitbucket\.io$`), Fingerprint: "Repository not found"},
+	{Name: "Zendesk", Pattern: regexp.MustCompile(`\.zendesk\.com$`), Fingerprint: "Help Center Closed"},
+}
+
+// matchTakeoverService returns the service a CNAME target belongs to, if it's a known one
+func matchTakeoverService(target string) *takeoverService {
+	target = strings.ToLower(strings.TrimSuffix(target, "."))
+	for i := range takeoverServices {
+		if takeoverServices[i].Pattern.MatchString(target) {
+			return &takeoverServices[i]
+		}
+	}
+	return nil
+}
+
+// DanglingChecker looks for CNAME, ALIAS and A records pointing at infrastructure that no
+// longer exists: CNAME and ALIAS targets that don't resolve or that a cloud service reports as
+// unclaimed, and A record addresses nothing answers on
+type DanglingChecker struct {
+	lookupHost func(ctx context.Context, host string) ([]string, error)
+	fetch      func(ctx context.Context, host string) (string, error) // Body served for http://host/
+	dial       func(ctx context.Context, address string) error
+	timeout    time.Duration // Per lookup, request or connection
+	workers    int
+}
+
+// NewDanglingChecker creates a checker that uses the system resolver
+func NewDanglingChecker() *DanglingChecker {
+	dc := &DanglingChecker{timeout: 5 * time.Second, workers: danglingWorkers}
+	client := &http.Client{
+		Timeout: dc.timeout,
+		// Unclaimed targets serve their fingerprint directly; a redirect means something answered
+		CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse },
+	}
+	dialer := &net.Dialer{Timeout: dc.timeout}
+
+	dc.lookupHost = net.DefaultResolver.LookupHost
+	dc.fetch = func(ctx context.Context, host string) (string, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://"+host+"/", nil)
+		if err != nil {
+			return "", err
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return "", err
+		}
+		defer resp.Body.Close()
+		body, err := io.ReadAll(io.LimitReader(resp.Body, maxFingerprintBody))
+		return string(body), err
+	}
+	dc.dial = func(ctx context.Context, address string) error {
+		conn, err := dialer.DialContext(ctx, "tcp", address)
+		if err != nil {
+			return err
+		}
+		return conn.Close()
+	}
+	return dc
+}
+
+// Check checks the CNAME, ALIAS and A records of the given domains; records maps domain IDs
+// to their records and other record types are ignored
+func (dc *DanglingChecker) Check(ctx context.Context, domains []types.Domain, records map[string][]types.DNSRecord) *types.DanglingDNSReport {
+	start := time.Now()
+	report := &types.DanglingDNSReport{Findings: []types.DanglingDNSFinding{}, Domains: len(domains), CheckedAt: start}
+
+	type job struct {
+		domain types.Domain
+		record types.DNSRecord
+	}
+	jobs := make(chan job)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for i := 0; i < dc.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				finding, err := dc.CheckRecord(ctx, j.domain, j.record)
+				mu.Lock()
+				report.RecordsChecked++
+				if err != nil {
+					report.Errors++
+				} else if finding != nil {
+					report.Findings = append(report.Findings, *finding)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+queue:
+	for _, domain := range domains {
+		for _, record := range records[domain.ID] {
+			if record.Type != "CNAME" && record.Type != "ALIAS" && record.Type != "A" {
+				continue
+			}
+			select {
+			case jobs <- job{domain: domain, record: record}:
+			case <-ctx.Done():
+				break queue
+			}
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	report.DurationMs = time.Since(start).Milliseconds()
+	report.Finish()
+	return report
+}
+
+// CheckRecord checks one CNAME, ALIAS or A record. It returns nil when the target looks live,
+// and an error when the check itself failed, e.g. on a resolver timeout.
+func (dc *DanglingChecker) CheckRecord(ctx context.Context, domain types.Domain, record types.DNSRecord) (*types.DanglingDNSFinding, error) {
+	finding := &types.DanglingDNSFinding{
+		DomainID:   domain.ID,
+		DomainName: domain.Name,
+		RecordID:   record.ID,
+		Host:       recordHost(record.Name, domain.Name),
+		Type:       record.Type,
+		Target:     strings.TrimSuffix(record.Value, "."),
+	}
+
+	switch record.Type {
+	case "CNAME", "ALIAS":
+		return dc.checkCNAME(ctx, finding)
+	case "A":
+		return dc.checkA(ctx, finding)
+	default:
+		return nil, nil
+	}
+}
+
+// checkCNAME flags targets that don't resolve, and targets on takeover-prone services that
+// either don't resolve or serve the service's unclaimed-name page for the record's host
+func (dc *DanglingChecker) checkCNAME(ctx context.Context, finding *types.DanglingDNSFinding) (*types.DanglingDNSFinding, error) {
+	service := matchTakeoverService(finding.Target)
+	if service != nil {
+		finding.Service = service.Name
+	}
+
+	lookupCtx, cancel := context.WithTimeout(ctx, dc.timeout)
+	_, err := dc.lookupHost(lookupCtx, finding.Target)
+	cancel()
+	if err != nil {
+		var dnsErr *net.DNSError
+		if !errors.As(err, &dnsErr) || !dnsErr.IsNotFound {
+			return nil, fmt.Errorf("failed to resolve %s: %w", finding.Target, err)
+		}
+		if service != nil {
+			finding.Risk = types.DanglingTakeover
+			finding.Reason = fmt.Sprintf("%s does not exist; anyone who creates it on %s controls %s", finding.Target, service.Name, finding.Host)
+			return finding, nil
+		}
+		finding.Risk = types.DanglingUnresolved
+		finding.Reason = fmt.Sprintf("%s does not exist", finding.Target)
+		return finding, nil
+	}
+
+	// A wildcard record's host can't be requested, and some services have no fingerprint
+	if service == nil || service.Fingerprint == "" || strings.HasPrefix(finding.Host, "*") {
+		return nil, nil
+	}
+	fetchCtx, cancel := context.WithTimeout(ctx, dc.timeout)
+	defer cancel()
+	body, err := dc.fetch(fetchCtx, finding.Host)
+	if err != nil {
+		return nil, nil // An unclaimed target still serves its page, so a failed request isn't one
+	}
+	if strings.Contains(body, service.Fingerprint) {
+		finding.Risk = types.DanglingTakeover
+		finding.Reason = fmt.Sprintf("%s reports %s as unclaimed; anyone who claims it there controls %s", service.Name, finding.Target, finding.Host)
+		return finding, nil
+	}
+	return nil, nil
+}
+
+// checkA flags public addresses that accept connections on neither port 80 nor 443, such as
+// a released cloud IP. Private and reserved addresses can't be reached from here and are skipped.
+func (dc *DanglingChecker) checkA(ctx context.Context, finding *types.DanglingDNSFinding) (*types.DanglingDNSFinding, error) {
+	ip := net.ParseIP(finding.Target)
+	if ip == nil || ip.IsPrivate() || ip.IsLoopback() || ip.IsUnspecified() || ip.IsLinkLocalUnicast() || ip.IsMulticast() {
+		return nil, nil
+	}
+
+	var lastErr error
+	for _, port := range []string{"443", "80"} {
+		dialCtx, cancel := context.WithTimeout(ctx, dc.timeout)
+		lastErr = dc.dial(dialCtx, net.JoinHostPort(finding.Target, port))
+		cancel()
+		if lastErr == nil {
+			return nil, nil
+		}
+	}
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	finding.Risk = types.DanglingUnreachable
+	finding.Reason = fmt.Sprintf("%s accepts no connections on port 80 or 443 (%v); it may have been released", finding.Target, lastErr)
+	return finding, nil
+}
+
+// recordHost is the fully qualified name of a record stored as @ or a name relative to the domain
+func recordHost(name, domainName string) string {
+	domainName = types.NormalizeDomainName(domainName)
+	name = strings.ToLower(strings.TrimSuffix(name, "."))
+	if name == "" || name == "@" || name == domainName {
+		return domainName
+	}
+	if strings.HasSuffix(name, "."+domainName) {
+		return name
+	}
+	return name + "." + domainName
+}
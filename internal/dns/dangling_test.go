@@ -0,0 +1,118 @@
+package dns
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/rusiqe/domainvault/internal/types"
+)
+
+func newStubDanglingChecker() *DanglingChecker {
+	return &DanglingChecker{
+		lookupHost: func(_ context.Context, host string) ([]string, error) {
+			switch host {
+			case "gone.example.net", "old-bucket.s3.amazonaws.com":
+				return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+			case "slow.example.net":
+				return nil, &net.DNSError{Err: "i/o timeout", Name: host, IsTimeout: true}
+			}
+			return []string{"192.0.2.10"}, nil
+		},
+		fetch: func(_ context.Context, host string) (string, error) {
+			if host == "docs.example.com" {
+				return "<h1>404</h1><p>There isn't a GitHub Pages site here.</p>", nil
+			}
+			return "<html>ok</html>", nil
+		},
+		dial: func(_ context.Context, address string) error {
+			if address == "203.0.113.9:443" || address == "203.0.113.9:80" {
+				return errors.New("connection refused")
+			}
+			return nil
+		},
+		workers: 2,
+	}
+}
+
+func TestDanglingChecker_Check(t *testing.T) {
+	domain := types.Domain{ID: "dangling-domain", Name: "Example.com"}
+	records := map[string][]types.DNSRecord{domain.ID: {
+		{ID: "1", Type: "CNAME", Name: "www", Value: "live.example.net."},
+		{ID: "2", Type: "CNAME", Name: "legacy", Value: "gone.example.net"},
+		{ID: "3", Type: "CNAME", Name: "assets", Value: "old-bucket.s3.amazonaws.com"},
+		{ID: "4", Type: "CNAME", Name: "docs", Value: "example-org.github.io"},
+		{ID: "5", Type: "CNAME", Name: "blog", Value: "example-blog.github.io"},
+		{ID: "6", Type: "CNAME", Name: "timeout", Value: "slow.example.net"},
+		{ID: "7", Type: "A", Name: "@", Value: "192.0.2.1"},
+		{ID: "8", Type: "A", Name: "old", Value: "203.0.113.9"},
+		{ID: "9", Type: "A", Name: "intranet", Value: "10.0.0.5"},
+		{ID: "10", Type: "TXT", Name: "@", Value: "v=spf1 -all"},
+	}}
+
+	report := newStubDanglingChecker().Check(context.Background(), []types.Domain{domain}, records)
+
+	if report.RecordsChecked != 9 || report.Errors != 1 {
+		t.Errorf("checked %d records with %d errors, want 9 and 1", report.RecordsChecked, report.Errors)
+	}
+	want := []struct {
+		host    string
+		risk    types.DanglingRisk
+		service string
+	}{
+		{"assets.example.com", types.DanglingTakeover, "AWS S3"},
+		{"docs.example.com", types.DanglingTakeover, "GitHub Pages"},
+		{"legacy.example.com", types.DanglingUnresolved, ""},
+		{"old.example.com", types.DanglingUnreachable, ""},
+	}
+	if len(report.Findings) != len(want) {
+		t.Fatalf("got %d findings, want %d: %+v", len(report.Findings), len(want), report.Findings)
+	}
+	for i, w := range want {
+		got := report.Findings[i]
+		if got.Host != w.host || got.Risk != w.risk || got.Service != w.service {
+			t.Errorf("finding %d = %s %s %q, want %s %s %q", i, got.Host, got.Risk, got.Service, w.host, w.risk, w.service)
+		}
+	}
+	if report.Summary[types.DanglingTakeover] != 2 || report.Summary[types.DanglingUnreachable] != 1 {
+		t.Errorf("summary = %v", report.Summary)
+	}
+}
+
+func TestMatchTakeoverService(t *testing.T) {
+	tests := map[string]string{
+		"bucket.s3.amazonaws.com":                   "AWS S3",
+		"bucket.s3-website-us-east-1.amazonaws.com": "AWS S3",
+		"bucket.s3.eu-west-1.amazonaws.com.":        "AWS S3",
+		"myapp.azurewebsites.net":                   "Microsoft Azure",
+		"myapp.herokudns.com":                       "Heroku",
+		"d111111abcdef8.cloudfront.net":             "",
+		"ec2-192-0-2-1.compute-1.amazonaws.com":     "",
+		"notgithub.io.example.com":                  "",
+	}
+	for target, want := range tests {
+		got := ""
+		if service := matchTakeoverService(target); service != nil {
+			got = service.Name
+		}
+		if got != want {
+			t.Errorf("matchTakeoverService(%q) = %q, want %q", target, got, want)
+		}
+	}
+}
+
+func TestRecordHost(t *testing.T) {
+	tests := []struct{ name, want string }{
+		{"@", "example.com"},
+		{"", "example.com"},
+		{"www", "www.example.com"},
+		{"WWW.Example.com.", "www.example.com"},
+		{"*", "*.example.com"},
+	}
+	for _, tt := range tests {
+		if got := recordHost(tt.name, "Example.com"); got != tt.want {
+			t.Errorf("recordHost(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package dns
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/rusiqe/domainvault/internal/types"
+)
+
+// ErrUnsupportedEmailProvider is returned when no setup recipe exists for an email provider
+var ErrUnsupportedEmailProvider = errors.New("unsupported email provider")
+
+// EmailSetupOptions carries optional provider-specific inputs for the setup wizard
+type EmailSetupOptions struct {
+	Tenant     string // Microsoft 365 tenant name (the <tenant>.onmicrosoft.com prefix)
+	DMARCEmail string // Address that receives aggregate DMARC reports
+}
+
+// EmailSetup describes the DNS records a domain needs for an email provider
+type EmailSetup struct {
+	Provider    string            `json:"provider"`
+	DisplayName string            `json:"display_name"`
+	Records     []types.DNSRecord `json:"records"`
+	Notes       []string          `json:"notes"`
+}
+
+// emailProviderNames maps accepted provider keys (and aliases) to canonical keys
+var emailProviderNames = map[string]string{
+	"google":           "google",
+	"google_workspace": "google",
+	"gsuite":           "google",
+	"microsoft":        "microsoft",
+	"microsoft365":     "microsoft",
+	"office365":        "microsoft",
+	"m365":             "microsoft",
+	"zoho":             "zoho",
+}
+
+// SupportedEmailProviders returns the canonical email provider keys accepted by BuildEmailSetup
+func SupportedEmailProviders() []string {
+	seen := make(map[string]bool)
+	var names []string
+	for _, canonical := range emailProviderNames {
+		if !seen[canonical] {
+			seen[canonical] = true
+			names = append(names, canonical)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// BuildEmailSetup returns the MX, SPF, DKIM and DMARC records needed to run email for
+// domainName on the given provider. Records are stubs bound to domainID and can be
+// created through the regular DNS record endpoints.
+func BuildEmailSetup(domainID, domainName, provider string, opts EmailSetupOptions) (*EmailSetup, error) {
+	canonical, ok := emailProviderNames[strings.ToLower(strings.TrimSpace(provider))]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s (supported: %s)", ErrUnsupportedEmailProvider, provider, strings.Join(SupportedEmailProviders(), ", "))
+	}
+
+	dmarcEmail := opts.DMARCEmail
+	if dmarcEmail == "" {
+		dmarcEmail = "dmarc-reports@" + domainName
+	}
+
+	var setup *EmailSetup
+	switch canonical {
+	case "google":
+		setup = &EmailSetup{
+			Provider:    canonical,
+			DisplayName: "Google Workspace",
+			Records: []types.DNSRecord{
+				{Type: "MX", Name: "@", Value: "aspmx.l.google.com", TTL: 3600, Priority: intPtr(1)},
+				{Type: "MX", Name: "@", Value: "alt1.aspmx.l.google.com", TTL: 3600, Priority: intPtr(5)},
+				{Type: "MX", Name: "@", Value: "alt2.aspmx.l.google.com", TTL: 3600, Priority: intPtr(5)},
+				{Type: "MX", Name: "@", Value: "alt3.aspmx.l.google.com", TTL: 3600, Priority: intPtr(10)},
+				{Type: "MX", Name: "@", Value: "alt4.aspmx.l.google.com", TTL: 3600, Priority: intPtr(10)},
+				{Type: "TXT", Name: "@", Value: "v=spf1 include:_spf.google.com ~all", TTL: 3600},
+				{Type: "TXT", Name: "google._domainkey", Value: "v=DKIM1; k=rsa; p=<public key from Admin console>", TTL: 3600},
+			},
+			Notes: []string{
+				"Generate the DKIM key in Admin console > Apps > Google Workspace > Gmail > Authenticate email and replace the placeholder value.",
+			},
+		}
+	case "microsoft":
+		tenant := opts.Tenant
+		if tenant == "" {
+			tenant = "<tenant>"
+		}
+		dashed := strings.ReplaceAll(domainName, ".", "-")
+		setup = &EmailSetup{
+			Provider:    canonical,
+			DisplayName: "Microsoft 365",
+			Records: []types.DNSRecord{
+				{Type: "MX", Name: "@", Value: dashed + ".mail.protection.outlook.com", TTL: 3600, Priority: intPtr(0)},
+				{Type: "TXT", Name: "@", Value: "v=spf1 include:spf.protection.outlook.com -all", TTL: 3600},
+				{Type: "CNAME", Name: "autodiscover", Value: "autodiscover.outlook.com", TTL: 3600},
+				{Type: "CNAME", Name: "selector1._domainkey", Value: fmt.Sprintf("selector1-%s._domainkey.%s.onmicrosoft.com", dashed, tenant), TTL: 3600},
+				{Type: "CNAME", Name: "selector2._domainkey", Value: fmt.Sprintf("selector2-%s._domainkey.%s.onmicrosoft.com", dashed, tenant), TTL: 3600},
+			},
+			Notes: []string{
+				"Enable DKIM signing in the Microsoft Defender portal after the selector CNAMEs resolve.",
+			},
+		}
+		if opts.Tenant == "" {
+			setup.Notes = append(setup.Notes, "Pass ?tenant=<name> to fill in the onmicrosoft.com tenant for the DKIM selectors.")
+		}
+	case "zoho":
+		setup = &EmailSetup{
+			Provider:    canonical,
+			DisplayName: "Zoho Mail",
+			Records: []types.DNSRecord{
+				{Type: "MX", Name: "@", Value: "mx.zoho.com", TTL: 3600, Priority: intPtr(10)},
+				{Type: "MX", Name: "@", Value: "mx2.zoho.com", TTL: 3600, Priority: intPtr(20)},
+				{Type: "MX", Name: "@", Value: "mx3.zoho.com", TTL: 3600, Priority: intPtr(50)},
+				{Type: "TXT", Name: "@", Value: "v=spf1 include:zoho.com ~all", TTL: 3600},
+				{Type: "TXT", Name: "zmail._domainkey", Value: "v=DKIM1; k=rsa; p=<public key from Zoho Mail admin console>", TTL: 3600},
+			},
+			Notes: []string{
+				"Generate the DKIM key in Zoho Mail Admin Console > Email Authentication > DKIM and replace the placeholder value.",
+			},
+		}
+	}
+
+	// DMARC is provider independent; start in monitoring mode
+	setup.Records = append(setup.Records, types.DNSRecord{
+		Type:  "TXT",
+		Name:  "_dmarc",
+		Value: fmt.Sprintf("v=DMARC1; p=none; rua=mailto:%s; fo=1", dmarcEmail),
+		TTL:   3600,
+	})
+	setup.Notes = append(setup.Notes, "DMARC starts at p=none; tighten to quarantine or reject once reports show aligned mail.")
+
+	for i := range setup.Records {
+		setup.Records[i].DomainID = domainID
+	}
+
+	return setup, nil
+}
+
+// EmailSetupConflicts lists existing records that the setup would replace or duplicate:
+// MX records, SPF policies at the apex, and DMARC policies.
+func EmailSetupConflicts(existing []types.DNSRecord) []types.DNSRecord {
+	var conflicts []types.DNSRecord
+	for _, record := range existing {
+		value := strings.ToLower(record.Value)
+		switch {
+		case record.Type == "MX":
+			conflicts = append(conflicts, record)
+		case record.Type == "TXT" && strings.HasPrefix(value, "v=spf1"):
+			conflicts = append(conflicts, record)
+		case record.Type == "TXT" && record.Name == "_dmarc":
+			conflicts = append(conflicts, record)
+		}
+	}
+	return conflicts
+}
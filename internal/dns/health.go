@@ -0,0 +1,534 @@
+package dns
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	mdns "github.com/miekg/dns"
+
+	"github.com/rusiqe/domainvault/internal/types"
+)
+
+// HealthStatus is the outcome of a live DNS health check
+type HealthStatus string
+
+const (
+	HealthPass HealthStatus = "pass"
+	HealthWarn HealthStatus = "warn"
+	HealthFail HealthStatus = "fail"
+)
+
+// DNS health check categories, in report order
+const (
+	HealthCategoryParent      = "parent"
+	HealthCategoryNameservers = "nameservers"
+	HealthCategorySOA         = "soa"
+)
+
+// recursionProbeName is looked up with recursion requested to find open resolvers;
+// an authoritative-only server refuses it or answers without recursion available
+const recursionProbeName = "www.iana.org."
+
+// HealthCheck is one check in a DNS health report
+type HealthCheck struct {
+	Name    string       `json:"name"`
+	Status  HealthStatus `json:"status"`
+	Message string       `json:"message"`
+	Details []string     `json:"details,omitempty"`
+}
+
+// HealthCategory groups the checks of one category; its status is the worst of its checks
+type HealthCategory struct {
+	Name   string        `json:"name"`
+	Status HealthStatus  `json:"status"`
+	Checks []HealthCheck `json:"checks"`
+}
+
+// NameserverProbe is what one address of a delegated nameserver answered
+type NameserverProbe struct {
+	Host          string   `json:"host"`
+	Address       string   `json:"address"`
+	Responding    bool     `json:"responding"`
+	Authoritative bool     `json:"authoritative"`
+	Serial        uint32   `json:"serial,omitempty"`
+	Nameservers   []string `json:"nameservers,omitempty"` // NS set the server itself returns
+	OpenRecursion bool     `json:"open_recursion"`
+	Error         string   `json:"error,omitempty"`
+
+	soa *mdns.SOA
+}
+
+// HealthReport is the result of checking a domain against its live nameservers, in the
+// spirit of intodns.com
+type HealthReport struct {
+	Domain     string               `json:"domain"`
+	Status     HealthStatus         `json:"status"` // Worst status of any check
+	Summary    map[HealthStatus]int `json:"summary"`
+	Delegation []string             `json:"delegation"` // Nameservers the parent zone delegates to
+	Probes     []NameserverProbe    `json:"probes"`
+	Categories []HealthCategory     `json:"categories"`
+	CheckedAt  time.Time            `json:"checked_at"`
+}
+
+// HealthChecker queries a domain's parent zone and authoritative nameservers directly
+type HealthChecker struct {
+	resolver string        // Recursive resolver used to find the parent zone and nameserver addresses
+	timeout  time.Duration // Per query
+}
+
+// NewHealthChecker creates a checker that uses the system's first configured resolver
+func NewHealthChecker() *HealthChecker {
+	resolver := "1.1.1.1:53"
+	if conf, err := mdns.ClientConfigFromFile("/etc/resolv.conf"); err == nil && len(conf.Servers) > 0 {
+		resolver = net.JoinHostPort(conf.Servers[0], conf.Port)
+	}
+	return &HealthChecker{resolver: resolver, timeout: 3 * time.Second}
+}
+
+// Check runs the live health checks for a domain: the parent delegation and glue, whether
+// every nameserver responds authoritatively (no lame delegation), agrees on the NS set and
+// SOA serial, refuses recursion, and whether the SOA timers are sane
+func (hc *HealthChecker) Check(domainName string) *HealthReport {
+	domain := mdns.Fqdn(types.NormalizeDomainName(domainName))
+	report := &HealthReport{
+		Domain:     strings.TrimSuffix(domain, "."),
+		Delegation: []string{},
+		Probes:     []NameserverProbe{},
+		CheckedAt:  time.Now(),
+	}
+	defer report.finish()
+
+	delegation, glue, err := hc.parentDelegation(domain)
+	if err != nil {
+		report.add(HealthCategoryParent, "Delegation", HealthFail, err.Error())
+		return report
+	}
+	report.Delegation = trimDots(delegation)
+	report.add(HealthCategoryParent, "Delegation", HealthPass,
+		fmt.Sprintf("The parent zone delegates to %d nameservers", len(delegation)), report.Delegation...)
+
+	if len(delegation) < 2 {
+		report.add(HealthCategoryParent, "Nameserver count", HealthWarn,
+			"Only one nameserver is delegated; at least two are recommended (RFC 2182)")
+	} else {
+		report.add(HealthCategoryParent, "Nameserver count", HealthPass,
+			fmt.Sprintf("%d nameservers are delegated", len(delegation)))
+	}
+	checkGlue(report, domain, delegation, glue)
+
+	// Glue gives in-zone nameserver addresses; the rest are resolved normally
+	var unresolved []string
+	addresses := make(map[string][]string, len(delegation))
+	for _, host := range delegation {
+		addrs := glue[host]
+		if len(addrs) == 0 {
+			addrs = hc.lookupAddrs(host)
+		}
+		if len(addrs) == 0 {
+			unresolved = append(unresolved, strings.TrimSuffix(host, "."))
+			continue
+		}
+		addresses[host] = addrs
+	}
+	if len(unresolved) > 0 {
+		report.add(HealthCategoryNameservers, "Nameserver addresses", HealthFail,
+			"Some nameservers have no IPv4 address", unresolved...)
+	} else {
+		report.add(HealthCategoryNameservers, "Nameserver addresses", HealthPass,
+			"Every nameserver has an IPv4 address")
+	}
+
+	report.Probes = hc.probeAll(domain, addresses)
+	checkProbes(report, delegation)
+	checkSOA(report, delegation)
+	return report
+}
+
+// query sends one query to server, retrying over TCP if the UDP answer was truncated
+func (hc *HealthChecker) query(server, name string, qtype uint16, recursive bool) (*mdns.Msg, error) {
+	msg := new(mdns.Msg)
+	msg.SetQuestion(mdns.Fqdn(name), qtype)
+	msg.RecursionDesired = recursive
+
+	client := &mdns.Client{Timeout: hc.timeout}
+	resp, _, err := client.Exchange(msg, server)
+	if err == nil && resp.Truncated {
+		client.Net = "tcp"
+		resp, _, err = client.Exchange(msg, server)
+	}
+	return resp, err
+}
+
+// lookupAddrs resolves a hostname's IPv4 addresses through the recursive resolver
+func (hc *HealthChecker) lookupAddrs(host string) []string {
+	resp, err := hc.query(hc.resolver, host, mdns.TypeA, true)
+	if err != nil {
+		return nil
+	}
+	var addrs []string
+	for _, rr := range resp.Answer {
+		if a, ok := rr.(*mdns.A); ok {
+			addrs = append(addrs, a.A.String())
+		}
+	}
+	return addrs
+}
+
+// parentDelegation asks the parent zone's own nameservers for the domain's NS records.
+// Their referral is the delegation registries publish, along with any glue.
+func (hc *HealthChecker) parentDelegation(domain string) ([]string, map[string][]string, error) {
+	labels := mdns.SplitDomainName(domain)
+	for i := 1; i < len(labels); i++ {
+		parent := mdns.Fqdn(strings.Join(labels[i:], "."))
+		resp, err := hc.query(hc.resolver, parent, mdns.TypeNS, true)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to look up the nameservers of %s: %v", parent, err)
+		}
+		var parentServers []string
+		for _, rr := range resp.Answer {
+			if ns, ok := rr.(*mdns.NS); ok {
+				parentServers = append(parentServers, ns.Ns)
+			}
+		}
+		if len(parentServers) == 0 {
+			continue // Not a zone cut, try the next label up
+		}
+
+		for _, server := range parentServers {
+			for _, addr := range hc.lookupAddrs(server) {
+				resp, err := hc.query(net.JoinHostPort(addr, "53"), domain, mdns.TypeNS, false)
+				if err != nil {
+					continue
+				}
+				if resp.Rcode == mdns.RcodeNameError {
+					return nil, nil, fmt.Errorf("%s is not delegated: the %s zone does not know it", strings.TrimSuffix(domain, "."), parent)
+				}
+				if delegation, glue := referral(resp, domain); len(delegation) > 0 {
+					return delegation, glue, nil
+				}
+			}
+		}
+		return nil, nil, fmt.Errorf("no nameserver of %s returned a delegation for %s", parent, strings.TrimSuffix(domain, "."))
+	}
+	return nil, nil, fmt.Errorf("could not find the parent zone of %s", strings.TrimSuffix(domain, "."))
+}
+
+// referral extracts the NS names for domain and the IPv4 glue from a parent's response
+func referral(resp *mdns.Msg, domain string) ([]string, map[string][]string) {
+	var names []string
+	for _, rr := range append(resp.Ns, resp.Answer...) {
+		if ns, ok := rr.(*mdns.NS); ok && strings.EqualFold(ns.Hdr.Name, domain) {
+			names = append(names, strings.ToLower(ns.Ns))
+		}
+	}
+
+	glue := map[string][]string{}
+	for _, rr := range resp.Extra {
+		if a, ok := rr.(*mdns.A); ok {
+			host := strings.ToLower(a.Hdr.Name)
+			glue[host] = append(glue[host], a.A.String())
+		}
+	}
+	return uniqueSorted(names), glue
+}
+
+// checkGlue reports nameservers inside the domain itself that lack glue at the parent;
+// without it they can't be found, since finding them requires the zone they serve
+func checkGlue(report *HealthReport, domain string, delegation []string, glue map[string][]string) {
+	var inZone, missing []string
+	for _, host := range delegation {
+		if mdns.IsSubDomain(domain, host) {
+			inZone = append(inZone, host)
+			if len(glue[host]) == 0 {
+				missing = append(missing, strings.TrimSuffix(host, "."))
+			}
+		}
+	}
+
+	switch {
+	case len(missing) > 0:
+		report.add(HealthCategoryParent, "Glue records", HealthFail,
+			"Nameservers inside the domain have no glue records at the parent", missing...)
+	case len(inZone) > 0:
+		report.add(HealthCategoryParent, "Glue records", HealthPass, "The parent has glue for every in-zone nameserver")
+	default:
+		report.add(HealthCategoryParent, "Glue records", HealthPass, "No nameserver is inside the domain, so no glue is needed")
+	}
+}
+
+// probeAll queries every nameserver address concurrently, returning probes sorted by host and address
+func (hc *HealthChecker) probeAll(domain string, addresses map[string][]string) []NameserverProbe {
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	probes := []NameserverProbe{}
+	for host, addrs := range addresses {
+		for _, addr := range addrs {
+			wg.Add(1)
+			go func(host, addr string) {
+				defer wg.Done()
+				probe := hc.probe(domain, host, addr)
+				mu.Lock()
+				probes = append(probes, probe)
+				mu.Unlock()
+			}(host, addr)
+		}
+	}
+	wg.Wait()
+
+	sort.Slice(probes, func(i, j int) bool {
+		if probes[i].Host != probes[j].Host {
+			return probes[i].Host < probes[j].Host
+		}
+		return probes[i].Address < probes[j].Address
+	})
+	return probes
+}
+
+// probe asks one nameserver address for the domain's SOA and NS records, and whether it recurses
+func (hc *HealthChecker) probe(domain, host, addr string) NameserverProbe {
+	probe := NameserverProbe{Host: strings.TrimSuffix(host, "."), Address: addr}
+	server := net.JoinHostPort(addr, "53")
+
+	resp, err := hc.query(server, domain, mdns.TypeSOA, false)
+	if err != nil {
+		probe.Error = err.Error()
+		return probe
+	}
+	probe.Responding = true
+	for _, rr := range resp.Answer {
+		if soa, ok := rr.(*mdns.SOA); ok {
+			probe.soa = soa
+			probe.Serial = soa.Serial
+		}
+	}
+	probe.Authoritative = resp.Authoritative && resp.Rcode == mdns.RcodeSuccess && probe.soa != nil
+	if !probe.Authoritative {
+		probe.Error = fmt.Sprintf("not authoritative (rcode %s)", mdns.RcodeToString[resp.Rcode])
+		return probe
+	}
+
+	if resp, err := hc.query(server, domain, mdns.TypeNS, false); err == nil {
+		var names []string
+		for _, rr := range resp.Answer {
+			if ns, ok := rr.(*mdns.NS); ok {
+				names = append(names, strings.ToLower(ns.Ns))
+			}
+		}
+		probe.Nameservers = trimDots(uniqueSorted(names))
+	}
+
+	// Authoritative servers that also recurse for anyone can be abused for amplification attacks
+	if resp, err := hc.query(server, recursionProbeName, mdns.TypeA, true); err == nil {
+		probe.OpenRecursion = resp.RecursionAvailable && resp.Rcode == mdns.RcodeSuccess && len(resp.Answer) > 0
+	}
+	return probe
+}
+
+// checkProbes reports unresponsive and lame nameservers, open recursion, and whether the
+// nameservers agree with each other and with the parent
+func checkProbes(report *HealthReport, delegation []string) {
+	var silent, lame, recursive []string
+	nsSets := map[string][]string{}
+	serials := map[uint32][]string{}
+	for _, probe := range report.Probes {
+		label := fmt.Sprintf("%s (%s)", probe.Host, probe.Address)
+		switch {
+		case !probe.Responding:
+			silent = append(silent, fmt.Sprintf("%s: %s", label, probe.Error))
+		case !probe.Authoritative:
+			lame = append(lame, fmt.Sprintf("%s: %s", label, probe.Error))
+		default:
+			key := strings.Join(probe.Nameservers, ", ")
+			nsSets[key] = append(nsSets[key], label)
+			serials[probe.Serial] = append(serials[probe.Serial], label)
+		}
+		if probe.OpenRecursion {
+			recursive = append(recursive, label)
+		}
+	}
+
+	if len(silent) > 0 {
+		report.add(HealthCategoryNameservers, "Responding", HealthFail, "Some nameservers did not respond", silent...)
+	} else if len(report.Probes) > 0 {
+		report.add(HealthCategoryNameservers, "Responding", HealthPass, "Every nameserver responded")
+	}
+
+	if len(lame) > 0 {
+		report.add(HealthCategoryNameservers, "Lame delegation", HealthFail,
+			"Some delegated nameservers do not answer authoritatively for the domain", lame...)
+	} else if len(report.Probes) > len(silent) {
+		report.add(HealthCategoryNameservers, "Lame delegation", HealthPass, "Every responding nameserver is authoritative")
+	}
+
+	if len(recursive) > 0 {
+		report.add(HealthCategoryNameservers, "Open recursion", HealthWarn,
+			"Some nameservers answer recursive queries for anyone, which allows amplification attacks", recursive...)
+	} else if len(report.Probes) > len(silent) {
+		report.add(HealthCategoryNameservers, "Open recursion", HealthPass, "No nameserver allows recursive queries")
+	}
+
+	if len(nsSets) == 0 {
+		return
+	}
+
+	if len(nsSets) > 1 {
+		report.add(HealthCategoryNameservers, "NS consistency", HealthFail,
+			"Nameservers return different NS record sets", groupDetails(nsSets)...)
+	} else {
+		report.add(HealthCategoryNameservers, "NS consistency", HealthPass, "Every nameserver returns the same NS records")
+	}
+
+	parent := strings.Join(trimDots(delegation), ", ")
+	var differing []string
+	for set, labels := range nsSets {
+		if set != parent {
+			differing = append(differing, fmt.Sprintf("%s: %s", strings.Join(labels, ", "), set))
+		}
+	}
+	if len(differing) > 0 {
+		sort.Strings(differing)
+		report.add(HealthCategoryNameservers, "NS matches parent", HealthWarn,
+			fmt.Sprintf("NS records at the nameservers differ from the parent delegation (%s)", parent), differing...)
+	} else {
+		report.add(HealthCategoryNameservers, "NS matches parent", HealthPass, "NS records at the nameservers match the parent delegation")
+	}
+
+	if len(serials) > 1 {
+		details := map[string][]string{}
+		for serial, labels := range serials {
+			details[fmt.Sprint(serial)] = labels
+		}
+		report.add(HealthCategorySOA, "Serial consistency", HealthWarn,
+			"Nameservers serve different SOA serials, so some have not picked up the latest zone", groupDetails(details)...)
+	} else {
+		report.add(HealthCategorySOA, "Serial consistency", HealthPass, "Every nameserver serves the same SOA serial")
+	}
+}
+
+// checkSOA checks the SOA record's primary nameserver and timers against common recommendations (RIPE-203)
+func checkSOA(report *HealthReport, delegation []string) {
+	var soa *mdns.SOA
+	for _, probe := range report.Probes {
+		if probe.soa != nil {
+			soa = probe.soa
+			break
+		}
+	}
+	if soa == nil {
+		if len(report.Probes) > 0 {
+			report.add(HealthCategorySOA, "SOA record", HealthFail, "No nameserver returned an SOA record")
+		}
+		return
+	}
+	report.add(HealthCategorySOA, "SOA record", HealthPass,
+		fmt.Sprintf("Primary %s, contact %s, serial %d", strings.TrimSuffix(soa.Ns, "."), strings.TrimSuffix(soa.Mbox, "."), soa.Serial))
+
+	primaryListed := false
+	for _, host := range delegation {
+		if strings.EqualFold(host, soa.Ns) {
+			primaryListed = true
+		}
+	}
+	if primaryListed {
+		report.add(HealthCategorySOA, "Primary nameserver", HealthPass, "The SOA primary nameserver is delegated")
+	} else {
+		report.add(HealthCategorySOA, "Primary nameserver", HealthWarn,
+			fmt.Sprintf("The SOA primary nameserver %s is not among the delegated nameservers", strings.TrimSuffix(soa.Ns, ".")))
+	}
+
+	timers := []struct {
+		name     string
+		value    uint32
+		min, max uint32
+	}{
+		{"Refresh", soa.Refresh, 1200, 43200},
+		{"Retry", soa.Retry, 120, 7200},
+		{"Expire", soa.Expire, 604800, 2419200},
+		{"Minimum TTL", soa.Minttl, 300, 86400},
+	}
+	for _, timer := range timers {
+		if timer.value < timer.min || timer.value > timer.max {
+			report.add(HealthCategorySOA, timer.name, HealthWarn,
+				fmt.Sprintf("%s is %ds; %d-%ds is recommended", timer.name, timer.value, timer.min, timer.max))
+		} else {
+			report.add(HealthCategorySOA, timer.name, HealthPass, fmt.Sprintf("%s is %ds", timer.name, timer.value))
+		}
+	}
+	if soa.Retry >= soa.Refresh {
+		report.add(HealthCategorySOA, "Retry below refresh", HealthWarn,
+			fmt.Sprintf("Retry (%ds) should be lower than refresh (%ds)", soa.Retry, soa.Refresh))
+	}
+}
+
+// add appends a check to its category, creating the category in report order
+func (r *HealthReport) add(category, name string, status HealthStatus, message string, details ...string) {
+	check := HealthCheck{Name: name, Status: status, Message: message, Details: details}
+	for i := range r.Categories {
+		if r.Categories[i].Name == category {
+			r.Categories[i].Checks = append(r.Categories[i].Checks, check)
+			return
+		}
+	}
+	r.Categories = append(r.Categories, HealthCategory{Name: category, Checks: []HealthCheck{check}})
+}
+
+// finish orders the categories and fills in the category, overall and summary statuses
+func (r *HealthReport) finish() {
+	order := map[string]int{HealthCategoryParent: 0, HealthCategoryNameservers: 1, HealthCategorySOA: 2}
+	sort.SliceStable(r.Categories, func(i, j int) bool { return order[r.Categories[i].Name] < order[r.Categories[j].Name] })
+
+	r.Status = HealthPass
+	r.Summary = map[HealthStatus]int{HealthPass: 0, HealthWarn: 0, HealthFail: 0}
+	for i := range r.Categories {
+		r.Categories[i].Status = HealthPass
+		for _, check := range r.Categories[i].Checks {
+			r.Summary[check.Status]++
+			r.Categories[i].Status = worseStatus(r.Categories[i].Status, check.Status)
+		}
+		r.Status = worseStatus(r.Status, r.Categories[i].Status)
+	}
+}
+
+// worseStatus returns the more severe of two statuses
+func worseStatus(a, b HealthStatus) HealthStatus {
+	rank := map[HealthStatus]int{HealthPass: 0, HealthWarn: 1, HealthFail: 2}
+	if rank[b] > rank[a] {
+		return b
+	}
+	return a
+}
+
+// groupDetails renders "value: who" lines, sorted, for checks where servers disagree
+func groupDetails(groups map[string][]string) []string {
+	details := make([]string, 0, len(groups))
+	for value, labels := range groups {
+		details = append(details, fmt.Sprintf("%s: %s", value, strings.Join(labels, ", ")))
+	}
+	sort.Strings(details)
+	return details
+}
+
+func uniqueSorted(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	unique := []string{}
+	for _, v := range values {
+		if !seen[v] {
+			seen[v] = true
+			unique = append(unique, v)
+		}
+	}
+	sort.Strings(unique)
+	return unique
+}
+
+func trimDots(names []string) []string {
+	trimmed := make([]string, len(names))
+	for i, name := range names {
+		trimmed[i] = strings.TrimSuffix(name, ".")
+	}
+	return trimmed
+}
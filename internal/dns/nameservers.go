@@ -0,0 +1,64 @@
+package dns
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/rusiqe/domainvault/internal/types"
+)
+
+// ApexNameservers returns the sorted, lowercased targets of a domain's apex NS records.
+// NS records on subdomains are delegations, not the domain's own nameservers, and are skipped.
+func ApexNameservers(domainName string, records []types.DNSRecord) []string {
+	apex := types.NormalizeDomainName(domainName)
+	seen := map[string]bool{}
+	var nameservers []string
+	for _, record := range records {
+		if !strings.EqualFold(record.Type, "NS") {
+			continue
+		}
+		name := strings.TrimSuffix(strings.ToLower(strings.TrimSpace(record.Name)), ".")
+		if name != "" && name != "@" && name != apex {
+			continue
+		}
+		ns := strings.TrimSuffix(strings.ToLower(strings.TrimSpace(record.Value)), ".")
+		if ns != "" && !seen[ns] {
+			seen[ns] = true
+			nameservers = append(nameservers, ns)
+		}
+	}
+	sort.Strings(nameservers)
+	return nameservers
+}
+
+// CheckNameserverChange compares the apex NS records of a fresh fetch with the stored ones
+// and records the change if they differ. It returns nil when nothing changed, or when either
+// side has no NS records, as some providers don't return NS and there is nothing to compare.
+func (d *DNSService) CheckNameserverChange(domain types.Domain, stored, fetched []types.DNSRecord) (*types.NameserverChange, error) {
+	previous := ApexNameservers(domain.Name, stored)
+	current := ApexNameservers(domain.Name, fetched)
+	if len(previous) == 0 || len(current) == 0 || strings.Join(previous, ",") == strings.Join(current, ",") {
+		return nil, nil
+	}
+
+	change := &types.NameserverChange{
+		DomainID:   domain.ID,
+		Previous:   previous,
+		Current:    current,
+		DetectedAt: time.Now(),
+	}
+	if err := d.repo.CreateNameserverChange(change); err != nil {
+		return change, fmt.Errorf("failed to record nameserver change: %w", err)
+	}
+
+	log.Printf("Nameservers of %s changed from %s to %s", domain.Name, strings.Join(previous, ", "), strings.Join(current, ", "))
+	return change, nil
+}
+
+// GetNameserverHistory returns up to limit of a domain's most recent nameserver changes, oldest first
+func (d *DNSService) GetNameserverHistory(domainID string, limit int) ([]types.NameserverChange, error) {
+	return d.repo.GetNameserverHistory(domainID, limit)
+}
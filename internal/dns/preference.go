@@ -0,0 +1,150 @@
+package dns
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/rusiqe/domainvault/internal/types"
+)
+
+// MXEntry is an MX record in the order sending servers try it
+type MXEntry struct {
+	Priority int    `json:"priority"`
+	Host     string `json:"host"`
+	Primary  bool   `json:"primary"` // Has the lowest priority, so it's tried first
+	Display  string `json:"display"` // e.g. "10 mx1.example.com"
+}
+
+// MXAnalysis is a domain's MX records sorted by preference, with the primary mail exchangers
+// and any redundancy problems
+type MXAnalysis struct {
+	Records  []MXEntry `json:"records"`
+	Primary  []string  `json:"primary"`
+	Warnings []string  `json:"warnings"`
+}
+
+// AnalyzeMX sorts MX records by priority, lowest (most preferred) first, and warns when two
+// exchangers share a priority or there is only one. A record without a priority counts as 0.
+func AnalyzeMX(records []types.DNSRecord) MXAnalysis {
+	analysis := MXAnalysis{Records: []MXEntry{}, Primary: []string{}, Warnings: []string{}}
+	for _, record := range records {
+		entry := MXEntry{Host: strings.TrimSuffix(record.Value, ".")}
+		if record.Priority != nil {
+			entry.Priority = *record.Priority
+		} else {
+			analysis.Warnings = append(analysis.Warnings, fmt.Sprintf("MX %s has no priority", entry.Host))
+		}
+		entry.Display = fmt.Sprintf("%d %s", entry.Priority, entry.Host)
+		analysis.Records = append(analysis.Records, entry)
+	}
+	if len(analysis.Records) == 0 {
+		return analysis
+	}
+
+	sort.SliceStable(analysis.Records, func(i, j int) bool {
+		if analysis.Records[i].Priority != analysis.Records[j].Priority {
+			return analysis.Records[i].Priority < analysis.Records[j].Priority
+		}
+		return analysis.Records[i].Host < analysis.Records[j].Host
+	})
+
+	lowest := analysis.Records[0].Priority
+	byPriority := make(map[int][]string)
+	var priorities []int
+	for i := range analysis.Records {
+		entry := &analysis.Records[i]
+		if entry.Priority == lowest {
+			entry.Primary = true
+			analysis.Primary = append(analysis.Primary, entry.Host)
+		}
+		if _, seen := byPriority[entry.Priority]; !seen {
+			priorities = append(priorities, entry.Priority)
+		}
+		byPriority[entry.Priority] = append(byPriority[entry.Priority], entry.Host)
+	}
+
+	if len(analysis.Records) == 1 {
+		analysis.Warnings = append(analysis.Warnings,
+			fmt.Sprintf("Only one mail exchanger (%s); mail waits in senders' queues while it's down", analysis.Records[0].Host))
+	}
+	for _, priority := range priorities {
+		if hosts := byPriority[priority]; len(hosts) > 1 {
+			analysis.Warnings = append(analysis.Warnings,
+				fmt.Sprintf("Priority %d is shared by %s; senders pick between them at random", priority, strings.Join(hosts, ", ")))
+		}
+	}
+	return analysis
+}
+
+// SRVEntry is an SRV target in the order clients try it
+type SRVEntry struct {
+	Priority int    `json:"priority"`
+	Weight   int    `json:"weight"`
+	Port     int    `json:"port"`
+	Target   string `json:"target"`
+	Primary  bool   `json:"primary"` // Has the service's lowest priority, so it's tried first
+	Display  string `json:"display"`
+}
+
+// SRVServiceAnalysis is one service's SRV targets sorted by preference
+type SRVServiceAnalysis struct {
+	Name     string     `json:"name"` // Owner name, e.g. _sip._tcp
+	Targets  []SRVEntry `json:"targets"`
+	Warnings []string   `json:"warnings"`
+}
+
+// AnalyzeSRV groups SRV records by owner name and sorts each service's targets by priority,
+// then by weight with the heaviest first. It warns when a service has one target, or when
+// targets share both priority and weight so nothing prefers one of them.
+func AnalyzeSRV(records []types.DNSRecord) []SRVServiceAnalysis {
+	byName := make(map[string]*SRVServiceAnalysis)
+	var names []string
+	for _, record := range records {
+		service, ok := byName[record.Name]
+		if !ok {
+			service = &SRVServiceAnalysis{Name: record.Name, Targets: []SRVEntry{}, Warnings: []string{}}
+			byName[record.Name] = service
+			names = append(names, record.Name)
+		}
+		entry := SRVEntry{Target: strings.TrimSuffix(record.Value, "."), Display: FormatSRV(record)}
+		if record.Priority != nil {
+			entry.Priority = *record.Priority
+		}
+		if record.Weight != nil {
+			entry.Weight = *record.Weight
+		}
+		if record.Port != nil {
+			entry.Port = *record.Port
+		}
+		service.Targets = append(service.Targets, entry)
+	}
+	sort.Strings(names)
+
+	analyses := make([]SRVServiceAnalysis, 0, len(names))
+	for _, name := range names {
+		service := byName[name]
+		targets := service.Targets
+		sort.SliceStable(targets, func(i, j int) bool {
+			if targets[i].Priority != targets[j].Priority {
+				return targets[i].Priority < targets[j].Priority
+			}
+			if targets[i].Weight != targets[j].Weight {
+				return targets[i].Weight > targets[j].Weight
+			}
+			return targets[i].Target < targets[j].Target
+		})
+		for i := range targets {
+			targets[i].Primary = targets[i].Priority == targets[0].Priority
+			if i > 0 && targets[i].Priority == targets[i-1].Priority && targets[i].Weight == targets[i-1].Weight {
+				service.Warnings = append(service.Warnings, fmt.Sprintf("%s and %s share priority %d and weight %d",
+					targets[i-1].Target, targets[i].Target, targets[i].Priority, targets[i].Weight))
+			}
+		}
+		if len(targets) == 1 {
+			service.Warnings = append(service.Warnings, fmt.Sprintf("%s has only one target (%s)", name, targets[0].Target))
+		}
+		analyses = append(analyses, *service)
+	}
+	return analyses
+}
@@ -0,0 +1,181 @@
+package dns
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rusiqe/domainvault/internal/providers"
+	"github.com/rusiqe/domainvault/internal/types"
+)
+
+// RefreshResult is the outcome of refreshing one domain's stored records from its providers
+type RefreshResult struct {
+	DomainID         string                  `json:"domain_id"`
+	DomainName       string                  `json:"domain_name"`
+	Source           string                  `json:"source"`  // Provider the records were fetched from
+	Updated          bool                    `json:"updated"` // False when the fetched records matched the stored ones
+	Records          []types.DNSRecord       `json:"records"`
+	NameserverChange *types.NameserverChange `json:"nameserver_change,omitempty"` // Set when the apex NS records changed
+}
+
+// RefreshDomain fetches a domain's records from Cloudflare, falling back to its registrar,
+// and replaces the stored records if they differ. Either client may be nil when that
+// provider isn't connected. A fetch that returns no records counts as failed, so an empty
+// answer never wipes the stored zone. A nameserver change is recorded and returned in the
+// result for the caller to alert on.
+func (d *DNSService) RefreshDomain(domain types.Domain, cloudflare, registrar providers.RegistrarClient) (*RefreshResult, error) {
+	sources := []struct {
+		name   string
+		client providers.RegistrarClient
+	}{
+		{"cloudflare", cloudflare},
+		{domain.Provider, registrar},
+	}
+
+	var source string
+	var records []types.DNSRecord
+	var failures []string
+	for _, s := range sources {
+		if s.client == nil {
+			continue
+		}
+		fetched, err := s.client.FetchDNSRecords(domain.Name)
+		if err == nil && len(fetched) == 0 {
+			err = fmt.Errorf("no records returned")
+		}
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", s.name, err))
+			continue
+		}
+		source, records = s.name, fetched
+		break
+	}
+	if source == "" {
+		if len(failures) == 0 {
+			return nil, fmt.Errorf("no DNS provider connected for %s", domain.Name)
+		}
+		return nil, fmt.Errorf("failed to fetch DNS records for %s: %s", domain.Name, strings.Join(failures, "; "))
+	}
+
+	result := &RefreshResult{DomainID: domain.ID, DomainName: domain.Name, Source: source}
+	stored, err := d.GetDomainRecords(domain.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stored records for %s: %w", domain.Name, err)
+	}
+	if recordSetsEqual(stored, records) {
+		d.RecordProviderState(domain.ID, source, stored)
+		result.Records = stored
+		return result, nil
+	}
+
+	// A silent nameserver change can mean a hijack, so it's recorded before the records are
+	// replaced; failing to record it doesn't hold up the refresh
+	change, err := d.CheckNameserverChange(domain, stored, records)
+	if err != nil {
+		log.Printf("DNS refresh: %v", err)
+	}
+	result.NameserverChange = change
+
+	now := time.Now()
+	for i := range records {
+		records[i].DomainID = domain.ID
+		records[i].CreatedAt = now
+		records[i].UpdatedAt = now
+	}
+	if err := d.StoreProviderRecords(domain.ID, source, records); err != nil {
+		return result, fmt.Errorf("failed to update records for %s: %w", domain.Name, err)
+	}
+	result.Updated = true
+	result.Records = records
+	return result, nil
+}
+
+// DefaultRefreshWorkers is how many domains RefreshDomains fetches at once when not configured
+const DefaultRefreshWorkers = 5
+
+// RefreshSummary counts the outcome of refreshing many domains
+type RefreshSummary struct {
+	Total     int `json:"total"`
+	Updated   int `json:"updated"`
+	Unchanged int `json:"unchanged"`
+	Failed    int `json:"failed"`
+	Skipped   int `json:"skipped"` // Not started before the context was done
+}
+
+// RefreshDomains runs RefreshDomain on each domain with a pool of workers. registrarFor returns
+// the registrar client of a provider, nil if it isn't connected. onResult, if set, is called
+// with each domain's outcome from the worker goroutines, so it must be safe for concurrent use.
+// Domains not started before ctx is done are skipped; ones in progress finish.
+func (d *DNSService) RefreshDomains(ctx context.Context, domains []types.Domain, cloudflare providers.RegistrarClient,
+	registrarFor func(provider string) providers.RegistrarClient, workers int,
+	onResult func(domain types.Domain, result *RefreshResult, err error)) RefreshSummary {
+	if workers <= 0 {
+		workers = DefaultRefreshWorkers
+	}
+
+	summary := RefreshSummary{Total: len(domains)}
+	var mu sync.Mutex // Protects summary
+	jobs := make(chan types.Domain)
+	var wg sync.WaitGroup
+	for w := 0; w < workers && w < len(domains); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for domain := range jobs {
+				result, err := d.RefreshDomain(domain, cloudflare, registrarFor(domain.Provider))
+				if onResult != nil {
+					onResult(domain, result, err)
+				}
+
+				mu.Lock()
+				switch {
+				case err != nil:
+					summary.Failed++
+				case result.Updated:
+					summary.Updated++
+				default:
+					summary.Unchanged++
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+dispatch:
+	for i, domain := range domains {
+		select {
+		case jobs <- domain:
+		case <-ctx.Done():
+			mu.Lock()
+			summary.Skipped = len(domains) - i
+			mu.Unlock()
+			break dispatch
+		}
+	}
+	close(jobs)
+	wg.Wait()
+	return summary
+}
+
+// recordSetsEqual compares two record sets by content, ignoring IDs and timestamps
+func recordSetsEqual(a, b []types.DNSRecord) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	remaining := make(map[string]int, len(a))
+	for _, r := range a {
+		remaining[recordKey(r)]++
+	}
+	for _, r := range b {
+		key := recordKey(r)
+		if remaining[key] == 0 {
+			return false
+		}
+		remaining[key]--
+	}
+	return true
+}
@@ -2,50 +2,154 @@ package dns
 
 import (
 	"fmt"
+	"log"
+	"strings"
 	"time"
 
+	"github.com/rusiqe/domainvault/internal/providers"
 	"github.com/rusiqe/domainvault/internal/types"
 )
 
 // DNSService handles DNS record operations
 type DNSService struct {
-	repo DNSRepository
+	repo  DNSRepository
+	actor string       // Recorded as changed_by in the DNS history
+	cache *RecordCache // Shared with the services WithActor returns
+
+	backupRetention int // Backups kept per domain, 0 for DefaultBackupRetention
 }
 
 // DNSRepository defines the interface for DNS data operations
 type DNSRepository interface {
 	CreateRecord(record *types.DNSRecord) error
 	GetRecordsByDomain(domainID string) ([]types.DNSRecord, error)
+	GetRecordsPage(domainID string, filter types.DNSRecordFilter) ([]types.DNSRecord, error)
+	CountRecordsByType(domainID string) (map[string]int, error)
 	GetRecordByID(id string) (*types.DNSRecord, error)
 	UpdateRecord(record *types.DNSRecord) error
 	DeleteRecord(id string) error
 	DeleteRecordsByDomain(domainID string) error
 	BulkCreateRecords(records []types.DNSRecord) error
+	ReplaceRecordsByDomain(domainID string, records []types.DNSRecord) error
+	CreateDNSHistory(entries []types.DNSRecordHistory) error
+	GetDNSHistory(domainID string, limit int) ([]types.DNSRecordHistory, error)
+	CreateNameserverChange(change *types.NameserverChange) error
+	GetNameserverHistory(domainID string, limit int) ([]types.NameserverChange, error)
+	SaveDNSProviderState(state *types.DNSProviderState) error
+	GetDNSProviderState(domainID string) (*types.DNSProviderState, error)
+	GetDNSSyncResult(domainID string) (*types.DNSSyncResult, error)
+	CreateDNSBackup(backup *types.DNSBackup) error
+	GetDNSBackups(domainID string) ([]types.DNSBackup, error)
+	GetDNSBackup(id string) (*types.DNSBackup, error)
+	PruneDNSBackups(domainID string, keep int) error
 }
 
-// NewDNSService creates a new DNS service
+// NewDNSService creates a new DNS service; changes are attributed to "system" unless WithActor is used
 func NewDNSService(repo DNSRepository) *DNSService {
 	return &DNSService{
-		repo: repo,
+		repo:  repo,
+		actor: "system",
+		cache: NewRecordCache(0),
+	}
+}
+
+// SetCacheTTL caches each domain's stored and provider records for up to ttl; 0 or less
+// disables the cache. Every change made through the service invalidates the domain's entries.
+func (d *DNSService) SetCacheTTL(ttl time.Duration) {
+	d.cache = NewRecordCache(ttl)
+}
+
+// WithActor returns a DNS service that attributes its changes to actor in the DNS history
+func (d *DNSService) WithActor(actor string) *DNSService {
+	scoped := *d
+	if actor != "" {
+		scoped.actor = actor
+	}
+	return &scoped
+}
+
+// GetHistory returns up to limit of a domain's most recent DNS changes, oldest first
+func (d *DNSService) GetHistory(domainID string, limit int) ([]types.DNSRecordHistory, error) {
+	return d.repo.GetDNSHistory(domainID, limit)
+}
+
+// recordHistory appends a change to the DNS history. Failures are logged rather than
+// returned so a history write never undoes a DNS change that already succeeded.
+func (d *DNSService) recordHistory(action string, before, after *types.DNSRecord) {
+	entry := types.DNSRecordHistory{
+		Action:    action,
+		ChangedBy: d.actor,
+		ChangedAt: time.Now(),
+	}
+	if before != nil {
+		entry.DomainID, entry.RecordID = before.DomainID, before.ID
+		entry.Before = &types.DNSRecordSnapshot{DNSRecord: *before}
+	}
+	if after != nil {
+		entry.DomainID, entry.RecordID = after.DomainID, after.ID
+		entry.After = &types.DNSRecordSnapshot{DNSRecord: *after}
+	}
+	d.writeHistory([]types.DNSRecordHistory{entry})
+}
+
+// writeHistory stores history entries, logging instead of failing
+func (d *DNSService) writeHistory(entries []types.DNSRecordHistory) {
+	if err := d.repo.CreateDNSHistory(entries); err != nil {
+		log.Printf("Failed to record DNS history: %v", err)
 	}
 }
 
 // CreateRecord creates a new DNS record
 func (d *DNSService) CreateRecord(record *types.DNSRecord) error {
 	if err := d.validateRecord(record); err != nil {
-		return fmt.Errorf("invalid DNS record: %w", err)
+		return fmt.Errorf("%w: %w", types.ErrInvalidDNSRecord, err)
+	}
+
+	existing, err := d.repo.GetRecordsByDomain(record.DomainID)
+	if err != nil {
+		return fmt.Errorf("failed to get existing records: %w", err)
+	}
+	if err := d.ValidateForProvider(record.DomainID, []types.DNSRecord{*record}, len(existing)+1); err != nil {
+		return fmt.Errorf("%w: %w", types.ErrInvalidDNSRecord, err)
 	}
 
 	now := time.Now()
 	record.CreatedAt = now
 	record.UpdatedAt = now
 
-	return d.repo.CreateRecord(record)
+	err = d.repo.CreateRecord(record)
+	d.cache.Invalidate(record.DomainID)
+	if err != nil {
+		return err
+	}
+	d.recordHistory(types.DNSChangeCreate, nil, record)
+	return nil
 }
 
 // GetDomainRecords retrieves all DNS records for a domain
 func (d *DNSService) GetDomainRecords(domainID string) ([]types.DNSRecord, error) {
-	return d.repo.GetRecordsByDomain(domainID)
+	return d.cache.Load(domainID, CacheSourceDatabase, func() ([]types.DNSRecord, error) {
+		return d.repo.GetRecordsByDomain(domainID)
+	})
+}
+
+// ProviderRecords returns a domain's live records at provider, read with fetch unless cached
+func (d *DNSService) ProviderRecords(domainID, provider string, fetch func() ([]types.DNSRecord, error)) ([]types.DNSRecord, error) {
+	return d.cache.Load(domainID, provider, fetch)
+}
+
+// GetDomainRecordsPage retrieves a page of a domain's DNS records, with the number of records
+// matching the filter and the domain's record counts by type over every record
+func (d *DNSService) GetDomainRecordsPage(domainID string, filter types.DNSRecordFilter) ([]types.DNSRecord, int, map[string]int, error) {
+	counts, err := d.repo.CountRecordsByType(domainID)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+	records, err := d.repo.GetRecordsPage(domainID, filter)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+	return records, filter.Total(counts), counts, nil
 }
 
 // GetRecord retrieves a specific DNS record
@@ -56,17 +160,37 @@ func (d *DNSService) GetRecord(id string) (*types.DNSRecord, error) {
 // UpdateRecord updates an existing DNS record
 func (d *DNSService) UpdateRecord(record *types.DNSRecord) error {
 	if err := d.validateRecord(record); err != nil {
-		return fmt.Errorf("invalid DNS record: %w", err)
+		return fmt.Errorf("%w: %w", types.ErrInvalidDNSRecord, err)
+	}
+	if err := d.ValidateForProvider(record.DomainID, []types.DNSRecord{*record}, 0); err != nil {
+		return fmt.Errorf("%w: %w", types.ErrInvalidDNSRecord, err)
+	}
+
+	before, err := d.repo.GetRecordByID(record.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get existing record: %w", err)
 	}
 
 	record.UpdatedAt = time.Now()
-	return d.repo.UpdateRecord(record)
+	err = d.repo.UpdateRecord(record)
+	d.cache.Invalidate(before.DomainID)
+	if record.DomainID != before.DomainID {
+		d.cache.Invalidate(record.DomainID)
+	}
+	if err != nil {
+		return err
+	}
+	d.recordHistory(types.DNSChangeUpdate, before, record)
+	return nil
 }
 
 // CreateOrUpdateRecord creates a new DNS record or updates existing one with same type and name
 func (d *DNSService) CreateOrUpdateRecord(record types.DNSRecord) error {
 	if err := d.validateRecord(&record); err != nil {
-		return fmt.Errorf("invalid DNS record: %w", err)
+		return fmt.Errorf("%w: %w", types.ErrInvalidDNSRecord, err)
+	}
+	if err := d.ValidateForProvider(record.DomainID, []types.DNSRecord{record}, 0); err != nil {
+		return fmt.Errorf("%w: %w", types.ErrInvalidDNSRecord, err)
 	}
 
 	// Check if a record with same type and name already exists
@@ -82,43 +206,173 @@ func (d *DNSService) CreateOrUpdateRecord(record types.DNSRecord) error {
 			record.ID = existing.ID
 			record.CreatedAt = existing.CreatedAt
 			record.UpdatedAt = time.Now()
-			return d.repo.UpdateRecord(&record)
+			err := d.repo.UpdateRecord(&record)
+			d.cache.Invalidate(record.DomainID)
+			if err != nil {
+				return err
+			}
+			before := existing
+			d.recordHistory(types.DNSChangeUpdate, &before, &record)
+			return nil
 		}
 	}
 
 	// Create new record if no existing record found
+	if err := d.ValidateForProvider(record.DomainID, nil, len(existingRecords)+1); err != nil {
+		return fmt.Errorf("%w: %w", types.ErrInvalidDNSRecord, err)
+	}
 	now := time.Now()
 	record.CreatedAt = now
 	record.UpdatedAt = now
-	return d.repo.CreateRecord(&record)
+	err = d.repo.CreateRecord(&record)
+	d.cache.Invalidate(record.DomainID)
+	if err != nil {
+		return err
+	}
+	d.recordHistory(types.DNSChangeCreate, nil, &record)
+	return nil
 }
 
 // DeleteRecord deletes a DNS record
 func (d *DNSService) DeleteRecord(id string) error {
-	return d.repo.DeleteRecord(id)
+	before, err := d.repo.GetRecordByID(id)
+	if err != nil {
+		return err
+	}
+	err = d.repo.DeleteRecord(id)
+	d.cache.Invalidate(before.DomainID)
+	if err != nil {
+		return err
+	}
+	d.recordHistory(types.DNSChangeDelete, before, nil)
+	return nil
 }
 
-// BulkUpdateRecords updates multiple DNS records for a domain
+// BulkUpdateRecords replaces all of a domain's DNS records. Every record is validated before
+// anything is written, and the replacement happens in one transaction.
 func (d *DNSService) BulkUpdateRecords(domainID string, records []types.DNSRecord) error {
-	// Delete existing records for the domain
-	if err := d.repo.DeleteRecordsByDomain(domainID); err != nil {
-		return fmt.Errorf("failed to delete existing records: %w", err)
+	previous, err := d.repo.GetRecordsByDomain(domainID)
+	if err != nil {
+		return fmt.Errorf("failed to get existing records: %w", err)
 	}
 
 	// Validate all records
 	for i := range records {
 		records[i].DomainID = domainID
 		if err := d.validateRecord(&records[i]); err != nil {
-			return fmt.Errorf("invalid DNS record at index %d: %w", i, err)
+			return fmt.Errorf("%w at index %d: %w", types.ErrInvalidDNSRecord, i, err)
+		}
+	}
+
+	err = d.repo.ReplaceRecordsByDomain(domainID, records)
+	d.cache.Invalidate(domainID)
+	if err != nil {
+		return fmt.Errorf("failed to replace records: %w", err)
+	}
+	d.writeHistory(d.replacementHistory(previous, records))
+	return nil
+}
+
+// replacementHistory describes a zone replacement as deletes of records that went away and
+// creates of records that are new; records present in both sets are not logged
+func (d *DNSService) replacementHistory(previous, current []types.DNSRecord) []types.DNSRecordHistory {
+	remaining := make(map[string]int, len(previous))
+	for _, r := range previous {
+		remaining[recordKey(r)]++
+	}
+	added := make([]types.DNSRecord, 0, len(current))
+	for _, r := range current {
+		key := recordKey(r)
+		if remaining[key] > 0 {
+			remaining[key]--
+			continue
+		}
+		added = append(added, r)
+	}
+
+	now := time.Now()
+	var entries []types.DNSRecordHistory
+	for _, r := range previous {
+		key := recordKey(r)
+		if remaining[key] == 0 {
+			continue
+		}
+		remaining[key]--
+		entries = append(entries, types.DNSRecordHistory{
+			DomainID:  r.DomainID,
+			RecordID:  r.ID,
+			Action:    types.DNSChangeDelete,
+			Before:    &types.DNSRecordSnapshot{DNSRecord: r},
+			ChangedBy: d.actor,
+			ChangedAt: now,
+		})
+	}
+	for _, r := range added {
+		entries = append(entries, types.DNSRecordHistory{
+			DomainID:  r.DomainID,
+			RecordID:  r.ID,
+			Action:    types.DNSChangeCreate,
+			After:     &types.DNSRecordSnapshot{DNSRecord: r},
+			ChangedBy: d.actor,
+			ChangedAt: now,
+		})
+	}
+	return entries
+}
+
+// recordKey identifies a record by content, ignoring IDs and timestamps
+func recordKey(r types.DNSRecord) string {
+	key := fmt.Sprintf("%s|%s|%s|%d", r.Type, r.Name, r.Value, r.TTL)
+	for _, v := range []*int{r.Priority, r.Weight, r.Port} {
+		if v != nil {
+			key += fmt.Sprintf("|%d", *v)
+		} else {
+			key += "|-"
+		}
+	}
+	return key
+}
+
+// ValidateForProvider checks records against the DNS capabilities of the provider the domain
+// belongs to, so limits are enforced locally instead of surfacing as provider-side rejections.
+// zoneSize is the resulting number of records in the zone; pass 0 to skip the count check.
+// Domains on providers without known capabilities are not constrained.
+func (d *DNSService) ValidateForProvider(domainID string, records []types.DNSRecord, zoneSize int) error {
+	caps, ok := d.domainCapabilities(domainID)
+	if !ok {
+		return nil
+	}
+
+	for i, record := range records {
+		if record.TTL <= 0 {
+			record.TTL = 3600 // Matches the default applied by validateRecord
+		}
+		record.Type = canonicalRecordType(record.Type)
+		if err := caps.ValidateRecord(record); err != nil {
+			if len(records) > 1 {
+				return fmt.Errorf("record at index %d: %w", i, err)
+			}
+			return err
 		}
-		
-		now := time.Now()
-		records[i].CreatedAt = now
-		records[i].UpdatedAt = now
 	}
 
-	// Create new records
-	return d.repo.BulkCreateRecords(records)
+	if zoneSize > 0 {
+		return caps.ValidateRecordCount(zoneSize)
+	}
+	return nil
+}
+
+// domainCapabilities resolves the provider capabilities for a domain when the repository can look domains up
+func (d *DNSService) domainCapabilities(domainID string) (providers.Capabilities, bool) {
+	repo, ok := d.repo.(interface{ GetByID(string) (*types.Domain, error) })
+	if !ok {
+		return providers.Capabilities{}, false
+	}
+	domain, err := repo.GetByID(domainID)
+	if err != nil || domain == nil {
+		return providers.Capabilities{}, false
+	}
+	return providers.GetCapabilities(domain.Provider)
 }
 
 // GetCommonRecordTemplates returns common DNS record templates
@@ -139,6 +393,23 @@ func (d *DNSService) GetCommonRecordTemplates() map[string][]types.DNSRecord {
 			{Type: "CNAME", Name: "assets", Value: "cdn.example.com", TTL: 3600},
 			{Type: "CNAME", Name: "static", Value: "cdn.example.com", TTL: 3600},
 		},
+		"letsencrypt_only": {
+			{Type: "CAA", Name: "@", Value: `0 issue "letsencrypt.org"`, TTL: 3600},
+			{Type: "CAA", Name: "@", Value: `0 issuewild "letsencrypt.org"`, TTL: 3600},
+			{Type: "CAA", Name: "@", Value: `0 iodef "mailto:security@example.com"`, TTL: 3600},
+		},
+		"sip_service": {
+			{Type: "SRV", Name: "_sip._udp", Value: "sip.example.com", TTL: 3600, Priority: intPtr(10), Weight: intPtr(60), Port: intPtr(5060)},
+			{Type: "SRV", Name: "_sip._tcp", Value: "sip.example.com", TTL: 3600, Priority: intPtr(10), Weight: intPtr(60), Port: intPtr(5060)},
+			{Type: "SRV", Name: "_sips._tcp", Value: "sip.example.com", TTL: 3600, Priority: intPtr(10), Weight: intPtr(60), Port: intPtr(5061)},
+		},
+		"xmpp_service": {
+			{Type: "SRV", Name: "_xmpp-client._tcp", Value: "xmpp.example.com", TTL: 3600, Priority: intPtr(5), Weight: intPtr(0), Port: intPtr(5222)},
+			{Type: "SRV", Name: "_xmpp-server._tcp", Value: "xmpp.example.com", TTL: 3600, Priority: intPtr(5), Weight: intPtr(0), Port: intPtr(5269)},
+		},
+		"minecraft_server": {
+			{Type: "SRV", Name: "_minecraft._tcp", Value: "mc.example.com", TTL: 3600, Priority: intPtr(0), Weight: intPtr(5), Port: intPtr(25565)},
+		},
 	}
 }
 
@@ -148,6 +419,7 @@ func (d *DNSService) validateRecord(record *types.DNSRecord) error {
 		return fmt.Errorf("domain ID is required")
 	}
 	
+	record.Type = canonicalRecordType(record.Type)
 	if record.Type == "" {
 		return fmt.Errorf("record type is required")
 	}
@@ -155,6 +427,9 @@ func (d *DNSService) validateRecord(record *types.DNSRecord) error {
 	if record.Name == "" {
 		return fmt.Errorf("record name is required")
 	}
+	if err := validateRecordName(record.Name); err != nil {
+		return err
+	}
 	
 	if record.Value == "" {
 		return fmt.Errorf("record value is required")
@@ -171,14 +446,30 @@ func (d *DNSService) validateRecord(record *types.DNSRecord) error {
 			return fmt.Errorf("MX records require priority")
 		}
 	case "SRV":
-		if record.Priority == nil || record.Weight == nil || record.Port == nil {
-			return fmt.Errorf("SRV records require priority, weight, and port")
+		if err := validateSRV(record); err != nil {
+			return err
+		}
+	case "TLSA":
+		if err := validateTLSA(record); err != nil {
+			return err
 		}
 	case "A":
 		// Could add IP validation here
 	case "AAAA":
 		// Could add IPv6 validation here
-	case "CNAME", "TXT", "NS":
+	case "CAA":
+		caa, err := ParseCAA(record.Value)
+		if err != nil {
+			return err
+		}
+		record.Value = caa.String() // Store in canonical form so flags and tag round-trip
+	case "TXT":
+		record.Value = types.DechunkTXT(record.Value) // Store chunked values pasted in quotes as one value
+	case "ALIAS":
+		if err := validateAlias(record); err != nil {
+			return err
+		}
+	case "CNAME", "NS":
 		// Basic validation is sufficient
 	default:
 		return fmt.Errorf("unsupported record type: %s", record.Type)
@@ -187,6 +478,23 @@ func (d *DNSService) validateRecord(record *types.DNSRecord) error {
 	return nil
 }
 
+// RecordWarnings lists the records that are valid but won't be published exactly as entered,
+// such as TXT values too long for one character-string that will be split into several
+func RecordWarnings(records []types.DNSRecord) []string {
+	var warnings []string
+	for _, record := range records {
+		if !strings.EqualFold(record.Type, "TXT") {
+			continue
+		}
+		value := types.DechunkTXT(record.Value)
+		if types.TXTNeedsChunking(value) {
+			warnings = append(warnings, fmt.Sprintf("TXT %s is %d bytes, over the %d-byte string limit; it will be published as %d strings",
+				record.Name, len(value), types.MaxTXTStringLength, len(types.ChunkTXT(value))))
+		}
+	}
+	return warnings
+}
+
 // intPtr returns a pointer to an int
 func intPtr(i int) *int {
 	return &i
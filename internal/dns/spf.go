@@ -0,0 +1,541 @@
+package dns
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	mdns "github.com/miekg/dns"
+
+	"github.com/rusiqe/domainvault/internal/types"
+)
+
+// SPF evaluation limits from RFC 7208 section 4.6.4
+const (
+	SPFLookupLimit     = 10 // Terms that query DNS (include, a, mx, ptr, exists, redirect)
+	spfVoidLookupLimit = 2  // Lookups that return no records
+	spfMXLimit         = 10 // MX hosts an mx term may resolve
+	spfMaxDepth        = 10 // Nested include/redirect levels followed before giving up
+)
+
+// spfStringLength is the longest single string a TXT record can hold; longer values are split
+const spfStringLength = 255
+
+// Where an analysed SPF record came from
+const (
+	SPFSourceStored = "stored" // DomainVault's copy of the domain's DNS records
+	SPFSourceLive   = "live"   // Looked up through the resolver
+)
+
+// SPFTerm is one mechanism or modifier of an SPF record, with what it resolved to
+type SPFTerm struct {
+	Term      string    `json:"term"`                // As written, e.g. "include:_spf.google.com"
+	Lookups   int       `json:"lookups"`             // DNS lookups the term costs, including nested records
+	Record    string    `json:"record,omitempty"`    // SPF record of an include or redirect target
+	Addresses []string  `json:"addresses,omitempty"` // Networks an a or mx term resolved to
+	Terms     []SPFTerm `json:"terms,omitempty"`     // Terms of the include or redirect target
+	Error     string    `json:"error,omitempty"`
+
+	qualifier string // "+", "-", "~" or "?"
+	kind      string // Mechanism or modifier name, lower-cased
+	target    string // Domain or network after the colon or equals sign
+	cidr      string // Prefix lengths of an a or mx term, e.g. "/24" or "/24//64"
+	ignored   bool   // A redirect in a record that has an all mechanism never applies
+}
+
+// SPFAnalysis is the lookup count and flattening suggestion for a domain's SPF record
+type SPFAnalysis struct {
+	DomainID     string        `json:"domain_id,omitempty"`
+	Domain       string        `json:"domain"`
+	Record       string        `json:"record"`
+	Source       string        `json:"source"`
+	Lookups      int           `json:"lookups"`
+	LookupLimit  int           `json:"lookup_limit"`
+	ExceedsLimit bool          `json:"exceeds_limit"`
+	VoidLookups  int           `json:"void_lookups"`
+	Terms        []SPFTerm     `json:"terms"`
+	Flattened    *SPFFlattened `json:"flattened,omitempty"` // Nil when inlining wouldn't save any lookups
+	Warnings     []string      `json:"warnings"`
+	AnalyzedAt   time.Time     `json:"analyzed_at"`
+}
+
+// SPFFlattened is a suggested replacement record with include, a and mx terms inlined as
+// ip4/ip6 networks
+type SPFFlattened struct {
+	Record      types.DNSRecord `json:"record"`         // TXT record stub ready to apply at the apex
+	Lookups     int             `json:"lookups"`        // Lookups the flattened record still needs
+	Kept        []string        `json:"kept,omitempty"` // Terms left as written because they can't be inlined
+	Recommended bool            `json:"recommended"`    // The original record exceeds the lookup limit
+}
+
+// FindSPFRecords returns the SPF policies among a domain's records: TXT records at the apex
+// starting with v=spf1. More than one is itself an error receivers reject.
+func FindSPFRecords(records []types.DNSRecord) []types.DNSRecord {
+	var spf []types.DNSRecord
+	for _, record := range records {
+		if record.Type == "TXT" && (record.Name == "@" || record.Name == "") && isSPFRecord(record.Value) {
+			spf = append(spf, record)
+		}
+	}
+	return spf
+}
+
+// isSPFRecord reports whether a TXT value is an SPF version 1 policy
+func isSPFRecord(value string) bool {
+	value = strings.ToLower(strings.TrimSpace(value))
+	return value == "v=spf1" || strings.HasPrefix(value, "v=spf1 ")
+}
+
+// SPFAnalyzer resolves SPF records through the same recursive resolver as the health check
+type SPFAnalyzer struct {
+	checker *HealthChecker
+}
+
+// NewSPFAnalyzer creates an analyzer that uses the system's first configured resolver
+func NewSPFAnalyzer() *SPFAnalyzer {
+	return &SPFAnalyzer{checker: NewHealthChecker()}
+}
+
+// LookupRecord returns the SPF record a domain publishes
+func (a *SPFAnalyzer) LookupRecord(domainName string) (string, error) {
+	record, _, err := a.lookupSPF(types.NormalizeDomainName(domainName))
+	return record, err
+}
+
+// Analyze resolves every include, a, mx and redirect term of record, counts the DNS lookups
+// a receiver needs to evaluate it and suggests a flattened record
+func (a *SPFAnalyzer) Analyze(domainID, domainName, record, source string) *SPFAnalysis {
+	domain := types.NormalizeDomainName(domainName)
+	record = strings.Join(strings.Fields(record), " ")
+	analysis := &SPFAnalysis{
+		DomainID:    domainID,
+		Domain:      domain,
+		Record:      record,
+		Source:      source,
+		LookupLimit: SPFLookupLimit,
+		Terms:       []SPFTerm{},
+		Warnings:    []string{},
+		AnalyzedAt:  time.Now(),
+	}
+	if !isSPFRecord(record) {
+		analysis.Warnings = append(analysis.Warnings, "Record is not an SPF policy; it must start with v=spf1")
+		return analysis
+	}
+
+	eval := &spfEval{analyzer: a, visited: map[string]bool{domain: true}}
+	analysis.Terms = eval.expand(domain, record, 0)
+	analysis.Lookups = sumLookups(analysis.Terms)
+	analysis.VoidLookups = eval.voids
+	analysis.ExceedsLimit = analysis.Lookups > SPFLookupLimit
+
+	if analysis.ExceedsLimit {
+		analysis.Warnings = append(analysis.Warnings, fmt.Sprintf(
+			"The record needs %d DNS lookups; receivers return a permanent error above %d, so mail fails SPF",
+			analysis.Lookups, SPFLookupLimit))
+	}
+	if eval.voids > spfVoidLookupLimit {
+		analysis.Warnings = append(analysis.Warnings, fmt.Sprintf(
+			"%d lookups returned no records; receivers may return a permanent error above %d",
+			eval.voids, spfVoidLookupLimit))
+	}
+	analysis.Warnings = append(analysis.Warnings, eval.warnings...)
+
+	value, kept := flattenSPFTerms(analysis.Terms)
+	flattened := &SPFFlattened{
+		Record: types.DNSRecord{
+			DomainID: domainID,
+			Type:     "TXT",
+			Name:     "@",
+			Value:    strings.Join(append([]string{"v=spf1"}, value...), " "),
+			TTL:      3600,
+		},
+		Kept:        kept,
+		Recommended: analysis.ExceedsLimit,
+	}
+	for _, term := range analysis.Terms {
+		if containsString(kept, term.Term) {
+			flattened.Lookups += term.Lookups
+		}
+	}
+	if flattened.Lookups >= analysis.Lookups {
+		return analysis
+	}
+	analysis.Flattened = flattened
+
+	analysis.Warnings = append(analysis.Warnings,
+		"A flattened record inlines today's addresses; re-flatten it whenever an included provider changes its IPs, or mail from the new addresses will fail SPF")
+	if len(kept) > 0 {
+		analysis.Warnings = append(analysis.Warnings,
+			fmt.Sprintf("Some terms can't be inlined and were left as written: %s", strings.Join(kept, ", ")))
+	}
+	if length := len(flattened.Record.Value); length > spfStringLength {
+		analysis.Warnings = append(analysis.Warnings, fmt.Sprintf(
+			"The flattened record is %d characters; it must be published as several strings of at most %d characters",
+			length, spfStringLength))
+	}
+	return analysis
+}
+
+// spfEval carries the state of one analysis across nested records
+type spfEval struct {
+	analyzer *SPFAnalyzer
+	visited  map[string]bool
+	voids    int
+	warnings []string
+}
+
+func (e *spfEval) warn(format string, args ...interface{}) {
+	e.warnings = append(e.warnings, fmt.Sprintf(format, args...))
+}
+
+// expand parses a record published at domain and resolves each of its terms
+func (e *spfEval) expand(domain, record string, depth int) []SPFTerm {
+	fields := strings.Fields(record)
+	hasAll := false
+	for _, field := range fields[1:] {
+		if parseSPFTerm(field).kind == "all" {
+			hasAll = true
+		}
+	}
+
+	terms := make([]SPFTerm, 0, len(fields)-1)
+	for _, field := range fields[1:] {
+		term := parseSPFTerm(field)
+		target := term.target
+		if target == "" {
+			target = domain
+		}
+
+		switch term.kind {
+		case "ip4", "ip6", "all", "exp":
+		case "include", "redirect":
+			if term.kind == "redirect" && hasAll {
+				term.ignored = true
+				break
+			}
+			term.Lookups = 1
+			if term.target == "" {
+				term.Error = fmt.Sprintf("%s requires a domain", term.kind)
+				break
+			}
+			if strings.Contains(target, "%") {
+				term.Error = "macros are expanded per message and can't be resolved here"
+				break
+			}
+			if depth >= spfMaxDepth || e.visited[strings.ToLower(target)] {
+				term.Error = "include loop or nesting too deep"
+				e.warn("%s: include loop or nesting too deep", term.Term)
+				break
+			}
+
+			nested, void, err := e.analyzer.lookupSPF(target)
+			if void {
+				e.voids++
+			}
+			if err != nil {
+				term.Error = err.Error()
+				e.warn("%s: %v", term.Term, err)
+				break
+			}
+			e.visited[strings.ToLower(target)] = true
+			term.Record = nested
+			term.Terms = e.expand(target, nested, depth+1)
+			term.Lookups += sumLookups(term.Terms)
+			delete(e.visited, strings.ToLower(target))
+		case "a", "mx":
+			term.Lookups = 1
+			if strings.Contains(target, "%") {
+				term.Error = "macros are expanded per message and can't be resolved here"
+				break
+			}
+			addresses, err := e.resolveNetworks(term.kind, target, term.cidr)
+			if err != nil {
+				term.Error = err.Error()
+				e.warn("%s: %v", term.Term, err)
+				break
+			}
+			if len(addresses) == 0 {
+				e.voids++
+			}
+			term.Addresses = addresses
+		case "ptr":
+			term.Lookups = 1
+			e.warn("%s: the ptr mechanism is slow and deprecated (RFC 7208 section 5.5); list the sending addresses instead", term.Term)
+		case "exists":
+			term.Lookups = 1
+		default:
+			term.Error = "unknown mechanism"
+			e.warn("%s: unknown mechanism", term.Term)
+		}
+		terms = append(terms, term)
+	}
+	return terms
+}
+
+// resolveNetworks returns the addresses an a or mx term matches, with its prefix lengths applied
+func (e *spfEval) resolveNetworks(kind, host, cidr string) ([]string, error) {
+	v4Prefix, v6Prefix, err := parseDualCIDR(cidr)
+	if err != nil {
+		return nil, err
+	}
+
+	hosts := []string{host}
+	if kind == "mx" {
+		resp, err := e.analyzer.checker.query(e.analyzer.checker.resolver, host, mdns.TypeMX, true)
+		if err != nil {
+			return nil, fmt.Errorf("MX lookup failed: %w", err)
+		}
+		hosts = nil
+		for _, rr := range resp.Answer {
+			if mx, ok := rr.(*mdns.MX); ok {
+				hosts = append(hosts, mx.Mx)
+			}
+		}
+		if len(hosts) > spfMXLimit {
+			return nil, fmt.Errorf("%d MX hosts; receivers only evaluate up to %d", len(hosts), spfMXLimit)
+		}
+	}
+
+	var networks []string
+	for _, h := range hosts {
+		for _, qtype := range []uint16{mdns.TypeA, mdns.TypeAAAA} {
+			resp, err := e.analyzer.checker.query(e.analyzer.checker.resolver, h, qtype, true)
+			if err != nil {
+				return nil, fmt.Errorf("address lookup for %s failed: %w", strings.TrimSuffix(h, "."), err)
+			}
+			for _, rr := range resp.Answer {
+				switch addr := rr.(type) {
+				case *mdns.A:
+					networks = append(networks, maskNetwork(addr.A, v4Prefix, 32))
+				case *mdns.AAAA:
+					networks = append(networks, maskNetwork(addr.AAAA, v6Prefix, 128))
+				}
+			}
+		}
+	}
+	return uniqueSorted(networks), nil
+}
+
+// lookupSPF returns the SPF record published at domain. void reports that the lookup found
+// no records at all, which counts against the void lookup limit.
+func (a *SPFAnalyzer) lookupSPF(domain string) (record string, void bool, err error) {
+	resp, err := a.checker.query(a.checker.resolver, domain, mdns.TypeTXT, true)
+	if err != nil {
+		return "", false, fmt.Errorf("TXT lookup failed: %w", err)
+	}
+	if resp.Rcode == mdns.RcodeNameError || (resp.Rcode == mdns.RcodeSuccess && len(resp.Answer) == 0) {
+		return "", true, fmt.Errorf("%s has no SPF record", domain)
+	}
+	if resp.Rcode != mdns.RcodeSuccess {
+		return "", false, fmt.Errorf("TXT lookup for %s failed: %s", domain, mdns.RcodeToString[resp.Rcode])
+	}
+
+	var records []string
+	for _, rr := range resp.Answer {
+		// A TXT record's strings are concatenated without spaces (RFC 7208 section 3.3)
+		if txt, ok := rr.(*mdns.TXT); ok && isSPFRecord(strings.Join(txt.Txt, "")) {
+			records = append(records, strings.Join(txt.Txt, ""))
+		}
+	}
+	switch len(records) {
+	case 0:
+		return "", false, fmt.Errorf("%s has no SPF record", domain)
+	case 1:
+		return records[0], false, nil
+	default:
+		return "", false, fmt.Errorf("%s publishes %d SPF records; receivers reject more than one", domain, len(records))
+	}
+}
+
+// parseSPFTerm splits a term into qualifier, name, target and prefix lengths. Modifiers are
+// name=value; mechanisms are name[:target][/cidr].
+func parseSPFTerm(raw string) SPFTerm {
+	term := SPFTerm{Term: raw, qualifier: "+"}
+	body := raw
+	if strings.ContainsAny(body[:1], "+-~?") {
+		term.qualifier, body = body[:1], body[1:]
+	}
+
+	if i := strings.IndexAny(body, "=:/"); i >= 0 && body[i] == '=' {
+		term.kind, term.target = strings.ToLower(body[:i]), body[i+1:]
+		return term
+	}
+
+	name, rest := body, ""
+	if i := strings.IndexAny(body, ":/"); i >= 0 {
+		name, rest = body[:i], body[i:]
+	}
+	term.kind = strings.ToLower(name)
+	switch term.kind {
+	case "ip4", "ip6":
+		// The network keeps its prefix length; IPv6 addresses contain colons of their own
+		term.target = strings.TrimPrefix(rest, ":")
+	default:
+		if strings.HasPrefix(rest, ":") {
+			rest = rest[1:]
+			if i := strings.Index(rest, "/"); i >= 0 {
+				term.target, term.cidr = rest[:i], rest[i:]
+			} else {
+				term.target = rest
+			}
+		} else {
+			term.cidr = rest
+		}
+	}
+	return term
+}
+
+// parseDualCIDR parses the "/24", "//64" or "/24//64" suffix of an a or mx term
+func parseDualCIDR(cidr string) (v4, v6 int, err error) {
+	v4, v6 = 32, 128
+	if cidr == "" {
+		return v4, v6, nil
+	}
+
+	v4Part, v6Part := cidr, ""
+	if i := strings.Index(cidr, "//"); i >= 0 {
+		v4Part, v6Part = cidr[:i], cidr[i+2:]
+	}
+	if v4Part != "" {
+		if v4, err = strconv.Atoi(strings.TrimPrefix(v4Part, "/")); err != nil || v4 < 0 || v4 > 32 {
+			return 0, 0, fmt.Errorf("invalid IPv4 prefix length %q", v4Part)
+		}
+	}
+	if v6Part != "" {
+		if v6, err = strconv.Atoi(v6Part); err != nil || v6 < 0 || v6 > 128 {
+			return 0, 0, fmt.Errorf("invalid IPv6 prefix length %q", v6Part)
+		}
+	}
+	return v4, v6, nil
+}
+
+// maskNetwork renders ip as a network of the given prefix length, omitting a full-length prefix
+func maskNetwork(ip net.IP, prefix, bits int) string {
+	if prefix == bits {
+		return ip.String()
+	}
+	return fmt.Sprintf("%s/%d", ip.Mask(net.CIDRMask(prefix, bits)).String(), prefix)
+}
+
+// flattenSPFTerms rewrites terms with include, a, mx and redirect terms replaced by the networks
+// they match, and returns the terms it had to keep as written
+func flattenSPFTerms(terms []SPFTerm) (flattened, kept []string) {
+	seen := map[string]bool{}
+	add := func(term string) {
+		if !seen[term] {
+			seen[term] = true
+			flattened = append(flattened, term)
+		}
+	}
+	addNetworks := func(qualifier string, networks []string) {
+		if qualifier == "+" {
+			qualifier = ""
+		}
+		for _, network := range networks {
+			mechanism := "ip4:"
+			if strings.Contains(network, ":") {
+				mechanism = "ip6:"
+			}
+			add(qualifier + mechanism + network)
+		}
+	}
+	keep := func(term SPFTerm) {
+		add(term.Term)
+		kept = append(kept, term.Term)
+	}
+
+	for _, term := range terms {
+		switch term.kind {
+		case "a", "mx":
+			if term.Error != "" {
+				keep(term)
+				continue
+			}
+			addNetworks(term.qualifier, term.Addresses)
+		case "include":
+			networks, ok := passNetworks(term)
+			if !ok {
+				keep(term)
+				continue
+			}
+			addNetworks(term.qualifier, networks)
+		case "redirect":
+			if term.ignored {
+				continue
+			}
+			// The target's policy, including its all mechanism, replaces the rest of the record
+			nested, nestedKept := flattenSPFTerms(term.Terms)
+			if term.Error != "" || len(nestedKept) > 0 {
+				keep(term)
+				continue
+			}
+			for _, t := range nested {
+				add(t)
+			}
+		default:
+			add(term.Term)
+			if term.Lookups > 0 || term.Error != "" {
+				kept = append(kept, term.Term)
+			}
+		}
+	}
+	return flattened, kept
+}
+
+// passNetworks returns the networks an include matches: everything its target record passes.
+// ok is false when that can't be expressed as a list of networks, e.g. because the record uses
+// exists or ptr, or excludes addresses before passing others.
+func passNetworks(include SPFTerm) (networks []string, ok bool) {
+	if include.Error != "" {
+		return nil, false
+	}
+	for _, term := range include.Terms {
+		if term.kind == "all" {
+			if term.qualifier == "+" {
+				return nil, false
+			}
+			continue
+		}
+		if term.kind == "exp" || term.ignored {
+			continue
+		}
+		if term.qualifier != "+" || term.Error != "" {
+			return nil, false
+		}
+
+		switch term.kind {
+		case "ip4", "ip6":
+			networks = append(networks, term.target)
+		case "a", "mx":
+			networks = append(networks, term.Addresses...)
+		case "include", "redirect":
+			nested, ok := passNetworks(term)
+			if !ok {
+				return nil, false
+			}
+			networks = append(networks, nested...)
+		default:
+			return nil, false
+		}
+	}
+	return networks, true
+}
+
+func sumLookups(terms []SPFTerm) int {
+	total := 0
+	for _, term := range terms {
+		total += term.Lookups
+	}
+	return total
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
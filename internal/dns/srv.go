@@ -0,0 +1,71 @@
+package dns
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rusiqe/domainvault/internal/types"
+)
+
+// SRVService is the service and protocol encoded in an SRV owner name such as `_sip._tcp`
+type SRVService struct {
+	Service  string `json:"service"`        // e.g. "sip", without the leading underscore
+	Protocol string `json:"protocol"`       // e.g. "tcp"
+	Host     string `json:"host,omitempty"` // Subdomain after the protocol label, empty for the zone apex
+}
+
+// ParseSRVName parses an SRV owner name of the form `_service._proto[.host]` (RFC 2782)
+func ParseSRVName(name string) (SRVService, error) {
+	labels := strings.SplitN(name, ".", 3)
+	if len(labels) < 2 || !strings.HasPrefix(labels[0], "_") || !strings.HasPrefix(labels[1], "_") ||
+		len(labels[0]) < 2 || len(labels[1]) < 2 {
+		return SRVService{}, fmt.Errorf("SRV name must be in the form _service._proto, e.g. _sip._tcp")
+	}
+
+	svc := SRVService{
+		Service:  strings.ToLower(labels[0][1:]),
+		Protocol: strings.ToLower(labels[1][1:]),
+	}
+	if len(labels) == 3 {
+		svc.Host = labels[2]
+	}
+	return svc, nil
+}
+
+// validateSRV checks the owner name, target and the priority/weight/port fields of an SRV record
+func validateSRV(record *types.DNSRecord) error {
+	if record.Priority == nil || record.Weight == nil || record.Port == nil {
+		return fmt.Errorf("SRV records require priority, weight, and port")
+	}
+	if _, err := ParseSRVName(record.Name); err != nil {
+		return err
+	}
+
+	fields := []struct {
+		name  string
+		value int
+	}{{"priority", *record.Priority}, {"weight", *record.Weight}, {"port", *record.Port}}
+	for _, field := range fields {
+		if field.value < 0 || field.value > 65535 {
+			return fmt.Errorf("SRV %s must be between 0 and 65535", field.name)
+		}
+	}
+
+	// A target of "." explicitly says the service is not available at this domain
+	target := strings.TrimSuffix(strings.TrimSpace(record.Value), ".")
+	if target == "" && strings.TrimSpace(record.Value) != "." {
+		return fmt.Errorf("SRV target is required")
+	}
+	if strings.ContainsAny(target, " \t") {
+		return fmt.Errorf("SRV target must be a single hostname")
+	}
+	return nil
+}
+
+// FormatSRV renders an SRV record for display as `_sip._tcp -> 10 60 5060 sip.example.com`
+func FormatSRV(record types.DNSRecord) string {
+	if record.Priority == nil || record.Weight == nil || record.Port == nil {
+		return fmt.Sprintf("%s -> %s", record.Name, record.Value)
+	}
+	return fmt.Sprintf("%s -> %d %d %d %s", record.Name, *record.Priority, *record.Weight, *record.Port, record.Value)
+}
@@ -0,0 +1,94 @@
+package dns
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/rusiqe/domainvault/internal/types"
+)
+
+// SyncState summarises how a domain's stored records compare with its provider's records as
+// last fetched
+type SyncState struct {
+	Provider      string               `json:"provider,omitempty"`
+	LastFetchedAt *time.Time           `json:"last_fetched_at,omitempty"` // Nil if never fetched
+	Synced        int                  `json:"synced"`
+	PendingPush   int                  `json:"pending_push"`
+	PendingDelete []types.DNSRecord    `json:"pending_delete"`      // At the provider but no longer stored
+	LastSync      *types.DNSSyncResult `json:"last_sync,omitempty"` // Latest fetch by a domain sync, nil if none ran
+}
+
+// StoreProviderRecords replaces a domain's stored records with the ones just fetched from
+// provider and remembers them as the provider's state, so later local edits can be flagged
+// as pending push. Failing to save the state is logged; the records are already stored.
+func (d *DNSService) StoreProviderRecords(domainID, provider string, records []types.DNSRecord) error {
+	if err := d.BulkUpdateRecords(domainID, records); err != nil {
+		return err
+	}
+	d.RecordProviderState(domainID, provider, records)
+	return nil
+}
+
+// RecordProviderState remembers records as what provider has for a domain, without touching
+// the stored records; used when a fetch matched what was already stored. Failures are logged.
+func (d *DNSService) RecordProviderState(domainID, provider string, records []types.DNSRecord) {
+	state := &types.DNSProviderState{
+		DomainID:  domainID,
+		Provider:  provider,
+		Records:   append(types.DNSRecordList{}, records...),
+		FetchedAt: time.Now(),
+	}
+	if err := d.repo.SaveDNSProviderState(state); err != nil {
+		log.Printf("Failed to save %s DNS state for domain %s: %v", provider, domainID, err)
+	}
+}
+
+// AnnotateSyncStatus sets SyncStatus on each of records by matching it, by content, against
+// the provider's records as last fetched. Records are left unannotated if the provider's
+// records were never fetched.
+func (d *DNSService) AnnotateSyncStatus(domainID string, records []types.DNSRecord) (*SyncState, error) {
+	state, err := d.repo.GetDNSProviderState(domainID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get provider DNS state: %w", err)
+	}
+
+	lastSync, err := d.repo.GetDNSSyncResult(domainID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DNS sync result: %w", err)
+	}
+
+	summary := &SyncState{PendingDelete: []types.DNSRecord{}, LastSync: lastSync}
+	if state == nil {
+		return summary, nil
+	}
+	summary.Provider = state.Provider
+	summary.LastFetchedAt = &state.FetchedAt
+
+	remaining := make(map[string]int, len(state.Records))
+	for _, r := range state.Records {
+		remaining[recordKey(r)]++
+	}
+	for i := range records {
+		key := recordKey(records[i])
+		if remaining[key] > 0 {
+			remaining[key]--
+			records[i].SyncStatus = types.DNSSyncSynced
+			summary.Synced++
+			continue
+		}
+		records[i].SyncStatus = types.DNSSyncPendingPush
+		summary.PendingPush++
+	}
+
+	for _, r := range state.Records {
+		key := recordKey(r)
+		if remaining[key] == 0 {
+			continue
+		}
+		remaining[key]--
+		r.SyncStatus = ""
+		summary.PendingDelete = append(summary.PendingDelete, r)
+	}
+	return summary, nil
+}
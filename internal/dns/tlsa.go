@@ -0,0 +1,101 @@
+package dns
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/rusiqe/domainvault/internal/types"
+)
+
+// TLSA certificate usages (RFC 6698 §2.1.1, RFC 7218 acronyms)
+var tlsaUsages = map[int]string{
+	0: "PKIX-TA",
+	1: "PKIX-EE",
+	2: "DANE-TA",
+	3: "DANE-EE",
+}
+
+// tlsaDigestLengths maps TLSA matching types to the expected data length in bytes (0 = any)
+var tlsaDigestLengths = map[int]int{
+	0: 0,  // Full certificate or public key
+	1: 32, // SHA-256
+	2: 64, // SHA-512
+}
+
+// TLSARecord is the parsed form of a TLSA record value: `<usage> <selector> <matching type> <data>`
+type TLSARecord struct {
+	Usage        int    `json:"usage"`
+	Selector     int    `json:"selector"`      // 0 full certificate, 1 SubjectPublicKeyInfo
+	MatchingType int    `json:"matching_type"` // 0 exact, 1 SHA-256, 2 SHA-512
+	Data         string `json:"data"`          // Lowercase hex
+}
+
+// ParseTLSA parses a TLSA record value such as `3 1 1 0b9fa5a5...`. Whitespace inside the
+// hex data is allowed, as zone files often split long digests.
+func ParseTLSA(value string) (TLSARecord, error) {
+	fields := strings.Fields(value)
+	if len(fields) < 4 {
+		return TLSARecord{}, fmt.Errorf("TLSA value must be in the form: usage selector matching-type data")
+	}
+
+	var nums [3]int
+	for i, name := range []string{"usage", "selector", "matching type"} {
+		n, err := strconv.Atoi(fields[i])
+		if err != nil {
+			return TLSARecord{}, fmt.Errorf("TLSA %s must be a number", name)
+		}
+		nums[i] = n
+	}
+
+	record := TLSARecord{Usage: nums[0], Selector: nums[1], MatchingType: nums[2], Data: strings.ToLower(strings.Join(fields[3:], ""))}
+	if _, ok := tlsaUsages[record.Usage]; !ok {
+		return TLSARecord{}, fmt.Errorf("TLSA usage must be between 0 and 3")
+	}
+	if record.Selector != 0 && record.Selector != 1 {
+		return TLSARecord{}, fmt.Errorf("TLSA selector must be 0 or 1")
+	}
+	length, ok := tlsaDigestLengths[record.MatchingType]
+	if !ok {
+		return TLSARecord{}, fmt.Errorf("TLSA matching type must be between 0 and 2")
+	}
+
+	data, err := hex.DecodeString(record.Data)
+	if err != nil || len(data) == 0 {
+		return TLSARecord{}, fmt.Errorf("TLSA data must be hex encoded")
+	}
+	if length > 0 && len(data) != length {
+		return TLSARecord{}, fmt.Errorf("TLSA matching type %d expects a %d byte digest, got %d bytes", record.MatchingType, length, len(data))
+	}
+
+	return record, nil
+}
+
+// String formats the record in canonical presentation form
+func (t TLSARecord) String() string {
+	return fmt.Sprintf("%d %d %d %s", t.Usage, t.Selector, t.MatchingType, t.Data)
+}
+
+// UsageName returns the RFC 7218 acronym for the certificate usage, e.g. DANE-EE
+func (t TLSARecord) UsageName() string {
+	return tlsaUsages[t.Usage]
+}
+
+// validateTLSA checks the `_port._proto[.host]` owner name and normalizes the value
+func validateTLSA(record *types.DNSRecord) error {
+	svc, err := ParseSRVName(record.Name)
+	if err != nil {
+		return fmt.Errorf("TLSA name must be in the form _port._proto, e.g. _443._tcp")
+	}
+	if port, err := strconv.Atoi(svc.Service); err != nil || port < 1 || port > 65535 {
+		return fmt.Errorf("TLSA name must start with a port number, e.g. _443._tcp")
+	}
+
+	tlsa, err := ParseTLSA(record.Value)
+	if err != nil {
+		return err
+	}
+	record.Value = tlsa.String() // Store in canonical form so the digest compares equal
+	return nil
+}
@@ -0,0 +1,54 @@
+package dns
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	mdns "github.com/miekg/dns"
+
+	"github.com/rusiqe/domainvault/internal/types"
+)
+
+// LookupTXTAuthoritative returns the TXT values at name, asked of domainName's delegated
+// nameservers directly. A record that was just added is seen at once, without waiting for
+// resolver caches to expire a previous "no such record" answer. Each value is the record's
+// strings joined, as long TXT values are split into 255 byte strings.
+func (hc *HealthChecker) LookupTXTAuthoritative(domainName, name string) ([]string, error) {
+	domain := mdns.Fqdn(types.NormalizeDomainName(domainName))
+	delegation, glue, err := hc.parentDelegation(domain)
+	if err != nil {
+		return nil, err
+	}
+
+	var failures []string
+	for _, host := range delegation {
+		addrs := glue[host]
+		if len(addrs) == 0 {
+			addrs = hc.lookupAddrs(host)
+		}
+		for _, addr := range addrs {
+			resp, err := hc.query(net.JoinHostPort(addr, "53"), name, mdns.TypeTXT, false)
+			if err != nil {
+				failures = append(failures, fmt.Sprintf("%s: %v", strings.TrimSuffix(host, "."), err))
+				continue
+			}
+			if resp.Rcode != mdns.RcodeSuccess && resp.Rcode != mdns.RcodeNameError {
+				failures = append(failures, fmt.Sprintf("%s: %s", strings.TrimSuffix(host, "."), mdns.RcodeToString[resp.Rcode]))
+				continue
+			}
+
+			values := []string{}
+			for _, rr := range resp.Answer {
+				if txt, ok := rr.(*mdns.TXT); ok {
+					values = append(values, strings.Join(txt.Txt, ""))
+				}
+			}
+			return values, nil
+		}
+	}
+	if len(failures) == 0 {
+		return nil, fmt.Errorf("no nameserver of %s has an address", strings.TrimSuffix(domain, "."))
+	}
+	return nil, fmt.Errorf("no nameserver of %s answered: %s", strings.TrimSuffix(domain, "."), strings.Join(failures, "; "))
+}
@@ -0,0 +1,273 @@
+package dns
+
+import (
+	"bufio"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/rusiqe/domainvault/internal/types"
+)
+
+// ExportZone renders records as a BIND-style zone file for the given domain
+func ExportZone(domainName string, records []types.DNSRecord) string {
+	sorted := make([]types.DNSRecord, len(records))
+	copy(sorted, records)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Name != sorted[j].Name {
+			return sorted[i].Name < sorted[j].Name
+		}
+		return sorted[i].Type < sorted[j].Type
+	})
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "$ORIGIN %s.\n", strings.TrimSuffix(domainName, "."))
+	for _, r := range sorted {
+		fmt.Fprintf(&b, "%s\t%d\tIN\t%s\t%s\n", r.Name, r.TTL, canonicalRecordType(r.Type), zoneRData(r))
+	}
+	return b.String()
+}
+
+// zoneRData formats a record's data section, re-attaching fields stored separately
+func zoneRData(r types.DNSRecord) string {
+	switch strings.ToUpper(r.Type) {
+	case "CNAME", "NS", "ALIAS":
+		return fqdn(r.Value)
+	case "MX":
+		if r.Priority != nil {
+			return fmt.Sprintf("%d %s", *r.Priority, fqdn(r.Value))
+		}
+	case "SRV":
+		if r.Priority != nil && r.Weight != nil && r.Port != nil {
+			return fmt.Sprintf("%d %d %d %s", *r.Priority, *r.Weight, *r.Port, fqdn(r.Value))
+		}
+	case "TXT":
+		return types.QuoteTXT(r.Value) // Long values become several strings, as in DKIM keys
+	case "CAA":
+		if caa, err := ParseCAA(r.Value); err == nil {
+			return caa.String()
+		}
+	case "TLSA":
+		if tlsa, err := ParseTLSA(r.Value); err == nil {
+			return tlsa.String()
+		}
+	}
+	return r.Value
+}
+
+// ImportZone parses a BIND-style zone file into records for domainID. It understands
+// one record per line in the form `name [ttl] [IN] type rdata`, plus $ORIGIN and $TTL.
+// Data in parentheses may span lines, as long DKIM keys usually do.
+func ImportZone(domainID, zone string) ([]types.DNSRecord, error) {
+	var records []types.DNSRecord
+	defaultTTL := 3600
+	origin := ""
+
+	scanner := bufio.NewScanner(strings.NewReader(zone))
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := stripZoneComment(scanner.Text())
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		start := lineNo
+		for zoneParenDepth(line) > 0 {
+			if !scanner.Scan() {
+				return nil, fmt.Errorf("line %d: unclosed parenthesis", start)
+			}
+			lineNo++
+			line += " " + stripZoneComment(scanner.Text())
+		}
+
+		fields := zoneFields(line)
+		switch strings.ToUpper(fields[0]) {
+		case "$ORIGIN":
+			if len(fields) > 1 {
+				origin = strings.TrimSuffix(fields[1], ".")
+			}
+			continue
+		case "$TTL":
+			if len(fields) > 1 {
+				if ttl, err := strconv.Atoi(fields[1]); err == nil {
+					defaultTTL = ttl
+				}
+			}
+			continue
+		}
+
+		if len(fields) < 3 {
+			return nil, fmt.Errorf("line %d: expected name, type and data", lineNo)
+		}
+
+		record := types.DNSRecord{DomainID: domainID, Name: relativeName(fields[0], origin), TTL: defaultTTL}
+		rest := fields[1:]
+		if ttl, err := strconv.Atoi(rest[0]); err == nil {
+			record.TTL = ttl
+			rest = rest[1:]
+		}
+		if len(rest) > 0 && strings.EqualFold(rest[0], "IN") {
+			rest = rest[1:]
+		}
+		if len(rest) < 2 {
+			return nil, fmt.Errorf("line %d: expected type and data", lineNo)
+		}
+		record.Type = canonicalRecordType(rest[0])
+		if record.Type == "SOA" {
+			continue // The SOA belongs to the DNS host, not to us
+		}
+
+		if err := setZoneRData(&record, rest[1:], origin); err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNo, err)
+		}
+		records = append(records, record)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// setZoneRData fills the value (and MX/SRV extras) from the data fields of a zone line
+func setZoneRData(record *types.DNSRecord, data []string, origin string) error {
+	switch record.Type {
+	case "MX":
+		if len(data) != 2 {
+			return fmt.Errorf("MX data must be: priority host")
+		}
+		priority, err := strconv.Atoi(data[0])
+		if err != nil {
+			return fmt.Errorf("invalid MX priority: %s", data[0])
+		}
+		record.Priority = &priority
+		record.Value = data[1]
+	case "SRV":
+		if len(data) != 4 {
+			return fmt.Errorf("SRV data must be: priority weight port target")
+		}
+		values := make([]int, 3)
+		for i := range values {
+			v, err := strconv.Atoi(data[i])
+			if err != nil {
+				return fmt.Errorf("invalid SRV field: %s", data[i])
+			}
+			values[i] = v
+		}
+		record.Priority, record.Weight, record.Port = &values[0], &values[1], &values[2]
+		record.Value = data[3]
+	case "TXT":
+		// Adjacent quoted strings are one value split into chunks
+		record.Value = types.DechunkTXT(strings.Join(data, " "))
+	case "CAA":
+		caa, err := ParseCAA(strings.Join(data, " "))
+		if err != nil {
+			return err
+		}
+		record.Value = caa.String()
+	case "TLSA":
+		tlsa, err := ParseTLSA(strings.Join(data, " "))
+		if err != nil {
+			return err
+		}
+		record.Value = tlsa.String()
+	default:
+		record.Value = strings.Join(data, " ")
+	}
+
+	if record.Type == "CNAME" || record.Type == "NS" || record.Type == "MX" || record.Type == "SRV" || record.Type == "ALIAS" {
+		record.Value = strings.TrimSuffix(record.Value, ".")
+	}
+	return nil
+}
+
+// fqdn makes a hostname absolute so it isn't read relative to $ORIGIN on import
+func fqdn(host string) string {
+	if host == "@" || host == "" || strings.HasSuffix(host, ".") {
+		return host
+	}
+	return host + "."
+}
+
+// relativeName converts an owner name to the @ / subdomain form records are stored in
+func relativeName(name, origin string) string {
+	if name == "@" || origin == "" {
+		return name
+	}
+	name = strings.TrimSuffix(name, ".")
+	if strings.EqualFold(name, origin) {
+		return "@"
+	}
+	return strings.TrimSuffix(name, "."+origin)
+}
+
+// stripZoneComment removes a trailing ; comment that isn't inside quotes
+func stripZoneComment(line string) string {
+	inQuotes := false
+	for i := 0; i < len(line); i++ {
+		switch line[i] {
+		case '\\':
+			if inQuotes {
+				i++ // An escaped quote doesn't end the string
+			}
+		case '"':
+			inQuotes = !inQuotes
+		case ';':
+			if !inQuotes {
+				return line[:i]
+			}
+		}
+	}
+	return line
+}
+
+// zoneParenDepth counts the parentheses outside quotes a zone line leaves open
+func zoneParenDepth(line string) int {
+	depth := 0
+	inQuotes := false
+	for i := 0; i < len(line); i++ {
+		switch {
+		case line[i] == '\\' && inQuotes:
+			i++
+		case line[i] == '"':
+			inQuotes = !inQuotes
+		case line[i] == '(' && !inQuotes:
+			depth++
+		case line[i] == ')' && !inQuotes:
+			depth--
+		}
+	}
+	return depth
+}
+
+// zoneFields splits a zone line on whitespace and grouping parentheses, keeping quoted
+// strings (with their quotes and escapes) intact
+func zoneFields(line string) []string {
+	var fields []string
+	var current strings.Builder
+	inQuotes, escaped := false, false
+	for _, ch := range line {
+		switch {
+		case escaped:
+			escaped = false
+			current.WriteRune(ch)
+		case ch == '\\' && inQuotes:
+			escaped = true
+			current.WriteRune(ch)
+		case ch == '"':
+			inQuotes = !inQuotes
+			current.WriteRune(ch)
+		case (ch == ' ' || ch == '\t' || ch == '(' || ch == ')') && !inQuotes:
+			if current.Len() > 0 {
+				fields = append(fields, current.String())
+				current.Reset()
+			}
+		default:
+			current.WriteRune(ch)
+		}
+	}
+	if current.Len() > 0 {
+		fields = append(fields, current.String())
+	}
+	return fields
+}
@@ -0,0 +1,70 @@
+package dns
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rusiqe/domainvault/internal/types"
+)
+
+func TestZoneLongTXTRoundTrip(t *testing.T) {
+	dkim := "v=DKIM1; k=rsa; p=" + strings.Repeat("MIIBIjANBgkqhkiG9w0B", 20)
+	records := []types.DNSRecord{
+		{Type: "TXT", Name: "default._domainkey", Value: dkim, TTL: 3600},
+		{Type: "TXT", Name: "@", Value: `say "hi"; ok`, TTL: 3600},
+	}
+
+	zone := ExportZone("example.com", records)
+	if !strings.Contains(zone, `" "`) {
+		t.Fatalf("ExportZone did not split the %d-byte DKIM key:\n%s", len(dkim), zone)
+	}
+
+	imported, err := ImportZone("d1", zone)
+	if err != nil {
+		t.Fatalf("ImportZone() unexpected error: %v", err)
+	}
+	values := map[string]string{}
+	for _, r := range imported {
+		values[r.Name] = r.Value
+	}
+	for _, r := range records {
+		if values[r.Name] != r.Value {
+			t.Errorf("%s did not round-trip: got %q, want %q", r.Name, values[r.Name], r.Value)
+		}
+	}
+}
+
+func TestImportZoneMultiLineTXT(t *testing.T) {
+	zone := `$ORIGIN example.com.
+selector._domainkey 3600 IN TXT ( "v=DKIM1; k=rsa; "  ; key follows
+	"p=MIIBIjAN"
+	"BgkqhkiG" )
+www 3600 IN A 192.0.2.1
+`
+	records, err := ImportZone("d1", zone)
+	if err != nil {
+		t.Fatalf("ImportZone() unexpected error: %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("Expected 2 records, got %d", len(records))
+	}
+	if want := "v=DKIM1; k=rsa; p=MIIBIjANBgkqhkiG"; records[0].Value != want {
+		t.Errorf("Multi-line TXT = %q, want %q", records[0].Value, want)
+	}
+
+	if _, err := ImportZone("d1", `x IN TXT ( "open"`); err == nil {
+		t.Error("Expected an error for an unclosed parenthesis")
+	}
+}
+
+func TestRecordWarnings(t *testing.T) {
+	records := []types.DNSRecord{
+		{Type: "TXT", Name: "k1._domainkey", Value: strings.Repeat("a", 400)},
+		{Type: "TXT", Name: "@", Value: "v=spf1 -all"},
+		{Type: "A", Name: "@", Value: "192.0.2.1"},
+	}
+	warnings := RecordWarnings(records)
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "k1._domainkey") {
+		t.Errorf("Expected one warning for the long TXT record, got %v", warnings)
+	}
+}
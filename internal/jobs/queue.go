@@ -0,0 +1,301 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Defaults for NewQueue
+const (
+	DefaultWorkers     = 4
+	DefaultQueueSize   = 256 // Jobs waiting for a worker before Enqueue refuses more
+	DefaultMaxRetained = 500 // Finished jobs kept for polling before the oldest are dropped
+)
+
+// ErrQueueFull is returned by Enqueue when too many jobs are already waiting
+var ErrQueueFull = errors.New("job queue is full")
+
+// ErrQueueClosed is returned by Enqueue once Shutdown has been called
+var ErrQueueClosed = errors.New("job queue is shut down")
+
+// Status is the lifecycle state of a job
+type Status string
+
+const (
+	StatusQueued    Status = "queued"
+	StatusRunning   Status = "running"
+	StatusSucceeded Status = "succeeded"
+	StatusFailed    Status = "failed"
+)
+
+// Job is a snapshot of a unit of background work
+type Job struct {
+	ID         string      `json:"id"`
+	Type       string      `json:"type"` // e.g. "bulk_sync", "bulk_status_check"
+	Status     Status      `json:"status"`
+	Progress   Progress    `json:"progress"`
+	Result     interface{} `json:"result,omitempty"` // Set once the job finishes, even if it failed; partial while running if the job publishes one
+	Error      string      `json:"error,omitempty"`
+	CreatedBy  string      `json:"created_by,omitempty"`
+	CreatedAt  time.Time   `json:"created_at"`
+	StartedAt  *time.Time  `json:"started_at,omitempty"`
+	FinishedAt *time.Time  `json:"finished_at,omitempty"`
+}
+
+// Progress counts the items a job has processed; Total is 0 until the job knows it
+type Progress struct {
+	Done    int    `json:"done"`
+	Total   int    `json:"total"`
+	Message string `json:"message,omitempty"`
+}
+
+// Finished reports whether the job has succeeded or failed
+func (j Job) Finished() bool {
+	return j.Status == StatusSucceeded || j.Status == StatusFailed
+}
+
+// Func is the work a job performs. It reports progress through the tracker and returns the
+// job's result. ctx is cancelled when the queue shuts down.
+type Func func(ctx context.Context, tracker *Tracker) (interface{}, error)
+
+// Tracker lets a running job report its progress. A nil Tracker ignores updates, so work can
+// be shared between jobs and synchronous requests.
+type Tracker struct {
+	queue *Queue
+	id    string
+}
+
+// SetTotal records how many items the job will process
+func (t *Tracker) SetTotal(total int) {
+	if t == nil {
+		return
+	}
+	t.queue.update(t.id, func(job *Job) { job.Progress.Total = total })
+}
+
+// Advance records that n more items were processed, with an optional message such as the current item
+func (t *Tracker) Advance(n int, message string) {
+	if t == nil {
+		return
+	}
+	t.queue.update(t.id, func(job *Job) {
+		job.Progress.Done += n
+		job.Progress.Message = message
+	})
+}
+
+// SetResult publishes a partial result while the job runs, so pollers see work done so far.
+// The job's return value replaces it when the job finishes. The result must not be changed
+// afterwards, since pollers read it concurrently; publish a fresh copy each time.
+func (t *Tracker) SetResult(result interface{}) {
+	if t == nil {
+		return
+	}
+	t.queue.update(t.id, func(job *Job) { job.Result = result })
+}
+
+type pending struct {
+	id string
+	fn Func
+}
+
+// Queue runs jobs on a fixed pool of workers and keeps their state for polling. It is safe
+// for concurrent use.
+type Queue struct {
+	mu          sync.RWMutex
+	jobs        map[string]*Job
+	closed      bool
+	maxRetained int
+
+	work   chan pending
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewQueue starts a queue with the given number of workers
+func NewQueue(workers int) *Queue {
+	if workers <= 0 {
+		workers = DefaultWorkers
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	q := &Queue{
+		jobs:        make(map[string]*Job),
+		maxRetained: DefaultMaxRetained,
+		work:        make(chan pending, DefaultQueueSize),
+		ctx:         ctx,
+		cancel:      cancel,
+	}
+
+	for i := 0; i < workers; i++ {
+		q.wg.Add(1)
+		go q.worker()
+	}
+	return q
+}
+
+// Enqueue schedules fn and returns a snapshot of the queued job
+func (q *Queue) Enqueue(jobType, createdBy string, fn Func) (Job, error) {
+	job := &Job{
+		ID:        uuid.New().String(),
+		Type:      jobType,
+		Status:    StatusQueued,
+		CreatedBy: createdBy,
+		CreatedAt: time.Now(),
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed {
+		return Job{}, ErrQueueClosed
+	}
+
+	select {
+	case q.work <- pending{id: job.ID, fn: fn}:
+	default:
+		return Job{}, ErrQueueFull
+	}
+	q.jobs[job.ID] = job
+	q.pruneLocked()
+	return *job, nil
+}
+
+// Get returns a snapshot of the job with the given ID
+func (q *Queue) Get(id string) (Job, bool) {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	job, ok := q.jobs[id]
+	if !ok {
+		return Job{}, false
+	}
+	return *job, true
+}
+
+// List returns snapshots of the retained jobs, newest first. Empty filters match every job.
+func (q *Queue) List(jobType string, status Status) []Job {
+	q.mu.RLock()
+	jobs := make([]Job, 0, len(q.jobs))
+	for _, job := range q.jobs {
+		if (jobType == "" || job.Type == jobType) && (status == "" || job.Status == status) {
+			jobs = append(jobs, *job)
+		}
+	}
+	q.mu.RUnlock()
+
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i].CreatedAt.After(jobs[j].CreatedAt) })
+	return jobs
+}
+
+// Shutdown stops accepting jobs, cancels the context of running jobs and waits for the workers
+// to exit. Jobs still waiting for a worker are marked failed.
+func (q *Queue) Shutdown(ctx context.Context) error {
+	q.mu.Lock()
+	if !q.closed {
+		q.closed = true
+		close(q.work)
+	}
+	q.mu.Unlock()
+	q.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		q.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("timed out waiting for running jobs: %w", ctx.Err())
+	}
+}
+
+// worker runs queued jobs until the queue is shut down
+func (q *Queue) worker() {
+	defer q.wg.Done()
+	for p := range q.work {
+		if q.ctx.Err() != nil {
+			q.finish(p.id, nil, ErrQueueClosed)
+			continue
+		}
+		q.run(p)
+	}
+}
+
+// run executes one job, converting a panic into a failure so the worker survives
+func (q *Queue) run(p pending) {
+	now := time.Now()
+	q.update(p.id, func(job *Job) {
+		job.Status = StatusRunning
+		job.StartedAt = &now
+	})
+
+	var (
+		result interface{}
+		err    error
+	)
+	func() {
+		defer func() {
+			if r := recover(); r != nil {
+				log.Printf("Job %s panicked: %v", p.id, r)
+				err = fmt.Errorf("job panicked: %v", r)
+			}
+		}()
+		result, err = p.fn(q.ctx, &Tracker{queue: q, id: p.id})
+	}()
+
+	q.finish(p.id, result, err)
+}
+
+// finish records a job's outcome
+func (q *Queue) finish(id string, result interface{}, err error) {
+	now := time.Now()
+	q.update(id, func(job *Job) {
+		job.Result = result
+		job.FinishedAt = &now
+		job.Status = StatusSucceeded
+		if err != nil {
+			job.Status = StatusFailed
+			job.Error = err.Error()
+		}
+	})
+}
+
+// update applies fn to the job with the given ID under the lock
+func (q *Queue) update(id string, fn func(job *Job)) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if job, ok := q.jobs[id]; ok {
+		fn(job)
+	}
+}
+
+// pruneLocked drops the oldest finished jobs beyond maxRetained. Callers hold q.mu.
+func (q *Queue) pruneLocked() {
+	if len(q.jobs) <= q.maxRetained {
+		return
+	}
+
+	var finished []*Job
+	for _, job := range q.jobs {
+		if job.Finished() {
+			finished = append(finished, job)
+		}
+	}
+	sort.Slice(finished, func(i, j int) bool { return finished[i].CreatedAt.Before(finished[j].CreatedAt) })
+
+	for _, job := range finished {
+		if len(q.jobs) <= q.maxRetained {
+			break
+		}
+		delete(q.jobs, job.ID)
+	}
+}
@@ -0,0 +1,149 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// waitFor polls until the job has finished or the test times out
+func waitFor(t *testing.T, q *Queue, id string) Job {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if job, ok := q.Get(id); ok && job.Finished() {
+			return job
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("job %s did not finish", id)
+	return Job{}
+}
+
+func TestQueue_RunsJobsAndRecordsResults(t *testing.T) {
+	q := NewQueue(2)
+	defer q.Shutdown(context.Background())
+
+	ok, err := q.Enqueue("bulk_status_check", "admin", func(ctx context.Context, tracker *Tracker) (interface{}, error) {
+		tracker.SetTotal(3)
+		for i := 0; i < 3; i++ {
+			tracker.Advance(1, "example.com")
+		}
+		return "done", nil
+	})
+	if err != nil {
+		t.Fatalf("Enqueue() error: %v", err)
+	}
+	if ok.Status != StatusQueued || ok.CreatedBy != "admin" {
+		t.Errorf("Enqueue() returned %+v, want a queued job created by admin", ok)
+	}
+
+	failing, _ := q.Enqueue("bulk_sync", "admin", func(ctx context.Context, tracker *Tracker) (interface{}, error) {
+		return nil, errors.New("provider unreachable")
+	})
+	panicking, _ := q.Enqueue("bulk_sync", "admin", func(ctx context.Context, tracker *Tracker) (interface{}, error) {
+		panic("boom")
+	})
+
+	job := waitFor(t, q, ok.ID)
+	if job.Status != StatusSucceeded || job.Result != "done" || job.Progress.Done != 3 || job.Progress.Total != 3 {
+		t.Errorf("Unexpected succeeded job: %+v", job)
+	}
+	if job := waitFor(t, q, failing.ID); job.Status != StatusFailed || job.Error != "provider unreachable" {
+		t.Errorf("Unexpected failed job: %+v", job)
+	}
+	if job := waitFor(t, q, panicking.ID); job.Status != StatusFailed {
+		t.Errorf("A panicking job should fail, got %+v", job)
+	}
+
+	if jobs := q.List("bulk_sync", ""); len(jobs) != 2 {
+		t.Errorf("List(bulk_sync) returned %d jobs, want 2", len(jobs))
+	}
+	if jobs := q.List("", StatusSucceeded); len(jobs) != 1 || jobs[0].ID != ok.ID {
+		t.Errorf("List(succeeded) = %+v, want only %s", jobs, ok.ID)
+	}
+}
+
+func TestQueue_ConcurrentEnqueueAndPoll(t *testing.T) {
+	q := NewQueue(4)
+	defer q.Shutdown(context.Background())
+
+	var wg sync.WaitGroup
+	ids := make(chan string, 50)
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			job, err := q.Enqueue("import", "", func(ctx context.Context, tracker *Tracker) (interface{}, error) {
+				tracker.Advance(1, "")
+				return nil, nil
+			})
+			if err != nil {
+				t.Errorf("Enqueue() error: %v", err)
+				return
+			}
+			q.List("", "")
+			ids <- job.ID
+		}()
+	}
+	wg.Wait()
+	close(ids)
+
+	for id := range ids {
+		if job := waitFor(t, q, id); job.Status != StatusSucceeded {
+			t.Errorf("Job %s finished with %s", id, job.Status)
+		}
+	}
+}
+
+func TestQueue_Shutdown(t *testing.T) {
+	q := NewQueue(1)
+	started := make(chan struct{})
+	running, _ := q.Enqueue("bulk_sync", "", func(ctx context.Context, tracker *Tracker) (interface{}, error) {
+		close(started)
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+	<-started
+	waiting, _ := q.Enqueue("bulk_sync", "", func(ctx context.Context, tracker *Tracker) (interface{}, error) {
+		return "should not run", nil
+	})
+
+	if err := q.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown() error: %v", err)
+	}
+	if job, _ := q.Get(running.ID); job.Status != StatusFailed {
+		t.Errorf("Running job should be cancelled, got %+v", job)
+	}
+	if job, _ := q.Get(waiting.ID); job.Status != StatusFailed || job.Result != nil {
+		t.Errorf("Waiting job should fail without running, got %+v", job)
+	}
+	if _, err := q.Enqueue("bulk_sync", "", nil); !errors.Is(err, ErrQueueClosed) {
+		t.Errorf("Enqueue() after Shutdown error = %v, want ErrQueueClosed", err)
+	}
+}
+
+func TestTracker_SetResultPublishesPartialResults(t *testing.T) {
+	q := NewQueue(1)
+	defer q.Shutdown(context.Background())
+
+	published := make(chan struct{})
+	release := make(chan struct{})
+	queued, _ := q.Enqueue("availability_search", "admin", func(ctx context.Context, tracker *Tracker) (interface{}, error) {
+		tracker.SetResult("1 of 2")
+		close(published)
+		<-release
+		return "2 of 2", nil
+	})
+
+	<-published
+	if job, _ := q.Get(queued.ID); job.Status != StatusRunning || job.Result != "1 of 2" {
+		t.Errorf("running job = %+v, want the partial result", job)
+	}
+	close(release)
+	if job := waitFor(t, q, queued.ID); job.Result != "2 of 2" {
+		t.Errorf("finished job result = %v, want the returned result", job.Result)
+	}
+}
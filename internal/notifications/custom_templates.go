@@ -0,0 +1,297 @@
+package notifications
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/rusiqe/domainvault/internal/types"
+)
+
+// TemplateStore supplies the stored notification templates that replace the default wording
+type TemplateStore interface {
+	GetNotificationTemplates() ([]types.NotificationTemplate, error)
+}
+
+// TemplateChannels are the channels templates can be written for
+var TemplateChannels = []NotificationChannel{ChannelEmail, ChannelSlack, ChannelWebhook}
+
+// TemplateEvents are the alert types templates can be written for
+var TemplateEvents = []AlertType{
+	AlertExpiringSoon, AlertExpired, AlertGracePeriod, AlertStatusDown, AlertMonitorRecovered,
+	AlertStatusTransition, AlertHTTPTransition, AlertNameserverChange, AlertRenewalPriceRise,
+	AlertResponseTimeSLA, AlertDomainAvailable, AlertDNSChanged, AlertDanglingDNS, AlertSyncFailed,
+	AlertProviderStale, AlertBulkOperation, AlertSecurity, EventDomainPurchased, EventDomainPurchaseFailed,
+}
+
+// TemplateData is what notification templates are rendered against
+type TemplateData struct {
+	Alert           Alert
+	Domain          types.Domain // Zero when the alert isn't about one domain
+	HasDomain       bool
+	DaysUntilExpiry int     // Negative once the domain has expired
+	Urgency         string  // "tomorrow", "very soon" or "soon", for expiry wording
+	RenewalCost     float64 // 0 when the renewal price isn't known
+	DashboardURL    string  // The DomainVault admin page, empty if no public URL is set
+}
+
+// templateSource is the text/template source of an alert's subject and body
+type templateSource struct {
+	Subject string
+	Body    string
+}
+
+// genericTemplate leaves an alert's built-in title and message as they are
+var genericTemplate = templateSource{Subject: "{{.Alert.Title}}", Body: "{{.Alert.Message}}"}
+
+// defaultTemplates are the built-in wording of events whose messages are rendered from
+// templates; every other event uses genericTemplate
+var defaultTemplates = map[AlertType]templateSource{
+	AlertExpiringSoon: {
+		Subject: "Domain {{.Domain.Name}} expires in {{.DaysUntilExpiry}} days",
+		Body: `Domain {{.Domain.Name}} expires {{.Urgency}} ({{.DaysUntilExpiry}} days).
+Expiration Date: {{date "January 2, 2006" .Domain.ExpiresAt}}
+Provider: {{.Domain.Provider}}
+Renewal Price: ${{money .RenewalCost}}
+Auto-renew: {{.Domain.AutoRenew}}
+
+Please ensure renewal is processed in time.{{if .DashboardURL}}
+Manage it in DomainVault: {{.DashboardURL}}{{end}}`,
+	},
+	AlertExpired: {
+		Subject: "Domain {{.Domain.Name}} has expired",
+		Body: `Domain {{.Domain.Name}} has expired on {{date "January 2, 2006" .Domain.ExpiresAt}}.
+Please renew immediately to avoid losing the domain.
+Provider: {{.Domain.Provider}}
+Renewal Price: ${{money .RenewalCost}}
+Auto-renew: {{.Domain.AutoRenew}}{{if .DashboardURL}}
+Manage it in DomainVault: {{.DashboardURL}}{{end}}`,
+	},
+}
+
+// templateFuncs are the functions templates can call besides the text/template builtins
+var templateFuncs = template.FuncMap{
+	"date": func(layout string, value interface{}) string {
+		switch t := value.(type) {
+		case time.Time:
+			return t.Format(layout)
+		case *time.Time:
+			if t != nil {
+				return t.Format(layout)
+			}
+		}
+		return ""
+	},
+	"money": func(value interface{}) string {
+		switch v := value.(type) {
+		case float64:
+			return fmt.Sprintf("%.2f", v)
+		case *float64:
+			if v != nil {
+				return fmt.Sprintf("%.2f", *v)
+			}
+		}
+		return "0.00"
+	},
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+	"join":  strings.Join,
+}
+
+// DefaultTemplate returns the built-in template for an event on channel
+func DefaultTemplate(channel NotificationChannel, event AlertType) types.NotificationTemplate {
+	source, ok := defaultTemplates[event]
+	if !ok {
+		source = genericTemplate
+	}
+	return types.NotificationTemplate{Channel: string(channel), Event: string(event), Subject: source.Subject, Body: source.Body}
+}
+
+// NewTemplateData builds the data an alert's templates are rendered against. dashboardURL is
+// linked from the default expiry wording.
+func NewTemplateData(alert Alert, dashboardURL string) TemplateData {
+	data := TemplateData{Alert: alert, DashboardURL: dashboardURL}
+	if alert.Domain != nil {
+		data.Domain = *alert.Domain
+		data.HasDomain = true
+		data.DaysUntilExpiry = int(time.Until(alert.Domain.ExpiresAt).Hours() / 24)
+		data.RenewalCost = getPrice(alert.Domain.RenewalPrice)
+	}
+	if days, ok := alert.Data["days_until_expiry"].(int); ok {
+		data.DaysUntilExpiry = days
+	}
+
+	switch {
+	case data.DaysUntilExpiry <= 1:
+		data.Urgency = "tomorrow"
+	case data.DaysUntilExpiry <= 7:
+		data.Urgency = "very soon"
+	default:
+		data.Urgency = "soon"
+	}
+	return data
+}
+
+// parseTemplate parses a template's subject and body
+func parseTemplate(t types.NotificationTemplate) (*template.Template, *template.Template, error) {
+	subject, err := template.New("subject").Funcs(templateFuncs).Parse(t.Subject)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: subject: %v", types.ErrInvalidNotificationTemplate, err)
+	}
+	body, err := template.New("body").Funcs(templateFuncs).Parse(t.Body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: body: %v", types.ErrInvalidNotificationTemplate, err)
+	}
+	return subject, body, nil
+}
+
+// RenderTemplate renders a template's subject and body against data
+func RenderTemplate(t types.NotificationTemplate, data TemplateData) (string, string, error) {
+	subjectTmpl, bodyTmpl, err := parseTemplate(t)
+	if err != nil {
+		return "", "", err
+	}
+	var subject, body bytes.Buffer
+	if err := subjectTmpl.Execute(&subject, data); err != nil {
+		return "", "", fmt.Errorf("%w: subject: %v", types.ErrInvalidNotificationTemplate, err)
+	}
+	if err := bodyTmpl.Execute(&body, data); err != nil {
+		return "", "", fmt.Errorf("%w: body: %v", types.ErrInvalidNotificationTemplate, err)
+	}
+	return strings.TrimSpace(subject.String()), body.String(), nil
+}
+
+// SampleDomain is the domain templates are previewed against when no real one is chosen
+func SampleDomain() types.Domain {
+	now := time.Now()
+	price := 12.99
+	return types.Domain{
+		ID:           "sample",
+		Name:         "example.com",
+		Provider:     "namecheap",
+		Status:       "active",
+		ExpiresAt:    now.AddDate(0, 0, 14),
+		AutoRenew:    false,
+		RenewalPrice: &price,
+		CreatedAt:    now.AddDate(-2, 0, 0),
+		UpdatedAt:    now,
+	}
+}
+
+// SetTemplateStore makes the service render alerts with the stored templates in place of the
+// default wording
+func (ns *NotificationService) SetTemplateStore(store TemplateStore) {
+	ns.templateStore = store
+}
+
+// SetDashboardURL sets the DomainVault page templates link to as DashboardURL
+func (ns *NotificationService) SetDashboardURL(url string) {
+	ns.templates.dashboardURL = strings.TrimSuffix(url, "/")
+}
+
+// ValidateTemplate checks a template is for a known channel and event and renders against a
+// sample alert, so mistakes such as unknown fields are caught before it's saved
+func (ns *NotificationService) ValidateTemplate(t *types.NotificationTemplate) error {
+	if err := t.Validate(); err != nil {
+		return err
+	}
+	if !isTemplateChannel(NotificationChannel(t.Channel)) {
+		return fmt.Errorf("%w: unknown channel %q", types.ErrInvalidNotificationTemplate, t.Channel)
+	}
+	if !isTemplateEvent(AlertType(t.Event)) {
+		return fmt.Errorf("%w: unknown event %q", types.ErrInvalidNotificationTemplate, t.Event)
+	}
+	_, _, err := ns.PreviewTemplate(*t, SampleDomain())
+	return err
+}
+
+// PreviewTemplate renders a template against a sample alert of its event about domain
+func (ns *NotificationService) PreviewTemplate(t types.NotificationTemplate, domain types.Domain) (string, string, error) {
+	alert := ns.sampleAlert(AlertType(t.Event), domain)
+	subject, body, err := RenderTemplate(t, NewTemplateData(alert, ns.templates.dashboardURL))
+	if err != nil {
+		return "", "", err
+	}
+	if subject == "" {
+		subject = alert.Title
+	}
+	return subject, body, nil
+}
+
+// sampleAlert returns an alert of type event about domain, as the monitors would raise it
+func (ns *NotificationService) sampleAlert(event AlertType, domain types.Domain) Alert {
+	switch event {
+	case AlertExpiringSoon:
+		days := int(time.Until(domain.ExpiresAt).Hours() / 24)
+		if days <= 0 {
+			days = 14
+		}
+		return ns.CreateExpirationAlert(domain, days)
+	case AlertExpired:
+		return ns.CreateExpirationAlert(domain, 0)
+	case AlertGracePeriod:
+		return ns.CreateGracePeriodAlert(domain)
+	}
+	return Alert{
+		ID:          fmt.Sprintf("sample_%s", event),
+		Type:        event,
+		Severity:    SeverityMedium,
+		Title:       fmt.Sprintf("Sample %s alert for %s", event, domain.Name),
+		Message:     fmt.Sprintf("This is a sample %s alert for %s.", event, domain.Name),
+		Data:        map[string]interface{}{"domain_id": domain.ID, "domain_name": domain.Name},
+		CreatedAt:   time.Now(),
+		TriggeredBy: "template_preview",
+		Domain:      &domain,
+	}
+}
+
+// applyTemplate renders alert with the stored template for its event on channel, if there is
+// one. It reports whether a stored template was used; a template that fails to render is
+// logged and the alert sent with its default wording.
+func (ns *NotificationService) applyTemplate(channel NotificationChannel, alert Alert) (Alert, bool) {
+	if ns.templateStore == nil {
+		return alert, false
+	}
+	templates, err := ns.templateStore.GetNotificationTemplates()
+	if err != nil {
+		log.Printf("Failed to load notification templates: %v", err)
+		return alert, false
+	}
+	for _, t := range templates {
+		if t.Channel != string(channel) || t.Event != string(alert.Type) {
+			continue
+		}
+		subject, body, err := RenderTemplate(t, NewTemplateData(alert, ns.templates.dashboardURL))
+		if err != nil {
+			log.Printf("Failed to render %s template for %s, using the default wording: %v", channel, alert.Type, err)
+			return alert, false
+		}
+		if subject != "" {
+			alert.Title = subject
+		}
+		alert.Message = body
+		return alert, true
+	}
+	return alert, false
+}
+
+func isTemplateChannel(channel NotificationChannel) bool {
+	for _, c := range TemplateChannels {
+		if c == channel {
+			return true
+		}
+	}
+	return false
+}
+
+func isTemplateEvent(event AlertType) bool {
+	for _, e := range TemplateEvents {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,108 @@
+package notifications
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/rusiqe/domainvault/internal/types"
+)
+
+// Events are alerts for things integrations act on rather than problems, such as finance
+// recording a purchase. They go to every webhook as well as through the notification rules.
+const (
+	EventDomainPurchased      AlertType = "domain.purchased"
+	EventDomainPurchaseFailed AlertType = "domain.purchase_failed"
+)
+
+// EmitEvent delivers an event to every webhook and through the notification rules for the
+// other channels. Rules' webhook channels are skipped so an endpoint gets the event once.
+func (ns *NotificationService) EmitEvent(event Alert) error {
+	if len(ns.webhookTargets()) > 0 {
+		if err := ns.sendWebhookAlert(event); err != nil {
+			log.Printf("Failed to send %s event to webhooks: %v", event.Type, err)
+		}
+	}
+
+	rules := ns.GetRules()
+	for i := range rules {
+		channels := make([]NotificationChannel, 0, len(rules[i].Channels))
+		for _, channel := range rules[i].Channels {
+			if channel != ChannelWebhook {
+				channels = append(channels, channel)
+			}
+		}
+		rules[i].Channels = channels
+	}
+	return ns.SendAlert(event, rules)
+}
+
+// CreatePurchaseEvents returns a domain.purchased event for each domain a purchase bought and
+// a domain.purchase_failed event for each it didn't. A purchase rejected as a whole, or that
+// failed with purchaseErr, fails every requested domain.
+func (ns *NotificationService) CreatePurchaseEvents(request types.DomainPurchaseRequest, response *types.DomainPurchaseResponse, purchaseErr error) []Alert {
+	now := time.Now()
+	var events []Alert
+	failed := func(domain, provider, transactionID, reason string) Alert {
+		return Alert{
+			ID:       fmt.Sprintf("purchase_failed_%s_%d", domain, now.UnixNano()),
+			Type:     EventDomainPurchaseFailed,
+			Severity: SeverityMedium,
+			Title:    fmt.Sprintf("Purchase of %s failed", domain),
+			Message:  fmt.Sprintf("%s could not be purchased: %s", domain, reason),
+			Data: map[string]interface{}{
+				"domain":         domain,
+				"provider":       provider,
+				"provider_id":    request.ProviderID,
+				"transaction_id": transactionID,
+				"error":          reason,
+			},
+			CreatedAt:   now,
+			TriggeredBy: "purchase",
+		}
+	}
+
+	if purchaseErr != nil || response == nil || (!response.Success && len(response.FailedDomains) == 0) {
+		reason := "purchase failed"
+		provider, transactionID := "", ""
+		if purchaseErr != nil {
+			reason = purchaseErr.Error()
+		} else if response != nil {
+			reason = response.Message
+			provider, transactionID = response.Provider, response.TransactionID
+		}
+		for _, item := range request.Domains {
+			events = append(events, failed(item.Domain, provider, transactionID, reason))
+		}
+		return events
+	}
+
+	for _, purchased := range response.PurchasedDomains {
+		events = append(events, Alert{
+			ID:       fmt.Sprintf("purchased_%s_%d", purchased.Domain, now.UnixNano()),
+			Type:     EventDomainPurchased,
+			Severity: SeverityLow,
+			Title:    fmt.Sprintf("Purchased %s", purchased.Domain),
+			Message: fmt.Sprintf("%s was purchased through %s for %.2f %s, registered until %s",
+				purchased.Domain, response.Provider, purchased.Cost, response.Currency, purchased.ExpiresAt.Format("2006-01-02")),
+			Data: map[string]interface{}{
+				"domain":         purchased.Domain,
+				"domain_id":      purchased.DomainID,
+				"provider":       response.Provider,
+				"provider_id":    request.ProviderID,
+				"cost":           purchased.Cost,
+				"currency":       response.Currency,
+				"period":         purchased.Period,
+				"expires_at":     purchased.ExpiresAt,
+				"auto_renew":     request.AutoRenew,
+				"transaction_id": response.TransactionID,
+			},
+			CreatedAt:   now,
+			TriggeredBy: "purchase",
+		})
+	}
+	for _, failure := range response.FailedDomains {
+		events = append(events, failed(failure.Domain, response.Provider, response.TransactionID, failure.Error))
+	}
+	return events
+}
@@ -6,6 +6,7 @@ import (
 	"log"
 	"net/smtp"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/rusiqe/domainvault/internal/types"
@@ -16,7 +17,14 @@ type NotificationService struct {
 	emailConfig   EmailConfig
 	slackConfig   SlackConfig
 	webhookConfig WebhookConfig
+	webhookStore  WebhookEndpointStore // Optional; stored endpoints managed through the admin API
+	templateStore TemplateStore        // Optional; stored templates replacing the default wording
 	templates     *TemplateManager
+
+	rules   []NotificationRule // Rules used by Notify
+	rulesMu sync.RWMutex
+
+	channelsMu sync.RWMutex // Protects the Enabled flags of the channel configs, toggled at runtime
 }
 
 // EmailConfig contains SMTP configuration
@@ -56,6 +64,17 @@ const (
 	AlertSyncFailed     AlertType = "sync_failed"
 	AlertBulkOperation  AlertType = "bulk_operation"
 	AlertSecurity       AlertType = "security"
+
+	AlertStatusTransition AlertType = "status_transition"      // Domain status changed, e.g. active -> expired
+	AlertHTTPTransition   AlertType = "http_status_transition" // Website moved between healthy (2xx) and failing (5xx/unreachable)
+	AlertNameserverChange AlertType = "nameserver_change"      // Apex NS records changed, a possible hijack
+	AlertDomainAvailable  AlertType = "domain_available"       // A watchlist domain can be registered
+	AlertResponseTimeSLA  AlertType = "response_time_sla"      // Average response time went over the domain's SLA
+	AlertMonitorRecovered AlertType = "monitor_recovered"      // An UptimeRobot monitor came back up
+	AlertProviderStale    AlertType = "provider_stale"         // A connected provider hasn't synced successfully for too long
+	AlertRenewalPriceRise AlertType = "renewal_price_increase" // A sync saw a domain's renewal price rise past the alert threshold
+	AlertDanglingDNS      AlertType = "dangling_dns"           // A record points at deprovisioned infrastructure, a possible subdomain takeover
+	AlertGracePeriod      AlertType = "grace_period"           // A domain past its expiry is still held by its registrar and must be renewed now
 )
 
 // AlertSeverity represents alert severity levels
@@ -78,6 +97,7 @@ type Alert struct {
 	Data        map[string]interface{} `json:"data"`
 	CreatedAt   time.Time              `json:"created_at"`
 	TriggeredBy string                 `json:"triggered_by"`
+	Domain      *types.Domain          `json:"-"` // The domain the alert is about, for templates; nil if none
 }
 
 // NotificationChannel represents a notification delivery method
@@ -115,6 +135,31 @@ func NewNotificationService(emailConfig EmailConfig, slackConfig SlackConfig, we
 	}
 }
 
+// SetChannelsEnabled turns the email, Slack and configured webhook channels on or off. Stored
+// webhook endpoints have their own enabled flag and aren't affected.
+func (ns *NotificationService) SetChannelsEnabled(email, slack, webhook bool) {
+	ns.channelsMu.Lock()
+	defer ns.channelsMu.Unlock()
+	ns.emailConfig.Enabled = email
+	ns.slackConfig.Enabled = slack
+	ns.webhookConfig.Enabled = webhook
+}
+
+// channelEnabled reports whether a channel is turned on
+func (ns *NotificationService) channelEnabled(channel NotificationChannel) bool {
+	ns.channelsMu.RLock()
+	defer ns.channelsMu.RUnlock()
+	switch channel {
+	case ChannelEmail:
+		return ns.emailConfig.Enabled
+	case ChannelSlack:
+		return ns.slackConfig.Enabled
+	case ChannelWebhook:
+		return ns.webhookConfig.Enabled
+	}
+	return false
+}
+
 // SendAlert sends an alert through configured channels
 func (ns *NotificationService) SendAlert(alert Alert, rules []NotificationRule) error {
 	for _, rule := range rules {
@@ -131,22 +176,20 @@ func (ns *NotificationService) SendAlert(alert Alert, rules []NotificationRule)
 		for _, channel := range rule.Channels {
 			switch channel {
 			case ChannelEmail:
-				if ns.emailConfig.Enabled {
+				if ns.channelEnabled(ChannelEmail) {
 					if err := ns.sendEmailAlert(alert, rule.Recipients); err != nil {
 						log.Printf("Failed to send email alert: %v", err)
 					}
 				}
 			case ChannelSlack:
-				if ns.slackConfig.Enabled {
+				if ns.channelEnabled(ChannelSlack) {
 					if err := ns.sendSlackAlert(alert); err != nil {
 						log.Printf("Failed to send Slack alert: %v", err)
 					}
 				}
 			case ChannelWebhook:
-				if ns.webhookConfig.Enabled {
-					if err := ns.sendWebhookAlert(alert); err != nil {
-						log.Printf("Failed to send webhook alert: %v", err)
-					}
+				if err := ns.sendWebhookAlert(alert); err != nil {
+					log.Printf("Failed to send webhook alert: %v", err)
 				}
 			}
 		}
@@ -155,6 +198,174 @@ func (ns *NotificationService) SendAlert(alert Alert, rules []NotificationRule)
 	return nil
 }
 
+// Notify sends an alert through the service's stored notification rules
+func (ns *NotificationService) Notify(alert Alert) error {
+	return ns.SendAlert(alert, ns.GetRules())
+}
+
+// GetRules returns the stored notification rules
+func (ns *NotificationService) GetRules() []NotificationRule {
+	ns.rulesMu.RLock()
+	defer ns.rulesMu.RUnlock()
+	rules := make([]NotificationRule, len(ns.rules))
+	copy(rules, ns.rules)
+	return rules
+}
+
+// SaveRule adds a rule, or replaces the stored rule with the same ID
+func (ns *NotificationService) SaveRule(rule NotificationRule) NotificationRule {
+	ns.rulesMu.Lock()
+	defer ns.rulesMu.Unlock()
+
+	now := time.Now()
+	rule.UpdatedAt = now
+	for i, existing := range ns.rules {
+		if existing.ID == rule.ID {
+			rule.CreatedAt = existing.CreatedAt
+			ns.rules[i] = rule
+			return rule
+		}
+	}
+
+	if rule.ID == "" {
+		rule.ID = fmt.Sprintf("rule_%d", now.UnixNano())
+	}
+	rule.CreatedAt = now
+	ns.rules = append(ns.rules, rule)
+	return rule
+}
+
+// DeleteRule removes a stored rule, reporting whether it existed
+func (ns *NotificationService) DeleteRule(id string) bool {
+	ns.rulesMu.Lock()
+	defer ns.rulesMu.Unlock()
+
+	for i, rule := range ns.rules {
+		if rule.ID == id {
+			ns.rules = append(ns.rules[:i], ns.rules[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// DetectTransitions compares a domain's stored state with its new state and returns an alert
+// for each transition rules can subscribe to: any change of Status, and the website moving
+// between healthy (2xx) and failing (5xx or unreachable). Alert data carries old_value and new_value.
+func (ns *NotificationService) DetectTransitions(previous, current types.Domain) []Alert {
+	var alerts []Alert
+
+	if previous.Status != "" && current.Status != "" && previous.Status != current.Status {
+		severity := SeverityMedium
+		switch current.Status {
+		case "expired", types.DomainStatusGracePeriod:
+			severity = SeverityCritical
+		case "transferring", "transferred", "pending_renewal":
+			severity = SeverityHigh
+		case "active":
+			severity = SeverityLow
+		}
+
+		alerts = append(alerts, ns.transitionAlert(current, AlertStatusTransition, severity, "status",
+			previous.Status, current.Status,
+			fmt.Sprintf("Domain %s status changed from %s to %s", current.Name, previous.Status, current.Status)))
+	}
+
+	before, after := httpHealth(previous.HTTPStatus), httpHealth(current.HTTPStatus)
+	if (before == "healthy" && after == "failing") || (before == "failing" && after == "healthy") {
+		severity := SeverityHigh
+		title := fmt.Sprintf("Website %s is failing (HTTP %d)", current.Name, *current.HTTPStatus)
+		if after == "healthy" {
+			severity = SeverityLow
+			title = fmt.Sprintf("Website %s recovered (HTTP %d)", current.Name, *current.HTTPStatus)
+		}
+
+		alerts = append(alerts, ns.transitionAlert(current, AlertHTTPTransition, severity, "http_status",
+			*previous.HTTPStatus, *current.HTTPStatus, title))
+	}
+
+	return alerts
+}
+
+// transitionAlert builds a transition alert with the old and new values in its data
+func (ns *NotificationService) transitionAlert(domain types.Domain, alertType AlertType, severity AlertSeverity, field string, oldValue, newValue interface{}, title string) Alert {
+	return Alert{
+		ID:       fmt.Sprintf("%s_%s_%d", alertType, domain.ID, time.Now().UnixNano()),
+		Type:     alertType,
+		Severity: severity,
+		Title:    title,
+		Message:  title,
+		Data: map[string]interface{}{
+			"domain_id":   domain.ID,
+			"domain_name": domain.Name,
+			"provider":    domain.Provider,
+			"field":       field,
+			"old_value":   oldValue,
+			"new_value":   newValue,
+		},
+		CreatedAt:   time.Now(),
+		TriggeredBy: "transition_monitor",
+		Domain:      &domain,
+	}
+}
+
+// CreateNameserverChangeAlert creates a high-severity alert for a change of nameservers, since
+// an unexpected change can mean the domain has been hijacked
+func (ns *NotificationService) CreateNameserverChangeAlert(domain types.Domain, change types.NameserverChange) Alert {
+	alert := ns.transitionAlert(domain, AlertNameserverChange, SeverityHigh, "nameservers",
+		[]string(change.Previous), []string(change.Current),
+		fmt.Sprintf("Nameservers of %s changed", domain.Name))
+	alert.Message = fmt.Sprintf("Nameservers of %s changed from %s to %s. If this change was not expected, the domain may have been hijacked.",
+		domain.Name, strings.Join(change.Previous, ", "), strings.Join(change.Current, ", "))
+	alert.Data["change_id"] = change.ID
+	return alert
+}
+
+// NotifyNameserverChange logs and sends the alert for a nameserver change found by a DNS refresh
+func (ns *NotificationService) NotifyNameserverChange(domain types.Domain, change types.NameserverChange) {
+	alert := ns.CreateNameserverChangeAlert(domain, change)
+	log.Printf("SECURITY ALERT [%s]: %s", alert.Severity, alert.Message)
+	if err := ns.Notify(alert); err != nil {
+		log.Printf("Failed to send nameserver change alert for %s: %v", domain.Name, err)
+	}
+}
+
+// CreateRenewalPriceAlert creates an alert for a rise in a domain's renewal price seen by a
+// sync. It's high severity when the domain auto-renews, since the new price will be charged
+// without anyone approving it.
+func (ns *NotificationService) CreateRenewalPriceAlert(domain types.Domain, change types.RenewalPriceChange) Alert {
+	severity := SeverityMedium
+	if domain.AutoRenew {
+		severity = SeverityHigh
+	}
+	alert := ns.transitionAlert(domain, AlertRenewalPriceRise, severity, "renewal_price",
+		change.OldPrice, change.NewPrice,
+		fmt.Sprintf("Renewal price of %s rose %.0f%%", domain.Name, change.PercentChange()))
+	alert.Message = fmt.Sprintf("The renewal price of %s at %s rose from %.2f to %.2f (%.1f%%).",
+		domain.Name, change.Provider, change.OldPrice, change.NewPrice, change.PercentChange())
+	if domain.AutoRenew {
+		alert.Message += " The domain auto-renews, so the new price will be charged at its next renewal."
+	}
+	alert.Data["percent_change"] = change.PercentChange()
+	alert.Data["auto_renew"] = domain.AutoRenew
+	alert.Data["change_id"] = change.ID
+	return alert
+}
+
+// httpHealth classifies an HTTP status for transition detection
+func httpHealth(status *int) string {
+	switch {
+	case status == nil:
+		return ""
+	case *status >= 200 && *status < 300:
+		return "healthy"
+	case *status == 0 || *status == 408 || *status >= 500: // 0 and 408 mean unreachable or timed out
+		return "failing"
+	default:
+		return "other"
+	}
+}
+
 // CreateExpirationAlert creates alerts for expiring domains
 func (ns *NotificationService) CreateExpirationAlert(domain types.Domain, daysUntilExpiry int) Alert {
 	severity := SeverityMedium
@@ -171,12 +382,7 @@ func (ns *NotificationService) CreateExpirationAlert(domain types.Domain, daysUn
 		severity = SeverityLow
 	}
 
-	title := fmt.Sprintf("Domain %s expires in %d days", domain.Name, daysUntilExpiry)
-	if daysUntilExpiry <= 0 {
-		title = fmt.Sprintf("Domain %s has expired", domain.Name)
-	}
-
-	message := ns.templates.RenderExpirationAlert(domain, daysUntilExpiry)
+	title, message := ns.templates.RenderExpirationAlert(domain, daysUntilExpiry)
 
 	return Alert{
 		ID:       fmt.Sprintf("exp_%s_%d", domain.ID, time.Now().Unix()),
@@ -195,6 +401,7 @@ func (ns *NotificationService) CreateExpirationAlert(domain types.Domain, daysUn
 		},
 		CreatedAt:   time.Now(),
 		TriggeredBy: "expiration_monitor",
+		Domain:      &domain,
 	}
 }
 
@@ -226,6 +433,90 @@ func (ns *NotificationService) CreateStatusAlert(domain types.Domain, previousSt
 		},
 		CreatedAt:   time.Now(),
 		TriggeredBy: "status_monitor",
+		Domain:      &domain,
+	}
+}
+
+// CreateMonitorAlert creates an alert for an UptimeRobot monitor going down or coming back up.
+// Down alerts use AlertStatusDown, so rules for website downtime receive them.
+func (ns *NotificationService) CreateMonitorAlert(domain types.Domain, down bool, details string, downtime time.Duration) Alert {
+	alert := Alert{
+		ID:       fmt.Sprintf("monitor_%s_%d", domain.ID, time.Now().UnixNano()),
+		Type:     AlertStatusDown,
+		Severity: SeverityHigh,
+		Title:    fmt.Sprintf("Website %s is down", domain.Name),
+		Message:  fmt.Sprintf("UptimeRobot reports %s is down: %s", domain.Name, details),
+		Data: map[string]interface{}{
+			"domain_id":   domain.ID,
+			"domain_name": domain.Name,
+			"monitor_id":  domain.UptimeRobotMonitorID,
+			"details":     details,
+		},
+		CreatedAt:   time.Now(),
+		TriggeredBy: "uptimerobot",
+		Domain:      &domain,
+	}
+	if !down {
+		alert.Type = AlertMonitorRecovered
+		alert.Severity = SeverityLow
+		alert.Title = fmt.Sprintf("Website %s is back up", domain.Name)
+		alert.Message = fmt.Sprintf("UptimeRobot reports %s is back up after %s", domain.Name, downtime)
+		alert.Data["downtime_seconds"] = int(downtime.Seconds())
+	}
+	return alert
+}
+
+// DetectSLABreach returns an alert when a domain's average response time has gone over its
+// SLA since the previous sync. A domain that stays over the SLA alerts only once.
+func (ns *NotificationService) DetectSLABreach(previous, current types.Domain, sla types.ResponseTimeSLA) (Alert, bool) {
+	if !sla.Breached(current) || sla.Breached(previous) {
+		return Alert{}, false
+	}
+
+	threshold := sla.ThresholdFor(current.Name)
+	severity := SeverityMedium
+	if *current.ResponseTime >= 2*threshold {
+		severity = SeverityHigh
+	}
+	alert := ns.transitionAlert(current, AlertResponseTimeSLA, severity, "response_time",
+		previous.ResponseTime, *current.ResponseTime,
+		fmt.Sprintf("%s is responding slower than its SLA", current.Name))
+	alert.Message = fmt.Sprintf("The average response time of %s is %dms, over its SLA of %dms.",
+		current.Name, *current.ResponseTime, threshold)
+	alert.Data["threshold_ms"] = threshold
+	alert.TriggeredBy = "sla_monitor"
+	return alert, true
+}
+
+// CreateWatchlistAlert creates an alert for a watched domain that has become available to
+// register within its price limit
+func (ns *NotificationService) CreateWatchlistAlert(entry types.WatchlistEntry, availability types.DomainAvailability) Alert {
+	price := "an unknown price"
+	if availability.Price != nil {
+		currency := availability.Currency
+		if currency == "" {
+			currency = entry.Currency
+		}
+		price = fmt.Sprintf("%.2f %s", *availability.Price, currency)
+	}
+
+	return Alert{
+		ID:       fmt.Sprintf("watch_%s_%d", entry.ID, time.Now().Unix()),
+		Type:     AlertDomainAvailable,
+		Severity: SeverityHigh,
+		Title:    fmt.Sprintf("Watched domain %s is available", entry.Domain),
+		Message: fmt.Sprintf("%s can be registered for %s (checked via %s). Register it soon; dropped domains are often picked up quickly.",
+			entry.Domain, price, availability.Source),
+		Data: map[string]interface{}{
+			"watchlist_id": entry.ID,
+			"domain_name":  entry.Domain,
+			"price":        availability.Price,
+			"currency":     availability.Currency,
+			"max_price":    entry.MaxPrice,
+			"source":       availability.Source,
+		},
+		CreatedAt:   time.Now(),
+		TriggeredBy: "watchlist_monitor",
 	}
 }
 
@@ -247,6 +538,89 @@ func (ns *NotificationService) CreateSyncFailureAlert(provider string, errorMsg
 	}
 }
 
+// CreateProviderStaleAlert creates an alert for a connected provider that hasn't synced
+// successfully within its stale threshold. lastSuccess is zero if it never has.
+func (ns *NotificationService) CreateProviderStaleAlert(name, provider string, lastSuccess time.Time, age, threshold time.Duration) Alert {
+	since := "since it was connected"
+	data := map[string]interface{}{
+		"provider":          provider,
+		"name":              name,
+		"age_seconds":       int(age.Seconds()),
+		"threshold_seconds": int(threshold.Seconds()),
+	}
+	if !lastSuccess.IsZero() {
+		since = "since " + lastSuccess.Format(time.RFC3339)
+		data["last_successful_sync"] = lastSuccess
+	}
+	return Alert{
+		ID:       fmt.Sprintf("provider_stale_%s_%d", name, time.Now().Unix()),
+		Type:     AlertProviderStale,
+		Severity: SeverityHigh,
+		Title:    fmt.Sprintf("Provider %s has stopped syncing", name),
+		Message: fmt.Sprintf("%s (%s) has not synced successfully for %s (%s). Its credentials may have expired or been rotated.",
+			name, provider, age.Round(time.Minute), since),
+		Data:        data,
+		CreatedAt:   time.Now(),
+		TriggeredBy: "provider_service",
+	}
+}
+
+// CreateDanglingDNSAlert creates an alert for a record pointing at infrastructure that no
+// longer exists. Takeover risks are critical, since anyone can claim the target and serve
+// content on the domain; other dangling records are medium.
+func (ns *NotificationService) CreateDanglingDNSAlert(finding types.DanglingDNSFinding) Alert {
+	severity, title := SeverityMedium, fmt.Sprintf("%s points at infrastructure that no longer exists", finding.Host)
+	if finding.Risk == types.DanglingTakeover {
+		severity, title = SeverityCritical, fmt.Sprintf("%s can be taken over", finding.Host)
+	}
+	return Alert{
+		ID:       fmt.Sprintf("dangling_dns_%s_%d", finding.RecordID, time.Now().UnixNano()),
+		Type:     AlertDanglingDNS,
+		Severity: severity,
+		Title:    title,
+		Message:  fmt.Sprintf("The %s record %s -> %s is dangling: %s. Remove the record or reclaim the target.", finding.Type, finding.Host, finding.Target, finding.Reason),
+		Data: map[string]interface{}{
+			"domain_id":   finding.DomainID,
+			"domain_name": finding.DomainName,
+			"record_id":   finding.RecordID,
+			"host":        finding.Host,
+			"type":        finding.Type,
+			"target":      finding.Target,
+			"risk":        finding.Risk,
+			"service":     finding.Service,
+		},
+		CreatedAt:   time.Now(),
+		TriggeredBy: "dangling_dns_check",
+	}
+}
+
+// CreateGracePeriodAlert creates a critical alert for a domain that has expired but is still
+// held by its registrar. It can still be renewed, but once it's released it can be lost or
+// only recovered at a redemption fee.
+func (ns *NotificationService) CreateGracePeriodAlert(domain types.Domain) Alert {
+	daysPast := int(time.Since(domain.ExpiresAt).Hours() / 24)
+	title := fmt.Sprintf("Domain %s expired %d days ago and is in its grace period", domain.Name, daysPast)
+	return Alert{
+		ID:       fmt.Sprintf("grace_period_%s_%d", domain.ID, time.Now().UnixNano()),
+		Type:     AlertGracePeriod,
+		Severity: SeverityCritical,
+		Title:    title,
+		Message:  fmt.Sprintf("%s expired on %s but %s still lists it as active. Renew it now; once the registrar releases it, it can be registered by anyone or only redeemed at a much higher price.", domain.Name, domain.ExpiresAt.Format("2006-01-02"), domain.Provider),
+		Data: map[string]interface{}{
+			"domain_id":        domain.ID,
+			"domain_name":      domain.Name,
+			"provider":         domain.Provider,
+			"expires_at":       domain.ExpiresAt,
+			"days_past_expiry": daysPast,
+			"auto_renew":       domain.AutoRenew,
+			"renewal_price":    domain.RenewalPrice,
+		},
+		CreatedAt:   time.Now(),
+		TriggeredBy: "sync",
+		Domain:      &domain,
+	}
+}
+
 // matchesRule checks if an alert matches a notification rule
 func (ns *NotificationService) matchesRule(alert Alert, rule NotificationRule) bool {
 	// Check alert type
@@ -273,19 +647,39 @@ func (ns *NotificationService) matchesRule(alert Alert, rule NotificationRule) b
 		return false
 	}
 
-	// Additional condition checks could be added here
+	// Transition rules can be narrowed to specific values, e.g. {"from": "active", "to": "expired"}
+	if from, ok := rule.Conditions["from"]; ok && fmt.Sprint(from) != fmt.Sprint(alert.Data["old_value"]) {
+		return false
+	}
+	if to, ok := rule.Conditions["to"]; ok && fmt.Sprint(to) != fmt.Sprint(alert.Data["new_value"]) {
+		return false
+	}
+
 	return true
 }
 
 // sendEmailAlert sends an alert via email
 func (ns *NotificationService) sendEmailAlert(alert Alert, recipients []string) error {
-	if !ns.emailConfig.Enabled || len(recipients) == 0 {
+	if !ns.channelEnabled(ChannelEmail) || len(recipients) == 0 {
 		return fmt.Errorf("email not configured or no recipients")
 	}
 
+	alert, custom := ns.applyTemplate(ChannelEmail, alert)
 	subject := fmt.Sprintf("[DomainVault %s] %s", strings.ToUpper(string(alert.Severity)), alert.Title)
+	if custom {
+		subject = alert.Title
+	}
 	body := ns.templates.RenderEmailAlert(alert)
 
+	return ns.SendEmail(recipients, subject, body)
+}
+
+// SendEmail sends an HTML email through the configured SMTP server
+func (ns *NotificationService) SendEmail(recipients []string, subject, body string) error {
+	if !ns.channelEnabled(ChannelEmail) || len(recipients) == 0 {
+		return fmt.Errorf("email not configured or no recipients")
+	}
+
 	// Setup authentication
 	auth := smtp.PlainAuth("", ns.emailConfig.Username, ns.emailConfig.Password, ns.emailConfig.SMTPHost)
 
@@ -300,9 +694,10 @@ func (ns *NotificationService) sendEmailAlert(alert Alert, recipients []string)
 
 // sendSlackAlert sends an alert via Slack webhook
 func (ns *NotificationService) sendSlackAlert(alert Alert) error {
-	if !ns.slackConfig.Enabled {
+	if !ns.channelEnabled(ChannelSlack) {
 		return fmt.Errorf("Slack not configured")
 	}
+	alert, _ = ns.applyTemplate(ChannelSlack, alert)
 
 	// Create Slack message payload
 	payload := map[string]interface{}{
@@ -344,17 +739,20 @@ func (ns *NotificationService) sendSlackAlert(alert Alert) error {
 	return nil
 }
 
-// sendWebhookAlert sends an alert via custom webhook
+// sendWebhookAlert sends an alert via custom webhook. Each delivery is signed with its
+// target's secret in the WebhookSignatureHeader.
 func (ns *NotificationService) sendWebhookAlert(alert Alert) error {
-	if !ns.webhookConfig.Enabled {
+	targets := ns.webhookTargets()
+	if len(targets) == 0 {
 		return fmt.Errorf("webhooks not configured")
 	}
+	alert, _ = ns.applyTemplate(ChannelWebhook, alert)
 
 	// Create webhook payload
+	now := time.Now()
 	payload := map[string]interface{}{
-		"alert":      alert,
-		"timestamp":  time.Now().Unix(),
-		"signature":  ns.generateWebhookSignature(alert),
+		"alert":     alert,
+		"timestamp": now.Unix(),
 	}
 
 	jsonPayload, err := json.Marshal(payload)
@@ -362,9 +760,10 @@ func (ns *NotificationService) sendWebhookAlert(alert Alert) error {
 		return fmt.Errorf("failed to marshal webhook payload: %w", err)
 	}
 
-	// Send to each configured webhook URL (implementation would use HTTP client)
-	for _, url := range ns.webhookConfig.URLs {
-		log.Printf("Would send to webhook %s: %s", url, string(jsonPayload))
+	// Send to each webhook target (implementation would use HTTP client)
+	for _, target := range targets {
+		signature := SignWebhookPayload(target.secret, now, jsonPayload)
+		log.Printf("Would send to webhook %s (%s: %s): %s", target.url, WebhookSignatureHeader, signature, string(jsonPayload))
 	}
 
 	return nil
@@ -385,9 +784,3 @@ func (ns *NotificationService) getSeverityColor(severity AlertSeverity) string {
 		return "#cccccc"
 	}
 }
-
-// generateWebhookSignature generates HMAC signature for webhook security
-func (ns *NotificationService) generateWebhookSignature(alert Alert) string {
-	// Implementation would use HMAC-SHA256 with webhook secret
-	return "webhook_signature_placeholder"
-}
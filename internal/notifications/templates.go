@@ -2,6 +2,7 @@ package notifications
 
 import (
 	"fmt"
+	"log"
 	"strings"
 	"time"
 
@@ -9,49 +10,34 @@ import (
 )
 
 // TemplateManager handles notification templates
-type TemplateManager struct{}
+type TemplateManager struct {
+	dashboardURL string // Linked from templates as DashboardURL
+}
 
 // NewTemplateManager creates a new template manager
 func NewTemplateManager() *TemplateManager {
 	return &TemplateManager{}
 }
 
-// RenderExpirationAlert renders expiration alert message
-func (tm *TemplateManager) RenderExpirationAlert(domain types.Domain, daysUntilExpiry int) string {
+// RenderExpirationAlert renders the default title and message of an expiration alert
+func (tm *TemplateManager) RenderExpirationAlert(domain types.Domain, daysUntilExpiry int) (string, string) {
+	event := AlertExpiringSoon
 	if daysUntilExpiry <= 0 {
-		return fmt.Sprintf(`Domain %s has expired on %s. 
-Please renew immediately to avoid losing the domain.
-Provider: %s
-Renewal Price: $%.2f
-Auto-renew: %v`,
-			domain.Name,
-			domain.ExpiresAt.Format("January 2, 2006"),
-			domain.Provider,
-			getPrice(domain.RenewalPrice),
-			domain.AutoRenew)
+		event = AlertExpired
 	}
+	data := NewTemplateData(Alert{
+		Type:   event,
+		Data:   map[string]interface{}{"days_until_expiry": daysUntilExpiry},
+		Domain: &domain,
+	}, tm.dashboardURL)
 
-	urgency := "soon"
-	if daysUntilExpiry <= 7 {
-		urgency = "very soon"
-	} else if daysUntilExpiry <= 1 {
-		urgency = "tomorrow"
+	title, message, err := RenderTemplate(DefaultTemplate(ChannelEmail, event), data)
+	if err != nil {
+		// The defaults are fixed, so this only happens if one is broken
+		log.Printf("Failed to render default %s template: %v", event, err)
+		return fmt.Sprintf("Domain %s expires in %d days", domain.Name, daysUntilExpiry), ""
 	}
-
-	return fmt.Sprintf(`Domain %s expires %s (%d days).
-Expiration Date: %s
-Provider: %s
-Renewal Price: $%.2f
-Auto-renew: %v
-
-Please ensure renewal is processed in time.`,
-		domain.Name,
-		urgency,
-		daysUntilExpiry,
-		domain.ExpiresAt.Format("January 2, 2006"),
-		domain.Provider,
-		getPrice(domain.RenewalPrice),
-		domain.AutoRenew)
+	return title, message
 }
 
 // RenderStatusAlert renders status change alert message
@@ -0,0 +1,125 @@
+package notifications
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rusiqe/domainvault/internal/types"
+)
+
+// WebhookSignatureHeader carries the signature of a webhook delivery as "t=<unix>,v1=<hex>",
+// where v1 is the HMAC-SHA256 of "<unix>.<body>" keyed with the endpoint's signing secret.
+//
+// Deliveries are signed with the current secret only. Receivers should accept a signature made
+// with either the current or the previous secret, so a rotation doesn't drop deliveries while
+// the new secret is being rolled out; the previous secret stops being valid once its rotation
+// window closes.
+const WebhookSignatureHeader = "X-DomainVault-Signature"
+
+// DefaultWebhookTolerance is how old a signed timestamp VerifyWebhookSignature accepts
+const DefaultWebhookTolerance = 5 * time.Minute
+
+// ErrInvalidWebhookSignature is returned when no accepted secret produced a signature
+var ErrInvalidWebhookSignature = errors.New("invalid webhook signature")
+
+// WebhookEndpointStore supplies the webhook endpoints alerts are delivered to
+type WebhookEndpointStore interface {
+	GetWebhookEndpoints() ([]types.WebhookEndpoint, error)
+}
+
+// SetWebhookStore makes the service deliver webhook alerts to the stored endpoints as well as
+// the configured URLs
+func (ns *NotificationService) SetWebhookStore(store WebhookEndpointStore) {
+	ns.webhookStore = store
+}
+
+// SignWebhookPayload returns the WebhookSignatureHeader value for body sent at timestamp
+func SignWebhookPayload(secret string, timestamp time.Time, body []byte) string {
+	unix := timestamp.Unix()
+	return fmt.Sprintf("t=%d,v1=%s", unix, webhookMAC(secret, unix, body))
+}
+
+// VerifyWebhookSignature checks a WebhookSignatureHeader value against each of secrets, which
+// should be the endpoint's current and previous secret during a rotation. Signatures older
+// than tolerance are rejected to limit replays.
+func VerifyWebhookSignature(header string, body []byte, secrets []string, tolerance time.Duration, now time.Time) error {
+	var (
+		unix       int64
+		signatures []string
+	)
+	for _, part := range strings.Split(header, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+		switch key {
+		case "t":
+			parsed, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return fmt.Errorf("%w: bad timestamp", ErrInvalidWebhookSignature)
+			}
+			unix = parsed
+		case "v1":
+			signatures = append(signatures, value)
+		}
+	}
+	if unix == 0 || len(signatures) == 0 {
+		return fmt.Errorf("%w: missing timestamp or signature", ErrInvalidWebhookSignature)
+	}
+	if age := now.Sub(time.Unix(unix, 0)); age > tolerance || age < -tolerance {
+		return fmt.Errorf("%w: timestamp outside tolerance", ErrInvalidWebhookSignature)
+	}
+
+	for _, secret := range secrets {
+		if secret == "" {
+			continue
+		}
+		expected := webhookMAC(secret, unix, body)
+		for _, signature := range signatures {
+			if hmac.Equal([]byte(signature), []byte(expected)) {
+				return nil
+			}
+		}
+	}
+	return ErrInvalidWebhookSignature
+}
+
+func webhookMAC(secret string, unix int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(mac, "%d.", unix)
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// webhookTarget is one URL a webhook alert goes to, with the secret that signs it
+type webhookTarget struct {
+	url    string
+	secret string
+}
+
+// webhookTargets returns the configured URLs and the enabled stored endpoints
+func (ns *NotificationService) webhookTargets() []webhookTarget {
+	var targets []webhookTarget
+	if ns.channelEnabled(ChannelWebhook) {
+		for _, url := range ns.webhookConfig.URLs {
+			targets = append(targets, webhookTarget{url: url, secret: ns.webhookConfig.Secret})
+		}
+	}
+
+	if ns.webhookStore != nil {
+		endpoints, err := ns.webhookStore.GetWebhookEndpoints()
+		if err != nil {
+			log.Printf("Failed to load webhook endpoints: %v", err)
+		}
+		for _, endpoint := range endpoints {
+			if endpoint.Enabled {
+				targets = append(targets, webhookTarget{url: endpoint.URL, secret: endpoint.CurrentSecret()})
+			}
+		}
+	}
+	return targets
+}
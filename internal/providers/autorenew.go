@@ -0,0 +1,61 @@
+package providers
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/rusiqe/domainvault/internal/types"
+)
+
+// AutoRenewReport compares our auto-renew setting for a domain with the registrar's
+type AutoRenewReport struct {
+	DomainID        string    `json:"domain_id"`
+	Domain          string    `json:"domain"`
+	Provider        string    `json:"provider"`
+	LocalAutoRenew  bool      `json:"local_auto_renew"`     // Desired value stored in DomainVault
+	RemoteAutoRenew bool      `json:"registrar_auto_renew"` // Value the registrar reported (after any push)
+	Drift           bool      `json:"drift"`                // Values differed when checked
+	AtRisk          bool      `json:"at_risk"`              // We expect auto-renew but the registrar has it off
+	Pushed          bool      `json:"pushed"`               // Our value was written to the registrar
+	CheckedAt       time.Time `json:"checked_at"`
+}
+
+// ReconcileAutoRenew reads the registrar's auto-renew flag for a domain and reports drift
+// against the stored value. When push is true and the values differ, the stored value is
+// written to the registrar. Providers without auto-renew support return ErrAutoRenewUnsupported.
+func (ps *ProviderService) ReconcileAutoRenew(domain types.Domain, push bool) (*AutoRenewReport, error) {
+	client, ok := ps.GetClientByProviderName(domain.Provider)
+	if !ok {
+		return nil, fmt.Errorf("no connected client for provider %s", domain.Provider)
+	}
+	manager, ok := client.(AutoRenewManager)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", types.ErrAutoRenewUnsupported, domain.Provider)
+	}
+
+	remote, err := manager.GetAutoRenew(domain.Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read auto-renew for %s: %w", domain.Name, err)
+	}
+
+	report := &AutoRenewReport{
+		DomainID:        domain.ID,
+		Domain:          domain.Name,
+		Provider:        domain.Provider,
+		LocalAutoRenew:  domain.AutoRenew,
+		RemoteAutoRenew: remote,
+		Drift:           remote != domain.AutoRenew,
+		AtRisk:          domain.AutoRenew && !remote,
+		CheckedAt:       time.Now(),
+	}
+
+	if push && report.Drift {
+		if err := manager.SetAutoRenew(domain.Name, domain.AutoRenew); err != nil {
+			return report, fmt.Errorf("failed to set auto-renew for %s: %w", domain.Name, err)
+		}
+		report.Pushed = true
+		report.RemoteAutoRenew = domain.AutoRenew
+	}
+
+	return report, nil
+}
@@ -0,0 +1,253 @@
+package providers
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rusiqe/domainvault/internal/types"
+)
+
+// ConnectionStatusDegraded marks a connected provider whose circuit breaker is open after
+// repeated failures; calls to it are skipped until a probe succeeds
+const ConnectionStatusDegraded = "degraded"
+
+// Defaults for provider timeouts and circuit breakers
+const (
+	DefaultProviderTimeout  = 30 * time.Second // Per HTTP request to a provider API
+	DefaultCallTimeout      = 10 * time.Minute // Per guarded call, e.g. a whole paginated FetchDomains
+	DefaultBreakerThreshold = 5                // Consecutive failures that open the breaker
+	DefaultBreakerCooldown  = 5 * time.Minute  // How long an open breaker rejects calls before a probe
+)
+
+// Circuit breaker states
+const (
+	BreakerClosed   = "closed"    // Calls go through
+	BreakerOpen     = "open"      // Calls fail fast until the cooldown ends
+	BreakerHalfOpen = "half_open" // The cooldown ended; one probe call is in flight
+)
+
+// BreakerConfig configures the circuit breakers guarding provider calls. Zero values use the
+// defaults.
+type BreakerConfig struct {
+	Threshold   int           // Consecutive failures that open the breaker
+	Cooldown    time.Duration // How long an open breaker rejects calls before letting a probe through
+	CallTimeout time.Duration // A call running longer than this is abandoned and counts as a failure
+}
+
+// withDefaults fills zero fields with the defaults
+func (c BreakerConfig) withDefaults() BreakerConfig {
+	if c.Threshold <= 0 {
+		c.Threshold = DefaultBreakerThreshold
+	}
+	if c.Cooldown <= 0 {
+		c.Cooldown = DefaultBreakerCooldown
+	}
+	if c.CallTimeout <= 0 {
+		c.CallTimeout = DefaultCallTimeout
+	}
+	return c
+}
+
+// BreakerStatus is a circuit breaker's state as reported in provider status
+type BreakerStatus struct {
+	State               string     `json:"state"` // closed, open or half_open
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+	OpenedAt            *time.Time `json:"opened_at,omitempty"`
+	RetryAt             *time.Time `json:"retry_at,omitempty"` // When an open breaker lets the next probe through
+	LastError           string     `json:"last_error,omitempty"`
+}
+
+// CircuitBreaker stops calling a provider after repeated failures so one flaky registrar
+// can't stall every sync. It opens after Threshold consecutive failures and rejects calls
+// with ErrProviderCircuitOpen for the cooldown. The first call after the cooldown is a probe:
+// success closes the breaker, failure opens it for another cooldown.
+type CircuitBreaker struct {
+	config   BreakerConfig
+	state    string
+	failures int
+	openedAt time.Time
+	lastErr  string
+	now      func() time.Time
+	mu       sync.Mutex
+}
+
+// NewCircuitBreaker creates a closed circuit breaker
+func NewCircuitBreaker(config BreakerConfig) *CircuitBreaker {
+	return &CircuitBreaker{config: config.withDefaults(), state: BreakerClosed, now: time.Now}
+}
+
+// Call runs fn unless the breaker is open and records the outcome. A call that outlasts the
+// call timeout is abandoned, left to finish in the background, and counts as a failure.
+func (b *CircuitBreaker) Call(fn func() error) error {
+	if err := b.allow(); err != nil {
+		return err
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- fn() }()
+
+	var err error
+	timer := time.NewTimer(b.config.CallTimeout)
+	defer timer.Stop()
+	select {
+	case err = <-done:
+	case <-timer.C:
+		err = fmt.Errorf("%w: no response after %s", types.ErrProviderTimeout, b.config.CallTimeout)
+	}
+	b.record(err)
+	return err
+}
+
+// allow reports whether a call may go ahead, moving an open breaker whose cooldown has ended
+// to half-open so exactly one probe goes through
+func (b *CircuitBreaker) allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case BreakerOpen:
+		retryAt := b.openedAt.Add(b.config.Cooldown)
+		if b.now().Before(retryAt) {
+			return fmt.Errorf("%w: retrying after %s (last error: %s)", types.ErrProviderCircuitOpen, retryAt.Format(time.RFC3339), b.lastErr)
+		}
+		b.state = BreakerHalfOpen
+	case BreakerHalfOpen:
+		return fmt.Errorf("%w: a probe is already in progress", types.ErrProviderCircuitOpen)
+	}
+	return nil
+}
+
+// record counts a call's outcome, opening or closing the breaker as needed. A call refused
+// for maintenance mode never reached the provider, so it counts as neither.
+func (b *CircuitBreaker) record(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if errors.Is(err, types.ErrMaintenanceMode) {
+		if b.state == BreakerHalfOpen {
+			b.state = BreakerOpen // Nothing was probed; the next call probes instead
+		}
+		return
+	}
+	if err == nil {
+		b.state = BreakerClosed
+		b.failures = 0
+		b.lastErr = ""
+		return
+	}
+
+	b.failures++
+	b.lastErr = err.Error()
+	if b.state == BreakerHalfOpen || b.failures >= b.config.Threshold {
+		b.state = BreakerOpen
+		b.openedAt = b.now()
+	}
+}
+
+// Reset closes the breaker, e.g. after a successful connection test
+func (b *CircuitBreaker) Reset() {
+	b.record(nil)
+}
+
+// IsOpen reports whether calls are currently being rejected
+func (b *CircuitBreaker) IsOpen() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state != BreakerClosed
+}
+
+// Status returns the breaker's current state
+func (b *CircuitBreaker) Status() BreakerStatus {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	status := BreakerStatus{State: b.state, ConsecutiveFailures: b.failures, LastError: b.lastErr}
+	if b.state != BreakerClosed {
+		openedAt := b.openedAt
+		retryAt := openedAt.Add(b.config.Cooldown)
+		status.OpenedAt = &openedAt
+		status.RetryAt = &retryAt
+	}
+	return status
+}
+
+// httpTimeout returns the request timeout from a provider's optional "timeout" credential,
+// given as a duration such as "45s" or a number of seconds, or DefaultProviderTimeout
+func httpTimeout(creds ProviderCredentials) time.Duration {
+	switch value := creds["timeout"].(type) {
+	case string:
+		value = strings.TrimSpace(value)
+		if d, err := time.ParseDuration(value); err == nil && d > 0 {
+			return d
+		}
+		if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+			return time.Duration(seconds) * time.Second
+		}
+	case float64:
+		if value > 0 {
+			return time.Duration(value * float64(time.Second))
+		}
+	case int:
+		if value > 0 {
+			return time.Duration(value) * time.Second
+		}
+	case time.Duration:
+		if value > 0 {
+			return value
+		}
+	}
+	return DefaultProviderTimeout
+}
+
+// newHTTPClient creates the HTTP client a provider talks to its API with, using the
+// provider's configured request timeout
+func newHTTPClient(creds ProviderCredentials) *http.Client {
+	return &http.Client{Timeout: httpTimeout(creds)}
+}
+
+// SetBreakerConfig sets how the circuit breakers of connected providers behave. Breakers
+// already created keep their settings.
+func (ps *ProviderService) SetBreakerConfig(config BreakerConfig) {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	ps.breakerConfig = config
+}
+
+// breakerLocked returns a connected provider's circuit breaker, creating it on first use.
+// The caller holds ps.mu for writing.
+func (ps *ProviderService) breakerLocked(provider *ConnectedProvider) *CircuitBreaker {
+	if provider.Breaker == nil {
+		provider.Breaker = NewCircuitBreaker(ps.breakerConfig)
+	}
+	return provider.Breaker
+}
+
+// updateBreakerStatusLocked moves a provider into or out of the degraded status to match its
+// circuit breaker. The caller holds ps.mu for writing.
+func (ps *ProviderService) updateBreakerStatusLocked(provider *ConnectedProvider) {
+	open := provider.Breaker != nil && provider.Breaker.IsOpen()
+	switch {
+	case open && provider.ConnectionStatus != ConnectionStatusDegraded:
+		provider.ConnectionStatus = ConnectionStatusDegraded
+		status := provider.Breaker.Status()
+		log.Printf("Provider %s (%s) is degraded after %d consecutive failures; calls are paused until %s",
+			provider.Name, provider.Provider, status.ConsecutiveFailures, status.RetryAt.Format(time.RFC3339))
+	case !open && provider.ConnectionStatus == ConnectionStatusDegraded:
+		provider.ConnectionStatus = ConnectionStatusConnected
+		log.Printf("Provider %s (%s) recovered, circuit breaker closed", provider.Name, provider.Provider)
+	}
+}
+
+// BreakerStatus returns the state of the provider's circuit breaker, closed if it has none yet
+func (p *ConnectedProvider) BreakerStatus() BreakerStatus {
+	if p.Breaker == nil {
+		return BreakerStatus{State: BreakerClosed}
+	}
+	return p.Breaker.Status()
+}
@@ -0,0 +1,133 @@
+package providers
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rusiqe/domainvault/internal/types"
+)
+
+// Capabilities describes the DNS limits a provider enforces on its zones
+type Capabilities struct {
+	Provider             string   `json:"provider"`
+	MinTTL               int      `json:"min_ttl"`                // Lowest TTL in seconds the provider accepts
+	MaxTTL               int      `json:"max_ttl"`                // Highest TTL in seconds the provider accepts
+	DefaultTTL           int      `json:"default_ttl"`            // TTL the provider applies when none is given
+	SupportedRecordTypes []string `json:"supported_record_types"` // Record types the provider can host
+	MaxRecordsPerZone    int      `json:"max_records_per_zone"`   // 0 means no documented limit
+	SupportsProxying     bool     `json:"supports_proxying"`      // Whether records can be proxied (e.g. Cloudflare orange cloud)
+}
+
+// providerCapabilities holds the known limits for each supported provider
+var providerCapabilities = map[string]Capabilities{
+	"godaddy": {
+		Provider:             "godaddy",
+		MinTTL:               600,
+		MaxTTL:               604800,
+		DefaultTTL:           3600,
+		SupportedRecordTypes: []string{"A", "AAAA", "CNAME", "MX", "TXT", "NS", "SRV", "CAA"},
+		MaxRecordsPerZone:    500,
+	},
+	"namecheap": {
+		Provider:             "namecheap",
+		MinTTL:               60,
+		MaxTTL:               86400,
+		DefaultTTL:           1800,
+		SupportedRecordTypes: []string{"A", "AAAA", "CNAME", "MX", "TXT", "NS", "SRV", "CAA", "ALIAS"},
+		MaxRecordsPerZone:    150,
+	},
+	"hostinger": {
+		Provider:             "hostinger",
+		MinTTL:               300,
+		MaxTTL:               86400,
+		DefaultTTL:           14400,
+		SupportedRecordTypes: []string{"A", "AAAA", "CNAME", "MX", "TXT", "NS", "SRV", "CAA"},
+		MaxRecordsPerZone:    1000,
+	},
+	"ovh": {
+		Provider:             "ovh",
+		MinTTL:               60,
+		MaxTTL:               86400,
+		DefaultTTL:           3600,
+		SupportedRecordTypes: []string{"A", "AAAA", "CNAME", "MX", "TXT", "NS", "SRV", "CAA", "TLSA"},
+	},
+	"dnsimple": {
+		Provider:             "dnsimple",
+		MinTTL:               60,
+		MaxTTL:               86400,
+		DefaultTTL:           3600,
+		SupportedRecordTypes: []string{"A", "AAAA", "CNAME", "MX", "TXT", "NS", "SRV", "CAA", "TLSA", "ALIAS"},
+	},
+	"dynadot": {
+		Provider:             "dynadot",
+		MinTTL:               60,
+		MaxTTL:               86400,
+		DefaultTTL:           3600,
+		SupportedRecordTypes: []string{"A", "AAAA", "CNAME", "MX", "TXT", "SRV", "CAA"},
+		MaxRecordsPerZone:    100,
+	},
+	"hover": {
+		Provider:             "hover",
+		MinTTL:               300,
+		MaxTTL:               86400,
+		DefaultTTL:           900,
+		SupportedRecordTypes: []string{"A", "AAAA", "CNAME", "MX", "TXT", "SRV"},
+	},
+	"cloudflare": {
+		Provider:             "cloudflare",
+		MinTTL:               60,
+		MaxTTL:               86400,
+		DefaultTTL:           300,
+		SupportedRecordTypes: []string{"A", "AAAA", "CNAME", "MX", "TXT", "NS", "SRV", "CAA", "TLSA"},
+		MaxRecordsPerZone:    3500,
+		SupportsProxying:     true,
+	},
+	"mock": {
+		Provider:             "mock",
+		MinTTL:               60,
+		MaxTTL:               604800,
+		DefaultTTL:           3600,
+		SupportedRecordTypes: []string{"A", "AAAA", "CNAME", "MX", "TXT", "NS", "SRV", "CAA", "TLSA", "ALIAS"},
+	},
+}
+
+// GetCapabilities returns the DNS capabilities for a provider
+func GetCapabilities(provider string) (Capabilities, bool) {
+	caps, ok := providerCapabilities[strings.ToLower(provider)]
+	return caps, ok
+}
+
+// SupportsRecordType reports whether the provider can host records of the given type
+func (c Capabilities) SupportsRecordType(recordType string) bool {
+	for _, t := range c.SupportedRecordTypes {
+		if strings.EqualFold(t, recordType) {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateRecord checks a record against the provider's TTL range and supported types
+func (c Capabilities) ValidateRecord(record types.DNSRecord) error {
+	if !c.SupportsRecordType(record.Type) {
+		if strings.EqualFold(record.Type, "ALIAS") {
+			return fmt.Errorf("%w: %s does not support ALIAS records; point the name at A or AAAA records instead, or host the zone with a provider that supports ALIAS", types.ErrUnsupportedRecordType, c.Provider)
+		}
+		return fmt.Errorf("%w: %s does not support %s records", types.ErrUnsupportedRecordType, c.Provider, record.Type)
+	}
+	if c.MinTTL > 0 && record.TTL < c.MinTTL {
+		return fmt.Errorf("TTL %d is below the %s minimum of %d seconds", record.TTL, c.Provider, c.MinTTL)
+	}
+	if c.MaxTTL > 0 && record.TTL > c.MaxTTL {
+		return fmt.Errorf("TTL %d exceeds the %s maximum of %d seconds", record.TTL, c.Provider, c.MaxTTL)
+	}
+	return nil
+}
+
+// ValidateRecordCount checks that a zone of the given size fits within the provider's limit
+func (c Capabilities) ValidateRecordCount(count int) error {
+	if c.MaxRecordsPerZone > 0 && count > c.MaxRecordsPerZone {
+		return fmt.Errorf("%d records exceeds the %s limit of %d records per zone", count, c.Provider, c.MaxRecordsPerZone)
+	}
+	return nil
+}
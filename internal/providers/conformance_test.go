@@ -0,0 +1,423 @@
+package providers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/rusiqe/domainvault/internal/types"
+)
+
+// conformanceCase runs one HTTP provider client through the shared RegistrarClient checks
+// against responses recorded from its API
+type conformanceCase struct {
+	provider  string
+	newClient func(baseURL string, client *http.Client) RegistrarClient
+	route     func(r *http.Request) string // Fixture key for a request; the URL path when nil
+	fixtures  map[string]string            // Recorded response bodies by fixture key; anything else is a 404
+
+	domains    []conformanceDomain // What FetchDomains should map the fixtures to
+	dnsDomain  string
+	records    []conformanceRecord // What FetchDNSRecords(dnsDomain) should map the fixtures to
+	missingErr bool                // FetchDNSRecords fails for a domain without a zone rather than returning none
+
+	// Optional interfaces the client implements
+	autoRenew, contacts, status, availability bool
+}
+
+// conformanceDomain is the part of a types.Domain a provider maps from its API
+type conformanceDomain struct {
+	Name, Provider, Status  string
+	AutoRenew               bool
+	ExpiresAt, RegisteredAt string // YYYY-MM-DD, empty when not reported
+}
+
+// conformanceRecord is the part of a types.DNSRecord a provider maps from its API
+type conformanceRecord struct {
+	Type, Name, Value      string
+	TTL                    int
+	Priority, Weight, Port int // 0 when not set
+}
+
+var conformanceCases = []conformanceCase{
+	{
+		provider: "godaddy",
+		newClient: func(baseURL string, client *http.Client) RegistrarClient {
+			return &GoDaddyClient{apiKey: "key", apiSecret: "secret", baseURL: baseURL, client: client}
+		},
+		fixtures: map[string]string{
+			"/domains": `[
+				{"domain":"example.com","domainId":1001,"expires":"2027-03-01T00:00:00Z","createdAt":"2019-03-01T12:00:00Z","renewable":true,"status":"ACTIVE"},
+				{"domain":"example.org","domainId":1002,"expires":"2026-11-15T00:00:00Z","createdAt":"2021-11-15T08:30:00Z","renewable":true,"status":"ACTIVE"}]`,
+			"/domains/example.com/records": `[
+				{"type":"A","name":"@","data":"192.0.2.10","ttl":600},
+				{"type":"MX","name":"@","data":"mail.example.com","ttl":3600,"priority":10},
+				{"type":"SRV","name":"_sip._tcp","data":"sip.example.com","ttl":3600,"priority":10,"weight":5,"port":5060}]`,
+		},
+		domains: []conformanceDomain{
+			{Name: "example.com", Provider: "godaddy", ExpiresAt: "2027-03-01", RegisteredAt: "2019-03-01"},
+			{Name: "example.org", Provider: "godaddy", ExpiresAt: "2026-11-15", RegisteredAt: "2021-11-15"},
+		},
+		dnsDomain: "example.com",
+		records: []conformanceRecord{
+			{Type: "A", Name: "@", Value: "192.0.2.10", TTL: 600},
+			{Type: "MX", Name: "@", Value: "mail.example.com", TTL: 3600, Priority: 10},
+			{Type: "SRV", Name: "_sip._tcp", Value: "sip.example.com", TTL: 3600, Priority: 10, Weight: 5, Port: 5060},
+		},
+		autoRenew: true, contacts: true, status: true, availability: true,
+	},
+	{
+		provider: "namecheap",
+		newClient: func(baseURL string, client *http.Client) RegistrarClient {
+			return &NamecheapClient{apiKey: "key", username: "owner", baseURL: baseURL + "/xml.response", client: client}
+		},
+		route: func(r *http.Request) string {
+			query := r.URL.Query()
+			if sld := query.Get("SLD"); sld != "" {
+				return query.Get("Command") + ":" + sld
+			}
+			return query.Get("Command")
+		},
+		fixtures: map[string]string{
+			"namecheap.domains.getList": `<?xml version="1.0" encoding="utf-8"?>
+<ApiResponse Status="OK" xmlns="http://api.namecheap.com/xml.response">
+  <Errors />
+  <CommandResponse Type="namecheap.domains.getList">
+    <DomainGetListResult>
+      <Domain ID="101" Name="example.com" User="owner" Created="03/01/2019" Expires="03/01/2027" IsExpired="false" AutoRenew="true" />
+      <Domain ID="102" Name="example.co.uk" User="owner" Created="11/15/2021" Expires="11/15/2026" IsExpired="false" AutoRenew="false" />
+    </DomainGetListResult>
+  </CommandResponse>
+</ApiResponse>`,
+			"namecheap.domains.dns.getHosts:example": `<?xml version="1.0" encoding="utf-8"?>
+<ApiResponse Status="OK" xmlns="http://api.namecheap.com/xml.response">
+  <Errors />
+  <CommandResponse Type="namecheap.domains.dns.getHosts">
+    <DomainDNSGetHostsResult Domain="example.com" IsUsingOurDNS="true">
+      <host HostId="1" Name="@" Type="A" Address="192.0.2.10" MXPref="10" TTL="1800" />
+      <host HostId="2" Name="www" Type="CNAME" Address="example.com." MXPref="10" TTL="1800" />
+      <host HostId="3" Name="@" Type="MX" Address="mail.example.com." MXPref="20" TTL="1800" />
+    </DomainDNSGetHostsResult>
+  </CommandResponse>
+</ApiResponse>`,
+			"namecheap.domains.dns.getHosts:missing": `<?xml version="1.0" encoding="utf-8"?>
+<ApiResponse Status="ERROR" xmlns="http://api.namecheap.com/xml.response">
+  <Errors>
+    <Error Number="2019166">Domain not found</Error>
+  </Errors>
+  <CommandResponse />
+</ApiResponse>`,
+		},
+		domains: []conformanceDomain{
+			{Name: "example.com", Provider: "namecheap", ExpiresAt: "2027-03-01", RegisteredAt: "2019-03-01"},
+			{Name: "example.co.uk", Provider: "namecheap", ExpiresAt: "2026-11-15", RegisteredAt: "2021-11-15"},
+		},
+		dnsDomain: "example.com",
+		records: []conformanceRecord{
+			{Type: "A", Name: "@", Value: "192.0.2.10", TTL: 1800},
+			{Type: "CNAME", Name: "www", Value: "example.com.", TTL: 1800},
+			{Type: "MX", Name: "@", Value: "mail.example.com.", TTL: 1800, Priority: 20},
+		},
+		missingErr: true,
+		status:     true,
+	},
+	{
+		provider: "hostinger",
+		newClient: func(baseURL string, client *http.Client) RegistrarClient {
+			return &HostingerClient{apiKey: "key", baseURL: baseURL, client: client}
+		},
+		fixtures: map[string]string{
+			"/domains/v1/portfolio": `[
+				{"id":1,"domain":"example.com","type":"domain","status":"active","created_at":"2019-03-01T12:00:00Z","expires_at":"2027-03-01T00:00:00Z"},
+				{"id":2,"domain":"example.net","type":"domain","status":"pending_setup","created_at":"2024-05-01T00:00:00Z","expires_at":null},
+				{"id":3,"domain":null,"type":"free_domain","status":"requested","created_at":"2024-05-01T00:00:00Z","expires_at":null}]`,
+			"/domains/v1/dns/example.com": `[
+				{"id":11,"type":"A","name":"@","content":"192.0.2.10","ttl":14400},
+				{"id":12,"type":"MX","name":"@","content":"mx1.hostinger.com","ttl":14400,"priority":5}]`,
+		},
+		domains: []conformanceDomain{
+			{Name: "example.com", Provider: "hostinger", Status: "active", ExpiresAt: "2027-03-01", RegisteredAt: "2019-03-01"},
+			{Name: "example.net", Provider: "hostinger", Status: "pending", RegisteredAt: "2024-05-01"},
+		},
+		dnsDomain: "example.com",
+		records: []conformanceRecord{
+			{Type: "A", Name: "@", Value: "192.0.2.10", TTL: 14400},
+			{Type: "MX", Name: "@", Value: "mx1.hostinger.com", TTL: 14400, Priority: 5},
+		},
+	},
+	{
+		provider: "ovh",
+		newClient: func(baseURL string, client *http.Client) RegistrarClient {
+			return &OVHClient{appKey: "app", appSecret: "secret", consumerKey: "consumer", baseURL: baseURL, client: client}
+		},
+		fixtures: map[string]string{
+			"/domain":                           `["example.com"]`,
+			"/domain/example.com/serviceInfos":  `{"expiration":"2027-03-01","creation":"2019-03-01","status":"ok","renew":{"automatic":true}}`,
+			"/domain/zone/example.com/record":   `[1,2,3]`,
+			"/domain/zone/example.com/record/1": `{"id":1,"zone":"example.com","fieldType":"A","subDomain":"","target":"192.0.2.10","ttl":0}`,
+			"/domain/zone/example.com/record/2": `{"id":2,"zone":"example.com","fieldType":"MX","subDomain":"","target":"10 mx1.mail.ovh.net.","ttl":3600}`,
+			"/domain/zone/example.com/record/3": `{"id":3,"zone":"example.com","fieldType":"SPF","subDomain":"","target":"\"v=spf1 include:mx.ovh.com ~all\"","ttl":600}`,
+		},
+		domains: []conformanceDomain{
+			{Name: "example.com", Provider: "ovh", Status: "active", AutoRenew: true, ExpiresAt: "2027-03-01", RegisteredAt: "2019-03-01"},
+		},
+		dnsDomain: "example.com",
+		records: []conformanceRecord{
+			{Type: "A", Name: "@", Value: "192.0.2.10", TTL: 3600},
+			{Type: "MX", Name: "@", Value: "mx1.mail.ovh.net.", TTL: 3600, Priority: 10},
+			{Type: "TXT", Name: "@", Value: "v=spf1 include:mx.ovh.com ~all", TTL: 600},
+		},
+	},
+	{
+		provider: "dnsimple",
+		newClient: func(baseURL string, client *http.Client) RegistrarClient {
+			return &DNSimpleClient{accessToken: "token", baseURL: baseURL, client: client}
+		},
+		fixtures: map[string]string{
+			"/whoami": `{"data":{"user":null,"account":{"id":1010,"email":"owner@example.com"}}}`,
+			"/1010/domains": `{"data":[
+				{"id":1,"name":"example.com","state":"registered","auto_renew":true,"expires_at":"2027-03-01T00:00:00Z","created_at":"2019-03-01T12:00:00Z"},
+				{"id":2,"name":"example.io","state":"hosted","auto_renew":false,"expires_at":null,"created_at":"2022-06-01T00:00:00Z"}],
+				"pagination":{"current_page":1,"per_page":100,"total_entries":2,"total_pages":1}}`,
+			"/1010/zones/example.com/records": `{"data":[
+				{"id":1,"name":"","content":"ns1.dnsimple.com admin.dnsimple.com 1 86400 7200 604800 300","ttl":3600,"priority":null,"type":"SOA","system_record":true},
+				{"id":2,"name":"","content":"ns1.dnsimple.com","ttl":3600,"priority":null,"type":"NS","system_record":true},
+				{"id":3,"name":"","content":"192.0.2.10","ttl":3600,"priority":null,"type":"A","system_record":false},
+				{"id":4,"name":"","content":"mail.example.com","ttl":3600,"priority":10,"type":"MX","system_record":false},
+				{"id":5,"name":"_sip._tcp","content":"5 5060 sip.example.com","ttl":3600,"priority":10,"type":"SRV","system_record":false},
+				{"id":6,"name":"","content":"\"v=spf1 ~all\"","ttl":3600,"priority":null,"type":"TXT","system_record":false}],
+				"pagination":{"current_page":1,"per_page":100,"total_entries":6,"total_pages":1}}`,
+		},
+		domains: []conformanceDomain{
+			{Name: "example.com", Provider: "dnsimple", Status: "active", AutoRenew: true, ExpiresAt: "2027-03-01", RegisteredAt: "2019-03-01"},
+			{Name: "example.io", Provider: "dnsimple", Status: "active", RegisteredAt: "2022-06-01"},
+		},
+		dnsDomain: "example.com",
+		records: []conformanceRecord{
+			{Type: "NS", Name: "@", Value: "ns1.dnsimple.com", TTL: 3600},
+			{Type: "A", Name: "@", Value: "192.0.2.10", TTL: 3600},
+			{Type: "MX", Name: "@", Value: "mail.example.com", TTL: 3600, Priority: 10},
+			{Type: "SRV", Name: "_sip._tcp", Value: "sip.example.com", TTL: 3600, Priority: 10, Weight: 5, Port: 5060},
+			{Type: "TXT", Name: "@", Value: "v=spf1 ~all", TTL: 3600},
+		},
+	},
+	{
+		provider: "dynadot",
+		newClient: func(baseURL string, client *http.Client) RegistrarClient {
+			return &DynadotClient{apiKey: "key", baseURL: baseURL + "/api3.json", client: client}
+		},
+		route: func(r *http.Request) string {
+			query := r.URL.Query()
+			if domain := query.Get("domain"); domain != "" {
+				return query.Get("command") + ":" + domain
+			}
+			return query.Get("command")
+		},
+		fixtures: map[string]string{
+			"list_domain": `{"ListDomainInfoResponse":{"ResponseCode":0,"Status":"success","MainDomains":[
+				{"Name":"example.com","Expiration":"1803859200000","Registration":"1551398400000","RenewOption":"auto-renew","Hold":"no","Disabled":"no","Locked":"yes"},
+				{"Name":"example.net","Expiration":"1857859200000","Registration":"1636934400000","RenewOption":"do not renew","Hold":"yes","Disabled":"no","Locked":"no"}]}}`,
+			"get_dns:example.com": `{"GetDnsResponse":{"ResponseCode":0,"Status":"success","GetDns":{"NameServerSettings":{
+				"Type":"Dynadot DNS","TTL":"1800",
+				"MainDomains":[{"RecordType":"a","Value":"192.0.2.10"},{"RecordType":"mx","Value":"mail.example.com","Value2":"10"},{"RecordType":"txt","Value":"v=spf1 ~all"}],
+				"SubDomains":[{"Subhost":"www","RecordType":"cname","Value":"example.com"}]}}}}`,
+			"get_dns:missing.com": `{"GetDnsResponse":{"ResponseCode":-1,"Status":"error","Error":"could not find domain"}}`,
+		},
+		domains: []conformanceDomain{
+			{Name: "example.com", Provider: "dynadot", Status: "active", AutoRenew: true, ExpiresAt: "2027-03-01", RegisteredAt: "2019-03-01"},
+			{Name: "example.net", Provider: "dynadot", Status: "suspended", ExpiresAt: "2028-11-15", RegisteredAt: "2021-11-15"},
+		},
+		dnsDomain: "example.com",
+		records: []conformanceRecord{
+			{Type: "A", Name: "@", Value: "192.0.2.10", TTL: 1800},
+			{Type: "MX", Name: "@", Value: "mail.example.com", TTL: 1800, Priority: 10},
+			{Type: "TXT", Name: "@", Value: "v=spf1 ~all", TTL: 1800},
+			{Type: "CNAME", Name: "www", Value: "example.com", TTL: 1800},
+		},
+		missingErr: true,
+	},
+	{
+		provider: "hover",
+		newClient: func(baseURL string, client *http.Client) RegistrarClient {
+			return &HoverClient{username: "owner", password: "secret", baseURL: baseURL, client: client}
+		},
+		fixtures: map[string]string{
+			"/login": `{"succeeded":true}`,
+			"/domains": `{"succeeded":true,"domains":[
+				{"id":"dom1001","domain_name":"example.com","status":"active","expires_on":"2027-03-01","registered_on":"2019-03-01","auto_renew":true},
+				{"id":"dom1002","domain_name":"example.ca","status":"pending_transfer","expires_on":"2028-11-15","registered_on":"2021-11-15","auto_renew":false}]}`,
+			"/domains/example.com/dns": `{"succeeded":true,"domains":[{"domain_name":"example.com","id":"dom1001","active":true,"entries":[
+				{"id":"dns1","name":"@","type":"A","content":"192.0.2.10","ttl":900,"is_default":false},
+				{"id":"dns2","name":"@","type":"MX","content":"10 mx.hover.com.cust.hostedemail.com","ttl":900,"is_default":true},
+				{"id":"dns3","name":"_sip._tcp","type":"SRV","content":"10 5 5060 sip.example.com","ttl":900,"is_default":false},
+				{"id":"dns4","name":"www","type":"CNAME","content":"example.com","ttl":900,"is_default":false}]}]}`,
+		},
+		domains: []conformanceDomain{
+			{Name: "example.com", Provider: "hover", Status: "active", AutoRenew: true, ExpiresAt: "2027-03-01", RegisteredAt: "2019-03-01"},
+			{Name: "example.ca", Provider: "hover", Status: "pending", ExpiresAt: "2028-11-15", RegisteredAt: "2021-11-15"},
+		},
+		dnsDomain: "example.com",
+		records: []conformanceRecord{
+			{Type: "A", Name: "@", Value: "192.0.2.10", TTL: 900},
+			{Type: "MX", Name: "@", Value: "mx.hover.com.cust.hostedemail.com", TTL: 900, Priority: 10},
+			{Type: "SRV", Name: "_sip._tcp", Value: "sip.example.com", TTL: 900, Priority: 10, Weight: 5, Port: 5060},
+			{Type: "CNAME", Name: "www", Value: "example.com", TTL: 900},
+		},
+	},
+}
+
+// TestProviderConformance runs every HTTP provider client through the same checks: domains and
+// records map correctly from recorded API responses, auth and rate limit failures surface as
+// ErrProviderAuth and ErrProviderRateLimit, and operations a client doesn't implement are
+// reported as unsupported. A new provider gets coverage by adding a case.
+func TestProviderConformance(t *testing.T) {
+	for _, tc := range conformanceCases {
+		t.Run(tc.provider, func(t *testing.T) {
+			server := httptest.NewServer(tc.fixtureHandler())
+			defer server.Close()
+			client := tc.newClient(server.URL, server.Client())
+
+			if got := client.GetProviderName(); got != tc.provider {
+				t.Fatalf("GetProviderName() = %q, want %q", got, tc.provider)
+			}
+
+			t.Run("FetchDomains", func(t *testing.T) {
+				domains, err := client.FetchDomains()
+				if err != nil {
+					t.Fatalf("FetchDomains() unexpected error: %v", err)
+				}
+				ids := make(map[string]bool, len(domains))
+				got := make([]conformanceDomain, len(domains))
+				for i, domain := range domains {
+					if domain.ID == "" || ids[domain.ID] {
+						t.Errorf("Domain %s needs a unique ID, got %q", domain.Name, domain.ID)
+					}
+					ids[domain.ID] = true
+					registered := ""
+					if domain.RegisteredAt != nil {
+						registered = domain.RegisteredAt.UTC().Format("2006-01-02")
+					}
+					got[i] = conformanceDomain{
+						Name:         domain.Name,
+						Provider:     domain.Provider,
+						Status:       domain.Status,
+						AutoRenew:    domain.AutoRenew,
+						ExpiresAt:    conformanceDate(domain.ExpiresAt.IsZero(), domain.ExpiresAt.UTC().Format("2006-01-02")),
+						RegisteredAt: registered,
+					}
+				}
+				if !reflect.DeepEqual(got, tc.domains) {
+					t.Errorf("FetchDomains() mapped to\n%+v\nwant\n%+v", got, tc.domains)
+				}
+			})
+
+			t.Run("FetchDNSRecords", func(t *testing.T) {
+				records, err := client.FetchDNSRecords(tc.dnsDomain)
+				if err != nil {
+					t.Fatalf("FetchDNSRecords(%s) unexpected error: %v", tc.dnsDomain, err)
+				}
+				got := make([]conformanceRecord, len(records))
+				for i, record := range records {
+					if record.ID == "" {
+						t.Errorf("Record %s %s needs an ID", record.Type, record.Name)
+					}
+					got[i] = conformanceRecord{
+						Type: record.Type, Name: record.Name, Value: record.Value, TTL: record.TTL,
+						Priority: intValue(record.Priority), Weight: intValue(record.Weight), Port: intValue(record.Port),
+					}
+				}
+				if !reflect.DeepEqual(got, tc.records) {
+					t.Errorf("FetchDNSRecords(%s) mapped to\n%+v\nwant\n%+v", tc.dnsDomain, got, tc.records)
+				}
+
+				records, err = client.FetchDNSRecords("missing.com")
+				switch {
+				case tc.missingErr && err == nil:
+					t.Error("FetchDNSRecords(missing) expected an error")
+				case !tc.missingErr && (err != nil || len(records) != 0):
+					t.Errorf("FetchDNSRecords(missing) = %v, %v; want no records and no error", records, err)
+				}
+			})
+
+			t.Run("Failures", func(t *testing.T) {
+				for status, want := range map[int]error{
+					http.StatusUnauthorized:    types.ErrProviderAuth,
+					http.StatusTooManyRequests: types.ErrProviderRateLimit,
+				} {
+					failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						w.WriteHeader(status)
+					}))
+					client := tc.newClient(failing.URL, failing.Client())
+					if _, err := client.FetchDomains(); !errors.Is(err, want) {
+						t.Errorf("FetchDomains() on HTTP %d error = %v, want %v", status, err, want)
+					}
+					if _, err := client.FetchDNSRecords(tc.dnsDomain); !errors.Is(err, want) {
+						t.Errorf("FetchDNSRecords() on HTTP %d error = %v, want %v", status, err, want)
+					}
+					failing.Close()
+				}
+			})
+
+			t.Run("OptionalOperations", func(t *testing.T) {
+				_, autoRenew := client.(AutoRenewManager)
+				_, contacts := client.(ContactManager)
+				_, status := client.(DomainStatusReader)
+				_, availability := client.(AvailabilityChecker)
+				got := [4]bool{autoRenew, contacts, status, availability}
+				if want := [4]bool{tc.autoRenew, tc.contacts, tc.status, tc.availability}; got != want {
+					t.Errorf("Implements [auto-renew contacts status availability] = %v, want %v", got, want)
+				}
+
+				svc := NewProviderService()
+				svc.RegisterClient(tc.provider, client)
+				domain := types.Domain{ID: "d1", Name: tc.dnsDomain, Provider: tc.provider}
+				if _, err := svc.ReconcileAutoRenew(domain, false); !tc.autoRenew && !errors.Is(err, types.ErrAutoRenewUnsupported) {
+					t.Errorf("ReconcileAutoRenew() error = %v, want ErrAutoRenewUnsupported", err)
+				}
+				if _, err := svc.GetDomainContacts(domain); !tc.contacts && !errors.Is(err, types.ErrContactsUnsupported) {
+					t.Errorf("GetDomainContacts() error = %v, want ErrContactsUnsupported", err)
+				}
+				if _, err := svc.CheckAvailability("available.com"); !tc.availability && !errors.Is(err, types.ErrAvailabilityUnsupported) {
+					t.Errorf("CheckAvailability() error = %v, want ErrAvailabilityUnsupported", err)
+				}
+			})
+		})
+	}
+}
+
+// fixtureHandler serves the case's recorded responses, choosing the content type from the body
+func (tc conformanceCase) fixtureHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.URL.Path
+		if tc.route != nil {
+			key = tc.route(r)
+		}
+		body, ok := tc.fixtures[key]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		if len(body) > 0 && body[0] == '<' {
+			w.Header().Set("Content-Type", "application/xml")
+		} else {
+			w.Header().Set("Content-Type", "application/json")
+		}
+		w.Write([]byte(body))
+	})
+}
+
+func conformanceDate(zero bool, date string) string {
+	if zero {
+		return ""
+	}
+	return date
+}
+
+func intValue(v *int) int {
+	if v == nil {
+		return 0
+	}
+	return *v
+}
@@ -0,0 +1,150 @@
+package providers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Connection statuses set by testing a connected provider's credentials
+const (
+	ConnectionStatusConnected = "connected"
+	ConnectionStatusError     = "error"
+)
+
+// DefaultConnectionTestWorkers is how many providers TestConnectedProviders tests at once
+const DefaultConnectionTestWorkers = 5
+
+// ConnectionTestResult is the outcome of testing one connected provider's credentials
+type ConnectionTestResult struct {
+	ProviderID       string `json:"provider_id"`
+	Name             string `json:"name"`
+	Provider         string `json:"provider"`
+	AccountName      string `json:"account_name"`
+	Enabled          bool   `json:"enabled"`
+	Success          bool   `json:"success"`
+	Message          string `json:"message"`
+	DomainsFound     int    `json:"domains_found"`
+	DurationMs       int64  `json:"duration_ms"`
+	ConnectionStatus string `json:"connection_status"` // After the test
+}
+
+// ConnectionTestReport is the outcome of testing every connected provider
+type ConnectionTestReport struct {
+	Results   []ConnectionTestResult `json:"results"` // Failures first
+	Total     int                    `json:"total"`
+	Succeeded int                    `json:"succeeded"`
+	Failed    int                    `json:"failed"`
+	TestedAt  time.Time              `json:"tested_at"`
+}
+
+// TestConnectedProviders tests every connected provider's credentials by fetching its domains
+// with a pool of workers, and sets each provider's connection status from the outcome. A
+// stale provider stays stale after a successful test, since only a successful sync shows it
+// is syncing again. A successful test also closes the provider's circuit breaker, taking it
+// out of the degraded status. Providers whose fetch hasn't finished when ctx is done fail as
+// timed out.
+func (ps *ProviderService) TestConnectedProviders(ctx context.Context, workers int) ConnectionTestReport {
+	if workers <= 0 {
+		workers = DefaultConnectionTestWorkers
+	}
+
+	ps.mu.RLock()
+	connected := make([]*ConnectedProvider, 0, len(ps.connectedProviders))
+	for _, provider := range ps.connectedProviders {
+		connected = append(connected, provider)
+	}
+	ps.mu.RUnlock()
+
+	report := ConnectionTestReport{Results: make([]ConnectionTestResult, len(connected)), Total: len(connected), TestedAt: time.Now()}
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers && w < len(connected); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				report.Results[i] = ps.testConnectedProvider(ctx, connected[i])
+			}
+		}()
+	}
+	for i := range connected {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	for _, result := range report.Results {
+		if result.Success {
+			report.Succeeded++
+		} else {
+			report.Failed++
+		}
+	}
+	sort.SliceStable(report.Results, func(i, j int) bool {
+		if report.Results[i].Success != report.Results[j].Success {
+			return !report.Results[i].Success
+		}
+		return report.Results[i].Name < report.Results[j].Name
+	})
+	return report
+}
+
+// testConnectedProvider fetches a provider's domains, giving up when ctx is done, and records
+// the outcome in the provider's connection status
+func (ps *ProviderService) testConnectedProvider(ctx context.Context, provider *ConnectedProvider) ConnectionTestResult {
+	ps.mu.RLock()
+	result := ConnectionTestResult{
+		ProviderID:  provider.ID,
+		Name:        provider.Name,
+		Provider:    provider.Provider,
+		AccountName: provider.AccountName,
+		Enabled:     provider.Enabled,
+	}
+	client := provider.Client
+	ps.mu.RUnlock()
+
+	start := time.Now()
+	var err error
+	if client == nil {
+		err = fmt.Errorf("no client configured")
+	} else {
+		type fetchResult struct {
+			domains int
+			err     error
+		}
+		done := make(chan fetchResult, 1)
+		go func() {
+			domains, err := client.FetchDomains()
+			done <- fetchResult{len(domains), err}
+		}()
+		select {
+		case fetched := <-done:
+			result.DomainsFound, err = fetched.domains, fetched.err
+		case <-ctx.Done():
+			err = fmt.Errorf("timed out")
+		}
+	}
+	result.DurationMs = time.Since(start).Milliseconds()
+
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	if err != nil {
+		result.Message = fmt.Sprintf("Connection test failed: %v", err)
+		provider.ConnectionStatus = ConnectionStatusError
+	} else {
+		result.Success = true
+		result.Message = "Connection successful"
+		// A successful test is a probe: it closes an open circuit breaker
+		ps.breakerLocked(provider).Reset()
+		ps.updateBreakerStatusLocked(provider)
+		if provider.ConnectionStatus != ConnectionStatusStale {
+			provider.ConnectionStatus = ConnectionStatusConnected
+		}
+	}
+	provider.UpdatedAt = time.Now()
+	result.ConnectionStatus = provider.ConnectionStatus
+	return result
+}
@@ -0,0 +1,60 @@
+package providers
+
+import (
+	"fmt"
+
+	"github.com/rusiqe/domainvault/internal/types"
+)
+
+// contactManager returns the connected client for the domain's provider if it manages contacts
+func (ps *ProviderService) contactManager(domain types.Domain) (ContactManager, error) {
+	client, ok := ps.GetClientByProviderName(domain.Provider)
+	if !ok {
+		return nil, fmt.Errorf("no connected client for provider %s", domain.Provider)
+	}
+	manager, ok := client.(ContactManager)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", types.ErrContactsUnsupported, domain.Provider)
+	}
+	return manager, nil
+}
+
+// GetDomainContacts reads a domain's contacts and WHOIS privacy from its registrar.
+// Providers without contact support return ErrContactsUnsupported.
+func (ps *ProviderService) GetDomainContacts(domain types.Domain) (*types.DomainContacts, error) {
+	manager, err := ps.contactManager(domain)
+	if err != nil {
+		return nil, err
+	}
+
+	contacts, err := manager.GetContacts(domain.Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read contacts for %s: %w", domain.Name, err)
+	}
+	contacts.DomainID = domain.ID
+	contacts.Domain = domain.Name
+	contacts.Provider = domain.Provider
+	return contacts, nil
+}
+
+// UpdateDomainContacts applies a privacy and/or registrant change at the registrar and returns
+// the contacts as the registrar reports them afterwards. The registrant must already be validated.
+func (ps *ProviderService) UpdateDomainContacts(domain types.Domain, update types.DomainContactsUpdate) (*types.DomainContacts, error) {
+	manager, err := ps.contactManager(domain)
+	if err != nil {
+		return nil, err
+	}
+
+	if update.Registrant != nil {
+		if err := manager.UpdateRegistrant(domain.Name, *update.Registrant); err != nil {
+			return nil, fmt.Errorf("failed to update registrant for %s: %w", domain.Name, err)
+		}
+	}
+	if update.Privacy != nil {
+		if err := manager.SetPrivacy(domain.Name, *update.Privacy); err != nil {
+			return nil, fmt.Errorf("failed to set WHOIS privacy for %s: %w", domain.Name, err)
+		}
+	}
+
+	return ps.GetDomainContacts(domain)
+}
@@ -0,0 +1,282 @@
+package providers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rusiqe/domainvault/internal/types"
+)
+
+// dnsimpleEndpoints maps DNSimple environments to API base URLs
+var dnsimpleEndpoints = map[string]string{
+	"production": "https://api.dnsimple.com/v2",
+	"sandbox":    "https://api.sandbox.dnsimple.com/v2",
+}
+
+// DNSimpleClient implements RegistrarClient for the DNSimple v2 API
+// API docs: https://developer.dnsimple.com/v2/
+type DNSimpleClient struct {
+	accessToken string
+	accountID   string // Resolved from /whoami when not configured
+	baseURL     string
+	client      *http.Client
+}
+
+// DNSimpleDomain represents a domain from DNSimple API
+type DNSimpleDomain struct {
+	ID        int64  `json:"id"`
+	Name      string `json:"name"`
+	State     string `json:"state"` // registered, hosted, resolving
+	AutoRenew bool   `json:"auto_renew"`
+	ExpiresAt string `json:"expires_at"` // RFC 3339, null for hosted domains
+	CreatedAt string `json:"created_at"`
+}
+
+// DNSimpleRecord represents a zone record from DNSimple API
+type DNSimpleRecord struct {
+	ID           int64  `json:"id"`
+	Name         string `json:"name"` // Empty for the zone apex
+	Content      string `json:"content"`
+	TTL          int    `json:"ttl"`
+	Priority     *int   `json:"priority"`
+	Type         string `json:"type"`
+	SystemRecord bool   `json:"system_record"` // SOA and DNSimple NS records
+}
+
+// dnsimplePagination is the pagination block of DNSimple list responses
+type dnsimplePagination struct {
+	CurrentPage int `json:"current_page"`
+	TotalPages  int `json:"total_pages"`
+}
+
+// NewDNSimpleClient creates a new DNSimple client
+func NewDNSimpleClient(creds ProviderCredentials) (*DNSimpleClient, error) {
+	accessToken, ok := creds["access_token"].(string)
+	if !ok || accessToken == "" {
+		return nil, types.ErrMissingConfig
+	}
+
+	// Environment may be "production" (default), "sandbox" or a full base URL
+	baseURL := dnsimpleEndpoints["production"]
+	if environment, ok := creds["environment"].(string); ok && environment != "" {
+		if known, exists := dnsimpleEndpoints[strings.ToLower(environment)]; exists {
+			baseURL = known
+		} else {
+			baseURL = strings.TrimSuffix(environment, "/")
+		}
+	}
+
+	accountID, _ := creds["account_id"].(string)
+
+	return &DNSimpleClient{
+		accessToken: accessToken,
+		accountID:   accountID,
+		baseURL:     baseURL,
+		client:      newHTTPClient(creds),
+	}, nil
+}
+
+// FetchDomains retrieves all domains in the account from DNSimple API
+func (d *DNSimpleClient) FetchDomains() ([]types.Domain, error) {
+	account, err := d.account()
+	if err != nil {
+		return nil, err
+	}
+
+	var domains []types.Domain
+	for page := 1; ; page++ {
+		var resp struct {
+			Data       []DNSimpleDomain   `json:"data"`
+			Pagination dnsimplePagination `json:"pagination"`
+		}
+		if err := d.get(fmt.Sprintf("/%s/domains?page=%d&per_page=100", account, page), &resp); err != nil {
+			return nil, fmt.Errorf("failed to fetch domains: %w", err)
+		}
+
+		for _, dd := range resp.Data {
+			domain := types.Domain{
+				ID:        uuid.New().String(), // Generate new UUID
+				Name:      dd.Name,
+				Provider:  "dnsimple",
+				AutoRenew: dd.AutoRenew,
+				Status:    d.mapStatus(dd.State),
+				UpdatedAt: time.Now(),
+			}
+			if expires, err := time.Parse(time.RFC3339, dd.ExpiresAt); err == nil {
+				domain.ExpiresAt = expires
+			}
+			if created, err := time.Parse(time.RFC3339, dd.CreatedAt); err == nil {
+				domain.RegisteredAt = registeredAt(created)
+			}
+			domains = append(domains, domain)
+		}
+
+		if resp.Pagination.CurrentPage >= resp.Pagination.TotalPages {
+			break
+		}
+	}
+
+	return domains, nil
+}
+
+// GetProviderName returns the provider name
+func (d *DNSimpleClient) GetProviderName() string {
+	return "dnsimple"
+}
+
+// mapStatus maps DNSimple domain state to internal status
+func (d *DNSimpleClient) mapStatus(state string) string {
+	switch state {
+	case "registered", "hosted":
+		return "active"
+	case "resolving":
+		return "pending"
+	case "expired":
+		return "expired"
+	default:
+		return "unknown"
+	}
+}
+
+// FetchDNSRecords retrieves the zone records for a domain from DNSimple API
+func (d *DNSimpleClient) FetchDNSRecords(domain string) ([]types.DNSRecord, error) {
+	account, err := d.account()
+	if err != nil {
+		return nil, err
+	}
+
+	var dnsRecords []types.DNSRecord
+	for page := 1; ; page++ {
+		var resp struct {
+			Data       []DNSimpleRecord   `json:"data"`
+			Pagination dnsimplePagination `json:"pagination"`
+		}
+		path := fmt.Sprintf("/%s/zones/%s/records?page=%d&per_page=100", account, url.PathEscape(domain), page)
+		if err := d.get(path, &resp); err != nil {
+			if err == types.ErrDomainNotFound {
+				// Domain has no DNSimple-hosted zone
+				return []types.DNSRecord{}, nil
+			}
+			return nil, fmt.Errorf("failed to fetch DNS records: %w", err)
+		}
+
+		for _, dr := range resp.Data {
+			if dr.Type == "SOA" {
+				continue
+			}
+			dnsRecords = append(dnsRecords, d.convertRecord(dr))
+		}
+
+		if resp.Pagination.CurrentPage >= resp.Pagination.TotalPages {
+			break
+		}
+	}
+
+	return dnsRecords, nil
+}
+
+// convertRecord maps a DNSimple record to the internal format. DNSimple returns priority
+// separately for MX and SRV, and SRV content as "weight port target".
+func (d *DNSimpleClient) convertRecord(dr DNSimpleRecord) types.DNSRecord {
+	record := types.DNSRecord{
+		ID:        uuid.New().String(), // Generate new UUID
+		Type:      strings.ToUpper(dr.Type),
+		Name:      dr.Name,
+		Value:     dr.Content,
+		TTL:       dr.TTL,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	if record.Name == "" {
+		record.Name = "@"
+	}
+
+	switch record.Type {
+	case "MX":
+		record.Priority = dr.Priority
+	case "SRV":
+		record.Priority = dr.Priority
+		if fields := strings.Fields(dr.Content); len(fields) == 3 {
+			weight, errW := strconv.Atoi(fields[0])
+			port, errPort := strconv.Atoi(fields[1])
+			if errW == nil && errPort == nil {
+				record.Weight = &weight
+				record.Port = &port
+				record.Value = fields[2]
+			}
+		}
+	case "TXT":
+		record.Value = types.DechunkTXT(record.Value)
+	}
+
+	return record
+}
+
+// account returns the account ID, looking it up from the access token on first use
+func (d *DNSimpleClient) account() (string, error) {
+	if d.accountID != "" {
+		return d.accountID, nil
+	}
+
+	var resp struct {
+		Data struct {
+			Account *struct {
+				ID int64 `json:"id"`
+			} `json:"account"`
+		} `json:"data"`
+	}
+	if err := d.get("/whoami", &resp); err != nil {
+		return "", fmt.Errorf("failed to resolve account: %w", err)
+	}
+	if resp.Data.Account == nil {
+		// User tokens span several accounts, so the account must be configured
+		return "", fmt.Errorf("account_id is required for user access tokens: %w", types.ErrMissingConfig)
+	}
+
+	d.accountID = strconv.FormatInt(resp.Data.Account.ID, 10)
+	return d.accountID, nil
+}
+
+// get performs an authenticated GET request and decodes the JSON response into out
+func (d *DNSimpleClient) get(path string, out interface{}) error {
+	req, err := http.NewRequest("GET", d.baseURL+path, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+d.accessToken)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == 401 || resp.StatusCode == 403 {
+		return types.ErrProviderAuth
+	}
+
+	if resp.StatusCode == 429 {
+		return types.ErrProviderRateLimit
+	}
+
+	if resp.StatusCode == 404 {
+		return types.ErrDomainNotFound
+	}
+
+	if resp.StatusCode != 200 {
+		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,233 @@
+package providers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rusiqe/domainvault/internal/types"
+)
+
+// DynadotClient implements RegistrarClient for the Dynadot API3 JSON interface. Domains and
+// Dynadot-hosted DNS are read-only here: auto-renew, contacts, availability and registrar
+// status are not implemented, so those operations report as unsupported.
+// API docs: https://www.dynadot.com/domain/api3.html
+type DynadotClient struct {
+	apiKey  string
+	baseURL string
+	client  *http.Client
+}
+
+// DynadotDomain represents a domain from the Dynadot list_domain command
+type DynadotDomain struct {
+	Name         string `json:"Name"`
+	Expiration   string `json:"Expiration"`   // Unix time in milliseconds
+	Registration string `json:"Registration"` // Unix time in milliseconds
+	RenewOption  string `json:"RenewOption"`  // auto-renew, do not renew, reset
+	Hold         string `json:"Hold"`         // yes or no
+	Disabled     string `json:"Disabled"`     // yes or no
+}
+
+// DynadotRecord represents a record from the Dynadot get_dns command. Value2 holds the MX
+// distance; SubHost is empty for records on the zone apex.
+type DynadotRecord struct {
+	SubHost    string `json:"Subhost"`
+	RecordType string `json:"RecordType"`
+	Value      string `json:"Value"`
+	Value2     string `json:"Value2"`
+}
+
+// dynadotStatus is the status block every Dynadot response carries
+type dynadotStatus struct {
+	Status string `json:"Status"` // success or error
+	Error  string `json:"Error"`
+}
+
+// NewDynadotClient creates a new Dynadot client
+func NewDynadotClient(creds ProviderCredentials) (*DynadotClient, error) {
+	apiKey, ok := creds["api_key"].(string)
+	if !ok || apiKey == "" {
+		return nil, types.ErrMissingConfig
+	}
+
+	return &DynadotClient{
+		apiKey:  apiKey,
+		baseURL: "https://api.dynadot.com/api3.json",
+		client:  newHTTPClient(creds),
+	}, nil
+}
+
+// FetchDomains retrieves all domains in the account from Dynadot API
+func (d *DynadotClient) FetchDomains() ([]types.Domain, error) {
+	var resp struct {
+		ListDomainInfoResponse struct {
+			dynadotStatus
+			MainDomains []DynadotDomain `json:"MainDomains"`
+		} `json:"ListDomainInfoResponse"`
+	}
+	if err := d.call(url.Values{"command": {"list_domain"}}, &resp, func() dynadotStatus {
+		return resp.ListDomainInfoResponse.dynadotStatus
+	}); err != nil {
+		return nil, fmt.Errorf("failed to fetch domains: %w", err)
+	}
+
+	var domains []types.Domain
+	for _, dd := range resp.ListDomainInfoResponse.MainDomains {
+		domain := types.Domain{
+			ID:           uuid.New().String(), // Generate new UUID
+			Name:         strings.ToLower(dd.Name),
+			Provider:     "dynadot",
+			AutoRenew:    dd.RenewOption == "auto-renew",
+			ExpiresAt:    dynadotTime(dd.Expiration),
+			RegisteredAt: registeredAt(dynadotTime(dd.Registration)),
+			UpdatedAt:    time.Now(),
+		}
+		domain.Status = d.mapStatus(dd, domain.ExpiresAt)
+		domains = append(domains, domain)
+	}
+
+	return domains, nil
+}
+
+// GetProviderName returns the provider name
+func (d *DynadotClient) GetProviderName() string {
+	return "dynadot"
+}
+
+// mapStatus derives the internal status from Dynadot's hold and disabled flags and the
+// expiry, since list_domain has no status field of its own
+func (d *DynadotClient) mapStatus(dd DynadotDomain, expiresAt time.Time) string {
+	switch {
+	case strings.EqualFold(dd.Disabled, "yes"), strings.EqualFold(dd.Hold, "yes"):
+		return "suspended"
+	case !expiresAt.IsZero() && expiresAt.Before(time.Now()):
+		return "expired"
+	default:
+		return "active"
+	}
+}
+
+// FetchDNSRecords retrieves the Dynadot-hosted records for a domain. Domains delegated to
+// other nameservers have no records here and return none.
+func (d *DynadotClient) FetchDNSRecords(domain string) ([]types.DNSRecord, error) {
+	var resp struct {
+		GetDnsResponse struct {
+			dynadotStatus
+			GetDns struct {
+				NameServerSettings struct {
+					MainDomains []DynadotRecord `json:"MainDomains"`
+					SubDomains  []DynadotRecord `json:"SubDomains"`
+					TTL         string          `json:"TTL"`
+				} `json:"NameServerSettings"`
+			} `json:"GetDns"`
+		} `json:"GetDnsResponse"`
+	}
+	if err := d.call(url.Values{"command": {"get_dns"}, "domain": {domain}}, &resp, func() dynadotStatus {
+		return resp.GetDnsResponse.dynadotStatus
+	}); err != nil {
+		return nil, fmt.Errorf("failed to fetch DNS records: %w", err)
+	}
+
+	settings := resp.GetDnsResponse.GetDns.NameServerSettings
+	ttl, err := strconv.Atoi(settings.TTL)
+	if err != nil || ttl <= 0 {
+		ttl = 3600 // Dynadot's default when the zone has no TTL set
+	}
+
+	dnsRecords := []types.DNSRecord{}
+	for _, dr := range append(settings.MainDomains, settings.SubDomains...) {
+		dnsRecords = append(dnsRecords, d.convertRecord(dr, ttl))
+	}
+	return dnsRecords, nil
+}
+
+// convertRecord maps a Dynadot record to the internal format. Dynadot applies one TTL to
+// the whole zone and returns record types in lower case.
+func (d *DynadotClient) convertRecord(dr DynadotRecord, ttl int) types.DNSRecord {
+	record := types.DNSRecord{
+		ID:        uuid.New().String(), // Generate new UUID
+		Type:      strings.ToUpper(dr.RecordType),
+		Name:      dr.SubHost,
+		Value:     dr.Value,
+		TTL:       ttl,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	if record.Name == "" {
+		record.Name = "@"
+	}
+
+	switch record.Type {
+	case "MX":
+		if priority, err := strconv.Atoi(dr.Value2); err == nil {
+			record.Priority = &priority
+		}
+	case "TXT":
+		record.Value = types.DechunkTXT(record.Value)
+	}
+
+	return record
+}
+
+// call runs a Dynadot command and decodes the JSON response into out. Dynadot reports most
+// failures as HTTP 200 with an error status in the body, which status reads back from out.
+func (d *DynadotClient) call(params url.Values, out interface{}, status func() dynadotStatus) error {
+	params.Set("key", d.apiKey)
+	req, err := http.NewRequest("GET", d.baseURL+"?"+params.Encode(), nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == 401 || resp.StatusCode == 403 {
+		return types.ErrProviderAuth
+	}
+
+	if resp.StatusCode == 429 {
+		return types.ErrProviderRateLimit
+	}
+
+	if resp.StatusCode != 200 {
+		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	if result := status(); !strings.EqualFold(result.Status, "success") {
+		message := strings.ToLower(result.Error)
+		switch {
+		case strings.Contains(message, "key"), strings.Contains(message, "ip address"):
+			// Invalid or missing key, or a request from an IP outside the key's allow list
+			return fmt.Errorf("%w: %s", types.ErrProviderAuth, result.Error)
+		case strings.Contains(message, "too many"), strings.Contains(message, "rate limit"):
+			return fmt.Errorf("%w: %s", types.ErrProviderRateLimit, result.Error)
+		case result.Error != "":
+			return fmt.Errorf("dynadot error: %s", result.Error)
+		default:
+			return fmt.Errorf("dynadot returned status %q", result.Status)
+		}
+	}
+	return nil
+}
+
+// dynadotTime parses Dynadot's millisecond Unix timestamps, returning zero when absent
+func dynadotTime(millis string) time.Time {
+	ms, err := strconv.ParseInt(millis, 10, 64)
+	if err != nil || ms <= 0 {
+		return time.Time{}
+	}
+	return time.UnixMilli(ms).UTC()
+}
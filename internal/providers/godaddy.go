@@ -1,9 +1,12 @@
 package providers
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -44,9 +47,7 @@ func NewGoDaddyClient(creds ProviderCredentials) (*GoDaddyClient, error) {
 		apiKey:    apiKey,
 		apiSecret: apiSecret,
 		baseURL:   "https://api.godaddy.com/v1",
-		client: &http.Client{
-			Timeout: 30 * time.Second,
-		},
+		client:    newHTTPClient(creds),
 	}, nil
 }
 
@@ -90,12 +91,12 @@ func (g *GoDaddyClient) FetchDomains() ([]types.Domain, error) {
 	domains := make([]types.Domain, len(godaddyDomains))
 	for i, gd := range godaddyDomains {
 		domains[i] = types.Domain{
-			ID:        uuid.New().String(), // Generate new UUID
-			Name:      gd.Domain,
-			Provider:  "godaddy",
-			ExpiresAt: gd.ExpiresAt,
-			CreatedAt: gd.CreatedAt,
-			UpdatedAt: time.Now(),
+			ID:           uuid.New().String(), // Generate new UUID
+			Name:         gd.Domain,
+			Provider:     "godaddy",
+			ExpiresAt:    gd.ExpiresAt,
+			RegisteredAt: registeredAt(gd.CreatedAt),
+			UpdatedAt:    time.Now(),
 		}
 	}
 
@@ -189,6 +190,272 @@ func (g *GoDaddyClient) FetchDNSRecords(domain string) ([]types.DNSRecord, error
 	return dnsRecords, nil
 }
 
+// GoDaddyDomainDetail represents the single-domain response from GoDaddy API
+type GoDaddyDomainDetail struct {
+	Domain            string          `json:"domain"`
+	RenewAuto         bool            `json:"renewAuto"`
+	Status            string          `json:"status"`
+	Locked            bool            `json:"locked"`        // Transfer lock is on
+	HoldRegistrar     bool            `json:"holdRegistrar"` // Registrar has put the domain on hold
+	Privacy           bool            `json:"privacy"`     // Privacy product is active on the domain
+	ExposeWhois       *bool           `json:"exposeWhois"` // Contact details are published in WHOIS
+	ContactRegistrant *GoDaddyContact `json:"contactRegistrant"`
+	ContactAdmin      *GoDaddyContact `json:"contactAdmin"`
+	ContactTech       *GoDaddyContact `json:"contactTech"`
+	ContactBilling    *GoDaddyContact `json:"contactBilling"`
+}
+
+// GoDaddyContact represents a domain contact in GoDaddy API
+type GoDaddyContact struct {
+	NameFirst      string         `json:"nameFirst"`
+	NameLast       string         `json:"nameLast"`
+	Organization   string         `json:"organization,omitempty"`
+	Email          string         `json:"email"`
+	Phone          string         `json:"phone"`
+	AddressMailing GoDaddyAddress `json:"addressMailing"`
+}
+
+// GoDaddyAddress represents a contact's mailing address in GoDaddy API
+type GoDaddyAddress struct {
+	Address1   string `json:"address1"`
+	Address2   string `json:"address2,omitempty"`
+	City       string `json:"city"`
+	State      string `json:"state"`
+	PostalCode string `json:"postalCode"`
+	Country    string `json:"country"`
+}
+
+// domainRequest sends a request for a single domain's resource and decodes the JSON response
+// into out, if given. payload, if given, is sent as the JSON body.
+func (g *GoDaddyClient) domainRequest(method, domain, path string, payload, out interface{}) error {
+	url := fmt.Sprintf("%s/domains/%s%s", g.baseURL, domain, path)
+
+	var body io.Reader
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return fmt.Errorf("failed to encode request: %w", err)
+		}
+		body = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, url, body)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("sso-key %s:%s", g.apiKey, g.apiSecret))
+	req.Header.Set("Accept", "application/json")
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("request for domain %s failed: %w", domain, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == 401 {
+		return types.ErrProviderAuth
+	}
+	if resp.StatusCode == 429 {
+		return types.ErrProviderRateLimit
+	}
+	if resp.StatusCode == 404 {
+		return types.ErrDomainNotFound
+	}
+	if resp.StatusCode != 200 && resp.StatusCode != 204 {
+		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			return fmt.Errorf("failed to decode response: %w", err)
+		}
+	}
+	return nil
+}
+
+// GetAutoRenew reads the registrar-side auto-renew flag for a domain
+func (g *GoDaddyClient) GetAutoRenew(domain string) (bool, error) {
+	var detail GoDaddyDomainDetail
+	if err := g.domainRequest("GET", domain, "", nil, &detail); err != nil {
+		return false, err
+	}
+	return detail.RenewAuto, nil
+}
+
+// SetAutoRenew updates the registrar-side auto-renew flag for a domain
+func (g *GoDaddyClient) SetAutoRenew(domain string, enabled bool) error {
+	return g.domainRequest("PATCH", domain, "", map[string]bool{"renewAuto": enabled}, nil)
+}
+
+// GetContacts reads a domain's contacts and whether its details are hidden from WHOIS
+func (g *GoDaddyClient) GetContacts(domain string) (*types.DomainContacts, error) {
+	var detail GoDaddyDomainDetail
+	if err := g.domainRequest("GET", domain, "", nil, &detail); err != nil {
+		return nil, err
+	}
+
+	return &types.DomainContacts{
+		Domain:     domain,
+		Privacy:    detail.Privacy || (detail.ExposeWhois != nil && !*detail.ExposeWhois),
+		Registrant: detail.ContactRegistrant.toContact(),
+		Admin:      detail.ContactAdmin.toContact(),
+		Tech:       detail.ContactTech.toContact(),
+		Billing:    detail.ContactBilling.toContact(),
+	}, nil
+}
+
+// SetPrivacy hides or publishes a domain's contact details in WHOIS
+func (g *GoDaddyClient) SetPrivacy(domain string, enabled bool) error {
+	return g.domainRequest("PATCH", domain, "", map[string]bool{"exposeWhois": !enabled}, nil)
+}
+
+// UpdateRegistrant replaces a domain's registrant contact
+func (g *GoDaddyClient) UpdateRegistrant(domain string, registrant types.DomainContact) error {
+	payload := map[string]GoDaddyContact{"contactRegistrant": fromContact(registrant)}
+	return g.domainRequest("PATCH", domain, "/contacts", payload, nil)
+}
+
+// GetDomainStatus reads a domain's status at GoDaddy, translated to EPP status codes
+func (g *GoDaddyClient) GetDomainStatus(domain string) ([]string, error) {
+	var detail GoDaddyDomainDetail
+	if err := g.domainRequest("GET", domain, "", nil, &detail); err != nil {
+		return nil, err
+	}
+
+	statuses := []string{godaddyStatusCode(detail.Status)}
+	if detail.Locked {
+		statuses = append(statuses, types.RegistrarStatusClientTransferProhibited)
+	}
+	if detail.HoldRegistrar {
+		statuses = append(statuses, types.RegistrarStatusClientHold)
+	}
+	return statuses, nil
+}
+
+// godaddyStatusCode maps one of GoDaddy's domain statuses, e.g. HELD_DISPUTED or
+// CANCELLED_REDEEMABLE, to the nearest EPP status code. Statuses with no equivalent are
+// kept, lowercased.
+func godaddyStatusCode(status string) string {
+	status = strings.ToUpper(status)
+	switch {
+	case status == "ACTIVE":
+		return types.RegistrarStatusOK
+	case strings.Contains(status, "REDEEM") || strings.Contains(status, "REDEMPTION"):
+		return types.RegistrarStatusRedemptionPeriod
+	case strings.Contains(status, "HELD") || strings.HasPrefix(status, "LOCKED_") ||
+		strings.HasPrefix(status, "SUSPENDED") || status == "CONFISCATED":
+		return types.RegistrarStatusClientHold
+	case strings.HasPrefix(status, "CANCELLED") || strings.Contains(status, "DELETE"):
+		return types.RegistrarStatusPendingDelete
+	case strings.Contains(status, "TRANSFER"):
+		return types.RegistrarStatusPendingTransfer
+	case strings.Contains(status, "EXPIRED"):
+		return types.RegistrarStatusExpired
+	default:
+		return strings.ToLower(status)
+	}
+}
+
+// GoDaddyAvailability is GoDaddy's answer to an availability check. Prices are in millionths
+// of the currency unit.
+type GoDaddyAvailability struct {
+	Available bool   `json:"available"`
+	Domain    string `json:"domain"`
+	Price     int64  `json:"price"`
+	Currency  string `json:"currency"`
+	Period    int    `json:"period"`
+}
+
+// CheckAvailability asks GoDaddy whether a domain can be registered and what the first
+// period costs
+func (g *GoDaddyClient) CheckAvailability(domain string) (*types.DomainAvailability, error) {
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/domains/available", g.baseURL), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	query := req.URL.Query()
+	query.Set("domain", domain)
+	query.Set("checkType", "FULL")
+	req.URL.RawQuery = query.Encode()
+	req.Header.Set("Authorization", fmt.Sprintf("sso-key %s:%s", g.apiKey, g.apiSecret))
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("availability check for %s failed: %w", domain, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == 401 {
+		return nil, types.ErrProviderAuth
+	}
+	if resp.StatusCode == 429 {
+		return nil, types.ErrProviderRateLimit
+	}
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	var result GoDaddyAvailability
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	availability := &types.DomainAvailability{
+		Domain:    domain,
+		Available: result.Available,
+		Currency:  result.Currency,
+		Source:    "godaddy",
+	}
+	if result.Available && result.Price > 0 {
+		price := float64(result.Price) / 1e6
+		availability.Price = &price
+	}
+	return availability, nil
+}
+
+// toContact converts a GoDaddy contact, which may be absent, to the internal form
+func (c *GoDaddyContact) toContact() *types.DomainContact {
+	if c == nil {
+		return nil
+	}
+	return &types.DomainContact{
+		FirstName:    c.NameFirst,
+		LastName:     c.NameLast,
+		Organization: c.Organization,
+		Email:        c.Email,
+		Phone:        c.Phone,
+		Address1:     c.AddressMailing.Address1,
+		Address2:     c.AddressMailing.Address2,
+		City:         c.AddressMailing.City,
+		State:        c.AddressMailing.State,
+		PostalCode:   c.AddressMailing.PostalCode,
+		Country:      c.AddressMailing.Country,
+	}
+}
+
+// fromContact converts an internal contact to GoDaddy's form
+func fromContact(c types.DomainContact) GoDaddyContact {
+	return GoDaddyContact{
+		NameFirst:    c.FirstName,
+		NameLast:     c.LastName,
+		Organization: c.Organization,
+		Email:        c.Email,
+		Phone:        c.Phone,
+		AddressMailing: GoDaddyAddress{
+			Address1:   c.Address1,
+			Address2:   c.Address2,
+			City:       c.City,
+			State:      c.State,
+			PostalCode: c.PostalCode,
+			Country:    c.Country,
+		},
+	}
+}
+
 // Future implementations for MVP expansion:
 // func (g *GoDaddyClient) RenewDomain(domainID string) error { ... }
 // func (g *GoDaddyClient) UpdateDNS(domain string, records []types.DNSRecord) error { ... }
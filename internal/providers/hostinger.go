@@ -41,9 +41,7 @@ func NewHostingerClient(creds ProviderCredentials) (*HostingerClient, error) {
 	return &HostingerClient{
 		apiKey:  apiKey,
 		baseURL: "https://developers.hostinger.com/api", // Official API base URL
-		client: &http.Client{
-			Timeout: 30 * time.Second,
-		},
+		client:  newHTTPClient(creds),
 	}, nil
 }
 
@@ -100,14 +98,14 @@ func (h *HostingerClient) FetchDomains() ([]types.Domain, error) {
 		}
 
 		domains = append(domains, types.Domain{
-			ID:        uuid.New().String(), // Generate new UUID
-			Name:      *hd.Domain,
-			Provider:  "hostinger",
-			ExpiresAt: expiresAt,
-			AutoRenew: false, // API doesn't provide auto-renew info
-			Status:    h.mapStatus(hd.Status),
-			CreatedAt: hd.CreatedAt,
-			UpdatedAt: time.Now(),
+			ID:           uuid.New().String(), // Generate new UUID
+			Name:         *hd.Domain,
+			Provider:     "hostinger",
+			ExpiresAt:    expiresAt,
+			AutoRenew:    false, // API doesn't provide auto-renew info
+			Status:       h.mapStatus(hd.Status),
+			RegisteredAt: registeredAt(hd.CreatedAt),
+			UpdatedAt:    time.Now(),
 		})
 	}
 
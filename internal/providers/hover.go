@@ -0,0 +1,303 @@
+package providers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rusiqe/domainvault/internal/types"
+)
+
+// HoverClient implements RegistrarClient for Hover. Hover has no public API: this client
+// signs in with the account username and password and calls the JSON endpoints the Hover
+// control panel uses, authenticated by the session cookie it sets. Caveats:
+//   - The endpoints are undocumented and may change without notice; responses that don't
+//     match the expected shape fail with ErrProviderUnexpectedResponse instead of syncing
+//     partial data.
+//   - Accounts with two-factor authentication can't sign in this way and fail with
+//     ErrProviderAuth.
+//   - Only domains and DNS are read. Auto-renew, contacts, availability and registrar
+//     status are not implemented, so those operations report as unsupported.
+type HoverClient struct {
+	username string
+	password string
+	baseURL  string
+	client   *http.Client
+
+	mu       sync.Mutex
+	loggedIn bool
+}
+
+// HoverDomain represents a domain from Hover's /domains endpoint
+type HoverDomain struct {
+	ID           string `json:"id"`
+	DomainName   string `json:"domain_name"`
+	Status       string `json:"status"`
+	ExpiresOn    string `json:"expires_on"`    // YYYY-MM-DD
+	RegisteredOn string `json:"registered_on"` // YYYY-MM-DD
+	AutoRenew    bool   `json:"auto_renew"`
+}
+
+// HoverRecord represents a record from Hover's /domains/{domain}/dns endpoint. MX content
+// is "priority host" and SRV content is "priority weight port target".
+type HoverRecord struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Content string `json:"content"`
+	TTL     int    `json:"ttl"`
+}
+
+// NewHoverClient creates a new Hover client
+func NewHoverClient(creds ProviderCredentials) (*HoverClient, error) {
+	username, ok := creds["username"].(string)
+	if !ok || username == "" {
+		return nil, types.ErrMissingConfig
+	}
+	password, ok := creds["password"].(string)
+	if !ok || password == "" {
+		return nil, types.ErrMissingConfig
+	}
+
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cookie jar: %w", err)
+	}
+
+	return &HoverClient{
+		username: username,
+		password: password,
+		baseURL:  "https://www.hover.com/api",
+		client: &http.Client{
+			Timeout: httpTimeout(creds),
+			Jar:     jar,
+		},
+	}, nil
+}
+
+// FetchDomains retrieves all domains in the account from Hover
+func (h *HoverClient) FetchDomains() ([]types.Domain, error) {
+	var resp struct {
+		Succeeded bool           `json:"succeeded"`
+		Domains   *[]HoverDomain `json:"domains"`
+	}
+	if err := h.get("/domains", &resp); err != nil {
+		return nil, fmt.Errorf("failed to fetch domains: %w", err)
+	}
+	if !resp.Succeeded || resp.Domains == nil {
+		return nil, fmt.Errorf("failed to fetch domains: %w", types.ErrProviderUnexpectedResponse)
+	}
+
+	var domains []types.Domain
+	for _, hd := range *resp.Domains {
+		domain := types.Domain{
+			ID:        uuid.New().String(), // Generate new UUID
+			Name:      strings.ToLower(hd.DomainName),
+			Provider:  "hover",
+			AutoRenew: hd.AutoRenew,
+			Status:    h.mapStatus(hd.Status),
+			UpdatedAt: time.Now(),
+		}
+		if expires, err := time.Parse("2006-01-02", hd.ExpiresOn); err == nil {
+			domain.ExpiresAt = expires
+		}
+		if created, err := time.Parse("2006-01-02", hd.RegisteredOn); err == nil {
+			domain.RegisteredAt = registeredAt(created)
+		}
+		domains = append(domains, domain)
+	}
+
+	return domains, nil
+}
+
+// GetProviderName returns the provider name
+func (h *HoverClient) GetProviderName() string {
+	return "hover"
+}
+
+// mapStatus maps Hover domain status to internal status
+func (h *HoverClient) mapStatus(status string) string {
+	switch strings.ToLower(status) {
+	case "active":
+		return "active"
+	case "pending", "pending_transfer", "transfer_in_progress":
+		return "pending"
+	case "expired":
+		return "expired"
+	default:
+		return "unknown"
+	}
+}
+
+// FetchDNSRecords retrieves the Hover-hosted records for a domain
+func (h *HoverClient) FetchDNSRecords(domain string) ([]types.DNSRecord, error) {
+	var resp struct {
+		Succeeded bool `json:"succeeded"`
+		Domains   []struct {
+			DomainName string        `json:"domain_name"`
+			Entries    []HoverRecord `json:"entries"`
+		} `json:"domains"`
+	}
+	if err := h.get("/domains/"+url.PathEscape(domain)+"/dns", &resp); err != nil {
+		if err == types.ErrDomainNotFound {
+			// Domain isn't in the account or has no Hover-hosted zone
+			return []types.DNSRecord{}, nil
+		}
+		return nil, fmt.Errorf("failed to fetch DNS records: %w", err)
+	}
+	if !resp.Succeeded {
+		return nil, fmt.Errorf("failed to fetch DNS records: %w", types.ErrProviderUnexpectedResponse)
+	}
+
+	dnsRecords := []types.DNSRecord{}
+	for _, zone := range resp.Domains {
+		for _, hr := range zone.Entries {
+			dnsRecords = append(dnsRecords, h.convertRecord(hr))
+		}
+	}
+	return dnsRecords, nil
+}
+
+// convertRecord maps a Hover record to the internal format, splitting the priority, weight
+// and port out of MX and SRV content
+func (h *HoverClient) convertRecord(hr HoverRecord) types.DNSRecord {
+	record := types.DNSRecord{
+		ID:        uuid.New().String(), // Generate new UUID
+		Type:      strings.ToUpper(hr.Type),
+		Name:      hr.Name,
+		Value:     hr.Content,
+		TTL:       hr.TTL,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	if record.Name == "" {
+		record.Name = "@"
+	}
+
+	fields := strings.Fields(hr.Content)
+	switch record.Type {
+	case "MX":
+		if len(fields) == 2 {
+			if priority, err := strconv.Atoi(fields[0]); err == nil {
+				record.Priority = &priority
+				record.Value = fields[1]
+			}
+		}
+	case "SRV":
+		if len(fields) == 4 {
+			priority, errP := strconv.Atoi(fields[0])
+			weight, errW := strconv.Atoi(fields[1])
+			port, errPort := strconv.Atoi(fields[2])
+			if errP == nil && errW == nil && errPort == nil {
+				record.Priority = &priority
+				record.Weight = &weight
+				record.Port = &port
+				record.Value = fields[3]
+			}
+		}
+	case "TXT":
+		record.Value = types.DechunkTXT(record.Value)
+	}
+
+	return record
+}
+
+// login signs in with the account credentials; the session cookie lands in the client's jar
+func (h *HoverClient) login() error {
+	form := url.Values{"username": {h.username}, "password": {h.password}}
+	resp, err := h.client.PostForm(h.baseURL+"/login", form)
+	if err != nil {
+		return fmt.Errorf("login request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == 401 || resp.StatusCode == 403 {
+		return types.ErrProviderAuth
+	}
+
+	if resp.StatusCode == 429 {
+		return types.ErrProviderRateLimit
+	}
+
+	if resp.StatusCode != 200 {
+		return fmt.Errorf("unexpected login status code: %d", resp.StatusCode)
+	}
+
+	var result struct {
+		Succeeded bool   `json:"succeeded"`
+		Status    string `json:"status"` // need_2fa when the account has two-factor authentication
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("failed to decode login response: %w", types.ErrProviderUnexpectedResponse)
+	}
+	if result.Status == "need_2fa" {
+		return fmt.Errorf("%w: Hover accounts with two-factor authentication are not supported", types.ErrProviderAuth)
+	}
+	if !result.Succeeded {
+		return types.ErrProviderAuth
+	}
+	return nil
+}
+
+// get performs a GET request within the session, signing in first if needed and once more
+// if the session has expired, and decodes the JSON response into out
+func (h *HoverClient) get(path string, out interface{}) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for attempt := 0; ; attempt++ {
+		if !h.loggedIn {
+			if err := h.login(); err != nil {
+				return err
+			}
+			h.loggedIn = true
+		}
+
+		req, err := http.NewRequest("GET", h.baseURL+path, nil)
+		if err != nil {
+			return fmt.Errorf("failed to create request: %w", err)
+		}
+		req.Header.Set("Accept", "application/json")
+
+		resp, err := h.client.Do(req)
+		if err != nil {
+			return fmt.Errorf("request failed: %w", err)
+		}
+
+		if resp.StatusCode == 401 && attempt == 0 {
+			// Session cookie expired; sign in again and retry once
+			resp.Body.Close()
+			h.loggedIn = false
+			continue
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode == 401 || resp.StatusCode == 403 {
+			return types.ErrProviderAuth
+		}
+
+		if resp.StatusCode == 429 {
+			return types.ErrProviderRateLimit
+		}
+
+		if resp.StatusCode == 404 {
+			return types.ErrDomainNotFound
+		}
+
+		if resp.StatusCode != 200 {
+			return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+		}
+
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			return fmt.Errorf("failed to decode response: %w", types.ErrProviderUnexpectedResponse)
+		}
+		return nil
+	}
+}
@@ -1,6 +1,8 @@
 package providers
 
 import (
+	"time"
+
 	"github.com/rusiqe/domainvault/internal/types"
 )
 
@@ -15,10 +17,50 @@ type RegistrarClient interface {
 	
 	// Future hooks for MVP expansion
 	// RenewDomain(domainID string) error
-	// UpdateDNS(domain string, records []types.DNSRecord) error
 	// GetDomainInfo(domain string) (*types.Domain, error)
 }
 
+// AutoRenewManager is implemented by registrar clients that can read and change the
+// registrar-side auto-renew flag. Callers check for it with a type assertion.
+type AutoRenewManager interface {
+	GetAutoRenew(domain string) (bool, error)
+	SetAutoRenew(domain string, enabled bool) error
+}
+
+// DNSWriter is implemented by clients that can replace a domain's records at the provider.
+// Callers check for it with a type assertion, and should go through the DNS service's
+// PushRecords so the zone is backed up first.
+type DNSWriter interface {
+	ReplaceDNSRecords(domain string, records []types.DNSRecord) error
+}
+
+// ContactManager is implemented by registrar clients that can read a domain's contacts and
+// change its WHOIS privacy and registrant. Callers check for it with a type assertion.
+type ContactManager interface {
+	GetContacts(domain string) (*types.DomainContacts, error)
+	SetPrivacy(domain string, enabled bool) error
+	UpdateRegistrant(domain string, registrant types.DomainContact) error
+}
+
+// AvailabilityChecker is implemented by registrar clients and lookup services that can tell
+// whether a domain is free to register. Callers check for it with a type assertion.
+type AvailabilityChecker interface {
+	CheckAvailability(domain string) (*types.DomainAvailability, error)
+}
+
+// ExpiryLookup is implemented by lookup services that read a domain's expiry from its
+// registry rather than from a registrar account
+type ExpiryLookup interface {
+	LookupExpiry(domain string) (*types.RegistryExpiry, error)
+}
+
+// DomainStatusReader is implemented by registrar clients that can read a domain's
+// authoritative status at the registrar, as EPP status codes such as clientHold or
+// pendingTransfer. Callers check for it with a type assertion.
+type DomainStatusReader interface {
+	GetDomainStatus(domain string) ([]string, error)
+}
+
 // ProviderCredentials holds authentication data for providers
 type ProviderCredentials map[string]interface{}
 
@@ -31,6 +73,14 @@ func NewClient(provider string, creds ProviderCredentials) (RegistrarClient, err
 		return NewNamecheapClient(creds)
 	case "hostinger":
 		return NewHostingerClient(creds)
+	case "ovh":
+		return NewOVHClient(creds)
+	case "dnsimple":
+		return NewDNSimpleClient(creds)
+	case "dynadot":
+		return NewDynadotClient(creds)
+	case "hover":
+		return NewHoverClient(creds)
 	case "cloudflare":
 		return NewCloudflareClient(creds)
 	case "mock":
@@ -61,6 +111,27 @@ func ValidateCredentials(provider string, creds ProviderCredentials) error {
 		if _, ok := creds["api_key"]; !ok {
 			return types.ErrMissingConfig
 		}
+	case "ovh":
+		for _, field := range []string{"application_key", "application_secret", "consumer_key"} {
+			if _, ok := creds[field]; !ok {
+				return types.ErrMissingConfig
+			}
+		}
+	case "dnsimple":
+		if _, ok := creds["access_token"]; !ok {
+			return types.ErrMissingConfig
+		}
+	case "dynadot":
+		if _, ok := creds["api_key"]; !ok {
+			return types.ErrMissingConfig
+		}
+	case "hover":
+		if _, ok := creds["username"]; !ok {
+			return types.ErrMissingConfig
+		}
+		if _, ok := creds["password"]; !ok {
+			return types.ErrMissingConfig
+		}
 	case "cloudflare":
 		if _, ok := creds["api_token"]; !ok {
 			return types.ErrMissingConfig
@@ -73,3 +144,13 @@ func ValidateCredentials(provider string, creds ProviderCredentials) error {
 	}
 	return nil
 }
+
+// registeredAt converts a registrar's creation date to a Domain's RegisteredAt, nil when the
+// registrar didn't report one
+func registeredAt(created time.Time) *time.Time {
+	if created.IsZero() {
+		return nil
+	}
+	created = created.UTC()
+	return &created
+}
@@ -1,6 +1,7 @@
 package providers
 
 import (
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
@@ -11,6 +12,16 @@ import (
 type MockClient struct {
 	name    string
 	domains []types.Domain
+
+	autoRenewMu sync.Mutex
+	autoRenew   map[string]bool // Registrar-side auto-renew flags; unset domains report false
+
+	contactsMu  sync.Mutex
+	privacy     map[string]bool
+	registrants map[string]types.DomainContact
+
+	zonesMu sync.Mutex
+	zones   map[string][]types.DNSRecord // Zones written with ReplaceDNSRecords; others are generated
 }
 
 // NewMockClient creates a mock provider client
@@ -19,34 +30,35 @@ func NewMockClient(creds ProviderCredentials) (*MockClient, error) {
 	now := time.Now()
 	domains := []types.Domain{
 		{
-			ID:        uuid.New().String(),
-			Name:      "example.com",
-			Provider:  "mock",
-			ExpiresAt: now.AddDate(1, 0, 0), // 1 year from now
-			CreatedAt: now.AddDate(-1, 0, 0), // 1 year ago
-			UpdatedAt: now,
+			ID:           uuid.New().String(),
+			Name:         "example.com",
+			Provider:     "mock",
+			ExpiresAt:    now.AddDate(1, 0, 0),                // 1 year from now
+			RegisteredAt: registeredAt(now.AddDate(-1, 0, 0)), // 1 year ago
+			UpdatedAt:    now,
 		},
 		{
-			ID:        uuid.New().String(),
-			Name:      "test.org",
-			Provider:  "mock",
-			ExpiresAt: now.AddDate(0, 1, 0), // 1 month from now
-			CreatedAt: now.AddDate(-2, 0, 0), // 2 years ago
-			UpdatedAt: now,
+			ID:           uuid.New().String(),
+			Name:         "test.org",
+			Provider:     "mock",
+			ExpiresAt:    now.AddDate(0, 1, 0),                // 1 month from now
+			RegisteredAt: registeredAt(now.AddDate(-2, 0, 0)), // 2 years ago
+			UpdatedAt:    now,
 		},
 		{
-			ID:        uuid.New().String(),
-			Name:      "demo.net",
-			Provider:  "mock",
-			ExpiresAt: now.AddDate(0, 0, 30), // 30 days from now
-			CreatedAt: now.AddDate(0, -6, 0), // 6 months ago
-			UpdatedAt: now,
+			ID:           uuid.New().String(),
+			Name:         "demo.net",
+			Provider:     "mock",
+			ExpiresAt:    now.AddDate(0, 0, 30),               // 30 days from now
+			RegisteredAt: registeredAt(now.AddDate(0, -6, 0)), // 6 months ago
+			UpdatedAt:    now,
 		},
 	}
 
 	return &MockClient{
-		name:    "mock",
-		domains: domains,
+		name:      "mock",
+		domains:   domains,
+		autoRenew: make(map[string]bool),
 	}, nil
 }
 
@@ -72,6 +84,13 @@ func (m *MockClient) FetchDNSRecords(domain string) ([]types.DNSRecord, error) {
 	// Simulate API delay
 	time.Sleep(50 * time.Millisecond)
 	
+	m.zonesMu.Lock()
+	zone, written := m.zones[domain]
+	m.zonesMu.Unlock()
+	if written {
+		return append([]types.DNSRecord(nil), zone...), nil
+	}
+	
 	now := time.Now()
 	
 	// Generate mock DNS records based on domain name
@@ -138,6 +157,93 @@ func (m *MockClient) FetchDNSRecords(domain string) ([]types.DNSRecord, error) {
 	return records, nil
 }
 
+// ReplaceDNSRecords replaces the mock zone of a domain; later fetches return these records
+func (m *MockClient) ReplaceDNSRecords(domain string, records []types.DNSRecord) error {
+	m.zonesMu.Lock()
+	defer m.zonesMu.Unlock()
+	if m.zones == nil {
+		m.zones = make(map[string][]types.DNSRecord)
+	}
+	m.zones[domain] = append([]types.DNSRecord(nil), records...)
+	return nil
+}
+
+// GetAutoRenew returns the mock registrar-side auto-renew flag
+func (m *MockClient) GetAutoRenew(domain string) (bool, error) {
+	m.autoRenewMu.Lock()
+	defer m.autoRenewMu.Unlock()
+	return m.autoRenew[domain], nil
+}
+
+// SetAutoRenew sets the mock registrar-side auto-renew flag
+func (m *MockClient) SetAutoRenew(domain string, enabled bool) error {
+	m.autoRenewMu.Lock()
+	defer m.autoRenewMu.Unlock()
+	if m.autoRenew == nil {
+		m.autoRenew = make(map[string]bool)
+	}
+	m.autoRenew[domain] = enabled
+	return nil
+}
+
+// GetContacts returns the mock registrant and WHOIS privacy flag of a domain
+func (m *MockClient) GetContacts(domain string) (*types.DomainContacts, error) {
+	m.contactsMu.Lock()
+	defer m.contactsMu.Unlock()
+
+	contacts := &types.DomainContacts{Domain: domain, Privacy: m.privacy[domain]}
+	if registrant, ok := m.registrants[domain]; ok {
+		contacts.Registrant = &registrant
+	}
+	return contacts, nil
+}
+
+// SetPrivacy sets the mock WHOIS privacy flag
+func (m *MockClient) SetPrivacy(domain string, enabled bool) error {
+	m.contactsMu.Lock()
+	defer m.contactsMu.Unlock()
+	if m.privacy == nil {
+		m.privacy = make(map[string]bool)
+	}
+	m.privacy[domain] = enabled
+	return nil
+}
+
+// UpdateRegistrant sets the mock registrant contact
+func (m *MockClient) UpdateRegistrant(domain string, registrant types.DomainContact) error {
+	m.contactsMu.Lock()
+	defer m.contactsMu.Unlock()
+	if m.registrants == nil {
+		m.registrants = make(map[string]types.DomainContact)
+	}
+	m.registrants[domain] = registrant
+	return nil
+}
+
+// GetDomainStatus reports every mock domain as active with its transfer lock on
+func (m *MockClient) GetDomainStatus(domain string) ([]string, error) {
+	return []string{types.RegistrarStatusOK, types.RegistrarStatusClientTransferProhibited}, nil
+}
+
+// mockRegistrationPrice is what the mock provider charges to register a free domain
+const mockRegistrationPrice = 12.99
+
+// CheckAvailability reports a domain as taken when it's one of the mock provider's domains
+// and free at a fixed price otherwise
+func (m *MockClient) CheckAvailability(domain string) (*types.DomainAvailability, error) {
+	availability := &types.DomainAvailability{Domain: domain, Available: true, Source: m.name}
+	for _, d := range m.domains {
+		if types.NormalizeDomainName(d.Name) == types.NormalizeDomainName(domain) {
+			availability.Available = false
+			return availability, nil
+		}
+	}
+	price := mockRegistrationPrice
+	availability.Price = &price
+	availability.Currency = "USD"
+	return availability, nil
+}
+
 // AddMockDomain adds a domain to the mock provider (for testing)
 func (m *MockClient) AddMockDomain(domain types.Domain) {
 	domain.Provider = m.name
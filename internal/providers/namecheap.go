@@ -65,9 +65,7 @@ func NewNamecheapClient(creds ProviderCredentials) (*NamecheapClient, error) {
 		apiKey:   apiKey,
 		username: username,
 		baseURL:  "https://api.namecheap.com/xml.response",
-		client: &http.Client{
-			Timeout: 30 * time.Second,
-		},
+		client:   newHTTPClient(creds),
 	}, nil
 }
 
@@ -92,6 +90,10 @@ func (n *NamecheapClient) FetchDomains() ([]types.Domain, error) {
 		return nil, types.ErrProviderAuth
 	}
 	
+	if resp.StatusCode == 429 {
+		return nil, types.ErrProviderRateLimit
+	}
+
 	if resp.StatusCode != 200 {
 		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
 	}
@@ -116,12 +118,12 @@ func (n *NamecheapClient) FetchDomains() ([]types.Domain, error) {
 		expiresAt, _ := time.Parse("01/02/2006", nd.Expires)
 		
 		domains[i] = types.Domain{
-			ID:        uuid.New().String(), // Generate new UUID
-			Name:      nd.Name,
-			Provider:  "namecheap",
-			ExpiresAt: expiresAt,
-			CreatedAt: createdAt,
-			UpdatedAt: time.Now(),
+			ID:           uuid.New().String(), // Generate new UUID
+			Name:         nd.Name,
+			Provider:     "namecheap",
+			ExpiresAt:    expiresAt,
+			RegisteredAt: registeredAt(createdAt),
+			UpdatedAt:    time.Now(),
 		}
 	}
 
@@ -174,6 +176,10 @@ func (n *NamecheapClient) FetchDNSRecords(domain string) ([]types.DNSRecord, err
 		return nil, types.ErrProviderAuth
 	}
 	
+	if resp.StatusCode == 429 {
+		return nil, types.ErrProviderRateLimit
+	}
+
 	if resp.StatusCode != 200 {
 		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
 	}
@@ -212,8 +218,8 @@ func (n *NamecheapClient) FetchDNSRecords(domain string) ([]types.DNSRecord, err
 			UpdatedAt: time.Now(),
 		}
 		
-		// Handle priority for MX records
-		if hr.Priority != nil {
+		// Namecheap returns an MXPref on every host; it's only a priority for MX records
+		if hr.Priority != nil && strings.EqualFold(hr.Type, "MX") {
 			dnsRecord.Priority = hr.Priority
 		}
 		
@@ -223,6 +229,79 @@ func (n *NamecheapClient) FetchDNSRecords(domain string) ([]types.DNSRecord, err
 	return dnsRecords, nil
 }
 
+// GetDomainStatus reads a domain's status at Namecheap, translated to EPP status codes
+func (n *NamecheapClient) GetDomainStatus(domain string) ([]string, error) {
+	params := url.Values{}
+	params.Set("ApiUser", n.username)
+	params.Set("ApiKey", n.apiKey)
+	params.Set("UserName", n.username)
+	params.Set("Command", "namecheap.domains.getInfo")
+	params.Set("ClientIp", "127.0.0.1")
+	params.Set("DomainName", domain)
+
+	resp, err := n.client.Get(fmt.Sprintf("%s?%s", n.baseURL, params.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch domain info: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == 401 {
+		return nil, types.ErrProviderAuth
+	}
+	if resp.StatusCode == 429 {
+		return nil, types.ErrProviderRateLimit
+	}
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	var ncResponse struct {
+		Status          string `xml:"Status,attr"`
+		CommandResponse struct {
+			DomainGetInfoResult struct {
+				Status string `xml:"Status,attr"`
+			} `xml:"DomainGetInfoResult"`
+		} `xml:"CommandResponse"`
+		Errors []NamecheapError `xml:"Errors>Error"`
+	}
+	if err := xml.NewDecoder(resp.Body).Decode(&ncResponse); err != nil {
+		return nil, fmt.Errorf("failed to decode domain info response: %w", err)
+	}
+
+	if ncResponse.Status != "OK" {
+		if len(ncResponse.Errors) > 0 {
+			return nil, fmt.Errorf("namecheap domain info API error: %s", ncResponse.Errors[0].Description)
+		}
+		return nil, fmt.Errorf("unknown namecheap domain info API error")
+	}
+
+	return []string{namecheapStatusCode(ncResponse.CommandResponse.DomainGetInfoResult.Status)}, nil
+}
+
+// namecheapStatusCode maps Namecheap's domain status (Ok, Locked, Expired, ...) to the nearest
+// EPP status code. Statuses with no equivalent are kept, lowercased.
+func namecheapStatusCode(status string) string {
+	status = strings.ToLower(strings.TrimSpace(status))
+	switch {
+	case status == "ok" || status == "active":
+		return types.RegistrarStatusOK
+	case status == "locked":
+		return types.RegistrarStatusClientTransferProhibited
+	case strings.Contains(status, "redemption"):
+		return types.RegistrarStatusRedemptionPeriod
+	case strings.Contains(status, "hold") || strings.Contains(status, "suspend"):
+		return types.RegistrarStatusClientHold
+	case strings.Contains(status, "delet"):
+		return types.RegistrarStatusPendingDelete
+	case strings.Contains(status, "transfer"):
+		return types.RegistrarStatusPendingTransfer
+	case strings.Contains(status, "expired"):
+		return types.RegistrarStatusExpired
+	default:
+		return status
+	}
+}
+
 // Future implementations for MVP expansion:
 // func (n *NamecheapClient) RenewDomain(domainID string) error { ... }
 // func (n *NamecheapClient) UpdateDNS(domain string, records []types.DNSRecord) error { ... }
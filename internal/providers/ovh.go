@@ -0,0 +1,299 @@
+package providers
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rusiqe/domainvault/internal/types"
+)
+
+// ovhEndpoints maps OVH API region names to base URLs
+var ovhEndpoints = map[string]string{
+	"ovh-eu": "https://eu.api.ovh.com/1.0",
+	"ovh-ca": "https://ca.api.ovh.com/1.0",
+	"ovh-us": "https://api.us.ovhcloud.com/1.0",
+}
+
+// OVHClient implements RegistrarClient for the OVH API
+// API docs: https://api.ovh.com/console/
+// Requests are signed with the application secret and consumer key:
+// "$1$" + SHA1(AS+CK+METHOD+URL+BODY+TIMESTAMP), joined with "+"
+type OVHClient struct {
+	appKey      string
+	appSecret   string
+	consumerKey string
+	baseURL     string
+	client      *http.Client
+
+	timeDelta     int64 // OVH server time minus local time, in seconds
+	timeDeltaOnce sync.Once
+}
+
+// OVHServiceInfos represents /domain/{domain}/serviceInfos from OVH API
+type OVHServiceInfos struct {
+	Expiration string `json:"expiration"` // YYYY-MM-DD
+	Creation   string `json:"creation"`   // YYYY-MM-DD
+	Status     string `json:"status"`     // ok, expired, inCreation, unPaid, pendingDebt, unRenewed
+	Renew      struct {
+		Automatic bool `json:"automatic"`
+	} `json:"renew"`
+}
+
+// OVHDNSRecord represents /domain/zone/{zone}/record/{id} from OVH API
+type OVHDNSRecord struct {
+	ID        int64  `json:"id"`
+	Zone      string `json:"zone"`
+	FieldType string `json:"fieldType"`
+	SubDomain string `json:"subDomain"`
+	Target    string `json:"target"`
+	TTL       int    `json:"ttl"` // 0 means the zone default
+}
+
+// NewOVHClient creates a new OVH client
+func NewOVHClient(creds ProviderCredentials) (*OVHClient, error) {
+	appKey, ok := creds["application_key"].(string)
+	if !ok || appKey == "" {
+		return nil, types.ErrMissingConfig
+	}
+
+	appSecret, ok := creds["application_secret"].(string)
+	if !ok || appSecret == "" {
+		return nil, types.ErrMissingConfig
+	}
+
+	consumerKey, ok := creds["consumer_key"].(string)
+	if !ok || consumerKey == "" {
+		return nil, types.ErrMissingConfig
+	}
+
+	// Endpoint may be a region name or a full base URL; default to Europe
+	baseURL := ovhEndpoints["ovh-eu"]
+	if endpoint, ok := creds["endpoint"].(string); ok && endpoint != "" {
+		if known, exists := ovhEndpoints[endpoint]; exists {
+			baseURL = known
+		} else {
+			baseURL = strings.TrimSuffix(endpoint, "/")
+		}
+	}
+
+	return &OVHClient{
+		appKey:      appKey,
+		appSecret:   appSecret,
+		consumerKey: consumerKey,
+		baseURL:     baseURL,
+		client:      newHTTPClient(creds),
+	}, nil
+}
+
+// FetchDomains retrieves domains from OVH API, with expiry from each domain's serviceInfos
+func (o *OVHClient) FetchDomains() ([]types.Domain, error) {
+	var names []string
+	if err := o.get("/domain", &names); err != nil {
+		return nil, fmt.Errorf("failed to fetch domains: %w", err)
+	}
+
+	domains := make([]types.Domain, 0, len(names))
+	for _, name := range names {
+		var info OVHServiceInfos
+		if err := o.get(fmt.Sprintf("/domain/%s/serviceInfos", url.PathEscape(name)), &info); err != nil {
+			return nil, fmt.Errorf("failed to fetch service info for %s: %w", name, err)
+		}
+
+		domain := types.Domain{
+			ID:        uuid.New().String(), // Generate new UUID
+			Name:      name,
+			Provider:  "ovh",
+			AutoRenew: info.Renew.Automatic,
+			Status:    o.mapStatus(info.Status),
+			UpdatedAt: time.Now(),
+		}
+		if expires, err := time.Parse("2006-01-02", info.Expiration); err == nil {
+			domain.ExpiresAt = expires
+		}
+		if created, err := time.Parse("2006-01-02", info.Creation); err == nil {
+			domain.RegisteredAt = registeredAt(created)
+		}
+
+		domains = append(domains, domain)
+	}
+
+	return domains, nil
+}
+
+// GetProviderName returns the provider name
+func (o *OVHClient) GetProviderName() string {
+	return "ovh"
+}
+
+// mapStatus maps OVH service status to internal status
+func (o *OVHClient) mapStatus(ovhStatus string) string {
+	switch ovhStatus {
+	case "ok":
+		return "active"
+	case "expired":
+		return "expired"
+	case "inCreation":
+		return "pending"
+	case "unPaid", "pendingDebt", "unRenewed":
+		return "pending_renewal"
+	default:
+		return "unknown"
+	}
+}
+
+// FetchDNSRecords retrieves DNS records for a zone from OVH API
+func (o *OVHClient) FetchDNSRecords(domain string) ([]types.DNSRecord, error) {
+	zone := url.PathEscape(domain)
+
+	var ids []int64
+	if err := o.get(fmt.Sprintf("/domain/zone/%s/record", zone), &ids); err != nil {
+		if err == types.ErrDomainNotFound {
+			// Domain has no OVH-hosted zone
+			return []types.DNSRecord{}, nil
+		}
+		return nil, fmt.Errorf("failed to fetch DNS records: %w", err)
+	}
+
+	dnsRecords := make([]types.DNSRecord, 0, len(ids))
+	for _, id := range ids {
+		var or OVHDNSRecord
+		if err := o.get(fmt.Sprintf("/domain/zone/%s/record/%d", zone, id), &or); err != nil {
+			return nil, fmt.Errorf("failed to fetch DNS record %d: %w", id, err)
+		}
+		dnsRecords = append(dnsRecords, o.convertRecord(or))
+	}
+
+	return dnsRecords, nil
+}
+
+// convertRecord maps an OVH record to the internal format. OVH stores MX and SRV
+// parameters inside the target, and has TXT variants (SPF, DKIM, DMARC) as separate types.
+func (o *OVHClient) convertRecord(or OVHDNSRecord) types.DNSRecord {
+	record := types.DNSRecord{
+		ID:        uuid.New().String(), // Generate new UUID
+		Type:      strings.ToUpper(or.FieldType),
+		Name:      or.SubDomain,
+		Value:     or.Target,
+		TTL:       or.TTL,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	if record.Name == "" {
+		record.Name = "@"
+	}
+	if record.TTL == 0 {
+		record.TTL = 3600 // OVH zone default
+	}
+
+	switch record.Type {
+	case "SPF", "DKIM", "DMARC":
+		record.Type = "TXT"
+		record.Value = types.DechunkTXT(record.Value)
+	case "TXT":
+		record.Value = types.DechunkTXT(record.Value)
+	case "MX":
+		// "10 mx1.mail.ovh.net."
+		if fields := strings.Fields(or.Target); len(fields) == 2 {
+			if priority, err := strconv.Atoi(fields[0]); err == nil {
+				record.Priority = &priority
+				record.Value = fields[1]
+			}
+		}
+	case "SRV":
+		// "priority weight port target"
+		if fields := strings.Fields(or.Target); len(fields) == 4 {
+			priority, errP := strconv.Atoi(fields[0])
+			weight, errW := strconv.Atoi(fields[1])
+			port, errPort := strconv.Atoi(fields[2])
+			if errP == nil && errW == nil && errPort == nil {
+				record.Priority = &priority
+				record.Weight = &weight
+				record.Port = &port
+				record.Value = fields[3]
+			}
+		}
+	}
+
+	return record
+}
+
+// get performs a signed GET request and decodes the JSON response into out
+func (o *OVHClient) get(path string, out interface{}) error {
+	fullURL := o.baseURL + path
+
+	req, err := http.NewRequest("GET", fullURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix()+o.serverTimeDelta(), 10)
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("X-Ovh-Application", o.appKey)
+	req.Header.Set("X-Ovh-Consumer", o.consumerKey)
+	req.Header.Set("X-Ovh-Timestamp", timestamp)
+	req.Header.Set("X-Ovh-Signature", o.sign("GET", fullURL, "", timestamp))
+
+	resp, err := o.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == 401 || resp.StatusCode == 403 {
+		return types.ErrProviderAuth
+	}
+
+	if resp.StatusCode == 429 {
+		return types.ErrProviderRateLimit
+	}
+
+	if resp.StatusCode == 404 {
+		return types.ErrDomainNotFound
+	}
+
+	if resp.StatusCode != 200 {
+		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+// sign computes the X-Ovh-Signature header value
+func (o *OVHClient) sign(method, fullURL, body, timestamp string) string {
+	sum := sha1.Sum([]byte(strings.Join([]string{o.appSecret, o.consumerKey, method, fullURL, body, timestamp}, "+")))
+	return "$1$" + hex.EncodeToString(sum[:])
+}
+
+// serverTimeDelta returns the offset between OVH's clock and ours, fetched once from /auth/time.
+// OVH rejects signatures whose timestamp drifts too far, so local clock skew must be corrected.
+func (o *OVHClient) serverTimeDelta() int64 {
+	o.timeDeltaOnce.Do(func() {
+		resp, err := o.client.Get(o.baseURL + "/auth/time")
+		if err != nil {
+			return
+		}
+		defer resp.Body.Close()
+
+		var serverTime int64
+		if resp.StatusCode == 200 && json.NewDecoder(resp.Body).Decode(&serverTime) == nil {
+			o.timeDelta = serverTime - time.Now().Unix()
+		}
+	})
+	return o.timeDelta
+}
+
+// Future implementations for MVP expansion:
+// func (o *OVHClient) RenewDomain(domainID string) error { ... }
+// func (o *OVHClient) UpdateDNS(domain string, records []types.DNSRecord) error { ... }
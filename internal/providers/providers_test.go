@@ -1,9 +1,18 @@
 package providers
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/rusiqe/domainvault/internal/storage"
 	"github.com/rusiqe/domainvault/internal/types"
 )
 
@@ -51,6 +60,46 @@ func TestNewClient(t *testing.T) {
 			wantErr:  nil,
 			wantType: "*providers.HostingerClient",
 		},
+		{
+			name:     "create ovh client",
+			provider: "ovh",
+			creds: ProviderCredentials{
+				"application_key":    "test-ak",
+				"application_secret": "test-as",
+				"consumer_key":       "test-ck",
+			},
+			wantErr:  nil,
+			wantType: "*providers.OVHClient",
+		},
+		{
+			name:     "create dnsimple client",
+			provider: "dnsimple",
+			creds: ProviderCredentials{
+				"access_token": "test-token",
+				"environment":  "sandbox",
+			},
+			wantErr:  nil,
+			wantType: "*providers.DNSimpleClient",
+		},
+		{
+			name:     "create dynadot client",
+			provider: "dynadot",
+			creds: ProviderCredentials{
+				"api_key": "test-key",
+			},
+			wantErr:  nil,
+			wantType: "*providers.DynadotClient",
+		},
+		{
+			name:     "create hover client",
+			provider: "hover",
+			creds: ProviderCredentials{
+				"username": "owner",
+				"password": "secret",
+			},
+			wantErr:  nil,
+			wantType: "*providers.HoverClient",
+		},
 		{
 			name:     "unsupported provider",
 			provider: "unsupported",
@@ -178,6 +227,37 @@ func TestValidateCredentials(t *testing.T) {
 			creds:    ProviderCredentials{},
 			wantErr:  types.ErrMissingConfig,
 		},
+		{
+			name:     "valid ovh credentials",
+			provider: "ovh",
+			creds: ProviderCredentials{
+				"application_key":    "test-ak",
+				"application_secret": "test-as",
+				"consumer_key":       "test-ck",
+			},
+			wantErr: nil,
+		},
+		{
+			name:     "ovh missing consumer_key",
+			provider: "ovh",
+			creds: ProviderCredentials{
+				"application_key":    "test-ak",
+				"application_secret": "test-as",
+			},
+			wantErr: types.ErrMissingConfig,
+		},
+		{
+			name:     "dnsimple missing access_token",
+			provider: "dnsimple",
+			creds:    ProviderCredentials{"account_id": "1385"},
+			wantErr:  types.ErrMissingConfig,
+		},
+		{
+			name:     "hover missing password",
+			provider: "hover",
+			creds:    ProviderCredentials{"username": "owner"},
+			wantErr:  types.ErrMissingConfig,
+		},
 		{
 			name:     "mock credentials (no validation)",
 			provider: "mock",
@@ -341,3 +421,871 @@ func TestMockClient_SimulatedDelay(t *testing.T) {
 		t.Errorf("FetchDomains() took too long: %v", duration)
 	}
 }
+
+func TestCapabilities_ValidateRecord(t *testing.T) {
+	caps, ok := GetCapabilities("Hostinger")
+	if !ok {
+		t.Fatal("GetCapabilities(\"Hostinger\") returned no capabilities")
+	}
+
+	tests := []struct {
+		name    string
+		record  types.DNSRecord
+		wantErr bool
+	}{
+		{name: "TTL within range", record: types.DNSRecord{Type: "A", TTL: 3600}, wantErr: false},
+		{name: "TTL below minimum", record: types.DNSRecord{Type: "A", TTL: 60}, wantErr: true},
+		{name: "TTL above maximum", record: types.DNSRecord{Type: "A", TTL: 172800}, wantErr: true},
+		{name: "unsupported type", record: types.DNSRecord{Type: "PTR", TTL: 3600}, wantErr: true},
+		{name: "unsupported ALIAS", record: types.DNSRecord{Type: "ALIAS", TTL: 3600}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := caps.ValidateRecord(tt.record)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateRecord() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	if err := caps.ValidateRecord(types.DNSRecord{Type: "ALIAS", TTL: 3600}); !errors.Is(err, types.ErrUnsupportedRecordType) {
+		t.Errorf("ValidateRecord(ALIAS) error = %v, want ErrUnsupportedRecordType", err)
+	}
+	if dnsimple, _ := GetCapabilities("dnsimple"); !dnsimple.SupportsRecordType("alias") {
+		t.Error("dnsimple should support ALIAS records")
+	}
+
+	if err := caps.ValidateRecordCount(caps.MaxRecordsPerZone + 1); err == nil {
+		t.Error("ValidateRecordCount() expected error above zone limit")
+	}
+	if _, ok := GetCapabilities("unknown"); ok {
+		t.Error("GetCapabilities(\"unknown\") should not return capabilities")
+	}
+}
+
+func TestProviderService_ReconcileAutoRenew(t *testing.T) {
+	client, err := NewMockClient(ProviderCredentials{})
+	if err != nil {
+		t.Fatalf("Failed to create mock client: %v", err)
+	}
+	svc := NewProviderService()
+	svc.RegisterClient("mock", client)
+
+	domain := types.Domain{ID: "d1", Name: "example.com", Provider: "mock", AutoRenew: true}
+
+	report, err := svc.ReconcileAutoRenew(domain, false)
+	if err != nil {
+		t.Fatalf("ReconcileAutoRenew() unexpected error: %v", err)
+	}
+	if !report.Drift || !report.AtRisk || report.Pushed {
+		t.Errorf("Expected at-risk drift without push, got %+v", report)
+	}
+
+	report, err = svc.ReconcileAutoRenew(domain, true)
+	if err != nil {
+		t.Fatalf("ReconcileAutoRenew(push) unexpected error: %v", err)
+	}
+	if !report.Pushed || !report.RemoteAutoRenew {
+		t.Errorf("Expected auto-renew to be pushed, got %+v", report)
+	}
+
+	if enabled, _ := client.GetAutoRenew("example.com"); !enabled {
+		t.Error("Mock registrar auto-renew should be enabled after push")
+	}
+}
+
+func TestProviderService_DomainContacts(t *testing.T) {
+	client, err := NewMockClient(ProviderCredentials{})
+	if err != nil {
+		t.Fatalf("Failed to create mock client: %v", err)
+	}
+	svc := NewProviderService()
+	svc.RegisterClient("mock", client)
+
+	domain := types.Domain{ID: "d1", Name: "example.com", Provider: "mock"}
+	privacy := true
+	registrant := types.DomainContact{FirstName: "Ada", LastName: "Lovelace", Email: "ada@example.com"}
+
+	contacts, err := svc.UpdateDomainContacts(domain, types.DomainContactsUpdate{Privacy: &privacy, Registrant: &registrant})
+	if err != nil {
+		t.Fatalf("UpdateDomainContacts() unexpected error: %v", err)
+	}
+	if !contacts.Privacy || contacts.Registrant == nil || contacts.Registrant.Email != "ada@example.com" {
+		t.Errorf("Expected privacy on and the new registrant, got %+v", contacts)
+	}
+	if contacts.DomainID != "d1" || contacts.Provider != "mock" {
+		t.Errorf("Expected contacts to identify the domain, got %+v", contacts)
+	}
+
+	if _, err := svc.GetDomainContacts(types.Domain{Name: "example.com", Provider: "unconnected"}); err == nil {
+		t.Error("Expected an error for a provider with no connected client")
+	}
+}
+
+func TestGoDaddyClient_Contacts(t *testing.T) {
+	var patched map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/domains/example.com":
+			w.Write([]byte(`{"domain":"example.com","privacy":false,"exposeWhois":false,
+				"contactRegistrant":{"nameFirst":"Ada","nameLast":"Lovelace","email":"ada@example.com",
+				"addressMailing":{"city":"London","country":"GB"}}}`))
+		case r.Method == http.MethodPatch && r.URL.Path == "/domains/example.com":
+			json.NewDecoder(r.Body).Decode(&patched)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := &GoDaddyClient{apiKey: "key", apiSecret: "secret", baseURL: server.URL, client: server.Client()}
+
+	contacts, err := client.GetContacts("example.com")
+	if err != nil {
+		t.Fatalf("GetContacts() unexpected error: %v", err)
+	}
+	if !contacts.Privacy {
+		t.Error("exposeWhois=false should report privacy enabled")
+	}
+	if contacts.Registrant == nil || contacts.Registrant.City != "London" || contacts.Admin != nil {
+		t.Errorf("Unexpected contacts: %+v", contacts)
+	}
+
+	if err := client.SetPrivacy("example.com", true); err != nil {
+		t.Fatalf("SetPrivacy() unexpected error: %v", err)
+	}
+	if patched["exposeWhois"] != false {
+		t.Errorf("SetPrivacy(true) should send exposeWhois=false, sent %v", patched)
+	}
+
+	if _, err := client.GetContacts("missing.com"); !errors.Is(err, types.ErrDomainNotFound) {
+		t.Errorf("GetContacts(missing) error = %v, want ErrDomainNotFound", err)
+	}
+}
+
+func TestGoDaddyClient_GetDomainStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/domains/locked.com":
+			w.Write([]byte(`{"domain":"locked.com","status":"ACTIVE","locked":true}`))
+		case "/domains/held.com":
+			w.Write([]byte(`{"domain":"held.com","status":"HELD_DISPUTED","locked":false}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := &GoDaddyClient{apiKey: "key", apiSecret: "secret", baseURL: server.URL, client: server.Client()}
+
+	tests := map[string][]string{
+		"locked.com": {types.RegistrarStatusOK, types.RegistrarStatusClientTransferProhibited},
+		"held.com":   {types.RegistrarStatusClientHold},
+	}
+	for domain, want := range tests {
+		got, err := client.GetDomainStatus(domain)
+		if err != nil {
+			t.Fatalf("GetDomainStatus(%s) unexpected error: %v", domain, err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("GetDomainStatus(%s) = %v, want %v", domain, got, want)
+		}
+	}
+
+	if _, err := client.GetDomainStatus("missing.com"); !errors.Is(err, types.ErrDomainNotFound) {
+		t.Errorf("GetDomainStatus(missing) error = %v, want ErrDomainNotFound", err)
+	}
+}
+
+func TestOVHClient_convertRecord(t *testing.T) {
+	client := &OVHClient{}
+
+	mx := client.convertRecord(OVHDNSRecord{FieldType: "MX", SubDomain: "", Target: "10 mx1.mail.ovh.net.", TTL: 0})
+	if mx.Name != "@" || mx.TTL != 3600 || mx.Value != "mx1.mail.ovh.net." || mx.Priority == nil || *mx.Priority != 10 {
+		t.Errorf("Unexpected MX conversion: %+v", mx)
+	}
+
+	srv := client.convertRecord(OVHDNSRecord{FieldType: "SRV", SubDomain: "_sip._tcp", Target: "1 5 5060 sip.example.com.", TTL: 300})
+	if srv.Port == nil || *srv.Port != 5060 || srv.Weight == nil || *srv.Weight != 5 || srv.Value != "sip.example.com." {
+		t.Errorf("Unexpected SRV conversion: %+v", srv)
+	}
+
+	spf := client.convertRecord(OVHDNSRecord{FieldType: "SPF", Target: `"v=spf1 include:mx.ovh.com ~all"`, TTL: 600})
+	if spf.Type != "TXT" || spf.Value != "v=spf1 include:mx.ovh.com ~all" {
+		t.Errorf("Unexpected SPF conversion: %+v", spf)
+	}
+}
+
+func TestDNSimpleClient_convertRecord(t *testing.T) {
+	client := &DNSimpleClient{}
+	priority := 10
+
+	mx := client.convertRecord(DNSimpleRecord{Type: "MX", Name: "", Content: "mx.example.com", TTL: 3600, Priority: &priority})
+	if mx.Name != "@" || mx.Value != "mx.example.com" || mx.Priority == nil || *mx.Priority != 10 {
+		t.Errorf("Unexpected MX conversion: %+v", mx)
+	}
+
+	srv := client.convertRecord(DNSimpleRecord{Type: "SRV", Name: "_sip._tcp", Content: "5 5060 sip.example.com", TTL: 600, Priority: &priority})
+	if srv.Priority == nil || *srv.Priority != 10 || srv.Weight == nil || *srv.Weight != 5 ||
+		srv.Port == nil || *srv.Port != 5060 || srv.Value != "sip.example.com" {
+		t.Errorf("Unexpected SRV conversion: %+v", srv)
+	}
+}
+
+func TestProviderService_PerProviderAutoSync(t *testing.T) {
+	client, err := NewMockClient(ProviderCredentials{})
+	if err != nil {
+		t.Fatalf("Failed to create mock client: %v", err)
+	}
+	svc := NewProviderService()
+	cp := svc.RegisterClient("mock", client)
+
+	nextSync := func() interface{} {
+		for _, p := range svc.GetAutoSyncStatus()["providers"].([]map[string]interface{}) {
+			if p["id"] == cp.ID {
+				return p["next_sync_time"]
+			}
+		}
+		return nil
+	}
+
+	if err := svc.UpdateConnectedProvider(cp.ID, map[string]interface{}{"auto_sync_enabled": true}); err != nil {
+		t.Fatalf("UpdateConnectedProvider() unexpected error: %v", err)
+	}
+	if nextSync() != nil {
+		t.Error("Provider should not be scheduled before the scheduler starts")
+	}
+
+	svc.StartAutoSync(func(_ string, c RegistrarClient) ([]types.Domain, error) { return c.FetchDomains() })
+	defer svc.StopAutoSync()
+
+	next, ok := nextSync().(time.Time)
+	if !ok || time.Until(next) < 23*time.Hour {
+		t.Errorf("Expected next sync about 24h out, got %v", nextSync())
+	}
+
+	if err := svc.UpdateConnectedProvider(cp.ID, map[string]interface{}{"sync_interval_hours": float64(2)}); err != nil {
+		t.Fatalf("UpdateConnectedProvider() unexpected error: %v", err)
+	}
+	next, ok = nextSync().(time.Time)
+	if !ok || time.Until(next) > 2*time.Hour {
+		t.Errorf("Expected next sync within 2h after interval change, got %v", nextSync())
+	}
+
+	if err := svc.UpdateConnectedProvider(cp.ID, map[string]interface{}{"auto_sync_enabled": false}); err != nil {
+		t.Fatalf("UpdateConnectedProvider() unexpected error: %v", err)
+	}
+	if nextSync() != nil {
+		t.Error("Provider should be unscheduled after disabling auto-sync")
+	}
+}
+
+func TestProviderService_SyncProviderAccountRef(t *testing.T) {
+	client, err := NewMockClient(ProviderCredentials{})
+	if err != nil {
+		t.Fatalf("Failed to create mock client: %v", err)
+	}
+	svc := NewProviderService()
+	cp := svc.RegisterClient("mock", client)
+
+	var accountRef string
+	if err := svc.SyncProvider(cp.ID, func(ref string, c RegistrarClient) ([]types.Domain, error) {
+		accountRef = ref
+		return c.FetchDomains()
+	}); err != nil {
+		t.Fatalf("SyncProvider() unexpected error: %v", err)
+	}
+	if accountRef != cp.ID {
+		t.Errorf("Expected the sync to be given account ref %q, got %q", cp.ID, accountRef)
+	}
+}
+
+func TestProviderService_CheckStaleProviders(t *testing.T) {
+	client, err := NewMockClient(ProviderCredentials{})
+	if err != nil {
+		t.Fatalf("Failed to create mock client: %v", err)
+	}
+	svc := NewProviderService()
+	svc.SetStaleMultiplier(2)
+	cp := svc.RegisterClient("mock", client)
+	manual := svc.RegisterClient("manual", client)
+
+	if err := svc.UpdateConnectedProvider(cp.ID, map[string]interface{}{"auto_sync_enabled": true, "sync_interval_hours": float64(1)}); err != nil {
+		t.Fatalf("UpdateConnectedProvider() unexpected error: %v", err)
+	}
+	if err := svc.SyncProvider(cp.ID, func(_ string, c RegistrarClient) ([]types.Domain, error) { return c.FetchDomains() }); err != nil {
+		t.Fatalf("SyncProvider() unexpected error: %v", err)
+	}
+
+	now := time.Now()
+	if stale := svc.CheckStaleProviders(now.Add(90 * time.Minute)); len(stale) != 0 {
+		t.Errorf("Expected no stale providers within 2 intervals, got %d", len(stale))
+	}
+	stale := svc.CheckStaleProviders(now.Add(3 * time.Hour))
+	if len(stale) != 1 || stale[0].ID != cp.ID {
+		t.Fatalf("Expected only the auto-sync provider to go stale, got %v", stale)
+	}
+	if manual.ConnectionStatus == ConnectionStatusStale {
+		t.Error("A provider without auto-sync should never be stale")
+	}
+	if age := stale[0].LastSuccessAge(now.Add(3 * time.Hour)); age < 3*time.Hour-time.Minute {
+		t.Errorf("Expected a last success age of about 3h, got %s", age)
+	}
+	if again := svc.CheckStaleProviders(now.Add(4 * time.Hour)); len(again) != 0 {
+		t.Error("A provider already stale should not be reported again")
+	}
+
+	if err := svc.SyncProvider(cp.ID, func(_ string, c RegistrarClient) ([]types.Domain, error) { return c.FetchDomains() }); err != nil {
+		t.Fatalf("SyncProvider() unexpected error: %v", err)
+	}
+	if cp.ConnectionStatus != "connected" {
+		t.Errorf("Expected a successful sync to clear the stale status, got %q", cp.ConnectionStatus)
+	}
+}
+
+// failingClient is a registrar client whose credentials are always rejected
+type failingClient struct{}
+
+func (failingClient) FetchDomains() ([]types.Domain, error) {
+	return nil, errors.New("invalid credentials")
+}
+func (failingClient) GetProviderName() string { return "failing" }
+func (failingClient) FetchDNSRecords(string) ([]types.DNSRecord, error) {
+	return nil, errors.New("invalid credentials")
+}
+
+func TestProviderService_TestConnectedProviders(t *testing.T) {
+	client, err := NewMockClient(ProviderCredentials{})
+	if err != nil {
+		t.Fatalf("Failed to create mock client: %v", err)
+	}
+	svc := NewProviderService()
+	good := svc.RegisterClient("mock", client)
+	bad := svc.RegisterClient("failing", failingClient{})
+	stale := svc.RegisterClient("stale", client)
+	stale.ConnectionStatus = ConnectionStatusStale
+
+	report := svc.TestConnectedProviders(context.Background(), 2)
+	if report.Total != 3 || report.Succeeded != 2 || report.Failed != 1 {
+		t.Fatalf("Expected 2 of 3 providers to pass, got %+v", report)
+	}
+	if report.Results[0].ProviderID != bad.ID || report.Results[0].Success {
+		t.Errorf("Expected the failed provider first, got %+v", report.Results[0])
+	}
+	for _, result := range report.Results[1:] {
+		if !result.Success || result.DomainsFound == 0 {
+			t.Errorf("Expected %s to pass with domains found, got %+v", result.Name, result)
+		}
+	}
+	if bad.ConnectionStatus != ConnectionStatusError {
+		t.Errorf("Expected the failed provider's status to be %q, got %q", ConnectionStatusError, bad.ConnectionStatus)
+	}
+	if good.ConnectionStatus != ConnectionStatusConnected {
+		t.Errorf("Expected the passing provider to stay connected, got %q", good.ConnectionStatus)
+	}
+	if stale.ConnectionStatus != ConnectionStatusStale {
+		t.Errorf("Expected a passing test to leave a stale provider stale, got %q", stale.ConnectionStatus)
+	}
+
+	// A passing test clears an earlier failure
+	bad.Client = client
+	svc.TestConnectedProviders(context.Background(), 0)
+	if bad.ConnectionStatus != ConnectionStatusConnected {
+		t.Errorf("Expected a passing test to clear the error status, got %q", bad.ConnectionStatus)
+	}
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	now := time.Now()
+	b := NewCircuitBreaker(BreakerConfig{Threshold: 2, Cooldown: time.Minute, CallTimeout: 50 * time.Millisecond})
+	b.now = func() time.Time { return now }
+	fail := func() error { return errors.New("boom") }
+	calls := 0
+	succeed := func() error { calls++; return nil }
+
+	if err := b.Call(fail); err == nil || b.IsOpen() {
+		t.Fatalf("Expected one failure to leave the breaker closed, got err=%v open=%v", err, b.IsOpen())
+	}
+	b.Call(fail)
+	status := b.Status()
+	if status.State != BreakerOpen || status.ConsecutiveFailures != 2 || status.RetryAt == nil {
+		t.Fatalf("Expected the breaker to open after 2 failures, got %+v", status)
+	}
+	if err := b.Call(succeed); !errors.Is(err, types.ErrProviderCircuitOpen) || calls != 0 {
+		t.Fatalf("Expected an open breaker to reject calls, got err=%v calls=%d", err, calls)
+	}
+
+	// After the cooldown one probe goes through; a failure reopens the breaker
+	now = now.Add(2 * time.Minute)
+	b.Call(fail)
+	if !b.IsOpen() || b.Status().State != BreakerOpen {
+		t.Fatalf("Expected a failed probe to reopen the breaker, got %+v", b.Status())
+	}
+	now = now.Add(2 * time.Minute)
+	if err := b.Call(succeed); err != nil || calls != 1 || b.IsOpen() {
+		t.Fatalf("Expected a successful probe to close the breaker, got err=%v %+v", err, b.Status())
+	}
+	if status := b.Status(); status.ConsecutiveFailures != 0 || status.OpenedAt != nil {
+		t.Errorf("Expected a closed breaker to clear its failures, got %+v", status)
+	}
+
+	// A hung call is abandoned and counts as a failure
+	if err := b.Call(func() error { time.Sleep(time.Second); return nil }); !errors.Is(err, types.ErrProviderTimeout) {
+		t.Errorf("Expected a hung call to time out, got %v", err)
+	}
+	if b.Status().ConsecutiveFailures != 1 {
+		t.Errorf("Expected the timeout to count as a failure, got %+v", b.Status())
+	}
+}
+
+func TestProviderService_CircuitBreaker(t *testing.T) {
+	svc := NewProviderService()
+	svc.SetBreakerConfig(BreakerConfig{Threshold: 2, Cooldown: time.Hour})
+	cp := svc.RegisterClient("failing", failingClient{})
+	sync := func(_ string, c RegistrarClient) ([]types.Domain, error) { return c.FetchDomains() }
+
+	for i := 0; i < 2; i++ {
+		if err := svc.SyncProvider(cp.ID, sync); err == nil {
+			t.Fatal("Expected the failing provider's sync to fail")
+		}
+	}
+	if cp.ConnectionStatus != ConnectionStatusDegraded || cp.BreakerStatus().State != BreakerOpen {
+		t.Fatalf("Expected the provider to be degraded with an open breaker, got %q %+v", cp.ConnectionStatus, cp.BreakerStatus())
+	}
+	if err := svc.SyncProvider(cp.ID, sync); !errors.Is(err, types.ErrProviderCircuitOpen) {
+		t.Errorf("Expected syncs to be short-circuited while the breaker is open, got %v", err)
+	}
+
+	// A successful connection test closes the breaker
+	client, err := NewMockClient(ProviderCredentials{})
+	if err != nil {
+		t.Fatalf("Failed to create mock client: %v", err)
+	}
+	cp.Client = client
+	svc.TestConnectedProviders(context.Background(), 0)
+	if cp.ConnectionStatus != ConnectionStatusConnected || cp.BreakerStatus().State != BreakerClosed {
+		t.Errorf("Expected a passing test to close the breaker, got %q %+v", cp.ConnectionStatus, cp.BreakerStatus())
+	}
+	if err := svc.SyncProvider(cp.ID, sync); err != nil {
+		t.Errorf("SyncProvider() unexpected error after the breaker closed: %v", err)
+	}
+}
+
+func TestProviderService_MaintenanceDoesNotTripBreaker(t *testing.T) {
+	svc := NewProviderService()
+	svc.SetBreakerConfig(BreakerConfig{Threshold: 2, Cooldown: time.Hour})
+	client, err := NewMockClient(ProviderCredentials{})
+	if err != nil {
+		t.Fatalf("Failed to create mock client: %v", err)
+	}
+	cp := svc.RegisterClient("mock", client)
+	paused := func(string, RegistrarClient) ([]types.Domain, error) { return nil, types.ErrMaintenanceMode }
+
+	for i := 0; i < 3; i++ {
+		if err := svc.SyncProvider(cp.ID, paused); !errors.Is(err, types.ErrMaintenanceMode) {
+			t.Fatalf("SyncProvider() during maintenance = %v, want ErrMaintenanceMode", err)
+		}
+	}
+	if status := cp.BreakerStatus(); status.State != BreakerClosed || status.ConsecutiveFailures != 0 || cp.ErrorCount != 0 {
+		t.Errorf("Expected maintenance skips not to count as failures, got %+v with %d errors", status, cp.ErrorCount)
+	}
+
+	// A probe refused for maintenance leaves the next call to probe
+	now := time.Now()
+	b := NewCircuitBreaker(BreakerConfig{Threshold: 1, Cooldown: time.Minute})
+	b.now = func() time.Time { return now }
+	b.Call(func() error { return errors.New("boom") })
+	now = now.Add(2 * time.Minute)
+	b.Call(func() error { return types.ErrMaintenanceMode })
+	if err := b.Call(func() error { return nil }); err != nil || b.IsOpen() {
+		t.Errorf("Expected the probe after maintenance to close the breaker, got err=%v %+v", err, b.Status())
+	}
+}
+
+func TestHTTPTimeout(t *testing.T) {
+	tests := []struct {
+		value interface{}
+		want  time.Duration
+	}{
+		{nil, DefaultProviderTimeout},
+		{"45s", 45 * time.Second},
+		{"90", 90 * time.Second},
+		{float64(10), 10 * time.Second},
+		{"soon", DefaultProviderTimeout},
+		{"-5s", DefaultProviderTimeout},
+	}
+	for _, tt := range tests {
+		creds := ProviderCredentials{}
+		if tt.value != nil {
+			creds["timeout"] = tt.value
+		}
+		if got := httpTimeout(creds); got != tt.want {
+			t.Errorf("httpTimeout(%v) = %s, want %s", tt.value, got, tt.want)
+		}
+	}
+
+	client, err := NewGoDaddyClient(ProviderCredentials{"api_key": "key", "api_secret": "secret", "timeout": "5s"})
+	if err != nil {
+		t.Fatalf("NewGoDaddyClient() unexpected error: %v", err)
+	}
+	if client.client.Timeout != 5*time.Second {
+		t.Errorf("Expected the configured timeout on the HTTP client, got %s", client.client.Timeout)
+	}
+}
+
+func TestRDAPClient_LookupExpiry(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/domain/example.com":
+			w.Write([]byte(`{"events": [{"eventAction": "registration", "eventDate": "2001-05-01T00:00:00Z"},
+				{"eventAction": "expiration", "eventDate": "2027-05-01T12:00:00+02:00"}]}`))
+		case "/domain/busy.com":
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	client := NewRDAPClient(server.URL)
+
+	expiry, err := client.LookupExpiry("example.com")
+	if err != nil {
+		t.Fatalf("LookupExpiry() unexpected error: %v", err)
+	}
+	want := time.Date(2027, 5, 1, 10, 0, 0, 0, time.UTC)
+	if expiry.RegistryExpiresAt == nil || !expiry.RegistryExpiresAt.Equal(want) || expiry.Source != types.ExpirySourceRDAP {
+		t.Errorf("Expected %v from rdap, got %+v", want, expiry)
+	}
+	if registered := time.Date(2001, 5, 1, 0, 0, 0, 0, time.UTC); expiry.RegisteredAt == nil || !expiry.RegisteredAt.Equal(registered) {
+		t.Errorf("Expected registration date %v, got %v", registered, expiry.RegisteredAt)
+	}
+	if _, err := client.LookupExpiry("busy.com"); !errors.Is(err, types.ErrProviderRateLimit) {
+		t.Errorf("Expected a rate limit error, got %v", err)
+	}
+	if _, err := client.LookupExpiry("missing.com"); !errors.Is(err, types.ErrDomainNotFound) {
+		t.Errorf("Expected a not found error, got %v", err)
+	}
+}
+
+func TestParseWHOISExpiry(t *testing.T) {
+	tests := []struct {
+		reply string
+		want  time.Time
+	}{
+		{"Domain Name: EXAMPLE.COM\nRegistry Expiry Date: 2027-08-13T04:00:00Z\n", time.Date(2027, 8, 13, 4, 0, 0, 0, time.UTC)},
+		{"domain: EXAMPLE.RU\npaid-till: 2026-03-01T21:00:00Z\n", time.Date(2026, 3, 1, 21, 0, 0, 0, time.UTC)},
+		{"Expiration Date: 15-Jan-2028\nExpiry Date: 2029-01-01\n", time.Date(2028, 1, 15, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		got, err := ParseWHOISExpiry(tt.reply)
+		if err != nil || !got.Equal(tt.want) {
+			t.Errorf("ParseWHOISExpiry(%q) = %v, %v, want %v", tt.reply, got, err, tt.want)
+		}
+	}
+	if _, err := ParseWHOISExpiry("No match for domain\n"); err == nil {
+		t.Error("Expected an error for a reply without an expiry")
+	}
+}
+
+func TestParseWHOISCreated(t *testing.T) {
+	tests := []struct {
+		reply string
+		want  time.Time
+	}{
+		{"Domain Name: EXAMPLE.COM\nUpdated Date: 2024-08-14T07:01:38Z\nCreation Date: 1995-08-14T04:00:00Z\n", time.Date(1995, 8, 14, 4, 0, 0, 0, time.UTC)},
+		{"Domain name:\n    example.co.uk\nRelevant dates:\n    Registered on: 26-Aug-1996\n", time.Date(1996, 8, 26, 0, 0, 0, 0, time.UTC)},
+		{"domain: EXAMPLE.RU\ncreated: 2003-02-27T21:00:00Z\npaid-till: 2026-02-28T21:00:00Z\n", time.Date(2003, 2, 27, 21, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		got, err := ParseWHOISCreated(tt.reply)
+		if err != nil || !got.Equal(tt.want) {
+			t.Errorf("ParseWHOISCreated(%q) = %v, %v, want %v", tt.reply, got, err, tt.want)
+		}
+	}
+	if _, err := ParseWHOISCreated("Registry Expiry Date: 2027-08-13T04:00:00Z\n"); err == nil {
+		t.Error("Expected an error for a reply without a registration date")
+	}
+}
+
+func TestHoverClient_Session(t *testing.T) {
+	logins, expired := 0, true
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/login":
+			logins++
+			if r.FormValue("username") == "2fa-user" {
+				w.Write([]byte(`{"succeeded":false,"status":"need_2fa"}`))
+				return
+			}
+			w.Write([]byte(`{"succeeded":true}`))
+		case "/domains":
+			if expired {
+				// First request after sign-in fails as if the session cookie had expired
+				expired = false
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.Write([]byte(`{"succeeded":true,"domains":[]}`))
+		case "/domains/example.com/dns":
+			w.Write([]byte(`{"error":"not what the client expects"}`))
+		}
+	}))
+	defer server.Close()
+
+	client := &HoverClient{username: "owner", password: "secret", baseURL: server.URL, client: server.Client()}
+	if _, err := client.FetchDomains(); err != nil {
+		t.Fatalf("FetchDomains() unexpected error: %v", err)
+	}
+	if logins != 2 {
+		t.Errorf("Expected a second sign-in after the session expired, got %d sign-ins", logins)
+	}
+	if _, err := client.FetchDNSRecords("example.com"); !errors.Is(err, types.ErrProviderUnexpectedResponse) {
+		t.Errorf("Expected ErrProviderUnexpectedResponse for an unrecognised response, got %v", err)
+	}
+
+	twoFactor := &HoverClient{username: "2fa-user", password: "secret", baseURL: server.URL, client: server.Client()}
+	if _, err := twoFactor.FetchDomains(); !errors.Is(err, types.ErrProviderAuth) {
+		t.Errorf("Expected ErrProviderAuth for a two-factor account, got %v", err)
+	}
+}
+
+func TestRateLimiter(t *testing.T) {
+	limiter := NewRateLimiter(50) // One call every 20ms
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		if err := limiter.Wait(context.Background()); err != nil {
+			t.Fatalf("Wait() unexpected error: %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 60*time.Millisecond {
+		t.Errorf("4 calls at 50/s took %s, want at least 60ms", elapsed)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	slow := NewRateLimiter(0.1)
+	slow.Wait(ctx) // The first call isn't delayed
+	cancel()
+	if err := slow.Wait(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("Wait() on a cancelled context = %v, want context.Canceled", err)
+	}
+
+	var unlimited RateLimiter
+	start = time.Now()
+	for i := 0; i < 100; i++ {
+		unlimited.Wait(context.Background())
+	}
+	if elapsed := time.Since(start); elapsed > 50*time.Millisecond {
+		t.Errorf("an unlimited limiter waited %s", elapsed)
+	}
+}
+
+func TestSignAWSRequest(t *testing.T) {
+	// The get-vanilla case of the AWS Signature Version 4 test suite
+	req, _ := http.NewRequest(http.MethodGet, "https://example.amazonaws.com/", nil)
+	config := AWSSecretsConfig{Region: "us-east-1", AccessKeyID: "AKIDEXAMPLE", SecretAccessKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY"}
+	signAWSRequest(req, nil, time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC), config, "service")
+
+	want := "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/service/aws4_request, " +
+		"SignedHeaders=host;x-amz-date, Signature=5fa00fa31553b73ebf1942676e86291e8372ff2a2260956d9b8aae1d763fbf31"
+	if got := req.Header.Get("Authorization"); got != want {
+		t.Errorf("Authorization = %q, want %q", got, want)
+	}
+}
+
+func TestVaultSecretStore(t *testing.T) {
+	var mu sync.Mutex
+	secrets := map[string]map[string]string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "vault-token" {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"errors":["permission denied"]}`))
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		name := strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, "/v1/kv/data/dv/"), "/v1/kv/metadata/dv/")
+		switch r.Method {
+		case http.MethodPost:
+			var body struct {
+				Data map[string]string `json:"data"`
+			}
+			json.NewDecoder(r.Body).Decode(&body)
+			secrets[name] = body.Data
+			w.Write([]byte(`{"data":{"version":1}}`))
+		case http.MethodGet:
+			secret, ok := secrets[name]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				w.Write([]byte(`{"errors":[]}`))
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{"data": secret}})
+		case http.MethodDelete:
+			delete(secrets, name)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	store := NewVaultSecretStore(VaultConfig{Address: server.URL + "/", Token: "vault-token", Mount: "/kv/", Prefix: "dv"})
+	if err := store.Put("godaddy-1", map[string]string{"api_key": "key", "api_secret": "secret"}); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	got, err := store.Get("godaddy-1")
+	if err != nil || got["api_key"] != "key" || got["api_secret"] != "secret" {
+		t.Fatalf("Get() = %v, %v", got, err)
+	}
+	if err := store.Delete("godaddy-1"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := store.Get("godaddy-1"); !errors.Is(err, types.ErrSecretNotFound) {
+		t.Errorf("Get() after Delete() error = %v, want ErrSecretNotFound", err)
+	}
+	if err := store.Put("../sys/policy", map[string]string{}); !errors.Is(err, types.ErrInvalidSecretReference) {
+		t.Errorf("Put() with a path reference error = %v, want ErrInvalidSecretReference", err)
+	}
+
+	denied := NewVaultSecretStore(VaultConfig{Address: server.URL, Token: "wrong", Mount: "kv", Prefix: "dv"})
+	if _, err := denied.Get("godaddy-1"); err == nil || !strings.Contains(err.Error(), "permission denied") {
+		t.Errorf("Get() with a bad token error = %v, want Vault's error", err)
+	}
+}
+
+func TestAWSSecretStore(t *testing.T) {
+	secrets := map[string]string{}
+	var actions []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=AKID/") {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		action := strings.TrimPrefix(r.Header.Get("X-Amz-Target"), "secretsmanager.")
+		actions = append(actions, action)
+		var body struct {
+			Name, SecretId, SecretString string
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+
+		notFound := func() {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"__type":"ResourceNotFoundException","message":"Secrets Manager can't find the specified secret."}`))
+		}
+		switch action {
+		case "PutSecretValue", "GetSecretValue", "DeleteSecret":
+			if _, ok := secrets[body.SecretId]; !ok {
+				notFound()
+				return
+			}
+		}
+		switch action {
+		case "CreateSecret":
+			secrets[body.Name] = body.SecretString
+		case "PutSecretValue":
+			secrets[body.SecretId] = body.SecretString
+		case "GetSecretValue":
+			json.NewEncoder(w).Encode(map[string]string{"SecretString": secrets[body.SecretId]})
+			return
+		case "DeleteSecret":
+			delete(secrets, body.SecretId)
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	store := NewAWSSecretStore(AWSSecretsConfig{Region: "eu-west-1", AccessKeyID: "AKID", SecretAccessKey: "secret", Prefix: "dv/", Endpoint: server.URL})
+	if err := store.Put("ovh-1", map[string]string{"application_key": "v1"}); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	if err := store.Put("ovh-1", map[string]string{"application_key": "v2"}); err != nil {
+		t.Fatalf("Put() of a new value error = %v", err)
+	}
+	got, err := store.Get("ovh-1")
+	if err != nil || got["application_key"] != "v2" {
+		t.Fatalf("Get() = %v, %v", got, err)
+	}
+	if _, ok := secrets["dv/ovh-1"]; !ok {
+		t.Errorf("secret names = %v, want the prefix applied", secrets)
+	}
+	if err := store.Delete("ovh-1"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := store.Get("ovh-1"); !errors.Is(err, types.ErrSecretNotFound) {
+		t.Errorf("Get() after Delete() error = %v, want ErrSecretNotFound", err)
+	}
+	if err := store.Delete("ovh-1"); err != nil {
+		t.Errorf("Delete() of a missing secret error = %v", err)
+	}
+
+	want := []string{"PutSecretValue", "CreateSecret", "PutSecretValue", "GetSecretValue", "DeleteSecret", "GetSecretValue", "DeleteSecret"}
+	if !reflect.DeepEqual(actions, want) {
+		t.Errorf("actions = %v, want %v", actions, want)
+	}
+}
+
+func TestSecureProviderService_SecretStore(t *testing.T) {
+	repo := storage.NewMockRepo()
+	svc := NewSecureProviderService(repo)
+
+	resp, err := svc.AddSecureConnection(&types.SecureProviderConnectionRequest{
+		Provider:    "mock",
+		Name:        "Mock account",
+		AccountName: "ops",
+		Credentials: map[string]string{"api_key": "secret-key"},
+	})
+	if err != nil || !resp.Success {
+		t.Fatalf("AddSecureConnection() = %+v, %v", resp, err)
+	}
+
+	stored, err := repo.GetSecureCredentialsByID(resp.ProviderID)
+	if err != nil {
+		t.Fatalf("GetSecureCredentialsByID() error = %v", err)
+	}
+	reference := stored.CredentialReference
+	if !strings.HasPrefix(reference, "mock-") {
+		t.Errorf("credential reference = %q, want a generated mock- reference", reference)
+	}
+	if secret, err := repo.GetSecret(reference); err != nil || secret["api_key"] != "secret-key" {
+		t.Errorf("stored secret = %v, %v", secret, err)
+	}
+
+	if err := svc.SyncSecureProvider(resp.ProviderID, func(client RegistrarClient) ([]types.Domain, error) {
+		return client.FetchDomains()
+	}); err != nil {
+		t.Errorf("SyncSecureProvider() error = %v", err)
+	}
+
+	client, ok := svc.SyncClients()[resp.ProviderID]
+	if !ok || client.GetProviderName() != "mock" {
+		t.Fatalf("SyncClients() = %v, want a mock client for the connection", svc.SyncClients())
+	}
+	if domains, err := client.FetchDomains(); err != nil || len(domains) == 0 {
+		t.Errorf("SecureClient.FetchDomains() = %d domains, %v", len(domains), err)
+	}
+	if err := svc.secretStore().Delete(reference); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := client.FetchDomains(); !errors.Is(err, types.ErrSecretNotFound) {
+		t.Errorf("SecureClient.FetchDomains() after the secret was deleted error = %v, want ErrSecretNotFound", err)
+	}
+	if err := svc.secretStore().Put(reference, map[string]string{"api_key": "secret-key"}); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+
+	if err := svc.RemoveSecureConnection(resp.ProviderID); err != nil {
+		t.Fatalf("RemoveSecureConnection() error = %v", err)
+	}
+	if _, err := repo.GetSecret(reference); !errors.Is(err, types.ErrSecretNotFound) {
+		t.Errorf("secret after removal error = %v, want ErrSecretNotFound", err)
+	}
+
+	if _, err := svc.AddSecureConnection(&types.SecureProviderConnectionRequest{
+		Provider:            "mock",
+		Name:                "Both",
+		CredentialReference: "MOCK_DEFAULT",
+		Credentials:         map[string]string{"api_key": "k"},
+	}); !errors.Is(err, types.ErrInvalidSecretReference) {
+		t.Errorf("AddSecureConnection() with a reference and credentials error = %v, want ErrInvalidSecretReference", err)
+	}
+}
@@ -0,0 +1,94 @@
+package providers
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RateLimiter spaces calls to an external service evenly so they stay under its rate limit.
+// Each Wait reserves the next free slot, so concurrent callers queue up instead of bursting.
+// The zero value doesn't limit.
+type RateLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time // Earliest time the next call may start
+}
+
+// NewRateLimiter creates a limiter allowing perSecond calls a second; 0 doesn't limit
+func NewRateLimiter(perSecond float64) *RateLimiter {
+	l := &RateLimiter{}
+	l.SetRate(perSecond)
+	return l
+}
+
+// SetRate changes how many calls a second the limiter allows; 0 stops limiting
+func (l *RateLimiter) SetRate(perSecond float64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.interval = 0
+	if perSecond > 0 {
+		l.interval = time.Duration(float64(time.Second) / perSecond)
+	}
+}
+
+// Wait blocks until the caller's slot comes up, or returns ctx's error if ctx ends first
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	l.mu.Lock()
+	if l.interval == 0 {
+		l.mu.Unlock()
+		return nil
+	}
+	slot := time.Now()
+	if l.next.After(slot) {
+		slot = l.next
+	}
+	l.next = slot.Add(l.interval)
+	l.mu.Unlock()
+
+	wait := time.Until(slot)
+	if wait <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// rateLimiters hands out one RateLimiter per key, such as a WHOIS server or a connected
+// provider, all at the same rate. The zero value doesn't limit.
+type rateLimiters struct {
+	mu        sync.Mutex
+	perSecond float64
+	limiters  map[string]*RateLimiter
+}
+
+// setRate changes the rate of every key's limiter
+func (r *rateLimiters) setRate(perSecond float64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.perSecond = perSecond
+	for _, limiter := range r.limiters {
+		limiter.SetRate(perSecond)
+	}
+}
+
+// wait waits for key's next slot
+func (r *rateLimiters) wait(ctx context.Context, key string) error {
+	r.mu.Lock()
+	if r.limiters == nil {
+		r.limiters = make(map[string]*RateLimiter)
+	}
+	limiter, ok := r.limiters[key]
+	if !ok {
+		limiter = NewRateLimiter(r.perSecond)
+		r.limiters[key] = limiter
+	}
+	r.mu.Unlock()
+	return limiter.Wait(ctx)
+}
@@ -0,0 +1,137 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/rusiqe/domainvault/internal/types"
+)
+
+// DefaultRDAPBaseURL is the bootstrap service that redirects each lookup to the registry
+// responsible for the domain's TLD
+const DefaultRDAPBaseURL = "https://rdap.org"
+
+// RDAPClient looks domains up in registry RDAP services, the JSON successor to WHOIS. It needs
+// no credentials, so it can check availability when no registrar offers it, though it can't
+// tell what a registration would cost.
+type RDAPClient struct {
+	baseURL string
+	client  *http.Client
+	limiter RateLimiter
+}
+
+// NewRDAPClient creates an RDAP client; an empty baseURL uses DefaultRDAPBaseURL
+func NewRDAPClient(baseURL string) *RDAPClient {
+	if baseURL == "" {
+		baseURL = DefaultRDAPBaseURL
+	}
+	return &RDAPClient{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		client:  &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// SetRateLimit sets how many lookups a second the client makes, shared by every caller; 0
+// doesn't limit
+func (r *RDAPClient) SetRateLimit(perSecond float64) {
+	r.limiter.SetRate(perSecond)
+}
+
+// CheckAvailability reports a domain as available when its registry has no record of it
+func (r *RDAPClient) CheckAvailability(domain string) (*types.DomainAvailability, error) {
+	resp, err := r.lookup(domain)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	availability := &types.DomainAvailability{Domain: domain, Source: "rdap"}
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return availability, nil
+	case http.StatusNotFound:
+		availability.Available = true
+		return availability, nil
+	case http.StatusTooManyRequests:
+		return nil, types.ErrProviderRateLimit
+	default:
+		return nil, fmt.Errorf("RDAP lookup for %s returned status %d", domain, resp.StatusCode)
+	}
+}
+
+// rdapDomain is the part of an RDAP domain response that carries its lifecycle dates
+type rdapDomain struct {
+	Events []struct {
+		Action string `json:"eventAction"`
+		Date   string `json:"eventDate"`
+	} `json:"events"`
+}
+
+// LookupExpiry reads a domain's expiry from the "expiration" event of its registry's RDAP
+// record, and its registration date from the "registration" event when there is one
+func (r *RDAPClient) LookupExpiry(domain string) (*types.RegistryExpiry, error) {
+	resp, err := r.lookup(domain)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, fmt.Errorf("%w: the registry has no RDAP record of %s", types.ErrDomainNotFound, domain)
+	case http.StatusTooManyRequests:
+		return nil, types.ErrProviderRateLimit
+	default:
+		return nil, fmt.Errorf("RDAP lookup for %s returned status %d", domain, resp.StatusCode)
+	}
+
+	var record rdapDomain
+	if err := json.NewDecoder(resp.Body).Decode(&record); err != nil {
+		return nil, fmt.Errorf("failed to decode RDAP response for %s: %w", domain, err)
+	}
+	result := &types.RegistryExpiry{Source: types.ExpirySourceRDAP}
+	for _, event := range record.Events {
+		switch event.Action {
+		case "expiration":
+			expires, err := time.Parse(time.RFC3339, event.Date)
+			if err != nil {
+				return nil, fmt.Errorf("invalid RDAP expiration date %q for %s", event.Date, domain)
+			}
+			expires = expires.UTC()
+			result.RegistryExpiresAt = &expires
+		case "registration":
+			if registered, err := time.Parse(time.RFC3339, event.Date); err == nil {
+				registered = registered.UTC()
+				result.RegisteredAt = &registered
+			}
+		}
+	}
+	if result.RegistryExpiresAt == nil {
+		return nil, fmt.Errorf("RDAP record of %s has no expiration event", domain)
+	}
+	return result, nil
+}
+
+// lookup requests a domain's RDAP record once the rate limit allows; the caller closes the body
+func (r *RDAPClient) lookup(domain string) (*http.Response, error) {
+	r.limiter.Wait(context.Background())
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/domain/%s", r.baseURL, url.PathEscape(domain)), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/rdap+json")
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("RDAP lookup for %s failed: %w", domain, err)
+	}
+	return resp, nil
+}
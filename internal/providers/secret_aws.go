@@ -0,0 +1,221 @@
+package providers
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/rusiqe/domainvault/internal/types"
+)
+
+// AWSSecretsConfig locates the AWS Secrets Manager secrets are kept in
+type AWSSecretsConfig struct {
+	Region          string
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string // For temporary credentials, empty otherwise
+	Prefix          string // Prepended to each reference to form the secret name
+	Endpoint        string // Overrides https://secretsmanager.<region>.amazonaws.com, e.g. for a VPC endpoint
+}
+
+// awsRecoveryWindowDays is how long a deleted secret can still be restored in AWS
+const awsRecoveryWindowDays = 7
+
+// AWSSecretStore keeps secrets in AWS Secrets Manager, one secret per reference holding the
+// credentials as a JSON object. Requests are signed with AWS Signature Version 4.
+type AWSSecretStore struct {
+	config AWSSecretsConfig
+	client *http.Client
+	now    func() time.Time
+}
+
+// NewAWSSecretStore creates a secret store backed by AWS Secrets Manager
+func NewAWSSecretStore(config AWSSecretsConfig) *AWSSecretStore {
+	if config.Endpoint == "" {
+		config.Endpoint = fmt.Sprintf("https://secretsmanager.%s.amazonaws.com", config.Region)
+	}
+	config.Endpoint = strings.TrimSuffix(config.Endpoint, "/")
+	return &AWSSecretStore{config: config, client: &http.Client{Timeout: 30 * time.Second}, now: time.Now}
+}
+
+// awsError is the error body Secrets Manager returns
+type awsError struct {
+	Type    string `json:"__type"`
+	Message string `json:"message"`
+}
+
+func (e *awsError) Error() string {
+	return fmt.Sprintf("AWS Secrets Manager %s: %s", e.Type, e.Message)
+}
+
+// is reports whether the error is of the given exception type; the type may carry a namespace
+func (e *awsError) is(exception string) bool {
+	return e.Type == exception || strings.HasSuffix(e.Type, "#"+exception)
+}
+
+// Put stores secret as the current value of the reference's secret, creating it if needed
+func (s *AWSSecretStore) Put(reference string, secret map[string]string) error {
+	if err := validateSecretReference(reference); err != nil {
+		return err
+	}
+	value, err := json.Marshal(secret)
+	if err != nil {
+		return err
+	}
+
+	err = s.call("PutSecretValue", map[string]interface{}{"SecretId": s.name(reference), "SecretString": string(value)}, nil)
+	if awsErr, ok := err.(*awsError); ok && awsErr.is("ResourceNotFoundException") {
+		err = s.call("CreateSecret", map[string]interface{}{"Name": s.name(reference), "SecretString": string(value)}, nil)
+	}
+	return err
+}
+
+// Get returns the current value of the reference's secret
+func (s *AWSSecretStore) Get(reference string) (map[string]string, error) {
+	if err := validateSecretReference(reference); err != nil {
+		return nil, err
+	}
+	var response struct {
+		SecretString string `json:"SecretString"`
+	}
+	err := s.call("GetSecretValue", map[string]interface{}{"SecretId": s.name(reference)}, &response)
+	if awsErr, ok := err.(*awsError); ok && awsErr.is("ResourceNotFoundException") {
+		return nil, types.ErrSecretNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var secret map[string]string
+	if err := json.Unmarshal([]byte(response.SecretString), &secret); err != nil {
+		return nil, fmt.Errorf("secret %s is not a JSON object of strings: %w", s.name(reference), err)
+	}
+	return secret, nil
+}
+
+// Delete schedules the reference's secret for deletion after the recovery window
+func (s *AWSSecretStore) Delete(reference string) error {
+	if err := validateSecretReference(reference); err != nil {
+		return err
+	}
+	err := s.call("DeleteSecret", map[string]interface{}{"SecretId": s.name(reference), "RecoveryWindowInDays": awsRecoveryWindowDays}, nil)
+	if awsErr, ok := err.(*awsError); ok && awsErr.is("ResourceNotFoundException") {
+		return nil
+	}
+	return err
+}
+
+// Backend returns SecretBackendAWS
+func (s *AWSSecretStore) Backend() string {
+	return SecretBackendAWS
+}
+
+// name is the Secrets Manager name of a reference's secret
+func (s *AWSSecretStore) name(reference string) string {
+	return s.config.Prefix + reference
+}
+
+// call invokes a Secrets Manager action and decodes its response into out, if given
+func (s *AWSSecretStore) call(action string, input interface{}, out interface{}) error {
+	body, err := json.Marshal(input)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, s.config.Endpoint+"/", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", "secretsmanager."+action)
+	signAWSRequest(req, body, s.now().UTC(), s.config, "secretsmanager")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to reach AWS Secrets Manager: %w", err)
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return fmt.Errorf("failed to read AWS Secrets Manager response: %w", err)
+	}
+
+	if resp.StatusCode >= 300 {
+		awsErr := &awsError{}
+		if json.Unmarshal(respBody, awsErr) != nil || awsErr.Type == "" {
+			awsErr.Type = fmt.Sprintf("HTTP %d", resp.StatusCode)
+		}
+		return awsErr
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.Unmarshal(respBody, out); err != nil {
+		return fmt.Errorf("failed to parse AWS Secrets Manager response: %w", err)
+	}
+	return nil
+}
+
+// signAWSRequest adds AWS Signature Version 4 headers to a request to service with the given
+// body, signing every header the request has
+func signAWSRequest(req *http.Request, body []byte, now time.Time, config AWSSecretsConfig, service string) {
+	amzDate := now.Format("20060102T150405Z")
+	date := amzDate[:8]
+	req.Header.Set("X-Amz-Date", amzDate)
+	if config.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", config.SessionToken)
+	}
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(req.Header.Get(name))
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	path := req.URL.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	canonicalRequest := strings.Join([]string{
+		req.Method, path, req.URL.RawQuery, canonicalHeaders.String(), signedHeaders, sha256Hex(body),
+	}, "\n")
+
+	scope := date + "/" + config.Region + "/" + service + "/aws4_request"
+	stringToSign := strings.Join([]string{"AWS4-HMAC-SHA256", amzDate, scope, sha256Hex([]byte(canonicalRequest))}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+config.SecretAccessKey), date)
+	key = hmacSHA256(key, config.Region)
+	key = hmacSHA256(key, service)
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		config.AccessKeyID, scope, signedHeaders, signature))
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,82 @@
+package providers
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/rusiqe/domainvault/internal/storage"
+	"github.com/rusiqe/domainvault/internal/types"
+)
+
+// Secret store backends, selected with SECRET_BACKEND
+const (
+	SecretBackendDB    = "db"
+	SecretBackendVault = "vault"
+	SecretBackendAWS   = "aws"
+)
+
+// SecretStore keeps provider credentials outside the connection records, which hold only a
+// reference to them. The provider service reads them each time it needs a client, so a secret
+// rotated in the store is picked up without touching the connection.
+type SecretStore interface {
+	Put(reference string, secret map[string]string) error // Creates or replaces
+	Get(reference string) (map[string]string, error)      // types.ErrSecretNotFound if there is none
+	Delete(reference string) error                        // No error if there is none
+	Backend() string
+}
+
+// secretReferencePattern limits references to characters every backend accepts in a path or name
+var secretReferencePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]{0,127}$`)
+
+// validateSecretReference rejects references that can't be used as a path or secret name
+func validateSecretReference(reference string) error {
+	if !secretReferencePattern.MatchString(reference) {
+		return fmt.Errorf("%w: %q", types.ErrInvalidSecretReference, reference)
+	}
+	return nil
+}
+
+// newSecretReference creates a reference for a new connection's credentials
+func newSecretReference(provider string) string {
+	return fmt.Sprintf("%s-%s", strings.ToLower(provider), uuid.New().String())
+}
+
+// DBSecretStore keeps secrets in the database, encrypted with the credentials master key when
+// one is configured. It's the default backend.
+type DBSecretStore struct {
+	repo storage.DomainRepository
+}
+
+// NewDBSecretStore creates a secret store backed by repo
+func NewDBSecretStore(repo storage.DomainRepository) *DBSecretStore {
+	return &DBSecretStore{repo: repo}
+}
+
+// Put stores secret under reference
+func (s *DBSecretStore) Put(reference string, secret map[string]string) error {
+	if err := validateSecretReference(reference); err != nil {
+		return err
+	}
+	return s.repo.PutSecret(reference, types.CredentialsMap(secret))
+}
+
+// Get returns the secret stored under reference
+func (s *DBSecretStore) Get(reference string) (map[string]string, error) {
+	secret, err := s.repo.GetSecret(reference)
+	if err != nil {
+		return nil, err
+	}
+	return secret, nil
+}
+
+// Delete removes the secret stored under reference
+func (s *DBSecretStore) Delete(reference string) error {
+	return s.repo.DeleteSecret(reference)
+}
+
+// Backend returns SecretBackendDB
+func (s *DBSecretStore) Backend() string {
+	return SecretBackendDB
+}
@@ -0,0 +1,138 @@
+package providers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/rusiqe/domainvault/internal/types"
+)
+
+// VaultConfig locates the HashiCorp Vault KV version 2 engine secrets are kept in
+type VaultConfig struct {
+	Address   string // e.g. https://vault.example.com:8200
+	Token     string
+	Namespace string // Vault Enterprise namespace, empty for none
+	Mount     string // Path the KV v2 engine is mounted at, "secret" when empty
+	Prefix    string // Path under the mount secrets are kept at
+}
+
+// VaultSecretStore keeps secrets in a HashiCorp Vault KV version 2 engine, one secret per
+// reference at <mount>/<prefix>/<reference>
+type VaultSecretStore struct {
+	config VaultConfig
+	client *http.Client
+}
+
+// NewVaultSecretStore creates a secret store backed by Vault
+func NewVaultSecretStore(config VaultConfig) *VaultSecretStore {
+	config.Address = strings.TrimSuffix(config.Address, "/")
+	config.Mount = strings.Trim(config.Mount, "/")
+	if config.Mount == "" {
+		config.Mount = "secret"
+	}
+	config.Prefix = strings.Trim(config.Prefix, "/")
+	return &VaultSecretStore{config: config, client: &http.Client{Timeout: 30 * time.Second}}
+}
+
+// Put writes secret as a new version of the reference's secret
+func (s *VaultSecretStore) Put(reference string, secret map[string]string) error {
+	if err := validateSecretReference(reference); err != nil {
+		return err
+	}
+	body, err := json.Marshal(map[string]interface{}{"data": secret})
+	if err != nil {
+		return err
+	}
+	_, err = s.do(http.MethodPost, s.path("data", reference), body)
+	return err
+}
+
+// Get reads the latest version of the reference's secret
+func (s *VaultSecretStore) Get(reference string) (map[string]string, error) {
+	if err := validateSecretReference(reference); err != nil {
+		return nil, err
+	}
+	body, err := s.do(http.MethodGet, s.path("data", reference), nil)
+	if err != nil {
+		return nil, err
+	}
+	var response struct {
+		Data struct {
+			Data map[string]string `json:"data"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse Vault response: %w", err)
+	}
+	if response.Data.Data == nil {
+		return nil, types.ErrSecretNotFound // The latest version was deleted
+	}
+	return response.Data.Data, nil
+}
+
+// Delete removes every version of the reference's secret
+func (s *VaultSecretStore) Delete(reference string) error {
+	if err := validateSecretReference(reference); err != nil {
+		return err
+	}
+	_, err := s.do(http.MethodDelete, s.path("metadata", reference), nil)
+	if err == types.ErrSecretNotFound {
+		return nil
+	}
+	return err
+}
+
+// Backend returns SecretBackendVault
+func (s *VaultSecretStore) Backend() string {
+	return SecretBackendVault
+}
+
+// path is the API path of a reference's secret data or metadata
+func (s *VaultSecretStore) path(kind, reference string) string {
+	if s.config.Prefix == "" {
+		return fmt.Sprintf("/v1/%s/%s/%s", s.config.Mount, kind, reference)
+	}
+	return fmt.Sprintf("/v1/%s/%s/%s/%s", s.config.Mount, kind, s.config.Prefix, reference)
+}
+
+// do sends a request to Vault and returns the response body. A 404 is types.ErrSecretNotFound.
+func (s *VaultSecretStore) do(method, path string, body []byte) ([]byte, error) {
+	req, err := http.NewRequest(method, s.config.Address+path, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("X-Vault-Token", s.config.Token)
+	if s.config.Namespace != "" {
+		req.Header.Set("X-Vault-Namespace", s.config.Namespace)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach Vault: %w", err)
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Vault response: %w", err)
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, types.ErrSecretNotFound
+	case resp.StatusCode >= 300:
+		var vaultErr struct {
+			Errors []string `json:"errors"`
+		}
+		json.Unmarshal(respBody, &vaultErr)
+		return nil, fmt.Errorf("vault returned %d: %s", resp.StatusCode, strings.Join(vaultErr.Errors, "; "))
+	}
+	return respBody, nil
+}
@@ -11,23 +11,24 @@ import (
 	"github.com/rusiqe/domainvault/internal/types"
 )
 
-// SecureProviderService manages secure provider connections using environment variable references
-// This service improves security by not storing API keys directly in the database
+// SecureProviderService manages secure provider connections whose credentials are kept outside
+// the database record: in environment variables, or in a secret store such as Vault
 type SecureProviderService struct {
 	repository             storage.DomainRepository
+	secrets                SecretStore
 	connectedProviders     map[string]*SecureConnectedProvider
 	autoSyncScheduler      *SecureAutoSyncScheduler
 	mu                     sync.RWMutex
 }
 
-// SecureConnectedProvider represents a connected provider with secure credentials
+// SecureConnectedProvider represents a connected provider with secure credentials. It holds no
+// client; one is created from the resolved credentials each time the provider is used.
 type SecureConnectedProvider struct {
 	ID                  string
 	Provider            string
 	Name                string
 	AccountName         string
-	CredentialReference string // Reference to environment variables
-	Client              RegistrarClient
+	CredentialReference string // Environment variable set or secret store reference
 	Enabled             bool
 	AutoSyncEnabled     bool
 	SyncInterval        time.Duration
@@ -51,12 +52,13 @@ type SecureAutoSyncScheduler struct {
 	running    bool
 }
 
-// NewSecureProviderService creates a new secure provider service
+// NewSecureProviderService creates a new secure provider service keeping secrets in the database
 func NewSecureProviderService(repository storage.DomainRepository) *SecureProviderService {
 	ctx, cancel := context.WithCancel(context.Background())
 	
 	service := &SecureProviderService{
 		repository:         repository,
+		secrets:            NewDBSecretStore(repository),
 		connectedProviders: make(map[string]*SecureConnectedProvider),
 		autoSyncScheduler: &SecureAutoSyncScheduler{
 			providers: make(map[string]*SecureConnectedProvider),
@@ -74,7 +76,98 @@ func NewSecureProviderService(repository storage.DomainRepository) *SecureProvid
 	return service
 }
 
-// loadExistingConnections loads secure credential connections from the database
+// SetSecretStore sets where credentials given to new connections are kept and where stored
+// references are resolved. Existing secrets aren't moved between stores.
+func (s *SecureProviderService) SetSecretStore(store SecretStore) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.secrets = store
+}
+
+// secretStore returns the configured secret store
+func (s *SecureProviderService) secretStore() SecretStore {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.secrets
+}
+
+// resolveSecureCredentials reads the credentials a reference names: a predefined environment
+// variable set, or otherwise a secret in store
+func resolveSecureCredentials(store SecretStore, reference string) (ProviderCredentials, error) {
+	var resolved map[string]string
+	var err error
+	if types.IsEnvCredentialReference(reference) {
+		resolved, err = types.ResolveCredentials(reference)
+	} else {
+		resolved, err = store.Get(reference)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	providerCreds := make(ProviderCredentials, len(resolved))
+	for key, value := range resolved {
+		providerCreds[key] = value
+	}
+	return providerCreds, nil
+}
+
+// clientFor resolves a provider's credentials and creates a client with them
+func (s *SecureProviderService) clientFor(provider *SecureConnectedProvider) (RegistrarClient, error) {
+	creds, err := resolveSecureCredentials(s.secretStore(), provider.CredentialReference)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve credentials for %s: %w", provider.Name, err)
+	}
+	return NewClient(provider.Provider, creds)
+}
+
+// SecureClient is the registrar client of a secure connection. It resolves the connection's
+// credentials on every call, so a rotated secret is used by the next sync without a restart.
+type SecureClient struct {
+	service  *SecureProviderService
+	provider *SecureConnectedProvider
+}
+
+// FetchDomains fetches the connection's domains with its current credentials
+func (c *SecureClient) FetchDomains() ([]types.Domain, error) {
+	client, err := c.service.clientFor(c.provider)
+	if err != nil {
+		return nil, err
+	}
+	return client.FetchDomains()
+}
+
+// GetProviderName returns the registrar the connection is for
+func (c *SecureClient) GetProviderName() string {
+	return c.provider.Provider
+}
+
+// FetchDNSRecords fetches a domain's DNS records with the connection's current credentials
+func (c *SecureClient) FetchDNSRecords(domain string) ([]types.DNSRecord, error) {
+	client, err := c.service.clientFor(c.provider)
+	if err != nil {
+		return nil, err
+	}
+	return client.FetchDNSRecords(domain)
+}
+
+// SyncClients returns a client for every enabled secure connection, keyed by connection ID,
+// for the domain sync to fetch through
+func (s *SecureProviderService) SyncClients() map[string]*SecureClient {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	clients := make(map[string]*SecureClient, len(s.connectedProviders))
+	for id, provider := range s.connectedProviders {
+		if provider.Enabled {
+			clients[id] = &SecureClient{service: s, provider: provider}
+		}
+	}
+	return clients
+}
+
+// loadExistingConnections loads secure credential connections from the database. Credentials
+// are resolved when a connection is used, not here.
 func (s *SecureProviderService) loadExistingConnections() error {
 	credentials, err := s.repository.GetAllSecureCredentials()
 	if err != nil {
@@ -86,26 +179,6 @@ func (s *SecureProviderService) loadExistingConnections() error {
 			continue
 		}
 		
-		// Resolve credentials from environment variables
-		resolvedCreds, err := types.ResolveCredentials(cred.CredentialReference)
-		if err != nil {
-			log.Printf("Warning: Failed to resolve credentials for %s (%s): %v", cred.Name, cred.CredentialReference, err)
-			continue
-		}
-		
-		// Convert to ProviderCredentials format for client creation
-		providerCreds := make(ProviderCredentials)
-		for key, value := range resolvedCreds {
-			providerCreds[key] = value
-		}
-		
-		// Create client
-		client, err := NewClient(cred.Provider, providerCreds)
-		if err != nil {
-			log.Printf("Warning: Failed to create client for %s: %v", cred.Name, err)
-			continue
-		}
-		
 		// Create secure connected provider
 		connectedProvider := &SecureConnectedProvider{
 			ID:                  cred.ID,
@@ -113,7 +186,6 @@ func (s *SecureProviderService) loadExistingConnections() error {
 			Name:                cred.Name,
 			AccountName:         cred.AccountName,
 			CredentialReference: cred.CredentialReference,
-			Client:              client,
 			Enabled:             cred.Enabled,
 			AutoSyncEnabled:     true, // Default to enabled
 			SyncInterval:        24 * time.Hour, // Default interval
@@ -139,30 +211,27 @@ func (s *SecureProviderService) GetAvailableCredentialOptions(provider string) [
 	return types.GetCredentialOptions(provider)
 }
 
-// TestSecureConnection tests a connection using environment variable references
+// TestSecureConnection tests a connection using an environment variable set or secret store reference
 func (s *SecureProviderService) TestSecureConnection(provider, credentialReference string) (*types.ProviderConnectionResponse, error) {
-	// Resolve credentials from environment variables
-	credentials, err := types.ResolveCredentials(credentialReference)
+	credentials, err := resolveSecureCredentials(s.secretStore(), credentialReference)
 	if err != nil {
 		return &types.ProviderConnectionResponse{
 			Success: false,
 			Message: fmt.Sprintf("Failed to resolve credentials: %v", err),
 		}, nil
 	}
-	
-	// Convert to ProviderCredentials format
-	providerCreds := make(ProviderCredentials)
-	for key, value := range credentials {
-		providerCreds[key] = value
-	}
-	
+	return testProviderCredentials(provider, credentials), nil
+}
+
+// testProviderCredentials tests credentials by fetching the provider's domains with them
+func testProviderCredentials(provider string, credentials ProviderCredentials) *types.ProviderConnectionResponse {
 	// Create test client
-	client, err := NewClient(provider, providerCreds)
+	client, err := NewClient(provider, credentials)
 	if err != nil {
 		return &types.ProviderConnectionResponse{
 			Success: false,
 			Message: fmt.Sprintf("Failed to create client: %v", err),
-		}, nil
+		}
 	}
 	
 	// Test the connection by trying to fetch domains
@@ -171,82 +240,105 @@ func (s *SecureProviderService) TestSecureConnection(provider, credentialReferen
 		return &types.ProviderConnectionResponse{
 			Success: false,
 			Message: fmt.Sprintf("Connection test failed: %v", err),
-		}, nil
+		}
 	}
 	
 	return &types.ProviderConnectionResponse{
 		Success:      true,
 		Message:      "Connection successful",
 		DomainsFound: len(domains),
-	}, nil
+	}
 }
 
-// AddSecureConnection adds a new secure provider connection
+// AddSecureConnection adds a new secure provider connection. The request either names an
+// environment variable set or existing secret with CredentialReference, or gives Credentials,
+// which are put in the secret store under a new reference; only the reference is saved with
+// the connection.
 func (s *SecureProviderService) AddSecureConnection(req *types.SecureProviderConnectionRequest) (*types.ProviderConnectionResponse, error) {
+	store := s.secretStore()
 	s.mu.Lock()
 	defer s.mu.Unlock()
+
+	reference := req.CredentialReference
+	switch {
+	case len(req.Credentials) > 0 && reference != "":
+		return nil, fmt.Errorf("%w: give credential_reference or credentials, not both", types.ErrInvalidSecretReference)
+	case len(req.Credentials) > 0:
+		reference = newSecretReference(req.Provider)
+	case reference == "":
+		return nil, fmt.Errorf("%w: credential_reference or credentials is required", types.ErrInvalidSecretReference)
+	}
+
+	// Resolve the credentials up front so a bad reference fails before anything is saved
+	var providerCreds ProviderCredentials
+	if len(req.Credentials) > 0 {
+		providerCreds = make(ProviderCredentials, len(req.Credentials))
+		for key, value := range req.Credentials {
+			providerCreds[key] = value
+		}
+	} else {
+		resolved, err := resolveSecureCredentials(store, reference)
+		if err != nil {
+			return &types.ProviderConnectionResponse{
+				Success: false,
+				Message: fmt.Sprintf("Failed to resolve credentials: %v", err),
+			}, nil
+		}
+		providerCreds = resolved
+	}
+	if _, err := NewClient(req.Provider, providerCreds); err != nil {
+		return &types.ProviderConnectionResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to create client: %v", err),
+		}, nil
+	}
 	
 	// Test connection first if requested
 	if req.TestConnection {
-		testResult, err := s.TestSecureConnection(req.Provider, req.CredentialReference)
-		if err != nil {
-			return nil, fmt.Errorf("connection test failed: %w", err)
-		}
-		if !testResult.Success {
+		if testResult := testProviderCredentials(req.Provider, providerCreds); !testResult.Success {
 			return testResult, nil
 		}
 	}
+
+	if len(req.Credentials) > 0 {
+		if err := store.Put(reference, req.Credentials); err != nil {
+			return &types.ProviderConnectionResponse{
+				Success: false,
+				Message: fmt.Sprintf("Failed to store credentials in the %s secret store: %v", store.Backend(), err),
+			}, nil
+		}
+	}
 	
 	// Create secure credentials record
 	secureCredentials := &types.SecureProviderCredentials{
 		Provider:            req.Provider,
 		Name:                req.Name,
 		AccountName:         req.AccountName,
-		CredentialReference: req.CredentialReference,
+		CredentialReference: reference,
 		Enabled:             true,
 		ConnectionStatus:    "connected",
 	}
 	
 	// Save to database
 	if err := s.repository.CreateSecureCredentials(secureCredentials); err != nil {
+		if len(req.Credentials) > 0 {
+			if delErr := store.Delete(reference); delErr != nil {
+				log.Printf("Warning: Failed to remove unused secret %s: %v", reference, delErr)
+			}
+		}
 		return &types.ProviderConnectionResponse{
 			Success: false,
 			Message: fmt.Sprintf("Failed to save secure credentials: %v", err),
 		}, nil
 	}
 	
-	// Resolve credentials from environment variables for client creation
-	resolvedCreds, err := types.ResolveCredentials(req.CredentialReference)
-	if err != nil {
-		return &types.ProviderConnectionResponse{
-			Success: false,
-			Message: fmt.Sprintf("Failed to resolve credentials: %v", err),
-		}, nil
-	}
-	
-	// Convert to ProviderCredentials format
-	providerCreds := make(ProviderCredentials)
-	for key, value := range resolvedCreds {
-		providerCreds[key] = value
-	}
-	
-	// Create client
-	client, err := NewClient(req.Provider, providerCreds)
-	if err != nil {
-		return &types.ProviderConnectionResponse{
-			Success: false,
-			Message: fmt.Sprintf("Failed to create client: %v", err),
-		}, nil
-	}
-	
 	// Create connected provider
 	connectedProvider := &SecureConnectedProvider{
 		ID:                  secureCredentials.ID,
 		Provider:            req.Provider,
 		Name:                req.Name,  
 		AccountName:         req.AccountName,
-		CredentialReference: req.CredentialReference,
-		Client:              client,
+		CredentialReference: reference,
 		Enabled:             true,
 		AutoSyncEnabled:     req.AutoSync,
 		SyncInterval:        time.Duration(req.SyncIntervalHours) * time.Hour,
@@ -280,7 +372,7 @@ func (s *SecureProviderService) AddSecureConnection(req *types.SecureProviderCon
 		response.SyncStarted = true
 	}
 	
-	log.Printf("Added secure provider connection: %s (%s) using reference %s", req.Name, req.Provider, req.CredentialReference)
+	log.Printf("Added secure provider connection: %s (%s) using reference %s", req.Name, req.Provider, reference)
 	return response, nil
 }
 
@@ -328,6 +420,13 @@ func (s *SecureProviderService) RemoveSecureConnection(id string) error {
 	
 	// Remove from connected providers
 	delete(s.connectedProviders, id)
+
+	// Secrets the connection kept in the store go with it; environment variables are left alone
+	if !types.IsEnvCredentialReference(provider.CredentialReference) {
+		if err := s.secrets.Delete(provider.CredentialReference); err != nil {
+			log.Printf("Warning: Failed to delete secret %s of %s: %v", provider.CredentialReference, provider.Name, err)
+		}
+	}
 	
 	log.Printf("Removed secure provider connection: %s (%s)", provider.Name, provider.Provider)
 	return nil
@@ -355,8 +454,12 @@ func (s *SecureProviderService) SyncSecureProvider(id string, syncFunc func(Regi
 	provider.LastSyncStatus = "syncing"
 	s.mu.Unlock()
 	
-	// Perform sync
-	domains, err := syncFunc(provider.Client)
+	// Perform sync with a client built from the credentials as they are now
+	client, err := s.clientFor(provider)
+	var domains []types.Domain
+	if err == nil {
+		domains, err = syncFunc(client)
+	}
 	if err != nil {
 		s.mu.Lock()
 		provider.LastSyncStatus = fmt.Sprintf("failed: %v", err)
@@ -507,6 +610,7 @@ func (s *SecureProviderService) GetAutoSyncStatus() map[string]interface{} {
 	
 	status := map[string]interface{}{
 		"running":           s.autoSyncScheduler.running,
+		"secret_backend":    s.secrets.Backend(),
 		"total_providers":   len(s.connectedProviders),
 		"enabled_providers": 0,
 		"syncing_providers": len(s.autoSyncScheduler.providers),
@@ -529,7 +633,7 @@ func (s *SecureProviderService) GetAutoSyncStatus() map[string]interface{} {
 			"last_sync_status":  provider.LastSyncStatus,
 			"domains_count":     provider.DomainsCount,
 			"error_count":       provider.ErrorCount,
-			"credential_reference": provider.CredentialReference, // Show which env vars are being used
+			"credential_reference": provider.CredentialReference, // Show which env vars or secret are being used
 		}
 		
 		status["providers"] = append(status["providers"].([]map[string]interface{}), providerStatus)
@@ -2,6 +2,7 @@ package providers
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"sync"
@@ -15,6 +16,9 @@ type ProviderService struct {
 	supportedProviders map[string]types.ProviderInfo
 	connectedProviders map[string]*ConnectedProvider
 	autoSyncScheduler  *AutoSyncScheduler
+	staleMultiplier    int // Sync intervals without a successful sync before a provider is stale, 0 uses the default
+	breakerConfig      BreakerConfig // Settings for the circuit breakers created for connected providers
+	availabilityLimits rateLimiters  // Paces availability checks, per connected provider
 	mu                 sync.RWMutex
 }
 
@@ -43,29 +47,39 @@ func (ps *ProviderService) RegisterClient(providerName string, client RegistrarC
 
 // ConnectedProvider represents a connected provider with its credentials
 type ConnectedProvider struct {
-	ID               string
-	Provider         string
-	Name             string
-	AccountName      string
-	Credentials      ProviderCredentials
-	Client           RegistrarClient
-	Enabled          bool
-	AutoSyncEnabled  bool
-	SyncInterval     time.Duration
-	LastSyncTime     time.Time
-	LastSyncStatus   string
-	ConnectionStatus string
-	DomainsCount     int
-	ErrorCount       int
-	CreatedAt        time.Time
-	UpdatedAt        time.Time
-}
-
-// AutoSyncScheduler manages automatic syncing for providers
+	ID                 string
+	Provider           string
+	Name               string
+	AccountName        string
+	Credentials        ProviderCredentials
+	Client             RegistrarClient
+	Enabled            bool
+	AutoSyncEnabled    bool
+	SyncInterval       time.Duration
+	LastSyncTime       time.Time
+	LastSyncStatus     string
+	LastSuccessfulSync time.Time // Zero until the first successful sync
+	ConnectionStatus   string
+	DomainsCount       int
+	ErrorCount         int
+	Breaker            *CircuitBreaker // Guards syncs; created on first use
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+}
+
+// SyncFunc fetches and stores a connected provider's domains. accountRef is the connected
+// provider's ID, for the sync to record on each domain as its account_ref.
+type SyncFunc func(accountRef string, client RegistrarClient) ([]types.Domain, error)
+
+// defaultProviderSyncInterval applies to connected providers without their own interval
+const defaultProviderSyncInterval = 24 * time.Hour
+
+// AutoSyncScheduler manages automatic syncing for providers, one timer per provider
 type AutoSyncScheduler struct {
 	providers  map[string]*ConnectedProvider
 	syncFunc   func(providerID string) error
 	tickers    map[string]*time.Ticker
+	nextRun    map[string]time.Time
 	ctx        context.Context
 	cancel     context.CancelFunc
 	mu         sync.RWMutex
@@ -82,6 +96,7 @@ func NewProviderService() *ProviderService {
 		autoSyncScheduler: &AutoSyncScheduler{
 			providers: make(map[string]*ConnectedProvider),
 			tickers:   make(map[string]*time.Ticker),
+			nextRun:   make(map[string]time.Time),
 			ctx:       ctx,
 			cancel:    cancel,
 		},
@@ -259,6 +274,120 @@ func initializeSupportedProviders() map[string]types.ProviderInfo {
 				},
 			},
 		},
+		"ovh": {
+			Name:        "ovh",
+			DisplayName: "OVHcloud",
+			Description: "European registrar and DNS host with signed-request API access",
+			DocumentationURL: "https://help.ovhcloud.com/csm/en-gb-api-getting-started-ovhcloud-api?id=kb_article_view&sysparm_article=KB0042784",
+			Fields: []types.ProviderFieldInfo{
+				{
+					Name:        "application_key",
+					DisplayName: "Application Key",
+					Type:        "text",
+					Required:    true,
+					Description: "Application key from createApp",
+					Placeholder: "7kbG7Bk7S9Nt7ZSV",
+				},
+				{
+					Name:        "application_secret",
+					DisplayName: "Application Secret",
+					Type:        "password",
+					Required:    true,
+					Description: "Application secret from createApp",
+					Placeholder: "EXEgWIz07P0HYwtQDs7cNIqCiQaWSuHF",
+				},
+				{
+					Name:        "consumer_key",
+					DisplayName: "Consumer Key",
+					Type:        "password",
+					Required:    true,
+					Description: "Consumer key with GET access to /domain and /domain/zone",
+					Placeholder: "MtSwSrPpNjqfVSmJhLbPyr2i45lSwPU1",
+				},
+				{
+					Name:        "endpoint",
+					DisplayName: "Endpoint (Optional)",
+					Type:        "text",
+					Required:    false,
+					Description: "API region: ovh-eu (default), ovh-ca or ovh-us",
+					Placeholder: "ovh-eu",
+				},
+			},
+		},
+		"dnsimple": {
+			Name:        "dnsimple",
+			DisplayName: "DNSimple",
+			Description: "Developer-focused registrar and DNS host with a REST API",
+			DocumentationURL: "https://developer.dnsimple.com/v2/",
+			Fields: []types.ProviderFieldInfo{
+				{
+					Name:        "access_token",
+					DisplayName: "Access Token",
+					Type:        "password",
+					Required:    true,
+					Description: "Account access token from Account > Access Tokens",
+					Placeholder: "dnsimple_a_xxxxxxxxxxxxxxxxxxxxxxxx",
+				},
+				{
+					Name:        "account_id",
+					DisplayName: "Account ID (Optional)",
+					Type:        "text",
+					Required:    false,
+					Description: "Only needed for user access tokens; account tokens resolve it automatically",
+					Placeholder: "1385",
+				},
+				{
+					Name:        "environment",
+					DisplayName: "Environment (Optional)",
+					Type:        "text",
+					Required:    false,
+					Description: "production (default) or sandbox",
+					Placeholder: "production",
+				},
+			},
+		},
+		"dynadot": {
+			Name:        "dynadot",
+			DisplayName: "Dynadot",
+			Description: "Registrar with a key-based API. Syncs domains, expiry, auto-renew and Dynadot-hosted DNS (read-only)",
+			DocumentationURL: "https://www.dynadot.com/domain/api3.html",
+			Fields: []types.ProviderFieldInfo{
+				{
+					Name:        "api_key",
+					DisplayName: "API Key",
+					Type:        "password",
+					Required:    true,
+					Description: "API key from Tools > API; allow your server's IP address there too",
+					Placeholder: "0A1b2C3d4E5f6G7h8I9j0K1l2M3n4O5p",
+				},
+			},
+			UnsupportedOperations: []string{"auto_renew", "contacts", "availability", "status"},
+		},
+		"hover": {
+			Name:        "hover",
+			DisplayName: "Hover",
+			Description: "Hover has no official API: signs in with your account password and reads domains and DNS through the control panel's endpoints, which may change without notice. Accounts with two-factor authentication are not supported",
+			DocumentationURL: "https://help.hover.com/",
+			Fields: []types.ProviderFieldInfo{
+				{
+					Name:        "username",
+					DisplayName: "Username",
+					Type:        "text",
+					Required:    true,
+					Description: "Your Hover account username",
+					Placeholder: "yourusername",
+				},
+				{
+					Name:        "password",
+					DisplayName: "Password",
+					Type:        "password",
+					Required:    true,
+					Description: "Your Hover account password (two-factor authentication must be off)",
+					Placeholder: "••••••••",
+				},
+			},
+			UnsupportedOperations: []string{"auto_renew", "contacts", "availability", "status"},
+		},
 		"mock": {
 			Name:        "mock",
 			DisplayName: "Mock Provider (Testing)",
@@ -370,8 +499,8 @@ func (ps *ProviderService) AddConnectedProvider(req *types.ProviderConnectionReq
 	}
 	
 	// Set default sync interval if not provided
-	if connectedProvider.SyncInterval == 0 {
-		connectedProvider.SyncInterval = 24 * time.Hour // Default to 24 hours
+	if connectedProvider.SyncInterval <= 0 {
+		connectedProvider.SyncInterval = defaultProviderSyncInterval
 	}
 	
 	// Add to connected providers
@@ -438,19 +567,21 @@ func (ps *ProviderService) UpdateConnectedProvider(id string, updates map[string
 	if enabled, ok := updates["enabled"].(bool); ok {
 		provider.Enabled = enabled
 	}
+	reschedule := false
 	if autoSync, ok := updates["auto_sync_enabled"].(bool); ok {
+		reschedule = reschedule || autoSync != provider.AutoSyncEnabled
 		provider.AutoSyncEnabled = autoSync
-		if autoSync {
-			ps.autoSyncScheduler.AddProvider(provider)
-		} else {
-			ps.autoSyncScheduler.RemoveProvider(id)
-		}
 	}
-	if interval, ok := updates["sync_interval_hours"].(float64); ok {
-		provider.SyncInterval = time.Duration(interval) * time.Hour
-		if provider.AutoSyncEnabled {
-			ps.autoSyncScheduler.UpdateProvider(provider)
+	if hours, ok := updates["sync_interval_hours"].(float64); ok {
+		if hours <= 0 {
+			return fmt.Errorf("sync_interval_hours must be positive")
 		}
+		interval := time.Duration(hours * float64(time.Hour))
+		reschedule = reschedule || interval != provider.SyncInterval
+		provider.SyncInterval = interval
+	}
+	if reschedule {
+		ps.autoSyncScheduler.UpdateProvider(provider)
 	}
 	
 	provider.UpdatedAt = time.Now()
@@ -478,7 +609,7 @@ func (ps *ProviderService) RemoveConnectedProvider(id string) error {
 }
 
 // SyncProvider syncs a specific provider
-func (ps *ProviderService) SyncProvider(id string, syncFunc func(RegistrarClient) ([]types.Domain, error)) error {
+func (ps *ProviderService) SyncProvider(id string, syncFunc SyncFunc) error {
 	ps.mu.RLock()
 	provider, exists := ps.connectedProviders[id]
 	ps.mu.RUnlock()
@@ -497,14 +628,30 @@ func (ps *ProviderService) SyncProvider(id string, syncFunc func(RegistrarClient
 	ps.mu.Lock()
 	provider.LastSyncTime = time.Now()
 	provider.LastSyncStatus = "syncing"
+	breaker := ps.breakerLocked(provider)
 	ps.mu.Unlock()
 	
-	// Perform sync
-	domains, err := syncFunc(provider.Client)
+	// Perform sync, skipped while the provider's circuit breaker is open
+	var domains []types.Domain
+	err := breaker.Call(func() error {
+		fetched, err := syncFunc(provider.ID, provider.Client)
+		if err == nil {
+			domains = fetched
+		}
+		return err
+	})
+	if errors.Is(err, types.ErrMaintenanceMode) {
+		// Skipped before reaching the provider, so it isn't a provider error
+		ps.mu.Lock()
+		provider.LastSyncStatus = "skipped: maintenance mode"
+		ps.mu.Unlock()
+		return err
+	}
 	if err != nil {
 		ps.mu.Lock()
 		provider.LastSyncStatus = fmt.Sprintf("failed: %v", err)
 		provider.ErrorCount++
+		ps.updateBreakerStatusLocked(provider)
 		ps.mu.Unlock()
 		return err
 	}
@@ -512,8 +659,14 @@ func (ps *ProviderService) SyncProvider(id string, syncFunc func(RegistrarClient
 	// Update sync status
 	ps.mu.Lock()
 	provider.LastSyncStatus = "success"
+	provider.LastSuccessfulSync = time.Now()
 	provider.DomainsCount = len(domains)
 	provider.UpdatedAt = time.Now()
+	if provider.ConnectionStatus == ConnectionStatusStale {
+		provider.ConnectionStatus = ConnectionStatusConnected
+		log.Printf("Provider %s (%s) is no longer stale", provider.Name, provider.Provider)
+	}
+	ps.updateBreakerStatusLocked(provider)
 	ps.mu.Unlock()
 	
 	log.Printf("Sync completed for provider: %s (%s) - %d domains", provider.Name, provider.Provider, len(domains))
@@ -521,7 +674,7 @@ func (ps *ProviderService) SyncProvider(id string, syncFunc func(RegistrarClient
 }
 
 // SyncAllProviders syncs all enabled providers
-func (ps *ProviderService) SyncAllProviders(syncFunc func(RegistrarClient) ([]types.Domain, error)) error {
+func (ps *ProviderService) SyncAllProviders(syncFunc SyncFunc) error {
 	ps.mu.RLock()
 	providers := make([]*ConnectedProvider, 0, len(ps.connectedProviders))
 	for _, provider := range ps.connectedProviders {
@@ -552,21 +705,34 @@ func (ps *ProviderService) SyncAllProviders(syncFunc func(RegistrarClient) ([]ty
 // AUTO-SYNC SCHEDULER
 // ============================================================================
 
-// StartAutoSync starts the auto-sync scheduler
-func (ps *ProviderService) StartAutoSync(syncFunc func(RegistrarClient) ([]types.Domain, error)) {
-	ps.autoSyncScheduler.mu.Lock()
-	defer ps.autoSyncScheduler.mu.Unlock()
+// StartAutoSync starts the auto-sync scheduler, giving every auto-sync provider its own timer
+func (ps *ProviderService) StartAutoSync(syncFunc SyncFunc) {
+	ps.mu.RLock()
+	defer ps.mu.RUnlock()
+
+	scheduler := ps.autoSyncScheduler
+	scheduler.mu.Lock()
+	defer scheduler.mu.Unlock()
 	
-	if ps.autoSyncScheduler.running {
+	if scheduler.running {
 		return
 	}
 	
-	ps.autoSyncScheduler.syncFunc = func(providerID string) error {
+	scheduler.syncFunc = func(providerID string) error {
 		return ps.SyncProvider(providerID, syncFunc)
 	}
 	
-	ps.autoSyncScheduler.running = true
-	log.Println("Auto-sync scheduler started")
+	// A stopped scheduler's context is cancelled, so each run needs a fresh one
+	scheduler.ctx, scheduler.cancel = context.WithCancel(context.Background())
+	scheduler.running = true
+
+	for _, provider := range ps.connectedProviders {
+		if provider.AutoSyncEnabled {
+			scheduler.addProviderLocked(provider)
+		}
+	}
+
+	log.Printf("Auto-sync scheduler started (%d providers scheduled)", len(scheduler.tickers))
 }
 
 // StopAutoSync stops the auto-sync scheduler
@@ -583,6 +749,8 @@ func (ps *ProviderService) StopAutoSync() {
 		ticker.Stop()
 	}
 	ps.autoSyncScheduler.tickers = make(map[string]*time.Ticker)
+	ps.autoSyncScheduler.providers = make(map[string]*ConnectedProvider)
+	ps.autoSyncScheduler.nextRun = make(map[string]time.Time)
 	
 	// Cancel context
 	ps.autoSyncScheduler.cancel()
@@ -595,30 +763,48 @@ func (ps *ProviderService) StopAutoSync() {
 func (scheduler *AutoSyncScheduler) AddProvider(provider *ConnectedProvider) {
 	scheduler.mu.Lock()
 	defer scheduler.mu.Unlock()
-	
+	scheduler.addProviderLocked(provider)
+}
+
+// addProviderLocked starts (or restarts) a provider's timer at its own interval; caller holds scheduler.mu
+func (scheduler *AutoSyncScheduler) addProviderLocked(provider *ConnectedProvider) {
 	if !scheduler.running || !provider.AutoSyncEnabled {
 		return
 	}
 	
 	// Remove existing ticker if any
-	if ticker, exists := scheduler.tickers[provider.ID]; exists {
-		ticker.Stop()
+	scheduler.removeProviderLocked(provider.ID)
+
+	interval := provider.SyncInterval
+	if interval <= 0 {
+		interval = defaultProviderSyncInterval
 	}
 	
 	// Create new ticker
-	ticker := time.NewTicker(provider.SyncInterval)
+	ticker := time.NewTicker(interval)
 	scheduler.tickers[provider.ID] = ticker
 	scheduler.providers[provider.ID] = provider
+	scheduler.nextRun[provider.ID] = time.Now().Add(interval)
 	
 	// Start goroutine for this provider
-	go func(providerID string, ticker *time.Ticker) {
+	go func(ctx context.Context, providerID string, ticker *time.Ticker) {
 		for {
 			select {
-			case <-scheduler.ctx.Done():
+			case <-ctx.Done():
 				return
 			case <-ticker.C:
-				if scheduler.syncFunc != nil {
-					if err := scheduler.syncFunc(providerID); err != nil {
+				scheduler.mu.Lock()
+				if scheduler.tickers[providerID] != ticker {
+					// Replaced or removed while this tick was pending
+					scheduler.mu.Unlock()
+					return
+				}
+				scheduler.nextRun[providerID] = time.Now().Add(interval)
+				syncFunc := scheduler.syncFunc
+				scheduler.mu.Unlock()
+
+				if syncFunc != nil {
+					if err := syncFunc(providerID); err != nil {
 						log.Printf("Auto-sync failed for provider %s: %v", providerID, err)
 					} else {
 						log.Printf("Auto-sync completed for provider %s", providerID)
@@ -626,9 +812,9 @@ func (scheduler *AutoSyncScheduler) AddProvider(provider *ConnectedProvider) {
 				}
 			}
 		}
-	}(provider.ID, ticker)
+	}(scheduler.ctx, provider.ID, ticker)
 	
-	log.Printf("Added provider %s to auto-sync scheduler (interval: %v)", provider.Name, provider.SyncInterval)
+	log.Printf("Added provider %s to auto-sync scheduler (interval: %v)", provider.Name, interval)
 }
 
 // RemoveProvider removes a provider from the auto-sync scheduler
@@ -636,19 +822,33 @@ func (scheduler *AutoSyncScheduler) RemoveProvider(providerID string) {
 	scheduler.mu.Lock()
 	defer scheduler.mu.Unlock()
 	
-	if ticker, exists := scheduler.tickers[providerID]; exists {
+	if scheduler.removeProviderLocked(providerID) {
+		log.Printf("Removed provider %s from auto-sync scheduler", providerID)
+	}
+}
+
+// removeProviderLocked stops a provider's timer; caller holds scheduler.mu
+func (scheduler *AutoSyncScheduler) removeProviderLocked(providerID string) bool {
+	ticker, exists := scheduler.tickers[providerID]
+	if exists {
 		ticker.Stop()
 		delete(scheduler.tickers, providerID)
 	}
-	
 	delete(scheduler.providers, providerID)
-	log.Printf("Removed provider %s from auto-sync scheduler", providerID)
+	delete(scheduler.nextRun, providerID)
+	return exists
 }
 
-// UpdateProvider updates a provider in the auto-sync scheduler
+// UpdateProvider reschedules a provider after its interval or auto-sync setting changed
 func (scheduler *AutoSyncScheduler) UpdateProvider(provider *ConnectedProvider) {
-	scheduler.RemoveProvider(provider.ID)
-	scheduler.AddProvider(provider)
+	scheduler.mu.Lock()
+	defer scheduler.mu.Unlock()
+
+	if !provider.AutoSyncEnabled {
+		scheduler.removeProviderLocked(provider.ID)
+		return
+	}
+	scheduler.addProviderLocked(provider)
 }
 
 // GetAutoSyncStatus returns the status of auto-sync for all providers
@@ -683,6 +883,12 @@ func (ps *ProviderService) GetAutoSyncStatus() map[string]interface{} {
 			"last_sync_status":  provider.LastSyncStatus,
 			"domains_count":     provider.DomainsCount,
 			"error_count":       provider.ErrorCount,
+			"connection_status": provider.ConnectionStatus,
+			"breaker":           provider.BreakerStatus(),
+			"next_sync_time":    nil,
+		}
+		if next, scheduled := ps.autoSyncScheduler.nextRun[provider.ID]; scheduled {
+			providerStatus["next_sync_time"] = next
 		}
 		
 		status["providers"] = append(status["providers"].([]map[string]interface{}), providerStatus)
@@ -770,6 +976,37 @@ func (ps *ProviderService) SearchDomains(request types.DomainSearchRequest) ([]t
 	return results, nil
 }
 
+// SetAvailabilityRateLimit sets how many availability checks a second are sent to each
+// connected provider; 0 doesn't limit
+func (ps *ProviderService) SetAvailabilityRateLimit(perSecond float64) {
+	ps.availabilityLimits.setRate(perSecond)
+}
+
+// CheckAvailability asks the enabled connected providers that support availability checks
+// whether a domain can be registered, returning the first answer. It returns
+// ErrAvailabilityUnsupported when no connected provider can check.
+func (ps *ProviderService) CheckAvailability(domain string) (*types.DomainAvailability, error) {
+	ps.mu.RLock()
+	checkers := make(map[string]AvailabilityChecker)
+	for id, provider := range ps.connectedProviders {
+		if checker, ok := provider.Client.(AvailabilityChecker); ok && provider.Enabled {
+			checkers[id] = checker
+		}
+	}
+	ps.mu.RUnlock()
+
+	lastErr := types.ErrAvailabilityUnsupported
+	for id, checker := range checkers {
+		ps.availabilityLimits.wait(context.Background(), id)
+		availability, err := checker.CheckAvailability(domain)
+		if err == nil {
+			return availability, nil
+		}
+		lastErr = err
+	}
+	return nil, lastErr
+}
+
 // PurchaseDomains handles domain purchase requests
 func (ps *ProviderService) PurchaseDomains(request types.DomainPurchaseRequest) (*types.DomainPurchaseResponse, error) {
 	ps.mu.RLock()
@@ -785,8 +1022,9 @@ func (ps *ProviderService) PurchaseDomains(request types.DomainPurchaseRequest)
 	
 	if !provider.Enabled {
 		return &types.DomainPurchaseResponse{
-			Success: false,
-			Message: "Provider is disabled",
+			Success:  false,
+			Message:  "Provider is disabled",
+			Provider: provider.Provider,
 		}, nil
 	}
 	
@@ -800,6 +1038,7 @@ func (ps *ProviderService) PurchaseDomains(request types.DomainPurchaseRequest)
 		TotalCost:        0,
 		Currency:         "USD",
 		TransactionID:    fmt.Sprintf("tx_%d", time.Now().UnixNano()),
+		Provider:         provider.Provider,
 	}
 	
 	for _, domainItem := range request.Domains {
@@ -0,0 +1,75 @@
+package providers
+
+import (
+	"log"
+	"time"
+)
+
+// ConnectionStatusStale marks a connected provider that hasn't synced successfully for
+// several of its sync intervals, usually because its credentials expired or were rotated
+const ConnectionStatusStale = "stale"
+
+// DefaultStaleMultiplier is how many sync intervals a provider may go without a successful
+// sync before it's stale
+const DefaultStaleMultiplier = 3
+
+// SetStaleMultiplier sets how many sync intervals a provider may go without a successful sync
+// before it's stale; 0 or less uses DefaultStaleMultiplier
+func (ps *ProviderService) SetStaleMultiplier(multiplier int) {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	ps.staleMultiplier = multiplier
+}
+
+// StaleAfter returns how long a provider may go without a successful sync before it's stale
+func (ps *ProviderService) StaleAfter(provider *ConnectedProvider) time.Duration {
+	ps.mu.RLock()
+	defer ps.mu.RUnlock()
+	return ps.staleAfterLocked(provider)
+}
+
+// staleAfterLocked is StaleAfter for a caller holding ps.mu
+func (ps *ProviderService) staleAfterLocked(provider *ConnectedProvider) time.Duration {
+	multiplier := ps.staleMultiplier
+	if multiplier <= 0 {
+		multiplier = DefaultStaleMultiplier
+	}
+	interval := provider.SyncInterval
+	if interval <= 0 {
+		interval = defaultProviderSyncInterval
+	}
+	return time.Duration(multiplier) * interval
+}
+
+// LastSuccessAge returns how long ago the provider last synced successfully, or how long it
+// has been connected if it never has
+func (p *ConnectedProvider) LastSuccessAge(now time.Time) time.Duration {
+	if p.LastSuccessfulSync.IsZero() {
+		return now.Sub(p.CreatedAt)
+	}
+	return now.Sub(p.LastSuccessfulSync)
+}
+
+// CheckStaleProviders marks enabled auto-sync providers stale once they've gone too long
+// without a successful sync, and returns copies of the ones that became stale in this check.
+// A provider stays stale until its next successful sync. Providers without auto-sync aren't
+// expected to sync on their own, so they're never stale.
+func (ps *ProviderService) CheckStaleProviders(now time.Time) []ConnectedProvider {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+
+	var stale []ConnectedProvider
+	for _, provider := range ps.connectedProviders {
+		if !provider.Enabled || !provider.AutoSyncEnabled || provider.ConnectionStatus == ConnectionStatusStale {
+			continue
+		}
+		if provider.LastSuccessAge(now) <= ps.staleAfterLocked(provider) {
+			continue
+		}
+		provider.ConnectionStatus = ConnectionStatusStale
+		provider.UpdatedAt = now
+		log.Printf("Provider %s (%s) is stale: no successful sync for %s", provider.Name, provider.Provider, provider.LastSuccessAge(now).Round(time.Minute))
+		stale = append(stale, *provider)
+	}
+	return stale
+}
@@ -0,0 +1,199 @@
+package providers
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rusiqe/domainvault/internal/types"
+)
+
+// DefaultWHOISServer is the IANA server that refers each TLD to its registry's WHOIS server
+const DefaultWHOISServer = "whois.iana.org"
+
+// whoisExpiryKeys are the field names registries use for a domain's expiry, most specific first
+var whoisExpiryKeys = []string{
+	"registry expiry date",
+	"registrar registration expiration date",
+	"expiration date",
+	"expiry date",
+	"expiration time",
+	"expires on",
+	"expire date",
+	"paid-till",
+	"expires",
+}
+
+// whoisCreatedKeys are the field names registries use for a domain's registration date
+var whoisCreatedKeys = []string{
+	"creation date",
+	"domain registration date",
+	"registration time",
+	"registered on",
+	"created on",
+	"created",
+	"registered",
+}
+
+// whoisDateLayouts are the date formats registries write dates in
+var whoisDateLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05Z",
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04:05 MST",
+	"2006-01-02",
+	"2006.01.02",
+	"2006/01/02",
+	"02-Jan-2006",
+	"02.01.2006",
+}
+
+// WHOISClient reads domain expiries over classic WHOIS (port 43). It's the fallback for
+// registries without RDAP: the format is free text, so expiry is found by field name.
+type WHOISClient struct {
+	server   string
+	timeout  time.Duration
+	limiters rateLimiters // One per server queried
+
+	mu        sync.Mutex
+	referrals map[string]string // Registry WHOIS server per TLD, from DefaultWHOISServer
+}
+
+// NewWHOISClient creates a WHOIS client; an empty server asks DefaultWHOISServer which
+// registry server to query for each TLD
+func NewWHOISClient(server string) *WHOISClient {
+	if server == "" {
+		server = DefaultWHOISServer
+	}
+	return &WHOISClient{server: server, timeout: 15 * time.Second, referrals: make(map[string]string)}
+}
+
+// SetRateLimit sets how many queries a second the client sends to each WHOIS server; 0
+// doesn't limit. Registries block clients that query too fast.
+func (w *WHOISClient) SetRateLimit(perSecond float64) {
+	w.limiters.setRate(perSecond)
+}
+
+// LookupExpiry queries the domain's registry WHOIS server and parses the expiry out of the reply
+func (w *WHOISClient) LookupExpiry(domain string) (*types.RegistryExpiry, error) {
+	domain = types.NormalizeDomainName(domain)
+	server := w.server
+	if server == DefaultWHOISServer {
+		var err error
+		if server, err = w.registryServer(domain[strings.LastIndex(domain, ".")+1:]); err != nil {
+			return nil, err
+		}
+	}
+
+	reply, err := w.query(server, domain)
+	if err != nil {
+		return nil, err
+	}
+	expires, err := ParseWHOISExpiry(reply)
+	if err != nil {
+		return nil, fmt.Errorf("WHOIS reply for %s from %s: %w", domain, server, err)
+	}
+	result := &types.RegistryExpiry{RegistryExpiresAt: &expires, Source: types.ExpirySourceWHOIS}
+	if registered, err := ParseWHOISCreated(reply); err == nil {
+		result.RegisteredAt = &registered
+	}
+	return result, nil
+}
+
+// registryServer asks DefaultWHOISServer for a TLD's registry WHOIS server, remembering the
+// answer so a batch of lookups asks once per TLD
+func (w *WHOISClient) registryServer(tld string) (string, error) {
+	w.mu.Lock()
+	server, ok := w.referrals[tld]
+	w.mu.Unlock()
+	if ok {
+		return server, nil
+	}
+
+	referral, err := w.query(DefaultWHOISServer, tld)
+	if err != nil {
+		return "", err
+	}
+	if server = whoisField(referral, "refer", "whois"); server == "" {
+		return "", fmt.Errorf("no WHOIS server known for .%s", tld)
+	}
+	w.mu.Lock()
+	w.referrals[tld] = server
+	w.mu.Unlock()
+	return server, nil
+}
+
+// query sends one WHOIS query, once the server's rate limit allows, and reads the whole reply
+func (w *WHOISClient) query(server, query string) (string, error) {
+	w.limiters.wait(context.Background(), server)
+	conn, err := net.DialTimeout("tcp", net.JoinHostPort(server, "43"), w.timeout)
+	if err != nil {
+		return "", fmt.Errorf("WHOIS connection to %s failed: %w", server, err)
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(w.timeout))
+
+	if _, err := fmt.Fprintf(conn, "%s\r\n", query); err != nil {
+		return "", fmt.Errorf("WHOIS query to %s failed: %w", server, err)
+	}
+	reply, err := io.ReadAll(io.LimitReader(conn, 1<<20))
+	if err != nil {
+		return "", fmt.Errorf("failed to read WHOIS reply from %s: %w", server, err)
+	}
+	return string(reply), nil
+}
+
+// ParseWHOISExpiry finds a domain's expiry in a WHOIS reply, returned in UTC
+func ParseWHOISExpiry(reply string) (time.Time, error) {
+	return parseWHOISDate(reply, "expiry", whoisExpiryKeys)
+}
+
+// ParseWHOISCreated finds a domain's registration date in a WHOIS reply, returned in UTC
+func ParseWHOISCreated(reply string) (time.Time, error) {
+	return parseWHOISDate(reply, "registration", whoisCreatedKeys)
+}
+
+// parseWHOISDate parses the first of keys found in a WHOIS reply as a date; what names the
+// date in errors
+func parseWHOISDate(reply, what string, keys []string) (time.Time, error) {
+	value := whoisField(reply, keys...)
+	if value == "" {
+		return time.Time{}, fmt.Errorf("no %s date found", what)
+	}
+	for _, layout := range whoisDateLayouts {
+		if date, err := time.Parse(layout, value); err == nil {
+			return date.UTC(), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unrecognized %s date %q", what, value)
+}
+
+// whoisField returns the value of the first of keys found in a "key: value" WHOIS reply,
+// matching keys case-insensitively
+func whoisField(reply string, keys ...string) string {
+	fields := make(map[string]string)
+	scanner := bufio.NewScanner(strings.NewReader(reply))
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(scanner.Text(), ":")
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+		if !ok || value == "" {
+			continue
+		}
+		if _, seen := fields[key]; !seen {
+			fields[key] = value
+		}
+	}
+	for _, key := range keys {
+		if value, ok := fields[key]; ok {
+			return value
+		}
+	}
+	return ""
+}
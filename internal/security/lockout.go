@@ -0,0 +1,166 @@
+package security
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// ErrAccountLocked is returned by ValidateLogin while a username has too many recent failed
+// logins from an IP address
+var ErrAccountLocked = errors.New("account temporarily locked due to multiple failed login attempts")
+
+// ErrLockoutUnavailable is returned when there is no login attempt store to check or clear
+var ErrLockoutUnavailable = errors.New("login attempt tracking is not configured")
+
+// LockedAccount is a username locked out from one IP address
+type LockedAccount struct {
+	Username       string    `json:"username"`
+	IPAddress      string    `json:"ip_address"`
+	FailedAttempts int       `json:"failed_attempts"`
+	LastAttemptAt  time.Time `json:"last_attempt_at"`
+	LockedUntil    time.Time `json:"locked_until"`
+}
+
+// LoginAttemptStatus is how many more failed logins a username can make from an IP address
+// before it's locked out
+type LoginAttemptStatus struct {
+	Username          string     `json:"username"`
+	IPAddress         string     `json:"ip_address"`
+	MaxAttempts       int        `json:"max_attempts"`
+	FailedAttempts    int        `json:"failed_attempts"`
+	RemainingAttempts int        `json:"remaining_attempts"`
+	Locked            bool       `json:"locked"`
+	LockedUntil       *time.Time `json:"locked_until,omitempty"`
+	WindowMinutes     int        `json:"window_minutes"` // Failures older than this no longer count
+}
+
+// SetLockoutPolicy changes how many failed logins inside window lock an account out. It applies
+// to the next login check; attempts already recorded are judged by the new policy.
+func (s *SecurityService) SetLockoutPolicy(maxAttempts int, window time.Duration) {
+	s.policyMu.Lock()
+	defer s.policyMu.Unlock()
+	s.config.MaxLoginAttempts = maxAttempts
+	s.config.LockoutDuration = window
+}
+
+// lockoutPolicy returns the failed logins that lock an account and the window they count in
+func (s *SecurityService) lockoutPolicy() (int, time.Duration) {
+	s.policyMu.RLock()
+	defer s.policyMu.RUnlock()
+	return s.config.MaxLoginAttempts, s.config.LockoutDuration
+}
+
+// recentFailures returns a username's failed logins from ipAddress inside the lockout window,
+// oldest first
+func (s *SecurityService) recentFailures(username, ipAddress string) ([]LoginAttempt, error) {
+	_, window := s.lockoutPolicy()
+	attempts, err := s.securityRepo.GetLoginAttempts(ipAddress, s.now().Add(-window))
+	if err != nil {
+		return nil, fmt.Errorf("failed to check login attempts: %w", err)
+	}
+
+	var failures []LoginAttempt
+	for _, attempt := range attempts {
+		if !attempt.Success && attempt.Username == username {
+			failures = append(failures, attempt)
+		}
+	}
+	sort.Slice(failures, func(i, j int) bool { return failures[i].CreatedAt.Before(failures[j].CreatedAt) })
+	return failures, nil
+}
+
+// lockedUntil returns when enough of failures (oldest first) will have aged out of the lockout
+// window for the lock to lift, or nil if they don't amount to a lock
+func (s *SecurityService) lockedUntil(failures []LoginAttempt) *time.Time {
+	maxAttempts, window := s.lockoutPolicy()
+	if maxAttempts <= 0 || len(failures) < maxAttempts {
+		return nil
+	}
+	until := failures[len(failures)-maxAttempts].CreatedAt.Add(window)
+	return &until
+}
+
+// LockedAccounts returns every username and IP address pair currently locked out, the most
+// recently failed first
+func (s *SecurityService) LockedAccounts() ([]LockedAccount, error) {
+	if s.securityRepo == nil {
+		return nil, ErrLockoutUnavailable
+	}
+	_, window := s.lockoutPolicy()
+	attempts, err := s.securityRepo.GetLoginAttempts("", s.now().Add(-window))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get login attempts: %w", err)
+	}
+
+	type pair struct{ username, ipAddress string }
+	failures := make(map[pair][]LoginAttempt)
+	for _, attempt := range attempts {
+		if !attempt.Success {
+			key := pair{attempt.Username, attempt.IPAddress}
+			failures[key] = append(failures[key], attempt)
+		}
+	}
+
+	locked := []LockedAccount{}
+	for key, list := range failures {
+		sort.Slice(list, func(i, j int) bool { return list[i].CreatedAt.Before(list[j].CreatedAt) })
+		until := s.lockedUntil(list)
+		if until == nil {
+			continue
+		}
+		locked = append(locked, LockedAccount{
+			Username:       key.username,
+			IPAddress:      key.ipAddress,
+			FailedAttempts: len(list),
+			LastAttemptAt:  list[len(list)-1].CreatedAt,
+			LockedUntil:    *until,
+		})
+	}
+	sort.Slice(locked, func(i, j int) bool { return locked[i].LastAttemptAt.After(locked[j].LastAttemptAt) })
+	return locked, nil
+}
+
+// UnlockAccount lifts a lockout early by clearing the recent failed logins for a username, an
+// IP address, or a username from one IP address. It returns how many attempts were cleared.
+func (s *SecurityService) UnlockAccount(username, ipAddress string) (int, error) {
+	if username == "" && ipAddress == "" {
+		return 0, fmt.Errorf("username or ip_address is required")
+	}
+	if s.securityRepo == nil {
+		return 0, ErrLockoutUnavailable
+	}
+	_, window := s.lockoutPolicy()
+	cleared, err := s.securityRepo.ClearFailedLoginAttempts(username, ipAddress, s.now().Add(-window))
+	if err != nil {
+		return 0, fmt.Errorf("failed to clear login attempts: %w", err)
+	}
+	return cleared, nil
+}
+
+// LoginAttemptStatus reports how close a username is to being locked out from ipAddress
+func (s *SecurityService) LoginAttemptStatus(username, ipAddress string) (*LoginAttemptStatus, error) {
+	if s.securityRepo == nil {
+		return nil, ErrLockoutUnavailable
+	}
+	failures, err := s.recentFailures(username, ipAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	maxAttempts, window := s.lockoutPolicy()
+	status := &LoginAttemptStatus{
+		Username:       username,
+		IPAddress:      ipAddress,
+		MaxAttempts:    maxAttempts,
+		FailedAttempts: len(failures),
+		WindowMinutes:  int(window / time.Minute),
+		LockedUntil:    s.lockedUntil(failures),
+	}
+	status.Locked = status.LockedUntil != nil
+	if remaining := maxAttempts - len(failures); remaining > 0 {
+		status.RemainingAttempts = remaining
+	}
+	return status, nil
+}
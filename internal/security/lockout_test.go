@@ -0,0 +1,123 @@
+package security
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func newLockoutService(now *time.Time) *SecurityService {
+	svc := NewSecurityService(nil, nil, NewMemorySecurityRepository(), SecurityConfig{
+		MaxLoginAttempts: 3,
+		LockoutDuration:  15 * time.Minute,
+	})
+	svc.now = func() time.Time { return *now }
+	return svc
+}
+
+func TestLockout_LocksAfterMaxFailures(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	svc := newLockoutService(&now)
+
+	for i := 0; i < 3; i++ {
+		if err := svc.ValidateLogin("203.0.113.10", "alice", "test"); err != nil {
+			t.Fatalf("attempt %d: unexpected error %v", i+1, err)
+		}
+		svc.RecordLoginAttempt("203.0.113.10", "alice", "test", false)
+		now = now.Add(time.Minute)
+	}
+
+	if err := svc.ValidateLogin("203.0.113.10", "alice", "test"); !errors.Is(err, ErrAccountLocked) {
+		t.Fatalf("Expected ErrAccountLocked, got %v", err)
+	}
+	if err := svc.ValidateLogin("198.51.100.7", "alice", "test"); err != nil {
+		t.Errorf("Expected another IP not to be locked, got %v", err)
+	}
+
+	status, err := svc.LoginAttemptStatus("alice", "203.0.113.10")
+	if err != nil {
+		t.Fatalf("LoginAttemptStatus() error = %v", err)
+	}
+	if !status.Locked || status.RemainingAttempts != 0 || status.FailedAttempts != 3 {
+		t.Errorf("Unexpected status %+v", status)
+	}
+	expectedUntil := time.Date(2024, 6, 1, 12, 15, 0, 0, time.UTC)
+	if status.LockedUntil == nil || !status.LockedUntil.Equal(expectedUntil) {
+		t.Errorf("Expected lock to lift at %v, got %v", expectedUntil, status.LockedUntil)
+	}
+
+	locked, err := svc.LockedAccounts()
+	if err != nil {
+		t.Fatalf("LockedAccounts() error = %v", err)
+	}
+	if len(locked) != 1 || locked[0].Username != "alice" || locked[0].IPAddress != "203.0.113.10" {
+		t.Fatalf("Unexpected locked accounts %+v", locked)
+	}
+
+	// The lock lifts once the oldest failure leaves the window
+	now = expectedUntil.Add(time.Second)
+	if err := svc.ValidateLogin("203.0.113.10", "alice", "test"); err != nil {
+		t.Errorf("Expected lock to have expired, got %v", err)
+	}
+}
+
+func TestLockout_Unlock(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	svc := newLockoutService(&now)
+
+	for i := 0; i < 3; i++ {
+		svc.RecordLoginAttempt("203.0.113.10", "alice", "test", false)
+		svc.RecordLoginAttempt("203.0.113.10", "bob", "test", false)
+	}
+	svc.RecordLoginAttempt("203.0.113.10", "alice", "test", true)
+
+	if _, err := svc.UnlockAccount("", ""); err == nil {
+		t.Error("Expected an error when neither username nor IP is given")
+	}
+
+	cleared, err := svc.UnlockAccount("alice", "")
+	if err != nil {
+		t.Fatalf("UnlockAccount() error = %v", err)
+	}
+	if cleared != 3 {
+		t.Errorf("Expected 3 cleared attempts, got %d", cleared)
+	}
+	if err := svc.ValidateLogin("203.0.113.10", "alice", "test"); err != nil {
+		t.Errorf("Expected alice to be unlocked, got %v", err)
+	}
+	if err := svc.ValidateLogin("203.0.113.10", "bob", "test"); !errors.Is(err, ErrAccountLocked) {
+		t.Errorf("Expected bob to stay locked, got %v", err)
+	}
+
+	status, _ := svc.LoginAttemptStatus("alice", "203.0.113.10")
+	if status.RemainingAttempts != 3 || status.Locked {
+		t.Errorf("Unexpected status after unlock %+v", status)
+	}
+}
+
+func TestLockout_WithoutRepository(t *testing.T) {
+	svc := NewSecurityService(nil, nil, nil, SecurityConfig{MaxLoginAttempts: 3})
+	if _, err := svc.LockedAccounts(); !errors.Is(err, ErrLockoutUnavailable) {
+		t.Errorf("Expected ErrLockoutUnavailable, got %v", err)
+	}
+	if err := svc.ValidateLogin("203.0.113.10", "alice", "test"); err != nil {
+		t.Errorf("Expected logins to be allowed without a repository, got %v", err)
+	}
+}
+
+func TestLockout_PolicyChangeAppliesToNextCheck(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	svc := newLockoutService(&now)
+
+	for i := 0; i < 2; i++ {
+		svc.RecordLoginAttempt("203.0.113.10", "alice", "test", false)
+	}
+	if err := svc.ValidateLogin("203.0.113.10", "alice", "test"); err != nil {
+		t.Fatalf("Expected no lock under the initial policy, got %v", err)
+	}
+
+	svc.SetLockoutPolicy(2, 15*time.Minute)
+	if err := svc.ValidateLogin("203.0.113.10", "alice", "test"); !errors.Is(err, ErrAccountLocked) {
+		t.Errorf("Expected ErrAccountLocked after lowering max attempts, got %v", err)
+	}
+}
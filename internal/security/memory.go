@@ -0,0 +1,132 @@
+package security
+
+import (
+	"sync"
+	"time"
+)
+
+// Bounds on what MemorySecurityRepository keeps
+const (
+	memoryAttemptRetention = 24 * time.Hour
+	memoryMaxAlerts        = 1000
+)
+
+// MemorySecurityRepository keeps login attempts, alerts and rules in memory. Lockouts don't
+// survive a restart, which only shortens them; attempts older than a day are dropped.
+type MemorySecurityRepository struct {
+	mu       sync.RWMutex
+	attempts []LoginAttempt
+	alerts   []SecurityAlert
+	rules    []SecurityRule
+}
+
+var _ SecurityRepository = (*MemorySecurityRepository)(nil)
+
+// NewMemorySecurityRepository creates an empty in-memory security repository
+func NewMemorySecurityRepository() *MemorySecurityRepository {
+	return &MemorySecurityRepository{}
+}
+
+func (m *MemorySecurityRepository) CreateSecurityAlert(alert *SecurityAlert) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.alerts = append(m.alerts, *alert)
+	if len(m.alerts) > memoryMaxAlerts {
+		m.alerts = append([]SecurityAlert(nil), m.alerts[len(m.alerts)-memoryMaxAlerts:]...)
+	}
+	return nil
+}
+
+func (m *MemorySecurityRepository) GetSecurityAlerts(filter SecurityFilter) ([]SecurityAlert, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var alerts []SecurityAlert
+	// Newest first
+	for i := len(m.alerts) - 1; i >= 0; i-- {
+		alert := m.alerts[i]
+		switch {
+		case filter.AlertType != nil && alert.AlertType != *filter.AlertType,
+			filter.Severity != nil && alert.Severity != *filter.Severity,
+			filter.UserID != nil && alert.UserID != *filter.UserID,
+			filter.Resolved != nil && alert.Resolved != *filter.Resolved,
+			filter.StartTime != nil && alert.CreatedAt.Before(*filter.StartTime),
+			filter.EndTime != nil && alert.CreatedAt.After(*filter.EndTime):
+			continue
+		}
+		alerts = append(alerts, alert)
+	}
+
+	if filter.Offset > 0 {
+		if filter.Offset >= len(alerts) {
+			return []SecurityAlert{}, nil
+		}
+		alerts = alerts[filter.Offset:]
+	}
+	if filter.Limit > 0 && filter.Limit < len(alerts) {
+		alerts = alerts[:filter.Limit]
+	}
+	return alerts, nil
+}
+
+func (m *MemorySecurityRepository) RecordLoginAttempt(attempt *LoginAttempt) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	cutoff := attempt.CreatedAt.Add(-memoryAttemptRetention)
+	kept := m.attempts[:0]
+	for _, a := range m.attempts {
+		if a.CreatedAt.After(cutoff) {
+			kept = append(kept, a)
+		}
+	}
+	m.attempts = append(kept, *attempt)
+	return nil
+}
+
+func (m *MemorySecurityRepository) GetLoginAttempts(ipAddress string, since time.Time) ([]LoginAttempt, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var attempts []LoginAttempt
+	for _, a := range m.attempts {
+		if (ipAddress == "" || a.IPAddress == ipAddress) && !a.CreatedAt.Before(since) {
+			attempts = append(attempts, a)
+		}
+	}
+	return attempts, nil
+}
+
+func (m *MemorySecurityRepository) ClearFailedLoginAttempts(username, ipAddress string, since time.Time) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	cleared := 0
+	kept := m.attempts[:0]
+	for _, a := range m.attempts {
+		if !a.Success && !a.CreatedAt.Before(since) &&
+			(username == "" || a.Username == username) && (ipAddress == "" || a.IPAddress == ipAddress) {
+			cleared++
+			continue
+		}
+		kept = append(kept, a)
+	}
+	m.attempts = kept
+	return cleared, nil
+}
+
+func (m *MemorySecurityRepository) CreateSecurityRule(rule *SecurityRule) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.rules = append(m.rules, *rule)
+	return nil
+}
+
+func (m *MemorySecurityRepository) GetSecurityRules() ([]SecurityRule, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return append([]SecurityRule(nil), m.rules...), nil
+}
@@ -8,7 +8,9 @@ import (
 	"log"
 	"net/http"
 	"strings"
+	"sync"
 	"time"
+	"unicode"
 
 	"github.com/rusiqe/domainvault/internal/types"
 )
@@ -19,6 +21,9 @@ type SecurityService struct {
 	sessionRepo   SessionRepository
 	securityRepo  SecurityRepository
 	config        SecurityConfig
+	location      *time.Location   // timezone for off-hours detection
+	now           func() time.Time // overridable clock for risk scoring
+	policyMu      sync.RWMutex     // Protects the lockout policy in config, changed at runtime
 }
 
 // SecurityConfig contains security configuration
@@ -33,8 +38,43 @@ type SecurityConfig struct {
 	AllowedIPs           []string      `json:"allowed_ips"`
 	EnableMFA            bool          `json:"enable_mfa"`
 	JWTSigningKey        string        `json:"-"` // Never expose in JSON
+
+	// Risk scoring. Zero values fall back to the defaults below.
+	RiskWeights        map[AuditEventType]int `json:"risk_weights,omitempty"` // Base score per event type, merged over DefaultRiskWeights
+	OffHoursStart      int                    `json:"off_hours_start"`        // Hour (0-23) off-hours begin, default 23
+	OffHoursEnd        int                    `json:"off_hours_end"`          // Hour (0-23) off-hours end, default 6
+	OffHoursTimezone   string                 `json:"off_hours_timezone"`     // IANA timezone, default local time
+	RiskAlertThreshold int                    `json:"risk_alert_threshold"`   // Score at which an alert is raised, default 80
+
+	DataRetention map[string]int `json:"data_retention,omitempty"` // Retention window in days per audit/history table
+}
+
+// Pseudo event types used as keys in RiskWeights
+const (
+	RiskKeyLoginFailed AuditEventType = "login_failed" // Base score for failed logins
+	RiskKeyDefault     AuditEventType = "default"      // Base score for event types without a weight
+)
+
+// DefaultRiskWeights are the base risk scores applied when SecurityConfig.RiskWeights has no entry
+var DefaultRiskWeights = map[AuditEventType]int{
+	EventLogin:             5,
+	RiskKeyLoginFailed:     30,
+	EventCredentialsView:   50,
+	EventCredentialsCreate: 50,
+	EventCredentialsUpdate: 50,
+	EventCredentialsDelete: 50,
+	EventDomainDelete:      40,
+	EventBulkOperation:     60,
+	EventSecurityViolation: 90,
+	RiskKeyDefault:         10,
 }
 
+const (
+	defaultOffHoursStart      = 23
+	defaultOffHoursEnd        = 6
+	defaultRiskAlertThreshold = 80
+)
+
 // AuditEvent represents a security audit event
 type AuditEvent struct {
 	ID            string                 `json:"id" db:"id"`
@@ -60,6 +100,8 @@ const (
 	EventLogin            AuditEventType = "login"
 	EventLogout           AuditEventType = "logout"
 	EventPasswordChange   AuditEventType = "password_change"
+	EventPasswordResetRequest AuditEventType = "password_reset_request"
+	EventPasswordReset    AuditEventType = "password_reset"
 	EventDomainCreate     AuditEventType = "domain_create"
 	EventDomainUpdate     AuditEventType = "domain_update"
 	EventDomainDelete     AuditEventType = "domain_delete"
@@ -285,7 +327,8 @@ type SecurityRepository interface {
 	CreateSecurityAlert(alert *SecurityAlert) error
 	GetSecurityAlerts(filter SecurityFilter) ([]SecurityAlert, error)
 	RecordLoginAttempt(attempt *LoginAttempt) error
-	GetLoginAttempts(ipAddress string, since time.Time) ([]LoginAttempt, error)
+	GetLoginAttempts(ipAddress string, since time.Time) ([]LoginAttempt, error) // Empty ipAddress matches every IP
+	ClearFailedLoginAttempts(username, ipAddress string, since time.Time) (int, error) // Empty arguments match any value
 	CreateSecurityRule(rule *SecurityRule) error
 	GetSecurityRules() ([]SecurityRule, error)
 }
@@ -321,11 +364,30 @@ func NewSecurityService(
 	securityRepo SecurityRepository,
 	config SecurityConfig,
 ) *SecurityService {
+	if config.OffHoursStart == 0 && config.OffHoursEnd == 0 {
+		config.OffHoursStart = defaultOffHoursStart
+		config.OffHoursEnd = defaultOffHoursEnd
+	}
+	if config.RiskAlertThreshold <= 0 {
+		config.RiskAlertThreshold = defaultRiskAlertThreshold
+	}
+
+	location := time.Local
+	if config.OffHoursTimezone != "" {
+		if loc, err := time.LoadLocation(config.OffHoursTimezone); err == nil {
+			location = loc
+		} else {
+			log.Printf("Invalid off-hours timezone %q, using local time: %v", config.OffHoursTimezone, err)
+		}
+	}
+
 	return &SecurityService{
 		auditRepo:    auditRepo,
 		sessionRepo:  sessionRepo,
 		securityRepo: securityRepo,
 		config:       config,
+		location:     location,
+		now:          time.Now,
 	}
 }
 
@@ -358,7 +420,7 @@ func (s *SecurityService) LogAuditEvent(eventType AuditEventType, userID, userna
 	}
 
 	// Create security alert for high-risk events
-	if event.RiskScore >= 80 {
+	if event.RiskScore >= s.config.RiskAlertThreshold {
 		s.createSecurityAlert(event)
 	}
 
@@ -405,23 +467,46 @@ func (s *SecurityService) ValidateAccess(r *http.Request, userID string, resourc
 	return nil
 }
 
+// ValidatePassword checks a new password against PasswordMinLength and RequireStrongPassword
+func (s *SecurityService) ValidatePassword(password string) error {
+	if len(password) < s.config.PasswordMinLength {
+		return fmt.Errorf("password must be at least %d characters", s.config.PasswordMinLength)
+	}
+	if !s.config.RequireStrongPassword {
+		return nil
+	}
+
+	var hasUpper, hasLower, hasDigit, hasSymbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r):
+			hasSymbol = true
+		}
+	}
+	if !hasUpper || !hasLower || !hasDigit || !hasSymbol {
+		return fmt.Errorf("password must contain upper and lower case letters, a digit and a symbol")
+	}
+	return nil
+}
+
 // ValidateLogin checks login attempt against security policies
 func (s *SecurityService) ValidateLogin(ipAddress, username, userAgent string) error {
 	// Check for brute force attacks only if security repo is available
 	if s.securityRepo != nil {
-		attempts, err := s.securityRepo.GetLoginAttempts(ipAddress, time.Now().Add(-s.config.LockoutDuration))
+		failures, err := s.recentFailures(username, ipAddress)
 		if err != nil {
-			return fmt.Errorf("failed to check login attempts: %w", err)
+			return err
 		}
 
-		failedAttempts := 0
-		for _, attempt := range attempts {
-			if !attempt.Success && attempt.Username == username {
-				failedAttempts++
-			}
-		}
+		failedAttempts := len(failures)
 
-		if failedAttempts >= s.config.MaxLoginAttempts {
+		if maxAttempts, _ := s.lockoutPolicy(); failedAttempts >= maxAttempts {
 			// Create security alert
 			alert := &SecurityAlert{
 				ID:          generateID(),
@@ -439,7 +524,7 @@ func (s *SecurityService) ValidateLogin(ipAddress, username, userAgent string) e
 			}
 			s.securityRepo.CreateSecurityAlert(alert)
 
-			return fmt.Errorf("account temporarily locked due to multiple failed login attempts")
+			return ErrAccountLocked
 		}
 	}
 
@@ -454,7 +539,7 @@ func (s *SecurityService) RecordLoginAttempt(ipAddress, username, userAgent stri
 		Username:  username,
 		Success:   success,
 		UserAgent: userAgent,
-		CreatedAt: time.Now(),
+		CreatedAt: s.now(),
 	}
 
 	// Record attempt only if security repo is available
@@ -526,7 +611,7 @@ func (s *SecurityService) GetSecurityMetrics(period time.Duration) (*SecurityMet
 			RequirementsMet:   3,
 			RequirementsTotal: 4,
 			AuditReadiness:    false,
-			DataRetention:     make(map[string]int),
+			DataRetention:     s.RetentionPolicy(),
 			PrivacyCompliance: make(map[string]bool),
 		},
 		ThreatIntelligence: ThreatMetrics{
@@ -542,29 +627,26 @@ func (s *SecurityService) GetSecurityMetrics(period time.Duration) (*SecurityMet
 	return metrics, nil
 }
 
+// RetentionPolicy returns a copy of the configured retention window (days) per table
+func (s *SecurityService) RetentionPolicy() map[string]int {
+	policy := make(map[string]int, len(s.config.DataRetention))
+	for table, days := range s.config.DataRetention {
+		policy[table] = days
+	}
+	return policy
+}
+
 // Helper methods
 
 func (s *SecurityService) calculateRiskScore(eventType AuditEventType, ipAddress string, success bool, details map[string]interface{}) int {
-	score := 0
-
 	// Base risk by event type
-	switch eventType {
-	case EventLogin:
-		if !success {
-			score += 30
-		} else {
-			score += 5
-		}
-	case EventCredentialsView, EventCredentialsCreate, EventCredentialsUpdate, EventCredentialsDelete:
-		score += 50
-	case EventDomainDelete:
-		score += 40
-	case EventBulkOperation:
-		score += 60
-	case EventSecurityViolation:
-		score += 90
-	default:
-		score += 10
+	key := eventType
+	if eventType == EventLogin && !success {
+		key = RiskKeyLoginFailed
+	}
+	score, ok := s.riskWeight(key)
+	if !ok {
+		score, _ = s.riskWeight(RiskKeyDefault)
 	}
 
 	// IP reputation (simplified)
@@ -573,8 +655,7 @@ func (s *SecurityService) calculateRiskScore(eventType AuditEventType, ipAddress
 	}
 
 	// Time-based factors (off-hours access)
-	hour := time.Now().Hour()
-	if hour < 6 || hour > 22 {
+	if s.isOffHours(s.now().In(s.location).Hour()) {
 		score += 20
 	}
 
@@ -592,6 +673,27 @@ func (s *SecurityService) calculateRiskScore(eventType AuditEventType, ipAddress
 	return score
 }
 
+// riskWeight returns the configured base score for a key, falling back to DefaultRiskWeights
+func (s *SecurityService) riskWeight(key AuditEventType) (int, bool) {
+	if weight, ok := s.config.RiskWeights[key]; ok {
+		return weight, true
+	}
+	weight, ok := DefaultRiskWeights[key]
+	return weight, ok
+}
+
+// isOffHours reports whether hour falls in [OffHoursStart, OffHoursEnd), wrapping past midnight
+func (s *SecurityService) isOffHours(hour int) bool {
+	start, end := s.config.OffHoursStart, s.config.OffHoursEnd
+	if start == end {
+		return false
+	}
+	if start < end {
+		return hour >= start && hour < end
+	}
+	return hour >= start || hour < end
+}
+
 func (s *SecurityService) createSecurityAlert(event *AuditEvent) {
 	alertType := AlertMaliciousActivity
 	severity := SeverityMedium
@@ -599,7 +701,7 @@ func (s *SecurityService) createSecurityAlert(event *AuditEvent) {
 	if event.RiskScore >= 90 {
 		severity = SeverityCritical
 		alertType = AlertAccountCompromise
-	} else if event.RiskScore >= 80 {
+	} else if event.RiskScore >= s.config.RiskAlertThreshold {
 		severity = SeverityHigh
 	}
 
@@ -0,0 +1,118 @@
+package security
+
+import (
+	"testing"
+	"time"
+)
+
+func newTestService(config SecurityConfig, now time.Time) *SecurityService {
+	svc := NewSecurityService(nil, nil, nil, config)
+	svc.now = func() time.Time { return now }
+	return svc
+}
+
+func TestCalculateRiskScore(t *testing.T) {
+	noon := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	midnight := time.Date(2024, 6, 1, 0, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		config    SecurityConfig
+		now       time.Time
+		eventType AuditEventType
+		success   bool
+		details   map[string]interface{}
+		expected  int
+	}{
+		{
+			name:      "default bulk operation weight",
+			config:    SecurityConfig{OffHoursTimezone: "UTC"},
+			now:       noon,
+			eventType: EventBulkOperation,
+			success:   true,
+			expected:  60,
+		},
+		{
+			name:      "custom bulk operation weight",
+			config:    SecurityConfig{OffHoursTimezone: "UTC", RiskWeights: map[AuditEventType]int{EventBulkOperation: 25}},
+			now:       noon,
+			eventType: EventBulkOperation,
+			success:   true,
+			expected:  25,
+		},
+		{
+			name:      "custom failed login weight",
+			config:    SecurityConfig{OffHoursTimezone: "UTC", RiskWeights: map[AuditEventType]int{RiskKeyLoginFailed: 70}},
+			now:       noon,
+			eventType: EventLogin,
+			success:   false,
+			expected:  70,
+		},
+		{
+			name:      "custom default weight for unlisted event",
+			config:    SecurityConfig{OffHoursTimezone: "UTC", RiskWeights: map[AuditEventType]int{RiskKeyDefault: 0}},
+			now:       noon,
+			eventType: EventDNSView,
+			success:   true,
+			expected:  0,
+		},
+		{
+			name:      "default off-hours window adds score",
+			config:    SecurityConfig{OffHoursTimezone: "UTC"},
+			now:       midnight,
+			eventType: EventDomainDelete,
+			success:   true,
+			expected:  60,
+		},
+		{
+			name:      "custom off-hours window excludes midnight",
+			config:    SecurityConfig{OffHoursTimezone: "UTC", OffHoursStart: 2, OffHoursEnd: 5},
+			now:       midnight,
+			eventType: EventDomainDelete,
+			success:   true,
+			expected:  40,
+		},
+		{
+			name:      "off-hours evaluated in configured timezone",
+			config:    SecurityConfig{OffHoursTimezone: "America/New_York"},
+			now:       time.Date(2024, 6, 1, 4, 0, 0, 0, time.UTC), // 00:00 in New York
+			eventType: EventDomainDelete,
+			success:   true,
+			expected:  60,
+		},
+		{
+			name:      "error details and cap at 100",
+			config:    SecurityConfig{OffHoursTimezone: "UTC", RiskWeights: map[AuditEventType]int{EventSecurityViolation: 95}},
+			now:       midnight,
+			eventType: EventSecurityViolation,
+			success:   false,
+			details:   map[string]interface{}{"error": "denied"},
+			expected:  100,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := newTestService(tt.config, tt.now)
+			score := svc.calculateRiskScore(tt.eventType, "203.0.113.10", tt.success, tt.details)
+			if score != tt.expected {
+				t.Errorf("calculateRiskScore() = %d, expected %d", score, tt.expected)
+			}
+		})
+	}
+}
+
+func TestNewSecurityService_RiskDefaults(t *testing.T) {
+	svc := NewSecurityService(nil, nil, nil, SecurityConfig{})
+	if svc.config.RiskAlertThreshold != 80 {
+		t.Errorf("Expected default alert threshold 80, got %d", svc.config.RiskAlertThreshold)
+	}
+	if svc.config.OffHoursStart != 23 || svc.config.OffHoursEnd != 6 {
+		t.Errorf("Expected default off-hours 23-6, got %d-%d", svc.config.OffHoursStart, svc.config.OffHoursEnd)
+	}
+
+	svc = NewSecurityService(nil, nil, nil, SecurityConfig{RiskAlertThreshold: 50})
+	if svc.config.RiskAlertThreshold != 50 {
+		t.Errorf("Expected alert threshold 50, got %d", svc.config.RiskAlertThreshold)
+	}
+}
@@ -0,0 +1,77 @@
+package status
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/rusiqe/domainvault/internal/types"
+)
+
+// CertificateResult describes the TLS certificate a site serves on port 443
+type CertificateResult struct {
+	Domain        string     `json:"domain"`
+	Status        string     `json:"status"` // valid, invalid, unverified or unavailable, as in website checks
+	Subject       string     `json:"subject,omitempty"`
+	Issuer        string     `json:"issuer,omitempty"`
+	DNSNames      []string   `json:"dns_names,omitempty"`
+	NotBefore     *time.Time `json:"not_before,omitempty"`
+	NotAfter      *time.Time `json:"not_after,omitempty"`
+	DaysRemaining *int       `json:"days_remaining,omitempty"` // Negative once expired
+	Error         string     `json:"error,omitempty"`          // Why the certificate couldn't be read or didn't verify
+	CheckedAt     time.Time  `json:"checked_at"`
+}
+
+// CheckCertificate reads the certificate a site serves and verifies it against the system
+// roots for the domain name. The connection is made directly, not through the checker's
+// proxy. Hosts the checker doesn't verify report the certificate as unverified.
+func (sc *StatusChecker) CheckCertificate(ctx context.Context, domainName string) CertificateResult {
+	host := types.NormalizeDomainName(domainName)
+	result := CertificateResult{Domain: host, Status: "unavailable", CheckedAt: time.Now()}
+
+	ctx, cancel := context.WithTimeout(ctx, sc.timeout)
+	defer cancel()
+
+	// Verification happens below so the certificate can be reported even when it's invalid
+	dialer := &tls.Dialer{Config: &tls.Config{ServerName: host, InsecureSkipVerify: true}}
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(host, "443"))
+	if err != nil {
+		result.Error = fmt.Sprintf("TLS connection failed: %v", err)
+		return result
+	}
+	defer conn.Close()
+
+	certs := conn.(*tls.Conn).ConnectionState().PeerCertificates
+	if len(certs) == 0 {
+		result.Error = "no certificate presented"
+		return result
+	}
+	leaf := certs[0]
+	notBefore, notAfter := leaf.NotBefore, leaf.NotAfter
+	days := int(time.Until(notAfter).Hours() / 24)
+	result.Subject = leaf.Subject.CommonName
+	result.Issuer = leaf.Issuer.CommonName
+	result.DNSNames = leaf.DNSNames
+	result.NotBefore = &notBefore
+	result.NotAfter = &notAfter
+	result.DaysRemaining = &days
+
+	if sc.transport.skipsVerification(host) {
+		result.Status = sslStatusUnverified
+		return result
+	}
+	intermediates := x509.NewCertPool()
+	for _, cert := range certs[1:] {
+		intermediates.AddCert(cert)
+	}
+	if _, err := leaf.Verify(x509.VerifyOptions{DNSName: host, Intermediates: intermediates}); err != nil {
+		result.Status = "invalid"
+		result.Error = err.Error()
+		return result
+	}
+	result.Status = "valid"
+	return result
+}
@@ -2,17 +2,30 @@ package status
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
 	"fmt"
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/rusiqe/domainvault/internal/types"
 )
 
+// Bulk website check defaults
+const (
+	DefaultBulkCheckWorkers = 10
+	DefaultBulkCheckTimeout = 2 * time.Minute
+	maxRedirects            = 10
+)
+
 // StatusChecker handles HTTP status monitoring for domains
 type StatusChecker struct {
-	client  *http.Client
-	timeout time.Duration
+	client       *http.Client
+	followClient *http.Client // Follows redirects to find the final status
+	timeout      time.Duration
+	transport    *hostTransport // Set by NewStatusCheckerWithOptions
 }
 
 // NewStatusChecker creates a new status checker with default settings
@@ -25,6 +38,15 @@ func NewStatusChecker() *StatusChecker {
 				return http.ErrUseLastResponse
 			},
 		},
+		followClient: &http.Client{
+			Timeout: 10 * time.Second,
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				if len(via) >= maxRedirects {
+					return fmt.Errorf("stopped after %d redirects", maxRedirects)
+				}
+				return nil
+			},
+		},
 		timeout: 10 * time.Second,
 	}
 }
@@ -177,6 +199,8 @@ type StatusSummary struct {
 	TotalChecked  int            `json:"total_checked"`
 	StatusCounts  map[string]int `json:"status_counts"`
 	LastCheckTime time.Time      `json:"last_check_time"`
+	SLABreaches   []types.SLABreach `json:"sla_breaches,omitempty"` // Monitored domains over their response-time SLA
+	RegistrarConcerns []types.RegistrarStatusConcern `json:"registrar_concerns,omitempty"` // Domains whose registrar reports a status such as clientHold
 }
 
 // GetStatusSummary creates a summary from a list of domains
@@ -187,6 +211,15 @@ func GetStatusSummary(domains []types.Domain) *StatusSummary {
 
 	var lastCheck time.Time
 	for _, domain := range domains {
+		if concerns := domain.RegistrarStatusConcerns(); len(concerns) > 0 {
+			summary.RegistrarConcerns = append(summary.RegistrarConcerns, types.RegistrarStatusConcern{
+				DomainID:   domain.ID,
+				DomainName: domain.Name,
+				Provider:   domain.Provider,
+				Statuses:   concerns,
+			})
+		}
+
 		if domain.LastStatusCheck != nil {
 			summary.TotalChecked++
 			
@@ -230,7 +263,7 @@ func (sc *StatusChecker) CheckWebsiteStatus(request types.WebsiteStatusRequest)
 	results := make([]types.WebsiteStatusResult, 0, len(request.Domains))
 	
 	for _, domainName := range request.Domains {
-		result := sc.checkSingleWebsiteStatus(domainName)
+		result := sc.checkSingleWebsiteStatus(context.Background(), domainName)
 		results = append(results, result)
 		
 		// Small delay between requests to be respectful
@@ -240,35 +273,46 @@ func (sc *StatusChecker) CheckWebsiteStatus(request types.WebsiteStatusRequest)
 	return results, nil
 }
 
-// BulkCheckWebsiteStatus checks website status for multiple domains concurrently
-func (sc *StatusChecker) BulkCheckWebsiteStatus(request types.WebsiteStatusRequest) ([]types.WebsiteStatusResult, error) {
+// BulkCheckWebsiteStatus checks website status for multiple domains concurrently using a pool
+// of workers. Domains not checked before ctx is done are reported with a timeout error.
+func (sc *StatusChecker) BulkCheckWebsiteStatus(ctx context.Context, request types.WebsiteStatusRequest, workers int) ([]types.WebsiteStatusResult, error) {
+	if workers <= 0 {
+		workers = DefaultBulkCheckWorkers
+	}
 	results := make([]types.WebsiteStatusResult, len(request.Domains))
-	type result struct {
-		index int
-		status types.WebsiteStatusResult
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers && w < len(request.Domains); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range jobs {
+				results[index] = sc.checkSingleWebsiteStatus(ctx, request.Domains[index])
+			}
+		}()
 	}
-	
-	resultChan := make(chan result, len(request.Domains))
-	
-	// Start goroutines for each domain check
+
 	for i, domainName := range request.Domains {
-		go func(index int, domain string) {
-			status := sc.checkSingleWebsiteStatus(domain)
-			resultChan <- result{index: index, status: status}
-		}(i, domainName)
-	}
-	
-	// Collect results
-	for i := 0; i < len(request.Domains); i++ {
-		result := <-resultChan
-		results[result.index] = result.status
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+			results[i] = types.WebsiteStatusResult{
+				Domain:        domainName,
+				StatusMessage: "Not checked",
+				LastChecked:   time.Now(),
+				Error:         ctx.Err().Error(),
+			}
+		}
 	}
-	
+	close(jobs)
+	wg.Wait()
+
 	return results, nil
 }
 
-// checkSingleWebsiteStatus checks the status of a single website
-func (sc *StatusChecker) checkSingleWebsiteStatus(domainName string) types.WebsiteStatusResult {
+// checkSingleWebsiteStatus checks the status of a single website over HTTP and HTTPS
+func (sc *StatusChecker) checkSingleWebsiteStatus(ctx context.Context, domainName string) types.WebsiteStatusResult {
 	now := time.Now()
 	result := types.WebsiteStatusResult{
 		Domain:      domainName,
@@ -276,54 +320,82 @@ func (sc *StatusChecker) checkSingleWebsiteStatus(domainName string) types.Websi
 	}
 	
 	// Try HTTP first
-	httpResult := sc.performStatusCheck(fmt.Sprintf("http://%s", domainName))
+	httpResult := sc.performStatusCheck(ctx, fmt.Sprintf("http://%s", domainName))
 	result.HTTPStatus = httpResult.statusCode
 	result.StatusMessage = httpResult.message
 	result.ResponseTime = httpResult.responseTime
 	result.RedirectURL = httpResult.redirectURL
+	result.FinalStatus = httpResult.finalStatus
+	result.FinalURL = httpResult.finalURL
 	result.Error = httpResult.error
-	
-	// If HTTP fails or returns an error, try HTTPS
-	if httpResult.statusCode == 0 || httpResult.statusCode >= 400 {
-		httpsResult := sc.performStatusCheck(fmt.Sprintf("https://%s", domainName))
-		
-		// Use HTTPS result if it's better
-		if httpsResult.statusCode > 0 && httpsResult.statusCode < httpResult.statusCode {
-			result.HTTPStatus = httpsResult.statusCode
-			result.StatusMessage = httpsResult.message + " (HTTPS)"
-			result.ResponseTime = httpsResult.responseTime
-			result.RedirectURL = httpsResult.redirectURL
-			result.Error = httpsResult.error
-			result.SSLStatus = "valid"
-		}
-	} else if httpResult.statusCode >= 200 && httpResult.statusCode < 300 {
-		// Also check HTTPS to see if SSL is available
-		httpsResult := sc.performStatusCheck(fmt.Sprintf("https://%s", domainName))
-		if httpsResult.statusCode >= 200 && httpsResult.statusCode < 300 {
-			result.SSLStatus = "valid"
-		} else {
-			result.SSLStatus = "unavailable"
-		}
+
+	// HTTPS is always checked so the SSL status reflects the certificate, not just the HTTP result
+	httpsResult := sc.performStatusCheck(ctx, fmt.Sprintf("https://%s", domainName))
+	switch {
+	case httpsResult.statusCode > 0 && sc.transport.skipsVerification(domainName):
+		result.SSLStatus = sslStatusUnverified
+	case httpsResult.certError:
+		result.SSLStatus = "invalid"
+	case httpsResult.statusCode > 0 && httpsResult.statusCode != 408:
+		result.SSLStatus = "valid"
+	default:
+		result.SSLStatus = "unavailable"
+	}
+
+	// Use the HTTPS result if HTTP failed and HTTPS did better
+	if (httpResult.statusCode == 0 || httpResult.statusCode >= 400) &&
+		httpsResult.statusCode > 0 && (httpResult.statusCode == 0 || httpsResult.statusCode < httpResult.statusCode) {
+		result.HTTPStatus = httpsResult.statusCode
+		result.StatusMessage = httpsResult.message + " (HTTPS)"
+		result.ResponseTime = httpsResult.responseTime
+		result.RedirectURL = httpsResult.redirectURL
+		result.FinalStatus = httpsResult.finalStatus
+		result.FinalURL = httpsResult.finalURL
+		result.Error = httpsResult.error
 	}
 	
 	return result
 }
 
+// ApplyWebsiteStatus records a website check result on the domain so it survives beyond the response
+func ApplyWebsiteStatus(domain *types.Domain, result types.WebsiteStatusResult) {
+	checked := result.LastChecked
+	responseTime := int(result.ResponseTime)
+
+	domain.HTTPStatus = intPtr(result.HTTPStatus)
+	domain.StatusMessage = stringPtr(result.StatusMessage)
+	domain.LastStatusCheck = &checked
+	domain.LastResponseTime = &responseTime
+	domain.SSLStatus = nil
+	if result.SSLStatus != "" {
+		domain.SSLStatus = stringPtr(result.SSLStatus)
+	}
+	domain.RedirectURL = nil
+	if result.FinalURL != "" {
+		domain.RedirectURL = stringPtr(result.FinalURL)
+	} else if result.RedirectURL != "" {
+		domain.RedirectURL = stringPtr(result.RedirectURL)
+	}
+}
+
 // statusCheckResult represents the result of a single status check
 type statusCheckResult struct {
 	statusCode   int
 	message      string
 	responseTime int64
 	redirectURL  string
+	finalStatus  int    // Status after following redirects
+	finalURL     string // URL after following redirects, set only when redirected
+	certError    bool   // TLS certificate failed verification
 	error        string
 }
 
 // performStatusCheck performs the actual HTTP check
-func (sc *StatusChecker) performStatusCheck(url string) statusCheckResult {
+func (sc *StatusChecker) performStatusCheck(parent context.Context, url string) statusCheckResult {
 	start := time.Now()
 	result := statusCheckResult{}
 	
-	ctx, cancel := context.WithTimeout(context.Background(), sc.timeout)
+	ctx, cancel := context.WithTimeout(parent, sc.timeout)
 	defer cancel()
 	
 	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
@@ -347,20 +419,43 @@ func (sc *StatusChecker) performStatusCheck(url string) statusCheckResult {
 			result.statusCode = 0
 			result.message = "Connection failed"
 			result.error = err.Error()
+			result.certError = isCertificateError(err)
 		}
 		return result
 	}
 	defer resp.Body.Close()
 	
 	result.statusCode = resp.StatusCode
+	result.finalStatus = resp.StatusCode
 	result.message = getStatusMessage(resp.StatusCode)
 	
-	// Check for redirects
+	// Check for redirects, and follow them to find where the site ends up
 	if resp.StatusCode >= 300 && resp.StatusCode < 400 {
 		if location := resp.Header.Get("Location"); location != "" {
 			result.redirectURL = location
 		}
+		if finalReq, err := http.NewRequestWithContext(ctx, "GET", url, nil); err == nil {
+			finalReq.Header.Set("User-Agent", "DomainVault/1.0 Website Status Checker")
+			if finalResp, err := sc.followClient.Do(finalReq); err == nil {
+				finalResp.Body.Close()
+				result.finalStatus = finalResp.StatusCode
+				result.finalURL = finalResp.Request.URL.String()
+			} else if isCertificateError(err) {
+				result.finalStatus = 0
+				result.error = err.Error()
+			}
+		}
 	}
 	
 	return result
 }
+
+// isCertificateError reports whether a request failed because the TLS certificate didn't verify
+func isCertificateError(err error) bool {
+	var verifyErr *tls.CertificateVerificationError
+	var unknownAuthority x509.UnknownAuthorityError
+	var hostnameErr x509.HostnameError
+	var invalidErr x509.CertificateInvalidError
+	return errors.As(err, &verifyErr) || errors.As(err, &unknownAuthority) ||
+		errors.As(err, &hostnameErr) || errors.As(err, &invalidErr)
+}
@@ -1,6 +1,8 @@
 package storage
 
 import (
+	"encoding/json"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -16,29 +18,64 @@ type MockRepo struct {
 	projects          map[string]types.Project
 	credentials       map[string]types.ProviderCredentials
 	secureCredentials map[string]types.SecureProviderCredentials
+	secrets           map[string]types.CredentialsMap
 	users             map[string]types.User
 	sessions          map[string]types.Session
+	calendarTokens    map[string]string // token hash -> user ID
+	resetTokens       map[string]mockResetToken
+	idempotency       map[string]types.IdempotencyRecord
 	dnsRecords        map[string]types.DNSRecord
+	dnsHistory        []types.DNSRecordHistory
+	nsHistory         []types.NameserverChange
+	dnsProviderState  map[string]types.DNSProviderState
+	dnsSyncResults    map[string]types.DNSSyncResult
+	dnsBackups        []types.DNSBackup
+	settings          map[string][]byte
+	webhooks          map[string]types.WebhookEndpoint
+	notificationTemplates map[string]types.NotificationTemplate // Keyed by channel/event
+	categorizationRules map[string]types.CategorizationRule
+	savedViews        map[string]types.SavedView
+	watchlist         map[string]types.WatchlistEntry
+	snapshots         map[string]types.PortfolioSnapshot // Keyed by YYYY-MM-DD
+	registryExpiry    map[string]types.RegistryExpiry    // Keyed by domain ID
+	priceHistory      []types.RenewalPriceChange
 	mu                sync.RWMutex
 }
 
 // NewMockRepo creates a new mock repository with sample data
 func NewMockRepo() *MockRepo {
-	repo := &MockRepo{
+	repo := NewEmptyMockRepo()
+	repo.populateSampleData()
+	return repo
+}
+
+// NewEmptyMockRepo creates a mock repository without the sample data, for callers that seed
+// their own
+func NewEmptyMockRepo() *MockRepo {
+	return &MockRepo{
 		domains:           make(map[string]types.Domain),
 		categories:        make(map[string]types.Category),
 		projects:          make(map[string]types.Project),
 		credentials:       make(map[string]types.ProviderCredentials),
 		secureCredentials: make(map[string]types.SecureProviderCredentials),
+		secrets:           make(map[string]types.CredentialsMap),
 		users:             make(map[string]types.User),
 		sessions:          make(map[string]types.Session),
+		calendarTokens:    make(map[string]string),
+		resetTokens:       make(map[string]mockResetToken),
+		idempotency:       make(map[string]types.IdempotencyRecord),
 		dnsRecords:        make(map[string]types.DNSRecord),
+		dnsProviderState:  make(map[string]types.DNSProviderState),
+		dnsSyncResults:    make(map[string]types.DNSSyncResult),
+		settings:          make(map[string][]byte),
+		webhooks:          make(map[string]types.WebhookEndpoint),
+		notificationTemplates: make(map[string]types.NotificationTemplate),
+		categorizationRules: make(map[string]types.CategorizationRule),
+		savedViews:        make(map[string]types.SavedView),
+		watchlist:         make(map[string]types.WatchlistEntry),
+		snapshots:         make(map[string]types.PortfolioSnapshot),
+		registryExpiry:    make(map[string]types.RegistryExpiry),
 	}
-	
-	// Populate with sample data
-	repo.populateSampleData()
-	
-	return repo
 }
 
 func (r *MockRepo) populateSampleData() {
@@ -284,21 +321,34 @@ func (r *MockRepo) populateSampleData() {
 }
 
 // Domain repository methods
-func (r *MockRepo) UpsertDomains(domains []types.Domain) error {
+func (r *MockRepo) UpsertDomains(domains []types.Domain) (UpsertResult, error) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 	
+	var result UpsertResult
 	for _, domain := range domains {
 		if domain.ID == "" {
 			domain.ID = uuid.New().String()
 		}
+		if err := domain.Validate(); err != nil {
+			result.Skipped = append(result.Skipped, SkippedDomain{Name: domain.Name, Error: err.Error()})
+			continue
+		}
 		if domain.CreatedAt.IsZero() {
 			domain.CreatedAt = time.Now()
 		}
 		domain.UpdatedAt = time.Now()
+		if existing, exists := r.domains[domain.ID]; exists {
+			if existing.RegisteredAt != nil {
+				domain.RegisteredAt = existing.RegisteredAt // A stored registration date is kept
+			}
+			result.Updated++
+		} else {
+			result.Inserted++
+		}
 		r.domains[domain.ID] = domain
 	}
-	return nil
+	return result, nil
 }
 
 func (r *MockRepo) GetAll() ([]types.Domain, error) {
@@ -323,7 +373,94 @@ func (r *MockRepo) GetByID(id string) (*types.Domain, error) {
 	return &domain, nil
 }
 
+func (r *MockRepo) GetByMonitorID(monitorID int) (*types.Domain, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, domain := range r.domains {
+		if domain.UptimeRobotMonitorID != nil && *domain.UptimeRobotMonitorID == monitorID {
+			return &domain, nil
+		}
+	}
+	return nil, types.ErrDomainNotFound
+}
+
+func (r *MockRepo) MergeDomains(canonicalID string, duplicateIDs []string) (*types.Domain, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	
+	canonical, exists := r.domains[canonicalID]
+	if !exists {
+		return nil, types.ErrDomainNotFound
+	}
+	
+	for _, id := range duplicateIDs {
+		if id == canonicalID {
+			continue
+		}
+		dup, exists := r.domains[id]
+		if !exists {
+			return nil, types.ErrDomainNotFound
+		}
+		
+		if dup.CreatedAt.Before(canonical.CreatedAt) {
+			canonical.CreatedAt = dup.CreatedAt
+		}
+		if canonical.CategoryID == nil {
+			canonical.CategoryID = dup.CategoryID
+		}
+		if canonical.ProjectID == nil {
+			canonical.ProjectID = dup.ProjectID
+		}
+		for _, tag := range dup.Tags {
+			if !containsString(canonical.Tags, tag) {
+				canonical.Tags = append(canonical.Tags, tag)
+			}
+		}
+		for key, value := range dup.Metadata {
+			if _, ok := canonical.Metadata[key]; !ok {
+				if canonical.Metadata == nil {
+					canonical.Metadata = types.MetadataMap{}
+				}
+				canonical.Metadata[key] = value
+			}
+		}
+		
+		for recordID, record := range r.dnsRecords {
+			if record.DomainID == id {
+				record.DomainID = canonicalID
+				r.dnsRecords[recordID] = record
+			}
+		}
+		for i := range r.dnsHistory {
+			if r.dnsHistory[i].DomainID == id {
+				r.dnsHistory[i].DomainID = canonicalID
+			}
+		}
+		for i := range r.nsHistory {
+			if r.nsHistory[i].DomainID == id {
+				r.nsHistory[i].DomainID = canonicalID
+			}
+		}
+		for i := range r.priceHistory {
+			if r.priceHistory[i].DomainID == id {
+				r.priceHistory[i].DomainID = canonicalID
+			}
+		}
+		delete(r.domains, id)
+	}
+	
+	canonical.Name = types.NormalizeDomainName(canonical.Name)
+	canonical.UpdatedAt = time.Now()
+	r.domains[canonicalID] = canonical
+	return &canonical, nil
+}
+
 func (r *MockRepo) GetByFilter(filter types.DomainFilter) ([]types.Domain, error) {
+	if err := filter.ValidateSort(); err != nil {
+		return nil, err
+	}
+
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 	
@@ -333,6 +470,7 @@ func (r *MockRepo) GetByFilter(filter types.DomainFilter) ([]types.Domain, error
 			domains = append(domains, domain)
 		}
 	}
+	sortDomains(domains, filter.SortBy, filter.SortOrder)
 	
 	// Apply limit and offset
 	if filter.Offset > 0 && filter.Offset < len(domains) {
@@ -345,6 +483,46 @@ func (r *MockRepo) GetByFilter(filter types.DomainFilter) ([]types.Domain, error
 	return domains, nil
 }
 
+// sortDomains orders domains the way the Postgres ORDER BY does: missing renewal prices
+// last in either direction, ties broken by ID
+func sortDomains(domains []types.Domain, field, order string) {
+	sort.SliceStable(domains, func(i, j int) bool {
+		a, b := domains[i], domains[j]
+
+		var cmp int
+		switch field {
+		case "name":
+			cmp = strings.Compare(a.Name, b.Name)
+		case "provider":
+			cmp = strings.Compare(a.Provider, b.Provider)
+		case "expires_at":
+			cmp = a.ExpiresAt.Compare(b.ExpiresAt)
+		case "renewal_price":
+			switch {
+			case a.RenewalPrice == nil && b.RenewalPrice == nil:
+			case a.RenewalPrice == nil:
+				return false
+			case b.RenewalPrice == nil:
+				return true
+			case *a.RenewalPrice < *b.RenewalPrice:
+				cmp = -1
+			case *a.RenewalPrice > *b.RenewalPrice:
+				cmp = 1
+			}
+		default:
+			cmp = a.CreatedAt.Compare(b.CreatedAt)
+		}
+
+		if order == types.SortDesc {
+			cmp = -cmp
+		}
+		if cmp == 0 {
+			return a.ID < b.ID
+		}
+		return cmp < 0
+	})
+}
+
 func (r *MockRepo) matchesFilter(domain types.Domain, filter types.DomainFilter) bool {
 	if filter.Provider != "" && domain.Provider != filter.Provider {
 		return false
@@ -364,6 +542,20 @@ func (r *MockRepo) matchesFilter(domain types.Domain, filter types.DomainFilter)
 	if filter.ProjectID != nil && (domain.ProjectID == nil || *domain.ProjectID != *filter.ProjectID) {
 		return false
 	}
+	if filter.AccountRef != nil && (domain.AccountRef == nil || *domain.AccountRef != *filter.AccountRef) {
+		return false
+	}
+	if filter.AutoRenew != nil && domain.AutoRenew != *filter.AutoRenew {
+		return false
+	}
+	for key, value := range filter.Metadata {
+		if actual, ok := domain.Metadata[key]; !ok || actual != value {
+			return false
+		}
+	}
+	if filter.Scope != nil && !filter.Scope.Allows(domain) {
+		return false
+	}
 	return true
 }
 
@@ -380,6 +572,69 @@ func (r *MockRepo) GetDomainsByName(name string) ([]types.Domain, error) {
 	return domains, nil
 }
 
+func (r *MockRepo) GetDomainsByNames(names []string) ([]types.Domain, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	wanted := make(map[string]bool, len(names))
+	for _, name := range names {
+		wanted[name] = true
+	}
+	var domains []types.Domain
+	for _, domain := range r.domains {
+		if wanted[domain.Name] {
+			domains = append(domains, domain)
+		}
+	}
+	return domains, nil
+}
+
+func (r *MockRepo) SetDomainRegisteredAt(id string, registeredAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	domain, exists := r.domains[id]
+	if !exists {
+		return types.ErrDomainNotFound
+	}
+	domain.RegisteredAt = &registeredAt
+	r.domains[id] = domain
+	return nil
+}
+
+func (r *MockRepo) SetDomainVerification(id string, token *string, requestedAt, verifiedAt *time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	domain, exists := r.domains[id]
+	if !exists {
+		return types.ErrDomainNotFound
+	}
+	domain.VerificationToken = token
+	domain.VerificationRequestedAt = requestedAt
+	domain.VerifiedAt = verifiedAt
+	r.domains[id] = domain
+	return nil
+}
+
+func (r *MockRepo) BulkSetVisibility(ids []string, visible bool) ([]types.Domain, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var updated []types.Domain
+	for _, id := range ids {
+		domain, exists := r.domains[id]
+		if !exists {
+			continue
+		}
+		domain.Visible = visible
+		domain.UpdatedAt = time.Now()
+		r.domains[id] = domain
+		updated = append(updated, domain)
+	}
+	return updated, nil
+}
+
 func (r *MockRepo) Delete(id string) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
@@ -417,31 +672,32 @@ func (r *MockRepo) GetExpiring(threshold time.Duration) ([]types.Domain, error)
 	return domains, nil
 }
 
-func (r *MockRepo) GetSummary() (*types.DomainSummary, error) {
+func (r *MockRepo) GetSummary(expiringDays []int) (*types.DomainSummary, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 	
 	summary := &types.DomainSummary{
-		Total:      len(r.domains),
-		ByProvider: make(map[string]int),
-		ExpiringIn: make(map[string]int),
-		LastSync:   time.Now(),
+		Total:       len(r.domains),
+		GracePeriod: []string{},
+		ByProvider:  make(map[string]int),
+		ExpiringIn:  make(map[string]int),
+		LastSync:    time.Now(),
 	}
 	
 	now := time.Now()
 	for _, domain := range r.domains {
 		summary.ByProvider[domain.Provider]++
-		
-		if domain.ExpiresAt.Before(now.AddDate(0, 0, 30)) {
-			summary.ExpiringIn["30_days"]++
+		if domain.Status == types.DomainStatusGracePeriod {
+			summary.GracePeriod = append(summary.GracePeriod, domain.Name)
 		}
-		if domain.ExpiresAt.Before(now.AddDate(0, 0, 90)) {
-			summary.ExpiringIn["90_days"]++
-		}
-		if domain.ExpiresAt.Before(now.AddDate(1, 0, 0)) {
-			summary.ExpiringIn["365_days"]++
+		
+		for _, days := range expiringDays {
+			if domain.ExpiresAt.Before(now.AddDate(0, 0, days)) {
+				summary.ExpiringIn[types.ExpiringInKey(days)]++
+			}
 		}
 	}
+	sort.Strings(summary.GracePeriod)
 	
 	return summary, nil
 }
@@ -472,6 +728,11 @@ func (r *MockRepo) CreateCategory(category *types.Category) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 	
+	for _, existing := range r.categories {
+		if existing.ID != category.ID && strings.EqualFold(existing.Name, category.Name) {
+			return types.ErrCategoryExists
+		}
+	}
 	if category.ID == "" {
 		category.ID = uuid.New().String()
 	}
@@ -490,6 +751,31 @@ func (r *MockRepo) GetAllCategories() ([]types.Category, error) {
 	for _, category := range r.categories {
 		categories = append(categories, category)
 	}
+	sort.Slice(categories, func(i, j int) bool { return categories[i].Name < categories[j].Name })
+	return categories, nil
+}
+
+func (r *MockRepo) GetCategoriesWithCounts(sortBy string) ([]types.CategoryWithCount, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	
+	counts := make(map[string]int)
+	for _, domain := range r.domains {
+		if domain.CategoryID != nil && domain.Visible {
+			counts[*domain.CategoryID]++
+		}
+	}
+	
+	categories := make([]types.CategoryWithCount, 0, len(r.categories))
+	for _, category := range r.categories {
+		categories = append(categories, types.CategoryWithCount{Category: category, DomainCount: counts[category.ID]})
+	}
+	sort.Slice(categories, func(i, j int) bool {
+		if sortBy == "count" && categories[i].DomainCount != categories[j].DomainCount {
+			return categories[i].DomainCount > categories[j].DomainCount
+		}
+		return categories[i].Name < categories[j].Name
+	})
 	return categories, nil
 }
 
@@ -511,6 +797,11 @@ func (r *MockRepo) UpdateCategory(category *types.Category) error {
 	if _, exists := r.categories[category.ID]; !exists {
 		return types.ErrDomainNotFound
 	}
+	for _, existing := range r.categories {
+		if existing.ID != category.ID && strings.EqualFold(existing.Name, category.Name) {
+			return types.ErrCategoryExists
+		}
+	}
 	category.UpdatedAt = time.Now()
 	r.categories[category.ID] = *category
 	return nil
@@ -532,6 +823,11 @@ func (r *MockRepo) CreateProject(project *types.Project) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 	
+	for _, existing := range r.projects {
+		if existing.ID != project.ID && strings.EqualFold(existing.Name, project.Name) {
+			return types.ErrProjectExists
+		}
+	}
 	if project.ID == "" {
 		project.ID = uuid.New().String()
 	}
@@ -550,6 +846,31 @@ func (r *MockRepo) GetAllProjects() ([]types.Project, error) {
 	for _, project := range r.projects {
 		projects = append(projects, project)
 	}
+	sort.Slice(projects, func(i, j int) bool { return projects[i].Name < projects[j].Name })
+	return projects, nil
+}
+
+func (r *MockRepo) GetProjectsWithCounts(sortBy string) ([]types.ProjectWithCount, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	
+	counts := make(map[string]int)
+	for _, domain := range r.domains {
+		if domain.ProjectID != nil && domain.Visible {
+			counts[*domain.ProjectID]++
+		}
+	}
+	
+	projects := make([]types.ProjectWithCount, 0, len(r.projects))
+	for _, project := range r.projects {
+		projects = append(projects, types.ProjectWithCount{Project: project, DomainCount: counts[project.ID]})
+	}
+	sort.Slice(projects, func(i, j int) bool {
+		if sortBy == "count" && projects[i].DomainCount != projects[j].DomainCount {
+			return projects[i].DomainCount > projects[j].DomainCount
+		}
+		return projects[i].Name < projects[j].Name
+	})
 	return projects, nil
 }
 
@@ -571,6 +892,11 @@ func (r *MockRepo) UpdateProject(project *types.Project) error {
 	if _, exists := r.projects[project.ID]; !exists {
 		return types.ErrDomainNotFound
 	}
+	for _, existing := range r.projects {
+		if existing.ID != project.ID && strings.EqualFold(existing.Name, project.Name) {
+			return types.ErrProjectExists
+		}
+	}
 	project.UpdatedAt = time.Now()
 	r.projects[project.ID] = *project
 	return nil
@@ -698,6 +1024,18 @@ func (r *MockRepo) GetUserByID(id string) (*types.User, error) {
 	return &user, nil
 }
 
+func (r *MockRepo) GetUserByEmail(email string) (*types.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	
+	for _, user := range r.users {
+		if strings.EqualFold(user.Email, email) {
+			return &user, nil
+		}
+	}
+	return nil, types.ErrDomainNotFound
+}
+
 func (r *MockRepo) UpdateUser(user *types.User) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
@@ -710,6 +1048,37 @@ func (r *MockRepo) UpdateUser(user *types.User) error {
 	return nil
 }
 
+func (r *MockRepo) SetCalendarToken(userID, tokenHash string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	
+	if _, exists := r.users[userID]; !exists {
+		return types.ErrDomainNotFound
+	}
+	for hash, id := range r.calendarTokens {
+		if id == userID {
+			delete(r.calendarTokens, hash)
+		}
+	}
+	r.calendarTokens[tokenHash] = userID
+	return nil
+}
+
+func (r *MockRepo) GetUserByCalendarToken(tokenHash string) (*types.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	
+	userID, exists := r.calendarTokens[tokenHash]
+	if !exists {
+		return nil, types.ErrDomainNotFound
+	}
+	user, exists := r.users[userID]
+	if !exists {
+		return nil, types.ErrDomainNotFound
+	}
+	return &user, nil
+}
+
 func (r *MockRepo) DeleteUser(id string) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
@@ -758,118 +1127,870 @@ func (r *MockRepo) GetSessionByToken(token string) (*types.Session, error) {
 	return nil, types.ErrDomainNotFound
 }
 
-func (r *MockRepo) DeleteSession(token string) error {
+func (r *MockRepo) DeleteUserSessions(userID string) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 	
 	for id, session := range r.sessions {
-		if session.Token == token {
+		if session.UserID == userID {
 			delete(r.sessions, id)
-			return nil
 		}
 	}
-	return types.ErrDomainNotFound
+	return nil
 }
 
-func (r *MockRepo) DeleteExpiredSessions() error {
+// mockResetToken is an outstanding password reset token
+type mockResetToken struct {
+	userID    string
+	expiresAt time.Time
+}
+
+func (r *MockRepo) CreatePasswordResetToken(userID, tokenHash string, expiresAt time.Time) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 	
-	now := time.Now()
-	for id, session := range r.sessions {
-		if session.ExpiresAt.Before(now) {
-			delete(r.sessions, id)
+	for hash, token := range r.resetTokens {
+		if token.userID == userID {
+			delete(r.resetTokens, hash)
 		}
 	}
+	r.resetTokens[tokenHash] = mockResetToken{userID: userID, expiresAt: expiresAt}
 	return nil
 }
 
-// DNS repository methods
-func (r *MockRepo) CreateRecord(record *types.DNSRecord) error {
+func (r *MockRepo) ConsumePasswordResetToken(tokenHash string) (string, error) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 	
-	if record.ID == "" {
-		record.ID = uuid.New().String()
+	token, exists := r.resetTokens[tokenHash]
+	if !exists || time.Now().After(token.expiresAt) {
+		return "", types.ErrInvalidResetToken
 	}
-	now := time.Now()
-	record.CreatedAt = now
-	record.UpdatedAt = now
-	r.dnsRecords[record.ID] = *record
-	return nil
+	delete(r.resetTokens, tokenHash)
+	return token.userID, nil
 }
 
-func (r *MockRepo) GetRecordsByDomain(domainID string) ([]types.DNSRecord, error) {
-	r.mu.RLock()
-	defer r.mu.RUnlock()
+func (r *MockRepo) ReserveIdempotencyKey(record *types.IdempotencyRecord) (*types.IdempotencyRecord, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
 	
-	var records []types.DNSRecord
-	for _, record := range r.dnsRecords {
-		if record.DomainID == domainID {
-			records = append(records, record)
-		}
+	if existing, exists := r.idempotency[record.Key]; exists && time.Now().Before(existing.ExpiresAt) {
+		return &existing, nil
 	}
-	return records, nil
+	r.idempotency[record.Key] = *record
+	return nil, nil
 }
 
-func (r *MockRepo) GetRecordByID(id string) (*types.DNSRecord, error) {
+func (r *MockRepo) CompleteIdempotencyKey(key string, statusCode int, contentType string, body []byte) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	
+	record, exists := r.idempotency[key]
+	if !exists {
+		return types.ErrDomainNotFound
+	}
+	record.StatusCode = statusCode
+	record.ContentType = contentType
+	record.Body = body
+	r.idempotency[key] = record
+	return nil
+}
+
+func (r *MockRepo) GetSetting(key string, dest interface{}) error {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 	
-	record, exists := r.dnsRecords[id]
+	value, exists := r.settings[key]
 	if !exists {
-		return nil, types.ErrDomainNotFound
+		return types.ErrSettingNotFound
 	}
-	return &record, nil
+	return json.Unmarshal(value, dest)
 }
 
-func (r *MockRepo) UpdateRecord(record *types.DNSRecord) error {
+func (r *MockRepo) SaveSetting(key string, value interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	
 	r.mu.Lock()
 	defer r.mu.Unlock()
-	
-	if _, exists := r.dnsRecords[record.ID]; !exists {
-		return types.ErrDomainNotFound
-	}
-	record.UpdatedAt = time.Now()
-	r.dnsRecords[record.ID] = *record
+	r.settings[key] = data
 	return nil
 }
 
-func (r *MockRepo) DeleteRecord(id string) error {
+func (r *MockRepo) CreateWebhookEndpoint(endpoint *types.WebhookEndpoint) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
-	
-	if _, exists := r.dnsRecords[id]; !exists {
-		return types.ErrDomainNotFound
+
+	if endpoint.ID == "" {
+		endpoint.ID = uuid.New().String()
 	}
-	delete(r.dnsRecords, id)
+	endpoint.CreatedAt = time.Now()
+	endpoint.UpdatedAt = endpoint.CreatedAt
+	r.webhooks[endpoint.ID] = *endpoint
 	return nil
 }
 
-func (r *MockRepo) DeleteRecordsByDomain(domainID string) error {
+func (r *MockRepo) GetWebhookEndpoints() ([]types.WebhookEndpoint, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	endpoints := make([]types.WebhookEndpoint, 0, len(r.webhooks))
+	for _, endpoint := range r.webhooks {
+		endpoints = append(endpoints, endpoint)
+	}
+	sort.Slice(endpoints, func(i, j int) bool { return endpoints[i].CreatedAt.Before(endpoints[j].CreatedAt) })
+	return endpoints, nil
+}
+
+func (r *MockRepo) GetWebhookEndpointByID(id string) (*types.WebhookEndpoint, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	endpoint, exists := r.webhooks[id]
+	if !exists {
+		return nil, types.ErrWebhookNotFound
+	}
+	return &endpoint, nil
+}
+
+func (r *MockRepo) UpdateWebhookEndpoint(endpoint *types.WebhookEndpoint) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
-	
-	for id, record := range r.dnsRecords {
-		if record.DomainID == domainID {
-			delete(r.dnsRecords, id)
-		}
+
+	if _, exists := r.webhooks[endpoint.ID]; !exists {
+		return types.ErrWebhookNotFound
 	}
+	endpoint.UpdatedAt = time.Now()
+	r.webhooks[endpoint.ID] = *endpoint
 	return nil
 }
 
-func (r *MockRepo) BulkCreateRecords(records []types.DNSRecord) error {
+func (r *MockRepo) DeleteWebhookEndpoint(id string) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
-	
-	for _, record := range records {
-		if record.ID == "" {
-			record.ID = uuid.New().String()
-		}
-		now := time.Now()
-		record.CreatedAt = now
-		record.UpdatedAt = now
-		r.dnsRecords[record.ID] = record
+
+	if _, exists := r.webhooks[id]; !exists {
+		return types.ErrWebhookNotFound
+	}
+	delete(r.webhooks, id)
+	return nil
+}
+
+func (r *MockRepo) GetNotificationTemplates() ([]types.NotificationTemplate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	templates := make([]types.NotificationTemplate, 0, len(r.notificationTemplates))
+	for _, template := range r.notificationTemplates {
+		templates = append(templates, template)
+	}
+	sort.Slice(templates, func(i, j int) bool {
+		if templates[i].Event != templates[j].Event {
+			return templates[i].Event < templates[j].Event
+		}
+		return templates[i].Channel < templates[j].Channel
+	})
+	return templates, nil
+}
+
+func (r *MockRepo) SaveNotificationTemplate(template *types.NotificationTemplate) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := template.Channel + "/" + template.Event
+	now := time.Now()
+	template.CreatedAt = now
+	if existing, exists := r.notificationTemplates[key]; exists {
+		template.CreatedAt = existing.CreatedAt
+	}
+	template.UpdatedAt = now
+	r.notificationTemplates[key] = *template
+	return nil
+}
+
+func (r *MockRepo) DeleteNotificationTemplate(channel, event string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := channel + "/" + event
+	if _, exists := r.notificationTemplates[key]; !exists {
+		return types.ErrNotificationTemplateNotFound
+	}
+	delete(r.notificationTemplates, key)
+	return nil
+}
+
+func (r *MockRepo) CreateCategorizationRule(rule *types.CategorizationRule) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if rule.ID == "" {
+		rule.ID = uuid.New().String()
+	}
+	rule.CreatedAt = time.Now()
+	rule.UpdatedAt = rule.CreatedAt
+	r.categorizationRules[rule.ID] = *rule
+	return nil
+}
+
+func (r *MockRepo) GetCategorizationRules() ([]types.CategorizationRule, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	rules := make([]types.CategorizationRule, 0, len(r.categorizationRules))
+	for _, rule := range r.categorizationRules {
+		rules = append(rules, rule)
+	}
+	sort.Slice(rules, func(i, j int) bool {
+		if rules[i].Priority != rules[j].Priority {
+			return rules[i].Priority < rules[j].Priority
+		}
+		return rules[i].CreatedAt.Before(rules[j].CreatedAt)
+	})
+	return rules, nil
+}
+
+func (r *MockRepo) GetCategorizationRuleByID(id string) (*types.CategorizationRule, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	rule, exists := r.categorizationRules[id]
+	if !exists {
+		return nil, types.ErrCategorizationRuleNotFound
+	}
+	return &rule, nil
+}
+
+func (r *MockRepo) UpdateCategorizationRule(rule *types.CategorizationRule) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.categorizationRules[rule.ID]; !exists {
+		return types.ErrCategorizationRuleNotFound
+	}
+	rule.UpdatedAt = time.Now()
+	r.categorizationRules[rule.ID] = *rule
+	return nil
+}
+
+func (r *MockRepo) DeleteCategorizationRule(id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.categorizationRules[id]; !exists {
+		return types.ErrCategorizationRuleNotFound
+	}
+	delete(r.categorizationRules, id)
+	return nil
+}
+
+// savedViewNameTaken reports whether the user has another view with the name
+func (r *MockRepo) savedViewNameTaken(view *types.SavedView) bool {
+	for _, existing := range r.savedViews {
+		if existing.ID != view.ID && existing.UserID == view.UserID && existing.Name == view.Name {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *MockRepo) CreateSavedView(view *types.SavedView) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if view.ID == "" {
+		view.ID = uuid.New().String()
+	}
+	if r.savedViewNameTaken(view) {
+		return types.ErrSavedViewExists
+	}
+	view.CreatedAt = time.Now()
+	view.UpdatedAt = view.CreatedAt
+	r.savedViews[view.ID] = *view
+	return nil
+}
+
+func (r *MockRepo) GetSavedViews(userID string) ([]types.SavedView, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	views := []types.SavedView{}
+	for _, view := range r.savedViews {
+		if view.UserID == userID {
+			views = append(views, view)
+		}
+	}
+	sort.Slice(views, func(i, j int) bool { return views[i].Name < views[j].Name })
+	return views, nil
+}
+
+func (r *MockRepo) GetSavedViewByID(id string) (*types.SavedView, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	view, exists := r.savedViews[id]
+	if !exists {
+		return nil, types.ErrSavedViewNotFound
+	}
+	return &view, nil
+}
+
+func (r *MockRepo) UpdateSavedView(view *types.SavedView) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.savedViews[view.ID]; !exists {
+		return types.ErrSavedViewNotFound
+	}
+	if r.savedViewNameTaken(view) {
+		return types.ErrSavedViewExists
+	}
+	view.UpdatedAt = time.Now()
+	r.savedViews[view.ID] = *view
+	return nil
+}
+
+func (r *MockRepo) DeleteSavedView(id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.savedViews[id]; !exists {
+		return types.ErrSavedViewNotFound
+	}
+	delete(r.savedViews, id)
+	return nil
+}
+
+func (r *MockRepo) SavePortfolioSnapshot(snapshot *types.PortfolioSnapshot) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := snapshot.SnapshotDate.UTC().Format("2006-01-02")
+	if existing, ok := r.snapshots[key]; ok {
+		snapshot.ID = existing.ID
+	} else if snapshot.ID == "" {
+		snapshot.ID = uuid.New().String()
+	}
+	snapshot.CreatedAt = time.Now()
+	r.snapshots[key] = *snapshot
+	return nil
+}
+
+func (r *MockRepo) GetPortfolioSnapshots(since time.Time) ([]types.PortfolioSnapshot, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var snapshots []types.PortfolioSnapshot
+	for _, snapshot := range r.snapshots {
+		if !snapshot.SnapshotDate.Before(since) {
+			snapshots = append(snapshots, snapshot)
+		}
+	}
+	sort.Slice(snapshots, func(i, j int) bool { return snapshots[i].SnapshotDate.Before(snapshots[j].SnapshotDate) })
+	return snapshots, nil
+}
+
+func (r *MockRepo) SaveDNSProviderState(state *types.DNSProviderState) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	saved := *state
+	saved.Records = append(types.DNSRecordList{}, state.Records...)
+	r.dnsProviderState[state.DomainID] = saved
+	return nil
+}
+
+func (r *MockRepo) GetDNSProviderState(domainID string) (*types.DNSProviderState, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	state, exists := r.dnsProviderState[domainID]
+	if !exists {
+		return nil, nil
+	}
+	state.Records = append(types.DNSRecordList{}, state.Records...)
+	return &state, nil
+}
+
+func (r *MockRepo) SaveDNSSyncResult(result *types.DNSSyncResult) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.dnsSyncResults[result.DomainID] = *result
+	return nil
+}
+
+func (r *MockRepo) GetDNSSyncResult(domainID string) (*types.DNSSyncResult, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result, exists := r.dnsSyncResults[domainID]
+	if !exists {
+		return nil, nil
+	}
+	return &result, nil
+}
+
+func (r *MockRepo) CreateDNSBackup(backup *types.DNSBackup) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if backup.ID == "" {
+		backup.ID = uuid.New().String()
+	}
+	if backup.CreatedAt.IsZero() {
+		backup.CreatedAt = time.Now()
+	}
+	backup.RecordCount = len(backup.Records)
+	saved := *backup
+	saved.Records = append(types.DNSRecordList{}, backup.Records...)
+	r.dnsBackups = append(r.dnsBackups, saved)
+	return nil
+}
+
+func (r *MockRepo) GetDNSBackups(domainID string) ([]types.DNSBackup, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	backups := []types.DNSBackup{}
+	for i := len(r.dnsBackups) - 1; i >= 0; i-- {
+		if r.dnsBackups[i].DomainID == domainID {
+			backups = append(backups, r.dnsBackups[i])
+		}
+	}
+	return backups, nil
+}
+
+func (r *MockRepo) GetDNSBackup(id string) (*types.DNSBackup, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, backup := range r.dnsBackups {
+		if backup.ID == id {
+			backup.Records = append(types.DNSRecordList{}, backup.Records...)
+			return &backup, nil
+		}
+	}
+	return nil, types.ErrDNSBackupNotFound
+}
+
+func (r *MockRepo) PruneDNSBackups(domainID string, keep int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Backups are appended in creation order, so the newest are at the end
+	kept := r.dnsBackups[:0]
+	remaining := 0
+	for _, backup := range r.dnsBackups {
+		if backup.DomainID == domainID {
+			remaining++
+		}
+	}
+	for _, backup := range r.dnsBackups {
+		if backup.DomainID == domainID && remaining > keep {
+			remaining--
+			continue
+		}
+		kept = append(kept, backup)
+	}
+	r.dnsBackups = kept
+	return nil
+}
+
+func (r *MockRepo) CreateWatchlistEntry(entry *types.WatchlistEntry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.watchlist {
+		if existing.Domain == entry.Domain {
+			return types.ErrWatchlistExists
+		}
+	}
+	if entry.ID == "" {
+		entry.ID = uuid.New().String()
+	}
+	entry.CreatedAt = time.Now()
+	entry.UpdatedAt = entry.CreatedAt
+	r.watchlist[entry.ID] = *entry
+	return nil
+}
+
+func (r *MockRepo) GetWatchlist() ([]types.WatchlistEntry, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	entries := make([]types.WatchlistEntry, 0, len(r.watchlist))
+	for _, entry := range r.watchlist {
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		a, b := entries[i].LastCheckedAt, entries[j].LastCheckedAt
+		switch {
+		case a == nil && b == nil:
+			return entries[i].Domain < entries[j].Domain
+		case a == nil || b == nil:
+			return a == nil
+		case !a.Equal(*b):
+			return a.Before(*b)
+		}
+		return entries[i].Domain < entries[j].Domain
+	})
+	return entries, nil
+}
+
+func (r *MockRepo) GetWatchlistEntryByID(id string) (*types.WatchlistEntry, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	entry, exists := r.watchlist[id]
+	if !exists {
+		return nil, types.ErrWatchlistNotFound
+	}
+	return &entry, nil
+}
+
+func (r *MockRepo) UpdateWatchlistEntry(entry *types.WatchlistEntry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.watchlist[entry.ID]; !exists {
+		return types.ErrWatchlistNotFound
+	}
+	entry.UpdatedAt = time.Now()
+	r.watchlist[entry.ID] = *entry
+	return nil
+}
+
+func (r *MockRepo) DeleteWatchlistEntry(id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.watchlist[id]; !exists {
+		return types.ErrWatchlistNotFound
+	}
+	delete(r.watchlist, id)
+	return nil
+}
+
+func (r *MockRepo) SaveRegistryExpiry(expiry *types.RegistryExpiry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.registryExpiry[expiry.DomainID] = *expiry
+	return nil
+}
+
+func (r *MockRepo) GetRegistryExpiries() ([]types.RegistryExpiry, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	expiries := make([]types.RegistryExpiry, 0, len(r.registryExpiry))
+	for _, expiry := range r.registryExpiry {
+		domain, exists := r.domains[expiry.DomainID]
+		if !exists {
+			continue
+		}
+		expiry.DomainName = domain.Name
+		expiries = append(expiries, expiry)
+	}
+	sort.Slice(expiries, func(i, j int) bool {
+		if !expiries[i].CheckedAt.Equal(expiries[j].CheckedAt) {
+			return expiries[i].CheckedAt.Before(expiries[j].CheckedAt)
+		}
+		return expiries[i].DomainName < expiries[j].DomainName
+	})
+	return expiries, nil
+}
+
+func (r *MockRepo) CreateRenewalPriceChange(change *types.RenewalPriceChange) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if change.ID == "" {
+		change.ID = uuid.New().String()
+	}
+	if change.ChangedAt.IsZero() {
+		change.ChangedAt = time.Now()
+	}
+	r.priceHistory = append(r.priceHistory, *change)
+	return nil
+}
+
+func (r *MockRepo) GetRenewalPriceHistory(domainID string, limit int) ([]types.RenewalPriceChange, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var changes []types.RenewalPriceChange
+	for _, change := range r.priceHistory {
+		if change.DomainID == domainID {
+			changes = append(changes, change)
+		}
+	}
+	if limit > 0 && len(changes) > limit {
+		changes = changes[len(changes)-limit:]
+	}
+	return changes, nil
+}
+
+func (r *MockRepo) DeleteSession(token string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	
+	for id, session := range r.sessions {
+		if session.Token == token {
+			delete(r.sessions, id)
+			return nil
+		}
+	}
+	return types.ErrDomainNotFound
+}
+
+func (r *MockRepo) DeleteExpiredSessions() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	
+	now := time.Now()
+	for id, session := range r.sessions {
+		if session.ExpiresAt.Before(now) {
+			delete(r.sessions, id)
+		}
+	}
+	return nil
+}
+
+// DNS repository methods
+func (r *MockRepo) CreateRecord(record *types.DNSRecord) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	
+	if record.ID == "" {
+		record.ID = uuid.New().String()
+	}
+	now := time.Now()
+	record.CreatedAt = now
+	record.UpdatedAt = now
+	r.dnsRecords[record.ID] = *record
+	return nil
+}
+
+func (r *MockRepo) GetRecordsByDomain(domainID string) ([]types.DNSRecord, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	
+	var records []types.DNSRecord
+	for _, record := range r.dnsRecords {
+		if record.DomainID == domainID {
+			records = append(records, record)
+		}
+	}
+	return records, nil
+}
+
+func (r *MockRepo) GetRecordsPage(domainID string, filter types.DNSRecordFilter) ([]types.DNSRecord, error) {
+	records, err := r.GetRecordsByDomain(domainID)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(records, func(i, j int) bool {
+		if records[i].Type != records[j].Type {
+			return records[i].Type < records[j].Type
+		}
+		if records[i].Name != records[j].Name {
+			return records[i].Name < records[j].Name
+		}
+		return records[i].ID < records[j].ID
+	})
+	page, _ := filter.Page(records)
+	return page, nil
+}
+
+func (r *MockRepo) CountRecordsByType(domainID string) (map[string]int, error) {
+	records, err := r.GetRecordsByDomain(domainID)
+	if err != nil {
+		return nil, err
+	}
+	return types.CountDNSRecordsByType(records), nil
+}
+
+func (r *MockRepo) GetRecordsByValues(recordTypes, values []string) ([]types.DNSRecord, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	
+	wantTypes := make(map[string]bool, len(recordTypes))
+	for _, t := range recordTypes {
+		wantTypes[strings.ToUpper(t)] = true
+	}
+	wantValues := make(map[string]bool, len(values))
+	for _, v := range values {
+		wantValues[v] = true
+	}
+	var records []types.DNSRecord
+	for _, record := range r.dnsRecords {
+		if wantTypes[strings.ToUpper(record.Type)] && wantValues[types.InfrastructureValue(record.Value)] {
+			records = append(records, record)
+		}
+	}
+	return records, nil
+}
+
+func (r *MockRepo) GetRecordsByType(recordType string) ([]types.DNSRecord, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var records []types.DNSRecord
+	for _, record := range r.dnsRecords {
+		if strings.EqualFold(record.Type, recordType) {
+			records = append(records, record)
+		}
+	}
+	return records, nil
+}
+
+func (r *MockRepo) CreateDNSHistory(entries []types.DNSRecordHistory) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	
+	for _, entry := range entries {
+		if entry.ID == "" {
+			entry.ID = uuid.New().String()
+		}
+		if entry.ChangedAt.IsZero() {
+			entry.ChangedAt = time.Now()
+		}
+		r.dnsHistory = append(r.dnsHistory, entry)
+	}
+	return nil
+}
+
+func (r *MockRepo) GetDNSHistory(domainID string, limit int) ([]types.DNSRecordHistory, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	
+	// Entries are appended in order, so the slice is already chronological
+	var entries []types.DNSRecordHistory
+	for _, entry := range r.dnsHistory {
+		if entry.DomainID == domainID {
+			entries = append(entries, entry)
+		}
+	}
+	if limit > 0 && len(entries) > limit {
+		entries = entries[len(entries)-limit:]
+	}
+	return entries, nil
+}
+
+func (r *MockRepo) CreateNameserverChange(change *types.NameserverChange) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if change.ID == "" {
+		change.ID = uuid.New().String()
+	}
+	if change.DetectedAt.IsZero() {
+		change.DetectedAt = time.Now()
+	}
+	r.nsHistory = append(r.nsHistory, *change)
+	return nil
+}
+
+func (r *MockRepo) GetNameserverHistory(domainID string, limit int) ([]types.NameserverChange, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var changes []types.NameserverChange
+	for _, change := range r.nsHistory {
+		if change.DomainID == domainID {
+			changes = append(changes, change)
+		}
+	}
+	if limit > 0 && len(changes) > limit {
+		changes = changes[len(changes)-limit:]
+	}
+	return changes, nil
+}
+
+func (r *MockRepo) GetRecordByID(id string) (*types.DNSRecord, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	
+	record, exists := r.dnsRecords[id]
+	if !exists {
+		return nil, types.ErrDomainNotFound
+	}
+	return &record, nil
+}
+
+func (r *MockRepo) UpdateRecord(record *types.DNSRecord) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	
+	if _, exists := r.dnsRecords[record.ID]; !exists {
+		return types.ErrDomainNotFound
+	}
+	record.UpdatedAt = time.Now()
+	r.dnsRecords[record.ID] = *record
+	return nil
+}
+
+func (r *MockRepo) DeleteRecord(id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	
+	if _, exists := r.dnsRecords[id]; !exists {
+		return types.ErrDomainNotFound
+	}
+	delete(r.dnsRecords, id)
+	return nil
+}
+
+func (r *MockRepo) DeleteRecordsByDomain(domainID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	
+	for id, record := range r.dnsRecords {
+		if record.DomainID == domainID {
+			delete(r.dnsRecords, id)
+		}
+	}
+	return nil
+}
+
+func (r *MockRepo) BulkCreateRecords(records []types.DNSRecord) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	
+	for i := range records {
+		if records[i].ID == "" {
+			records[i].ID = uuid.New().String()
+		}
+		now := time.Now()
+		records[i].CreatedAt = now
+		records[i].UpdatedAt = now
+		r.dnsRecords[records[i].ID] = records[i]
+	}
+	return nil
+}
+
+func (r *MockRepo) ReplaceRecordsByDomain(domainID string, records []types.DNSRecord) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, record := range r.dnsRecords {
+		if record.DomainID == domainID {
+			delete(r.dnsRecords, id)
+		}
+	}
+	now := time.Now()
+	for i := range records {
+		if records[i].ID == "" {
+			records[i].ID = uuid.New().String()
+		}
+		records[i].DomainID = domainID
+		records[i].CreatedAt = now
+		records[i].UpdatedAt = now
+		r.dnsRecords[records[i].ID] = records[i]
 	}
 	return nil
 }
@@ -959,13 +2080,48 @@ func (r *MockRepo) DeleteSecureCredentials(id string) error {
 	return nil
 }
 
-// NewRepo creates a repository instance based on the connection string
-func NewRepo(dsn string) (DomainRepository, error) {
+func (r *MockRepo) PutSecret(reference string, secret types.CredentialsMap) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored := make(types.CredentialsMap, len(secret))
+	for key, value := range secret {
+		stored[key] = value
+	}
+	r.secrets[reference] = stored
+	return nil
+}
+
+func (r *MockRepo) GetSecret(reference string) (types.CredentialsMap, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	secret, exists := r.secrets[reference]
+	if !exists {
+		return nil, types.ErrSecretNotFound
+	}
+	copied := make(types.CredentialsMap, len(secret))
+	for key, value := range secret {
+		copied[key] = value
+	}
+	return copied, nil
+}
+
+func (r *MockRepo) DeleteSecret(reference string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.secrets, reference)
+	return nil
+}
+
+// NewRepo creates a repository instance based on the connection string; pool applies to Postgres only
+func NewRepo(dsn string, pool PoolConfig) (DomainRepository, error) {
 	if strings.HasPrefix(dsn, "mock://") {
 		return NewMockRepo(), nil
 	}
 	if strings.HasPrefix(dsn, "sqlite://") || strings.HasSuffix(dsn, ".db") || strings.HasSuffix(dsn, ".sqlite") {
 		return NewSQLiteRepo(dsn)
 	}
-	return NewPostgresRepo(dsn)
+	return NewPostgresRepo(dsn, pool)
 }
@@ -0,0 +1,123 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// PoolConfig tunes the Postgres connection pool, per-query timeout and upsert batch size
+type PoolConfig struct {
+	MaxOpenConns    int           // 0 uses the default
+	MaxIdleConns    int           // 0 uses the default
+	ConnMaxLifetime time.Duration // 0 uses the default
+	QueryTimeout    time.Duration // Deadline for each query or transaction, 0 disables it
+	UpsertBatchSize int           // Domains written per UpsertDomains statement, 0 uses the default
+}
+
+// maxPlaceholders is PostgreSQL's limit on bind parameters in one statement
+const maxPlaceholders = 65535
+
+// maxUpsertBatchSize keeps a batch's placeholders, one per upsert column per domain, under
+// maxPlaceholders
+var maxUpsertBatchSize = maxPlaceholders / len(upsertColumns)
+
+// DefaultPoolConfig returns the pool settings used when none are configured
+func DefaultPoolConfig() PoolConfig {
+	return PoolConfig{
+		MaxOpenConns:    25,
+		MaxIdleConns:    5,
+		ConnMaxLifetime: 5 * time.Minute,
+		QueryTimeout:    30 * time.Second,
+		UpsertBatchSize: 500,
+	}
+}
+
+// withDefaults fills unset pool settings from DefaultPoolConfig
+func (p PoolConfig) withDefaults() PoolConfig {
+	defaults := DefaultPoolConfig()
+	if p.MaxOpenConns <= 0 {
+		p.MaxOpenConns = defaults.MaxOpenConns
+	}
+	if p.MaxIdleConns <= 0 {
+		p.MaxIdleConns = defaults.MaxIdleConns
+	}
+	if p.ConnMaxLifetime <= 0 {
+		p.ConnMaxLifetime = defaults.ConnMaxLifetime
+	}
+	if p.UpsertBatchSize <= 0 {
+		p.UpsertBatchSize = defaults.UpsertBatchSize
+	}
+	if p.UpsertBatchSize > maxUpsertBatchSize {
+		p.UpsertBatchSize = maxUpsertBatchSize
+	}
+	return p
+}
+
+// timeoutDB wraps sqlx.DB so every query runs under the configured timeout and a runaway
+// query fails instead of holding a request (and a pooled connection) forever
+type timeoutDB struct {
+	db      *sqlx.DB
+	timeout time.Duration
+}
+
+// context returns a context carrying the query deadline
+func (t *timeoutDB) context() (context.Context, context.CancelFunc) {
+	if t.timeout <= 0 {
+		return context.WithCancel(context.Background())
+	}
+	return context.WithTimeout(context.Background(), t.timeout)
+}
+
+func (t *timeoutDB) Get(dest interface{}, query string, args ...interface{}) error {
+	ctx, cancel := t.context()
+	defer cancel()
+	return t.db.GetContext(ctx, dest, query, args...)
+}
+
+func (t *timeoutDB) Select(dest interface{}, query string, args ...interface{}) error {
+	ctx, cancel := t.context()
+	defer cancel()
+	return t.db.SelectContext(ctx, dest, query, args...)
+}
+
+func (t *timeoutDB) Exec(query string, args ...interface{}) (sql.Result, error) {
+	ctx, cancel := t.context()
+	defer cancel()
+	return t.db.ExecContext(ctx, query, args...)
+}
+
+func (t *timeoutDB) NamedExec(query string, arg interface{}) (sql.Result, error) {
+	ctx, cancel := t.context()
+	defer cancel()
+	return t.db.NamedExecContext(ctx, query, arg)
+}
+
+// Beginx starts a transaction bounded by the query timeout as a whole. The returned
+// cancel func must be called once the transaction is committed or rolled back.
+func (t *timeoutDB) Beginx() (*sqlx.Tx, context.CancelFunc, error) {
+	ctx, cancel := t.context()
+	tx, err := t.db.BeginTxx(ctx, nil)
+	if err != nil {
+		cancel()
+		return nil, nil, err
+	}
+	return tx, cancel, nil
+}
+
+func (t *timeoutDB) Ping() error {
+	ctx, cancel := t.context()
+	defer cancel()
+	return t.db.PingContext(ctx)
+}
+
+func (t *timeoutDB) Close() error {
+	return t.db.Close()
+}
+
+// PoolStats reports connection pool usage for health checks
+func (r *PostgresRepo) PoolStats() sql.DBStats {
+	return r.db.db.Stats()
+}
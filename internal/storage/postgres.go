@@ -2,34 +2,39 @@ package storage
 
 import (
 	"database/sql"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
-	_ "github.com/lib/pq" // PostgreSQL driver
+	"github.com/lib/pq" // PostgreSQL driver
 	"github.com/rusiqe/domainvault/internal/types"
 )
 
 // PostgresRepo implements DomainRepository for PostgreSQL
 type PostgresRepo struct {
-	db *sqlx.DB
+	db              *timeoutDB
+	upsertBatchSize int
 }
 
 // NewPostgresRepo creates a new PostgreSQL repository
-func NewPostgresRepo(dsn string) (*PostgresRepo, error) {
+func NewPostgresRepo(dsn string, pool PoolConfig) (*PostgresRepo, error) {
 	db, err := sqlx.Connect("postgres", dsn)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
 
 	// Configure connection pool
-	db.SetMaxOpenConns(25)
-	db.SetMaxIdleConns(5)
-	db.SetConnMaxLifetime(5 * time.Minute)
+	pool = pool.withDefaults()
+	db.SetMaxOpenConns(pool.MaxOpenConns)
+	db.SetMaxIdleConns(pool.MaxIdleConns)
+	db.SetConnMaxLifetime(pool.ConnMaxLifetime)
 
-	repo := &PostgresRepo{db: db}
+	repo := &PostgresRepo{db: &timeoutDB{db: db, timeout: pool.QueryTimeout}, upsertBatchSize: pool.UpsertBatchSize}
 	
 	// Test connection
 	if err := repo.Ping(); err != nil {
@@ -49,23 +54,39 @@ func (r *PostgresRepo) Ping() error {
 	return r.db.Ping()
 }
 
-// UpsertDomains inserts or updates multiple domains
-func (r *PostgresRepo) UpsertDomains(domains []types.Domain) error {
-	if len(domains) == 0 {
-		return nil
-	}
+// upsertColumns are the domain columns written by UpsertDomains, in placeholder order
+var upsertColumns = []string{"id", "name", "provider", "credential_id", "account_ref", "expires_at", "created_at", "updated_at", "category_id", "project_id", "auto_renew", "renewal_price", "status", "tags", "metadata", "http_status", "last_status_check", "status_message", "registrar_status", "registered_at"}
 
-	tx, err := r.db.Beginx()
-	if err != nil {
-		return fmt.Errorf("failed to begin transaction: %w", err)
-	}
-	defer tx.Rollback()
+// upsertValues returns a domain's values for upsertColumns
+func upsertValues(d *types.Domain) []interface{} {
+	return []interface{}{d.ID, d.Name, d.Provider, d.CredentialID, d.AccountRef, d.ExpiresAt, d.CreatedAt, d.UpdatedAt, d.CategoryID, d.ProjectID, d.AutoRenew, d.RenewalPrice, d.Status, d.Tags, d.Metadata, d.HTTPStatus, d.LastStatusCheck, d.StatusMessage, d.RegistrarStatus, d.RegisteredAt}
+}
 
-	query := `
-		INSERT INTO domains (id, name, provider, expires_at, created_at, updated_at, category_id, project_id, auto_renew, renewal_price, status, tags, http_status, last_status_check, status_message)
-		VALUES (:id, :name, :provider, :expires_at, :created_at, :updated_at, :category_id, :project_id, :auto_renew, :renewal_price, :status, :tags, :http_status, :last_status_check, :status_message)
+// upsertBatchQuery builds a multi-row upsert of rows domains. xmax is 0 only for a row the
+// statement inserted, which tells inserts and updates apart.
+func upsertBatchQuery(rows int) string {
+	var b strings.Builder
+	b.WriteString("INSERT INTO domains (" + strings.Join(upsertColumns, ", ") + ") VALUES ")
+	n := 1
+	for i := 0; i < rows; i++ {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteString("(")
+		for j := range upsertColumns {
+			if j > 0 {
+				b.WriteString(", ")
+			}
+			fmt.Fprintf(&b, "$%d", n)
+			n++
+		}
+		b.WriteString(")")
+	}
+	b.WriteString(`
 		ON CONFLICT (name) DO UPDATE SET
 			provider = EXCLUDED.provider,
+			credential_id = COALESCE(EXCLUDED.credential_id, domains.credential_id),
+			account_ref = COALESCE(EXCLUDED.account_ref, domains.account_ref),
 			expires_at = EXCLUDED.expires_at,
 			category_id = EXCLUDED.category_id,
 			project_id = EXCLUDED.project_id,
@@ -73,38 +94,146 @@ func (r *PostgresRepo) UpsertDomains(domains []types.Domain) error {
 			renewal_price = EXCLUDED.renewal_price,
 			status = EXCLUDED.status,
 			tags = EXCLUDED.tags,
-			http_status = EXCLUDED.http_status,
-			last_status_check = EXCLUDED.last_status_check,
-			status_message = EXCLUDED.status_message,
+			http_status = COALESCE(EXCLUDED.http_status, domains.http_status),
+			last_status_check = COALESCE(EXCLUDED.last_status_check, domains.last_status_check),
+			status_message = COALESCE(EXCLUDED.status_message, domains.status_message),
+			registrar_status = COALESCE(EXCLUDED.registrar_status, domains.registrar_status),
+			registered_at = COALESCE(domains.registered_at, EXCLUDED.registered_at),
 			updated_at = NOW()
-		RETURNING id`
+		RETURNING id, name, (xmax = 0) AS inserted`)
+	return b.String()
+}
+
+// upsertBatches splits domains into batches of at most size. A name can only be written
+// once per statement, so a repeated name starts a new batch.
+func upsertBatches(domains []types.Domain, size int) [][]types.Domain {
+	var batches [][]types.Domain
+	start := 0
+	names := make(map[string]bool)
+	for i := range domains {
+		if i-start == size || names[domains[i].Name] {
+			batches = append(batches, domains[start:i])
+			start = i
+			names = make(map[string]bool)
+		}
+		names[domains[i].Name] = true
+	}
+	if start < len(domains) {
+		batches = append(batches, domains[start:])
+	}
+	return batches
+}
+
+// UpsertDomains inserts or updates multiple domains. They're written in batches of the
+// configured size with one multi-row statement and commit per batch, so a failed batch
+// doesn't undo the ones before it; the rest are still attempted. Domains that fail
+// validation are left out and listed in the result's Skipped. Each written domain's ID is
+// set to the stored row's.
+func (r *PostgresRepo) UpsertDomains(domains []types.Domain) (UpsertResult, error) {
+	var result UpsertResult
+	if len(domains) == 0 {
+		return result, nil
+	}
 
+	now := time.Now()
+	valid := make([]types.Domain, 0, len(domains))
+	positions := make([]int, 0, len(domains)) // Index in domains of each valid domain
 	for i := range domains {
 		// Generate UUID if not present
 		if domains[i].ID == "" {
 			domains[i].ID = uuid.New().String()
 		}
-		
+		if err := domains[i].Validate(); err != nil {
+			result.Skipped = append(result.Skipped, SkippedDomain{Name: domains[i].Name, Error: err.Error()})
+			continue
+		}
+
 		// Set timestamps
-		now := time.Now()
 		if domains[i].CreatedAt.IsZero() {
 			domains[i].CreatedAt = now
 		}
 		domains[i].UpdatedAt = now
+		valid = append(valid, domains[i])
+		positions = append(positions, i)
+	}
 
-		_, err := tx.NamedExec(query, domains[i])
+	var firstErr error
+	failed := 0
+	for _, batch := range upsertBatches(valid, r.upsertBatchSize) {
+		inserted, updated, err := r.upsertBatch(batch)
 		if err != nil {
-			return fmt.Errorf("failed to upsert domain %s: %w", domains[i].Name, err)
+			if firstErr == nil {
+				firstErr = err
+			}
+			failed += len(batch)
+			continue
 		}
+		result.Inserted += inserted
+		result.Updated += updated
 	}
+	for j, i := range positions {
+		domains[i].ID = valid[j].ID
+	}
+	if firstErr != nil {
+		return result, fmt.Errorf("failed to upsert %d of %d domains: %w", failed, len(valid), firstErr)
+	}
+	return result, nil
+}
 
-	return tx.Commit()
+// upsertBatch writes one batch of domains in its own transaction
+func (r *PostgresRepo) upsertBatch(batch []types.Domain) (inserted, updated int, err error) {
+	tx, cancel, err := r.db.Beginx()
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer cancel()
+	defer tx.Rollback()
+
+	args := make([]interface{}, 0, len(batch)*len(upsertColumns))
+	for i := range batch {
+		args = append(args, upsertValues(&batch[i])...)
+	}
+
+	rows, err := tx.Query(upsertBatchQuery(len(batch)), args...)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to upsert batch starting at %s: %w", batch[0].Name, err)
+	}
+	ids := make(map[string]string, len(batch))
+	for rows.Next() {
+		var id, name string
+		var wasInserted bool
+		if err := rows.Scan(&id, &name, &wasInserted); err != nil {
+			rows.Close()
+			return 0, 0, fmt.Errorf("failed to read upsert result: %w", err)
+		}
+		ids[name] = id
+		if wasInserted {
+			inserted++
+		} else {
+			updated++
+		}
+	}
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return 0, 0, fmt.Errorf("failed to upsert batch starting at %s: %w", batch[0].Name, err)
+	}
+	rows.Close()
+
+	if err := tx.Commit(); err != nil {
+		return 0, 0, fmt.Errorf("failed to commit batch starting at %s: %w", batch[0].Name, err)
+	}
+	for i := range batch {
+		if id, ok := ids[batch[i].Name]; ok {
+			batch[i].ID = id
+		}
+	}
+	return inserted, updated, nil
 }
 
 // GetAll retrieves all domains
 func (r *PostgresRepo) GetAll() ([]types.Domain, error) {
 	var domains []types.Domain
-query := "SELECT id, name, provider, expires_at, created_at, updated_at, category_id, project_id, auto_renew, renewal_price, status, tags, visible, http_status, last_status_check, status_message FROM domains WHERE visible = TRUE ORDER BY created_at DESC"
+query := "SELECT id, name, provider, expires_at, created_at, updated_at, category_id, project_id, auto_renew, renewal_price, status, tags, metadata, visible, http_status, last_status_check, status_message, ssl_status, redirect_url, last_response_time, uptime_robot_monitor_id, uptime_ratio, response_time, monitor_status, last_downtime, credential_id, account_ref, registrar_status, verification_token, verification_requested_at, verified_at, registered_at FROM domains WHERE visible = TRUE ORDER BY created_at DESC"
 	
 	err := r.db.Select(&domains, query)
 	if err != nil {
@@ -117,7 +246,7 @@ query := "SELECT id, name, provider, expires_at, created_at, updated_at, categor
 // GetByID retrieves a domain by its ID
 func (r *PostgresRepo) GetByID(id string) (*types.Domain, error) {
 	var domain types.Domain
-query := "SELECT id, name, provider, expires_at, created_at, updated_at, category_id, project_id, auto_renew, renewal_price, status, tags, visible, http_status, last_status_check, status_message FROM domains WHERE id = $1 AND visible = TRUE"
+query := "SELECT id, name, provider, expires_at, created_at, updated_at, category_id, project_id, auto_renew, renewal_price, status, tags, metadata, visible, http_status, last_status_check, status_message, ssl_status, redirect_url, last_response_time, uptime_robot_monitor_id, uptime_ratio, response_time, monitor_status, last_downtime, credential_id, account_ref, registrar_status, verification_token, verification_requested_at, verified_at, registered_at FROM domains WHERE id = $1 AND visible = TRUE"
 	
 	err := r.db.Get(&domain, query, id)
 	if err != nil {
@@ -130,14 +259,33 @@ query := "SELECT id, name, provider, expires_at, created_at, updated_at, categor
 	return &domain, nil
 }
 
+// GetByMonitorID retrieves the visible domain linked to an UptimeRobot monitor
+func (r *PostgresRepo) GetByMonitorID(monitorID int) (*types.Domain, error) {
+	var domain types.Domain
+	query := "SELECT id, name, provider, expires_at, created_at, updated_at, category_id, project_id, auto_renew, renewal_price, status, tags, metadata, visible, http_status, last_status_check, status_message, ssl_status, redirect_url, last_response_time, uptime_robot_monitor_id, uptime_ratio, response_time, monitor_status, last_downtime, credential_id, account_ref, registrar_status, verification_token, verification_requested_at, verified_at, registered_at FROM domains WHERE uptime_robot_monitor_id = $1 AND visible = TRUE LIMIT 1"
+
+	if err := r.db.Get(&domain, query, monitorID); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, types.ErrDomainNotFound
+		}
+		return nil, fmt.Errorf("failed to get domain by monitor ID: %w", err)
+	}
+	return &domain, nil
+}
+
 // GetByFilter retrieves domains based on filter criteria
 func (r *PostgresRepo) GetByFilter(filter types.DomainFilter) ([]types.Domain, error) {
+	// The sort field is interpolated into ORDER BY, so it must pass the allow-list first
+	if err := filter.ValidateSort(); err != nil {
+		return nil, err
+	}
+
 	var domains []types.Domain
 	var conditions []string
 	var args []interface{}
 	var argIndex int
 
-query := "SELECT id, name, provider, expires_at, created_at, updated_at, category_id, project_id, auto_renew, renewal_price, status, tags, visible, http_status, last_status_check, status_message FROM domains"
+query := "SELECT id, name, provider, expires_at, created_at, updated_at, category_id, project_id, auto_renew, renewal_price, status, tags, metadata, visible, http_status, last_status_check, status_message, ssl_status, redirect_url, last_response_time, uptime_robot_monitor_id, uptime_ratio, response_time, monitor_status, last_downtime, credential_id, account_ref, registrar_status, verification_token, verification_requested_at, verified_at, registered_at FROM domains"
 
 	// Build WHERE conditions
 	if filter.Provider != "" {
@@ -176,6 +324,38 @@ query := "SELECT id, name, provider, expires_at, created_at, updated_at, categor
 		args = append(args, *filter.ProjectID)
 	}
 
+	if filter.AccountRef != nil {
+		argIndex++
+		conditions = append(conditions, fmt.Sprintf("account_ref = $%d", argIndex))
+		args = append(args, *filter.AccountRef)
+	}
+
+	if filter.AutoRenew != nil {
+		argIndex++
+		conditions = append(conditions, fmt.Sprintf("auto_renew = $%d", argIndex))
+		args = append(args, *filter.AutoRenew)
+	}
+
+	// Containment (@>) lets Postgres use the GIN index on metadata; keys are sorted so the
+	// same filter always builds the same query
+	metadataKeys := make([]string, 0, len(filter.Metadata))
+	for key := range filter.Metadata {
+		metadataKeys = append(metadataKeys, key)
+	}
+	sort.Strings(metadataKeys)
+	for _, key := range metadataKeys {
+		conditions = append(conditions, fmt.Sprintf("metadata @> jsonb_build_object($%d::text, $%d::text)", argIndex+1, argIndex+2))
+		args = append(args, key, filter.Metadata[key])
+		argIndex += 2
+	}
+
+	// A scoped user sees the domains of their projects and those carrying one of their tags
+	if filter.Scope != nil && !filter.Scope.IsEmpty() {
+		conditions = append(conditions, fmt.Sprintf("(project_id::text = ANY($%d) OR jsonb_exists_any(COALESCE(tags, '[]'::jsonb), $%d))", argIndex+1, argIndex+2))
+		args = append(args, pq.Array(filter.Scope.ProjectIDs), pq.Array([]string(filter.Scope.Tags)))
+		argIndex += 2
+	}
+
 if filter.OnlyHidden {
 		conditions = append(conditions, "visible = FALSE")
 	} else if !filter.IncludeHidden {
@@ -185,7 +365,8 @@ if filter.OnlyHidden {
 		query += " WHERE " + strings.Join(conditions, " AND ")
 	}
 
-	query += " ORDER BY created_at DESC"
+	// id breaks ties so pages don't overlap when many rows share a sort value
+	query += fmt.Sprintf(" ORDER BY %s %s NULLS LAST, id", filter.SortBy, strings.ToUpper(filter.SortOrder))
 
 	// Add pagination
 	if filter.Limit > 0 {
@@ -211,7 +392,7 @@ if filter.OnlyHidden {
 // GetDomainsByName retrieves domains by exact name match
 func (r *PostgresRepo) GetDomainsByName(name string) ([]types.Domain, error) {
 	var domains []types.Domain
-	query := "SELECT id, name, provider, expires_at, created_at, updated_at, category_id, project_id, auto_renew, renewal_price, status, tags, http_status, last_status_check, status_message FROM domains WHERE name = $1"
+	query := "SELECT id, name, provider, expires_at, created_at, updated_at, category_id, project_id, auto_renew, renewal_price, status, tags, metadata, http_status, last_status_check, status_message, ssl_status, redirect_url, last_response_time, uptime_robot_monitor_id, uptime_ratio, response_time, monitor_status, last_downtime, credential_id, account_ref, registrar_status, verification_token, verification_requested_at, verified_at, registered_at FROM domains WHERE name = $1"
 	
 	err := r.db.Select(&domains, query, name)
 	if err != nil {
@@ -221,6 +402,22 @@ func (r *PostgresRepo) GetDomainsByName(name string) ([]types.Domain, error) {
 	return domains, nil
 }
 
+// GetDomainsByNames retrieves the domains whose name exactly matches any of names
+func (r *PostgresRepo) GetDomainsByNames(names []string) ([]types.Domain, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+	var domains []types.Domain
+	query := "SELECT id, name, provider, expires_at, created_at, updated_at, category_id, project_id, auto_renew, renewal_price, status, tags, metadata, http_status, last_status_check, status_message, ssl_status, redirect_url, last_response_time, uptime_robot_monitor_id, uptime_ratio, response_time, monitor_status, last_downtime, credential_id, account_ref, registrar_status, verification_token, verification_requested_at, verified_at, registered_at FROM domains WHERE name = ANY($1)"
+
+	err := r.db.Select(&domains, query, pq.Array(names))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get domains by names: %w", err)
+	}
+
+	return domains, nil
+}
+
 // Delete removes a domain by ID
 func (r *PostgresRepo) Delete(id string) error {
 result, err := r.db.Exec("UPDATE domains SET visible = FALSE, updated_at = NOW() WHERE id = $1", id)
@@ -236,6 +433,31 @@ result, err := r.db.Exec("UPDATE domains SET visible = FALSE, updated_at = NOW()
 return nil
 }
 
+// SetDomainRegisteredAt stores a domain's registration date as its registry reports it
+func (r *PostgresRepo) SetDomainRegisteredAt(id string, registeredAt time.Time) error {
+	result, err := r.db.Exec(`UPDATE domains SET registered_at = $2, updated_at = NOW() WHERE id = $1`, id, registeredAt)
+	if err != nil {
+		return fmt.Errorf("failed to store registration date of domain %s: %w", id, err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return types.ErrDomainNotFound
+	}
+	return nil
+}
+
+// SetDomainVerification stores a domain's ownership challenge token and verification times
+func (r *PostgresRepo) SetDomainVerification(id string, token *string, requestedAt, verifiedAt *time.Time) error {
+	result, err := r.db.Exec(`UPDATE domains SET verification_token = $2, verification_requested_at = $3,
+		verified_at = $4, updated_at = NOW() WHERE id = $1 AND visible = TRUE`, id, token, requestedAt, verifiedAt)
+	if err != nil {
+		return fmt.Errorf("failed to store verification of domain %s: %w", id, err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return types.ErrDomainNotFound
+	}
+	return nil
+}
+
 // SetVisibility updates the visibility (soft-delete flag) for a domain
 func (r *PostgresRepo) SetVisibility(id string, visible bool) error {
 	query := "UPDATE domains SET visible = $1, updated_at = NOW() WHERE id = $2"
@@ -250,11 +472,43 @@ func (r *PostgresRepo) SetVisibility(id string, visible bool) error {
 	return nil
 }
 
+// BulkSetVisibility sets the visibility of several domains in a single transaction. IDs that
+// match no domain are skipped; the domains that were updated are returned.
+func (r *PostgresRepo) BulkSetVisibility(ids []string, visible bool) ([]types.Domain, error) {
+	tx, cancel, err := r.db.Beginx()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer cancel()
+	defer tx.Rollback()
+
+	query := `UPDATE domains SET visible = $1, updated_at = NOW() WHERE id = $2
+	          RETURNING id, name, uptime_robot_monitor_id`
+
+	var updated []types.Domain
+	for _, id := range ids {
+		var domain types.Domain
+		if err := tx.Get(&domain, query, visible, id); err != nil {
+			if err == sql.ErrNoRows {
+				continue
+			}
+			return nil, fmt.Errorf("failed to set visibility of domain %s: %w", id, err)
+		}
+		domain.Visible = visible
+		updated = append(updated, domain)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit visibility changes: %w", err)
+	}
+	return updated, nil
+}
+
 // GetExpiring retrieves domains expiring within the threshold
 func (r *PostgresRepo) GetExpiring(threshold time.Duration) ([]types.Domain, error) {
 	var domains []types.Domain
 	query := `
-		SELECT id, name, provider, expires_at, created_at, updated_at, category_id, project_id, auto_renew, renewal_price, status, tags, http_status, last_status_check, status_message
+		SELECT id, name, provider, expires_at, created_at, updated_at, category_id, project_id, auto_renew, renewal_price, status, tags, metadata, http_status, last_status_check, status_message, ssl_status, redirect_url, last_response_time, uptime_robot_monitor_id, uptime_ratio, response_time, monitor_status, last_downtime, credential_id, account_ref, registrar_status, verification_token, verification_requested_at, verified_at, registered_at
 		FROM domains 
 		WHERE expires_at <= NOW() + $1 
 		ORDER BY expires_at ASC`
@@ -268,8 +522,9 @@ func (r *PostgresRepo) GetExpiring(threshold time.Duration) ([]types.Domain, err
 }
 
 // GetSummary provides domain statistics
-func (r *PostgresRepo) GetSummary() (*types.DomainSummary, error) {
+func (r *PostgresRepo) GetSummary(expiringDays []int) (*types.DomainSummary, error) {
 	summary := &types.DomainSummary{
+		GracePeriod: []string{},
 		ByProvider:  make(map[string]int),
 		ExpiringIn:  make(map[string]int),
 		LastSync:    time.Now(),
@@ -283,39 +538,32 @@ func (r *PostgresRepo) GetSummary() (*types.DomainSummary, error) {
 	if err := r.db.Get(&summary.Hidden, "SELECT COUNT(*) FROM domains WHERE visible = FALSE"); err != nil {
 		return nil, fmt.Errorf("failed to get hidden domain count: %w", err)
 	}
+	// Domains in their grace period, hidden or not, since a hidden one lapses all the same
+	if err := r.db.Select(&summary.GracePeriod, "SELECT name FROM domains WHERE status = $1 ORDER BY expires_at, name", types.DomainStatusGracePeriod); err != nil {
+		return nil, fmt.Errorf("failed to get grace period domains: %w", err)
+	}
 
 	// Get count by provider (visible only)
-	rows, err := r.db.Query("SELECT provider, COUNT(*) FROM domains WHERE visible = TRUE GROUP BY provider")
-	if err != nil {
+	var providerCounts []struct {
+		Provider string `db:"provider"`
+		Count    int    `db:"count"`
+	}
+	if err := r.db.Select(&providerCounts, "SELECT provider, COUNT(*) AS count FROM domains WHERE visible = TRUE GROUP BY provider"); err != nil {
 		return nil, fmt.Errorf("failed to get domains by provider: %w", err)
 	}
-	defer rows.Close()
-
-	for rows.Next() {
-		var provider string
-		var count int
-		if err := rows.Scan(&provider, &count); err != nil {
-			return nil, err
-		}
-		summary.ByProvider[provider] = count
+	for _, pc := range providerCounts {
+		summary.ByProvider[pc.Provider] = pc.Count
 	}
 
 	// Get expiring counts
 	now := time.Now()
-	expirationPeriods := map[string]time.Duration{
-		"30_days":  30 * 24 * time.Hour,
-		"90_days":  90 * 24 * time.Hour,
-		"365_days": 365 * 24 * time.Hour,
-	}
-
-for period, duration := range expirationPeriods {
+	for _, days := range expiringDays {
 		var count int
 		query := "SELECT COUNT(*) FROM domains WHERE visible = TRUE AND expires_at BETWEEN NOW() AND $1"
-		err := r.db.Get(&count, query, now.Add(duration))
-		if err != nil {
-			return nil, fmt.Errorf("failed to get expiring count for %s: %w", period, err)
+		if err := r.db.Get(&count, query, now.AddDate(0, 0, days)); err != nil {
+			return nil, fmt.Errorf("failed to get expiring count for %d days: %w", days, err)
 		}
-		summary.ExpiringIn[period] = count
+		summary.ExpiringIn[types.ExpiringInKey(days)] = count
 	}
 
 	return summary, nil
@@ -326,11 +574,14 @@ func (r *PostgresRepo) Update(domain *types.Domain) error {
 	domain.UpdatedAt = time.Now()
 	query := `
 		UPDATE domains 
-		SET name = :name, provider = :provider, expires_at = :expires_at, 
+		SET name = :name, provider = :provider, credential_id = :credential_id, account_ref = :account_ref, expires_at = :expires_at, 
 		    category_id = :category_id, project_id = :project_id, auto_renew = :auto_renew, 
-		    renewal_price = :renewal_price, status = :status, tags = :tags,
+		    renewal_price = :renewal_price, status = :status, tags = :tags, metadata = :metadata,
 		    http_status = :http_status, last_status_check = :last_status_check, 
-		    status_message = :status_message, updated_at = :updated_at
+		    status_message = :status_message, ssl_status = :ssl_status, redirect_url = :redirect_url,
+		    last_response_time = :last_response_time, uptime_robot_monitor_id = :uptime_robot_monitor_id,
+		    uptime_ratio = :uptime_ratio, response_time = :response_time, monitor_status = :monitor_status,
+		    last_downtime = :last_downtime, registrar_status = :registrar_status, registered_at = :registered_at, updated_at = :updated_at
 		WHERE id = :id`
 	
 	_, err := r.db.NamedExec(query, domain)
@@ -358,6 +609,100 @@ func (r *PostgresRepo) BulkRenew(domainIDs []string) error {
 	return nil
 }
 
+// MergeDomains consolidates duplicate domains into the canonical one: DNS records and their
+// history move to the canonical domain, it keeps the earliest created_at and any category,
+// project, tags or metadata keys it lacks, and the duplicates are deleted. Runs in a single transaction.
+func (r *PostgresRepo) MergeDomains(canonicalID string, duplicateIDs []string) (*types.Domain, error) {
+	canonical, err := r.GetByID(canonicalID)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, cancel, err := r.db.Beginx()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer cancel()
+	defer tx.Rollback()
+
+	for _, id := range duplicateIDs {
+		if id == canonicalID {
+			continue
+		}
+
+		var dup types.Domain
+		if err := tx.Get(&dup, "SELECT id, name, created_at, category_id, project_id, tags, metadata FROM domains WHERE id = $1", id); err != nil {
+			if err == sql.ErrNoRows {
+				return nil, types.ErrDomainNotFound
+			}
+			return nil, fmt.Errorf("failed to get duplicate domain %s: %w", id, err)
+		}
+
+		if dup.CreatedAt.Before(canonical.CreatedAt) {
+			canonical.CreatedAt = dup.CreatedAt
+		}
+		if canonical.CategoryID == nil {
+			canonical.CategoryID = dup.CategoryID
+		}
+		if canonical.ProjectID == nil {
+			canonical.ProjectID = dup.ProjectID
+		}
+		for _, tag := range dup.Tags {
+			if !containsString(canonical.Tags, tag) {
+				canonical.Tags = append(canonical.Tags, tag)
+			}
+		}
+		for key, value := range dup.Metadata {
+			if _, ok := canonical.Metadata[key]; !ok {
+				if canonical.Metadata == nil {
+					canonical.Metadata = types.MetadataMap{}
+				}
+				canonical.Metadata[key] = value
+			}
+		}
+
+		if _, err := tx.Exec("UPDATE dns_records SET domain_id = $1 WHERE domain_id = $2", canonicalID, id); err != nil {
+			return nil, fmt.Errorf("failed to move DNS records from %s: %w", dup.Name, err)
+		}
+		if _, err := tx.Exec("UPDATE dns_record_history SET domain_id = $1 WHERE domain_id = $2", canonicalID, id); err != nil {
+			return nil, fmt.Errorf("failed to move DNS history from %s: %w", dup.Name, err)
+		}
+		if _, err := tx.Exec("UPDATE nameserver_history SET domain_id = $1 WHERE domain_id = $2", canonicalID, id); err != nil {
+			return nil, fmt.Errorf("failed to move nameserver history from %s: %w", dup.Name, err)
+		}
+		if _, err := tx.Exec("UPDATE renewal_price_history SET domain_id = $1 WHERE domain_id = $2", canonicalID, id); err != nil {
+			return nil, fmt.Errorf("failed to move renewal price history from %s: %w", dup.Name, err)
+		}
+		if _, err := tx.Exec("DELETE FROM domains WHERE id = $1", id); err != nil {
+			return nil, fmt.Errorf("failed to delete duplicate %s: %w", dup.Name, err)
+		}
+	}
+
+	canonical.Name = types.NormalizeDomainName(canonical.Name)
+	canonical.UpdatedAt = time.Now()
+	if _, err := tx.NamedExec(`
+		UPDATE domains SET name = :name, created_at = :created_at, category_id = :category_id,
+		    project_id = :project_id, tags = :tags, metadata = :metadata, updated_at = :updated_at
+		WHERE id = :id`, canonical); err != nil {
+		return nil, fmt.Errorf("failed to update canonical domain: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit merge: %w", err)
+	}
+	return canonical, nil
+}
+
+// containsString reports whether values contains s
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
 // Category repository methods
 
 // CreateCategory creates a new category
@@ -369,11 +714,18 @@ func (r *PostgresRepo) CreateCategory(category *types.Category) error {
 	category.CreatedAt = now
 	category.UpdatedAt = now
 	
+	if err := r.checkGroupingName("categories", category.Name, category.ID, types.ErrCategoryExists); err != nil {
+		return err
+	}
+
 	query := `
 		INSERT INTO categories (id, name, description, color, created_at, updated_at)
 		VALUES (:id, :name, :description, :color, :created_at, :updated_at)`
 	
 	_, err := r.db.NamedExec(query, category)
+	if isUniqueViolation(err) {
+		return types.ErrCategoryExists
+	}
 	if err != nil {
 		return fmt.Errorf("failed to create category: %w", err)
 	}
@@ -394,6 +746,25 @@ func (r *PostgresRepo) GetAllCategories() ([]types.Category, error) {
 	return categories, nil
 }
 
+// GetCategoriesWithCounts retrieves all categories with the number of visible domains in each.
+// sortBy accepts "name" (default) or "count" (largest first).
+func (r *PostgresRepo) GetCategoriesWithCounts(sortBy string) ([]types.CategoryWithCount, error) {
+	var categories []types.CategoryWithCount
+	query := `
+		SELECT c.id, c.name, c.description, c.color, c.created_at, c.updated_at, COUNT(d.id) AS domain_count
+		FROM categories c
+		LEFT JOIN domains d ON d.category_id = c.id AND d.visible = TRUE
+		GROUP BY c.id, c.name, c.description, c.color, c.created_at, c.updated_at
+		ORDER BY ` + groupingOrderClause(sortBy, "c")
+
+	err := r.db.Select(&categories, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get categories with counts: %w", err)
+	}
+
+	return categories, nil
+}
+
 // GetCategoryByID retrieves a category by its ID
 func (r *PostgresRepo) GetCategoryByID(id string) (*types.Category, error) {
 	var category types.Category
@@ -412,6 +783,10 @@ func (r *PostgresRepo) GetCategoryByID(id string) (*types.Category, error) {
 
 // UpdateCategory updates a category
 func (r *PostgresRepo) UpdateCategory(category *types.Category) error {
+	if err := r.checkGroupingName("categories", category.Name, category.ID, types.ErrCategoryExists); err != nil {
+		return err
+	}
+
 	category.UpdatedAt = time.Now()
 	query := `
 		UPDATE categories 
@@ -419,6 +794,9 @@ func (r *PostgresRepo) UpdateCategory(category *types.Category) error {
 		WHERE id = :id`
 	
 	_, err := r.db.NamedExec(query, category)
+	if isUniqueViolation(err) {
+		return types.ErrCategoryExists
+	}
 	if err != nil {
 		return fmt.Errorf("failed to update category: %w", err)
 	}
@@ -452,11 +830,18 @@ func (r *PostgresRepo) CreateProject(project *types.Project) error {
 	project.CreatedAt = now
 	project.UpdatedAt = now
 	
+	if err := r.checkGroupingName("projects", project.Name, project.ID, types.ErrProjectExists); err != nil {
+		return err
+	}
+
 	query := `
 		INSERT INTO projects (id, name, description, color, created_at, updated_at)
 		VALUES (:id, :name, :description, :color, :created_at, :updated_at)`
 	
 	_, err := r.db.NamedExec(query, project)
+	if isUniqueViolation(err) {
+		return types.ErrProjectExists
+	}
 	if err != nil {
 		return fmt.Errorf("failed to create project: %w", err)
 	}
@@ -477,6 +862,53 @@ func (r *PostgresRepo) GetAllProjects() ([]types.Project, error) {
 	return projects, nil
 }
 
+// GetProjectsWithCounts retrieves all projects with the number of visible domains in each.
+// sortBy accepts "name" (default) or "count" (largest first).
+func (r *PostgresRepo) GetProjectsWithCounts(sortBy string) ([]types.ProjectWithCount, error) {
+	var projects []types.ProjectWithCount
+	query := `
+		SELECT p.id, p.name, p.description, p.color, p.created_at, p.updated_at, COUNT(d.id) AS domain_count
+		FROM projects p
+		LEFT JOIN domains d ON d.project_id = p.id AND d.visible = TRUE
+		GROUP BY p.id, p.name, p.description, p.color, p.created_at, p.updated_at
+		ORDER BY ` + groupingOrderClause(sortBy, "p")
+
+	err := r.db.Select(&projects, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get projects with counts: %w", err)
+	}
+
+	return projects, nil
+}
+
+// checkGroupingName returns exists when another row of table, categories or projects, has
+// name in any letter case
+func (r *PostgresRepo) checkGroupingName(table, name, id string, exists error) error {
+	var taken bool
+	query := "SELECT EXISTS(SELECT 1 FROM " + table + " WHERE LOWER(name) = LOWER($1) AND id::text <> $2)"
+	if err := r.db.Get(&taken, query, name, id); err != nil {
+		return fmt.Errorf("failed to check %s name: %w", table, err)
+	}
+	if taken {
+		return exists
+	}
+	return nil
+}
+
+// isUniqueViolation reports whether err is a Postgres unique constraint violation
+func isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23505"
+}
+
+// groupingOrderClause maps a whitelisted sort key to an ORDER BY clause for category/project listings
+func groupingOrderClause(sortBy, alias string) string {
+	if sortBy == "count" {
+		return "domain_count DESC, " + alias + ".name"
+	}
+	return alias + ".name"
+}
+
 // GetProjectByID retrieves a project by its ID
 func (r *PostgresRepo) GetProjectByID(id string) (*types.Project, error) {
 	var project types.Project
@@ -495,6 +927,10 @@ func (r *PostgresRepo) GetProjectByID(id string) (*types.Project, error) {
 
 // UpdateProject updates a project
 func (r *PostgresRepo) UpdateProject(project *types.Project) error {
+	if err := r.checkGroupingName("projects", project.Name, project.ID, types.ErrProjectExists); err != nil {
+		return err
+	}
+
 	project.UpdatedAt = time.Now()
 	query := `
 		UPDATE projects 
@@ -502,6 +938,9 @@ func (r *PostgresRepo) UpdateProject(project *types.Project) error {
 		WHERE id = :id`
 	
 	_, err := r.db.NamedExec(query, project)
+	if isUniqueViolation(err) {
+		return types.ErrProjectExists
+	}
 	if err != nil {
 		return fmt.Errorf("failed to update project: %w", err)
 	}
@@ -609,6 +1048,50 @@ func (r *PostgresRepo) UpdateCredentials(creds *types.ProviderCredentials) error
 	return nil
 }
 
+// EncryptCredentials rewrites stored credentials that are plaintext or wrapped with a retired
+// master key, so they are encrypted with the current key. Returns the number of rows rewritten.
+func (r *PostgresRepo) EncryptCredentials() (int, error) {
+	if !types.CredentialsEncryptionEnabled() {
+		return 0, nil
+	}
+
+	var rows []struct {
+		ID          string `db:"id"`
+		Credentials []byte `db:"credentials"`
+	}
+	if err := r.db.Select(&rows, `SELECT id, credentials FROM provider_credentials`); err != nil {
+		return 0, fmt.Errorf("failed to load credentials: %w", err)
+	}
+
+	tx, cancel, err := r.db.Beginx()
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer cancel()
+	defer tx.Rollback()
+
+	rewritten := 0
+	for _, row := range rows {
+		if !types.CredentialsNeedEncryption(row.Credentials) {
+			continue
+		}
+
+		var creds types.CredentialsMap
+		if err := creds.Scan(row.Credentials); err != nil {
+			return 0, fmt.Errorf("failed to read credentials %s: %w", row.ID, err)
+		}
+		if _, err := tx.Exec(`UPDATE provider_credentials SET credentials = $1 WHERE id = $2`, creds, row.ID); err != nil {
+			return 0, fmt.Errorf("failed to encrypt credentials %s: %w", row.ID, err)
+		}
+		rewritten++
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit credential encryption: %w", err)
+	}
+	return rewritten, nil
+}
+
 // DeleteCredentials deletes provider credentials
 func (r *PostgresRepo) DeleteCredentials(id string) error {
 	result, err := r.db.Exec("DELETE FROM provider_credentials WHERE id = $1", id)
@@ -728,6 +1211,39 @@ func (r *PostgresRepo) UpdateSecureCredentials(creds *types.SecureProviderCreden
 	return nil
 }
 
+// PutSecret creates or replaces the secret stored under reference
+func (r *PostgresRepo) PutSecret(reference string, secret types.CredentialsMap) error {
+	query := `
+		INSERT INTO provider_secrets (reference, secret, created_at, updated_at)
+		VALUES ($1, $2, NOW(), NOW())
+		ON CONFLICT (reference) DO UPDATE SET secret = EXCLUDED.secret, updated_at = NOW()`
+	if _, err := r.db.Exec(query, reference, secret); err != nil {
+		return fmt.Errorf("failed to store secret: %w", err)
+	}
+	return nil
+}
+
+// GetSecret returns the secret stored under reference
+func (r *PostgresRepo) GetSecret(reference string) (types.CredentialsMap, error) {
+	var secret types.CredentialsMap
+	err := r.db.Get(&secret, "SELECT secret FROM provider_secrets WHERE reference = $1", reference)
+	if err == sql.ErrNoRows {
+		return nil, types.ErrSecretNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get secret: %w", err)
+	}
+	return secret, nil
+}
+
+// DeleteSecret deletes the secret stored under reference, if there is one
+func (r *PostgresRepo) DeleteSecret(reference string) error {
+	if _, err := r.db.Exec("DELETE FROM provider_secrets WHERE reference = $1", reference); err != nil {
+		return fmt.Errorf("failed to delete secret: %w", err)
+	}
+	return nil
+}
+
 // DeleteSecureCredentials deletes secure provider credentials
 func (r *PostgresRepo) DeleteSecureCredentials(id string) error {
 	result, err := r.db.Exec("DELETE FROM secure_provider_credentials WHERE id = $1", id)
@@ -755,8 +1271,8 @@ func (r *PostgresRepo) CreateUser(user *types.User) error {
 	user.UpdatedAt = now
 	
 	query := `
-		INSERT INTO users (id, username, email, password_hash, role, enabled, created_at, updated_at)
-		VALUES (:id, :username, :email, :password_hash, :role, :enabled, :created_at, :updated_at)`
+		INSERT INTO users (id, username, email, password_hash, role, access_scope, enabled, created_at, updated_at)
+		VALUES (:id, :username, :email, :password_hash, :role, :access_scope, :enabled, :created_at, :updated_at)`
 	
 	_, err := r.db.NamedExec(query, user)
 	if err != nil {
@@ -769,7 +1285,7 @@ func (r *PostgresRepo) CreateUser(user *types.User) error {
 // GetUserByUsername retrieves a user by username
 func (r *PostgresRepo) GetUserByUsername(username string) (*types.User, error) {
 	var user types.User
-	query := `SELECT id, username, email, password_hash, role, enabled, last_login, 
+	query := `SELECT id, username, email, password_hash, role, access_scope, enabled, last_login, 
 	          created_at, updated_at FROM users WHERE username = $1`
 	
 	err := r.db.Get(&user, query, username)
@@ -786,7 +1302,7 @@ func (r *PostgresRepo) GetUserByUsername(username string) (*types.User, error) {
 // GetUserByID retrieves a user by ID
 func (r *PostgresRepo) GetUserByID(id string) (*types.User, error) {
 	var user types.User
-	query := `SELECT id, username, email, password_hash, role, enabled, last_login, 
+	query := `SELECT id, username, email, password_hash, role, access_scope, enabled, last_login, 
 	          created_at, updated_at FROM users WHERE id = $1`
 	
 	err := r.db.Get(&user, query, id)
@@ -806,7 +1322,7 @@ func (r *PostgresRepo) UpdateUser(user *types.User) error {
 	query := `
 		UPDATE users 
 		SET username = :username, email = :email, password_hash = :password_hash, 
-		    role = :role, enabled = :enabled, last_login = :last_login, updated_at = :updated_at
+		    role = :role, access_scope = :access_scope, enabled = :enabled, last_login = :last_login, updated_at = :updated_at
 		WHERE id = :id`
 	
 	_, err := r.db.NamedExec(query, user)
@@ -851,6 +1367,23 @@ func (r *PostgresRepo) CreateSession(session *types.Session) error {
 	return nil
 }
 
+// GetUserByEmail retrieves a user by email address (case-insensitive)
+func (r *PostgresRepo) GetUserByEmail(email string) (*types.User, error) {
+	var user types.User
+	query := `SELECT id, username, email, password_hash, role, access_scope, enabled, last_login, 
+	          created_at, updated_at FROM users WHERE LOWER(email) = LOWER($1)`
+
+	err := r.db.Get(&user, query, email)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, types.ErrDomainNotFound
+		}
+		return nil, fmt.Errorf("failed to get user by email: %w", err)
+	}
+
+	return &user, nil
+}
+
 // GetSessionByToken retrieves a session by token
 func (r *PostgresRepo) GetSessionByToken(token string) (*types.Session, error) {
 	var session types.Session
@@ -891,37 +1424,636 @@ func (r *PostgresRepo) DeleteExpiredSessions() error {
 	return nil
 }
 
-// UpdateLastLogin updates the last login time for a user
-func (r *PostgresRepo) UpdateLastLogin(userID string) error {
-	_, err := r.db.Exec("UPDATE users SET last_login = NOW() WHERE id = $1", userID)
+// DeleteUserSessions removes every session belonging to a user
+func (r *PostgresRepo) DeleteUserSessions(userID string) error {
+	_, err := r.db.Exec("DELETE FROM sessions WHERE user_id = $1", userID)
 	if err != nil {
-		return fmt.Errorf("failed to update last login: %w", err)
+		return fmt.Errorf("failed to delete user sessions: %w", err)
 	}
 	return nil
 }
 
-// DNS repository methods
-
-// CreateRecord creates a new DNS record
-func (r *PostgresRepo) CreateRecord(record *types.DNSRecord) error {
-	if record.ID == "" {
-		record.ID = uuid.New().String()
-	}
-	now := time.Now()
-	record.CreatedAt = now
-	record.UpdatedAt = now
-	
-	query := `
-		INSERT INTO dns_records (id, domain_id, type, name, value, ttl, priority, weight, port, created_at, updated_at)
-		VALUES (:id, :domain_id, :type, :name, :value, :ttl, :priority, :weight, :port, :created_at, :updated_at)`
-	
-	_, err := r.db.NamedExec(query, record)
+// CreatePasswordResetToken stores a password reset token hash, replacing any outstanding token for the user
+func (r *PostgresRepo) CreatePasswordResetToken(userID, tokenHash string, expiresAt time.Time) error {
+	tx, cancel, err := r.db.Beginx()
 	if err != nil {
-		return fmt.Errorf("failed to create DNS record: %w", err)
+		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
-	
-	return nil
-}
+	defer cancel()
+	defer tx.Rollback()
+
+	if _, err := tx.Exec("DELETE FROM password_reset_tokens WHERE user_id = $1", userID); err != nil {
+		return fmt.Errorf("failed to clear password reset tokens: %w", err)
+	}
+	if _, err := tx.Exec("INSERT INTO password_reset_tokens (token_hash, user_id, expires_at) VALUES ($1, $2, $3)",
+		tokenHash, userID, expiresAt); err != nil {
+		return fmt.Errorf("failed to create password reset token: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// ConsumePasswordResetToken deletes an unexpired reset token and returns its user ID
+func (r *PostgresRepo) ConsumePasswordResetToken(tokenHash string) (string, error) {
+	var userID string
+	err := r.db.Get(&userID,
+		"DELETE FROM password_reset_tokens WHERE token_hash = $1 AND expires_at > NOW() RETURNING user_id", tokenHash)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", types.ErrInvalidResetToken
+		}
+		return "", fmt.Errorf("failed to consume password reset token: %w", err)
+	}
+	return userID, nil
+}
+
+// ReserveIdempotencyKey claims an idempotency key for a new request. If the key is already
+// held by an unexpired record, that record is returned and nothing is written.
+func (r *PostgresRepo) ReserveIdempotencyKey(record *types.IdempotencyRecord) (*types.IdempotencyRecord, error) {
+	// Expired keys are cleared here; purchases are infrequent enough that this stays cheap
+	if _, err := r.db.Exec("DELETE FROM idempotency_keys WHERE expires_at <= NOW()"); err != nil {
+		return nil, fmt.Errorf("failed to clear expired idempotency keys: %w", err)
+	}
+
+	result, err := r.db.NamedExec(`
+		INSERT INTO idempotency_keys (key, request_hash, status_code, content_type, body, created_at, expires_at)
+		VALUES (:key, :request_hash, :status_code, :content_type, :body, :created_at, :expires_at)
+		ON CONFLICT (key) DO NOTHING`, record)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reserve idempotency key: %w", err)
+	}
+	if inserted, _ := result.RowsAffected(); inserted == 1 {
+		return nil, nil
+	}
+
+	var existing types.IdempotencyRecord
+	if err := r.db.Get(&existing, "SELECT key, request_hash, status_code, content_type, body, created_at, expires_at FROM idempotency_keys WHERE key = $1", record.Key); err != nil {
+		return nil, fmt.Errorf("failed to load idempotency key: %w", err)
+	}
+	return &existing, nil
+}
+
+// CompleteIdempotencyKey stores the response for a reserved idempotency key
+func (r *PostgresRepo) CompleteIdempotencyKey(key string, statusCode int, contentType string, body []byte) error {
+	result, err := r.db.Exec("UPDATE idempotency_keys SET status_code = $2, content_type = $3, body = $4 WHERE key = $1",
+		key, statusCode, contentType, body)
+	if err != nil {
+		return fmt.Errorf("failed to store idempotent response: %w", err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return types.ErrDomainNotFound
+	}
+	return nil
+}
+
+// GetSetting decodes the JSON value stored under key into dest
+func (r *PostgresRepo) GetSetting(key string, dest interface{}) error {
+	var value []byte
+	if err := r.db.Get(&value, "SELECT value FROM settings WHERE key = $1", key); err != nil {
+		if err == sql.ErrNoRows {
+			return types.ErrSettingNotFound
+		}
+		return fmt.Errorf("failed to get setting %s: %w", key, err)
+	}
+	if err := json.Unmarshal(value, dest); err != nil {
+		return fmt.Errorf("failed to decode setting %s: %w", key, err)
+	}
+	return nil
+}
+
+// SaveSetting stores value as JSON under key, replacing any previous value
+func (r *PostgresRepo) SaveSetting(key string, value interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to encode setting %s: %w", key, err)
+	}
+	query := `INSERT INTO settings (key, value, updated_at) VALUES ($1, $2, NOW())
+	          ON CONFLICT (key) DO UPDATE SET value = EXCLUDED.value, updated_at = NOW()`
+	if _, err := r.db.Exec(query, key, data); err != nil {
+		return fmt.Errorf("failed to save setting %s: %w", key, err)
+	}
+	return nil
+}
+
+const webhookEndpointColumns = `id, url, description, enabled, secrets, secret_rotated_at,
+	previous_secret_expires_at, created_at, updated_at`
+
+// CreateWebhookEndpoint stores a new webhook endpoint
+func (r *PostgresRepo) CreateWebhookEndpoint(endpoint *types.WebhookEndpoint) error {
+	if endpoint.ID == "" {
+		endpoint.ID = uuid.New().String()
+	}
+	now := time.Now()
+	endpoint.CreatedAt = now
+	endpoint.UpdatedAt = now
+
+	query := `
+		INSERT INTO webhook_endpoints (` + webhookEndpointColumns + `)
+		VALUES (:id, :url, :description, :enabled, :secrets, :secret_rotated_at,
+		        :previous_secret_expires_at, :created_at, :updated_at)`
+	if _, err := r.db.NamedExec(query, endpoint); err != nil {
+		return fmt.Errorf("failed to create webhook endpoint: %w", err)
+	}
+	return nil
+}
+
+// GetWebhookEndpoints returns every webhook endpoint, oldest first
+func (r *PostgresRepo) GetWebhookEndpoints() ([]types.WebhookEndpoint, error) {
+	var endpoints []types.WebhookEndpoint
+	query := "SELECT " + webhookEndpointColumns + " FROM webhook_endpoints ORDER BY created_at"
+	if err := r.db.Select(&endpoints, query); err != nil {
+		return nil, fmt.Errorf("failed to get webhook endpoints: %w", err)
+	}
+	return endpoints, nil
+}
+
+// GetWebhookEndpointByID retrieves a webhook endpoint by its ID
+func (r *PostgresRepo) GetWebhookEndpointByID(id string) (*types.WebhookEndpoint, error) {
+	var endpoint types.WebhookEndpoint
+	query := "SELECT " + webhookEndpointColumns + " FROM webhook_endpoints WHERE id = $1"
+	if err := r.db.Get(&endpoint, query, id); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, types.ErrWebhookNotFound
+		}
+		return nil, fmt.Errorf("failed to get webhook endpoint: %w", err)
+	}
+	return &endpoint, nil
+}
+
+// UpdateWebhookEndpoint saves an endpoint's settings, secrets and rotation timestamps
+func (r *PostgresRepo) UpdateWebhookEndpoint(endpoint *types.WebhookEndpoint) error {
+	endpoint.UpdatedAt = time.Now()
+	query := `
+		UPDATE webhook_endpoints
+		SET url = :url, description = :description, enabled = :enabled, secrets = :secrets,
+		    secret_rotated_at = :secret_rotated_at, previous_secret_expires_at = :previous_secret_expires_at,
+		    updated_at = :updated_at
+		WHERE id = :id`
+	result, err := r.db.NamedExec(query, endpoint)
+	if err != nil {
+		return fmt.Errorf("failed to update webhook endpoint: %w", err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return types.ErrWebhookNotFound
+	}
+	return nil
+}
+
+// DeleteWebhookEndpoint removes a webhook endpoint
+func (r *PostgresRepo) DeleteWebhookEndpoint(id string) error {
+	result, err := r.db.Exec("DELETE FROM webhook_endpoints WHERE id = $1", id)
+	if err != nil {
+		return fmt.Errorf("failed to delete webhook endpoint: %w", err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return types.ErrWebhookNotFound
+	}
+	return nil
+}
+
+// GetNotificationTemplates returns every stored notification template by event and channel
+func (r *PostgresRepo) GetNotificationTemplates() ([]types.NotificationTemplate, error) {
+	var templates []types.NotificationTemplate
+	query := `
+		SELECT channel, event, subject, body, created_at, updated_at
+		FROM notification_templates
+		ORDER BY event, channel`
+	if err := r.db.Select(&templates, query); err != nil {
+		return nil, fmt.Errorf("failed to get notification templates: %w", err)
+	}
+	return templates, nil
+}
+
+// SaveNotificationTemplate creates the template for its channel and event, or replaces it
+func (r *PostgresRepo) SaveNotificationTemplate(template *types.NotificationTemplate) error {
+	now := time.Now()
+	template.CreatedAt = now
+	template.UpdatedAt = now
+
+	query := `
+		INSERT INTO notification_templates (channel, event, subject, body, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $5)
+		ON CONFLICT (channel, event) DO UPDATE SET
+			subject = EXCLUDED.subject,
+			body = EXCLUDED.body,
+			updated_at = EXCLUDED.updated_at
+		RETURNING created_at`
+	err := r.db.Get(&template.CreatedAt, query, template.Channel, template.Event, template.Subject, template.Body, now)
+	if err != nil {
+		return fmt.Errorf("failed to save notification template: %w", err)
+	}
+	return nil
+}
+
+// DeleteNotificationTemplate removes the template for a channel and event, so its alerts go
+// back to the default wording
+func (r *PostgresRepo) DeleteNotificationTemplate(channel, event string) error {
+	result, err := r.db.Exec("DELETE FROM notification_templates WHERE channel = $1 AND event = $2", channel, event)
+	if err != nil {
+		return fmt.Errorf("failed to delete notification template: %w", err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return types.ErrNotificationTemplateNotFound
+	}
+	return nil
+}
+
+const categorizationRuleColumns = `id, name, priority, enabled, name_pattern, provider,
+	has_record_type, category_id, tags, created_at, updated_at`
+
+// CreateCategorizationRule stores a new categorization rule
+func (r *PostgresRepo) CreateCategorizationRule(rule *types.CategorizationRule) error {
+	if rule.ID == "" {
+		rule.ID = uuid.New().String()
+	}
+	now := time.Now()
+	rule.CreatedAt = now
+	rule.UpdatedAt = now
+
+	query := `
+		INSERT INTO categorization_rules (` + categorizationRuleColumns + `)
+		VALUES (:id, :name, :priority, :enabled, :name_pattern, :provider,
+		        :has_record_type, :category_id, :tags, :created_at, :updated_at)`
+	if _, err := r.db.NamedExec(query, rule); err != nil {
+		return fmt.Errorf("failed to create categorization rule: %w", err)
+	}
+	return nil
+}
+
+// GetCategorizationRules returns every categorization rule in the order they run
+func (r *PostgresRepo) GetCategorizationRules() ([]types.CategorizationRule, error) {
+	var rules []types.CategorizationRule
+	query := "SELECT " + categorizationRuleColumns + " FROM categorization_rules ORDER BY priority, created_at"
+	if err := r.db.Select(&rules, query); err != nil {
+		return nil, fmt.Errorf("failed to get categorization rules: %w", err)
+	}
+	return rules, nil
+}
+
+// GetCategorizationRuleByID retrieves a categorization rule by its ID
+func (r *PostgresRepo) GetCategorizationRuleByID(id string) (*types.CategorizationRule, error) {
+	var rule types.CategorizationRule
+	query := "SELECT " + categorizationRuleColumns + " FROM categorization_rules WHERE id = $1"
+	if err := r.db.Get(&rule, query, id); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, types.ErrCategorizationRuleNotFound
+		}
+		return nil, fmt.Errorf("failed to get categorization rule: %w", err)
+	}
+	return &rule, nil
+}
+
+// UpdateCategorizationRule saves a rule's conditions, actions, priority and enabled flag
+func (r *PostgresRepo) UpdateCategorizationRule(rule *types.CategorizationRule) error {
+	rule.UpdatedAt = time.Now()
+	query := `
+		UPDATE categorization_rules
+		SET name = :name, priority = :priority, enabled = :enabled, name_pattern = :name_pattern,
+		    provider = :provider, has_record_type = :has_record_type, category_id = :category_id,
+		    tags = :tags, updated_at = :updated_at
+		WHERE id = :id`
+	result, err := r.db.NamedExec(query, rule)
+	if err != nil {
+		return fmt.Errorf("failed to update categorization rule: %w", err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return types.ErrCategorizationRuleNotFound
+	}
+	return nil
+}
+
+// DeleteCategorizationRule removes a categorization rule
+func (r *PostgresRepo) DeleteCategorizationRule(id string) error {
+	result, err := r.db.Exec("DELETE FROM categorization_rules WHERE id = $1", id)
+	if err != nil {
+		return fmt.Errorf("failed to delete categorization rule: %w", err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return types.ErrCategorizationRuleNotFound
+	}
+	return nil
+}
+
+const savedViewColumns = "id, user_id, name, filter, created_at, updated_at"
+
+// CreateSavedView stores a new saved view
+func (r *PostgresRepo) CreateSavedView(view *types.SavedView) error {
+	if view.ID == "" {
+		view.ID = uuid.New().String()
+	}
+	now := time.Now()
+	view.CreatedAt = now
+	view.UpdatedAt = now
+
+	query := `
+		INSERT INTO saved_views (` + savedViewColumns + `)
+		VALUES (:id, :user_id, :name, :filter, :created_at, :updated_at)`
+	if _, err := r.db.NamedExec(query, view); err != nil {
+		if isUniqueViolation(err) {
+			return types.ErrSavedViewExists
+		}
+		return fmt.Errorf("failed to create saved view: %w", err)
+	}
+	return nil
+}
+
+// GetSavedViews returns a user's saved views by name
+func (r *PostgresRepo) GetSavedViews(userID string) ([]types.SavedView, error) {
+	var views []types.SavedView
+	query := "SELECT " + savedViewColumns + " FROM saved_views WHERE user_id = $1 ORDER BY name"
+	if err := r.db.Select(&views, query, userID); err != nil {
+		return nil, fmt.Errorf("failed to get saved views: %w", err)
+	}
+	return views, nil
+}
+
+// GetSavedViewByID retrieves a saved view by its ID
+func (r *PostgresRepo) GetSavedViewByID(id string) (*types.SavedView, error) {
+	var view types.SavedView
+	query := "SELECT " + savedViewColumns + " FROM saved_views WHERE id = $1"
+	if err := r.db.Get(&view, query, id); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, types.ErrSavedViewNotFound
+		}
+		return nil, fmt.Errorf("failed to get saved view: %w", err)
+	}
+	return &view, nil
+}
+
+// UpdateSavedView saves a view's name and filter
+func (r *PostgresRepo) UpdateSavedView(view *types.SavedView) error {
+	view.UpdatedAt = time.Now()
+	query := `
+		UPDATE saved_views
+		SET name = :name, filter = :filter, updated_at = :updated_at
+		WHERE id = :id`
+	result, err := r.db.NamedExec(query, view)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return types.ErrSavedViewExists
+		}
+		return fmt.Errorf("failed to update saved view: %w", err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return types.ErrSavedViewNotFound
+	}
+	return nil
+}
+
+// DeleteSavedView removes a saved view
+func (r *PostgresRepo) DeleteSavedView(id string) error {
+	result, err := r.db.Exec("DELETE FROM saved_views WHERE id = $1", id)
+	if err != nil {
+		return fmt.Errorf("failed to delete saved view: %w", err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return types.ErrSavedViewNotFound
+	}
+	return nil
+}
+
+// SavePortfolioSnapshot stores a day's portfolio snapshot, replacing any earlier one for that day
+func (r *PostgresRepo) SavePortfolioSnapshot(snapshot *types.PortfolioSnapshot) error {
+	if snapshot.ID == "" {
+		snapshot.ID = uuid.New().String()
+	}
+	snapshot.CreatedAt = time.Now()
+
+	query := `
+		INSERT INTO portfolio_snapshots (id, snapshot_date, total_domains, active_domains, expired_domains,
+			expiring_30, expiring_90, total_renewal_cost, status_distribution, created_at)
+		VALUES (:id, :snapshot_date, :total_domains, :active_domains, :expired_domains,
+			:expiring_30, :expiring_90, :total_renewal_cost, :status_distribution, :created_at)
+		ON CONFLICT (snapshot_date) DO UPDATE SET
+			total_domains = EXCLUDED.total_domains, active_domains = EXCLUDED.active_domains,
+			expired_domains = EXCLUDED.expired_domains, expiring_30 = EXCLUDED.expiring_30,
+			expiring_90 = EXCLUDED.expiring_90, total_renewal_cost = EXCLUDED.total_renewal_cost,
+			status_distribution = EXCLUDED.status_distribution, created_at = EXCLUDED.created_at`
+	if _, err := r.db.NamedExec(query, snapshot); err != nil {
+		return fmt.Errorf("failed to save portfolio snapshot: %w", err)
+	}
+	return nil
+}
+
+// GetPortfolioSnapshots returns the snapshots taken on or after since, oldest first
+func (r *PostgresRepo) GetPortfolioSnapshots(since time.Time) ([]types.PortfolioSnapshot, error) {
+	var snapshots []types.PortfolioSnapshot
+	query := `
+		SELECT id, snapshot_date, total_domains, active_domains, expired_domains, expiring_30,
+		       expiring_90, total_renewal_cost, status_distribution, created_at
+		FROM portfolio_snapshots
+		WHERE snapshot_date >= $1
+		ORDER BY snapshot_date`
+	if err := r.db.Select(&snapshots, query, since); err != nil {
+		return nil, fmt.Errorf("failed to get portfolio snapshots: %w", err)
+	}
+	return snapshots, nil
+}
+
+const watchlistColumns = `id, domain, max_price, currency, notes, status, last_price, last_source,
+	last_error, last_checked_at, notified_at, created_at, updated_at`
+
+// CreateWatchlistEntry adds a domain to the watchlist
+func (r *PostgresRepo) CreateWatchlistEntry(entry *types.WatchlistEntry) error {
+	if entry.ID == "" {
+		entry.ID = uuid.New().String()
+	}
+	now := time.Now()
+	entry.CreatedAt = now
+	entry.UpdatedAt = now
+
+	query := `
+		INSERT INTO watchlist (` + watchlistColumns + `)
+		VALUES (:id, :domain, :max_price, :currency, :notes, :status, :last_price, :last_source,
+		        :last_error, :last_checked_at, :notified_at, :created_at, :updated_at)
+		ON CONFLICT (domain) DO NOTHING`
+	result, err := r.db.NamedExec(query, entry)
+	if err != nil {
+		return fmt.Errorf("failed to create watchlist entry: %w", err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return types.ErrWatchlistExists
+	}
+	return nil
+}
+
+// GetWatchlist returns every watchlist entry, least recently checked first
+func (r *PostgresRepo) GetWatchlist() ([]types.WatchlistEntry, error) {
+	var entries []types.WatchlistEntry
+	query := "SELECT " + watchlistColumns + " FROM watchlist ORDER BY last_checked_at NULLS FIRST, domain"
+	if err := r.db.Select(&entries, query); err != nil {
+		return nil, fmt.Errorf("failed to get watchlist: %w", err)
+	}
+	return entries, nil
+}
+
+// GetWatchlistEntryByID retrieves a watchlist entry by its ID
+func (r *PostgresRepo) GetWatchlistEntryByID(id string) (*types.WatchlistEntry, error) {
+	var entry types.WatchlistEntry
+	query := "SELECT " + watchlistColumns + " FROM watchlist WHERE id = $1"
+	if err := r.db.Get(&entry, query, id); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, types.ErrWatchlistNotFound
+		}
+		return nil, fmt.Errorf("failed to get watchlist entry: %w", err)
+	}
+	return &entry, nil
+}
+
+// UpdateWatchlistEntry saves an entry's settings and the result of its last check
+func (r *PostgresRepo) UpdateWatchlistEntry(entry *types.WatchlistEntry) error {
+	entry.UpdatedAt = time.Now()
+	query := `
+		UPDATE watchlist
+		SET max_price = :max_price, currency = :currency, notes = :notes, status = :status,
+		    last_price = :last_price, last_source = :last_source, last_error = :last_error,
+		    last_checked_at = :last_checked_at, notified_at = :notified_at, updated_at = :updated_at
+		WHERE id = :id`
+	result, err := r.db.NamedExec(query, entry)
+	if err != nil {
+		return fmt.Errorf("failed to update watchlist entry: %w", err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return types.ErrWatchlistNotFound
+	}
+	return nil
+}
+
+// DeleteWatchlistEntry removes a domain from the watchlist
+func (r *PostgresRepo) DeleteWatchlistEntry(id string) error {
+	result, err := r.db.Exec("DELETE FROM watchlist WHERE id = $1", id)
+	if err != nil {
+		return fmt.Errorf("failed to delete watchlist entry: %w", err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return types.ErrWatchlistNotFound
+	}
+	return nil
+}
+
+// SaveRegistryExpiry stores the latest registry expiry check of a domain
+func (r *PostgresRepo) SaveRegistryExpiry(expiry *types.RegistryExpiry) error {
+	query := `
+		INSERT INTO domain_registry_expiry (domain_id, registry_expires_at, stored_expires_at, source,
+		                                    discrepancy, last_error, checked_at)
+		VALUES (:domain_id, :registry_expires_at, :stored_expires_at, :source, :discrepancy, :last_error, :checked_at)
+		ON CONFLICT (domain_id) DO UPDATE
+		SET registry_expires_at = EXCLUDED.registry_expires_at, stored_expires_at = EXCLUDED.stored_expires_at,
+		    source = EXCLUDED.source, discrepancy = EXCLUDED.discrepancy, last_error = EXCLUDED.last_error,
+		    checked_at = EXCLUDED.checked_at`
+	if _, err := r.db.NamedExec(query, expiry); err != nil {
+		return fmt.Errorf("failed to save registry expiry: %w", err)
+	}
+	return nil
+}
+
+// GetRegistryExpiries returns every domain's latest registry expiry check, least recently checked first
+func (r *PostgresRepo) GetRegistryExpiries() ([]types.RegistryExpiry, error) {
+	var expiries []types.RegistryExpiry
+	query := `
+		SELECT e.domain_id, d.name AS domain_name, e.registry_expires_at, e.stored_expires_at, e.source,
+		       e.discrepancy, e.last_error, e.checked_at
+		FROM domain_registry_expiry e
+		JOIN domains d ON d.id = e.domain_id
+		ORDER BY e.checked_at, d.name`
+	if err := r.db.Select(&expiries, query); err != nil {
+		return nil, fmt.Errorf("failed to get registry expiries: %w", err)
+	}
+	return expiries, nil
+}
+
+// CreateRenewalPriceChange records a change to a domain's renewal price
+func (r *PostgresRepo) CreateRenewalPriceChange(change *types.RenewalPriceChange) error {
+	if change.ID == "" {
+		change.ID = uuid.New().String()
+	}
+	if change.ChangedAt.IsZero() {
+		change.ChangedAt = time.Now()
+	}
+
+	query := `
+		INSERT INTO renewal_price_history (id, domain_id, provider, old_price, new_price, changed_at)
+		VALUES (:id, :domain_id, :provider, :old_price, :new_price, :changed_at)`
+	if _, err := r.db.NamedExec(query, change); err != nil {
+		return fmt.Errorf("failed to create renewal price history entry: %w", err)
+	}
+	return nil
+}
+
+// GetRenewalPriceHistory retrieves the most recent renewal price changes for a domain in chronological order
+func (r *PostgresRepo) GetRenewalPriceHistory(domainID string, limit int) ([]types.RenewalPriceChange, error) {
+	var changes []types.RenewalPriceChange
+	query := `SELECT * FROM (
+	            SELECT id, domain_id, provider, old_price, new_price, changed_at
+	            FROM renewal_price_history WHERE domain_id = $1
+	            ORDER BY changed_at DESC LIMIT $2
+	          ) recent ORDER BY changed_at ASC`
+	if err := r.db.Select(&changes, query, domainID, limit); err != nil {
+		return nil, fmt.Errorf("failed to get renewal price history: %w", err)
+	}
+	return changes, nil
+}
+
+// UpdateLastLogin updates the last login time for a user
+func (r *PostgresRepo) UpdateLastLogin(userID string) error {
+	_, err := r.db.Exec("UPDATE users SET last_login = NOW() WHERE id = $1", userID)
+	if err != nil {
+		return fmt.Errorf("failed to update last login: %w", err)
+	}
+	return nil
+}
+
+// SetCalendarToken stores the hash of a user's calendar feed token, replacing any previous one
+func (r *PostgresRepo) SetCalendarToken(userID, tokenHash string) error {
+	result, err := r.db.Exec("UPDATE users SET calendar_token_hash = $1, updated_at = NOW() WHERE id = $2", tokenHash, userID)
+	if err != nil {
+		return fmt.Errorf("failed to set calendar token: %w", err)
+	}
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return types.ErrDomainNotFound
+	}
+	return nil
+}
+
+// GetUserByCalendarToken retrieves the user owning a calendar feed token hash
+func (r *PostgresRepo) GetUserByCalendarToken(tokenHash string) (*types.User, error) {
+	var user types.User
+	query := `SELECT id, username, email, password_hash, role, access_scope, enabled, last_login, 
+	          created_at, updated_at FROM users WHERE calendar_token_hash = $1`
+
+	err := r.db.Get(&user, query, tokenHash)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, types.ErrDomainNotFound
+		}
+		return nil, fmt.Errorf("failed to get user by calendar token: %w", err)
+	}
+
+	return &user, nil
+}
+
+// DNS repository methods
+
+// CreateRecord creates a new DNS record
+func (r *PostgresRepo) CreateRecord(record *types.DNSRecord) error {
+	if record.ID == "" {
+		record.ID = uuid.New().String()
+	}
+	now := time.Now()
+	record.CreatedAt = now
+	record.UpdatedAt = now
+	
+	query := `
+		INSERT INTO dns_records (id, domain_id, type, name, value, ttl, priority, weight, port, created_at, updated_at)
+		VALUES (:id, :domain_id, :type, :name, :value, :ttl, :priority, :weight, :port, :created_at, :updated_at)`
+	
+	_, err := r.db.NamedExec(query, record)
+	if err != nil {
+		return fmt.Errorf("failed to create DNS record: %w", err)
+	}
+	
+	return nil
+}
 
 // GetRecordsByDomain retrieves all DNS records for a domain
 func (r *PostgresRepo) GetRecordsByDomain(domainID string) ([]types.DNSRecord, error) {
@@ -937,6 +2069,277 @@ func (r *PostgresRepo) GetRecordsByDomain(domainID string) ([]types.DNSRecord, e
 	return records, nil
 }
 
+// GetRecordsPage retrieves a page of a domain's DNS records, ordered by type and name
+func (r *PostgresRepo) GetRecordsPage(domainID string, filter types.DNSRecordFilter) ([]types.DNSRecord, error) {
+	query := `SELECT id, domain_id, type, name, value, ttl, priority, weight, port,
+	          created_at, updated_at FROM dns_records WHERE domain_id = $1`
+	args := []interface{}{domainID}
+	if len(filter.Types) > 0 {
+		args = append(args, pq.Array(filter.Types))
+		query += fmt.Sprintf(" AND upper(type) = ANY($%d)", len(args))
+	}
+	query += " ORDER BY type, name, id"
+	if filter.Limit > 0 {
+		args = append(args, filter.Limit)
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+	if filter.Offset > 0 {
+		args = append(args, filter.Offset)
+		query += fmt.Sprintf(" OFFSET $%d", len(args))
+	}
+
+	var records []types.DNSRecord
+	if err := r.db.Select(&records, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to get DNS records page: %w", err)
+	}
+	return records, nil
+}
+
+// CountRecordsByType counts a domain's DNS records by type
+func (r *PostgresRepo) CountRecordsByType(domainID string) (map[string]int, error) {
+	var rows []struct {
+		Type  string `db:"type"`
+		Count int    `db:"count"`
+	}
+	query := `SELECT type, COUNT(*) AS count FROM dns_records WHERE domain_id = $1 GROUP BY type`
+	if err := r.db.Select(&rows, query, domainID); err != nil {
+		return nil, fmt.Errorf("failed to count DNS records: %w", err)
+	}
+	counts := make(map[string]int, len(rows))
+	for _, row := range rows {
+		counts[row.Type] = row.Count
+	}
+	return counts, nil
+}
+
+// GetRecordsByValues finds the records of any of recordTypes whose value is one of values,
+// ignoring case and a trailing dot
+func (r *PostgresRepo) GetRecordsByValues(recordTypes, values []string) ([]types.DNSRecord, error) {
+	if len(recordTypes) == 0 || len(values) == 0 {
+		return nil, nil
+	}
+	var records []types.DNSRecord
+	query := `SELECT id, domain_id, type, name, value, ttl, priority, weight, port,
+	          created_at, updated_at FROM dns_records
+	          WHERE upper(type) = ANY($1) AND rtrim(lower(trim(value)), '.') = ANY($2)
+	          ORDER BY domain_id, type, name`
+
+	err := r.db.Select(&records, query, pq.Array(recordTypes), pq.Array(values))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DNS records by value: %w", err)
+	}
+	return records, nil
+}
+
+// GetRecordsByType returns every domain's records of recordType, ignoring case
+func (r *PostgresRepo) GetRecordsByType(recordType string) ([]types.DNSRecord, error) {
+	var records []types.DNSRecord
+	query := `SELECT id, domain_id, type, name, value, ttl, priority, weight, port,
+	          created_at, updated_at FROM dns_records
+	          WHERE upper(type) = upper($1)
+	          ORDER BY domain_id, name`
+
+	if err := r.db.Select(&records, query, recordType); err != nil {
+		return nil, fmt.Errorf("failed to get DNS records by type: %w", err)
+	}
+	return records, nil
+}
+
+// CreateDNSHistory appends entries to the DNS change history
+func (r *PostgresRepo) CreateDNSHistory(entries []types.DNSRecordHistory) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	tx, cancel, err := r.db.Beginx()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer cancel()
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO dns_record_history (id, domain_id, record_id, action, before_value, after_value, changed_by, changed_at)
+		VALUES (:id, :domain_id, :record_id, :action, :before_value, :after_value, :changed_by, :changed_at)`
+
+	for i := range entries {
+		if entries[i].ID == "" {
+			entries[i].ID = uuid.New().String()
+		}
+		if entries[i].ChangedAt.IsZero() {
+			entries[i].ChangedAt = time.Now()
+		}
+		if _, err := tx.NamedExec(query, entries[i]); err != nil {
+			return fmt.Errorf("failed to create DNS history entry: %w", err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// GetDNSHistory retrieves the most recent DNS changes for a domain in chronological order
+func (r *PostgresRepo) GetDNSHistory(domainID string, limit int) ([]types.DNSRecordHistory, error) {
+	var entries []types.DNSRecordHistory
+	query := `SELECT * FROM (
+	            SELECT id, domain_id, record_id, action, before_value, after_value, changed_by, changed_at
+	            FROM dns_record_history WHERE domain_id = $1
+	            ORDER BY changed_at DESC LIMIT $2
+	          ) recent ORDER BY changed_at ASC`
+
+	err := r.db.Select(&entries, query, domainID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DNS history: %w", err)
+	}
+
+	return entries, nil
+}
+
+// CreateNameserverChange records a change to a domain's nameservers
+func (r *PostgresRepo) CreateNameserverChange(change *types.NameserverChange) error {
+	if change.ID == "" {
+		change.ID = uuid.New().String()
+	}
+	if change.DetectedAt.IsZero() {
+		change.DetectedAt = time.Now()
+	}
+
+	query := `
+		INSERT INTO nameserver_history (id, domain_id, previous_nameservers, current_nameservers, detected_at)
+		VALUES (:id, :domain_id, :previous_nameservers, :current_nameservers, :detected_at)`
+
+	if _, err := r.db.NamedExec(query, change); err != nil {
+		return fmt.Errorf("failed to create nameserver history entry: %w", err)
+	}
+	return nil
+}
+
+// GetNameserverHistory retrieves the most recent nameserver changes for a domain in chronological order
+func (r *PostgresRepo) GetNameserverHistory(domainID string, limit int) ([]types.NameserverChange, error) {
+	var changes []types.NameserverChange
+	query := `SELECT * FROM (
+	            SELECT id, domain_id, previous_nameservers, current_nameservers, detected_at
+	            FROM nameserver_history WHERE domain_id = $1
+	            ORDER BY detected_at DESC LIMIT $2
+	          ) recent ORDER BY detected_at ASC`
+
+	err := r.db.Select(&changes, query, domainID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get nameserver history: %w", err)
+	}
+
+	return changes, nil
+}
+
+// SaveDNSProviderState stores the records a provider last returned for a domain
+func (r *PostgresRepo) SaveDNSProviderState(state *types.DNSProviderState) error {
+	query := `
+		INSERT INTO dns_provider_state (domain_id, provider, records, fetched_at)
+		VALUES (:domain_id, :provider, :records, :fetched_at)
+		ON CONFLICT (domain_id) DO UPDATE SET
+			provider = EXCLUDED.provider, records = EXCLUDED.records, fetched_at = EXCLUDED.fetched_at`
+	if _, err := r.db.NamedExec(query, state); err != nil {
+		return fmt.Errorf("failed to save DNS provider state: %w", err)
+	}
+	return nil
+}
+
+// GetDNSProviderState returns the records a provider last returned for a domain, or nil if
+// they were never fetched
+func (r *PostgresRepo) GetDNSProviderState(domainID string) (*types.DNSProviderState, error) {
+	var state types.DNSProviderState
+	query := "SELECT domain_id, provider, records, fetched_at FROM dns_provider_state WHERE domain_id = $1"
+	if err := r.db.Get(&state, query, domainID); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get DNS provider state: %w", err)
+	}
+	return &state, nil
+}
+
+// SaveDNSSyncResult stores the outcome of a domain's latest DNS sync
+func (r *PostgresRepo) SaveDNSSyncResult(result *types.DNSSyncResult) error {
+	query := `
+		INSERT INTO dns_sync_results (domain_id, provider, success, record_count, error, synced_at)
+		VALUES (:domain_id, :provider, :success, :record_count, :error, :synced_at)
+		ON CONFLICT (domain_id) DO UPDATE SET
+			provider = EXCLUDED.provider, success = EXCLUDED.success, record_count = EXCLUDED.record_count,
+			error = EXCLUDED.error, synced_at = EXCLUDED.synced_at`
+	if _, err := r.db.NamedExec(query, result); err != nil {
+		return fmt.Errorf("failed to save DNS sync result: %w", err)
+	}
+	return nil
+}
+
+// GetDNSSyncResult returns the outcome of a domain's latest DNS sync, or nil if its DNS was
+// never synced
+func (r *PostgresRepo) GetDNSSyncResult(domainID string) (*types.DNSSyncResult, error) {
+	var result types.DNSSyncResult
+	query := "SELECT domain_id, provider, success, record_count, error, synced_at FROM dns_sync_results WHERE domain_id = $1"
+	if err := r.db.Get(&result, query, domainID); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get DNS sync result: %w", err)
+	}
+	return &result, nil
+}
+
+// CreateDNSBackup stores a provider's zone captured before a write
+func (r *PostgresRepo) CreateDNSBackup(backup *types.DNSBackup) error {
+	if backup.ID == "" {
+		backup.ID = uuid.New().String()
+	}
+	if backup.CreatedAt.IsZero() {
+		backup.CreatedAt = time.Now()
+	}
+	backup.RecordCount = len(backup.Records)
+	query := `
+		INSERT INTO dns_backups (id, domain_id, provider, reason, records, record_count, created_by, created_at)
+		VALUES (:id, :domain_id, :provider, :reason, :records, :record_count, :created_by, :created_at)`
+	if _, err := r.db.NamedExec(query, backup); err != nil {
+		return fmt.Errorf("failed to create DNS backup: %w", err)
+	}
+	return nil
+}
+
+// GetDNSBackups returns a domain's DNS backups, newest first
+func (r *PostgresRepo) GetDNSBackups(domainID string) ([]types.DNSBackup, error) {
+	backups := []types.DNSBackup{}
+	query := `SELECT id, domain_id, provider, reason, records, record_count, created_by, created_at
+	          FROM dns_backups WHERE domain_id = $1 ORDER BY created_at DESC`
+	if err := r.db.Select(&backups, query, domainID); err != nil {
+		return nil, fmt.Errorf("failed to get DNS backups: %w", err)
+	}
+	return backups, nil
+}
+
+// GetDNSBackup returns one DNS backup
+func (r *PostgresRepo) GetDNSBackup(id string) (*types.DNSBackup, error) {
+	var backup types.DNSBackup
+	query := `SELECT id, domain_id, provider, reason, records, record_count, created_by, created_at
+	          FROM dns_backups WHERE id = $1`
+	if err := r.db.Get(&backup, query, id); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, types.ErrDNSBackupNotFound
+		}
+		return nil, fmt.Errorf("failed to get DNS backup: %w", err)
+	}
+	return &backup, nil
+}
+
+// PruneDNSBackups deletes all but a domain's newest keep backups
+func (r *PostgresRepo) PruneDNSBackups(domainID string, keep int) error {
+	query := `
+		DELETE FROM dns_backups WHERE domain_id = $1 AND id NOT IN (
+			SELECT id FROM dns_backups WHERE domain_id = $1 ORDER BY created_at DESC LIMIT $2
+		)`
+	if _, err := r.db.Exec(query, domainID, keep); err != nil {
+		return fmt.Errorf("failed to prune DNS backups: %w", err)
+	}
+	return nil
+}
+
 // GetRecordByID retrieves a DNS record by ID
 func (r *PostgresRepo) GetRecordByID(id string) (*types.DNSRecord, error) {
 	var record types.DNSRecord
@@ -1001,10 +2404,11 @@ func (r *PostgresRepo) BulkCreateRecords(records []types.DNSRecord) error {
 		return nil
 	}
 
-	tx, err := r.db.Beginx()
+	tx, cancel, err := r.db.Beginx()
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
+	defer cancel()
 	defer tx.Rollback()
 
 	query := `
@@ -1029,3 +2433,36 @@ func (r *PostgresRepo) BulkCreateRecords(records []types.DNSRecord) error {
 
 	return tx.Commit()
 }
+
+// ReplaceRecordsByDomain replaces all of a domain's DNS records in one transaction, so readers
+// see either the old records or the new ones and a failed insert leaves the old ones in place
+func (r *PostgresRepo) ReplaceRecordsByDomain(domainID string, records []types.DNSRecord) error {
+	tx, cancel, err := r.db.Beginx()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer cancel()
+	defer tx.Rollback()
+
+	if _, err := tx.Exec("DELETE FROM dns_records WHERE domain_id = $1", domainID); err != nil {
+		return fmt.Errorf("failed to delete DNS records by domain: %w", err)
+	}
+
+	query := `
+		INSERT INTO dns_records (id, domain_id, type, name, value, ttl, priority, weight, port, created_at, updated_at)
+		VALUES (:id, :domain_id, :type, :name, :value, :ttl, :priority, :weight, :port, :created_at, :updated_at)`
+	now := time.Now()
+	for i := range records {
+		if records[i].ID == "" {
+			records[i].ID = uuid.New().String()
+		}
+		records[i].DomainID = domainID
+		records[i].CreatedAt = now
+		records[i].UpdatedAt = now
+		if _, err := tx.NamedExec(query, records[i]); err != nil {
+			return fmt.Errorf("failed to create DNS record %d: %w", i, err)
+		}
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,130 @@
+package storage
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rusiqe/domainvault/internal/types"
+)
+
+func TestUpsertBatches(t *testing.T) {
+	names := []string{"a.com", "b.com", "c.com", "b.com", "d.com", "e.com"}
+	domains := make([]types.Domain, len(names))
+	for i, name := range names {
+		domains[i] = types.Domain{Name: name}
+	}
+
+	batches := upsertBatches(domains, 2)
+	var got []string
+	for _, batch := range batches {
+		var batchNames []string
+		for _, d := range batch {
+			batchNames = append(batchNames, d.Name)
+		}
+		got = append(got, strings.Join(batchNames, ","))
+	}
+	want := []string{"a.com,b.com", "c.com,b.com", "d.com,e.com"}
+	if strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("Expected batches %v, got %v", want, got)
+	}
+
+	// A repeated name ends the batch even when it isn't full
+	batches = upsertBatches(domains[:4], 10)
+	if len(batches) != 2 || len(batches[0]) != 3 || len(batches[1]) != 1 {
+		t.Errorf("Expected batches of 3 and 1, got %d batches", len(batches))
+	}
+
+	// Batches share the caller's slice, so stored IDs reach the caller
+	batches[0][0].ID = "stored"
+	if domains[0].ID != "stored" {
+		t.Error("Expected batches to alias the domains slice")
+	}
+}
+
+func TestUpsertBatchQuery(t *testing.T) {
+	query := upsertBatchQuery(3)
+	last := fmt.Sprintf("$%d)", 3*len(upsertColumns))
+	if !strings.Contains(query, last) || strings.Contains(query, fmt.Sprintf("$%d", 3*len(upsertColumns)+1)) {
+		t.Errorf("Expected placeholders to end at %s:\n%s", last, query)
+	}
+	if len(upsertValues(&types.Domain{})) != len(upsertColumns) {
+		t.Error("Expected one value per upsert column")
+	}
+	if maxUpsertBatchSize*len(upsertColumns) > maxPlaceholders {
+		t.Errorf("A batch of %d domains uses %d placeholders, over PostgreSQL's %d", maxUpsertBatchSize, maxUpsertBatchSize*len(upsertColumns), maxPlaceholders)
+	}
+	pool := PoolConfig{UpsertBatchSize: 1 << 20}.withDefaults()
+	if pool.UpsertBatchSize != maxUpsertBatchSize {
+		t.Errorf("Expected an oversized batch size to be capped at %d, got %d", maxUpsertBatchSize, pool.UpsertBatchSize)
+	}
+}
+
+func TestUpsertDomains_SkipsInvalid(t *testing.T) {
+	repo := NewEmptyMockRepo()
+	expires := time.Now().AddDate(1, 0, 0)
+	domains := []types.Domain{
+		{Name: "good.com", Provider: "mock", ExpiresAt: expires},
+		{Name: " ", Provider: "mock", ExpiresAt: expires},
+		{Name: "no-provider.com", ExpiresAt: expires},
+		{Name: "also-good.com", Provider: "mock", ExpiresAt: expires},
+	}
+
+	result, err := repo.UpsertDomains(domains)
+	if err != nil {
+		t.Fatalf("UpsertDomains() error: %v", err)
+	}
+	if result.Inserted != 2 || len(result.Skipped) != 2 {
+		t.Errorf("UpsertDomains() = %+v, want 2 inserted and 2 skipped", result)
+	}
+	if len(result.Skipped) == 2 && result.Skipped[1].Name != "no-provider.com" {
+		t.Errorf("Skipped = %+v, want the invalid domains", result.Skipped)
+	}
+	if stored, _ := repo.GetAll(); len(stored) != 2 {
+		t.Errorf("Expected the valid domains to be stored, got %d", len(stored))
+	}
+}
+
+// BenchmarkUpsertDomains compares batch sizes against a real database, set with
+// DOMAINVAULT_TEST_DATABASE_URL. A batch size of 1 writes each domain in its own statement
+// like the upsert did before batching.
+func BenchmarkUpsertDomains(b *testing.B) {
+	dsn := os.Getenv("DOMAINVAULT_TEST_DATABASE_URL")
+	if dsn == "" {
+		b.Skip("DOMAINVAULT_TEST_DATABASE_URL not set")
+	}
+
+	for _, size := range []int{1, 100, 500} {
+		b.Run(fmt.Sprintf("batch=%d", size), func(b *testing.B) {
+			repo, err := NewPostgresRepo(dsn, PoolConfig{UpsertBatchSize: size})
+			if err != nil {
+				b.Fatalf("Failed to connect: %v", err)
+			}
+			defer repo.Close()
+
+			domains := make([]types.Domain, 2000)
+			for i := range domains {
+				domains[i] = types.Domain{
+					Name:      fmt.Sprintf("bench-%d-%d.example", size, i),
+					Provider:  "godaddy",
+					ExpiresAt: time.Now().AddDate(1, 0, 0),
+					Status:    "active",
+				}
+			}
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := repo.UpsertDomains(domains); err != nil {
+					b.Fatalf("UpsertDomains failed: %v", err)
+				}
+			}
+			b.StopTimer()
+
+			for _, d := range domains {
+				repo.db.Exec("DELETE FROM domains WHERE id = $1", d.ID)
+			}
+		})
+	}
+}
@@ -6,49 +6,148 @@ import (
 	"github.com/rusiqe/domainvault/internal/types"
 )
 
+// UpsertResult counts the domains an upsert created and the ones it updated, and lists the
+// invalid ones it left out
+type UpsertResult struct {
+	Inserted int             `json:"inserted"`
+	Updated  int             `json:"updated"`
+	Skipped  []SkippedDomain `json:"skipped,omitempty"`
+}
+
+// SkippedDomain is a domain an upsert didn't write because it failed validation
+type SkippedDomain struct {
+	Name  string `json:"name"`
+	Error string `json:"error"`
+}
+
 // DomainRepository defines the interface for domain data operations
 type DomainRepository interface {
 	// Core operations
-	UpsertDomains(domains []types.Domain) error
+	UpsertDomains(domains []types.Domain) (UpsertResult, error) // Batched; a failed batch doesn't undo earlier ones, invalid domains are skipped
 	GetAll() ([]types.Domain, error)
 	GetByID(id string) (*types.Domain, error)
+	GetByMonitorID(monitorID int) (*types.Domain, error) // Domain linked to an UptimeRobot monitor
 	GetByFilter(filter types.DomainFilter) ([]types.Domain, error)
 	GetDomainsByName(name string) ([]types.Domain, error)
+	GetDomainsByNames(names []string) ([]types.Domain, error) // Exact name match against any of names, in one query
 	Delete(id string) error // Soft delete: sets visible=false
 	Update(domain *types.Domain) error
 	SetVisibility(id string, visible bool) error
+	SetDomainVerification(id string, token *string, requestedAt, verifiedAt *time.Time) error // Stores the ownership challenge state
+	SetDomainRegisteredAt(id string, registeredAt time.Time) error // Overrides the registrar's registration date with the registry's
+	BulkSetVisibility(ids []string, visible bool) ([]types.Domain, error) // One transaction; returns the domains found, with ID, name and monitor ID
+	MergeDomains(canonicalID string, duplicateIDs []string) (*types.Domain, error) // Folds duplicates into the canonical domain and deletes them
 	
 	// Utility operations
 	GetExpiring(threshold time.Duration) ([]types.Domain, error)
-	GetSummary() (*types.DomainSummary, error)
+	GetSummary(expiringDays []int) (*types.DomainSummary, error) // One expiring_in bucket per entry of expiringDays
 	BulkRenew(domainIDs []string) error
 	
 	// User management
 	CreateUser(user *types.User) error
 	GetUserByUsername(username string) (*types.User, error)
 	GetUserByID(id string) (*types.User, error)
+	GetUserByEmail(email string) (*types.User, error)
 	UpdateUser(user *types.User) error
 	DeleteUser(id string) error
 	UpdateLastLogin(userID string) error
+	SetCalendarToken(userID, tokenHash string) error
+	GetUserByCalendarToken(tokenHash string) (*types.User, error)
 	
 	// Session management
 	CreateSession(session *types.Session) error
 	GetSessionByToken(token string) (*types.Session, error)
 	DeleteSession(token string) error
+	DeleteUserSessions(userID string) error
 	DeleteExpiredSessions() error
 	
+	// Password reset tokens (stored as hashes)
+	CreatePasswordResetToken(userID, tokenHash string, expiresAt time.Time) error
+	ConsumePasswordResetToken(tokenHash string) (string, error) // Returns the user ID; single use, ErrInvalidResetToken when unknown, used or expired
+	
+	// Idempotency keys
+	ReserveIdempotencyKey(record *types.IdempotencyRecord) (*types.IdempotencyRecord, error) // Returns the existing record if the key is taken
+	CompleteIdempotencyKey(key string, statusCode int, contentType string, body []byte) error
+	
+	// Settings, stored as JSON by key
+	GetSetting(key string, dest interface{}) error // Returns ErrSettingNotFound if the key was never saved
+	SaveSetting(key string, value interface{}) error
+
+	// Outbound webhook endpoints
+	CreateWebhookEndpoint(endpoint *types.WebhookEndpoint) error
+	GetWebhookEndpoints() ([]types.WebhookEndpoint, error)
+	GetWebhookEndpointByID(id string) (*types.WebhookEndpoint, error) // Returns ErrWebhookNotFound
+	UpdateWebhookEndpoint(endpoint *types.WebhookEndpoint) error
+	DeleteWebhookEndpoint(id string) error
+
+	// Notification templates, at most one per channel and event
+	GetNotificationTemplates() ([]types.NotificationTemplate, error)
+	SaveNotificationTemplate(template *types.NotificationTemplate) error // Creates or replaces the template for its channel and event
+	DeleteNotificationTemplate(channel, event string) error // Returns ErrNotificationTemplateNotFound
+
+	// Automatic categorization rules
+	CreateCategorizationRule(rule *types.CategorizationRule) error
+	GetCategorizationRules() ([]types.CategorizationRule, error) // In priority order, lowest first
+	GetCategorizationRuleByID(id string) (*types.CategorizationRule, error) // Returns ErrCategorizationRuleNotFound
+	UpdateCategorizationRule(rule *types.CategorizationRule) error
+	DeleteCategorizationRule(id string) error
+
+	// Saved domain list views, private to each user
+	CreateSavedView(view *types.SavedView) error // Returns ErrSavedViewExists for a name the user already has
+	GetSavedViews(userID string) ([]types.SavedView, error) // By name
+	GetSavedViewByID(id string) (*types.SavedView, error) // Returns ErrSavedViewNotFound
+	UpdateSavedView(view *types.SavedView) error
+	DeleteSavedView(id string) error
+
+	// Portfolio snapshots, one per day
+	SavePortfolioSnapshot(snapshot *types.PortfolioSnapshot) error // Replaces the snapshot for the same date
+	GetPortfolioSnapshots(since time.Time) ([]types.PortfolioSnapshot, error) // Oldest first
+
+	// Domain acquisition watchlist
+	CreateWatchlistEntry(entry *types.WatchlistEntry) error         // Returns ErrWatchlistExists for a domain already watched
+	GetWatchlist() ([]types.WatchlistEntry, error)                  // Least recently checked first
+	GetWatchlistEntryByID(id string) (*types.WatchlistEntry, error) // Returns ErrWatchlistNotFound
+	UpdateWatchlistEntry(entry *types.WatchlistEntry) error
+	DeleteWatchlistEntry(id string) error
+
+	// Registry expiry reconciliation, one result per domain
+	SaveRegistryExpiry(expiry *types.RegistryExpiry) error // Replaces the domain's previous result
+	GetRegistryExpiries() ([]types.RegistryExpiry, error)  // With domain names, least recently checked first
+
+	// Renewal price history, recorded by syncs
+	CreateRenewalPriceChange(change *types.RenewalPriceChange) error
+	GetRenewalPriceHistory(domainID string, limit int) ([]types.RenewalPriceChange, error) // Most recent changes, oldest first
+	
 	// DNS management
 	CreateRecord(record *types.DNSRecord) error
 	GetRecordsByDomain(domainID string) ([]types.DNSRecord, error)
+	GetRecordsPage(domainID string, filter types.DNSRecordFilter) ([]types.DNSRecord, error) // Ordered by type and name
+	CountRecordsByType(domainID string) (map[string]int, error)
+	GetRecordsByValues(recordTypes, values []string) ([]types.DNSRecord, error) // Values compared as normalized by types.InfrastructureValue
+	GetRecordsByType(recordType string) ([]types.DNSRecord, error) // Across all domains, case-insensitive
 	GetRecordByID(id string) (*types.DNSRecord, error)
 	UpdateRecord(record *types.DNSRecord) error
 	DeleteRecord(id string) error
 	DeleteRecordsByDomain(domainID string) error
 	BulkCreateRecords(records []types.DNSRecord) error
+	ReplaceRecordsByDomain(domainID string, records []types.DNSRecord) error // Deletes and creates in one transaction
+	CreateDNSHistory(entries []types.DNSRecordHistory) error
+	GetDNSHistory(domainID string, limit int) ([]types.DNSRecordHistory, error) // Most recent entries, oldest first
+	CreateNameserverChange(change *types.NameserverChange) error
+	GetNameserverHistory(domainID string, limit int) ([]types.NameserverChange, error) // Most recent changes, oldest first
+	SaveDNSProviderState(state *types.DNSProviderState) error // Replaces the domain's previous state
+	GetDNSProviderState(domainID string) (*types.DNSProviderState, error) // Nil if never fetched
+	SaveDNSSyncResult(result *types.DNSSyncResult) error // Replaces the domain's previous result
+	GetDNSSyncResult(domainID string) (*types.DNSSyncResult, error) // Nil if never synced
+	CreateDNSBackup(backup *types.DNSBackup) error
+	GetDNSBackups(domainID string) ([]types.DNSBackup, error) // Newest first
+	GetDNSBackup(id string) (*types.DNSBackup, error)
+	PruneDNSBackups(domainID string, keep int) error // Deletes all but the newest keep backups
 	
 	// Category management
 	CreateCategory(category *types.Category) error
 	GetAllCategories() ([]types.Category, error)
+	GetCategoriesWithCounts(sortBy string) ([]types.CategoryWithCount, error)
 	GetCategoryByID(id string) (*types.Category, error)
 	UpdateCategory(category *types.Category) error
 	DeleteCategory(id string) error
@@ -56,6 +155,7 @@ type DomainRepository interface {
 	// Project management
 	CreateProject(project *types.Project) error
 	GetAllProjects() ([]types.Project, error)
+	GetProjectsWithCounts(sortBy string) ([]types.ProjectWithCount, error)
 	GetProjectByID(id string) (*types.Project, error)
 	UpdateProject(project *types.Project) error
 	DeleteProject(id string) error
@@ -76,6 +176,11 @@ type DomainRepository interface {
 	GetSecureCredentialsByReference(reference string) (*types.SecureProviderCredentials, error)
 	UpdateSecureCredentials(creds *types.SecureProviderCredentials) error
 	DeleteSecureCredentials(id string) error
+
+	// Provider secrets kept by the database secret store, encrypted with the credentials master key
+	PutSecret(reference string, secret types.CredentialsMap) error
+	GetSecret(reference string) (types.CredentialsMap, error) // types.ErrSecretNotFound if there is none
+	DeleteSecret(reference string) error                      // No error if there is none
 	
 	// Connection management
 	Close() error
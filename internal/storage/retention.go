@@ -0,0 +1,100 @@
+package storage
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// DefaultRetentionBatchSize is the number of rows deleted per statement during cleanup
+const DefaultRetentionBatchSize = 1000
+
+// retentionColumns maps the tables retention applies to onto their timestamp column.
+// Table names come from this allow-list only, never from user input.
+var retentionColumns = map[string]string{
+	"audit_events":             "timestamp",
+	"domain_status_history":    "changed_at",
+	"dns_record_history":       "changed_at",
+	"nameserver_history":       "detected_at",
+	"uptimerobot_monitor_logs": "created_at",
+}
+
+// RetentionResult reports the outcome of applying retention to one table
+type RetentionResult struct {
+	Table         string    `json:"table"`
+	RetentionDays int       `json:"retention_days"`
+	Cutoff        time.Time `json:"cutoff"`
+	Rows          int64     `json:"rows"` // Rows deleted, or rows that would be deleted in a dry run
+	DryRun        bool      `json:"dry_run"`
+	Skipped       string    `json:"skipped,omitempty"` // Reason the table was not processed
+}
+
+// RetentionTables lists the tables a retention policy may reference
+func RetentionTables() []string {
+	tables := make([]string, 0, len(retentionColumns))
+	for table := range retentionColumns {
+		tables = append(tables, table)
+	}
+	sort.Strings(tables)
+	return tables
+}
+
+// PurgeExpired deletes rows older than each table's retention window (days per table; 0 keeps
+// rows forever). Deletes run in batches of batchSize so no single statement holds long locks.
+// With dryRun set nothing is deleted and the counts of matching rows are reported instead.
+func (r *PostgresRepo) PurgeExpired(policy map[string]int, batchSize int, dryRun bool) ([]RetentionResult, error) {
+	if batchSize <= 0 {
+		batchSize = DefaultRetentionBatchSize
+	}
+
+	var results []RetentionResult
+	for _, table := range RetentionTables() {
+		days := policy[table]
+		if days <= 0 {
+			continue
+		}
+
+		result := RetentionResult{
+			Table:         table,
+			RetentionDays: days,
+			Cutoff:        time.Now().AddDate(0, 0, -days),
+			DryRun:        dryRun,
+		}
+
+		var exists bool
+		if err := r.db.Get(&exists, `SELECT to_regclass($1) IS NOT NULL`, table); err != nil {
+			return results, fmt.Errorf("failed to check table %s: %w", table, err)
+		}
+		if !exists {
+			result.Skipped = "table does not exist"
+			results = append(results, result)
+			continue
+		}
+
+		column := retentionColumns[table]
+		if dryRun {
+			query := fmt.Sprintf(`SELECT COUNT(*) FROM %s WHERE %s < $1`, table, column)
+			if err := r.db.Get(&result.Rows, query, result.Cutoff); err != nil {
+				return results, fmt.Errorf("failed to count expired rows in %s: %w", table, err)
+			}
+			results = append(results, result)
+			continue
+		}
+
+		query := fmt.Sprintf(`DELETE FROM %[1]s WHERE ctid IN (SELECT ctid FROM %[1]s WHERE %[2]s < $1 LIMIT $2)`, table, column)
+		for {
+			res, err := r.db.Exec(query, result.Cutoff, batchSize)
+			if err != nil {
+				return results, fmt.Errorf("failed to delete expired rows from %s: %w", table, err)
+			}
+			affected, _ := res.RowsAffected()
+			result.Rows += affected
+			if affected < int64(batchSize) {
+				break
+			}
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
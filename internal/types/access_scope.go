@@ -0,0 +1,117 @@
+package types
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// AccessScope limits a user to the domains of some projects and tags. A domain is in scope
+// when it belongs to one of ProjectIDs or carries one of Tags. An empty scope grants every
+// domain, and admins are never scoped.
+type AccessScope struct {
+	ProjectIDs []string  `json:"project_ids"`
+	Tags       TagsSlice `json:"tags"`
+}
+
+// SetUserScopeRequest replaces a user's access scope; empty lists remove the restriction
+type SetUserScopeRequest struct {
+	ProjectIDs []string `json:"project_ids"`
+	Tags       []string `json:"tags"`
+}
+
+// IsEmpty reports whether the scope grants every domain
+func (s AccessScope) IsEmpty() bool {
+	return len(s.ProjectIDs) == 0 && len(s.Tags) == 0
+}
+
+// Allows reports whether domain is in scope
+func (s AccessScope) Allows(domain Domain) bool {
+	if s.IsEmpty() {
+		return true
+	}
+	if domain.ProjectID != nil && s.AllowsProject(*domain.ProjectID) {
+		return true
+	}
+	for _, tag := range domain.Tags {
+		for _, allowed := range s.Tags {
+			if tag == allowed {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// AllowsProject reports whether the scope names the project
+func (s AccessScope) AllowsProject(projectID string) bool {
+	if s.IsEmpty() {
+		return true
+	}
+	for _, id := range s.ProjectIDs {
+		if id == projectID {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate trims and de-duplicates the project IDs and cleans the tags as domain tags are
+func (s *AccessScope) Validate() error {
+	ids := make([]string, 0, len(s.ProjectIDs))
+	seen := make(map[string]bool, len(s.ProjectIDs))
+	for _, id := range s.ProjectIDs {
+		id = strings.TrimSpace(id)
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		ids = append(ids, id)
+	}
+	s.ProjectIDs = ids
+
+	if s.Tags == nil {
+		s.Tags = TagsSlice{}
+	}
+	if err := s.Tags.Validate(); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidScope, err)
+	}
+	return nil
+}
+
+// Value implements the driver.Valuer interface for database storage
+func (s AccessScope) Value() (driver.Value, error) {
+	if s.ProjectIDs == nil {
+		s.ProjectIDs = []string{}
+	}
+	if s.Tags == nil {
+		s.Tags = TagsSlice{}
+	}
+	return json.Marshal(s)
+}
+
+// Scan implements the sql.Scanner interface for database retrieval
+func (s *AccessScope) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*s = AccessScope{}
+		return nil
+	case []byte:
+		return json.Unmarshal(v, s)
+	case string:
+		return json.Unmarshal([]byte(v), s)
+	default:
+		return fmt.Errorf("cannot scan %T into AccessScope", value)
+	}
+}
+
+// DomainScope returns the scope the user's domain access is limited to, or nil when the user
+// may reach every domain
+func (u *User) DomainScope() *AccessScope {
+	if u == nil || u.Role == RoleAdmin || u.Scope.IsEmpty() {
+		return nil
+	}
+	scope := u.Scope
+	return &scope
+}
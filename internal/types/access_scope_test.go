@@ -0,0 +1,80 @@
+package types
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestAccessScope_Allows(t *testing.T) {
+	marketing, sales := "project-marketing", "project-sales"
+	scope := AccessScope{ProjectIDs: []string{marketing}, Tags: TagsSlice{"client-acme"}}
+
+	tests := []struct {
+		name   string
+		domain Domain
+		want   bool
+	}{
+		{name: "project in scope", domain: Domain{ProjectID: &marketing}, want: true},
+		{name: "tag in scope", domain: Domain{ProjectID: &sales, Tags: TagsSlice{"parked", "client-acme"}}, want: true},
+		{name: "other project", domain: Domain{ProjectID: &sales, Tags: TagsSlice{"parked"}}},
+		{name: "no project or tags", domain: Domain{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := scope.Allows(tt.domain); got != tt.want {
+				t.Errorf("Allows() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if !(AccessScope{}).Allows(Domain{}) || !(AccessScope{}).AllowsProject(sales) {
+		t.Error("Expected an empty scope to allow everything")
+	}
+	if !scope.AllowsProject(marketing) || scope.AllowsProject(sales) {
+		t.Error("AllowsProject() should only allow the scope's projects")
+	}
+}
+
+func TestAccessScope_Validate(t *testing.T) {
+	scope := AccessScope{ProjectIDs: []string{" p1 ", "p1", "", "p2"}, Tags: TagsSlice{" client-acme ", "client-acme"}}
+	if err := scope.Validate(); err != nil {
+		t.Fatalf("Validate() unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(scope.ProjectIDs, []string{"p1", "p2"}) || !reflect.DeepEqual(scope.Tags, TagsSlice{"client-acme"}) {
+		t.Errorf("Validate() = %+v, want trimmed and de-duplicated lists", scope)
+	}
+
+	invalid := AccessScope{Tags: TagsSlice{strings.Repeat("x", MaxTagLength+1)}}
+	if err := invalid.Validate(); !errors.Is(err, ErrInvalidScope) {
+		t.Errorf("Validate() of an overlong tag error = %v, want ErrInvalidScope", err)
+	}
+
+	empty := AccessScope{}
+	if err := empty.Validate(); err != nil {
+		t.Fatalf("Validate() of an empty scope error: %v", err)
+	}
+	value, _ := empty.Value()
+	if string(value.([]byte)) != `{"project_ids":[],"tags":[]}` {
+		t.Errorf("Value() = %s, want empty lists", value)
+	}
+	var scanned AccessScope
+	if err := scanned.Scan([]byte(`{"project_ids":["p1"],"tags":["client-acme"]}`)); err != nil || !scanned.AllowsProject("p1") {
+		t.Errorf("Scan() = %+v, %v", scanned, err)
+	}
+}
+
+func TestUser_DomainScope(t *testing.T) {
+	scope := AccessScope{Tags: TagsSlice{"client-acme"}}
+
+	if got := (&User{Role: RoleEditor, Scope: scope}).DomainScope(); got == nil || !reflect.DeepEqual(*got, scope) {
+		t.Errorf("DomainScope() of a scoped editor = %+v, want %+v", got, scope)
+	}
+	if got := (&User{Role: RoleAdmin, Scope: scope}).DomainScope(); got != nil {
+		t.Errorf("DomainScope() of an admin = %+v, want nil", got)
+	}
+	if got := (&User{Role: RoleViewer}).DomainScope(); got != nil {
+		t.Errorf("DomainScope() of an unscoped viewer = %+v, want nil", got)
+	}
+}
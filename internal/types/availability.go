@@ -0,0 +1,101 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Availability check statuses in a bulk search
+const (
+	AvailabilityPending = "pending" // Not looked up yet
+	AvailabilityChecked = "checked"
+	AvailabilityFailed  = "failed"
+)
+
+// AvailabilitySearchRequest asks whether a batch of domains can be registered
+type AvailabilitySearchRequest struct {
+	Domains []string `json:"domains"`
+}
+
+// AvailabilityCheck is one domain's result in a bulk availability search
+type AvailabilityCheck struct {
+	Domain       string              `json:"domain"`
+	Status       string              `json:"status"`
+	Availability *DomainAvailability `json:"availability,omitempty"`
+	Error        string              `json:"error,omitempty"`
+}
+
+// AvailabilitySearch is the state of a bulk availability search. While Processing, pending
+// results fill in as their lookups finish.
+type AvailabilitySearch struct {
+	Results    []AvailabilityCheck `json:"results"`
+	Total      int                 `json:"total"`
+	Checked    int                 `json:"checked"`
+	Available  int                 `json:"available"`
+	Failed     int                 `json:"failed"`
+	Pending    int                 `json:"pending"`
+	Processing bool                `json:"processing"`
+}
+
+// Normalize lowercases and de-duplicates the requested domains, keeping their order, and
+// checks there are between 1 and maxDomains fully qualified names
+func (r *AvailabilitySearchRequest) Normalize(maxDomains int) error {
+	seen := make(map[string]bool, len(r.Domains))
+	domains := make([]string, 0, len(r.Domains))
+	for _, domain := range r.Domains {
+		domain = NormalizeDomainName(domain)
+		if domain == "" {
+			continue
+		}
+		if !strings.Contains(domain, ".") {
+			return fmt.Errorf("%w: %q is not a fully qualified domain name", ErrInvalidAvailabilitySearch, domain)
+		}
+		if !seen[domain] {
+			seen[domain] = true
+			domains = append(domains, domain)
+		}
+	}
+	if len(domains) == 0 {
+		return fmt.Errorf("%w: no domains given", ErrInvalidAvailabilitySearch)
+	}
+	if maxDomains > 0 && len(domains) > maxDomains {
+		return fmt.Errorf("%w: %d domains given, at most %d can be searched at once", ErrInvalidAvailabilitySearch, len(domains), maxDomains)
+	}
+	r.Domains = domains
+	return nil
+}
+
+// NewAvailabilitySearch starts a search with every domain pending
+func NewAvailabilitySearch(domains []string) *AvailabilitySearch {
+	search := &AvailabilitySearch{Results: make([]AvailabilityCheck, len(domains)), Total: len(domains), Pending: len(domains)}
+	for i, domain := range domains {
+		search.Results[i] = AvailabilityCheck{Domain: domain, Status: AvailabilityPending}
+	}
+	return search
+}
+
+// Record stores the result of the i'th domain's lookup and updates the counts
+func (s *AvailabilitySearch) Record(i int, availability *DomainAvailability, err error) {
+	result := &s.Results[i]
+	if result.Status != AvailabilityPending {
+		return
+	}
+	s.Pending--
+	if err != nil {
+		result.Status, result.Error = AvailabilityFailed, err.Error()
+		s.Failed++
+		return
+	}
+	result.Status, result.Availability = AvailabilityChecked, availability
+	s.Checked++
+	if availability.Available {
+		s.Available++
+	}
+}
+
+// Snapshot returns a copy of the search that later Records don't change
+func (s *AvailabilitySearch) Snapshot() *AvailabilitySearch {
+	snapshot := *s
+	snapshot.Results = append([]AvailabilityCheck(nil), s.Results...)
+	return &snapshot
+}
@@ -0,0 +1,46 @@
+package types
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestAvailabilitySearchRequest_Normalize(t *testing.T) {
+	req := AvailabilitySearchRequest{Domains: []string{" Example.com ", "example.org", "", "EXAMPLE.COM"}}
+	if err := req.Normalize(10); err != nil {
+		t.Fatalf("Normalize() unexpected error: %v", err)
+	}
+	if want := []string{"example.com", "example.org"}; !reflect.DeepEqual(req.Domains, want) {
+		t.Errorf("Domains = %v, want %v", req.Domains, want)
+	}
+
+	invalid := []AvailabilitySearchRequest{
+		{},
+		{Domains: []string{"localhost"}},
+		{Domains: []string{"a.com", "b.com", "c.com"}},
+	}
+	for _, r := range invalid {
+		if err := r.Normalize(2); !errors.Is(err, ErrInvalidAvailabilitySearch) {
+			t.Errorf("Normalize(%v) error = %v, want ErrInvalidAvailabilitySearch", r.Domains, err)
+		}
+	}
+}
+
+func TestAvailabilitySearch_Record(t *testing.T) {
+	search := NewAvailabilitySearch([]string{"a.com", "b.com", "c.com"})
+	search.Record(0, &DomainAvailability{Domain: "a.com", Available: true}, nil)
+	snapshot := search.Snapshot()
+	search.Record(1, nil, errors.New("rate limited"))
+	search.Record(1, &DomainAvailability{Domain: "b.com"}, nil) // Already recorded
+
+	if search.Checked != 1 || search.Available != 1 || search.Failed != 1 || search.Pending != 1 {
+		t.Errorf("counts = %+v, want 1 checked, 1 available, 1 failed, 1 pending", search)
+	}
+	if search.Results[1].Status != AvailabilityFailed || search.Results[2].Status != AvailabilityPending {
+		t.Errorf("results = %+v", search.Results)
+	}
+	if snapshot.Pending != 2 || snapshot.Results[1].Status != AvailabilityPending {
+		t.Errorf("snapshot changed by a later Record: %+v", snapshot)
+	}
+}
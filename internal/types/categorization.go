@@ -0,0 +1,192 @@
+package types
+
+import (
+	"fmt"
+	"path"
+	"strings"
+	"time"
+)
+
+// CategorizationRule assigns a category and tags to domains that match its conditions. Rules
+// run in priority order (lowest first) on import, on sync and on demand. A domain's category
+// comes from the first matching rule that sets one and is only filled in when the domain has
+// none, unless a recategorization asks to overwrite; tags from every matching rule are added.
+type CategorizationRule struct {
+	ID            string    `json:"id" db:"id"`
+	Name          string    `json:"name" db:"name"`
+	Priority      int       `json:"priority" db:"priority"`
+	Enabled       bool      `json:"enabled" db:"enabled"`
+	NamePattern   string    `json:"name_pattern,omitempty" db:"name_pattern"`       // Glob on the domain name, e.g. *.shop
+	Provider      string    `json:"provider,omitempty" db:"provider"`               // Registrar the domain is synced from
+	HasRecordType string    `json:"has_record_type,omitempty" db:"has_record_type"` // The domain has a DNS record of this type, e.g. MX
+	CategoryID    *string   `json:"category_id,omitempty" db:"category_id"`
+	Tags          TagsSlice `json:"tags" db:"tags"`
+	CreatedAt     time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// CategorizationRuleRequest creates or updates a categorization rule
+type CategorizationRuleRequest struct {
+	Name          string   `json:"name"`
+	Priority      int      `json:"priority"`
+	Enabled       *bool    `json:"enabled"` // Defaults to true on create
+	NamePattern   string   `json:"name_pattern"`
+	Provider      string   `json:"provider"`
+	HasRecordType string   `json:"has_record_type"`
+	CategoryID    *string  `json:"category_id"`
+	Tags          []string `json:"tags"`
+}
+
+// RecategorizeRequest re-applies the categorization rules to the whole portfolio
+type RecategorizeRequest struct {
+	DryRun    bool `json:"dry_run"`   // Report what would change without writing anything
+	Overwrite bool `json:"overwrite"` // Replace categories domains already have, not just fill missing ones
+}
+
+// CategorizationChange describes what the rules change on one domain
+type CategorizationChange struct {
+	DomainID       string   `json:"domain_id"`
+	DomainName     string   `json:"domain_name"`
+	CategoryBefore *string  `json:"category_before,omitempty"`
+	CategoryAfter  *string  `json:"category_after,omitempty"`
+	AddedTags      []string `json:"added_tags,omitempty"`
+	Rules          []string `json:"rules"` // Names of the rules that matched
+}
+
+// Validate cleans the rule's name, conditions and tags and checks it has at least one
+// condition and one action
+func (r *CategorizationRule) Validate() error {
+	name, err := SanitizeText(r.Name, MaxNameLength, false)
+	if err != nil {
+		return fmt.Errorf("%w: name %v", ErrInvalidCategorizationRule, err)
+	}
+	if name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidCategorizationRule)
+	}
+	r.Name = name
+
+	r.NamePattern = strings.ToLower(strings.TrimSpace(r.NamePattern))
+	if _, err := path.Match(r.NamePattern, ""); err != nil {
+		return fmt.Errorf("%w: invalid name_pattern %q", ErrInvalidCategorizationRule, r.NamePattern)
+	}
+	r.Provider = strings.ToLower(strings.TrimSpace(r.Provider))
+	r.HasRecordType = strings.ToUpper(strings.TrimSpace(r.HasRecordType))
+	if r.HasRecordType != "" && !isRecordTypeName(r.HasRecordType) {
+		return fmt.Errorf("%w: invalid record type %q", ErrInvalidCategorizationRule, r.HasRecordType)
+	}
+	if r.NamePattern == "" && r.Provider == "" && r.HasRecordType == "" {
+		return fmt.Errorf("%w: at least one of name_pattern, provider or has_record_type is required", ErrInvalidCategorizationRule)
+	}
+
+	if r.CategoryID != nil && strings.TrimSpace(*r.CategoryID) == "" {
+		r.CategoryID = nil
+	}
+	if r.Tags == nil {
+		r.Tags = TagsSlice{}
+	}
+	if err := r.Tags.Validate(); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidCategorizationRule, err)
+	}
+	if r.CategoryID == nil && len(r.Tags) == 0 {
+		return fmt.Errorf("%w: a category_id or at least one tag is required", ErrInvalidCategorizationRule)
+	}
+	return nil
+}
+
+// Matches reports whether a domain meets every condition of the rule. hasRecord tells whether
+// the domain has a DNS record of a type.
+func (r *CategorizationRule) Matches(domain Domain, hasRecord func(recordType string) bool) bool {
+	if r.NamePattern != "" {
+		if ok, _ := path.Match(r.NamePattern, NormalizeDomainName(domain.Name)); !ok {
+			return false
+		}
+	}
+	if r.Provider != "" && !strings.EqualFold(r.Provider, domain.Provider) {
+		return false
+	}
+	if r.HasRecordType != "" && !hasRecord(r.HasRecordType) {
+		return false
+	}
+	return true
+}
+
+// ApplyCategorizationRules runs enabled rules, in the order given, against a domain and
+// updates its category and tags. The category is only replaced when the domain has none or
+// overwrite is set. It returns nil when no rule changes anything.
+func ApplyCategorizationRules(rules []CategorizationRule, domain *Domain, hasRecord func(recordType string) bool, overwrite bool) *CategorizationChange {
+	change := &CategorizationChange{DomainID: domain.ID, DomainName: domain.Name, CategoryBefore: domain.CategoryID}
+
+	var category *string
+	categoryRule := -1
+	tags := append(TagsSlice{}, domain.Tags...)
+	present := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		present[tag] = true
+	}
+
+	var tagRules []int // Rules that added at least one tag
+	for i := range rules {
+		rule := &rules[i]
+		if !rule.Enabled || !rule.Matches(*domain, hasRecord) {
+			continue
+		}
+		if rule.CategoryID != nil && category == nil {
+			category = rule.CategoryID
+			categoryRule = i
+		}
+		added := false
+		for _, tag := range rule.Tags {
+			if !present[tag] && len(tags) < MaxTags {
+				present[tag] = true
+				tags = append(tags, tag)
+				change.AddedTags = append(change.AddedTags, tag)
+				added = true
+			}
+		}
+		if added {
+			tagRules = append(tagRules, i)
+		}
+	}
+
+	categoryChanged := category != nil && (domain.CategoryID == nil || (overwrite && *domain.CategoryID != *category))
+	if !categoryChanged && len(change.AddedTags) == 0 {
+		return nil
+	}
+	for i := range rules {
+		// The category rule only counts when its category was applied
+		if (i == categoryRule && categoryChanged) || containsIndex(tagRules, i) {
+			change.Rules = append(change.Rules, rules[i].Name)
+		}
+	}
+
+	if categoryChanged {
+		id := *category
+		domain.CategoryID = &id
+	}
+	domain.Tags = tags
+	change.CategoryAfter = domain.CategoryID
+	return change
+}
+
+// containsIndex reports whether i is in indexes
+func containsIndex(indexes []int, i int) bool {
+	for _, index := range indexes {
+		if index == i {
+			return true
+		}
+	}
+	return false
+}
+
+// isRecordTypeName reports whether s looks like a DNS record type mnemonic, e.g. MX or TLSA
+func isRecordTypeName(s string) bool {
+	if len(s) > 10 {
+		return false
+	}
+	for _, r := range s {
+		if (r < 'A' || r > 'Z') && (r < '0' || r > '9') {
+			return false
+		}
+	}
+	return s != ""
+}
@@ -0,0 +1,74 @@
+package types
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestCategorizationRule_Validate(t *testing.T) {
+	category := "cat-shop"
+	rule := CategorizationRule{Name: " Shops ", NamePattern: " *.SHOP ", HasRecordType: "mx", CategoryID: &category}
+	if err := rule.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if rule.Name != "Shops" || rule.NamePattern != "*.shop" || rule.HasRecordType != "MX" {
+		t.Errorf("Validate() should normalize the rule, got %+v", rule)
+	}
+
+	invalid := []CategorizationRule{
+		{NamePattern: "*.shop", Tags: TagsSlice{"shop"}},                    // No name
+		{Name: "No condition", Tags: TagsSlice{"shop"}},                     // No condition
+		{Name: "No action", NamePattern: "*.shop"},                          // No category or tags
+		{Name: "Bad pattern", NamePattern: "[*.shop", Tags: TagsSlice{"x"}}, // Unclosed class
+		{Name: "Bad type", HasRecordType: "M X", Tags: TagsSlice{"x"}},
+	}
+	for _, rule := range invalid {
+		if err := rule.Validate(); !errors.Is(err, ErrInvalidCategorizationRule) {
+			t.Errorf("Validate(%+v) error = %v, want ErrInvalidCategorizationRule", rule, err)
+		}
+	}
+}
+
+func TestApplyCategorizationRules(t *testing.T) {
+	shop, mail, manual := "cat-shop", "cat-mail", "cat-manual"
+	rules := []CategorizationRule{
+		{Name: "Shops", Enabled: true, NamePattern: "*.shop", CategoryID: &shop, Tags: TagsSlice{"store"}},
+		{Name: "Email", Enabled: true, HasRecordType: "MX", CategoryID: &mail, Tags: TagsSlice{"has-email"}},
+		{Name: "Disabled", Enabled: false, NamePattern: "*", Tags: TagsSlice{"never"}},
+	}
+	withMX := func(recordType string) bool { return recordType == "MX" }
+	noRecords := func(string) bool { return false }
+
+	domain := Domain{ID: "d1", Name: "Example.SHOP"}
+	change := ApplyCategorizationRules(rules, &domain, withMX, false)
+	if change == nil || domain.CategoryID == nil || *domain.CategoryID != shop {
+		t.Fatalf("Expected the first matching rule's category, got %v", domain.CategoryID)
+	}
+	if !reflect.DeepEqual([]string(domain.Tags), []string{"store", "has-email"}) {
+		t.Errorf("Expected tags from every matching rule, got %v", domain.Tags)
+	}
+	if !reflect.DeepEqual(change.Rules, []string{"Shops", "Email"}) {
+		t.Errorf("Expected both rules to be reported, got %v", change.Rules)
+	}
+
+	// A second run changes nothing
+	if again := ApplyCategorizationRules(rules, &domain, withMX, false); again != nil {
+		t.Errorf("Expected no change when reapplied, got %+v", again)
+	}
+
+	// An existing category is kept unless overwriting
+	categorized := Domain{ID: "d2", Name: "example.shop", CategoryID: &manual, Tags: TagsSlice{"store"}}
+	if change := ApplyCategorizationRules(rules, &categorized, noRecords, false); change != nil || *categorized.CategoryID != manual {
+		t.Errorf("Expected the manual category to be kept, got %v (%+v)", *categorized.CategoryID, change)
+	}
+	change = ApplyCategorizationRules(rules, &categorized, noRecords, true)
+	if change == nil || *categorized.CategoryID != shop || *change.CategoryBefore != manual {
+		t.Errorf("Expected overwrite to replace the category, got %v (%+v)", *categorized.CategoryID, change)
+	}
+
+	other := Domain{ID: "d3", Name: "example.com"}
+	if change := ApplyCategorizationRules(rules, &other, noRecords, false); change != nil || other.CategoryID != nil {
+		t.Errorf("Expected no rule to match example.com, got %+v", change)
+	}
+}
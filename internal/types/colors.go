@@ -0,0 +1,82 @@
+package types
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Colors given to categories and projects saved without one, matching the column defaults
+const (
+	DefaultCategoryColor = "#6366f1"
+	DefaultProjectColor  = "#059669"
+)
+
+// hexColorPattern matches #rgb and #rrggbb hex colors
+var hexColorPattern = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// PaletteColor is a suggested color for a category or project
+type PaletteColor struct {
+	Hex  string `json:"hex"`
+	Name string `json:"name"`
+}
+
+// ColorPalette is a curated set of colors that are distinct from each other and keep white
+// label text at a contrast ratio of at least 4.5:1
+var ColorPalette = []PaletteColor{
+	{"#1d4ed8", "Blue"},
+	{"#b91c1c", "Red"},
+	{"#15803d", "Green"},
+	{"#7e22ce", "Purple"},
+	{"#c2410c", "Orange"},
+	{"#0f766e", "Teal"},
+	{"#be185d", "Pink"},
+	{"#4338ca", "Indigo"},
+	{"#a16207", "Amber"},
+	{"#0369a1", "Sky"},
+	{"#4d7c0f", "Lime"},
+	{"#374151", "Slate"},
+}
+
+// NormalizeColor checks that color is a #rgb or #rrggbb hex code and returns it lowercased.
+// An empty color becomes defaultColor.
+func NormalizeColor(color, defaultColor string) (string, error) {
+	color = strings.TrimSpace(color)
+	if color == "" {
+		return defaultColor, nil
+	}
+	if !hexColorPattern.MatchString(color) {
+		return "", fmt.Errorf("must be a hex color like #1d4ed8, got %q", color)
+	}
+	return strings.ToLower(color), nil
+}
+
+// SuggestColors returns the palette without the colors in used, in palette order. When every
+// palette color is in use the whole palette is returned, since some color must be picked.
+func SuggestColors(used []string) []PaletteColor {
+	inUse := make(map[string]bool, len(used))
+	for _, color := range used {
+		if normalized, err := NormalizeColor(color, ""); err == nil && normalized != "" {
+			inUse[expandHexColor(normalized)] = true
+		}
+	}
+
+	suggestions := make([]PaletteColor, 0, len(ColorPalette))
+	for _, color := range ColorPalette {
+		if !inUse[color.Hex] {
+			suggestions = append(suggestions, color)
+		}
+	}
+	if len(suggestions) == 0 {
+		return append(suggestions, ColorPalette...)
+	}
+	return suggestions
+}
+
+// expandHexColor turns a normalized #rgb color into #rrggbb
+func expandHexColor(color string) string {
+	if len(color) != 4 {
+		return color
+	}
+	return string([]byte{'#', color[1], color[1], color[2], color[2], color[3], color[3]})
+}
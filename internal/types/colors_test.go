@@ -0,0 +1,86 @@
+package types
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"testing"
+)
+
+func TestNormalizeColor(t *testing.T) {
+	tests := []struct {
+		color, want string
+		wantErr     bool
+	}{
+		{"", DefaultCategoryColor, false},
+		{" #1D4ED8 ", "#1d4ed8", false},
+		{"#abc", "#abc", false},
+		{"1d4ed8", "", true},
+		{"#1d4ed", "", true},
+		{"#ggggggg", "", true},
+		{"red", "", true},
+	}
+	for _, tt := range tests {
+		got, err := NormalizeColor(tt.color, DefaultCategoryColor)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("NormalizeColor(%q) = %q, %v", tt.color, got, err)
+		}
+	}
+
+	if err := (&Category{Name: "Marketing", Color: "blue"}).Validate(); !errors.Is(err, ErrInvalidCategory) {
+		t.Errorf("invalid category color: error = %v, want ErrInvalidCategory", err)
+	}
+	project := Project{Name: "Launch"}
+	if err := project.Validate(); err != nil || project.Color != DefaultProjectColor {
+		t.Errorf("Expected the default project color, got %q, %v", project.Color, err)
+	}
+}
+
+// relativeLuminance is the WCAG relative luminance of a #rrggbb color
+func relativeLuminance(color string) float64 {
+	channel := func(hex string) float64 {
+		v, _ := strconv.ParseUint(hex, 16, 8)
+		c := float64(v) / 255
+		if c <= 0.03928 {
+			return c / 12.92
+		}
+		return math.Pow((c+0.055)/1.055, 2.4)
+	}
+	return 0.2126*channel(color[1:3]) + 0.7152*channel(color[3:5]) + 0.0722*channel(color[5:7])
+}
+
+func TestColorPalette_Accessible(t *testing.T) {
+	seen := make(map[string]bool)
+	for _, color := range ColorPalette {
+		if normalized, err := NormalizeColor(color.Hex, ""); err != nil || normalized != color.Hex || len(color.Hex) != 7 {
+			t.Errorf("Palette color %s isn't a normalized #rrggbb code", color.Hex)
+		}
+		if seen[color.Hex] {
+			t.Errorf("Palette color %s is repeated", color.Hex)
+		}
+		seen[color.Hex] = true
+
+		// Contrast of white text on the color
+		if ratio := 1.05 / (relativeLuminance(color.Hex) + 0.05); ratio < 4.5 {
+			t.Errorf("Palette color %s (%s) has a contrast ratio of %.2f with white", color.Hex, color.Name, ratio)
+		}
+	}
+}
+
+func TestSuggestColors(t *testing.T) {
+	suggestions := SuggestColors([]string{"#1D4ED8", "#b91c1c", "not a color", ""})
+	if len(suggestions) != len(ColorPalette)-2 {
+		t.Fatalf("Expected %d suggestions, got %d", len(ColorPalette)-2, len(suggestions))
+	}
+	if suggestions[0].Hex != "#15803d" {
+		t.Errorf("Expected palette order to be kept, got %s first", suggestions[0].Hex)
+	}
+
+	var all []string
+	for _, color := range ColorPalette {
+		all = append(all, color.Hex)
+	}
+	if got := SuggestColors(all); len(got) != len(ColorPalette) {
+		t.Errorf("Expected the whole palette when every color is used, got %d", len(got))
+	}
+}
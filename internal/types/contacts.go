@@ -0,0 +1,87 @@
+package types
+
+import (
+	"fmt"
+	"net/mail"
+	"regexp"
+	"strings"
+)
+
+// phonePattern matches the +<country code>.<number> form registrars use for contact phones
+var phonePattern = regexp.MustCompile(`^\+[0-9]{1,3}\.[0-9]{4,14}$`)
+
+// DomainContact is a registrant, admin, tech or billing contact held by the registrar
+type DomainContact struct {
+	FirstName    string `json:"first_name"`
+	LastName     string `json:"last_name"`
+	Organization string `json:"organization,omitempty"`
+	Email        string `json:"email"`
+	Phone        string `json:"phone"` // +<country code>.<number>, e.g. +1.4805058800
+	Address1     string `json:"address1"`
+	Address2     string `json:"address2,omitempty"`
+	City         string `json:"city"`
+	State        string `json:"state,omitempty"`
+	PostalCode   string `json:"postal_code"`
+	Country      string `json:"country"` // ISO 3166-1 alpha-2
+}
+
+// DomainContacts are the contacts and WHOIS privacy state of a domain at its registrar
+type DomainContacts struct {
+	DomainID   string         `json:"domain_id"`
+	Domain     string         `json:"domain"`
+	Provider   string         `json:"provider"`
+	Privacy    bool           `json:"privacy"` // Contact details are hidden from public WHOIS
+	Registrant *DomainContact `json:"registrant,omitempty"`
+	Admin      *DomainContact `json:"admin,omitempty"`
+	Tech       *DomainContact `json:"tech,omitempty"`
+	Billing    *DomainContact `json:"billing,omitempty"`
+}
+
+// DomainContactsUpdate changes a domain's WHOIS privacy and/or registrant; unset fields are left alone
+type DomainContactsUpdate struct {
+	Privacy    *bool          `json:"privacy"`
+	Registrant *DomainContact `json:"registrant"`
+}
+
+// Validate trims the contact's fields and checks that the ones registrars require are present
+func (c *DomainContact) Validate() error {
+	fields := []struct {
+		name     string
+		value    *string
+		required bool
+	}{
+		{"first_name", &c.FirstName, true},
+		{"last_name", &c.LastName, true},
+		{"organization", &c.Organization, false},
+		{"email", &c.Email, true},
+		{"phone", &c.Phone, true},
+		{"address1", &c.Address1, true},
+		{"address2", &c.Address2, false},
+		{"city", &c.City, true},
+		{"state", &c.State, false},
+		{"postal_code", &c.PostalCode, true},
+		{"country", &c.Country, true},
+	}
+	for _, field := range fields {
+		value, err := SanitizeText(*field.value, MaxNameLength, false)
+		if err != nil {
+			return fmt.Errorf("%w: %s %v", ErrInvalidContact, field.name, err)
+		}
+		if field.required && value == "" {
+			return fmt.Errorf("%w: %s is required", ErrInvalidContact, field.name)
+		}
+		*field.value = value
+	}
+
+	if _, err := mail.ParseAddress(c.Email); err != nil {
+		return fmt.Errorf("%w: email is not a valid address", ErrInvalidContact)
+	}
+	if !phonePattern.MatchString(c.Phone) {
+		return fmt.Errorf("%w: phone must be in the form +1.4805058800", ErrInvalidContact)
+	}
+	c.Country = strings.ToUpper(c.Country)
+	if len(c.Country) != 2 {
+		return fmt.Errorf("%w: country must be a two-letter ISO code", ErrInvalidContact)
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package types
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDomainContact_Validate(t *testing.T) {
+	valid := func() DomainContact {
+		return DomainContact{
+			FirstName: " Ada ", LastName: "Lovelace", Email: "ada@example.com", Phone: "+44.2079460000",
+			Address1: "12 St James's Square", City: "London", PostalCode: "SW1Y 4JH", Country: "gb",
+		}
+	}
+
+	contact := valid()
+	if err := contact.Validate(); err != nil {
+		t.Fatalf("Validate() unexpected error: %v", err)
+	}
+	if contact.FirstName != "Ada" || contact.Country != "GB" {
+		t.Errorf("Validate() should trim and upper-case fields, got %+v", contact)
+	}
+
+	invalid := map[string]func(*DomainContact){
+		"missing city":    func(c *DomainContact) { c.City = " " },
+		"bad email":       func(c *DomainContact) { c.Email = "ada" },
+		"bad phone":       func(c *DomainContact) { c.Phone = "020 7946 0000" },
+		"bad country":     func(c *DomainContact) { c.Country = "GBR" },
+		"control in name": func(c *DomainContact) { c.LastName = "Love\x00lace" },
+	}
+	for name, mutate := range invalid {
+		contact := valid()
+		mutate(&contact)
+		if err := contact.Validate(); !errors.Is(err, ErrInvalidContact) {
+			t.Errorf("%s: Validate() error = %v, want ErrInvalidContact", name, err)
+		}
+	}
+}
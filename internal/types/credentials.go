@@ -6,14 +6,15 @@ import (
 	"time"
 )
 
-// SecureProviderCredentials stores provider connection metadata with references to environment variables
-// This improves security by not storing actual API keys in the database
+// SecureProviderCredentials stores provider connection metadata with a reference to the credentials,
+// either a predefined environment variable set or a secret in the configured secret store.
+// This improves security by not storing actual API keys with the connection.
 type SecureProviderCredentials struct {
 	ID                  string    `json:"id" db:"id"`
 	Provider            string    `json:"provider" db:"provider"`                       // Provider type (godaddy, namecheap, hostinger)
 	Name                string    `json:"name" db:"name"`                               // User-friendly name
 	AccountName         string    `json:"account_name" db:"account_name"`               // Account identifier
-	CredentialReference string    `json:"credential_reference" db:"credential_reference"` // Reference to env var (e.g., "GODADDY_DEFAULT", "NAMECHEAP_ACCOUNT1")
+	CredentialReference string    `json:"credential_reference" db:"credential_reference"` // Env var set (e.g., "GODADDY_DEFAULT") or secret store reference
 	Enabled             bool      `json:"enabled" db:"enabled"`
 	ConnectionStatus    string    `json:"connection_status" db:"connection_status"`     // connected, error, testing
 	LastSync            *time.Time `json:"last_sync,omitempty" db:"last_sync"`
@@ -27,7 +28,8 @@ type SecureProviderConnectionRequest struct {
 	Provider              string `json:"provider" binding:"required"`
 	Name                  string `json:"name" binding:"required"`                    // User-friendly name
 	AccountName           string `json:"account_name" binding:"required"`           // Account identifier
-	CredentialReference   string `json:"credential_reference" binding:"required"`   // Reference to environment variables
+	CredentialReference   string `json:"credential_reference"`                      // Env var set or existing secret store reference
+	Credentials           map[string]string `json:"credentials,omitempty"`          // Credentials to keep in the secret store instead of a reference
 	TestConnection        bool   `json:"test_connection"`                           // Test before saving
 	AutoSync              bool   `json:"auto_sync"`                                 // Enable auto-sync
 	SyncIntervalHours     int    `json:"sync_interval_hours"`                       // Auto-sync interval in hours
@@ -105,6 +107,49 @@ var CredentialReferenceMap = map[string]PredefinedCredentialOption{
 			"client_id": "HOSTINGER_STAGING_CLIENT_ID",
 		},
 	},
+
+	// OVH credential references
+	"OVH_DEFAULT": {
+		Reference:   "OVH_DEFAULT",
+		DisplayName: "OVH Production Account",
+		Provider:    "ovh",
+		Fields: map[string]string{
+			"application_key":    "OVH_APPLICATION_KEY",
+			"application_secret": "OVH_APPLICATION_SECRET",
+			"consumer_key":       "OVH_CONSUMER_KEY",
+		},
+	},
+
+	// DNSimple credential references
+	"DNSIMPLE_DEFAULT": {
+		Reference:   "DNSIMPLE_DEFAULT",
+		DisplayName: "DNSimple Production Account",
+		Provider:    "dnsimple",
+		Fields: map[string]string{
+			"access_token": "DNSIMPLE_ACCESS_TOKEN",
+		},
+	},
+
+	// Dynadot credential references
+	"DYNADOT_DEFAULT": {
+		Reference:   "DYNADOT_DEFAULT",
+		DisplayName: "Dynadot Production Account",
+		Provider:    "dynadot",
+		Fields: map[string]string{
+			"api_key": "DYNADOT_API_KEY",
+		},
+	},
+
+	// Hover credential references
+	"HOVER_DEFAULT": {
+		Reference:   "HOVER_DEFAULT",
+		DisplayName: "Hover Account",
+		Provider:    "hover",
+		Fields: map[string]string{
+			"username": "HOVER_USERNAME",
+			"password": "HOVER_PASSWORD",
+		},
+	},
 }
 
 // GetCredentialOptions returns available credential options for a provider
@@ -128,6 +173,13 @@ func GetCredentialOptions(provider string) []PredefinedCredentialOption {
 	return options
 }
 
+// IsEnvCredentialReference reports whether reference names a predefined environment variable
+// set rather than a secret store entry
+func IsEnvCredentialReference(reference string) bool {
+	_, exists := CredentialReferenceMap[reference]
+	return exists
+}
+
 // ResolveCredentials resolves a credential reference to actual values from environment variables
 func ResolveCredentials(reference string) (map[string]string, error) {
 	option, exists := CredentialReferenceMap[reference]
@@ -155,6 +207,10 @@ func isRequiredField(provider, field string) bool {
 		"godaddy":   {"api_key", "api_secret"},
 		"namecheap": {"api_key", "username"},
 		"hostinger": {"api_key"},
+		"ovh":       {"application_key", "application_secret", "consumer_key"},
+		"dnsimple":  {"access_token"},
+		"dynadot":   {"api_key"},
+		"hover":     {"username", "password"},
 	}
 	
 	required, exists := requiredFields[provider]
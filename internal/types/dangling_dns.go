@@ -0,0 +1,67 @@
+package types
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// DanglingRisk classifies a DNS record that points at infrastructure which may no longer exist
+type DanglingRisk string
+
+const (
+	DanglingTakeover    DanglingRisk = "takeover"    // The target is unclaimed on a service that lets anyone claim it
+	DanglingUnresolved  DanglingRisk = "unresolved"  // A CNAME target that doesn't resolve
+	DanglingUnreachable DanglingRisk = "unreachable" // An A record address that answers on neither port 80 nor 443
+)
+
+// DanglingRisks lists the risks from most to least severe
+var DanglingRisks = []DanglingRisk{DanglingTakeover, DanglingUnresolved, DanglingUnreachable}
+
+// DanglingDNSFinding is a CNAME or A record whose target looks deprovisioned
+type DanglingDNSFinding struct {
+	DomainID   string       `json:"domain_id"`
+	DomainName string       `json:"domain_name"`
+	RecordID   string       `json:"record_id"`
+	Host       string       `json:"host"` // Fully qualified name of the record
+	Type       string       `json:"type"`
+	Target     string       `json:"target"`
+	Risk       DanglingRisk `json:"risk"`
+	Service    string       `json:"service,omitempty"` // Cloud service the target belongs to, when recognized
+	Reason     string       `json:"reason"`
+}
+
+// Key identifies the finding across scans, so it's only alerted on when first seen
+func (f DanglingDNSFinding) Key() string {
+	return strings.ToLower(strings.Join([]string{f.Host, f.Type, f.Target, string(f.Risk)}, "|"))
+}
+
+// DanglingDNSReport is the result of checking the portfolio's CNAME and A records
+type DanglingDNSReport struct {
+	Findings       []DanglingDNSFinding `json:"findings"`
+	Summary        map[DanglingRisk]int `json:"summary"`
+	Domains        int                  `json:"domains"`
+	RecordsChecked int                  `json:"records_checked"`
+	Errors         int                  `json:"errors"` // Records whose check failed, e.g. on a resolver timeout
+	CheckedAt      time.Time            `json:"checked_at"`
+	DurationMs     int64                `json:"duration_ms"`
+}
+
+// Finish counts the findings by risk and sorts them most severe first, then by host
+func (r *DanglingDNSReport) Finish() {
+	rank := make(map[DanglingRisk]int, len(DanglingRisks))
+	r.Summary = make(map[DanglingRisk]int, len(DanglingRisks))
+	for i, risk := range DanglingRisks {
+		rank[risk] = i
+		r.Summary[risk] = 0
+	}
+	for _, finding := range r.Findings {
+		r.Summary[finding.Risk]++
+	}
+	sort.SliceStable(r.Findings, func(i, j int) bool {
+		if rank[r.Findings[i].Risk] != rank[r.Findings[j].Risk] {
+			return rank[r.Findings[i].Risk] < rank[r.Findings[j].Risk]
+		}
+		return r.Findings[i].Host < r.Findings[j].Host
+	})
+}
@@ -0,0 +1,16 @@
+package types
+
+import "time"
+
+// DNSBackup is a domain's zone as a provider served it, captured before DomainVault wrote to
+// that provider so a bad push can be undone
+type DNSBackup struct {
+	ID          string        `json:"id" db:"id"`
+	DomainID    string        `json:"domain_id" db:"domain_id"`
+	Provider    string        `json:"provider" db:"provider"` // Provider the zone was fetched from and is restored to
+	Reason      string        `json:"reason" db:"reason"`     // The write the backup was taken before
+	Records     DNSRecordList `json:"records" db:"records"`
+	RecordCount int           `json:"record_count" db:"record_count"`
+	CreatedBy   string        `json:"created_by" db:"created_by"`
+	CreatedAt   time.Time     `json:"created_at" db:"created_at"`
+}
@@ -0,0 +1,96 @@
+package types
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// DNSInventoryTypes are the DNS record types the portfolio DNS inventory groups by value
+var DNSInventoryTypes = []string{"A", "AAAA", "CNAME", "MX", "NS"}
+
+// DNSInventoryDomain is a domain with records pointing at an inventory value
+type DNSInventoryDomain struct {
+	DomainID    string   `json:"domain_id"`
+	DomainName  string   `json:"domain_name"`
+	RecordNames []string `json:"record_names"` // Names of the domain's records with the value, e.g. "@" and "www"
+}
+
+// DNSInventoryEntry is one value in use across the portfolio and the domains using it
+type DNSInventoryEntry struct {
+	Value       string               `json:"value"`
+	DomainCount int                  `json:"domain_count"`
+	RecordCount int                  `json:"record_count"`
+	Domains     []DNSInventoryDomain `json:"domains"`
+}
+
+// DNSInventory is every unique value of one record type across a set of domains
+type DNSInventory struct {
+	Type         string              `json:"type"`
+	Entries      []DNSInventoryEntry `json:"entries"` // The most widely used values first
+	TotalValues  int                 `json:"total_values"`
+	TotalDomains int                 `json:"total_domains"` // Domains with at least one record of the type
+	GeneratedAt  time.Time           `json:"generated_at"`
+}
+
+// IsDNSInventoryType reports whether the inventory supports recordType
+func IsDNSInventoryType(recordType string) bool {
+	for _, t := range DNSInventoryTypes {
+		if strings.EqualFold(t, recordType) {
+			return true
+		}
+	}
+	return false
+}
+
+// BuildDNSInventory groups the records of recordType that belong to domains by value, compared
+// as normalized by InfrastructureValue. domains maps domain IDs to names; records of domains
+// not in it are left out, so it also scopes the inventory.
+func BuildDNSInventory(recordType string, records []DNSRecord, domains map[string]string, now time.Time) DNSInventory {
+	recordType = strings.ToUpper(recordType)
+	inventory := DNSInventory{Type: recordType, Entries: []DNSInventoryEntry{}, GeneratedAt: now}
+
+	type key struct{ value, domainID string }
+	entries := make(map[string]*DNSInventoryEntry)
+	names := make(map[key][]string)
+	usedBy := make(map[string]bool)
+	for _, record := range records {
+		domainName, ok := domains[record.DomainID]
+		value := InfrastructureValue(record.Value)
+		if !ok || value == "" || !strings.EqualFold(record.Type, recordType) {
+			continue
+		}
+		entry, ok := entries[value]
+		if !ok {
+			entry = &DNSInventoryEntry{Value: value}
+			entries[value] = entry
+		}
+		k := key{value, record.DomainID}
+		if _, ok := names[k]; !ok {
+			entry.Domains = append(entry.Domains, DNSInventoryDomain{DomainID: record.DomainID, DomainName: domainName})
+		}
+		names[k] = append(names[k], record.Name)
+		entry.RecordCount++
+		usedBy[record.DomainID] = true
+	}
+
+	for _, entry := range entries {
+		for i := range entry.Domains {
+			recordNames := names[key{entry.Value, entry.Domains[i].DomainID}]
+			sort.Strings(recordNames)
+			entry.Domains[i].RecordNames = recordNames
+		}
+		sort.Slice(entry.Domains, func(i, j int) bool { return entry.Domains[i].DomainName < entry.Domains[j].DomainName })
+		entry.DomainCount = len(entry.Domains)
+		inventory.Entries = append(inventory.Entries, *entry)
+	}
+	sort.Slice(inventory.Entries, func(i, j int) bool {
+		if inventory.Entries[i].DomainCount != inventory.Entries[j].DomainCount {
+			return inventory.Entries[i].DomainCount > inventory.Entries[j].DomainCount
+		}
+		return inventory.Entries[i].Value < inventory.Entries[j].Value
+	})
+	inventory.TotalValues = len(inventory.Entries)
+	inventory.TotalDomains = len(usedBy)
+	return inventory
+}
@@ -0,0 +1,42 @@
+package types
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestBuildDNSInventory(t *testing.T) {
+	domains := map[string]string{"d1": "alpha.com", "d2": "beta.com"}
+	records := []DNSRecord{
+		{DomainID: "d1", Type: "A", Name: "www", Value: "192.0.2.1"},
+		{DomainID: "d1", Type: "A", Name: "@", Value: "192.0.2.1"},
+		{DomainID: "d2", Type: "a", Name: "@", Value: " 192.0.2.1 "},
+		{DomainID: "d2", Type: "A", Name: "api", Value: "192.0.2.2"},
+		{DomainID: "d2", Type: "MX", Name: "@", Value: "192.0.2.1"},
+		{DomainID: "d3", Type: "A", Name: "@", Value: "192.0.2.3"}, // Out of scope
+	}
+
+	inventory := BuildDNSInventory("a", records, domains, time.Now())
+	if inventory.Type != "A" || inventory.TotalValues != 2 || inventory.TotalDomains != 2 {
+		t.Fatalf("Unexpected inventory totals: %+v", inventory)
+	}
+	shared := inventory.Entries[0]
+	if shared.Value != "192.0.2.1" || shared.DomainCount != 2 || shared.RecordCount != 3 {
+		t.Fatalf("Expected the shared IP first with 2 domains and 3 records, got %+v", shared)
+	}
+	want := []DNSInventoryDomain{
+		{DomainID: "d1", DomainName: "alpha.com", RecordNames: []string{"@", "www"}},
+		{DomainID: "d2", DomainName: "beta.com", RecordNames: []string{"@"}},
+	}
+	if !reflect.DeepEqual(shared.Domains, want) {
+		t.Errorf("Domains = %+v, want %+v", shared.Domains, want)
+	}
+	if inventory.Entries[1].Value != "192.0.2.2" || inventory.Entries[1].DomainCount != 1 {
+		t.Errorf("Unexpected second entry: %+v", inventory.Entries[1])
+	}
+
+	if empty := BuildDNSInventory("NS", records, domains, time.Now()); empty.Entries == nil || empty.TotalValues != 0 {
+		t.Errorf("Expected an empty inventory for a type with no records, got %+v", empty)
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"database/sql/driver"
 	"encoding/json"
 	"fmt"
+	"regexp"
+	"strings"
 	"time"
 )
 
@@ -12,8 +14,11 @@ type Domain struct {
 	ID          string    `json:"id" db:"id"`                   // UUIDv7
 	Name        string    `json:"name" db:"name"`               // FQDN
 	Provider    string    `json:"provider" db:"provider"`       // Registrar name
+	CredentialID *string  `json:"credential_id,omitempty" db:"credential_id"` // Stored provider account the domain was synced from
+	AccountRef  *string   `json:"account_ref,omitempty" db:"account_ref"`   // Connected provider account the domain was last synced from
 	ExpiresAt   time.Time `json:"expires_at" db:"expires_at"`   // Expiration date
-	CreatedAt   time.Time `json:"created_at" db:"created_at"`   // Record creation
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`   // When DomainVault first stored the domain, not its registration
+	RegisteredAt *time.Time `json:"registered_at,omitempty" db:"registered_at"` // Registration date at the registrar or registry, nil until one reports it
 	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`   // Last update
 	CategoryID  *string   `json:"category_id,omitempty" db:"category_id"` // Category assignment
 	ProjectID   *string   `json:"project_id,omitempty" db:"project_id"`   // Project assignment
@@ -21,12 +26,20 @@ type Domain struct {
 	RenewalPrice *float64 `json:"renewal_price,omitempty" db:"renewal_price"` // Annual renewal cost
 	Status      string    `json:"status" db:"status"`                      // active, expired, transferred, etc.
 	Tags        TagsSlice `json:"tags,omitempty" db:"tags"`                // Organization tags
+	Metadata    MetadataMap `json:"metadata,omitempty" db:"metadata"`     // Deployment-specific attributes, e.g. cost_center
 	Visible     bool      `json:"visible" db:"visible"`                    // Soft-delete visibility flag
+	RegistrarStatus *string `json:"registrar_status,omitempty" db:"registrar_status"` // Registrar-side EPP statuses, comma-separated, e.g. clientTransferProhibited
+	VerificationToken       *string    `json:"-" db:"verification_token"`                                        // Pending DNS TXT ownership challenge
+	VerificationRequestedAt *time.Time `json:"verification_requested_at,omitempty" db:"verification_requested_at"` // When the pending challenge was issued
+	VerifiedAt              *time.Time `json:"verified_at,omitempty" db:"verified_at"`                           // When control of the domain was last proven
 	
 	// HTTP Status monitoring
 	HTTPStatus      *int       `json:"http_status,omitempty" db:"http_status"`           // Last HTTP status code
 	LastStatusCheck *time.Time `json:"last_status_check,omitempty" db:"last_status_check"` // When status was last checked
 	StatusMessage   *string    `json:"status_message,omitempty" db:"status_message"`     // Human-readable status message
+	SSLStatus       *string    `json:"ssl_status,omitempty" db:"ssl_status"`             // valid, invalid, unavailable or unverified
+	RedirectURL     *string    `json:"redirect_url,omitempty" db:"redirect_url"`         // Where the site redirects to, if it does
+	LastResponseTime *int      `json:"last_response_time,omitempty" db:"last_response_time"` // Response time of the last website check in ms
 	
 	// UptimeRobot monitoring
 	UptimeRobotMonitorID *int     `json:"uptime_robot_monitor_id,omitempty" db:"uptime_robot_monitor_id"` // UptimeRobot monitor ID
@@ -48,6 +61,7 @@ type Domain struct {
 type DomainSummary struct {
 	Total       int                    `json:"total"`
 	Hidden      int                    `json:"hidden"`
+	GracePeriod []string               `json:"grace_period"` // Expired domains still held by their registrar, hidden ones included; renew urgently
 	ByProvider  map[string]int         `json:"by_provider"`
 	ExpiringIn  map[string]int         `json:"expiring_in"` // "30_days", "90_days", etc.
 	LastSync    time.Time              `json:"last_sync"`
@@ -61,12 +75,28 @@ type DomainFilter struct {
 	Search       string    `json:"search,omitempty"` // Search in domain name
 	CategoryID   *string   `json:"category_id,omitempty"`
 	ProjectID    *string   `json:"project_id,omitempty"`
+	AccountRef   *string   `json:"account_ref,omitempty"` // Connected provider account the domains were synced from
+	AutoRenew    *bool     `json:"auto_renew,omitempty"`
 	Limit        int       `json:"limit,omitempty"`
 	Offset       int       `json:"offset,omitempty"`
 	IncludeHidden bool     `json:"include_hidden,omitempty"` // Include domains with visible=false
 	OnlyHidden   bool      `json:"only_hidden,omitempty"`    // Return only hidden domains
+	SortBy       string    `json:"sort_by,omitempty"`        // One of DomainSortFields; defaults to created_at
+	SortOrder    string    `json:"sort_order,omitempty"`     // SortAsc or SortDesc
+	Metadata     map[string]string `json:"metadata,omitempty"` // Metadata key/value pairs that must all match
+	Scope        *AccessScope `json:"-"` // Limits results to a user's access scope; never taken from saved views
 }
 
+// Domain list sort orders
+const (
+	SortAsc  = "asc"
+	SortDesc = "desc"
+)
+
+// DomainSortFields lists the fields domain lists can be sorted by. Repositories interpolate
+// the field into ORDER BY, so only names from this list may ever reach a query.
+var DomainSortFields = []string{"name", "expires_at", "provider", "created_at", "renewal_price"}
+
 // Category represents a domain categorization
 type Category struct {
 	ID          string    `json:"id" db:"id"`
@@ -87,29 +117,54 @@ type Project struct {
 	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
 }
 
+// CategoryWithCount is a category annotated with the number of visible domains assigned to it
+type CategoryWithCount struct {
+	Category
+	DomainCount int `json:"domain_count" db:"domain_count"`
+}
+
+// ProjectWithCount is a project annotated with the number of visible domains assigned to it
+type ProjectWithCount struct {
+	Project
+	DomainCount int `json:"domain_count" db:"domain_count"`
+}
+
 // CredentialsMap is a custom type for handling JSON marshaling/unmarshaling of credentials
 type CredentialsMap map[string]string
 
-// Value implements the driver.Valuer interface for database storage
+// Value implements the driver.Valuer interface for database storage.
+// Credentials are envelope-encrypted when a master key is configured.
 func (c CredentialsMap) Value() (driver.Value, error) {
-	return json.Marshal(c)
+	plaintext, err := json.Marshal(c)
+	if err != nil {
+		return nil, err
+	}
+	return encryptCredentials(plaintext)
 }
 
-// Scan implements the sql.Scanner interface for database retrieval
+// Scan implements the sql.Scanner interface for database retrieval.
+// Both encrypted envelopes and legacy plaintext rows are accepted.
 func (c *CredentialsMap) Scan(value interface{}) error {
 	if value == nil {
 		*c = make(CredentialsMap)
 		return nil
 	}
 
+	var raw []byte
 	switch v := value.(type) {
 	case []byte:
-		return json.Unmarshal(v, c)
+		raw = v
 	case string:
-		return json.Unmarshal([]byte(v), c)
+		raw = []byte(v)
 	default:
 		return fmt.Errorf("cannot scan %T into CredentialsMap", value)
 	}
+
+	plaintext, err := decryptCredentials(raw)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(plaintext, c)
 }
 
 // TagsSlice is a custom type for handling JSON marshaling/unmarshaling of tags
@@ -137,6 +192,60 @@ func (t *TagsSlice) Scan(value interface{}) error {
 	}
 }
 
+// Metadata limits, keeping custom attributes small enough to return with every domain
+const (
+	MaxMetadataKeys        = 50
+	MaxMetadataValueLength = 1024
+)
+
+var metadataKeyPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,64}$`)
+
+// MetadataMap holds deployment-specific domain attributes such as a cost center, owner
+// email or ticket link, stored as JSON so new attributes need no schema change
+type MetadataMap map[string]string
+
+// Value implements the driver.Valuer interface for database storage
+func (m MetadataMap) Value() (driver.Value, error) {
+	if m == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(m)
+}
+
+// Scan implements the sql.Scanner interface for database retrieval
+func (m *MetadataMap) Scan(value interface{}) error {
+	if value == nil {
+		*m = make(MetadataMap)
+		return nil
+	}
+
+	switch v := value.(type) {
+	case []byte:
+		return json.Unmarshal(v, m)
+	case string:
+		return json.Unmarshal([]byte(v), m)
+	default:
+		return fmt.Errorf("cannot scan %T into MetadataMap", value)
+	}
+}
+
+// Validate checks metadata keys are short identifiers usable as metadata.<key> query
+// parameters, and that the number of keys and length of values stay within limits
+func (m MetadataMap) Validate() error {
+	if len(m) > MaxMetadataKeys {
+		return fmt.Errorf("%w: at most %d keys allowed", ErrInvalidMetadata, MaxMetadataKeys)
+	}
+	for key, value := range m {
+		if !metadataKeyPattern.MatchString(key) {
+			return fmt.Errorf("%w: key %q must be 1-64 letters, digits, '_' or '-'", ErrInvalidMetadata, key)
+		}
+		if len(value) > MaxMetadataValueLength {
+			return fmt.Errorf("%w: value of %q exceeds %d characters", ErrInvalidMetadata, key, MaxMetadataValueLength)
+		}
+	}
+	return nil
+}
+
 // ProviderCredentials stores encrypted API credentials for domain providers
 type ProviderCredentials struct {
 	ID              string         `json:"id" db:"id"`
@@ -159,6 +268,7 @@ type ProviderInfo struct {
 	Description  string                    `json:"description"`   // Provider description
 	Fields       []ProviderFieldInfo      `json:"fields"`        // Required credential fields
 	DocumentationURL string               `json:"documentation_url,omitempty"` // Setup guide URL
+	UnsupportedOperations []string        `json:"unsupported_operations,omitempty"` // Operations the integration can't perform (auto_renew, contacts, availability, status)
 }
 
 // ProviderFieldInfo describes a credential field
@@ -189,6 +299,7 @@ type ProviderConnectionResponse struct {
 	ProviderID   string `json:"provider_id,omitempty"`   // ID if successful
 	DomainsFound int    `json:"domains_found,omitempty"` // Number of domains found during test
 	SyncStarted  bool   `json:"sync_started,omitempty"`  // Whether initial sync was started
+	SyncJobID    string `json:"sync_job_id,omitempty"`   // Background job running the initial sync
 }
 
 // DomainSearchRequest represents a domain availability search request
@@ -251,6 +362,7 @@ type DomainPurchaseResponse struct {
 	TotalCost      float64                 `json:"total_cost,omitempty"`
 	Currency       string                  `json:"currency,omitempty"`
 	TransactionID  string                  `json:"transaction_id,omitempty"`
+	Provider       string                  `json:"provider,omitempty"` // Registrar the domains were bought through
 }
 
 // PurchasedDomainInfo represents information about a successfully purchased domain
@@ -281,6 +393,8 @@ type WebsiteStatusResult struct {
 	ResponseTime     int64     `json:"response_time_ms"`
 	SSLStatus        string    `json:"ssl_status,omitempty"`
 	RedirectURL      string    `json:"redirect_url,omitempty"`
+	FinalStatus      int       `json:"final_status"`        // Status code after following redirects
+	FinalURL         string    `json:"final_url,omitempty"` // URL reached after following redirects
 	LastChecked      time.Time `json:"last_checked"`
 	Error            string    `json:"error,omitempty"`
 }
@@ -302,12 +416,29 @@ type User struct {
 	Email        string    `json:"email" db:"email"`
 	PasswordHash string    `json:"-" db:"password_hash"` // Never expose in JSON
 	Role         string    `json:"role" db:"role"`
+	Scope        AccessScope `json:"scope" db:"access_scope"` // Domains a non-admin may reach; empty for all
 	Enabled      bool      `json:"enabled" db:"enabled"`
 	LastLogin    *time.Time `json:"last_login,omitempty" db:"last_login"`
 	CreatedAt    time.Time `json:"created_at" db:"created_at"`
 	UpdatedAt    time.Time `json:"updated_at" db:"updated_at"`
 }
 
+// User roles
+const (
+	RoleAdmin  = "admin"  // Full access, including users and credentials
+	RoleEditor = "editor" // Can manage domains and DNS
+	RoleViewer = "viewer" // Read-only access
+)
+
+// IsValidRole reports whether role is one of the known user roles
+func IsValidRole(role string) bool {
+	switch role {
+	case RoleAdmin, RoleEditor, RoleViewer:
+		return true
+	}
+	return false
+}
+
 // Session represents a user session
 type Session struct {
 	ID        string    `json:"id" db:"id"`
@@ -323,6 +454,23 @@ type LoginRequest struct {
 	Password string `json:"password" binding:"required"`
 }
 
+// ForgotPasswordRequest starts a password reset for a username or email address
+type ForgotPasswordRequest struct {
+	Username string `json:"username"`
+	Email    string `json:"email"`
+}
+
+// ResetPasswordRequest completes a password reset with the emailed token
+type ResetPasswordRequest struct {
+	Token       string `json:"token" binding:"required"`
+	NewPassword string `json:"new_password" binding:"required"`
+}
+
+// SetUserRoleRequest changes a user's role
+type SetUserRoleRequest struct {
+	Role string `json:"role" binding:"required"`
+}
+
 // LoginResponse represents a successful login
 type LoginResponse struct {
 	Token     string `json:"token"`
@@ -343,6 +491,197 @@ type DNSRecord struct {
 	Port     *int   `json:"port,omitempty" db:"port"`         // For SRV records
 	CreatedAt time.Time `json:"created_at" db:"created_at"`
 	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+
+	// SyncStatus compares a stored record with the provider's records as last fetched; empty
+	// when the domain's provider records have never been fetched
+	SyncStatus string `json:"sync_status,omitempty" db:"-"`
+}
+
+// DNS record sync states
+const (
+	DNSSyncSynced      = "synced"       // The provider had this record when last fetched
+	DNSSyncPendingPush = "pending_push" // A local edit the provider doesn't have yet
+)
+
+// DNSRecordFilter selects a page of a domain's DNS records
+type DNSRecordFilter struct {
+	Types  []string // Upper-case record types to include; every type when empty
+	Limit  int      // 0 returns every matching record
+	Offset int
+}
+
+// Matches reports whether record is of one of the filter's types
+func (f DNSRecordFilter) Matches(record DNSRecord) bool {
+	if len(f.Types) == 0 {
+		return true
+	}
+	for _, t := range f.Types {
+		if strings.EqualFold(t, record.Type) {
+			return true
+		}
+	}
+	return false
+}
+
+// Page applies the filter to records already in memory, such as ones just fetched from a
+// provider, keeping their order. It returns the page and how many records matched in all.
+func (f DNSRecordFilter) Page(records []DNSRecord) ([]DNSRecord, int) {
+	matched := make([]DNSRecord, 0, len(records))
+	for _, record := range records {
+		if f.Matches(record) {
+			matched = append(matched, record)
+		}
+	}
+	total := len(matched)
+	start := f.Offset
+	if start > total {
+		start = total
+	}
+	end := total
+	if f.Limit > 0 && start+f.Limit < total {
+		end = start + f.Limit
+	}
+	return matched[start:end], total
+}
+
+// Total returns how many records match the filter given a domain's record counts by type
+func (f DNSRecordFilter) Total(countsByType map[string]int) int {
+	total := 0
+	for recordType, count := range countsByType {
+		if f.Matches(DNSRecord{Type: recordType}) {
+			total += count
+		}
+	}
+	return total
+}
+
+// CountDNSRecordsByType counts records by their type
+func CountDNSRecordsByType(records []DNSRecord) map[string]int {
+	counts := make(map[string]int)
+	for _, record := range records {
+		counts[record.Type]++
+	}
+	return counts
+}
+
+// DNSProviderState is the set of records a provider returned for a domain the last time
+// they were fetched
+type DNSProviderState struct {
+	DomainID  string        `json:"domain_id" db:"domain_id"`
+	Provider  string        `json:"provider" db:"provider"`
+	Records   DNSRecordList `json:"records" db:"records"`
+	FetchedAt time.Time     `json:"fetched_at" db:"fetched_at"`
+}
+
+// DNSSyncResult is the outcome of the last time a domain sync fetched a domain's DNS records
+// into the local store
+type DNSSyncResult struct {
+	DomainID    string    `json:"domain_id" db:"domain_id"`
+	Provider    string    `json:"provider" db:"provider"`
+	Success     bool      `json:"success" db:"success"`
+	RecordCount int       `json:"record_count" db:"record_count"`
+	Error       string    `json:"error,omitempty" db:"error"`
+	SyncedAt    time.Time `json:"synced_at" db:"synced_at"`
+}
+
+// DNSRecordList is a list of DNS records stored as JSON
+type DNSRecordList []DNSRecord
+
+// Value implements the driver.Valuer interface for database storage
+func (l DNSRecordList) Value() (driver.Value, error) {
+	if l == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(l)
+}
+
+// Scan implements the sql.Scanner interface for database retrieval
+func (l *DNSRecordList) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*l = DNSRecordList{}
+		return nil
+	case []byte:
+		return json.Unmarshal(v, l)
+	case string:
+		return json.Unmarshal([]byte(v), l)
+	default:
+		return fmt.Errorf("cannot scan %T into DNSRecordList", value)
+	}
+}
+
+// DNS change history actions
+const (
+	DNSChangeCreate = "create"
+	DNSChangeUpdate = "update"
+	DNSChangeDelete = "delete"
+)
+
+// DNSRecordHistory is one entry in a domain's DNS change log
+type DNSRecordHistory struct {
+	ID        string             `json:"id" db:"id"`
+	DomainID  string             `json:"domain_id" db:"domain_id"`
+	RecordID  string             `json:"record_id" db:"record_id"`
+	Action    string             `json:"action" db:"action"`                 // create, update, delete
+	Before    *DNSRecordSnapshot `json:"before,omitempty" db:"before_value"` // Nil for creates
+	After     *DNSRecordSnapshot `json:"after,omitempty" db:"after_value"`   // Nil for deletes
+	ChangedBy string             `json:"changed_by" db:"changed_by"`         // Username, API caller or "system"
+	ChangedAt time.Time          `json:"changed_at" db:"changed_at"`
+}
+
+// DNSRecordSnapshot is a DNS record stored as JSON in the change history
+type DNSRecordSnapshot struct {
+	DNSRecord
+}
+
+// Value implements the driver.Valuer interface for database storage
+func (s DNSRecordSnapshot) Value() (driver.Value, error) {
+	return json.Marshal(s)
+}
+
+// Scan implements the sql.Scanner interface for database retrieval
+func (s *DNSRecordSnapshot) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case []byte:
+		return json.Unmarshal(v, s)
+	case string:
+		return json.Unmarshal([]byte(v), s)
+	default:
+		return fmt.Errorf("cannot scan %T into DNSRecordSnapshot", value)
+	}
+}
+
+// NameserverChange records a change to a domain's apex NS records seen by the DNS refresh.
+// An unexpected change can mean the domain has been hijacked.
+type NameserverChange struct {
+	ID         string      `json:"id" db:"id"`
+	DomainID   string      `json:"domain_id" db:"domain_id"`
+	Previous   Nameservers `json:"previous" db:"previous_nameservers"`
+	Current    Nameservers `json:"current" db:"current_nameservers"`
+	DetectedAt time.Time   `json:"detected_at" db:"detected_at"`
+}
+
+// Nameservers is a sorted list of nameserver hostnames stored as JSON
+type Nameservers []string
+
+// Value implements the driver.Valuer interface for database storage
+func (n Nameservers) Value() (driver.Value, error) {
+	return json.Marshal(n)
+}
+
+// Scan implements the sql.Scanner interface for database retrieval
+func (n *Nameservers) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*n = Nameservers{}
+		return nil
+	case []byte:
+		return json.Unmarshal(v, n)
+	case string:
+		return json.Unmarshal([]byte(v), n)
+	default:
+		return fmt.Errorf("cannot scan %T into Nameservers", value)
+	}
 }
 
 
@@ -352,6 +691,34 @@ type DomainDecommissionRequest struct {
 	StopAutoRenew bool `json:"stop_auto_renew"`
 	TransferOut   bool `json:"transfer_out"`
 	DeleteDNS     bool `json:"delete_dns"`
+	DryRun        bool `json:"dry_run"` // Report what would change without writing anything
+}
+
+// DomainDecommissionChange describes what decommissioning changes on one domain
+type DomainDecommissionChange struct {
+	DomainID           string `json:"domain_id"`
+	DomainName         string `json:"domain_name"`
+	AutoRenewBefore    bool   `json:"auto_renew_before"`
+	AutoRenewAfter     bool   `json:"auto_renew_after"`
+	StatusBefore       string `json:"status_before"`
+	StatusAfter        string `json:"status_after"`
+	DNSRecordsToDelete int    `json:"dns_records_to_delete"`
+}
+
+// DomainVisibilityRequest hides or restores several domains at once
+type DomainVisibilityRequest struct {
+	DomainIDs     []string `json:"domain_ids" binding:"required"`
+	Visible       *bool    `json:"visible" binding:"required"`
+	PauseMonitors bool     `json:"pause_monitors"` // When hiding, also pause the domains' UptimeRobot monitors
+}
+
+// DomainVisibilityResult is the outcome of a bulk visibility change for one domain
+type DomainVisibilityResult struct {
+	DomainID      string `json:"domain_id"`
+	DomainName    string `json:"domain_name,omitempty"`
+	Success       bool   `json:"success"`
+	MonitorPaused bool   `json:"monitor_paused,omitempty"`
+	Error         string `json:"error,omitempty"`
 }
 
 // BulkSyncRequest represents a manual bulk sync request
@@ -390,6 +757,13 @@ type UptimeRobotSyncResponse struct {
 	MonitorsUpdated int                   `json:"monitors_updated"`
 	MonitorsFailed  int                   `json:"monitors_failed"`
 	Results       []DomainMonitorResult   `json:"results,omitempty"`
+	SLABreaches   []SLABreach             `json:"sla_breaches"` // Synced domains over their response-time SLA
+}
+
+// BulkMonitorPauseRequest pauses or resumes the UptimeRobot monitors of several domains, each
+// given by ID or name
+type BulkMonitorPauseRequest struct {
+	DomainIDs []string `json:"domain_ids" binding:"required"`
 }
 
 // DomainMonitorResult represents the result of monitoring setup for a domain
@@ -405,15 +779,38 @@ type DomainMonitorResult struct {
 	Error         string  `json:"error,omitempty"`
 }
 
-// Validate checks if domain data is valid
+// Validate checks if domain data is valid.
+// It also normalizes the name so the same domain from different sources compares equal.
 func (d *Domain) Validate() error {
+	d.Name = NormalizeDomainName(d.Name)
 	if d.Name == "" {
 		return ErrInvalidDomainName
 	}
 	if d.Provider == "" {
 		return ErrInvalidProvider
 	}
-	return nil
+	return d.ValidateAnnotations()
+}
+
+// ValidateAnnotations checks the user-supplied tags and metadata, normalizing the tags
+func (d *Domain) ValidateAnnotations() error {
+	if d.Tags != nil {
+		if err := d.Tags.Validate(); err != nil {
+			return err
+		}
+	}
+	return d.Metadata.Validate()
+}
+
+// NormalizeDomainName lowercases a domain name and strips surrounding space and the trailing root dot
+func NormalizeDomainName(name string) string {
+	return strings.TrimSuffix(strings.ToLower(strings.TrimSpace(name)), ".")
+}
+
+// DuplicateKey returns the name used to group near-duplicate domains: the normalized name
+// without a leading "www."
+func DuplicateKey(name string) string {
+	return strings.TrimPrefix(NormalizeDomainName(name), "www.")
 }
 
 // IsExpiringSoon checks if domain expires within the given duration
@@ -430,19 +827,74 @@ func (d *Domain) DaysUntilExpiration() int {
 	return int(duration.Hours() / 24)
 }
 
-// Validate checks if category data is valid
+// AgeDays returns how many days ago the domain was registered, and false when its
+// registration date isn't known
+func (d *Domain) AgeDays(now time.Time) (float64, bool) {
+	if d.RegisteredAt == nil {
+		return 0, false
+	}
+	return now.Sub(*d.RegisteredAt).Hours() / 24, true
+}
+
+// ValidateSort normalizes the sort field and order and checks them against DomainSortFields.
+// Without a field the list is sorted newest first; an explicit field defaults to ascending.
+func (f *DomainFilter) ValidateSort() error {
+	f.SortBy = strings.ToLower(strings.TrimSpace(f.SortBy))
+	f.SortOrder = strings.ToLower(strings.TrimSpace(f.SortOrder))
+
+	if f.SortBy == "" {
+		f.SortBy = "created_at"
+		if f.SortOrder == "" {
+			f.SortOrder = SortDesc
+		}
+	}
+
+	known := false
+	for _, field := range DomainSortFields {
+		if f.SortBy == field {
+			known = true
+			break
+		}
+	}
+	if !known {
+		return fmt.Errorf("%w: unsupported sort field %q", ErrInvalidSort, f.SortBy)
+	}
+
+	switch f.SortOrder {
+	case "":
+		f.SortOrder = SortAsc
+	case SortAsc, SortDesc:
+	default:
+		return fmt.Errorf("%w: order must be %q or %q", ErrInvalidSort, SortAsc, SortDesc)
+	}
+	return nil
+}
+
+// Validate trims the category's name and description and checks their length and content,
+// and checks its color is a hex code, defaulting to DefaultCategoryColor
 func (c *Category) Validate() error {
-	if c.Name == "" {
-		return ErrInvalidDomainName // Reuse existing error for now
+	if err := sanitizeNameAndDescription(&c.Name, &c.Description, ErrInvalidCategory); err != nil {
+		return err
+	}
+	color, err := NormalizeColor(c.Color, DefaultCategoryColor)
+	if err != nil {
+		return fmt.Errorf("%w: color %v", ErrInvalidCategory, err)
 	}
+	c.Color = color
 	return nil
 }
 
-// Validate checks if project data is valid
+// Validate trims the project's name and description and checks their length and content,
+// and checks its color is a hex code, defaulting to DefaultProjectColor
 func (p *Project) Validate() error {
-	if p.Name == "" {
-		return ErrInvalidDomainName // Reuse existing error for now
+	if err := sanitizeNameAndDescription(&p.Name, &p.Description, ErrInvalidProject); err != nil {
+		return err
+	}
+	color, err := NormalizeColor(p.Color, DefaultProjectColor)
+	if err != nil {
+		return fmt.Errorf("%w: color %v", ErrInvalidProject, err)
 	}
+	p.Color = color
 	return nil
 }
 
@@ -1,6 +1,9 @@
 package types
 
 import (
+	"errors"
+	"fmt"
+	"strings"
 	"testing"
 	"time"
 )
@@ -55,6 +58,20 @@ func TestDomain_Validate(t *testing.T) {
 	}
 }
 
+func TestDomain_ValidateNormalizesName(t *testing.T) {
+	domain := Domain{Name: " Example.COM. ", Provider: "godaddy"}
+	if err := domain.Validate(); err != nil {
+		t.Fatalf("Domain.Validate() error = %v", err)
+	}
+	if domain.Name != "example.com" {
+		t.Errorf("Expected normalized name example.com, got %q", domain.Name)
+	}
+
+	if got := DuplicateKey("WWW.Example.com."); got != "example.com" {
+		t.Errorf("DuplicateKey() = %q, want example.com", got)
+	}
+}
+
 func TestDomain_IsExpiringSoon(t *testing.T) {
 	now := time.Now()
 	
@@ -157,6 +174,21 @@ func TestDomain_DaysUntilExpiration(t *testing.T) {
 	}
 }
 
+func TestDomain_AgeDays(t *testing.T) {
+	now := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	registered := now.AddDate(0, 0, -400)
+
+	// CreatedAt is when the domain was first stored and doesn't count towards its age
+	domain := Domain{CreatedAt: now.AddDate(0, 0, -10)}
+	if _, ok := domain.AgeDays(now); ok {
+		t.Error("AgeDays() reported an age without a registration date")
+	}
+	domain.RegisteredAt = &registered
+	if age, ok := domain.AgeDays(now); !ok || age != 400 {
+		t.Errorf("AgeDays() = %v, %v, want 400, true", age, ok)
+	}
+}
+
 func TestDomainFilter_Validation(t *testing.T) {
 	// Test that DomainFilter struct can be created and used
 	now := time.Now()
@@ -185,6 +217,114 @@ func TestDomainFilter_Validation(t *testing.T) {
 	}
 }
 
+func TestDomainFilter_ValidateSort(t *testing.T) {
+	tests := []struct {
+		name      string
+		sortBy    string
+		order     string
+		wantBy    string
+		wantOrder string
+		wantErr   bool
+	}{
+		{name: "default is newest first", wantBy: "created_at", wantOrder: SortDesc},
+		{name: "field defaults to ascending", sortBy: "expires_at", wantBy: "expires_at", wantOrder: SortAsc},
+		{name: "case insensitive", sortBy: "Renewal_Price", order: "DESC", wantBy: "renewal_price", wantOrder: SortDesc},
+		{name: "unknown field", sortBy: "name; DROP TABLE domains", wantErr: true},
+		{name: "unknown order", sortBy: "name", order: "sideways", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter := DomainFilter{SortBy: tt.sortBy, SortOrder: tt.order}
+			err := filter.ValidateSort()
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidSort) {
+					t.Errorf("ValidateSort() error = %v, want ErrInvalidSort", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ValidateSort() unexpected error: %v", err)
+			}
+			if filter.SortBy != tt.wantBy || filter.SortOrder != tt.wantOrder {
+				t.Errorf("ValidateSort() = %s %s, want %s %s", filter.SortBy, filter.SortOrder, tt.wantBy, tt.wantOrder)
+			}
+		})
+	}
+}
+
+func TestMetadataMap_Validate(t *testing.T) {
+	tooMany := MetadataMap{}
+	for i := 0; i <= MaxMetadataKeys; i++ {
+		tooMany[fmt.Sprintf("key_%d", i)] = "x"
+	}
+
+	tests := []struct {
+		name     string
+		metadata MetadataMap
+		wantErr  bool
+	}{
+		{name: "nil", metadata: nil},
+		{name: "valid", metadata: MetadataMap{"cost_center": "CC-1042", "owner-email": "ops@example.com"}},
+		{name: "key with dot", metadata: MetadataMap{"owner.email": "ops@example.com"}, wantErr: true},
+		{name: "empty key", metadata: MetadataMap{"": "x"}, wantErr: true},
+		{name: "value too long", metadata: MetadataMap{"ticket": strings.Repeat("x", MaxMetadataValueLength+1)}, wantErr: true},
+		{name: "too many keys", metadata: tooMany, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.metadata.Validate()
+			if tt.wantErr && !errors.Is(err, ErrInvalidMetadata) {
+				t.Errorf("Validate() error = %v, want ErrInvalidMetadata", err)
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("Validate() unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestMetadataMap_ValueScan(t *testing.T) {
+	value, err := MetadataMap(nil).Value()
+	if err != nil || string(value.([]byte)) != "{}" {
+		t.Fatalf("Value() of nil = %v, %v; want {}", value, err)
+	}
+
+	var scanned MetadataMap
+	if err := scanned.Scan([]byte(`{"cost_center":"CC-1042"}`)); err != nil {
+		t.Fatalf("Scan() error: %v", err)
+	}
+	if scanned["cost_center"] != "CC-1042" {
+		t.Errorf("Scan() = %v, want cost_center CC-1042", scanned)
+	}
+}
+
+func TestDNSRecordFilter_Page(t *testing.T) {
+	records := []DNSRecord{
+		{ID: "1", Type: "A"}, {ID: "2", Type: "MX"}, {ID: "3", Type: "A"},
+		{ID: "4", Type: "TXT"}, {ID: "5", Type: "A"},
+	}
+
+	page, total := DNSRecordFilter{Types: []string{"A"}, Limit: 2, Offset: 1}.Page(records)
+	if total != 3 || len(page) != 2 || page[0].ID != "3" || page[1].ID != "5" {
+		t.Errorf("Page() = %v, %d; want records 3 and 5 of 3", page, total)
+	}
+
+	page, total = DNSRecordFilter{Offset: 10}.Page(records)
+	if total != 5 || len(page) != 0 {
+		t.Errorf("Page() past the end = %v, %d; want no records of 5", page, total)
+	}
+
+	counts := CountDNSRecordsByType(records)
+	if got := (DNSRecordFilter{Types: []string{"a", "TXT"}}).Total(counts); got != 4 {
+		t.Errorf("Total() = %d, want 4", got)
+	}
+	if got := (DNSRecordFilter{}).Total(counts); got != 5 {
+		t.Errorf("Total() without types = %d, want 5", got)
+	}
+}
+
 // Helper function for absolute value
 func abs(x int) int {
 	if x < 0 {
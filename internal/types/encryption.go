@@ -0,0 +1,222 @@
+package types
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Credential encryption errors
+var (
+	ErrInvalidMasterKey      = errors.New("credentials master key must be 32 bytes, base64 or hex encoded")
+	ErrCredentialsKeyMissing = errors.New("no master key available to decrypt credentials")
+)
+
+// credentialsEnvelopeVersion marks blobs written with envelope encryption: a random
+// per-blob data key encrypts the credentials, and the master key encrypts the data key.
+const credentialsEnvelopeVersion = "aes-256-gcm-v1"
+
+// credentialsEnvelope is the JSON stored in place of plaintext credentials. It stays valid
+// JSON so it fits the existing JSONB column.
+type credentialsEnvelope struct {
+	Version    string `json:"_enc"`
+	KeyID      string `json:"kid"`   // Identifies which master key wrapped the data key
+	WrappedKey string `json:"dek"`   // Data key encrypted with the master key (nonce-prefixed)
+	Nonce      string `json:"nonce"` // Nonce for the credentials ciphertext
+	Ciphertext string `json:"ct"`
+}
+
+// credentialKeyring holds the master key used for new writes plus older keys still accepted for reads
+var credentialKeyring struct {
+	mu      sync.RWMutex
+	primary string            // Key ID used for encryption
+	keys    map[string][]byte // Key ID -> master key
+}
+
+// ParseMasterKey decodes a 32-byte master key given as base64 or hex
+func ParseMasterKey(encoded string) ([]byte, error) {
+	encoded = strings.TrimSpace(encoded)
+	if key, err := base64.StdEncoding.DecodeString(encoded); err == nil && len(key) == 32 {
+		return key, nil
+	}
+	if key, err := hex.DecodeString(encoded); err == nil && len(key) == 32 {
+		return key, nil
+	}
+	return nil, ErrInvalidMasterKey
+}
+
+// SetCredentialsMasterKey enables encryption of CredentialsMap values. New writes use primary;
+// previous keys are kept so rows written before a key rotation can still be read.
+// Passing a nil primary disables encryption.
+func SetCredentialsMasterKey(primary []byte, previous ...[]byte) error {
+	credentialKeyring.mu.Lock()
+	defer credentialKeyring.mu.Unlock()
+
+	credentialKeyring.primary = ""
+	credentialKeyring.keys = make(map[string][]byte)
+	if primary == nil {
+		return nil
+	}
+
+	for _, key := range append([][]byte{primary}, previous...) {
+		if len(key) != 32 {
+			return ErrInvalidMasterKey
+		}
+		credentialKeyring.keys[masterKeyID(key)] = key
+	}
+	credentialKeyring.primary = masterKeyID(primary)
+	return nil
+}
+
+// CredentialsEncryptionEnabled reports whether a master key is configured
+func CredentialsEncryptionEnabled() bool {
+	credentialKeyring.mu.RLock()
+	defer credentialKeyring.mu.RUnlock()
+	return credentialKeyring.primary != ""
+}
+
+// CredentialsNeedEncryption reports whether a stored credentials blob is plaintext or was
+// wrapped with a key other than the current primary, and should be rewritten
+func CredentialsNeedEncryption(raw []byte) bool {
+	credentialKeyring.mu.RLock()
+	primary := credentialKeyring.primary
+	credentialKeyring.mu.RUnlock()
+
+	if primary == "" {
+		return false
+	}
+	envelope, ok := parseCredentialsEnvelope(raw)
+	return !ok || envelope.KeyID != primary
+}
+
+// masterKeyID is a short non-secret fingerprint of a master key
+func masterKeyID(key []byte) string {
+	sum := sha256.Sum256(key)
+	return hex.EncodeToString(sum[:4])
+}
+
+func parseCredentialsEnvelope(raw []byte) (credentialsEnvelope, bool) {
+	var envelope credentialsEnvelope
+	if err := json.Unmarshal(raw, &envelope); err != nil || envelope.Version == "" {
+		return envelope, false
+	}
+	return envelope, true
+}
+
+// sealGCM encrypts plaintext with key and returns nonce || ciphertext
+func sealGCM(key, plaintext []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return gcm.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+// openGCM reverses sealGCM
+func openGCM(key, sealed []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed) < gcm.NonceSize() {
+		return nil, errors.New("ciphertext too short")
+	}
+	return gcm.Open(nil, sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():], nil)
+}
+
+// encryptCredentials wraps plaintext JSON in an envelope using the primary master key.
+// It returns the plaintext unchanged when encryption is disabled.
+func encryptCredentials(plaintext []byte) ([]byte, error) {
+	credentialKeyring.mu.RLock()
+	keyID := credentialKeyring.primary
+	masterKey := credentialKeyring.keys[keyID]
+	credentialKeyring.mu.RUnlock()
+
+	if keyID == "" {
+		return plaintext, nil
+	}
+
+	dataKey := make([]byte, 32)
+	if _, err := rand.Read(dataKey); err != nil {
+		return nil, fmt.Errorf("failed to generate data key: %w", err)
+	}
+
+	sealed, err := sealGCM(dataKey, plaintext)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt credentials: %w", err)
+	}
+	wrapped, err := sealGCM(masterKey, dataKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to wrap data key: %w", err)
+	}
+
+	return json.Marshal(credentialsEnvelope{
+		Version:    credentialsEnvelopeVersion,
+		KeyID:      keyID,
+		WrappedKey: base64.StdEncoding.EncodeToString(wrapped),
+		Nonce:      base64.StdEncoding.EncodeToString(sealed[:12]),
+		Ciphertext: base64.StdEncoding.EncodeToString(sealed[12:]),
+	})
+}
+
+// decryptCredentials returns the plaintext JSON for a stored blob, which may be an envelope
+// or legacy plaintext
+func decryptCredentials(raw []byte) ([]byte, error) {
+	envelope, ok := parseCredentialsEnvelope(raw)
+	if !ok {
+		return raw, nil
+	}
+	if envelope.Version != credentialsEnvelopeVersion {
+		return nil, fmt.Errorf("unsupported credentials encryption %q", envelope.Version)
+	}
+
+	credentialKeyring.mu.RLock()
+	masterKey, found := credentialKeyring.keys[envelope.KeyID]
+	credentialKeyring.mu.RUnlock()
+	if !found {
+		return nil, ErrCredentialsKeyMissing
+	}
+
+	wrapped, err := base64.StdEncoding.DecodeString(envelope.WrappedKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid wrapped key: %w", err)
+	}
+	nonce, err := base64.StdEncoding.DecodeString(envelope.Nonce)
+	if err != nil {
+		return nil, fmt.Errorf("invalid nonce: %w", err)
+	}
+	ciphertext, err := base64.StdEncoding.DecodeString(envelope.Ciphertext)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ciphertext: %w", err)
+	}
+
+	dataKey, err := openGCM(masterKey, wrapped)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unwrap data key: %w", err)
+	}
+	plaintext, err := openGCM(dataKey, append(nonce, ciphertext...))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt credentials: %w", err)
+	}
+	return plaintext, nil
+}
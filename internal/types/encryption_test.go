@@ -0,0 +1,80 @@
+package types
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestCredentialsMap_Encryption(t *testing.T) {
+	defer SetCredentialsMasterKey(nil)
+
+	creds := CredentialsMap{"api_key": "key-123", "api_secret": "secret-456"}
+
+	// Plaintext rows written before encryption was enabled
+	SetCredentialsMasterKey(nil)
+	legacy, err := creds.Value()
+	if err != nil {
+		t.Fatalf("Value() unexpected error: %v", err)
+	}
+
+	oldKey := bytes.Repeat([]byte{1}, 32)
+	newKey := bytes.Repeat([]byte{2}, 32)
+	if err := SetCredentialsMasterKey(oldKey); err != nil {
+		t.Fatalf("SetCredentialsMasterKey() unexpected error: %v", err)
+	}
+
+	if !CredentialsNeedEncryption(legacy.([]byte)) {
+		t.Error("Plaintext credentials should need encryption")
+	}
+
+	encrypted, err := creds.Value()
+	if err != nil {
+		t.Fatalf("Value() unexpected error: %v", err)
+	}
+	if bytes.Contains(encrypted.([]byte), []byte("secret-456")) {
+		t.Error("Encrypted credentials contain plaintext secret")
+	}
+
+	var decoded CredentialsMap
+	if err := decoded.Scan(encrypted); err != nil {
+		t.Fatalf("Scan() unexpected error: %v", err)
+	}
+	if decoded["api_secret"] != "secret-456" {
+		t.Errorf("Expected decrypted secret, got %v", decoded)
+	}
+
+	var fromLegacy CredentialsMap
+	if err := fromLegacy.Scan(legacy); err != nil || fromLegacy["api_key"] != "key-123" {
+		t.Errorf("Scan() of plaintext row = %v, %v", fromLegacy, err)
+	}
+
+	// After rotation the old key still decrypts, but rows should be rewritten
+	if err := SetCredentialsMasterKey(newKey, oldKey); err != nil {
+		t.Fatalf("SetCredentialsMasterKey() unexpected error: %v", err)
+	}
+	if !CredentialsNeedEncryption(encrypted.([]byte)) {
+		t.Error("Credentials wrapped with a retired key should need re-encryption")
+	}
+	decoded = nil
+	if err := decoded.Scan(encrypted); err != nil || decoded["api_key"] != "key-123" {
+		t.Errorf("Scan() with previous key = %v, %v", decoded, err)
+	}
+
+	// Without the old key the row can't be read
+	SetCredentialsMasterKey(newKey)
+	if err := decoded.Scan(encrypted); err != ErrCredentialsKeyMissing {
+		t.Errorf("Expected ErrCredentialsKeyMissing, got %v", err)
+	}
+}
+
+func TestParseMasterKey(t *testing.T) {
+	if _, err := ParseMasterKey("AQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQE="); err != nil {
+		t.Errorf("ParseMasterKey(base64) unexpected error: %v", err)
+	}
+	if _, err := ParseMasterKey("0101010101010101010101010101010101010101010101010101010101010101"); err != nil {
+		t.Errorf("ParseMasterKey(hex) unexpected error: %v", err)
+	}
+	if _, err := ParseMasterKey("too-short"); err != ErrInvalidMasterKey {
+		t.Errorf("Expected ErrInvalidMasterKey, got %v", err)
+	}
+}
@@ -0,0 +1,46 @@
+package types
+
+// Error codes returned in API error responses. They are part of the API contract: clients
+// switch on them, so existing codes must not be renamed.
+const (
+	CodeInvalidRequest      = "INVALID_REQUEST"      // Malformed body or missing parameter
+	CodeValidationFailed    = "VALIDATION_FAILED"    // Well-formed request with invalid values
+	CodeInvalidDomainName   = "INVALID_DOMAIN_NAME"  // Domain name isn't valid
+	CodeInvalidDNSRecord    = "INVALID_DNS_RECORD"   // DNS record or zone rejected
+	CodeUnauthorized        = "UNAUTHORIZED"         // Missing or invalid credentials
+	CodeForbidden           = "FORBIDDEN"            // Authenticated but not allowed
+	CodeAccountLocked       = "ACCOUNT_LOCKED"       // Too many failed logins
+	CodeNotFound            = "NOT_FOUND"            // Resource other than a domain or record not found
+	CodeDomainNotFound      = "DOMAIN_NOT_FOUND"     // Domain not found
+	CodeDNSRecordNotFound   = "DNS_RECORD_NOT_FOUND" // DNS record not found
+	CodeDomainNotVerified   = "DOMAIN_NOT_VERIFIED"  // Operation needs proof of control of the domain
+	CodeConflict            = "CONFLICT"             // Conflicts with the current state
+	CodeSyncInProgress      = "SYNC_IN_PROGRESS"     // Provider is already syncing
+	CodeDomainExists        = "DOMAIN_EXISTS"        // Domain is already tracked
+	CodeRateLimited         = "RATE_LIMITED"         // Too many requests
+	CodeRequestTooLarge     = "REQUEST_TOO_LARGE"    // Request body over the route's size limit
+	CodeTooManyOperations   = "TOO_MANY_OPERATIONS"  // Bulk request names more items than one request may
+	CodeInternal            = "INTERNAL_ERROR"       // Unexpected server error
+	CodeNotImplemented      = "NOT_IMPLEMENTED"      // Endpoint or operation isn't implemented
+	CodeServiceUnavailable  = "SERVICE_UNAVAILABLE"  // Required service isn't configured or running
+	CodeMaintenance         = "MAINTENANCE_MODE"     // Changes are disabled during maintenance
+	CodeUnsupportedProvider = "UNSUPPORTED_PROVIDER" // Provider unknown or not connected
+	CodeProviderUnsupported = "PROVIDER_UNSUPPORTED" // Provider can't perform the operation
+	CodeProviderUnavailable = "PROVIDER_UNAVAILABLE" // Provider or upstream service failed
+	CodeProviderAuthFailed  = "PROVIDER_AUTH_FAILED" // Provider rejected the stored credentials
+	CodeProviderRateLimited = "PROVIDER_RATE_LIMITED"
+	CodeProviderTimeout     = "PROVIDER_TIMEOUT"
+)
+
+// ErrorResponse is the body of every API error response
+type ErrorResponse struct {
+	Code    string      `json:"code"`
+	Message string      `json:"message"`
+	Details interface{} `json:"details,omitempty"`
+	Error   string      `json:"error"` // Same as Message, for clients written before codes existed
+}
+
+// NewErrorResponse creates an error response body; details may be nil
+func NewErrorResponse(code, message string, details interface{}) ErrorResponse {
+	return ErrorResponse{Code: code, Message: message, Details: details, Error: message}
+}
@@ -9,6 +9,16 @@ var (
 	ErrDomainNotFound    = errors.New("domain not found")
 	ErrDomainExists      = errors.New("domain already exists")
 	ErrDNSRecordNotFound = errors.New("DNS record not found")
+	ErrInvalidDNSRecord  = errors.New("invalid DNS record")
+	ErrInvalidSort       = errors.New("invalid sort")
+	ErrInvalidMetadata   = errors.New("invalid metadata")
+	ErrInvalidTags       = errors.New("invalid tags")
+	ErrInvalidCategory   = errors.New("invalid category")
+	ErrInvalidProject    = errors.New("invalid project")
+	ErrCategoryExists    = errors.New("a category with this name already exists")
+	ErrProjectExists     = errors.New("a project with this name already exists")
+	ErrInvalidContact    = errors.New("invalid contact")
+	ErrDomainNotVerified = errors.New("domain ownership not verified")
 )
 
 // Provider errors
@@ -17,6 +27,34 @@ var (
 	ErrProviderAuth       = errors.New("provider authentication failed")
 	ErrProviderRateLimit  = errors.New("provider rate limit exceeded")
 	ErrProviderTimeout    = errors.New("provider request timeout")
+	ErrProviderUnexpectedResponse = errors.New("unexpected response from provider API")
+	ErrProviderCircuitOpen = errors.New("provider temporarily disabled after repeated failures")
+	ErrAutoRenewUnsupported = errors.New("provider does not support auto-renew management")
+	ErrContactsUnsupported  = errors.New("provider does not support contact management")
+	ErrAvailabilityUnsupported = errors.New("provider does not support availability checks")
+	ErrDNSWriteUnsupported  = errors.New("provider does not support DNS updates")
+	ErrUnsupportedRecordType = errors.New("unsupported record type")
+)
+
+// DNS backup errors
+var (
+	ErrDNSBackupNotFound = errors.New("DNS backup not found")
+	ErrDNSBackupFailed   = errors.New("could not back up the provider's zone")
+)
+
+// Secret store errors
+var (
+	ErrSecretNotFound         = errors.New("secret not found")
+	ErrInvalidSecretReference = errors.New("invalid secret reference")
+)
+
+// Authorization errors
+var (
+	ErrInvalidRole  = errors.New("invalid role")
+	ErrInvalidScope = errors.New("invalid access scope")
+	ErrOutOfScope   = errors.New("outside your access scope")
+
+	ErrInvalidResetToken = errors.New("invalid or expired reset token")
 )
 
 // Database errors
@@ -25,6 +63,52 @@ var (
 	ErrDatabaseMigration  = errors.New("database migration failed")
 )
 
+// Settings errors
+var (
+	ErrSettingNotFound = errors.New("setting not found")
+	ErrInvalidSettings = errors.New("invalid settings")
+)
+
+// Webhook errors
+var (
+	ErrWebhookNotFound = errors.New("webhook endpoint not found")
+	ErrInvalidWebhook  = errors.New("invalid webhook endpoint")
+)
+
+// Notification template errors
+var (
+	ErrNotificationTemplateNotFound = errors.New("notification template not found")
+	ErrInvalidNotificationTemplate  = errors.New("invalid notification template")
+)
+
+// Categorization rule errors
+var (
+	ErrCategorizationRuleNotFound = errors.New("categorization rule not found")
+	ErrInvalidCategorizationRule  = errors.New("invalid categorization rule")
+)
+
+// Saved view errors
+var (
+	ErrSavedViewNotFound = errors.New("saved view not found")
+	ErrSavedViewExists   = errors.New("a saved view with this name already exists")
+	ErrInvalidSavedView  = errors.New("invalid saved view")
+)
+
+// Watchlist errors
+var (
+	ErrWatchlistNotFound = errors.New("watchlist entry not found")
+	ErrWatchlistExists   = errors.New("domain is already on the watchlist")
+	ErrInvalidWatchlist  = errors.New("invalid watchlist entry")
+	ErrInvalidAvailabilitySearch = errors.New("invalid availability search")
+)
+
+// Lifecycle errors
+var (
+	ErrShuttingDown = errors.New("service is shutting down")
+	ErrMaintenanceMode = errors.New("maintenance mode is on")
+	ErrSyncInProgress = errors.New("sync already in progress")
+)
+
 // Configuration errors
 var (
 	ErrInvalidConfig = errors.New("invalid configuration")
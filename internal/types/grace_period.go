@@ -0,0 +1,21 @@
+package types
+
+import (
+	"strings"
+	"time"
+)
+
+// DomainStatusGracePeriod marks a domain past its expiry date that its provider still lists as
+// active. The registrar is holding it in a grace or redemption period; unless it's renewed it
+// will be released, and redeeming it later costs far more than a renewal.
+const DomainStatusGracePeriod = "grace_period"
+
+// InGracePeriod reports whether a domain as fetched from its provider has expired but is still
+// listed as active, or was already flagged and still hasn't been renewed
+func InGracePeriod(domain Domain, now time.Time) bool {
+	if domain.ExpiresAt.IsZero() || !domain.ExpiresAt.Before(now) {
+		return false
+	}
+	status := strings.ToLower(domain.Status)
+	return status == "active" || status == DomainStatusGracePeriod
+}
@@ -0,0 +1,27 @@
+package types
+
+import (
+	"testing"
+	"time"
+)
+
+func TestInGracePeriod(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		domain Domain
+		want   bool
+	}{
+		{"expired and active", Domain{Status: "active", ExpiresAt: now.AddDate(0, 0, -3)}, true},
+		{"status case", Domain{Status: "Active", ExpiresAt: now.Add(-time.Hour)}, true},
+		{"already flagged", Domain{Status: DomainStatusGracePeriod, ExpiresAt: now.AddDate(0, 0, -20)}, true},
+		{"not yet expired", Domain{Status: "active", ExpiresAt: now.AddDate(0, 0, 3)}, false},
+		{"reported expired", Domain{Status: "expired", ExpiresAt: now.AddDate(0, 0, -3)}, false},
+		{"no expiry", Domain{Status: "active"}, false},
+	}
+	for _, tt := range tests {
+		if got := InGracePeriod(tt.domain, now); got != tt.want {
+			t.Errorf("%s: InGracePeriod() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,18 @@
+package types
+
+import "time"
+
+// IdempotencyTTL is how long a completed response is replayed for its idempotency key
+const IdempotencyTTL = 24 * time.Hour
+
+// IdempotencyRecord stores the response to a request made with an Idempotency-Key header.
+// A record with StatusCode 0 has been reserved by a request that is still running.
+type IdempotencyRecord struct {
+	Key         string    `json:"key" db:"key"`                   // Hash of the user, route and client key
+	RequestHash string    `json:"request_hash" db:"request_hash"` // Hash of the request body, to detect key reuse
+	StatusCode  int       `json:"status_code" db:"status_code"`
+	ContentType string    `json:"content_type" db:"content_type"`
+	Body        []byte    `json:"-" db:"body"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+	ExpiresAt   time.Time `json:"expires_at" db:"expires_at"`
+}
@@ -0,0 +1,61 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Notification template size limits
+const (
+	MaxTemplateSubjectLength = 500
+	MaxTemplateBodyLength    = 20000
+)
+
+// NotificationTemplate replaces the wording of one event's alerts on one channel. Subject and
+// Body are Go text/template sources rendered against the alert and the domain it's about;
+// Subject becomes the alert title (the email subject, the Slack text) and Body its message.
+type NotificationTemplate struct {
+	Channel   string    `json:"channel" db:"channel"` // email, slack or webhook
+	Event     string    `json:"event" db:"event"`     // Alert type, such as expiring_soon
+	Subject   string    `json:"subject" db:"subject"`
+	Body      string    `json:"body" db:"body"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// NotificationTemplateRequest creates or replaces the template for a channel and event
+type NotificationTemplateRequest struct {
+	Subject string `json:"subject"`
+	Body    string `json:"body" binding:"required"`
+}
+
+// NotificationTemplatePreviewRequest renders a template without saving it. The template is
+// rendered against the domain with DomainID, or a sample domain when it's empty.
+type NotificationTemplatePreviewRequest struct {
+	Channel  string `json:"channel" binding:"required"`
+	Event    string `json:"event" binding:"required"`
+	Subject  string `json:"subject"`
+	Body     string `json:"body" binding:"required"`
+	DomainID string `json:"domain_id"`
+}
+
+// Validate normalizes the channel and event and checks the template sizes. The template syntax
+// and whether the channel and event exist are checked by the notification service.
+func (t *NotificationTemplate) Validate() error {
+	t.Channel = strings.ToLower(strings.TrimSpace(t.Channel))
+	t.Event = strings.ToLower(strings.TrimSpace(t.Event))
+	if t.Channel == "" || t.Event == "" {
+		return fmt.Errorf("%w: channel and event are required", ErrInvalidNotificationTemplate)
+	}
+	if strings.TrimSpace(t.Body) == "" {
+		return fmt.Errorf("%w: body is required", ErrInvalidNotificationTemplate)
+	}
+	if len(t.Subject) > MaxTemplateSubjectLength {
+		return fmt.Errorf("%w: subject is longer than %d bytes", ErrInvalidNotificationTemplate, MaxTemplateSubjectLength)
+	}
+	if len(t.Body) > MaxTemplateBodyLength {
+		return fmt.Errorf("%w: body is longer than %d bytes", ErrInvalidNotificationTemplate, MaxTemplateBodyLength)
+	}
+	return nil
+}
@@ -0,0 +1,30 @@
+package types
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestNotificationTemplate_Validate(t *testing.T) {
+	valid := NotificationTemplate{Channel: " Email ", Event: "Expiring_Soon", Body: "{{.Domain.Name}} expires soon"}
+	if err := valid.Validate(); err != nil {
+		t.Fatalf("Validate() = %v", err)
+	}
+	if valid.Channel != "email" || valid.Event != "expiring_soon" {
+		t.Errorf("Validate() left channel %q and event %q, want them normalized", valid.Channel, valid.Event)
+	}
+
+	tests := map[string]NotificationTemplate{
+		"missing channel": {Event: "expired", Body: "x"},
+		"missing event":   {Channel: "slack", Body: "x"},
+		"blank body":      {Channel: "slack", Event: "expired", Body: "  \n"},
+		"long subject":    {Channel: "slack", Event: "expired", Subject: strings.Repeat("s", MaxTemplateSubjectLength+1), Body: "x"},
+		"long body":       {Channel: "slack", Event: "expired", Body: strings.Repeat("b", MaxTemplateBodyLength+1)},
+	}
+	for name, template := range tests {
+		if err := template.Validate(); !errors.Is(err, ErrInvalidNotificationTemplate) {
+			t.Errorf("%s: Validate() = %v, want ErrInvalidNotificationTemplate", name, err)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package types
+
+import "time"
+
+// ProviderExportVersion is the version of the provider configuration export format
+const ProviderExportVersion = 1
+
+// Sources of an exported provider connection
+const (
+	ProviderSourceConnected = "connected" // Connected through the provider management API
+	ProviderSourceStored    = "stored"    // Stored credentials synced as an account
+)
+
+// ProviderConfigExport is a backup of the provider setup with every secret removed
+type ProviderConfigExport struct {
+	Version    int                   `json:"version"`
+	ExportedAt time.Time             `json:"exported_at"`
+	Providers  []ProviderExportEntry `json:"providers"`
+}
+
+// ProviderExportEntry is one exported provider connection. Connection is in the form accepted
+// when connecting a provider, with every credential value blanked; the values must be entered
+// again before it is submitted to re-create the connection.
+type ProviderExportEntry struct {
+	ID               string                    `json:"id"`
+	Source           string                    `json:"source"` // connected or stored
+	Enabled          bool                      `json:"enabled"`
+	ConnectionStatus string                    `json:"connection_status"`
+	LastSync         *time.Time                `json:"last_sync,omitempty"`
+	LastSyncStatus   string                    `json:"last_sync_status,omitempty"`
+	DomainsCount     int                       `json:"domains_count"`
+	Connection       ProviderConnectionRequest `json:"connection"`
+}
+
+// RedactCredentials returns the credential field names of creds with blank values, so an
+// export shows which secrets must be re-entered without revealing them
+func RedactCredentials(creds map[string]string) map[string]string {
+	redacted := make(map[string]string, len(creds))
+	for field := range creds {
+		redacted[field] = ""
+	}
+	return redacted
+}
@@ -0,0 +1,87 @@
+package types
+
+import (
+	"fmt"
+	"time"
+)
+
+// DNS health outcomes recorded in a renewal readiness check, matching the live DNS health check
+const (
+	DNSHealthPass       = "pass"
+	DNSHealthWarn       = "warn"
+	DNSHealthFail       = "fail"
+	DNSHealthNotChecked = "not_checked"
+)
+
+// RenewalReadiness is the result of checking an expiring domain before renewal: whether its
+// website answers, its certificate is valid and its DNS is sane
+type RenewalReadiness struct {
+	DomainID        string    `json:"domain_id"`
+	DomainName      string    `json:"domain_name"`
+	Provider        string    `json:"provider"`
+	ExpiresAt       time.Time `json:"expires_at"`
+	DaysUntilExpiry int       `json:"days_until_expiry"` // Negative once expired
+	AutoRenew       bool      `json:"auto_renew"`
+
+	Reachable     bool   `json:"reachable"` // Website answered below 400 over HTTP or HTTPS
+	HTTPStatus    int    `json:"http_status"`
+	StatusMessage string `json:"status_message"`
+	SSLStatus     string `json:"ssl_status"` // valid, invalid, unavailable or unverified
+	CertValid     bool   `json:"cert_valid"` // Valid, or not verified by configuration
+
+	DNSStatus   string   `json:"dns_status"` // pass, warn, fail or not_checked
+	DNSHealthy  bool     `json:"dns_healthy"`
+	DNSProblems []string `json:"dns_problems,omitempty"` // Failed DNS health checks
+
+	Ready  bool     `json:"ready"`
+	Issues []string `json:"issues"` // Why the domain isn't ready, empty when it is
+}
+
+// RenewalReadinessReport is the readiness of every domain expiring within Days
+type RenewalReadinessReport struct {
+	Days      int                `json:"days"`
+	Checked   int                `json:"checked"`
+	Ready     int                `json:"ready"`
+	NotReady  int                `json:"not_ready"`
+	Domains   []RenewalReadiness `json:"domains"` // Soonest expiry first
+	CheckedAt time.Time          `json:"checked_at"`
+}
+
+// NewRenewalReadiness starts a readiness result for domain as of now
+func NewRenewalReadiness(domain Domain, now time.Time) RenewalReadiness {
+	return RenewalReadiness{
+		DomainID:        domain.ID,
+		DomainName:      domain.Name,
+		Provider:        domain.Provider,
+		ExpiresAt:       domain.ExpiresAt,
+		DaysUntilExpiry: int(domain.ExpiresAt.Sub(now).Hours() / 24),
+		AutoRenew:       domain.AutoRenew,
+		DNSStatus:       DNSHealthNotChecked,
+	}
+}
+
+// Evaluate derives the health flags from the recorded check results and lists the issues
+// that keep the domain from being ready
+func (r *RenewalReadiness) Evaluate() {
+	r.Reachable = r.HTTPStatus > 0 && r.HTTPStatus < 400
+	r.CertValid = r.SSLStatus == "valid" || r.SSLStatus == "unverified"
+	r.DNSHealthy = r.DNSStatus == DNSHealthPass || r.DNSStatus == DNSHealthWarn
+
+	r.Issues = []string{}
+	if r.DaysUntilExpiry < 0 {
+		r.Issues = append(r.Issues, fmt.Sprintf("Expired %d days ago", -r.DaysUntilExpiry))
+	}
+	if !r.Reachable {
+		r.Issues = append(r.Issues, fmt.Sprintf("Website unreachable: %s", r.StatusMessage))
+	}
+	if !r.CertValid {
+		r.Issues = append(r.Issues, fmt.Sprintf("SSL certificate %s", r.SSLStatus))
+	}
+	switch {
+	case r.DNSStatus == DNSHealthNotChecked:
+		r.Issues = append(r.Issues, "DNS not checked")
+	case !r.DNSHealthy:
+		r.Issues = append(r.Issues, "DNS health check failed")
+	}
+	r.Ready = len(r.Issues) == 0
+}
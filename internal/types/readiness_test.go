@@ -0,0 +1,46 @@
+package types
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRenewalReadiness_Evaluate(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	domain := Domain{ID: "d1", Name: "example.com", ExpiresAt: now.Add(20 * 24 * time.Hour)}
+
+	tests := []struct {
+		name       string
+		httpStatus int
+		sslStatus  string
+		dnsStatus  string
+		expiresAt  time.Time
+		wantReady  bool
+		wantIssues int
+	}{
+		{name: "healthy", httpStatus: 200, sslStatus: "valid", dnsStatus: DNSHealthPass, wantReady: true},
+		{name: "dns warnings are sane", httpStatus: 301, sslStatus: "unverified", dnsStatus: DNSHealthWarn, wantReady: true},
+		{name: "unreachable", httpStatus: 0, sslStatus: "unavailable", dnsStatus: DNSHealthPass, wantIssues: 2},
+		{name: "server error with bad cert", httpStatus: 503, sslStatus: "invalid", dnsStatus: DNSHealthFail, wantIssues: 3},
+		{name: "dns not checked", httpStatus: 200, sslStatus: "valid", dnsStatus: DNSHealthNotChecked, wantIssues: 1},
+		{name: "already expired", httpStatus: 200, sslStatus: "valid", dnsStatus: DNSHealthPass, expiresAt: now.Add(-3 * 24 * time.Hour), wantIssues: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := domain
+			if !tt.expiresAt.IsZero() {
+				d.ExpiresAt = tt.expiresAt
+			}
+			r := NewRenewalReadiness(d, now)
+			r.HTTPStatus = tt.httpStatus
+			r.SSLStatus = tt.sslStatus
+			r.DNSStatus = tt.dnsStatus
+			r.Evaluate()
+
+			if r.Ready != tt.wantReady || len(r.Issues) != tt.wantIssues {
+				t.Errorf("Evaluate() ready = %v with issues %v, want ready = %v with %d issues", r.Ready, r.Issues, tt.wantReady, tt.wantIssues)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package types
+
+import (
+	"sort"
+	"strings"
+)
+
+// Registrar-side domain statuses, using the EPP status codes of RFC 5731 where one exists
+const (
+	RegistrarStatusOK                       = "ok"
+	RegistrarStatusClientHold               = "clientHold"
+	RegistrarStatusServerHold               = "serverHold"
+	RegistrarStatusPendingDelete            = "pendingDelete"
+	RegistrarStatusPendingTransfer          = "pendingTransfer"
+	RegistrarStatusRedemptionPeriod         = "redemptionPeriod"
+	RegistrarStatusClientTransferProhibited = "clientTransferProhibited"
+	RegistrarStatusExpired                  = "expired" // Not an EPP code; reported by registrars for lapsed domains
+)
+
+// concerningRegistrarStatuses are statuses that stop a domain resolving or mean it's about to
+// be lost
+var concerningRegistrarStatuses = map[string]bool{
+	RegistrarStatusClientHold:       true,
+	RegistrarStatusServerHold:       true,
+	RegistrarStatusPendingDelete:    true,
+	RegistrarStatusPendingTransfer:  true,
+	RegistrarStatusRedemptionPeriod: true,
+	RegistrarStatusExpired:          true,
+}
+
+// RegistrarStatusConcern is a domain whose registrar reports a concerning status
+type RegistrarStatusConcern struct {
+	DomainID   string   `json:"domain_id"`
+	DomainName string   `json:"domain_name"`
+	Provider   string   `json:"provider"`
+	Statuses   []string `json:"statuses"` // The concerning statuses only
+}
+
+// JoinRegistrarStatuses formats statuses for storage in Domain.RegistrarStatus, sorted and
+// without duplicates
+func JoinRegistrarStatuses(statuses []string) string {
+	seen := make(map[string]bool, len(statuses))
+	var unique []string
+	for _, s := range statuses {
+		s = strings.TrimSpace(s)
+		if s != "" && !seen[s] {
+			seen[s] = true
+			unique = append(unique, s)
+		}
+	}
+	sort.Strings(unique)
+	return strings.Join(unique, ",")
+}
+
+// RegistrarStatuses splits the stored registrar status into its individual statuses
+func (d *Domain) RegistrarStatuses() []string {
+	if d.RegistrarStatus == nil || *d.RegistrarStatus == "" {
+		return nil
+	}
+	return strings.Split(*d.RegistrarStatus, ",")
+}
+
+// RegistrarStatusConcerns returns the registrar statuses that indicate a problem with the domain
+func (d *Domain) RegistrarStatusConcerns() []string {
+	var concerns []string
+	for _, s := range d.RegistrarStatuses() {
+		if concerningRegistrarStatuses[s] {
+			concerns = append(concerns, s)
+		}
+	}
+	return concerns
+}
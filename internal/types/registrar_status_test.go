@@ -0,0 +1,29 @@
+package types
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestJoinRegistrarStatuses(t *testing.T) {
+	got := JoinRegistrarStatuses([]string{RegistrarStatusClientTransferProhibited, " ok ", "", RegistrarStatusOK})
+	if want := "clientTransferProhibited,ok"; got != want {
+		t.Errorf("JoinRegistrarStatuses() = %q, want %q", got, want)
+	}
+}
+
+func TestDomain_RegistrarStatusConcerns(t *testing.T) {
+	status := JoinRegistrarStatuses([]string{RegistrarStatusClientHold, RegistrarStatusClientTransferProhibited})
+	domain := Domain{RegistrarStatus: &status}
+
+	if got := domain.RegistrarStatuses(); len(got) != 2 {
+		t.Errorf("RegistrarStatuses() = %v, want two statuses", got)
+	}
+	if got, want := domain.RegistrarStatusConcerns(), []string{RegistrarStatusClientHold}; !reflect.DeepEqual(got, want) {
+		t.Errorf("RegistrarStatusConcerns() = %v, want %v", got, want)
+	}
+
+	if concerns := (&Domain{}).RegistrarStatusConcerns(); concerns != nil {
+		t.Errorf("Domain without a registrar status should have no concerns, got %v", concerns)
+	}
+}
@@ -0,0 +1,48 @@
+package types
+
+import "time"
+
+// RegistryExpiryTolerance is how far the registry's expiry may be from the stored one before
+// the domain is flagged; registries and registrars report the same date at different times of day
+const RegistryExpiryTolerance = 24 * time.Hour
+
+// Registry expiry lookup sources
+const (
+	ExpirySourceRDAP  = "rdap"
+	ExpirySourceWHOIS = "whois"
+)
+
+// RegistryExpiry is the expiry a domain's registry reported at the last reconciliation,
+// stored apart from the provider-reported ExpiresAt
+type RegistryExpiry struct {
+	DomainID          string     `json:"domain_id" db:"domain_id"`
+	DomainName        string     `json:"domain_name" db:"domain_name"`
+	RegistryExpiresAt *time.Time `json:"registry_expires_at,omitempty" db:"registry_expires_at"` // Nil until a lookup succeeds
+	RegisteredAt      *time.Time `json:"registered_at,omitempty" db:"-"`                         // Registration date, when the lookup reported one; stored on the domain
+	StoredExpiresAt   time.Time  `json:"stored_expires_at" db:"stored_expires_at"`               // The domain's ExpiresAt when checked
+	Source            string     `json:"source,omitempty" db:"source"`                           // ExpirySourceRDAP or ExpirySourceWHOIS
+	Discrepancy       bool       `json:"discrepancy" db:"discrepancy"`
+	LastError         string     `json:"last_error,omitempty" db:"last_error"`
+	CheckedAt         time.Time  `json:"checked_at" db:"checked_at"`
+}
+
+// Compare sets the stored expiry to compare against and flags a discrepancy when the
+// registry's expiry is more than RegistryExpiryTolerance away from it
+func (e *RegistryExpiry) Compare(stored time.Time) {
+	e.StoredExpiresAt = stored
+	e.Discrepancy = false
+	if e.RegistryExpiresAt == nil {
+		return
+	}
+	diff := e.RegistryExpiresAt.Sub(stored)
+	e.Discrepancy = diff > RegistryExpiryTolerance || diff < -RegistryExpiryTolerance
+}
+
+// DifferenceDays is how many whole days the registry's expiry is after the stored one;
+// negative when it's before
+func (e *RegistryExpiry) DifferenceDays() int {
+	if e.RegistryExpiresAt == nil {
+		return 0
+	}
+	return int(e.RegistryExpiresAt.Sub(e.StoredExpiresAt).Hours() / 24)
+}
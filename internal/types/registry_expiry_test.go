@@ -0,0 +1,34 @@
+package types
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRegistryExpiry_Compare(t *testing.T) {
+	stored := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name        string
+		registry    *time.Time
+		discrepancy bool
+		days        int
+	}{
+		{"not looked up", nil, false, 0},
+		{"same day, other hour", timePtr(stored.Add(20 * time.Hour)), false, 0},
+		{"renewed at the registry", timePtr(stored.AddDate(1, 0, 0)), true, 365},
+		{"expires earlier", timePtr(stored.AddDate(0, 0, -3)), true, -3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expiry := RegistryExpiry{RegistryExpiresAt: tt.registry, Discrepancy: true}
+			expiry.Compare(stored)
+			if expiry.Discrepancy != tt.discrepancy || expiry.DifferenceDays() != tt.days {
+				t.Errorf("Compare() discrepancy = %v, %d days, want %v, %d days", expiry.Discrepancy, expiry.DifferenceDays(), tt.discrepancy, tt.days)
+			}
+		})
+	}
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
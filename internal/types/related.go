@@ -0,0 +1,181 @@
+package types
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"golang.org/x/net/publicsuffix"
+)
+
+// BrandProtectionTLDs are the suffixes suggested as variants of a domain's name
+var BrandProtectionTLDs = []string{"com", "net", "org", "io", "co", "info", "biz", "app", "dev", "ai", "us", "eu", "de", "co.uk"}
+
+// MaxTyposquatCandidates caps the typo variants suggested for a domain
+const MaxTyposquatCandidates = 30
+
+// SharedInfrastructureTypes are the DNS record types compared to find domains on the same
+// infrastructure
+var SharedInfrastructureTypes = []string{"A", "AAAA", "CNAME", "MX"}
+
+// RelatedDomain is a domain suggested for registration or monitoring because it's related to
+// another one
+type RelatedDomain struct {
+	Domain            string              `json:"domain"`
+	Reasons           []string            `json:"reasons"`
+	Owned             bool                `json:"owned"`
+	DomainID          string              `json:"domain_id,omitempty"` // Set when owned
+	Monitored         bool                `json:"monitored,omitempty"` // Owned and linked to an UptimeRobot monitor
+	Availability      *DomainAvailability `json:"availability,omitempty"`
+	AvailabilityError string              `json:"availability_error,omitempty"`
+}
+
+// RelatedDomainsReport groups the suggestions for a domain
+type RelatedDomainsReport struct {
+	DomainID            string          `json:"domain_id"`
+	DomainName          string          `json:"domain_name"`
+	SameInfrastructure  []RelatedDomain `json:"same_infrastructure"`  // Portfolio domains sharing DNS targets
+	TLDVariants         []RelatedDomain `json:"tld_variants"`         // The same name under other suffixes
+	TyposquatCandidates []RelatedDomain `json:"typosquat_candidates"` // Likely misspellings of the name
+	GeneratedAt         time.Time       `json:"generated_at"`
+}
+
+// SplitRegistrable splits a domain into the label registered under its public suffix and the
+// suffix itself, e.g. "shop.example.co.uk" into "example" and "co.uk"
+func SplitRegistrable(name string) (label, suffix string, ok bool) {
+	name = NormalizeDomainName(name)
+	registrable, err := publicsuffix.EffectiveTLDPlusOne(name)
+	if err != nil {
+		return "", "", false
+	}
+	suffix, _ = publicsuffix.PublicSuffix(registrable)
+	return strings.TrimSuffix(registrable, "."+suffix), suffix, true
+}
+
+// TLDVariantNames returns the domain's registered name under each brand protection suffix
+// other than its own
+func TLDVariantNames(name string) []string {
+	label, suffix, ok := SplitRegistrable(name)
+	if !ok {
+		return nil
+	}
+	var names []string
+	for _, tld := range BrandProtectionTLDs {
+		if tld != suffix {
+			names = append(names, label+"."+tld)
+		}
+	}
+	return names
+}
+
+// homoglyphs are characters commonly swapped for ones that look alike
+var homoglyphs = map[string][]string{
+	"o": {"0"}, "0": {"o"}, "l": {"1", "i"}, "i": {"1", "l"}, "1": {"l"},
+	"m": {"rn"}, "rn": {"m"}, "w": {"vv"}, "vv": {"w"}, "e": {"3"}, "s": {"5"},
+}
+
+// TyposquatNames returns likely misspellings of the domain's registered name under its own
+// suffix: a character left out, doubled, swapped with its neighbour or replaced by a look-alike,
+// and hyphens added or dropped. At most MaxTyposquatCandidates are returned, in a stable order.
+func TyposquatNames(name string) []string {
+	label, suffix, ok := SplitRegistrable(name)
+	if !ok {
+		return nil
+	}
+
+	seen := map[string]bool{label: true}
+	var labels []string
+	add := func(candidate string) {
+		if candidate == "" || seen[candidate] || strings.HasPrefix(candidate, "-") || strings.HasSuffix(candidate, "-") || strings.Contains(candidate, "--") {
+			return
+		}
+		seen[candidate] = true
+		labels = append(labels, candidate)
+	}
+
+	for pattern, replacements := range homoglyphs {
+		for i := strings.Index(label, pattern); i >= 0; {
+			for _, r := range replacements {
+				add(label[:i] + r + label[i+len(pattern):])
+			}
+			next := strings.Index(label[i+1:], pattern)
+			if next < 0 {
+				break
+			}
+			i += next + 1
+		}
+	}
+	for i := 0; i < len(label); i++ {
+		add(label[:i] + label[i+1:])                    // omission
+		add(label[:i+1] + label[i:])                    // repetition
+		if i+1 < len(label) && label[i] != label[i+1] { // transposition
+			add(label[:i] + string(label[i+1]) + string(label[i]) + label[i+2:])
+		}
+		if i > 0 && label[i] != '-' && label[i-1] != '-' { // hyphenation
+			add(label[:i] + "-" + label[i:])
+		}
+	}
+
+	// Map iteration is random; order by how close each candidate's length is to the original
+	distance := func(candidate string) int {
+		if len(candidate) < len(label) {
+			return len(label) - len(candidate)
+		}
+		return len(candidate) - len(label)
+	}
+	sort.SliceStable(labels, func(i, j int) bool {
+		di, dj := distance(labels[i]), distance(labels[j])
+		if di != dj {
+			return di < dj
+		}
+		return labels[i] < labels[j]
+	})
+	if len(labels) > MaxTyposquatCandidates {
+		labels = labels[:MaxTyposquatCandidates]
+	}
+
+	names := make([]string, len(labels))
+	for i, l := range labels {
+		names[i] = l + "." + suffix
+	}
+	return names
+}
+
+// SharedInfrastructure returns a reason for each DNS target the two record sets have in
+// common, e.g. "A 192.0.2.1"
+func SharedInfrastructure(records, other []DNSRecord) []string {
+	targets := make(map[string]bool)
+	for _, record := range records {
+		if key, ok := infrastructureKey(record); ok {
+			targets[key] = true
+		}
+	}
+
+	var reasons []string
+	seen := make(map[string]bool)
+	for _, record := range other {
+		key, ok := infrastructureKey(record)
+		if ok && targets[key] && !seen[key] {
+			seen[key] = true
+			reasons = append(reasons, key)
+		}
+	}
+	sort.Strings(reasons)
+	return reasons
+}
+
+// InfrastructureValue normalizes a record value for comparison across domains
+func InfrastructureValue(value string) string {
+	return strings.TrimSuffix(strings.ToLower(strings.TrimSpace(value)), ".")
+}
+
+// infrastructureKey is the type and normalized value of a record that points at infrastructure
+func infrastructureKey(record DNSRecord) (string, bool) {
+	recordType := strings.ToUpper(record.Type)
+	for _, t := range SharedInfrastructureTypes {
+		if t == recordType {
+			return recordType + " " + InfrastructureValue(record.Value), true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,81 @@
+package types
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSplitRegistrable(t *testing.T) {
+	tests := []struct {
+		name, label, suffix string
+	}{
+		{"example.com", "example", "com"},
+		{"Shop.Example.co.uk.", "example", "co.uk"},
+	}
+	for _, tt := range tests {
+		label, suffix, ok := SplitRegistrable(tt.name)
+		if !ok || label != tt.label || suffix != tt.suffix {
+			t.Errorf("SplitRegistrable(%q) = %q, %q, %v", tt.name, label, suffix, ok)
+		}
+	}
+	if _, _, ok := SplitRegistrable("com"); ok {
+		t.Error("Expected a bare suffix not to split")
+	}
+}
+
+func TestTLDVariantNames(t *testing.T) {
+	names := TLDVariantNames("www.acme.io")
+	if len(names) != len(BrandProtectionTLDs)-1 {
+		t.Errorf("Expected every TLD but io, got %v", names)
+	}
+	for _, name := range names {
+		if name == "acme.io" || !strings.HasPrefix(name, "acme.") {
+			t.Errorf("Unexpected variant %q", name)
+		}
+	}
+}
+
+func TestTyposquatNames(t *testing.T) {
+	names := TyposquatNames("google.com")
+	if len(names) == 0 || len(names) > MaxTyposquatCandidates {
+		t.Fatalf("Expected 1 to %d candidates, got %d", MaxTyposquatCandidates, len(names))
+	}
+	found := make(map[string]bool)
+	for _, name := range names {
+		if name == "google.com" {
+			t.Error("Expected the domain itself to be excluded")
+		}
+		if !strings.HasSuffix(name, ".com") {
+			t.Errorf("Expected candidates under the same suffix, got %q", name)
+		}
+		found[name] = true
+	}
+	for _, want := range []string{"g0ogle.com", "googel.com", "gogle.com"} {
+		if !found[want] {
+			t.Errorf("Expected %s among %v", want, names)
+		}
+	}
+	if !reflect.DeepEqual(names, TyposquatNames("google.com")) {
+		t.Error("Expected the same candidates in the same order each time")
+	}
+}
+
+func TestSharedInfrastructure(t *testing.T) {
+	records := []DNSRecord{
+		{Type: "A", Value: "192.0.2.1"},
+		{Type: "MX", Value: "Mail.Example.com."},
+		{Type: "TXT", Value: "v=spf1 -all"},
+	}
+	other := []DNSRecord{
+		{Type: "a", Value: "192.0.2.1"},
+		{Type: "MX", Value: "mail.example.com"},
+		{Type: "TXT", Value: "v=spf1 -all"},
+		{Type: "A", Value: "192.0.2.9"},
+	}
+	got := SharedInfrastructure(records, other)
+	want := []string{"A 192.0.2.1", "MX mail.example.com"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+}
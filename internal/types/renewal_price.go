@@ -0,0 +1,50 @@
+package types
+
+import (
+	"math"
+	"time"
+)
+
+// DefaultRenewalPriceAlertPercent is how far a renewal price must rise, in percent, before a
+// sync raises an alert
+const DefaultRenewalPriceAlertPercent = 10.0
+
+// RenewalPriceChange records a change to a domain's renewal price seen by a sync
+type RenewalPriceChange struct {
+	ID        string    `json:"id" db:"id"`
+	DomainID  string    `json:"domain_id" db:"domain_id"`
+	Provider  string    `json:"provider" db:"provider"`
+	OldPrice  float64   `json:"old_price" db:"old_price"`
+	NewPrice  float64   `json:"new_price" db:"new_price"`
+	ChangedAt time.Time `json:"changed_at" db:"changed_at"`
+}
+
+// DetectRenewalPriceChange returns the change between a domain's stored and synced renewal
+// prices, or nil when they're equal to the cent or either is unknown
+func DetectRenewalPriceChange(previous, current Domain) *RenewalPriceChange {
+	if previous.RenewalPrice == nil || current.RenewalPrice == nil {
+		return nil
+	}
+	oldPrice, newPrice := *previous.RenewalPrice, *current.RenewalPrice
+	if math.Round(oldPrice*100) == math.Round(newPrice*100) {
+		return nil
+	}
+	return &RenewalPriceChange{DomainID: previous.ID, Provider: current.Provider, OldPrice: oldPrice, NewPrice: newPrice}
+}
+
+// PercentChange is the change relative to the old price; positive for an increase. A change
+// from a price of zero counts as 100%.
+func (c RenewalPriceChange) PercentChange() float64 {
+	if c.OldPrice == 0 {
+		if c.NewPrice > 0 {
+			return 100
+		}
+		return 0
+	}
+	return (c.NewPrice - c.OldPrice) / c.OldPrice * 100
+}
+
+// ExceedsIncrease reports whether the change is a price rise of more than percent
+func (c RenewalPriceChange) ExceedsIncrease(percent float64) bool {
+	return c.NewPrice > c.OldPrice && c.PercentChange() > percent
+}
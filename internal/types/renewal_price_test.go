@@ -0,0 +1,34 @@
+package types
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDetectRenewalPriceChange(t *testing.T) {
+	price := func(p float64) *float64 { return &p }
+	previous := Domain{ID: "d1", Name: "example.com", RenewalPrice: price(10.00)}
+
+	if change := DetectRenewalPriceChange(previous, Domain{RenewalPrice: price(10.001)}); change != nil {
+		t.Errorf("Expected no change within a cent, got %+v", change)
+	}
+	if change := DetectRenewalPriceChange(previous, Domain{}); change != nil {
+		t.Errorf("Expected no change when the provider reports no price, got %+v", change)
+	}
+
+	change := DetectRenewalPriceChange(previous, Domain{Provider: "godaddy", RenewalPrice: price(12.50)})
+	if change == nil || change.DomainID != "d1" || change.OldPrice != 10 || change.NewPrice != 12.5 {
+		t.Fatalf("Expected a 10.00 -> 12.50 change, got %+v", change)
+	}
+	if got := change.PercentChange(); math.Abs(got-25) > 1e-9 {
+		t.Errorf("PercentChange() = %v, want 25", got)
+	}
+	if !change.ExceedsIncrease(10) || change.ExceedsIncrease(25) {
+		t.Error("Expected a 25% rise to exceed 10% but not 25%")
+	}
+
+	drop := RenewalPriceChange{OldPrice: 12.5, NewPrice: 10}
+	if drop.ExceedsIncrease(0) {
+		t.Error("Expected a price drop never to exceed an increase threshold")
+	}
+}
@@ -0,0 +1,98 @@
+package types
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// SavedView is a named domain list filter and sort a user saved to re-apply, e.g.
+// "expiring business domains not auto-renewing". Views are private to the user who saved them.
+type SavedView struct {
+	ID        string          `json:"id" db:"id"`
+	UserID    string          `json:"user_id" db:"user_id"`
+	Name      string          `json:"name" db:"name"`
+	Filter    SavedViewFilter `json:"filter" db:"filter"`
+	CreatedAt time.Time       `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time       `json:"updated_at" db:"updated_at"`
+}
+
+// SavedViewFilter is the DomainFilter a saved view applies, stored as JSON
+type SavedViewFilter DomainFilter
+
+// SavedViewRequest creates or updates a saved view
+type SavedViewRequest struct {
+	Name   string       `json:"name"`
+	Filter DomainFilter `json:"filter"`
+}
+
+// Value implements the driver.Valuer interface for database storage
+func (f SavedViewFilter) Value() (driver.Value, error) {
+	return json.Marshal(f)
+}
+
+// Scan implements the sql.Scanner interface for database retrieval
+func (f *SavedViewFilter) Scan(value interface{}) error {
+	if value == nil {
+		*f = SavedViewFilter{}
+		return nil
+	}
+
+	switch v := value.(type) {
+	case []byte:
+		return json.Unmarshal(v, f)
+	case string:
+		return json.Unmarshal([]byte(v), f)
+	default:
+		return fmt.Errorf("cannot scan %T into SavedViewFilter", value)
+	}
+}
+
+// Validate cleans the view's name and checks its filter. The offset isn't kept, so a view
+// always opens on its first page. extraSortFields are sort fields the domain list accepts
+// beyond DomainSortFields, such as computed scores.
+func (v *SavedView) Validate(extraSortFields ...string) error {
+	name, err := SanitizeText(v.Name, MaxNameLength, false)
+	if err != nil {
+		return fmt.Errorf("%w: name %v", ErrInvalidSavedView, err)
+	}
+	if name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidSavedView)
+	}
+	v.Name = name
+
+	filter := DomainFilter(v.Filter)
+	filter.Offset = 0
+	if filter.Limit < 0 {
+		return fmt.Errorf("%w: limit must not be negative", ErrInvalidSavedView)
+	}
+	if err := MetadataMap(filter.Metadata).Validate(); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidSavedView, err)
+	}
+	sortBy := strings.ToLower(strings.TrimSpace(filter.SortBy))
+	if isExtraSortField(sortBy, extraSortFields) {
+		filter.SortBy = sortBy
+		filter.SortOrder = strings.ToLower(strings.TrimSpace(filter.SortOrder))
+		if filter.SortOrder != "" && filter.SortOrder != SortAsc && filter.SortOrder != SortDesc {
+			return fmt.Errorf("%w: order must be %q or %q", ErrInvalidSavedView, SortAsc, SortDesc)
+		}
+	} else if filter.SortBy != "" || filter.SortOrder != "" {
+		if err := filter.ValidateSort(); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidSavedView, err)
+		}
+	}
+	v.Filter = SavedViewFilter(filter)
+	return nil
+}
+
+// isExtraSortField reports whether field is one of extra
+func isExtraSortField(field string, extra []string) bool {
+	for _, f := range extra {
+		if field != "" && field == f {
+			return true
+		}
+	}
+	return false
+}
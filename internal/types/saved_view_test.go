@@ -0,0 +1,62 @@
+package types
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSavedView_Validate(t *testing.T) {
+	autoRenew := false
+	view := SavedView{
+		Name:   "  Expiring, not auto-renewing  ",
+		Filter: SavedViewFilter{AutoRenew: &autoRenew, Offset: 100, SortBy: "Expires_At"},
+	}
+	if err := view.Validate(); err != nil {
+		t.Fatalf("Validate() unexpected error: %v", err)
+	}
+	if view.Name != "Expiring, not auto-renewing" {
+		t.Errorf("Name = %q, want it trimmed", view.Name)
+	}
+	if view.Filter.Offset != 0 {
+		t.Errorf("Offset = %d, want 0", view.Filter.Offset)
+	}
+	if view.Filter.SortBy != "expires_at" || view.Filter.SortOrder != SortAsc {
+		t.Errorf("sort = %s %s, want expires_at asc", view.Filter.SortBy, view.Filter.SortOrder)
+	}
+
+	scored := SavedView{Name: "Least healthy", Filter: SavedViewFilter{SortBy: "health_score", SortOrder: "ASC"}}
+	if err := scored.Validate("health_score"); err != nil {
+		t.Fatalf("Validate() with extra sort field: %v", err)
+	}
+	if scored.Filter.SortBy != "health_score" || scored.Filter.SortOrder != SortAsc {
+		t.Errorf("sort = %s %s, want health_score asc", scored.Filter.SortBy, scored.Filter.SortOrder)
+	}
+
+	invalid := []SavedView{
+		{Name: "   "},
+		{Name: "Bad sort", Filter: SavedViewFilter{SortBy: "health_score"}},
+		{Name: "Bad limit", Filter: SavedViewFilter{Limit: -1}},
+		{Name: "Bad metadata", Filter: SavedViewFilter{Metadata: map[string]string{"a.b": "x"}}},
+	}
+	for _, v := range invalid {
+		if err := v.Validate(); !errors.Is(err, ErrInvalidSavedView) {
+			t.Errorf("Validate(%q) error = %v, want ErrInvalidSavedView", v.Name, err)
+		}
+	}
+}
+
+func TestSavedViewFilter_ValueScan(t *testing.T) {
+	projectID := "project-1"
+	value, err := SavedViewFilter{ProjectID: &projectID, SortBy: "name"}.Value()
+	if err != nil {
+		t.Fatalf("Value() error: %v", err)
+	}
+
+	var scanned SavedViewFilter
+	if err := scanned.Scan(value); err != nil {
+		t.Fatalf("Scan() error: %v", err)
+	}
+	if scanned.ProjectID == nil || *scanned.ProjectID != projectID || scanned.SortBy != "name" {
+		t.Errorf("Scan() = %+v, want project and sort restored", scanned)
+	}
+}
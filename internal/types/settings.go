@@ -0,0 +1,229 @@
+package types
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Keys of the values kept in the settings store
+const (
+	SettingExpiryThresholds = "expiry_thresholds"
+	SettingResponseTimeSLA  = "response_time_sla"
+	SettingRuntime          = "runtime"
+	SettingMaintenance      = "maintenance"
+)
+
+// maxExpiryDays bounds every expiry threshold; registrations run for at most ten years
+const maxExpiryDays = 3650
+
+// ExpiryThresholds are the day windows that decide when a domain counts as expiring
+type ExpiryThresholds struct {
+	ExpiringSoonDays int   `json:"expiring_soon_days"` // Default window of the expiring list and the "expiring soon" status
+	SummaryDays      []int `json:"summary_days"`       // Buckets counted in the summary's expiring_in, ascending
+	ReminderDays     []int `json:"reminder_days"`      // Calendar reminder alarms, in days before expiry
+}
+
+// DefaultExpiryThresholds returns the thresholds used until they are changed in settings
+func DefaultExpiryThresholds() ExpiryThresholds {
+	return ExpiryThresholds{
+		ExpiringSoonDays: 30,
+		SummaryDays:      []int{30, 90, 365},
+		ReminderDays:     []int{30},
+	}
+}
+
+// Validate checks the thresholds are in range and sorts and de-duplicates the day lists
+func (t *ExpiryThresholds) Validate() error {
+	if t.ExpiringSoonDays < 1 || t.ExpiringSoonDays > maxExpiryDays {
+		return fmt.Errorf("%w: expiring_soon_days must be between 1 and %d", ErrInvalidSettings, maxExpiryDays)
+	}
+	if len(t.SummaryDays) == 0 {
+		return fmt.Errorf("%w: summary_days needs at least one threshold", ErrInvalidSettings)
+	}
+
+	var err error
+	if t.SummaryDays, err = normalizeDays("summary_days", t.SummaryDays, 1); err != nil {
+		return err
+	}
+	if t.ReminderDays, err = normalizeDays("reminder_days", t.ReminderDays, 0); err != nil {
+		return err
+	}
+	return nil
+}
+
+// normalizeDays range-checks a list of day counts and returns it sorted without duplicates
+func normalizeDays(field string, days []int, min int) ([]int, error) {
+	seen := make(map[int]bool, len(days))
+	normalized := make([]int, 0, len(days))
+	for _, d := range days {
+		if d < min || d > maxExpiryDays {
+			return nil, fmt.Errorf("%w: %s must be between %d and %d", ErrInvalidSettings, field, min, maxExpiryDays)
+		}
+		if !seen[d] {
+			seen[d] = true
+			normalized = append(normalized, d)
+		}
+	}
+	sort.Ints(normalized)
+	return normalized, nil
+}
+
+// ExpiringInKey names a DomainSummary.ExpiringIn bucket, e.g. "30_days"
+func ExpiringInKey(days int) string {
+	return fmt.Sprintf("%d_days", days)
+}
+
+// maxResponseTimeSLA bounds response-time SLA thresholds; UptimeRobot times out at a minute
+const maxResponseTimeSLA = 60000
+
+// ResponseTimeSLA is the average response time, in milliseconds, a monitored site is expected
+// to stay under. A threshold of 0 means no SLA.
+type ResponseTimeSLA struct {
+	DefaultMS int            `json:"default_ms"` // Applies to every domain without an override
+	Domains   map[string]int `json:"domains"`    // Per-domain overrides by domain name
+}
+
+// SLABreach is a domain whose average response time exceeds its SLA
+type SLABreach struct {
+	DomainID     string `json:"domain_id"`
+	DomainName   string `json:"domain_name"`
+	ResponseTime int    `json:"response_time"`
+	ThresholdMS  int    `json:"threshold_ms"`
+}
+
+// DefaultResponseTimeSLA returns the SLA used until it is changed in settings
+func DefaultResponseTimeSLA() ResponseTimeSLA {
+	return ResponseTimeSLA{DefaultMS: 2000, Domains: map[string]int{}}
+}
+
+// Validate checks the thresholds are in range and normalizes the override domain names
+func (s *ResponseTimeSLA) Validate() error {
+	if s.DefaultMS < 0 || s.DefaultMS > maxResponseTimeSLA {
+		return fmt.Errorf("%w: default_ms must be between 0 and %d", ErrInvalidSettings, maxResponseTimeSLA)
+	}
+
+	domains := make(map[string]int, len(s.Domains))
+	for name, ms := range s.Domains {
+		normalized := NormalizeDomainName(name)
+		if normalized == "" {
+			return fmt.Errorf("%w: domains needs domain names as keys", ErrInvalidSettings)
+		}
+		if ms < 0 || ms > maxResponseTimeSLA {
+			return fmt.Errorf("%w: threshold for %s must be between 0 and %d", ErrInvalidSettings, normalized, maxResponseTimeSLA)
+		}
+		domains[normalized] = ms
+	}
+	s.Domains = domains
+	return nil
+}
+
+// ThresholdFor returns the SLA threshold of a domain, 0 if it has none
+func (s ResponseTimeSLA) ThresholdFor(domainName string) int {
+	if ms, ok := s.Domains[NormalizeDomainName(domainName)]; ok {
+		return ms
+	}
+	return s.DefaultMS
+}
+
+// Breached reports whether a domain's average response time exceeds its SLA threshold
+func (s ResponseTimeSLA) Breached(domain Domain) bool {
+	threshold := s.ThresholdFor(domain.Name)
+	return threshold > 0 && domain.ResponseTime != nil && *domain.ResponseTime > threshold
+}
+
+// Breaches returns the domains whose average response time exceeds their SLA, slowest
+// relative to their threshold first
+func (s ResponseTimeSLA) Breaches(domains []Domain) []SLABreach {
+	breaches := []SLABreach{}
+	for _, domain := range domains {
+		if s.Breached(domain) {
+			breaches = append(breaches, SLABreach{
+				DomainID:     domain.ID,
+				DomainName:   domain.Name,
+				ResponseTime: *domain.ResponseTime,
+				ThresholdMS:  s.ThresholdFor(domain.Name),
+			})
+		}
+	}
+	sort.Slice(breaches, func(i, j int) bool {
+		return breaches[i].ResponseTime*breaches[j].ThresholdMS > breaches[j].ResponseTime*breaches[i].ThresholdMS
+	})
+	return breaches
+}
+
+// Bounds of the runtime settings
+const (
+	maxSyncIntervalMinutes = 7 * 24 * 60
+	maxLoginAttempts       = 100
+	maxLockoutMinutes      = 24 * 60
+)
+
+// RuntimeSettings are the settings that can be changed while the server runs. Until they are
+// saved, the values from the environment are used.
+type RuntimeSettings struct {
+	SyncIntervalMinutes int                         `json:"sync_interval_minutes"` // How often registrar domains are synced
+	SyncRegistrarStatus bool                        `json:"sync_registrar_status"` // Read each domain's registrar status during sync
+	Notifications       NotificationChannelSettings `json:"notifications"`
+	Security            SecurityPolicySettings      `json:"security"`
+}
+
+// NotificationChannelSettings turns alert delivery channels on or off
+type NotificationChannelSettings struct {
+	Email   bool `json:"email"`
+	Slack   bool `json:"slack"`
+	Webhook bool `json:"webhook"` // Configured webhook URLs; stored endpoints are enabled individually
+}
+
+// SecurityPolicySettings is the login lockout policy
+type SecurityPolicySettings struct {
+	MaxLoginAttempts int `json:"max_login_attempts"` // Failed logins that lock an account out
+	LockoutMinutes   int `json:"lockout_minutes"`    // Window failed logins count in, and how long a lock lasts
+}
+
+// Validate checks every setting is in range
+func (s RuntimeSettings) Validate() error {
+	if s.SyncIntervalMinutes < 1 || s.SyncIntervalMinutes > maxSyncIntervalMinutes {
+		return fmt.Errorf("%w: sync_interval_minutes must be between 1 and %d", ErrInvalidSettings, maxSyncIntervalMinutes)
+	}
+	if s.Security.MaxLoginAttempts < 1 || s.Security.MaxLoginAttempts > maxLoginAttempts {
+		return fmt.Errorf("%w: max_login_attempts must be between 1 and %d", ErrInvalidSettings, maxLoginAttempts)
+	}
+	if s.Security.LockoutMinutes < 1 || s.Security.LockoutMinutes > maxLockoutMinutes {
+		return fmt.Errorf("%w: lockout_minutes must be between 1 and %d", ErrInvalidSettings, maxLockoutMinutes)
+	}
+	return nil
+}
+
+// DefaultMaintenanceMessage is returned to rejected changes when maintenance mode has no message
+const DefaultMaintenanceMessage = "DomainVault is in maintenance mode; changes are disabled until it ends"
+
+// maxMaintenanceMessage bounds the message shown to clients during maintenance
+const maxMaintenanceMessage = 500
+
+// MaintenanceMode disables changes while reads are still served, e.g. during a database
+// migration. Background syncs and DNS refreshes pause while it is on.
+type MaintenanceMode struct {
+	Enabled   bool       `json:"enabled"`
+	Message   string     `json:"message,omitempty"`    // Shown to rejected requests, DefaultMaintenanceMessage if empty
+	EnabledAt *time.Time `json:"enabled_at,omitempty"` // When maintenance started
+	EnabledBy string     `json:"enabled_by,omitempty"` // Who turned it on
+}
+
+// Validate checks the message and trims its surrounding whitespace
+func (m *MaintenanceMode) Validate() error {
+	message, err := SanitizeText(m.Message, maxMaintenanceMessage, false)
+	if err != nil {
+		return fmt.Errorf("%w: message: %v", ErrInvalidSettings, err)
+	}
+	m.Message = message
+	return nil
+}
+
+// Notice is the message rejected requests get
+func (m MaintenanceMode) Notice() string {
+	if m.Message == "" {
+		return DefaultMaintenanceMessage
+	}
+	return m.Message
+}
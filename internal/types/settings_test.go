@@ -0,0 +1,133 @@
+package types
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestExpiryThresholds_Validate(t *testing.T) {
+	tests := []struct {
+		name       string
+		thresholds ExpiryThresholds
+		wantErr    bool
+	}{
+		{name: "defaults", thresholds: DefaultExpiryThresholds()},
+		{name: "no reminders", thresholds: ExpiryThresholds{ExpiringSoonDays: 60, SummaryDays: []int{60}}},
+		{name: "zero expiring soon", thresholds: ExpiryThresholds{SummaryDays: []int{30}}, wantErr: true},
+		{name: "no summary buckets", thresholds: ExpiryThresholds{ExpiringSoonDays: 60}, wantErr: true},
+		{name: "zero summary bucket", thresholds: ExpiryThresholds{ExpiringSoonDays: 60, SummaryDays: []int{0}}, wantErr: true},
+		{name: "negative reminder", thresholds: ExpiryThresholds{ExpiringSoonDays: 60, SummaryDays: []int{60}, ReminderDays: []int{-1}}, wantErr: true},
+		{name: "beyond ten years", thresholds: ExpiryThresholds{ExpiringSoonDays: 4000, SummaryDays: []int{60}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.thresholds.Validate()
+			if tt.wantErr && !errors.Is(err, ErrInvalidSettings) {
+				t.Errorf("Validate() error = %v, want ErrInvalidSettings", err)
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("Validate() unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestExpiryThresholds_ValidateNormalizes(t *testing.T) {
+	thresholds := ExpiryThresholds{ExpiringSoonDays: 60, SummaryDays: []int{180, 60, 60}, ReminderDays: []int{7, 60, 0, 7}}
+	if err := thresholds.Validate(); err != nil {
+		t.Fatalf("Validate() error: %v", err)
+	}
+	if want := []int{60, 180}; !reflect.DeepEqual(thresholds.SummaryDays, want) {
+		t.Errorf("SummaryDays = %v, want %v", thresholds.SummaryDays, want)
+	}
+	if want := []int{0, 7, 60}; !reflect.DeepEqual(thresholds.ReminderDays, want) {
+		t.Errorf("ReminderDays = %v, want %v", thresholds.ReminderDays, want)
+	}
+}
+
+func TestResponseTimeSLA(t *testing.T) {
+	sla := ResponseTimeSLA{DefaultMS: 1000, Domains: map[string]int{"Slow.Example.": 5000, "exempt.example": 0}}
+	if err := sla.Validate(); err != nil {
+		t.Fatalf("Validate() error: %v", err)
+	}
+	if sla.ThresholdFor("slow.example") != 5000 || sla.ThresholdFor("other.example") != 1000 {
+		t.Errorf("ThresholdFor() should use the normalized override, then the default: %+v", sla)
+	}
+
+	ms := func(v int) *int { return &v }
+	domains := []Domain{
+		{ID: "1", Name: "fast.example", ResponseTime: ms(800)},
+		{ID: "2", Name: "slow.example", ResponseTime: ms(6000)},
+		{ID: "3", Name: "exempt.example", ResponseTime: ms(9000)},
+		{ID: "4", Name: "unmonitored.example"},
+		{ID: "5", Name: "sluggish.example", ResponseTime: ms(3000)},
+	}
+	breaches := sla.Breaches(domains)
+	if len(breaches) != 2 || breaches[0].DomainID != "5" || breaches[1].DomainID != "2" {
+		t.Errorf("Breaches() = %+v, want sluggish (3x) then slow (1.2x)", breaches)
+	}
+
+	invalid := []ResponseTimeSLA{
+		{DefaultMS: -1},
+		{DefaultMS: maxResponseTimeSLA + 1},
+		{Domains: map[string]int{" ": 1000}},
+		{Domains: map[string]int{"slow.example": -5}},
+	}
+	for _, sla := range invalid {
+		if err := sla.Validate(); !errors.Is(err, ErrInvalidSettings) {
+			t.Errorf("Validate(%+v) error = %v, want ErrInvalidSettings", sla, err)
+		}
+	}
+}
+
+func TestRuntimeSettings_Validate(t *testing.T) {
+	valid := RuntimeSettings{
+		SyncIntervalMinutes: 60,
+		Security:            SecurityPolicySettings{MaxLoginAttempts: 5, LockoutMinutes: 15},
+	}
+	if err := valid.Validate(); err != nil {
+		t.Fatalf("Validate() unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		modify func(*RuntimeSettings)
+	}{
+		{name: "zero sync interval", modify: func(s *RuntimeSettings) { s.SyncIntervalMinutes = 0 }},
+		{name: "sync interval over a week", modify: func(s *RuntimeSettings) { s.SyncIntervalMinutes = 7*24*60 + 1 }},
+		{name: "zero login attempts", modify: func(s *RuntimeSettings) { s.Security.MaxLoginAttempts = 0 }},
+		{name: "zero lockout", modify: func(s *RuntimeSettings) { s.Security.LockoutMinutes = 0 }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings := valid
+			tt.modify(&settings)
+			if err := settings.Validate(); !errors.Is(err, ErrInvalidSettings) {
+				t.Errorf("Validate() error = %v, want ErrInvalidSettings", err)
+			}
+		})
+	}
+}
+
+func TestMaintenanceMode_Validate(t *testing.T) {
+	mode := MaintenanceMode{Enabled: true, Message: "  Migrating the database  "}
+	if err := mode.Validate(); err != nil {
+		t.Fatalf("Validate() unexpected error: %v", err)
+	}
+	if mode.Notice() != "Migrating the database" {
+		t.Errorf("Notice() = %q, want the trimmed message", mode.Notice())
+	}
+	if (MaintenanceMode{Enabled: true}).Notice() != DefaultMaintenanceMessage {
+		t.Error("Notice() without a message should be the default message")
+	}
+
+	for _, message := range []string{strings.Repeat("x", 501), "line\nbreak"} {
+		invalid := MaintenanceMode{Enabled: true, Message: message}
+		if err := invalid.Validate(); !errors.Is(err, ErrInvalidSettings) {
+			t.Errorf("Validate(%.20q) error = %v, want ErrInvalidSettings", message, err)
+		}
+	}
+}
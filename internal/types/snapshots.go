@@ -0,0 +1,90 @@
+package types
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// PortfolioSnapshot records the portfolio's headline figures for one day, giving trend
+// analysis a real baseline instead of one derived from the current domains
+type PortfolioSnapshot struct {
+	ID                 string       `json:"id" db:"id"`
+	SnapshotDate       time.Time    `json:"snapshot_date" db:"snapshot_date"` // UTC midnight; one snapshot per day
+	TotalDomains       int          `json:"total_domains" db:"total_domains"`
+	ActiveDomains      int          `json:"active_domains" db:"active_domains"`
+	ExpiredDomains     int          `json:"expired_domains" db:"expired_domains"`
+	Expiring30         int          `json:"expiring_30" db:"expiring_30"`
+	Expiring90         int          `json:"expiring_90" db:"expiring_90"`
+	TotalRenewalCost   float64      `json:"total_renewal_cost" db:"total_renewal_cost"`
+	StatusDistribution StatusCounts `json:"status_distribution" db:"status_distribution"`
+	CreatedAt          time.Time    `json:"created_at" db:"created_at"`
+}
+
+// Portfolio snapshot metrics that can be charted as a series
+const (
+	MetricTotalDomains   = "total_domains"
+	MetricActiveDomains  = "active_domains"
+	MetricExpiredDomains = "expired_domains"
+	MetricExpiring30     = "expiring_30"
+	MetricExpiring90     = "expiring_90"
+	MetricTotalCost      = "total_cost"
+
+	// MetricStatusPrefix selects one status's count, e.g. "status.active"
+	MetricStatusPrefix = "status."
+)
+
+// SnapshotMetrics lists the fixed metrics in display order
+func SnapshotMetrics() []string {
+	return []string{MetricTotalDomains, MetricActiveDomains, MetricExpiredDomains, MetricExpiring30, MetricExpiring90, MetricTotalCost}
+}
+
+// Metric returns the snapshot's value for a metric name, reporting false for unknown names
+func (s PortfolioSnapshot) Metric(name string) (float64, bool) {
+	switch name {
+	case MetricTotalDomains:
+		return float64(s.TotalDomains), true
+	case MetricActiveDomains:
+		return float64(s.ActiveDomains), true
+	case MetricExpiredDomains:
+		return float64(s.ExpiredDomains), true
+	case MetricExpiring30:
+		return float64(s.Expiring30), true
+	case MetricExpiring90:
+		return float64(s.Expiring90), true
+	case MetricTotalCost:
+		return s.TotalRenewalCost, true
+	}
+	if status := strings.TrimPrefix(name, MetricStatusPrefix); status != name && status != "" {
+		return float64(s.StatusDistribution[status]), true
+	}
+	return 0, false
+}
+
+// StatusCounts maps a domain status to the number of domains with it, stored as JSON
+type StatusCounts map[string]int
+
+// Value implements the driver.Valuer interface for database storage
+func (s StatusCounts) Value() (driver.Value, error) {
+	if s == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(s)
+}
+
+// Scan implements the sql.Scanner interface for database retrieval
+func (s *StatusCounts) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*s = StatusCounts{}
+		return nil
+	case []byte:
+		return json.Unmarshal(v, s)
+	case string:
+		return json.Unmarshal([]byte(v), s)
+	default:
+		return fmt.Errorf("cannot scan %T into StatusCounts", value)
+	}
+}
@@ -0,0 +1,31 @@
+package types
+
+import "testing"
+
+func TestPortfolioSnapshot_Metric(t *testing.T) {
+	snapshot := PortfolioSnapshot{
+		TotalDomains:       12,
+		Expiring30:         2,
+		TotalRenewalCost:   148.5,
+		StatusDistribution: StatusCounts{"active": 10, "expired": 2},
+	}
+
+	tests := map[string]float64{
+		MetricTotalDomains:             12,
+		MetricExpiring30:               2,
+		MetricTotalCost:                148.5,
+		MetricStatusPrefix + "active":  10,
+		MetricStatusPrefix + "pending": 0,
+	}
+	for metric, want := range tests {
+		if got, ok := snapshot.Metric(metric); !ok || got != want {
+			t.Errorf("Metric(%q) = %v, %v; want %v", metric, got, ok, want)
+		}
+	}
+
+	for _, metric := range []string{"", "cost", MetricStatusPrefix} {
+		if _, ok := snapshot.Metric(metric); ok {
+			t.Errorf("Metric(%q) should be unknown", metric)
+		}
+	}
+}
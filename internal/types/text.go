@@ -0,0 +1,77 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Limits on free-text fields, in characters
+const (
+	MaxNameLength        = 100  // Category and project names
+	MaxDescriptionLength = 2000 // Category and project descriptions
+	MaxTags              = 25   // Tags per domain
+	MaxTagLength         = 50
+)
+
+// SanitizeText trims surrounding whitespace from value and checks it is at most max characters
+// with no control characters. Multi-line text may also contain newlines and tabs.
+func SanitizeText(value string, max int, multiLine bool) (string, error) {
+	value = strings.TrimSpace(value)
+	if !utf8.ValidString(value) {
+		return "", fmt.Errorf("must be valid UTF-8")
+	}
+	if n := utf8.RuneCountInString(value); n > max {
+		return "", fmt.Errorf("must be at most %d characters, got %d", max, n)
+	}
+	for _, r := range value {
+		if multiLine && (r == '\n' || r == '\r' || r == '\t') {
+			continue
+		}
+		// Line and paragraph separators break rendering just like control characters
+		if unicode.IsControl(r) || r == '\u2028' || r == '\u2029' {
+			return "", fmt.Errorf("must not contain control characters")
+		}
+	}
+	return value, nil
+}
+
+// sanitizeNameAndDescription cleans the name and description shared by categories and projects
+func sanitizeNameAndDescription(name, description *string, errKind error) error {
+	cleaned, err := SanitizeText(*name, MaxNameLength, false)
+	if err != nil {
+		return fmt.Errorf("%w: name %v", errKind, err)
+	}
+	if cleaned == "" {
+		return fmt.Errorf("%w: name is required", errKind)
+	}
+	*name = cleaned
+
+	if *description, err = SanitizeText(*description, MaxDescriptionLength, true); err != nil {
+		return fmt.Errorf("%w: description %v", errKind, err)
+	}
+	return nil
+}
+
+// Validate trims each tag, drops empty and repeated tags, and checks the tag count and lengths
+func (t *TagsSlice) Validate() error {
+	tags := make(TagsSlice, 0, len(*t))
+	seen := make(map[string]bool, len(*t))
+	for _, tag := range *t {
+		cleaned, err := SanitizeText(tag, MaxTagLength, false)
+		if err != nil {
+			return fmt.Errorf("%w: tag %q %v", ErrInvalidTags, tag, err)
+		}
+		if cleaned == "" || seen[cleaned] {
+			continue
+		}
+		seen[cleaned] = true
+		tags = append(tags, cleaned)
+	}
+	if len(tags) > MaxTags {
+		return fmt.Errorf("%w: at most %d tags allowed", ErrInvalidTags, MaxTags)
+	}
+	*t = tags
+	return nil
+}
@@ -0,0 +1,90 @@
+package types
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSanitizeText(t *testing.T) {
+	tests := []struct {
+		name      string
+		value     string
+		multiLine bool
+		want      string
+		wantErr   bool
+	}{
+		{name: "trims whitespace", value: "  Marketing  ", want: "Marketing"},
+		{name: "multi-byte within limit", value: strings.Repeat("é", 10), want: strings.Repeat("é", 10)},
+		{name: "too long", value: strings.Repeat("x", 11), wantErr: true},
+		{name: "newline in single line", value: "a\nb", wantErr: true},
+		{name: "newline in multi-line", value: "a\nb", multiLine: true, want: "a\nb"},
+		{name: "NUL in multi-line", value: "a\x00b", multiLine: true, wantErr: true},
+		{name: "line separator", value: "a\u2028b", multiLine: true, wantErr: true},
+		{name: "invalid UTF-8", value: "a\xffb", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SanitizeText(tt.value, 10, tt.multiLine)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("SanitizeText(%q) = %q, want error", tt.value, got)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("SanitizeText(%q) = %q, %v; want %q", tt.value, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestTagsSlice_Validate(t *testing.T) {
+	tags := TagsSlice{" prod ", "", "prod", "billing"}
+	if err := tags.Validate(); err != nil {
+		t.Fatalf("Validate() error: %v", err)
+	}
+	if want := (TagsSlice{"prod", "billing"}); !reflect.DeepEqual(tags, want) {
+		t.Errorf("tags = %v, want %v", tags, want)
+	}
+
+	tooMany := make(TagsSlice, MaxTags+1)
+	for i := range tooMany {
+		tooMany[i] = strings.Repeat("t", i+1)
+	}
+	invalid := map[string]TagsSlice{
+		"too many":          tooMany,
+		"too long":          {strings.Repeat("t", MaxTagLength+1)},
+		"control character": {"prod\tus"},
+	}
+	for name, tags := range invalid {
+		if err := tags.Validate(); !errors.Is(err, ErrInvalidTags) {
+			t.Errorf("%s: Validate() error = %v, want ErrInvalidTags", name, err)
+		}
+	}
+}
+
+func TestCategoryAndProject_Validate(t *testing.T) {
+	category := Category{Name: "  Marketing ", Description: " Campaign sites\nand landing pages "}
+	if err := category.Validate(); err != nil {
+		t.Fatalf("Category.Validate() error: %v", err)
+	}
+	if category.Name != "Marketing" || category.Description != "Campaign sites\nand landing pages" {
+		t.Errorf("Category not trimmed: %q, %q", category.Name, category.Description)
+	}
+
+	if err := (&Category{Name: "   "}).Validate(); !errors.Is(err, ErrInvalidCategory) {
+		t.Errorf("blank category name: error = %v, want ErrInvalidCategory", err)
+	}
+	if err := (&Category{Name: strings.Repeat("x", MaxNameLength+1)}).Validate(); !errors.Is(err, ErrInvalidCategory) {
+		t.Errorf("long category name: error = %v, want ErrInvalidCategory", err)
+	}
+	if err := (&Project{Name: "Launch", Description: strings.Repeat("x", MaxDescriptionLength+1)}).Validate(); !errors.Is(err, ErrInvalidProject) {
+		t.Errorf("long project description: error = %v, want ErrInvalidProject", err)
+	}
+	if err := (&Project{Name: "Launch\x1b[31m"}).Validate(); !errors.Is(err, ErrInvalidProject) {
+		t.Errorf("project name with escape sequence: error = %v, want ErrInvalidProject", err)
+	}
+}
@@ -0,0 +1,123 @@
+package types
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// MaxTXTStringLength is the most bytes one character-string in a TXT record can hold. Longer
+// values, such as 2048-bit DKIM keys, are published as several strings that resolvers join.
+const MaxTXTStringLength = 255
+
+// TXTNeedsChunking reports whether a TXT value is too long for a single character-string
+func TXTNeedsChunking(value string) bool {
+	return len(value) > MaxTXTStringLength
+}
+
+// ChunkTXT splits a TXT value into character-strings of at most MaxTXTStringLength bytes,
+// never splitting a UTF-8 character. A short value comes back as a single chunk.
+func ChunkTXT(value string) []string {
+	if !TXTNeedsChunking(value) {
+		return []string{value}
+	}
+	var chunks []string
+	for len(value) > MaxTXTStringLength {
+		end := MaxTXTStringLength
+		for end > 0 && !utf8.RuneStart(value[end]) {
+			end--
+		}
+		chunks = append(chunks, value[:end])
+		value = value[end:]
+	}
+	if value != "" {
+		chunks = append(chunks, value)
+	}
+	return chunks
+}
+
+// QuoteTXT renders a TXT value the way zone files and most DNS APIs expect it: each chunk of
+// ChunkTXT in double quotes, separated by spaces, e.g. "v=DKIM1; k=rsa; p=MIIB..." "...IDAQAB"
+func QuoteTXT(value string) string {
+	chunks := ChunkTXT(value)
+	quoted := make([]string, len(chunks))
+	for i, chunk := range chunks {
+		quoted[i] = quoteTXTString(chunk)
+	}
+	return strings.Join(quoted, " ")
+}
+
+// quoteTXTString quotes one character-string, escaping the quotes and backslashes in it
+func quoteTXTString(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for i := 0; i < len(s); i++ {
+		if s[i] == '"' || s[i] == '\\' {
+			b.WriteByte('\\')
+		}
+		b.WriteByte(s[i])
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+// DechunkTXT joins a TXT value given as quoted character-strings, e.g. `"abc" "def"`, back
+// into the single value it represents. Escapes (\" \\ and \DDD) are decoded. A value that
+// isn't entirely quoted strings is returned unchanged, so plain values pass through as-is.
+func DechunkTXT(value string) string {
+	trimmed := strings.TrimSpace(value)
+	if !strings.HasPrefix(trimmed, `"`) {
+		return value
+	}
+
+	var b strings.Builder
+	rest := trimmed
+	for rest != "" {
+		if rest[0] != '"' {
+			return value
+		}
+		n, ok := unquoteTXTString(rest, &b)
+		if !ok {
+			return value
+		}
+		rest = strings.TrimLeft(rest[n:], " \t")
+	}
+	return b.String()
+}
+
+// unquoteTXTString decodes the quoted character-string at the start of s into b and returns
+// how many bytes of s it took; ok is false when the string is never closed
+func unquoteTXTString(s string, b *strings.Builder) (n int, ok bool) {
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '"':
+			return i + 1, true
+		case '\\':
+			if i+3 < len(s) && isDigits(s[i+1:i+4]) {
+				code, _ := strconv.Atoi(s[i+1 : i+4])
+				if code <= 255 {
+					b.WriteByte(byte(code))
+					i += 3
+					continue
+				}
+			}
+			if i+1 < len(s) {
+				i++
+				b.WriteByte(s[i])
+			}
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return 0, false
+}
+
+// isDigits reports whether s is all ASCII digits
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
@@ -0,0 +1,52 @@
+package types
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestChunkTXT(t *testing.T) {
+	long := strings.Repeat("a", 300)
+	if got := ChunkTXT("v=spf1 -all"); !reflect.DeepEqual(got, []string{"v=spf1 -all"}) {
+		t.Errorf("ChunkTXT(short) = %q, want one chunk", got)
+	}
+	got := ChunkTXT(long)
+	if len(got) != 2 || len(got[0]) != MaxTXTStringLength || strings.Join(got, "") != long {
+		t.Errorf("ChunkTXT(300 bytes) = %d chunks of %d, want 255 then 45", len(got), len(got[0]))
+	}
+
+	multiByte := strings.Repeat("a", 254) + "é"
+	got = ChunkTXT(multiByte)
+	if len(got) != 2 || got[1] != "é" {
+		t.Errorf("ChunkTXT split a UTF-8 character: %q", got)
+	}
+}
+
+func TestQuoteAndDechunkTXT(t *testing.T) {
+	dkim := "v=DKIM1; k=rsa; p=" + strings.Repeat("MIIBIjAN", 50)
+	quoted := QuoteTXT(dkim)
+	if strings.Count(quoted, `" "`) != 1 {
+		t.Errorf("QuoteTXT(%d bytes) = %q, want two quoted strings", len(dkim), quoted)
+	}
+	if got := DechunkTXT(quoted); got != dkim {
+		t.Errorf("DechunkTXT(QuoteTXT(dkim)) did not round-trip: %q", got)
+	}
+
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{value: `"abc" "def"`, want: "abcdef"},
+		{value: `"say \"hi\"" "\\"`, want: `say "hi"\`},
+		{value: `"caf\195\169"`, want: "café"},
+		{value: "v=spf1 include:_spf.example.com ~all", want: "v=spf1 include:_spf.example.com ~all"},
+		{value: `"unterminated`, want: `"unterminated`},
+		{value: `"abc" def`, want: `"abc" def`},
+	}
+	for _, tt := range tests {
+		if got := DechunkTXT(tt.value); got != tt.want {
+			t.Errorf("DechunkTXT(%q) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package types
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// VerificationRecordLabel is the label under a domain where its ownership TXT record goes
+const VerificationRecordLabel = "_domainvault-verification"
+
+// verificationValuePrefix starts the value of an ownership TXT record
+const verificationValuePrefix = "domainvault-verification="
+
+// DomainVerificationChallenge is the TXT record that must be published to prove control of a
+// domain, and the domain's verification state
+type DomainVerificationChallenge struct {
+	DomainID    string     `json:"domain_id"`
+	DomainName  string     `json:"domain_name"`
+	RecordType  string     `json:"record_type"`
+	RecordName  string     `json:"record_name"`
+	RecordValue string     `json:"record_value"`
+	RequestedAt *time.Time `json:"requested_at,omitempty"`
+	Verified    bool       `json:"verified"`
+	VerifiedAt  *time.Time `json:"verified_at,omitempty"`
+}
+
+// NewVerificationToken returns a random token for an ownership challenge
+func NewVerificationToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate verification token: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// VerificationRecordName returns the name of the ownership TXT record of a domain
+func VerificationRecordName(domainName string) string {
+	return VerificationRecordLabel + "." + NormalizeDomainName(domainName)
+}
+
+// VerificationRecordValue returns the TXT value that proves ownership with token
+func VerificationRecordValue(token string) string {
+	return verificationValuePrefix + token
+}
+
+// VerificationMatches reports whether any of the TXT values published for a domain carries token
+func VerificationMatches(values []string, token string) bool {
+	if token == "" {
+		return false
+	}
+	want := VerificationRecordValue(token)
+	for _, value := range values {
+		if strings.TrimSpace(value) == want {
+			return true
+		}
+	}
+	return false
+}
+
+// IsVerified reports whether control of the domain has been proven
+func (d *Domain) IsVerified() bool {
+	return d.VerifiedAt != nil
+}
+
+// VerificationChallenge returns the domain's verification state, with the record to publish
+// when a challenge is pending
+func (d *Domain) VerificationChallenge() DomainVerificationChallenge {
+	challenge := DomainVerificationChallenge{
+		DomainID:    d.ID,
+		DomainName:  d.Name,
+		RecordType:  "TXT",
+		RecordName:  VerificationRecordName(d.Name),
+		RequestedAt: d.VerificationRequestedAt,
+		Verified:    d.IsVerified(),
+		VerifiedAt:  d.VerifiedAt,
+	}
+	if d.VerificationToken != nil {
+		challenge.RecordValue = VerificationRecordValue(*d.VerificationToken)
+	}
+	return challenge
+}
@@ -0,0 +1,40 @@
+package types
+
+import "testing"
+
+func TestVerificationMatches(t *testing.T) {
+	token, err := NewVerificationToken()
+	if err != nil {
+		t.Fatalf("NewVerificationToken() error: %v", err)
+	}
+	if len(token) != 32 {
+		t.Errorf("Expected a 32 character token, got %q", token)
+	}
+
+	values := []string{"v=spf1 -all", " " + VerificationRecordValue(token) + " "}
+	if !VerificationMatches(values, token) {
+		t.Errorf("Expected %v to carry token %s", values, token)
+	}
+	if VerificationMatches(values, "other") {
+		t.Error("Expected a different token not to match")
+	}
+	if VerificationMatches([]string{VerificationRecordValue("")}, "") {
+		t.Error("Expected an empty token never to match")
+	}
+}
+
+func TestDomain_VerificationChallenge(t *testing.T) {
+	token := "abc123"
+	domain := Domain{ID: "d1", Name: "Example.COM", VerificationToken: &token}
+
+	challenge := domain.VerificationChallenge()
+	if challenge.RecordName != "_domainvault-verification.example.com" {
+		t.Errorf("RecordName = %q", challenge.RecordName)
+	}
+	if challenge.RecordValue != "domainvault-verification=abc123" {
+		t.Errorf("RecordValue = %q", challenge.RecordValue)
+	}
+	if challenge.Verified {
+		t.Error("Expected a pending challenge not to be verified")
+	}
+}
@@ -0,0 +1,97 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Watchlist entry statuses
+const (
+	WatchStatusPending    = "pending"     // Not checked yet
+	WatchStatusRegistered = "registered"  // Someone holds the domain
+	WatchStatusAvailable  = "available"   // Unregistered and within the price limit
+	WatchStatusOverBudget = "over_budget" // Unregistered, but above the price limit or with no known price
+)
+
+// DefaultWatchlistCurrency is used when a watchlist entry doesn't name a currency
+const DefaultWatchlistCurrency = "USD"
+
+// WatchlistEntry is a domain someone else holds that we want to register when it drops
+type WatchlistEntry struct {
+	ID            string     `json:"id" db:"id"`
+	Domain        string     `json:"domain" db:"domain"`
+	MaxPrice      *float64   `json:"max_price,omitempty" db:"max_price"` // Only alert when registration costs no more; nil alerts at any price
+	Currency      string     `json:"currency" db:"currency"`
+	Notes         string     `json:"notes" db:"notes"`
+	Status        string     `json:"status" db:"status"`
+	LastPrice     *float64   `json:"last_price,omitempty" db:"last_price"` // Registration price at the last check, when the source gave one
+	LastSource    string     `json:"last_source,omitempty" db:"last_source"`
+	LastError     string     `json:"last_error,omitempty" db:"last_error"`
+	LastCheckedAt *time.Time `json:"last_checked_at,omitempty" db:"last_checked_at"`
+	NotifiedAt    *time.Time `json:"notified_at,omitempty" db:"notified_at"` // Cleared when the domain is registered again
+	CreatedAt     time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// WatchlistRequest adds a domain to the watchlist or changes an entry
+type WatchlistRequest struct {
+	Domain   string   `json:"domain"`
+	MaxPrice *float64 `json:"max_price"`
+	Currency string   `json:"currency"`
+	Notes    string   `json:"notes"`
+}
+
+// DomainAvailability is whether a domain can be registered, and for how much when known
+type DomainAvailability struct {
+	Domain    string   `json:"domain"`
+	Available bool     `json:"available"`
+	Price     *float64 `json:"price,omitempty"`
+	Currency  string   `json:"currency,omitempty"`
+	Source    string   `json:"source"` // Provider or lookup service that answered
+}
+
+// Validate normalizes the domain and currency and checks the price limit and notes
+func (w *WatchlistEntry) Validate() error {
+	w.Domain = NormalizeDomainName(w.Domain)
+	if w.Domain == "" || !strings.Contains(w.Domain, ".") {
+		return fmt.Errorf("%w: domain must be a fully qualified name", ErrInvalidWatchlist)
+	}
+	if w.MaxPrice != nil && *w.MaxPrice < 0 {
+		return fmt.Errorf("%w: max_price cannot be negative", ErrInvalidWatchlist)
+	}
+
+	w.Currency = strings.ToUpper(strings.TrimSpace(w.Currency))
+	if w.Currency == "" {
+		w.Currency = DefaultWatchlistCurrency
+	}
+	if len(w.Currency) != 3 {
+		return fmt.Errorf("%w: currency must be a 3-letter code", ErrInvalidWatchlist)
+	}
+
+	notes, err := SanitizeText(w.Notes, MaxDescriptionLength, true)
+	if err != nil {
+		return fmt.Errorf("%w: notes %v", ErrInvalidWatchlist, err)
+	}
+	w.Notes = notes
+	if w.Status == "" {
+		w.Status = WatchStatusPending
+	}
+	return nil
+}
+
+// WithinBudget reports whether a registration at availability's price satisfies the entry's
+// price limit. Without a limit any price does; with one, the price must be known and in the
+// entry's currency.
+func (w *WatchlistEntry) WithinBudget(availability DomainAvailability) bool {
+	if w.MaxPrice == nil {
+		return true
+	}
+	if availability.Price == nil {
+		return false
+	}
+	if availability.Currency != "" && !strings.EqualFold(availability.Currency, w.Currency) {
+		return false
+	}
+	return *availability.Price <= *w.MaxPrice
+}
@@ -0,0 +1,56 @@
+package types
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestWatchlistEntry_Validate(t *testing.T) {
+	entry := WatchlistEntry{Domain: "  Wanted.Example.  ", Currency: "eur"}
+	if err := entry.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if entry.Domain != "wanted.example" || entry.Currency != "EUR" || entry.Status != WatchStatusPending {
+		t.Errorf("Validate() should normalize the entry, got %+v", entry)
+	}
+
+	defaulted := WatchlistEntry{Domain: "wanted.example"}
+	if err := defaulted.Validate(); err != nil || defaulted.Currency != DefaultWatchlistCurrency {
+		t.Errorf("Validate() without a currency = %q, %v; want %s", defaulted.Currency, err, DefaultWatchlistCurrency)
+	}
+
+	negative := -1.0
+	invalid := []WatchlistEntry{
+		{Domain: "localhost"},
+		{Domain: "wanted.example", MaxPrice: &negative},
+		{Domain: "wanted.example", Currency: "dollars"},
+	}
+	for _, entry := range invalid {
+		if err := entry.Validate(); !errors.Is(err, ErrInvalidWatchlist) {
+			t.Errorf("Validate(%+v) error = %v, want ErrInvalidWatchlist", entry, err)
+		}
+	}
+}
+
+func TestWatchlistEntry_WithinBudget(t *testing.T) {
+	limit, cheap, dear := 20.0, 12.99, 99.0
+	tests := []struct {
+		name         string
+		maxPrice     *float64
+		availability DomainAvailability
+		want         bool
+	}{
+		{"no limit, unknown price", nil, DomainAvailability{Available: true}, true},
+		{"within limit", &limit, DomainAvailability{Price: &cheap, Currency: "USD"}, true},
+		{"over limit", &limit, DomainAvailability{Price: &dear, Currency: "USD"}, false},
+		{"limit, unknown price", &limit, DomainAvailability{}, false},
+		{"other currency", &limit, DomainAvailability{Price: &cheap, Currency: "GBP"}, false},
+		{"currency not reported", &limit, DomainAvailability{Price: &cheap}, true},
+	}
+	for _, tt := range tests {
+		entry := WatchlistEntry{Domain: "wanted.example", MaxPrice: tt.maxPrice, Currency: "USD"}
+		if got := entry.WithinBudget(tt.availability); got != tt.want {
+			t.Errorf("%s: WithinBudget() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package types
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Webhook secret rotation windows
+const (
+	DefaultWebhookRotationWindow = 24 * time.Hour
+	MaxWebhookRotationWindow     = 30 * 24 * time.Hour
+)
+
+// webhookSecretPrefix marks generated signing secrets so they're recognisable in receivers' config
+const webhookSecretPrefix = "whsec_"
+
+// Keys of WebhookEndpoint.Secrets
+const (
+	webhookSecretCurrent  = "current"
+	webhookSecretPrevious = "previous"
+)
+
+// WebhookEndpoint is an outbound webhook receiver. Deliveries are signed with the current
+// secret; during a rotation the previous secret stays valid for receivers until it expires.
+type WebhookEndpoint struct {
+	ID                string         `json:"id" db:"id"`
+	URL               string         `json:"url" db:"url"`
+	Description       string         `json:"description" db:"description"`
+	Enabled           bool           `json:"enabled" db:"enabled"`
+	Secrets           CredentialsMap `json:"-" db:"secrets"` // Encrypted like provider credentials
+	SecretRotatedAt   *time.Time     `json:"secret_rotated_at,omitempty" db:"secret_rotated_at"`
+	PreviousExpiresAt *time.Time     `json:"previous_secret_expires_at,omitempty" db:"previous_secret_expires_at"`
+	CreatedAt         time.Time      `json:"created_at" db:"created_at"`
+	UpdatedAt         time.Time      `json:"updated_at" db:"updated_at"`
+}
+
+// WebhookEndpointRequest creates or updates a webhook endpoint
+type WebhookEndpointRequest struct {
+	URL         string `json:"url" binding:"required"`
+	Description string `json:"description"`
+	Enabled     *bool  `json:"enabled"` // Defaults to true on create
+}
+
+// WebhookRotateRequest rotates an endpoint's signing secret. The previous secret stays valid
+// for GraceHours (default 24, 0 revokes it immediately).
+type WebhookRotateRequest struct {
+	GraceHours *int `json:"grace_hours"`
+}
+
+// Validate checks the endpoint URL and description
+func (w *WebhookEndpoint) Validate() error {
+	w.URL = strings.TrimSpace(w.URL)
+	parsed, err := url.Parse(w.URL)
+	if err != nil || (parsed.Scheme != "https" && parsed.Scheme != "http") || parsed.Host == "" {
+		return fmt.Errorf("%w: url must be an absolute http or https URL", ErrInvalidWebhook)
+	}
+
+	description, err := SanitizeText(w.Description, MaxDescriptionLength, false)
+	if err != nil {
+		return fmt.Errorf("%w: description %v", ErrInvalidWebhook, err)
+	}
+	w.Description = description
+	return nil
+}
+
+// GenerateWebhookSecret returns a new random signing secret
+func GenerateWebhookSecret() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+	return webhookSecretPrefix + hex.EncodeToString(buf), nil
+}
+
+// CurrentSecret returns the secret new deliveries are signed with
+func (w *WebhookEndpoint) CurrentSecret() string {
+	return w.Secrets[webhookSecretCurrent]
+}
+
+// ActiveSecrets returns the secrets a receiver should accept at now: the current one, plus the
+// previous one while its rotation window is open
+func (w *WebhookEndpoint) ActiveSecrets(now time.Time) []string {
+	secrets := []string{w.CurrentSecret()}
+	if previous := w.Secrets[webhookSecretPrevious]; previous != "" && w.PreviousExpiresAt != nil && now.Before(*w.PreviousExpiresAt) {
+		secrets = append(secrets, previous)
+	}
+	return secrets
+}
+
+// PreviousSecretActive reports whether the previous secret is still inside its rotation window
+func (w *WebhookEndpoint) PreviousSecretActive(now time.Time) bool {
+	return len(w.ActiveSecrets(now)) > 1
+}
+
+// RotateSecret makes secret the current signing secret. The old current secret stays valid for
+// window; a zero window drops it at once.
+func (w *WebhookEndpoint) RotateSecret(secret string, window time.Duration, now time.Time) {
+	previous := w.CurrentSecret()
+	w.Secrets = CredentialsMap{webhookSecretCurrent: secret}
+	w.SecretRotatedAt = &now
+	w.PreviousExpiresAt = nil
+
+	if previous != "" && window > 0 {
+		w.Secrets[webhookSecretPrevious] = previous
+		expires := now.Add(window)
+		w.PreviousExpiresAt = &expires
+	}
+}
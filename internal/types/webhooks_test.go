@@ -0,0 +1,68 @@
+package types
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWebhookEndpoint_RotateSecret(t *testing.T) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	endpoint := WebhookEndpoint{URL: "https://hooks.example.com/domainvault"}
+
+	endpoint.RotateSecret("whsec_first", DefaultWebhookRotationWindow, now)
+	if endpoint.CurrentSecret() != "whsec_first" || endpoint.PreviousExpiresAt != nil {
+		t.Fatalf("First secret should have no previous secret, got %+v", endpoint)
+	}
+
+	endpoint.RotateSecret("whsec_second", 2*time.Hour, now)
+	if endpoint.CurrentSecret() != "whsec_second" {
+		t.Errorf("CurrentSecret() = %q, want whsec_second", endpoint.CurrentSecret())
+	}
+	if endpoint.SecretRotatedAt == nil || !endpoint.SecretRotatedAt.Equal(now) {
+		t.Errorf("SecretRotatedAt = %v, want %v", endpoint.SecretRotatedAt, now)
+	}
+
+	active := endpoint.ActiveSecrets(now.Add(time.Hour))
+	if len(active) != 2 || active[0] != "whsec_second" || active[1] != "whsec_first" {
+		t.Errorf("ActiveSecrets() during the window = %v, want current then previous", active)
+	}
+	if active := endpoint.ActiveSecrets(now.Add(3 * time.Hour)); len(active) != 1 {
+		t.Errorf("ActiveSecrets() after the window = %v, want only the current secret", active)
+	}
+
+	// A zero window revokes the old secret immediately
+	endpoint.RotateSecret("whsec_third", 0, now)
+	if endpoint.PreviousSecretActive(now) || endpoint.Secrets["previous"] != "" {
+		t.Errorf("Zero-window rotation should drop the previous secret, got %+v", endpoint.Secrets)
+	}
+}
+
+func TestWebhookEndpoint_Validate(t *testing.T) {
+	endpoint := WebhookEndpoint{URL: " https://hooks.example.com/in ", Description: " Ops channel "}
+	if err := endpoint.Validate(); err != nil {
+		t.Fatalf("Validate() unexpected error: %v", err)
+	}
+	if endpoint.URL != "https://hooks.example.com/in" || endpoint.Description != "Ops channel" {
+		t.Errorf("Validate() should trim fields, got %+v", endpoint)
+	}
+
+	for _, url := range []string{"", "hooks.example.com", "ftp://hooks.example.com", "https://"} {
+		endpoint := WebhookEndpoint{URL: url}
+		if err := endpoint.Validate(); !errors.Is(err, ErrInvalidWebhook) {
+			t.Errorf("Validate(%q) error = %v, want ErrInvalidWebhook", url, err)
+		}
+	}
+}
+
+func TestGenerateWebhookSecret(t *testing.T) {
+	first, err := GenerateWebhookSecret()
+	if err != nil {
+		t.Fatalf("GenerateWebhookSecret() error: %v", err)
+	}
+	second, _ := GenerateWebhookSecret()
+	if !strings.HasPrefix(first, "whsec_") || len(first) != len("whsec_")+64 || first == second {
+		t.Errorf("Unexpected secrets %q and %q", first, second)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -20,6 +21,15 @@ const (
 	
 	// Default interval for monitors (5 minutes)
 	DefaultInterval = 300
+
+	// DefaultRequestsPerMinute is the API rate limit of the free plan; paid plans allow more
+	DefaultRequestsPerMinute = 10
+
+	// MonitorsPageSize is the largest page getMonitors returns
+	MonitorsPageSize = 50
+
+	// maxRateLimitRetries is how often a request rejected with 429 is retried
+	maxRateLimitRetries = 3
 )
 
 // Client represents an UptimeRobot API client
@@ -27,6 +37,11 @@ type Client struct {
 	apiKey     string
 	httpClient *http.Client
 	baseURL    string
+
+	// Requests are spaced minInterval apart to stay under the account's rate limit
+	throttleMu  sync.Mutex
+	minInterval time.Duration
+	lastRequest time.Time
 }
 
 // NewClient creates a new UptimeRobot API client
@@ -36,7 +51,8 @@ func NewClient(apiKey string) *Client {
 		httpClient: &http.Client{
 			Timeout: DefaultTimeout,
 		},
-		baseURL: BaseURL,
+		baseURL:     BaseURL,
+		minInterval: time.Minute / DefaultRequestsPerMinute,
 	}
 }
 
@@ -44,8 +60,9 @@ func NewClient(apiKey string) *Client {
 func NewClientWithHTTPClient(apiKey string, httpClient *http.Client) *Client {
 	return &Client{
 		apiKey:     apiKey,
-		httpClient: httpClient,
-		baseURL:    BaseURL,
+		httpClient:  httpClient,
+		baseURL:     BaseURL,
+		minInterval: time.Minute / DefaultRequestsPerMinute,
 	}
 }
 
@@ -54,6 +71,38 @@ func (c *Client) SetBaseURL(baseURL string) {
 	c.baseURL = baseURL
 }
 
+// SetRateLimit sets how many requests per minute the client may make; 0 disables throttling
+func (c *Client) SetRateLimit(requestsPerMinute int) {
+	c.throttleMu.Lock()
+	defer c.throttleMu.Unlock()
+	if requestsPerMinute <= 0 {
+		c.minInterval = 0
+		return
+	}
+	c.minInterval = time.Minute / time.Duration(requestsPerMinute)
+}
+
+// throttle blocks until the next request is allowed under the rate limit
+func (c *Client) throttle() {
+	c.throttleMu.Lock()
+	defer c.throttleMu.Unlock()
+	if wait := time.Until(c.lastRequest.Add(c.minInterval)); wait > 0 {
+		time.Sleep(wait)
+	}
+	c.lastRequest = time.Now()
+}
+
+// retryAfter returns how long to back off after a 429, preferring the Retry-After header
+func (c *Client) retryAfter(resp *http.Response) time.Duration {
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if c.minInterval > 0 {
+		return c.minInterval
+	}
+	return time.Minute / DefaultRequestsPerMinute
+}
+
 // makeRequest makes an HTTP POST request to the UptimeRobot API
 func (c *Client) makeRequest(endpoint string, params map[string]interface{}) ([]byte, error) {
 	if params == nil {
@@ -91,34 +140,44 @@ func (c *Client) makeRequest(endpoint string, params map[string]interface{}) ([]
 		}
 	}
 	
-	// Create request
-	req, err := http.NewRequest("POST", c.baseURL+endpoint, strings.NewReader(data.Encode()))
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-	
-	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-	req.Header.Set("User-Agent", "DomainVault-UptimeRobot/1.0")
-	
-	// Make request
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to make request: %w", err)
-	}
-	defer resp.Body.Close()
-	
-	// Read response body
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
-	}
-	
-	// Check for HTTP errors
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+	for attempt := 0; ; attempt++ {
+		c.throttle()
+
+		// Create request
+		req, err := http.NewRequest("POST", c.baseURL+endpoint, strings.NewReader(data.Encode()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
+
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.Header.Set("User-Agent", "DomainVault-UptimeRobot/1.0")
+
+		// Make request
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to make request: %w", err)
+		}
+
+		// Read response body
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read response body: %w", err)
+		}
+
+		// Back off and retry when the rate limit was hit anyway, e.g. by another client on the account
+		if resp.StatusCode == http.StatusTooManyRequests && attempt < maxRateLimitRetries {
+			time.Sleep(c.retryAfter(resp))
+			continue
+		}
+
+		// Check for HTTP errors
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+		}
+
+		return body, nil
 	}
-	
-	return body, nil
 }
 
 // checkAPIResponse checks if the API response indicates success
@@ -159,6 +218,37 @@ func (c *Client) GetAccountDetails() (*Account, error) {
 
 // GetMonitors retrieves monitors based on the provided request
 func (c *Client) GetMonitors(req *GetMonitorsRequest) ([]Monitor, error) {
+	monitors, _, err := c.getMonitorsPage(req)
+	return monitors, err
+}
+
+// GetAllMonitors retrieves every monitor matching the request, following limit/offset
+// pagination so accounts with more than one page of monitors are fetched in full
+func (c *Client) GetAllMonitors(req *GetMonitorsRequest) ([]Monitor, error) {
+	page := GetMonitorsRequest{}
+	if req != nil {
+		page = *req
+	}
+	page.Limit = MonitorsPageSize
+	page.Offset = 0
+
+	var all []Monitor
+	for {
+		monitors, pagination, err := c.getMonitorsPage(&page)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch monitors at offset %d: %w", page.Offset, err)
+		}
+		all = append(all, monitors...)
+
+		page.Offset += len(monitors)
+		if len(monitors) < page.Limit || (pagination != nil && page.Offset >= pagination.Total) {
+			return all, nil
+		}
+	}
+}
+
+// getMonitorsPage fetches one page of monitors along with the response's pagination
+func (c *Client) getMonitorsPage(req *GetMonitorsRequest) ([]Monitor, *Pagination, error) {
 	params := make(map[string]interface{})
 	
 	if req != nil {
@@ -208,19 +298,19 @@ func (c *Client) GetMonitors(req *GetMonitorsRequest) ([]Monitor, error) {
 	
 	body, err := c.makeRequest("/getMonitors", params)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	
 	if err := checkAPIResponse(body); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	
 	var response GetMonitorsResponse
 	if err := json.Unmarshal(body, &response); err != nil {
-		return nil, fmt.Errorf("failed to parse monitors response: %w", err)
+		return nil, nil, fmt.Errorf("failed to parse monitors response: %w", err)
 	}
 	
-	return response.Monitors, nil
+	return response.Monitors, response.Pagination, nil
 }
 
 // CreateMonitor creates a new monitor
@@ -3,6 +3,9 @@ package uptimerobot
 import (
 	"fmt"
 	"log"
+	"math"
+	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
@@ -30,13 +33,22 @@ func NewService(cfg *config.UptimeRobotConfig) *Service {
 		service.isConfigured = true
 		service.client = nil // Use mock responses
 	} else if cfg != nil && cfg.APIKey != "" && cfg.Enabled {
-		service.client = NewClient(cfg.APIKey)
+		service.client = newConfiguredClient(cfg)
 		service.isConfigured = true
 	}
 
 	return service
 }
 
+// newConfiguredClient creates a client throttled to the configured rate limit
+func newConfiguredClient(cfg *config.UptimeRobotConfig) *Client {
+	client := NewClient(cfg.APIKey)
+	if cfg.RequestsPerMinute > 0 {
+		client.SetRateLimit(cfg.RequestsPerMinute)
+	}
+	return client
+}
+
 // IsConfigured returns true if UptimeRobot is properly configured
 func (s *Service) IsConfigured() bool {
 	return s.isConfigured
@@ -183,6 +195,72 @@ func (s *Service) DeleteMonitorForDomain(monitorID int) error {
 	return err
 }
 
+// AutoCreateEnabled reports whether monitors should be created automatically for new domains
+func (s *Service) AutoCreateEnabled() bool {
+	return s.isConfigured && s.config != nil && s.config.AutoCreateMonitors
+}
+
+// ServesHTTP reports whether a domain answers HTTP(S) requests, so only live sites get monitors
+func (s *Service) ServesHTTP(domainName string) bool {
+	client := &http.Client{
+		Timeout: 5 * time.Second,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse // Any response, including a redirect, means something is serving
+		},
+	}
+	for _, scheme := range []string{"https", "http"} {
+		resp, err := client.Head(fmt.Sprintf("%s://%s", scheme, domainName))
+		if err == nil {
+			resp.Body.Close()
+			return true
+		}
+	}
+	return false
+}
+
+// EnableMonitoringForDomain creates an HTTP monitor for a domain using the configured
+// interval and alert contacts, and records the monitor on the domain
+func (s *Service) EnableMonitoringForDomain(domain *types.Domain) error {
+	if !s.isConfigured {
+		return fmt.Errorf("UptimeRobot is not configured")
+	}
+	if domain.UptimeRobotMonitorID != nil {
+		return nil // Already monitored
+	}
+
+	monitorID := 0
+	if s.client == nil {
+		monitorID = 12346 // Mock ID
+	} else {
+		resp, err := s.CreateMonitorForDomain(domain, MonitorTypeHTTP, 0, nil)
+		if err != nil {
+			return err
+		}
+		monitorID = resp.Monitor.ID
+	}
+
+	status := "up"
+	domain.UptimeRobotMonitorID = &monitorID
+	domain.MonitorStatus = &status
+	return nil
+}
+
+// DisableMonitoringForDomain deletes a domain's monitor and clears its monitoring fields
+func (s *Service) DisableMonitoringForDomain(domain *types.Domain) error {
+	if domain.UptimeRobotMonitorID != nil {
+		if err := s.DeleteMonitor(*domain.UptimeRobotMonitorID); err != nil {
+			return err
+		}
+	}
+
+	domain.UptimeRobotMonitorID = nil
+	domain.UptimeRatio = nil
+	domain.ResponseTime = nil
+	domain.MonitorStatus = nil
+	domain.LastDowntime = nil
+	return nil
+}
+
 // GetMonitorStats retrieves detailed statistics for a monitor
 func (s *Service) GetMonitorStats(monitorID int, customRanges ...string) (*Monitor, error) {
 	if !s.isConfigured {
@@ -207,8 +285,12 @@ func (s *Service) SyncDomainMonitoring(domains []types.Domain, monitorType Monit
 		Results: make([]types.DomainMonitorResult, 0, len(domains)),
 	}
 
-	// Get all existing monitors
-	existingMonitors, err := s.client.GetMonitors(nil)
+	// Get all existing monitors with their latest response time, so updating them costs no
+	// further requests against the rate limit
+	existingMonitors, err := s.client.GetAllMonitors(&GetMonitorsRequest{
+		ResponseTimes:      1,
+		ResponseTimesLimit: 1,
+	})
 	if err != nil {
 		log.Printf("Failed to get existing monitors: %v", err)
 		response.Success = false
@@ -239,22 +321,14 @@ func (s *Service) SyncDomainMonitoring(domains []types.Domain, monitorType Monit
 			result.MonitorID = &existingMonitor.ID
 			result.Action = "updated"
 
-			// Get fresh stats for the monitor
-			if stats, err := s.GetMonitorStats(existingMonitor.ID, "1", "7", "30"); err == nil {
-				// Update domain with monitoring data (this would be done by the caller)
-				result.Success = true
-				result.Message = "Monitor updated successfully"
-				if len(stats.ResponseTimes) > 0 {
-					responseTime := stats.ResponseTimes[len(stats.ResponseTimes)-1].Value
-					result.ResponseTime = &responseTime
-				}
-				response.MonitorsUpdated++
-			} else {
-				result.Success = false
-				result.Error = fmt.Sprintf("Failed to get monitor stats: %v", err)
-				result.Message = "Failed to update monitor stats"
-				response.MonitorsFailed++
+			// Update domain with monitoring data (this would be done by the caller)
+			result.Success = true
+			result.Message = "Monitor updated successfully"
+			if len(existingMonitor.ResponseTimes) > 0 {
+				responseTime := existingMonitor.ResponseTimes[len(existingMonitor.ResponseTimes)-1].Value
+				result.ResponseTime = &responseTime
 			}
+			response.MonitorsUpdated++
 		} else if autoCreate {
 			// Create new monitor
 			createResp, err := s.CreateMonitorForDomain(&domain, monitorType, 0, nil)
@@ -343,6 +417,9 @@ func (s *Service) PauseMonitor(monitorID int) error {
 	if !s.isConfigured {
 		return fmt.Errorf("UptimeRobot is not configured")
 	}
+	if s.client == nil {
+		return nil // Mock mode
+	}
 
 	return s.client.PauseMonitor(monitorID)
 }
@@ -352,10 +429,38 @@ func (s *Service) ResumeMonitor(monitorID int) error {
 	if !s.isConfigured {
 		return fmt.Errorf("UptimeRobot is not configured")
 	}
+	if s.client == nil {
+		return nil // Mock mode
+	}
 
 	return s.client.ResumeMonitor(monitorID)
 }
 
+// SetDomainMonitorPaused pauses or resumes a domain's monitor and records its new status on
+// the domain, which the caller saves. A resumed monitor is "not_checked_yet" until
+// UptimeRobot's next check or the next monitor sync.
+func (s *Service) SetDomainMonitorPaused(domain *types.Domain, paused bool) error {
+	if domain.UptimeRobotMonitorID == nil {
+		return fmt.Errorf("domain %s has no UptimeRobot monitor", domain.Name)
+	}
+
+	status := MonitorStatusPaused
+	if paused {
+		if err := s.PauseMonitor(*domain.UptimeRobotMonitorID); err != nil {
+			return err
+		}
+	} else {
+		if err := s.ResumeMonitor(*domain.UptimeRobotMonitorID); err != nil {
+			return err
+		}
+		status = MonitorStatusNotCheckedYet
+	}
+
+	name := monitorStatusNames[status]
+	domain.MonitorStatus = &name
+	return nil
+}
+
 // GetAllMonitors retrieves all monitors with optional filtering
 func (s *Service) GetAllMonitors(includeStats bool) ([]Monitor, error) {
 	if !s.isConfigured {
@@ -371,7 +476,7 @@ func (s *Service) GetAllMonitors(includeStats bool) ([]Monitor, error) {
 		req.CustomUptimeRatio = []string{"1", "7", "30"} // 1 day, 7 days, 30 days
 	}
 
-	return s.client.GetMonitors(req)
+	return s.client.GetAllMonitors(req)
 }
 
 // GetDomainVaultMonitors retrieves only monitors created by DomainVault
@@ -397,7 +502,7 @@ func (s *Service) UpdateConfig(cfg *config.UptimeRobotConfig) error {
 	s.config = cfg
 
 	if cfg != nil && cfg.APIKey != "" && cfg.Enabled {
-		s.client = NewClient(cfg.APIKey)
+		s.client = newConfiguredClient(cfg)
 		s.isConfigured = true
 
 		// Test the new configuration
@@ -554,24 +659,138 @@ func (s *Service) GetMonitors() ([]Monitor, error) {
 	return s.GetAllMonitors(true)
 }
 
-// SyncMonitors synchronizes UptimeRobot monitor data with the database
-func (s *Service) SyncMonitors() error {
+// monitorLogDown is the log type UptimeRobot records when a monitor goes down
+const monitorLogDown = 1
+
+// monitorStatusNames maps monitor statuses to the values stored on domains
+var monitorStatusNames = map[MonitorStatus]string{
+	MonitorStatusPaused:        "paused",
+	MonitorStatusNotCheckedYet: "not_checked_yet",
+	MonitorStatusUp:            "up",
+	MonitorStatusSeemsDown:     "seems_down",
+	MonitorStatusDown:          "down",
+}
+
+// SyncMonitors fetches every monitor with its stats, in rate-limited pages, and copies the
+// uptime ratio, response time and status onto the domains they monitor. Domains are matched
+// by monitor ID, or by the monitor URL's host if not yet linked to a monitor. It returns the
+// domains that changed, which the caller saves, and per-domain results with synced/failed counts.
+func (s *Service) SyncMonitors(domains []types.Domain) ([]types.Domain, *types.UptimeRobotSyncResponse, error) {
 	if !s.isConfigured {
-		return fmt.Errorf("UptimeRobot is not configured")
+		return nil, nil, fmt.Errorf("UptimeRobot is not configured")
 	}
 
-	// Mock mode
+	var monitors []Monitor
+	var err error
 	if s.client == nil {
-		// Mock sync always succeeds
-		return nil
+		monitors, err = s.GetMonitors() // Mock mode
+	} else {
+		monitors, err = s.client.GetAllMonitors(&GetMonitorsRequest{
+			CustomUptimeRatio:  []string{"30"},
+			ResponseTimes:      1,
+			ResponseTimesLimit: 1,
+			Logs:               1,
+			LogsLimit:          10,
+		})
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch monitors: %w", err)
 	}
 
-	// In a real implementation, this would:
-	// 1. Fetch all monitors from UptimeRobot
-	// 2. Update domain records with monitoring data
-	// 3. Clean up orphaned monitors
-	// For now, just validate the connection
-	return s.TestConnection()
+	byID := make(map[int]*Monitor, len(monitors))
+	byHost := make(map[string]*Monitor, len(monitors))
+	for i := range monitors {
+		byID[monitors[i].ID] = &monitors[i]
+		if host := types.NormalizeDomainName(s.extractDomainFromURL(monitors[i].URL)); host != "" {
+			byHost[host] = &monitors[i]
+		}
+	}
+
+	response := &types.UptimeRobotSyncResponse{
+		Success: true,
+		Message: fmt.Sprintf("Synchronized %d monitors", len(monitors)),
+		Results: []types.DomainMonitorResult{},
+	}
+	var updated []types.Domain
+	for _, domain := range domains {
+		var monitor *Monitor
+		if domain.UptimeRobotMonitorID != nil {
+			if monitor = byID[*domain.UptimeRobotMonitorID]; monitor == nil {
+				response.Results = append(response.Results, types.DomainMonitorResult{
+					DomainID:   domain.ID,
+					DomainName: domain.Name,
+					MonitorID:  domain.UptimeRobotMonitorID,
+					Action:     "failed",
+					Message:    "Monitor no longer exists in UptimeRobot",
+					Error:      fmt.Sprintf("monitor %d not found", *domain.UptimeRobotMonitorID),
+				})
+				response.MonitorsFailed++
+				continue
+			}
+		} else if monitor = byHost[types.NormalizeDomainName(domain.Name)]; monitor == nil {
+			continue // Not monitored
+		}
+
+		applyMonitorStats(&domain, monitor)
+		updated = append(updated, domain)
+		response.Results = append(response.Results, types.DomainMonitorResult{
+			DomainID:     domain.ID,
+			DomainName:   domain.Name,
+			MonitorID:    domain.UptimeRobotMonitorID,
+			Action:       "updated",
+			Success:      true,
+			Message:      "Monitor stats synchronized",
+			UptimeRatio:  domain.UptimeRatio,
+			ResponseTime: domain.ResponseTime,
+		})
+		response.MonitorsSync++
+		response.MonitorsUpdated++
+	}
+
+	return updated, response, nil
+}
+
+// applyMonitorStats copies a monitor's status and stats onto its domain
+func applyMonitorStats(domain *types.Domain, monitor *Monitor) {
+	monitorID := monitor.ID
+	domain.UptimeRobotMonitorID = &monitorID
+
+	status, ok := monitorStatusNames[monitor.Status]
+	if !ok {
+		status = "unknown"
+	}
+	domain.MonitorStatus = &status
+
+	if ratio, ok := uptimeRatio(monitor); ok {
+		domain.UptimeRatio = &ratio
+	}
+
+	if average, err := monitor.AverageResponseTime.Float64(); err == nil {
+		responseTime := int(math.Round(average))
+		domain.ResponseTime = &responseTime
+	} else if len(monitor.ResponseTimes) > 0 {
+		responseTime := monitor.ResponseTimes[len(monitor.ResponseTimes)-1].Value
+		domain.ResponseTime = &responseTime
+	}
+
+	for _, entry := range monitor.Logs {
+		if entry.Type == monitorLogDown {
+			downtime := time.Unix(entry.Datetime, 0)
+			domain.LastDowntime = &downtime
+			break
+		}
+	}
+}
+
+// uptimeRatio returns a monitor's custom uptime ratio. Only one custom range is requested, but
+// the first is taken in case of more.
+func uptimeRatio(monitor *Monitor) (float64, bool) {
+	ratio := strings.Split(monitor.CustomUptimeRatio, "-")[0]
+	if ratio == "" {
+		return 0, false
+	}
+	value, err := strconv.ParseFloat(ratio, 64)
+	return value, err == nil
 }
 
 // CreateMonitor creates a new UptimeRobot monitor
@@ -1,6 +1,7 @@
 package uptimerobot
 
 import (
+	"encoding/json"
 	"time"
 )
 
@@ -28,6 +29,8 @@ type Monitor struct {
 	CustomHTTPHeaders map[string]string `json:"custom_http_headers,omitempty"`
 	CustomHTTPStatuses string           `json:"custom_http_statuses,omitempty"`
 	SSLEnabled       int               `json:"ssl,omitempty"`
+	CustomUptimeRatio   string          `json:"custom_uptime_ratio,omitempty"`   // Dash-separated, one per requested range
+	AverageResponseTime json.Number     `json:"average_response_time,omitempty"` // Milliseconds, returned with response_times
 }
 
 // MonitorType represents the type of monitor
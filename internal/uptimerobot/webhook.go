@@ -0,0 +1,79 @@
+package uptimerobot
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/rusiqe/domainvault/internal/types"
+)
+
+// Alert types UptimeRobot sends in the *alertType* webhook variable
+const (
+	AlertTypeDown      = 1
+	AlertTypeUp        = 2
+	AlertTypeSSLExpiry = 3
+)
+
+// AlertWebhook is the alert UptimeRobot sends to a webhook alert contact. Its fields are named
+// after UptimeRobot's webhook variables and may arrive as query parameters, a form or JSON.
+type AlertWebhook struct {
+	MonitorID             int    `form:"monitorID" json:"monitorID"`
+	MonitorURL            string `form:"monitorURL" json:"monitorURL"`
+	MonitorFriendlyName   string `form:"monitorFriendlyName" json:"monitorFriendlyName"`
+	AlertType             int    `form:"alertType" json:"alertType"`
+	AlertTypeFriendlyName string `form:"alertTypeFriendlyName" json:"alertTypeFriendlyName"`
+	AlertDetails          string `form:"alertDetails" json:"alertDetails"`
+	AlertDuration         int    `form:"alertDuration" json:"alertDuration"` // Seconds the monitor was down, on up alerts
+	AlertDateTime         int64  `form:"alertDateTime" json:"alertDateTime"` // Unix time of the alert
+}
+
+// Time returns when the alert happened, now if UptimeRobot didn't say
+func (a AlertWebhook) Time() time.Time {
+	if a.AlertDateTime > 0 {
+		return time.Unix(a.AlertDateTime, 0)
+	}
+	return time.Now()
+}
+
+// WebhookEnabled reports whether a webhook token is configured; without one, alert webhooks
+// are refused
+func (s *Service) WebhookEnabled() bool {
+	return s.config != nil && s.config.WebhookToken != ""
+}
+
+// ValidWebhookToken reports whether token is the configured webhook token
+func (s *Service) ValidWebhookToken(token string) bool {
+	if !s.WebhookEnabled() {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(token), []byte(s.config.WebhookToken)) == 1
+}
+
+// ApplyAlert records an alert's monitor status on the domain it monitors. Down alerts set the
+// last downtime. The uptime ratio isn't part of the alert, so it is fetched from the API when
+// a client is configured; failing to fetch it doesn't fail the alert.
+func (s *Service) ApplyAlert(domain *types.Domain, alert AlertWebhook) error {
+	var status string
+	switch alert.AlertType {
+	case AlertTypeDown:
+		status = monitorStatusNames[MonitorStatusDown]
+		downtime := alert.Time()
+		domain.LastDowntime = &downtime
+	case AlertTypeUp:
+		status = monitorStatusNames[MonitorStatusUp]
+	default:
+		return fmt.Errorf("unsupported alert type %d", alert.AlertType)
+	}
+	domain.MonitorStatus = &status
+
+	if s.client != nil {
+		if monitor, err := s.client.GetMonitorStats(alert.MonitorID, "30"); err != nil {
+			log.Printf("Failed to refresh uptime ratio of monitor %d: %v", alert.MonitorID, err)
+		} else if ratio, ok := uptimeRatio(monitor); ok {
+			domain.UptimeRatio = &ratio
+		}
+	}
+	return nil
+}
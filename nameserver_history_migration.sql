@@ -0,0 +1,12 @@
+-- Nameserver History Migration
+-- Records changes to a domain's apex NS records detected by the DNS refresh
+
+CREATE TABLE IF NOT EXISTS nameserver_history (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    domain_id UUID NOT NULL REFERENCES domains(id) ON DELETE CASCADE,
+    previous_nameservers JSONB NOT NULL DEFAULT '[]',
+    current_nameservers JSONB NOT NULL DEFAULT '[]',
+    detected_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_nameserver_history_domain_detected ON nameserver_history(domain_id, detected_at DESC);
@@ -0,0 +1,14 @@
+-- Notification Templates Migration
+-- Custom wording for alerts, one template per channel (email, slack, webhook) and event
+-- (alert type). subject and body are Go text/template sources; events without a row use the
+-- built-in wording.
+
+CREATE TABLE IF NOT EXISTS notification_templates (
+    channel VARCHAR(20) NOT NULL,
+    event VARCHAR(100) NOT NULL,
+    subject TEXT NOT NULL DEFAULT '',
+    body TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (channel, event)
+);
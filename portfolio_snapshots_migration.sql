@@ -0,0 +1,16 @@
+-- Portfolio Snapshots Migration
+-- One row per day with the portfolio's headline figures, written by the daily snapshot job
+-- and read by /api/v1/admin/analytics/history and the trend analysis.
+
+CREATE TABLE IF NOT EXISTS portfolio_snapshots (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    snapshot_date DATE NOT NULL UNIQUE,
+    total_domains INTEGER NOT NULL DEFAULT 0,
+    active_domains INTEGER NOT NULL DEFAULT 0,
+    expired_domains INTEGER NOT NULL DEFAULT 0,
+    expiring_30 INTEGER NOT NULL DEFAULT 0,
+    expiring_90 INTEGER NOT NULL DEFAULT 0,
+    total_renewal_cost NUMERIC(12, 2) NOT NULL DEFAULT 0,
+    status_distribution JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,16 @@
+-- Provider Secrets Migration
+-- Credentials of secure provider connections kept by the database secret store
+-- (SECRET_BACKEND=db, the default). Each row is the secret a connection's credential_reference
+-- names, envelope-encrypted like provider_credentials when CREDENTIALS_MASTER_KEY is set.
+-- With SECRET_BACKEND=vault or aws the secrets live in Vault or AWS Secrets Manager instead and
+-- this table stays empty.
+
+CREATE TABLE IF NOT EXISTS provider_secrets (
+    reference VARCHAR(128) PRIMARY KEY,
+    secret JSONB NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+COMMENT ON TABLE provider_secrets IS 'Secrets of secure provider connections when SECRET_BACKEND=db. Connections hold only the reference.';
+COMMENT ON COLUMN secure_provider_credentials.credential_reference IS 'Predefined environment variable set (e.g. GODADDY_DEFAULT) or the reference of a secret in the configured secret store.';
@@ -0,0 +1,5 @@
+-- Registrar Status Migration
+-- Stores the registrar's authoritative status codes for each domain (e.g. clientHold,
+-- pendingTransfer), read during sync when SYNC_REGISTRAR_STATUS is enabled
+
+ALTER TABLE domains ADD COLUMN IF NOT EXISTS registrar_status TEXT;
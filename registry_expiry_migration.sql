@@ -0,0 +1,15 @@
+-- Registry Expiry Migration
+-- Stores the expiry each domain's registry reported over RDAP or WHOIS, apart from the
+-- provider-reported expires_at, so the two can be reconciled
+
+CREATE TABLE IF NOT EXISTS domain_registry_expiry (
+    domain_id UUID PRIMARY KEY REFERENCES domains(id) ON DELETE CASCADE,
+    registry_expires_at TIMESTAMPTZ,
+    stored_expires_at TIMESTAMPTZ NOT NULL,
+    source VARCHAR(20) NOT NULL DEFAULT '',
+    discrepancy BOOLEAN NOT NULL DEFAULT FALSE,
+    last_error TEXT NOT NULL DEFAULT '',
+    checked_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_domain_registry_expiry_discrepancy ON domain_registry_expiry(discrepancy) WHERE discrepancy;
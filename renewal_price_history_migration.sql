@@ -0,0 +1,13 @@
+-- Renewal Price History Migration
+-- Records changes to a domain's renewal price seen by provider syncs
+
+CREATE TABLE IF NOT EXISTS renewal_price_history (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    domain_id UUID NOT NULL REFERENCES domains(id) ON DELETE CASCADE,
+    provider VARCHAR(50) NOT NULL DEFAULT '',
+    old_price NUMERIC(10, 2) NOT NULL,
+    new_price NUMERIC(10, 2) NOT NULL,
+    changed_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_renewal_price_history_domain_changed ON renewal_price_history(domain_id, changed_at DESC);
@@ -0,0 +1,15 @@
+-- Saved Views Migration
+-- Named domain list filters, with their sort, that each user saves and re-applies with
+-- GET /api/v1/domains?view=<id>. Views are private to the user who saved them.
+
+CREATE TABLE IF NOT EXISTS saved_views (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    filter JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (user_id, name)
+);
+
+CREATE INDEX IF NOT EXISTS idx_saved_views_user ON saved_views(user_id, name);
@@ -0,0 +1,9 @@
+-- Settings Migration
+-- Runtime-adjustable application settings, stored as one JSON value per key
+-- (e.g. expiry_thresholds). Services fall back to their defaults for missing keys.
+
+CREATE TABLE IF NOT EXISTS settings (
+    key VARCHAR(100) PRIMARY KEY,
+    value JSONB NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,22 @@
+-- Watchlist Migration
+-- Domains held by someone else that we want to register when they drop. A scheduled job
+-- checks each entry's availability and alerts once when it becomes available within
+-- max_price; notified_at is cleared when the domain is registered again.
+
+CREATE TABLE IF NOT EXISTS watchlist (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    domain VARCHAR(255) NOT NULL UNIQUE,
+    max_price DECIMAL(10,2),
+    currency VARCHAR(3) NOT NULL DEFAULT 'USD',
+    notes TEXT NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    last_price DECIMAL(10,2),
+    last_source VARCHAR(50) NOT NULL DEFAULT '',
+    last_error TEXT NOT NULL DEFAULT '',
+    last_checked_at TIMESTAMPTZ,
+    notified_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_watchlist_last_checked_at ON watchlist(last_checked_at);
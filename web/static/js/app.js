@@ -114,28 +114,38 @@ class DomainVaultApp {
         }
     }
 
+    // The API needs the token the admin console stores after signing in
+    apiFetch(path, options = {}) {
+        const headers = { ...(options.headers || {}) };
+        const token = localStorage.getItem('authToken');
+        if (token) {
+            headers['Authorization'] = `Bearer ${token}`;
+        }
+        return fetch(`${this.apiBase}${path}`, { ...options, headers });
+    }
+
     async loadDomains() {
-        const response = await fetch(`${this.apiBase}/domains`);
+        const response = await this.apiFetch('/domains');
         if (!response.ok) throw new Error('Failed to load domains');
         const data = await response.json();
         this.domains = data.domains || [];
     }
 
     async loadSummary() {
-        const response = await fetch(`${this.apiBase}/domains/summary`);
+        const response = await this.apiFetch('/domains/summary');
         if (!response.ok) throw new Error('Failed to load summary');
         this.summary = await response.json();
     }
 
     async loadSyncStatus() {
-        const response = await fetch(`${this.apiBase}/sync/status`);
+        const response = await this.apiFetch('/sync/status');
         if (!response.ok) throw new Error('Failed to load sync status');
         this.syncStatus = await response.json();
     }
 
     async loadExpiringDomains(days = 30) {
         try {
-            const response = await fetch(`${this.apiBase}/domains/expiring?days=${days}`);
+            const response = await this.apiFetch(`/domains/expiring?days=${days}`);
             if (!response.ok) throw new Error('Failed to load expiring domains');
             const data = await response.json();
             this.renderExpiringDomainsList(data.domains || []);
@@ -147,7 +157,7 @@ class DomainVaultApp {
     async triggerSync() {
         try {
             this.showSyncProgress();
-            const response = await fetch(`${this.apiBase}/sync`, { method: 'POST' });
+            const response = await this.apiFetch('/sync', { method: 'POST' });
             
             if (!response.ok) throw new Error('Failed to trigger sync');
             
@@ -0,0 +1,16 @@
+-- Webhook Endpoints Migration
+-- Outbound webhook receivers. Each endpoint has a current signing secret and, during a
+-- rotation, the previous secret, which receivers keep accepting until it expires.
+-- secrets is encrypted like provider_credentials.credentials when a master key is set.
+
+CREATE TABLE IF NOT EXISTS webhook_endpoints (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    url TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    enabled BOOLEAN NOT NULL DEFAULT true,
+    secrets JSONB NOT NULL,
+    secret_rotated_at TIMESTAMPTZ,
+    previous_secret_expires_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
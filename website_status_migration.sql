@@ -0,0 +1,9 @@
+-- Website Status Migration
+-- Persists the SSL, redirect and response time results of website status checks
+-- so the dashboard shows the last known website state without re-checking
+
+ALTER TABLE domains ADD COLUMN IF NOT EXISTS ssl_status VARCHAR(20);
+ALTER TABLE domains ADD COLUMN IF NOT EXISTS redirect_url TEXT;
+ALTER TABLE domains ADD COLUMN IF NOT EXISTS last_response_time INTEGER;
+
+CREATE INDEX IF NOT EXISTS idx_domains_ssl_status ON domains(ssl_status);